@@ -0,0 +1,69 @@
+package completion
+
+import (
+	"github.com/urfave/cli"
+
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Generate shell completion scripts for the Rocket Pool CLI",
+		Subcommands: []cli.Command{
+
+			{
+				Name:      "bash",
+				Usage:     "Print the bash completion script",
+				UsageText: "rocketpool completion bash\n\n   To enable it, add `source <(rocketpool completion bash)` to your ~/.bashrc.",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return printBashCompletion(c)
+
+				},
+			},
+
+			{
+				Name:      "zsh",
+				Usage:     "Print the zsh completion script",
+				UsageText: "rocketpool completion zsh\n\n   To enable it, add `source <(rocketpool completion zsh)` to your ~/.zshrc.",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return printZshCompletion(c)
+
+				},
+			},
+
+			{
+				Name:      "fish",
+				Usage:     "Print the fish completion script",
+				UsageText: "rocketpool completion fish\n\n   To enable it, run `rocketpool completion fish > ~/.config/fish/completions/rocketpool.fish`.",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return printFishCompletion(c)
+
+				},
+			},
+		},
+	})
+}
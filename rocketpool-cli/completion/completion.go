@@ -0,0 +1,80 @@
+package completion
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+)
+
+// Bash completion script; the CLI generates the candidates itself via --generate-bash-completion
+const bashCompletion string = `_rocketpool_bash_autocomplete() {
+  if [[ "${COMP_WORDS[0]}" != "source" ]]; then
+    local cur opts
+    COMPREPLY=()
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    if [[ "$cur" == "-"* ]]; then
+      opts=$( ${COMP_WORDS[@]:0:$COMP_CWORD} ${cur} --generate-bash-completion 2>/dev/null )
+    else
+      opts=$( ${COMP_WORDS[@]:0:$COMP_CWORD} --generate-bash-completion 2>/dev/null )
+    fi
+    COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
+    return 0
+  fi
+}
+
+complete -o bashdefault -o default -o nospace -F _rocketpool_bash_autocomplete {{PROG}}
+`
+
+// Zsh completion script; the CLI generates the candidates itself via --generate-bash-completion
+const zshCompletion string = `#compdef {{PROG}}
+
+_rocketpool_zsh_autocomplete() {
+  local -a opts
+  local cur
+  cur=${words[-1]}
+  if [[ "$cur" == "-"* ]]; then
+    opts=("${(@f)$(_CLI_ZSH_AUTOCOMPLETE_HACK=1 ${words[@]:0:#words[@]-1} ${cur} --generate-bash-completion 2>/dev/null)}")
+  else
+    opts=("${(@f)$(_CLI_ZSH_AUTOCOMPLETE_HACK=1 ${words[@]:0:#words[@]-1} --generate-bash-completion 2>/dev/null)}")
+  fi
+
+  if [[ "${opts[1]}" != "" ]]; then
+    _describe 'values' opts
+  else
+    _files
+  fi
+}
+
+compdef _rocketpool_zsh_autocomplete {{PROG}}
+`
+
+// Print the bash completion script
+func printBashCompletion(c *cli.Context) error {
+	fmt.Print(strings.ReplaceAll(bashCompletion, "{{PROG}}", getRootApp(c).Name))
+	return nil
+}
+
+// Print the zsh completion script
+func printZshCompletion(c *cli.Context) error {
+	fmt.Print(strings.ReplaceAll(zshCompletion, "{{PROG}}", getRootApp(c).Name))
+	return nil
+}
+
+// Print the fish completion script, generated from the full command tree
+func printFishCompletion(c *cli.Context) error {
+	script, err := getRootApp(c).ToFishCompletion()
+	if err != nil {
+		return fmt.Errorf("error generating fish completion script: %w", err)
+	}
+	fmt.Print(script)
+	return nil
+}
+
+// Get the top-level app, since subcommands run with their own app named after the command path
+func getRootApp(c *cli.Context) *cli.App {
+	for c.Parent() != nil {
+		c = c.Parent()
+	}
+	return c.App
+}
@@ -1,6 +1,9 @@
 package minipool
 
 import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
 
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -24,6 +27,19 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "include-finalized, f",
 						Usage: "Include finalized minipools in the list (default is to hide them).",
 					},
+					cli.BoolFlag{
+						Name:  "verify, v",
+						Usage: "Cross-check each minipool's status against its validator on the Beacon Chain and report any inconsistencies.",
+					},
+					cli.Uint64Flag{
+						Name:  "page",
+						Usage: "The page of minipools to show, when paginating with --page-size.",
+						Value: 1,
+					},
+					cli.Uint64Flag{
+						Name:  "page-size",
+						Usage: "The number of minipools to show per page (default is to show them all).",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -72,23 +88,31 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			{
 				Name:      "set-withdrawal-creds",
 				Aliases:   []string{"swc"},
-				Usage:     "Convert the withdrawal credentials for a migrated solo validator from the old 0x00 value to the minipool address. Required to complete the migration process.",
-				UsageText: "rocketpool minipool set-withdrawal-creds minipool-address [options]",
+				Usage:     "Convert the withdrawal credentials for a migrated solo validator from the old 0x00 value to the minipool address. Required to complete the migration process. If no minipool is given, this finds the node's validators that still have 0x00 credentials and guides you through changing them.",
+				UsageText: "rocketpool minipool set-withdrawal-creds [minipool-address] [options]",
 				Flags: []cli.Flag{
 					cli.StringFlag{
 						Name:  "mnemonic, m",
 						Usage: "Use this flag to provide the mnemonic for your validator key instead of typing it interactively.",
 					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm all interactive questions",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
 					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
-						return err
-					}
-					address, err := cliutils.ValidateAddress("minipool-address", c.Args().Get(0))
-					if err != nil {
-						return err
+					var address *common.Address
+					if c.NArg() > 0 {
+						if err := cliutils.ValidateArgCount(c, 1); err != nil {
+							return err
+						}
+						minipoolAddress, err := cliutils.ValidateAddress("minipool-address", c.Args().Get(0))
+						if err != nil {
+							return err
+						}
+						address = &minipoolAddress
 					}
 
 					// Run
@@ -290,6 +314,52 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "housekeeping",
+				Aliases:   []string{"hk"},
+				Usage:     "Find the refunds and balances across your minipools and fee distributor that are worth claiming at current gas prices, and claim them in one go.",
+				UsageText: "rocketpool minipool housekeeping [options]",
+				Flags: []cli.Flag{
+					cli.Float64Flag{
+						Name:  "min-profit, p",
+						Usage: "The least ETH an action must be worth after gas to be included",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the plan",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return runHousekeeping(c)
+
+				},
+			},
+
+			{
+				Name:      "sweep-forecast",
+				Aliases:   []string{"sf"},
+				Usage:     "Forecast when the Beacon Chain's withdrawal sweep will next reach each of your minipools' validators, and how much ETH it will send to them.",
+				UsageText: "rocketpool minipool sweep-forecast",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getSweepForecast(c)
+
+				},
+			},
+
 			/*
 			   REMOVED UNTIL BEACON WITHDRAWALS
 			   cli.Command{
@@ -393,6 +463,23 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "delegate-status",
+				Usage:     "Show which minipools are using an outdated delegate contract",
+				UsageText: "rocketpool minipool delegate-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getDelegateStatus(c)
+
+				},
+			},
+
 			{
 				Name:      "delegate-upgrade",
 				Aliases:   []string{"u"},
@@ -407,6 +494,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "include-finalized, f",
 						Usage: "Include finailized minipools in the list (default is to hide them).",
 					},
+					cli.Float64Flag{
+						Name:  "max-fee-cap",
+						Usage: "Abort the upgrades if the selected max fee is higher than this value (in gwei)",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -421,6 +512,9 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 							return err
 						}
 					}
+					if c.Float64("max-fee-cap") < 0 {
+						return fmt.Errorf("Invalid max fee cap: %f", c.Float64("max-fee-cap"))
+					}
 
 					// Run
 					return delegateUpgradeMinipools(c)
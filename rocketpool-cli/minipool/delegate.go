@@ -108,6 +108,13 @@ func delegateUpgradeMinipools(c *cli.Context) error {
 		return err
 	}
 
+	// Enforce the max fee cap if one was provided
+	maxFeeCap := c.Float64("max-fee-cap")
+	if maxFeeCap > 0 && g.MaxFeeGwei() > maxFeeCap {
+		fmt.Printf("The selected max fee of %.2f gwei is higher than the cap of %.2f gwei; aborting upgrades.\n", g.MaxFeeGwei(), maxFeeCap)
+		return nil
+	}
+
 	// Prompt for confirmation
 	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to upgrade %d minipools?", len(selectedMinipools)))) {
 		fmt.Println("Cancelled.")
@@ -370,3 +377,54 @@ func setUseLatestDelegateMinipools(c *cli.Context, setting bool) error {
 	return nil
 
 }
+
+func getDelegateStatus(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the delegate status
+	status, err := rp.MinipoolDelegateStatus()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("The latest minipool delegate is %s (v%d).\n", status.LatestDelegate.Hex(), status.LatestVersion)
+	if status.CriticalDelegatesErr != "" {
+		fmt.Printf("%sWARNING: Couldn't get the list of critical delegates: %s%s\n", colorYellow, status.CriticalDelegatesErr, colorReset)
+	}
+	fmt.Println()
+
+	// Print the outdated minipools
+	outdatedCount := 0
+	criticalCount := 0
+	for _, mp := range status.Minipools {
+		if !mp.Outdated || mp.Finalised {
+			continue
+		}
+		outdatedCount++
+		if mp.Critical {
+			criticalCount++
+			fmt.Printf("%s%s: v%d -> v%d (CRITICAL: %s)%s\n", colorRed, mp.Address.Hex(), mp.DelegateVersion, status.LatestVersion, mp.CriticalReason, colorReset)
+		} else {
+			fmt.Printf("%s: v%d -> v%d\n", mp.Address.Hex(), mp.DelegateVersion, status.LatestVersion)
+		}
+	}
+
+	if outdatedCount == 0 {
+		fmt.Println("All of your active minipools are using the latest delegate.")
+		return nil
+	}
+	fmt.Println()
+	fmt.Printf("%d minipool(s) are using an outdated delegate.\n", outdatedCount)
+	if criticalCount > 0 {
+		fmt.Printf("%s%d of them are using a delegate that has been flagged as critical; you should upgrade them as soon as possible.%s\n", colorRed, criticalCount, colorReset)
+	}
+	fmt.Println("You can upgrade them with `rocketpool minipool delegate-upgrade`.")
+	return nil
+
+}
@@ -3,6 +3,8 @@ package minipool
 import (
 	"bytes"
 	"fmt"
+	"os"
+	"text/tabwriter"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/types"
@@ -92,6 +94,9 @@ func exitMinipools(c *cli.Context) error {
 	fmt.Println("Your funds will be locked on the Beacon Chain until they've been withdrawn, which will happen automatically (this may take a few days).")
 	fmt.Printf("Once your funds have been withdrawn, you can run `rocketpool minipool close` to distribute them to your withdrawal address and close the minipool.\n\n%s", colorReset)
 
+	// Show when the exits would be processed; the projection is informational, so don't block the exit if it fails
+	printExitProjection(rp, selectedMinipools)
+
 	// Prompt for confirmation
 	if !(c.Bool("yes") || cliutils.ConfirmWithIAgree(fmt.Sprintf("Are you sure you want to exit %d minipool(s)? This action cannot be undone!", len(selectedMinipools)))) {
 		fmt.Println("Cancelled.")
@@ -112,3 +117,37 @@ func exitMinipools(c *cli.Context) error {
 	return nil
 
 }
+
+// Print when the selected minipools' validators would exit and be fully withdrawn, based on the Beacon Chain's exit queue
+func printExitProjection(rp *rocketpool.Client, minipools []api.MinipoolDetails) {
+	addresses := make([]common.Address, len(minipools))
+	for i, minipool := range minipools {
+		addresses[i] = minipool.Address
+	}
+	fmt.Println("Checking the Beacon Chain's exit queue (this may take a minute)...")
+	response, err := rp.GetMinipoolExitProjection(addresses)
+	if err != nil {
+		fmt.Printf("Couldn't project when your minipools will exit: %s\n\n", err.Error())
+		return
+	}
+
+	queue := response.Queue
+	fmt.Printf("There are %d validators in the exit queue, and %d can exit per epoch.\n", queue.Length, queue.ChurnLimit)
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "Minipool\tValidator\tExit\tWithdrawable\tFull withdrawal (est.)")
+	for _, minipool := range response.Minipools {
+		exit := fmt.Sprintf("%s (epoch %d)", minipool.ExitTime.Format(TimeFormat), minipool.ExitEpoch)
+		if minipool.Initiated {
+			exit += " - already exiting"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			minipool.Address.Hex(),
+			minipool.ValidatorIndex,
+			exit,
+			minipool.WithdrawableTime.Format(TimeFormat),
+			minipool.WithdrawalTime.Format(TimeFormat),
+		)
+	}
+	writer.Flush()
+	fmt.Println()
+}
@@ -0,0 +1,145 @@
+package minipool
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	rocketpoolapi "github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func runHousekeeping(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the plan
+	plan, err := rp.GetHousekeepingPlan()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Current gas price: %.2f gwei\n\n", eth.WeiToGwei(plan.GasPrice))
+
+	// Drop the actions that aren't worth enough after gas
+	minProfit := eth.EthToWei(c.Float64("min-profit"))
+	actions := []api.HousekeepingAction{}
+	for _, action := range plan.Actions {
+		if action.NetValue.Cmp(minProfit) > 0 {
+			actions = append(actions, action)
+		}
+	}
+
+	if len(plan.Unprofitable) > 0 {
+		fmt.Printf("%sThe following would cost more in gas than they're worth right now, so they've been left out:\n", colorYellow)
+		for _, action := range plan.Unprofitable {
+			fmt.Printf("\t%s\n", formatHousekeepingAction(action))
+		}
+		fmt.Printf("%s\n", colorReset)
+	}
+	if len(actions) == 0 {
+		fmt.Println("There's nothing worth claiming at current gas prices.")
+		return nil
+	}
+
+	// Print the plan, most valuable first
+	totalValue := big.NewInt(0)
+	totalCost := big.NewInt(0)
+	fmt.Println("Housekeeping plan:")
+	for i, action := range actions {
+		fmt.Printf("%d. %s\n", i+1, formatHousekeepingAction(action))
+		totalValue.Add(totalValue, action.Value)
+		totalCost.Add(totalCost, action.GasCost)
+	}
+	fmt.Printf("\nIn total, this claims %.6f ETH for about %.6f ETH of gas.\n\n", math.RoundDown(eth.WeiToEth(totalValue), 6), math.RoundUp(eth.WeiToEth(totalCost), 6))
+
+	// Get the total gas limit estimate
+	var totalGas uint64 = 0
+	var totalSafeGas uint64 = 0
+	var gasInfo rocketpoolapi.GasInfo
+	for _, action := range actions {
+		gasInfo = action.GasInfo
+		totalGas += gasInfo.EstGasLimit
+		totalSafeGas += gasInfo.SafeGasLimit
+	}
+	gasInfo.EstGasLimit = totalGas
+	gasInfo.SafeGasLimit = totalSafeGas
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(gasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to run these %d transactions?", len(actions)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Run the plan
+	failures := 0
+	for _, action := range actions {
+		var hash common.Hash
+		switch action.Kind {
+		case api.HousekeepingAction_Refund:
+			var response api.RefundMinipoolResponse
+			response, err = rp.RefundMinipool(action.Address)
+			hash = response.TxHash
+		case api.HousekeepingAction_DistributeBalance:
+			var response api.DistributeBalanceResponse
+			response, err = rp.DistributeBalance(action.Address)
+			hash = response.TxHash
+		case api.HousekeepingAction_DistributeFees:
+			var response api.NodeDistributeResponse
+			response, err = rp.Distribute()
+			hash = response.TxHash
+		default:
+			err = fmt.Errorf("unknown action [%s]", action.Kind)
+		}
+		if err == nil {
+			fmt.Printf("%s...\n", formatHousekeepingAction(action))
+			cliutils.PrintTransactionHash(rp, hash)
+			_, err = rp.WaitForTransaction(hash)
+		}
+		if err != nil {
+			fmt.Printf("%sCould not %s: %s.%s\n", colorRed, formatHousekeepingAction(action), err.Error(), colorReset)
+			failures++
+		}
+	}
+
+	if failures > 0 {
+		fmt.Printf("%d of %d transactions failed.\n", failures, len(actions))
+	} else {
+		fmt.Println("Housekeeping complete.")
+	}
+	return nil
+
+}
+
+// Describe a housekeeping action
+func formatHousekeepingAction(action api.HousekeepingAction) string {
+	value := math.RoundDown(eth.WeiToEth(action.Value), 6)
+	cost := math.RoundUp(eth.WeiToEth(action.GasCost), 6)
+	switch action.Kind {
+	case api.HousekeepingAction_Refund:
+		return fmt.Sprintf("Refund %.6f ETH from minipool %s (gas: ~%.6f ETH)", value, action.Address.Hex(), cost)
+	case api.HousekeepingAction_DistributeBalance:
+		return fmt.Sprintf("Distribute minipool %s for %.6f ETH (gas: ~%.6f ETH)", action.Address.Hex(), value, cost)
+	case api.HousekeepingAction_DistributeFees:
+		return fmt.Sprintf("Distribute the fee distributor for %.6f ETH (gas: ~%.6f ETH)", value, cost)
+	default:
+		return fmt.Sprintf("%s %s", action.Kind, action.Address.Hex())
+	}
+}
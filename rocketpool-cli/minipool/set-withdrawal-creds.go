@@ -6,11 +6,12 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/wallet"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
-	"github.com/rocket-pool/smartnode/shared/utils/cli/migration"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/urfave/cli"
 )
 
-func setWithdrawalCreds(c *cli.Context, minipoolAddress common.Address) error {
+func setWithdrawalCreds(c *cli.Context, minipoolAddress *common.Address) error {
 
 	// Get RP client
 	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
@@ -19,7 +20,55 @@ func setWithdrawalCreds(c *cli.Context, minipoolAddress common.Address) error {
 	}
 	defer rp.Close()
 
-	fmt.Printf("This will convert the withdrawal credentials for minipool %s's validator from the old 0x00 (BLS) value to the minipool address. This is meant for solo validator conversion **only**.\n\n", minipoolAddress.Hex())
+	// Find the validators that still have BLS credentials
+	blsCredentialed, err := rp.GetBlsCredentialedMinipools()
+	if err != nil {
+		return err
+	}
+	if len(blsCredentialed.Minipools) == 0 {
+		fmt.Println("None of your minipools' validators have the old 0x00 (BLS) withdrawal credentials, so there's nothing to change.")
+		return nil
+	}
+	fmt.Printf("%d of your minipools' validators still have the old 0x00 (BLS) withdrawal credentials:\n", len(blsCredentialed.Minipools))
+	for _, minipool := range blsCredentialed.Minipools {
+		vacancy := ""
+		if minipool.IsVacant {
+			vacancy = ", vacant"
+		}
+		fmt.Printf("\t%s (validator %s, %s%s)\n", minipool.Address.Hex(), minipool.ValidatorIndex, minipool.BeaconState, vacancy)
+	}
+	fmt.Println()
+
+	// Get selected minipools
+	var selectedMinipools []api.BlsCredentialedMinipool
+	if minipoolAddress != nil {
+		for _, minipool := range blsCredentialed.Minipools {
+			if minipool.Address == *minipoolAddress {
+				selectedMinipools = []api.BlsCredentialedMinipool{minipool}
+				break
+			}
+		}
+		if selectedMinipools == nil {
+			return fmt.Errorf("The validator for minipool %s doesn't have 0x00 (BLS) withdrawal credentials.", minipoolAddress.Hex())
+		}
+	} else if len(blsCredentialed.Minipools) == 1 {
+		selectedMinipools = blsCredentialed.Minipools
+	} else {
+		options := make([]string, len(blsCredentialed.Minipools)+1)
+		options[0] = "All of them"
+		for mi, minipool := range blsCredentialed.Minipools {
+			options[mi+1] = fmt.Sprintf("%s (validator %s)", minipool.Address.Hex(), minipool.ValidatorIndex)
+		}
+		selected, _ := cliutils.Select("Please select a minipool to change the withdrawal credentials for:", options)
+		if selected == 0 {
+			selectedMinipools = blsCredentialed.Minipools
+		} else {
+			selectedMinipools = []api.BlsCredentialedMinipool{blsCredentialed.Minipools[selected-1]}
+		}
+	}
+
+	fmt.Println("This will convert the withdrawal credentials for each selected minipool's validator from the old 0x00 (BLS) value to the minipool address. This is meant for solo validator conversion **only**.")
+	fmt.Println()
 
 	// Get the mnemonic
 	mnemonic := ""
@@ -29,9 +78,40 @@ func setWithdrawalCreds(c *cli.Context, minipoolAddress common.Address) error {
 		mnemonic = wallet.PromptMnemonic()
 	}
 
-	success := migration.ChangeWithdrawalCreds(rp, minipoolAddress, mnemonic)
-	if !success {
-		fmt.Println("Your withdrawal credentials cannot be automatically changed at this time. Import aborted.\nYou can try again later by using `rocketpool minipool set-withdrawal-creds`.")
+	// Change the credentials for each minipool
+	for _, minipool := range selectedMinipools {
+
+		// Check the change, including that the minipool is the validator's rightful target
+		canChange, err := rp.CanChangeWithdrawalCredentials(minipool.Address, mnemonic)
+		if err != nil {
+			fmt.Printf("%sCannot change the withdrawal credentials for minipool %s: %s%s\n\n", colorRed, minipool.Address.Hex(), err.Error(), colorReset)
+			continue
+		}
+		if !canChange.CanChange {
+			fmt.Printf("%sThe withdrawal credentials for minipool %s cannot be changed at this time.%s\n\n", colorRed, minipool.Address.Hex(), colorReset)
+			continue
+		}
+		fmt.Printf("Validator %s (minipool %s):\n", canChange.ValidatorIndex, minipool.Address.Hex())
+		fmt.Printf("\tCurrent withdrawal credentials: %s\n", canChange.CurrentCredentials.Hex())
+		fmt.Printf("\tNew withdrawal credentials:     %s\n", canChange.NewCredentials.Hex())
+		fmt.Println("The new credentials point to the minipool's address, which Rocket Pool has registered for this validator.")
+
+		// Prompt for confirmation
+		if !(c.Bool("yes") || cliutils.ConfirmWithIAgree("Withdrawal credentials can only be changed once. Are you sure you want to broadcast this change?")) {
+			fmt.Println("Skipped.")
+			fmt.Println()
+			continue
+		}
+
+		// Broadcast the change
+		if _, err := rp.ChangeWithdrawalCredentials(minipool.Address, mnemonic); err != nil {
+			fmt.Printf("%sCould not change the withdrawal credentials for minipool %s: %s%s\n\n", colorRed, minipool.Address.Hex(), err.Error(), colorReset)
+			continue
+		}
+		fmt.Printf("Successfully broadcast the withdrawal credentials change for minipool %s.\n", minipool.Address.Hex())
+		fmt.Println("It will be processed by the Beacon Chain's withdrawal credential change queue, which may take a few days if it's busy.")
+		fmt.Println()
+
 	}
 
 	return nil
@@ -30,7 +30,7 @@ func getStatus(c *cli.Context) error {
 	defer rp.Close()
 
 	// Get minipool statuses
-	status, err := rp.MinipoolStatus()
+	status, err := rp.MinipoolBulkStatus(c.Bool("include-finalized"), c.Uint64("page"), c.Uint64("page-size"))
 	if err != nil {
 		return err
 	}
@@ -64,17 +64,30 @@ func getStatus(c *cli.Context) error {
 	}
 
 	// Return if there aren't any minipools
-	if len(status.Minipools) == 0 {
+	if status.TotalCount == 0 && status.HiddenCount == 0 {
 		fmt.Println("The node does not have any minipools yet.")
 		return nil
 	}
 
 	// Return if all minipools are finalized and they are hidden
-	if len(status.Minipools) == len(finalisedMinipools) && !c.Bool("include-finalized") {
+	if status.TotalCount == 0 {
 		fmt.Println("All of this node's minipools have been finalized.\nTo show finalized minipools, re-run this command with the `-f` flag.")
 		return nil
 	}
 
+	// Return if the page is past the end
+	if len(status.Minipools) == 0 {
+		fmt.Printf("Page %d is empty; the node has %d minipool(s).\n", status.Page, status.TotalCount)
+		return nil
+	}
+
+	// Print which page is being shown
+	if status.PageSize > 0 {
+		first := (status.Page-1)*status.PageSize + 1
+		pageCount := (uint64(status.TotalCount) + status.PageSize - 1) / status.PageSize
+		fmt.Printf("Showing minipools %d-%d of %d (page %d of %d)\n\n", first, first+uint64(len(status.Minipools))-1, status.TotalCount, status.Page, pageCount)
+	}
+
 	// Print minipool details by status
 	for _, statusName := range types.MinipoolStatuses {
 		minipools, ok := statusMinipools[statusName]
@@ -108,7 +121,7 @@ func getStatus(c *cli.Context) error {
 			printMinipoolDetails(minipool, status.LatestDelegate)
 		}
 	} else {
-		fmt.Printf("%d finalized minipool(s) (hidden)\n", len(finalisedMinipools))
+		fmt.Printf("%d finalized minipool(s) (hidden)\n", status.HiddenCount)
 		fmt.Println("")
 	}
 
@@ -130,11 +143,59 @@ func getStatus(c *cli.Context) error {
 		fmt.Println("")
 	}
 
+	// Cross-check the minipools against the Beacon Chain
+	if c.Bool("verify") {
+		if err := printStatusVerification(rp); err != nil {
+			return err
+		}
+	}
+
 	// Return
 	return nil
 
 }
 
+// Print any inconsistencies between the EL and CL views of the node's minipools
+func printStatusVerification(rp *rocketpool.Client) error {
+
+	response, err := rp.VerifyMinipoolStatus()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("=== Beacon Chain Verification ===")
+	issueCount := 0
+	for _, minipool := range response.Minipools {
+		if len(minipool.Issues) == 0 {
+			continue
+		}
+		clStatus := "not found"
+		if minipool.ClExists {
+			clStatus = string(minipool.ClStatus)
+		}
+		fmt.Printf("%s (EL: %s, CL: %s)\n", minipool.Address.Hex(), minipool.ElStatus.String(), clStatus)
+		for _, issue := range minipool.Issues {
+			issueCount++
+			color := colorYellow
+			if issue.Critical {
+				color = colorRed
+			}
+			fmt.Printf("%s- %s%s\n", color, issue.Description, colorReset)
+			fmt.Printf("  Recommended action: %s\n", issue.Action)
+		}
+		fmt.Println("")
+	}
+
+	if issueCount == 0 {
+		fmt.Printf("All %d minipool(s) are consistent with their validators on the Beacon Chain.\n", len(response.Minipools))
+	} else {
+		fmt.Printf("Found %d issue(s) between the execution layer and the Beacon Chain.\n", issueCount)
+	}
+	fmt.Println("")
+	return nil
+
+}
+
 func printMinipoolDetails(minipool api.MinipoolDetails, latestDelegate common.Address) {
 
 	fmt.Printf("--------------------\n")
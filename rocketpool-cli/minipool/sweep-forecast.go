@@ -0,0 +1,58 @@
+package minipool
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func getSweepForecast(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the forecast
+	response, err := rp.GetMinipoolSweepForecast()
+	if err != nil {
+		return err
+	}
+
+	// Print the sweep's progress
+	fmt.Printf("The withdrawal sweep is at validator %d of %d, passing about %.1f validators per slot.\n", response.Sweep.NextIndex, response.Sweep.ValidatorCount, response.Sweep.ValidatorsPerSlot)
+	fmt.Printf("A full pass takes about %s.\n\n", response.CycleTime.Round(time.Hour))
+	if len(response.Minipools) == 0 {
+		fmt.Println("None of your minipools have validators on the Beacon Chain yet.")
+		return nil
+	}
+
+	// Print the forecast for each minipool
+	total := 0.0
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "Minipool\tValidator\tNext Sweep\tExpected ETH\t")
+	for _, minipool := range response.Minipools {
+		amount := math.RoundDown(eth.WeiToEth(minipool.ProjectedAmount), 6)
+		total += amount
+		note := ""
+		if minipool.FullWithdrawal {
+			note = " (full withdrawal)"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s (in %s)\t%.6f%s\t\n", minipool.Address.Hex(), minipool.ValidatorIndex, minipool.NextSweepTime.Format(TimeFormat), time.Until(minipool.NextSweepTime).Round(time.Minute), amount, note)
+	}
+	writer.Flush()
+	fmt.Println()
+	fmt.Printf("Your minipools are expected to receive a total of %.6f ETH at their next sweeps.\n", total)
+	fmt.Println("These are estimates: the sweep's speed changes as validators join and leave the Beacon Chain, and rewards vary between validators.")
+	return nil
+
+}
@@ -0,0 +1,60 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func approveRewardsTree(c *cli.Context, interval uint64) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the consistency gate report
+	canResponse, err := rp.CanApproveRewardsTree(interval)
+	if err != nil {
+		return err
+	}
+	if !canResponse.ReportExists {
+		fmt.Printf("The watchtower hasn't run the consistency gate on a rewards tree for interval %d.\n", interval)
+		return nil
+	}
+	report := canResponse.Report
+	if report.Passed {
+		fmt.Printf("%sThe rewards tree for interval %d passed the consistency gate, so it doesn't need to be approved.%s\n", colorGreen, interval, colorReset)
+		return nil
+	}
+	if canResponse.Approved {
+		fmt.Printf("The rewards tree for interval %d (root %s) has already been approved.\n", interval, report.MerkleRoot)
+		return nil
+	}
+
+	// Show what failed
+	fmt.Printf("The rewards tree for interval %d (root %s) failed the consistency gate at %s:\n", interval, report.MerkleRoot, report.GeneratedAt.Format("2006-01-02 15:04:05 MST"))
+	for _, check := range report.GetFailedChecks() {
+		fmt.Printf("%s%s:%s\n", colorRed, check.Name, colorReset)
+		for _, problem := range check.Problems {
+			fmt.Printf("\t%s\n", problem)
+		}
+	}
+	fmt.Println()
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("%sAre you sure you want the watchtower to submit this tree anyway?%s", colorYellow, colorReset))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Approve it
+	if _, err := rp.ApproveRewardsTree(interval); err != nil {
+		return err
+	}
+	fmt.Printf("Approved the rewards tree for interval %d. The watchtower will submit it the next time it checks for rewards trees.\n", interval)
+	return nil
+
+}
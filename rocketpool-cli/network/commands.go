@@ -100,6 +100,10 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "index",
 						Usage: "The index of the rewards interval you want to generate the tree for",
 					},
+					cli.Uint64Flag{
+						Name:  "memory-budget",
+						Usage: "The memory (in MB) available for generation, overriding the Tree Generation Memory Budget setting. Budgets below 32768 switch to slower algorithms that keep fewer attestation duties in memory; the budget itself isn't enforced.",
+					},
 					cli.BoolFlag{
 						Name:  "yes, y",
 						Usage: "Automatically confirm any questions about tree generation",
@@ -135,6 +139,173 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "totals-history",
+				Aliases:   []string{"th"},
+				Usage:     "Show the trend of the network totals recorded by your node",
+				UsageText: "rocketpool network totals-history [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "days, d",
+						Usage: "The number of days of history to show",
+						Value: defaultTotalsHistoryDays,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getNetworkTotalsHistory(c)
+
+				},
+			},
+
+			{
+				Name:      "smoothing-pool-stats",
+				Aliases:   []string{"sp"},
+				Usage:     "Show the network's smoothing pool membership over time and your node's expected share of the pool",
+				UsageText: "rocketpool network smoothing-pool-stats [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "days, d",
+						Usage: "The number of days of membership history to show",
+						Value: defaultSmoothingPoolStatsDays,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getSmoothingPoolStats(c)
+
+				},
+			},
+
+			{
+				Name:      "intervals",
+				Aliases:   []string{"i"},
+				Usage:     "Show every rewards interval's on-chain submission and whether your local rewards tree file matches it",
+				UsageText: "rocketpool network intervals [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "download, d",
+						Usage: "Download the rewards tree files that are missing or don't match their on-chain submission",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getIntervals(c)
+
+				},
+			},
+
+			{
+				Name:      "approve-rewards-tree",
+				Usage:     "Approve submitting the watchtower's rewards tree for an interval despite it failing the consistency gate",
+				UsageText: "rocketpool network approve-rewards-tree interval",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the approval",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					interval, err := cliutils.ValidateUint("interval", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return approveRewardsTree(c, interval)
+
+				},
+			},
+
+			{
+				Name:      "verify-attestation",
+				Aliases:   []string{"va"},
+				Usage:     "Verify the Oracle DAO signer quorum's attestation over an interval's rewards artifact manifest",
+				UsageText: "rocketpool network verify-attestation interval",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					interval, err := cliutils.ValidateUint("interval", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return verifyAttestation(c, interval)
+
+				},
+			},
+
+			{
+				Name:      "verify-rewards-tree",
+				Aliases:   []string{"vt"},
+				Usage:     "Regenerate an interval's rewards tree from its snapshot and compare it with the tree the Oracle DAO published, reporting any nodes whose rewards differ",
+				UsageText: "rocketpool network verify-rewards-tree interval",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					interval, err := cliutils.ValidateUint("interval", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return verifyRewardsTree(c, interval)
+
+				},
+			},
+
+			{
+				Name:      "export-rewards-csv",
+				Aliases:   []string{"ec"},
+				Usage:     "Export an interval's rewards file and minipool performance file as CSV files, with one row per node reward and one per minipool",
+				UsageText: "rocketpool network export-rewards-csv interval",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					interval, err := cliutils.ValidateUint("interval", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return exportRewardsCSV(c, interval)
+
+				},
+			},
 		},
 	})
 }
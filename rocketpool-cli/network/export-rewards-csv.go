@@ -0,0 +1,34 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func exportRewardsCSV(c *cli.Context, interval uint64) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Export the files
+	response, err := rp.ExportRewardsCSV(interval)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported the rewards for %d nodes in interval %d to:\n\t%s\n", response.NodeCount, response.Index, response.RewardsPath)
+	if response.MinipoolPerformanceMissing {
+		fmt.Printf("%sThe minipool performance file for interval %d is not on this machine, so it wasn't exported. Generate it with `rocketpool network generate-rewards-tree` to export it too.%s\n", colorYellow, response.Index, colorReset)
+		return nil
+	}
+	fmt.Printf("Exported the performance of %d minipools in interval %d to:\n\t%s\n", response.MinipoolCount, response.Index, response.MinipoolPerformancePath)
+	return nil
+
+}
@@ -11,6 +11,7 @@ import (
 
 const (
 	colorReset  string = "\033[0m"
+	colorRed    string = "\033[31m"
 	colorGreen  string = "\033[32m"
 	colorYellow string = "\033[33m"
 
@@ -72,7 +73,7 @@ func generateRewardsTree(c *cli.Context) error {
 	}
 
 	// Create the generation request
-	_, err = rp.GenerateRewardsTree(index)
+	_, err = rp.GenerateRewardsTree(index, c.Uint64("memory-budget"))
 	if err != nil {
 		return err
 	}
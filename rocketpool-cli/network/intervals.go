@@ -0,0 +1,72 @@
+package network
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func getIntervals(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the intervals
+	response, err := rp.NetworkIntervals(c.Bool("download"))
+	if err != nil {
+		return err
+	}
+	if len(response.Intervals) == 0 {
+		fmt.Println("No rewards intervals have been submitted yet.")
+		return nil
+	}
+
+	// Print the table
+	inconsistent := 0
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "Interval\tSubmitted\tBlock\tSubmitter\tMerkle Root\tCID\tLocal File\t")
+	for _, interval := range response.Intervals {
+		consistency := string(interval.Consistency)
+		if interval.Downloaded {
+			consistency += " (downloaded)"
+		}
+		if interval.Consistency != rewards.IntervalConsistency_Consistent {
+			inconsistent++
+		}
+		fmt.Fprintf(writer, "%d\t%s\t%d\t%s\t%s\t%s\t%s\t\n",
+			interval.Index,
+			interval.SubmissionTime.Local().Format("2006-01-02 15:04 MST"),
+			interval.BlockNumber,
+			interval.Submitter.Hex(),
+			interval.MerkleRoot.Hex(),
+			interval.CID,
+			consistency)
+	}
+	writer.Flush()
+	fmt.Println()
+
+	// Print the problems
+	for _, interval := range response.Intervals {
+		if interval.Detail != "" {
+			fmt.Printf("Interval %d: %s\n", interval.Index, interval.Detail)
+		}
+	}
+	if inconsistent == 0 {
+		fmt.Println("Every interval's rewards tree file matches its on-chain submission.")
+	} else if !c.Bool("download") {
+		fmt.Printf("%d interval(s) are missing their rewards tree file or don't match their on-chain submission. Re-run this command with the `--download` flag to download them.\n", inconsistent)
+	} else {
+		fmt.Printf("%d interval(s) still don't match their on-chain submission.\n", inconsistent)
+	}
+	return nil
+
+}
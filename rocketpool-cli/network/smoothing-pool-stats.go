@@ -0,0 +1,107 @@
+package network
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// The default number of days of smoothing pool membership history to show
+const defaultSmoothingPoolStatsDays uint64 = 30
+
+func getSmoothingPoolStats(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the stats
+	days := c.Uint64("days")
+	if days == 0 {
+		days = defaultSmoothingPoolStatsDays
+	}
+	response, err := rp.SmoothingPoolStats(days)
+	if err != nil {
+		return err
+	}
+
+	// Print the current membership
+	membership := response.Membership
+	fmt.Println("=== Smoothing Pool Membership ===")
+	fmt.Printf("%d of %d nodes (%.2f%%) are opted into the smoothing pool.\n", membership.OptedInNodes, membership.TotalNodes, percent(membership.OptedInNodes, membership.TotalNodes))
+	fmt.Printf("%d of %d active minipools (%.2f%%) are in the smoothing pool.\n", membership.OptedInMinipools, membership.ActiveMinipools, percent(membership.OptedInMinipools, membership.ActiveMinipools))
+	fmt.Printf("The smoothing pool currently holds %.6f ETH.\n\n", eth.WeiToEth(response.SmoothingPoolBalance))
+
+	// Print the registration changes
+	fmt.Printf("=== Registration Changes (last %d days) ===\n", days)
+	if len(membership.Changes) == 0 {
+		fmt.Println("No nodes have changed their smoothing pool registration in this period.")
+	} else {
+		optIns, optOuts := uint64(0), uint64(0)
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "Day\tOpt-ins\tOpt-outs\t")
+		for _, changes := range membership.Changes {
+			fmt.Fprintf(writer, "%s\t%d\t%d\t\n", changes.Day.Format("2006-01-02"), changes.OptIns, changes.OptOuts)
+			optIns += changes.OptIns
+			optOuts += changes.OptOuts
+		}
+		writer.Flush()
+		fmt.Printf("Total: %d opt-ins and %d opt-outs.\n", optIns, optOuts)
+	}
+	fmt.Println("Only each node's most recent change is known, so nodes that changed more than once are counted once.")
+	fmt.Println()
+
+	// Print the share of validators in the pool over time
+	fmt.Println("=== Share of Minipools in the Pool ===")
+	if len(response.History) == 0 {
+		if !response.HistoryEnabled {
+			fmt.Println("Network totals recording is disabled, so there's no membership history. You can enable it in the Smartnode section of the `rocketpool service config` TUI.")
+		} else {
+			fmt.Printf("No smoothing pool membership has been recorded in the last %d days yet. Please check again later.\n", days)
+		}
+	} else {
+		values := make([]float64, len(response.History))
+		for i, sample := range response.History {
+			values[i] = percent(sample.SmoothingPoolMinipools, sample.ActiveMinipools)
+		}
+		first, last := response.History[0], response.History[len(response.History)-1]
+		fmt.Printf("%s  %.2f%% -> %.2f%% (%s to %s)\n",
+			sparkline(downsample(values, totalsHistoryWidth)), values[0], values[len(values)-1],
+			first.Time.Local().Format("2006-01-02"), last.Time.Local().Format("2006-01-02"))
+	}
+	fmt.Println()
+
+	// Put the node's expected share in context
+	if !response.IsNodeRegistered {
+		return nil
+	}
+	share := response.NodeShare
+	fmt.Println("=== Your Node ===")
+	if share.ActiveMinipools == 0 {
+		fmt.Println("Your node doesn't have any active minipools, so it wouldn't earn anything from the smoothing pool.")
+		return nil
+	}
+	if share.OptedIn {
+		fmt.Printf("Your node is opted in. Its %d active minipool(s) make up %.4f%% of the pool's minipools.\n", share.ActiveMinipools, share.MinipoolShare*100)
+		fmt.Printf("With equal performance, your node earns %.4f%% of the pool's ETH, which would be %.6f ETH of its current balance.\n", share.EthShare*100, eth.WeiToEth(share.ExpectedEth))
+	} else {
+		fmt.Printf("Your node is not opted in. If it were, its %d active minipool(s) would make up %.4f%% of the pool's minipools.\n", share.ActiveMinipools, share.MinipoolShare*100)
+		fmt.Printf("With equal performance, your node would earn %.4f%% of the pool's ETH, which would be %.6f ETH of its current balance.\n", share.EthShare*100, eth.WeiToEth(share.ExpectedEth))
+	}
+	fmt.Println("In the long run, this matches what your minipools would earn from proposals on their own; the smoothing pool trades the luck of individual proposals for a steady share of everyone's.")
+	return nil
+
+}
+
+// Get a count as a percentage of a total
+func percent(count uint64, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) / float64(total) * 100
+}
@@ -0,0 +1,139 @@
+package network
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"text/tabwriter"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+const (
+	// The default number of days of network totals to show
+	defaultTotalsHistoryDays uint64 = 7
+
+	// The number of samples to show in the table and trend lines
+	totalsHistoryRows  int = 12
+	totalsHistoryWidth int = 48
+)
+
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+func getNetworkTotalsHistory(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the history
+	days := c.Uint64("days")
+	if days == 0 {
+		days = defaultTotalsHistoryDays
+	}
+	response, err := rp.NetworkTotalsHistory(days)
+	if err != nil {
+		return err
+	}
+	if len(response.Samples) == 0 {
+		if !response.Enabled {
+			fmt.Println("Network totals recording is disabled. You can enable it in the Smartnode section of the `rocketpool service config` TUI.")
+		} else {
+			fmt.Printf("No network totals have been recorded in the last %d days yet. Please check again later.\n", days)
+		}
+		return nil
+	}
+	samples := response.Samples
+	first, last := samples[0], samples[len(samples)-1]
+	fmt.Printf("%d samples from epoch %d (%s) to epoch %d (%s).\n\n", len(samples),
+		first.Epoch, first.Time.Local().Format("2006-01-02 15:04 MST"),
+		last.Epoch, last.Time.Local().Format("2006-01-02 15:04 MST"))
+
+	// Print a trend line for each value
+	trends := []struct {
+		name   string
+		format string
+		value  func(state.NetworkTotalsSample) float64
+	}{
+		{"Total node weight", "%.2f", func(s state.NetworkTotalsSample) float64 { return eth.WeiToEth(s.TotalNodeWeight) }},
+		{"Smoothing pool ETH", "%.6f", func(s state.NetworkTotalsSample) float64 { return eth.WeiToEth(s.SmoothingPoolBalance) }},
+		{"rETH exchange rate", "%.6f", func(s state.NetworkTotalsSample) float64 { return s.RethExchangeRate }},
+		{"Active minipools", "%.0f", func(s state.NetworkTotalsSample) float64 { return float64(s.ActiveMinipools) }},
+	}
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, trend := range trends {
+		values := make([]float64, len(samples))
+		for i, sample := range samples {
+			values[i] = trend.value(sample)
+		}
+		fmt.Fprintf(writer, "%s\t%s\t"+trend.format+"\t\n", trend.name, sparkline(downsample(values, totalsHistoryWidth)), values[len(values)-1])
+	}
+	writer.Flush()
+	fmt.Println()
+
+	// Print a table of evenly spaced samples
+	writer = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "Epoch\tTime\tNode Weight\tSmoothing Pool ETH\trETH Rate\tActive Minipools\t")
+	for _, i := range spacedIndices(len(samples), totalsHistoryRows) {
+		sample := samples[i]
+		fmt.Fprintf(writer, "%d\t%s\t%.2f\t%.6f\t%.6f\t%d\t\n",
+			sample.Epoch,
+			sample.Time.Local().Format("2006-01-02 15:04"),
+			eth.WeiToEth(sample.TotalNodeWeight),
+			eth.WeiToEth(sample.SmoothingPoolBalance),
+			sample.RethExchangeRate,
+			sample.ActiveMinipools,
+		)
+	}
+	writer.Flush()
+	return nil
+
+}
+
+// Get up to count indices spread evenly across a slice of the given length, always including the first and last
+func spacedIndices(length int, count int) []int {
+	if length <= count {
+		indices := make([]int, length)
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+	indices := make([]int, count)
+	for i := range indices {
+		indices[i] = i * (length - 1) / (count - 1)
+	}
+	return indices
+}
+
+// Reduce a series to at most width points
+func downsample(values []float64, width int) []float64 {
+	indices := spacedIndices(len(values), width)
+	points := make([]float64, len(indices))
+	for i, index := range indices {
+		points[i] = values[index]
+	}
+	return points
+}
+
+// Render a series as a line of block characters scaled between its minimum and maximum
+func sparkline(values []float64) string {
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, value := range values {
+		low = math.Min(low, value)
+		high = math.Max(high, value)
+	}
+	line := make([]rune, len(values))
+	for i, value := range values {
+		level := 0
+		if high > low {
+			level = int((value - low) / (high - low) * float64(len(sparkLevels)-1))
+		}
+		line[i] = sparkLevels[level]
+	}
+	return string(line)
+}
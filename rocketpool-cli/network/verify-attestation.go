@@ -0,0 +1,40 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func verifyAttestation(c *cli.Context, interval uint64) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Verify the attestation
+	response, err := rp.VerifyAttestation(interval)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Interval %d artifact manifest: %s\n", interval, response.ManifestHash.Hex())
+	if !response.SignersPinned {
+		fmt.Println("NOTE: no signer quorum is configured, so the attestation is checked against the signers it lists. Set the Attestation Signers and Attestation Threshold in the `rocketpool service config` TUI to pin them.")
+	}
+	fmt.Printf("Valid signatures from %d of %d signers (%d required):\n", len(response.ValidSigners), len(response.Signers), response.Threshold)
+	for _, signer := range response.ValidSigners {
+		fmt.Printf("\t%s\n", signer.Hex())
+	}
+	fmt.Println()
+
+	if !response.Valid {
+		fmt.Printf("%sThe attestation is NOT valid: %s%s\n", colorRed, response.Problem, colorReset)
+		return nil
+	}
+	fmt.Printf("%sThe attestation is valid.%s\n", colorGreen, colorReset)
+	return nil
+
+}
@@ -0,0 +1,73 @@
+package network
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// The number of differences to print
+const maxPrintedDivergences int = 25
+
+func verifyRewardsTree(c *cli.Context, interval uint64) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Verify the tree
+	fmt.Printf("Regenerating the rewards tree for interval %d. This can take a long time, and may need an archive EC for older intervals...\n", interval)
+	response, err := rp.VerifyRewardsTree(interval)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Interval %d was submitted by %s in transaction %s.\n", response.Index, response.Submitter.Hex(), response.TxHash.Hex())
+	fmt.Printf("Snapshot: Beacon block %d, execution block %d (ruleset v%d)\n", response.ConsensusBlock, response.ExecutionBlock, response.RulesetVersion)
+	fmt.Printf("On-chain root: %s\n", response.OnChainRoot.Hex())
+	fmt.Printf("Local root:    %s\n\n", response.LocalRoot.Hex())
+
+	if response.Matches {
+		fmt.Printf("%sThe regenerated tree matches the Oracle DAO's submission.%s\n", colorGreen, colorReset)
+		return nil
+	}
+	fmt.Printf("%sThe regenerated tree does NOT match the Oracle DAO's submission.%s\n", colorRed, colorReset)
+
+	// Print the differences
+	report := response.Report
+	if report == nil || len(report.Divergences) == 0 {
+		fmt.Println("The trees don't differ in any of the values that can be compared, so the difference is in how the tree was built.")
+		return nil
+	}
+	if report.FirstDivergentNode != nil {
+		fmt.Printf("The trees first diverge at node %s (%s).\n", report.FirstDivergentNode.Hex(), report.FirstDivergentCategory)
+	} else {
+		fmt.Printf("The trees first diverge in their %s.\n", report.FirstDivergentCategory)
+	}
+	fmt.Printf("Found %d difference(s):\n\n", len(report.Divergences))
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "Category\tField\tNode\tLocal\tPublished\t")
+	for i, divergence := range report.Divergences {
+		if i == maxPrintedDivergences {
+			break
+		}
+		node := ""
+		if divergence.Node != nil {
+			node = divergence.Node.Hex()
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t\n", divergence.Category, divergence.Field, node, divergence.Local, divergence.Consensus)
+	}
+	writer.Flush()
+	if len(report.Divergences) > maxPrintedDivergences {
+		fmt.Printf("\n...and %d more.\n", len(report.Divergences)-maxPrintedDivergences)
+	}
+	return nil
+
+}
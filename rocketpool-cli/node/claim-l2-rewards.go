@@ -0,0 +1,117 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/urfave/cli"
+
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func nodeClaimL2Rewards(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+	formatter := getFormatter(rp)
+
+	// Get eligible intervals
+	rewardsInfoResponse, err := rp.GetRewardsInfo()
+	if err != nil {
+		return fmt.Errorf("error getting rewards info: %w", err)
+	}
+	if !rewardsInfoResponse.Registered {
+		fmt.Printf("This node is not currently registered.\n")
+		return nil
+	}
+	if len(rewardsInfoResponse.InvalidIntervals) > 0 {
+		fmt.Printf("%sYou are missing valid rewards tree files for some intervals. Run `rocketpool node claim-rewards` to download them first.%s\n\n", colorYellow, colorReset)
+	}
+	if len(rewardsInfoResponse.L2UnclaimedIntervals) == 0 {
+		fmt.Println("Your node does not have any unclaimed rewards on Layer 2 networks.")
+		return nil
+	}
+
+	// Group the intervals by the network they were routed to
+	intervalsByNetwork := map[uint64][]rprewards.IntervalInfo{}
+	networks := []uint64{}
+	for _, intervalInfo := range rewardsInfoResponse.L2UnclaimedIntervals {
+		if _, exists := intervalsByNetwork[intervalInfo.RewardNetwork]; !exists {
+			networks = append(networks, intervalInfo.RewardNetwork)
+		}
+		intervalsByNetwork[intervalInfo.RewardNetwork] = append(intervalsByNetwork[intervalInfo.RewardNetwork], intervalInfo)
+	}
+	sort.Slice(networks, func(i, j int) bool { return networks[i] < networks[j] })
+
+	// Only claim on the requested network if there is one
+	if c.IsSet("network") {
+		network := c.Uint64("network")
+		if _, exists := intervalsByNetwork[network]; !exists {
+			fmt.Printf("Your node does not have any unclaimed rewards on %s.\n", getRewardNetworkName(rewardsInfoResponse, network))
+			return nil
+		}
+		networks = []uint64{network}
+	}
+
+	// Claim on each network separately, since each one needs its own transaction
+	for _, network := range networks {
+		intervals := intervalsByNetwork[network]
+		networkName := getRewardNetworkName(rewardsInfoResponse, network)
+		printIntervalRewards(formatter, rewardsInfoResponse, intervals)
+
+		claimRpl := big.NewInt(0)
+		claimEth := big.NewInt(0)
+		indices := []uint64{}
+		for _, intervalInfo := range intervals {
+			claimRpl.Add(claimRpl, &intervalInfo.CollateralRplAmount.Int)
+			claimRpl.Add(claimRpl, &intervalInfo.ODaoRplAmount.Int)
+			claimEth.Add(claimEth, &intervalInfo.SmoothingPoolEthAmount.Int)
+			indices = append(indices, intervalInfo.Index)
+		}
+		fmt.Printf("Claiming intervals %v on %s will pay out %s and %s on that network.\n\n", indices, networkName, formatter.Rpl(claimRpl), formatter.Eth(claimEth))
+
+		// Check claim ability
+		canClaim, err := rp.CanNodeClaimL2Rewards(network, indices)
+		if err != nil {
+			return err
+		}
+		if !canClaim.CanClaim {
+			if len(canClaim.UnrelayedIntervals) > 0 {
+				fmt.Printf("The rewards for intervals %v haven't been relayed to %s yet. Please try again once they have arrived.\n", canClaim.UnrelayedIntervals, canClaim.NetworkName)
+			}
+			if len(canClaim.ClaimedIntervals) > 0 {
+				fmt.Printf("The rewards for intervals %v have already been claimed on %s.\n", canClaim.ClaimedIntervals, canClaim.NetworkName)
+			}
+			fmt.Println()
+			continue
+		}
+		fmt.Printf("This claim is sent to %s (chain %d), which sets its own fees. It will use up to %d gas on that network.\n", canClaim.NetworkName, canClaim.ChainID, canClaim.GasInfo.SafeGasLimit)
+
+		// Prompt for confirmation
+		if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to claim your rewards on %s?", canClaim.NetworkName))) {
+			fmt.Println("Cancelled.")
+			fmt.Println()
+			continue
+		}
+
+		// Claim rewards
+		fmt.Printf("Claiming rewards on %s...\n", canClaim.NetworkName)
+		response, err := rp.NodeClaimL2Rewards(network, indices)
+		if err != nil {
+			return err
+		}
+		if !response.Succeeded {
+			return fmt.Errorf("the claim transaction %s on %s reverted", response.TxHash.Hex(), response.NetworkName)
+		}
+		fmt.Printf("Successfully claimed rewards on %s in transaction %s.\n\n", response.NetworkName, response.TxHash.Hex())
+	}
+
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/format"
 )
 
 const (
@@ -29,6 +30,7 @@ func nodeClaimRewards(c *cli.Context) error {
 		return err
 	}
 	defer rp.Close()
+	formatter := getFormatter(rp)
 
 	// Provide a notice
 	fmt.Printf("%sWelcome to the new rewards system!\nYou no longer need to claim rewards at each interval - you can simply let them accumulate and claim them whenever you want.\nHere you can see which intervals you haven't claimed yet, and how many rewards you earned during each one.%s\n\n", colorBlue, colorReset)
@@ -100,30 +102,34 @@ func nodeClaimRewards(c *cli.Context) error {
 		}
 	}
 
+	// Rewards routed to Layer 2 networks are claimed there instead
+	if len(rewardsInfoResponse.L2UnclaimedIntervals) > 0 {
+		printIntervalRewards(formatter, rewardsInfoResponse, rewardsInfoResponse.L2UnclaimedIntervals)
+		fmt.Printf("%sThe rewards above were routed to Layer 2 networks and can't be claimed here; use `rocketpool node claim-l2-rewards` to claim them.%s\n\n", colorBlue, colorReset)
+	}
+
 	if len(rewardsInfoResponse.UnclaimedIntervals) == 0 {
-		fmt.Println("Your node does not have any unclaimed rewards yet.")
+		if len(rewardsInfoResponse.L2UnclaimedIntervals) > 0 {
+			fmt.Println("Your node does not have any unclaimed rewards on Ethereum.")
+		} else {
+			fmt.Println("Your node does not have any unclaimed rewards yet.")
+		}
 		return nil
 	}
 
 	// Print the info for all available periods
 	totalRpl := big.NewInt(0)
 	totalEth := big.NewInt(0)
+	printIntervalRewards(formatter, rewardsInfoResponse, rewardsInfoResponse.UnclaimedIntervals)
 	for _, intervalInfo := range rewardsInfoResponse.UnclaimedIntervals {
-		fmt.Printf("Rewards for Interval %d (%s to %s):\n", intervalInfo.Index, intervalInfo.StartTime.Local(), intervalInfo.EndTime.Local())
-		fmt.Printf("\tStaking:        %.6f RPL\n", eth.WeiToEth(&intervalInfo.CollateralRplAmount.Int))
-		if intervalInfo.ODaoRplAmount.Cmp(big.NewInt(0)) == 1 {
-			fmt.Printf("\tOracle DAO:     %.6f RPL\n", eth.WeiToEth(&intervalInfo.ODaoRplAmount.Int))
-		}
-		fmt.Printf("\tSmoothing Pool: %.6f ETH\n\n", eth.WeiToEth(&intervalInfo.SmoothingPoolEthAmount.Int))
-
 		totalRpl.Add(totalRpl, &intervalInfo.CollateralRplAmount.Int)
 		totalRpl.Add(totalRpl, &intervalInfo.ODaoRplAmount.Int)
 		totalEth.Add(totalEth, &intervalInfo.SmoothingPoolEthAmount.Int)
 	}
 
 	fmt.Println("Total Pending Rewards:")
-	fmt.Printf("\t%.6f RPL\n", eth.WeiToEth(totalRpl))
-	fmt.Printf("\t%.6f ETH\n\n", eth.WeiToEth(totalEth))
+	fmt.Printf("\t%s\n", formatter.Rpl(totalRpl))
+	fmt.Printf("\t%s\n\n", formatter.Eth(totalEth))
 
 	// Get the list of intervals to claim
 	var indices []uint64
@@ -193,14 +199,22 @@ func nodeClaimRewards(c *cli.Context) error {
 			}
 		}
 	}
-	fmt.Printf("With this selection, you will claim %.6f RPL and %.6f ETH.\n\n", eth.WeiToEth(claimRpl), eth.WeiToEth(claimEth))
+	fmt.Printf("With this selection, you will claim %s and %s.\n\n", formatter.Rpl(claimRpl), formatter.Eth(claimEth))
 
 	// Get restake amount
-	restakeAmountWei, err := getRestakeAmount(c, rewardsInfoResponse, claimRpl)
+	restakeAmountWei, err := getRestakeAmount(c, formatter, rewardsInfoResponse, claimRpl)
 	if err != nil {
 		return err
 	}
 
+	// Show where the rewards will be sent and check the routing
+	if printClaimRouting(formatter, rewardsInfoResponse.ClaimRouting, claimRpl, claimEth, restakeAmountWei) {
+		if !(c.Bool("yes") || cliutils.Confirm("Please review the warnings above. Do you still control the recipient addresses and want to continue?")) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
 	// Check claim ability
 	if restakeAmountWei == nil {
 		canClaim, err := rp.CanNodeClaimRewards(indices)
@@ -259,8 +273,85 @@ func nodeClaimRewards(c *cli.Context) error {
 	return nil
 }
 
+// Print the rewards for each interval and the network they were routed to
+func printIntervalRewards(formatter *format.Formatter, rewardsInfoResponse api.NodeGetRewardsInfoResponse, intervals []rprewards.IntervalInfo) {
+	for _, intervalInfo := range intervals {
+		fmt.Printf("Rewards for Interval %d (%s to %s):\n", intervalInfo.Index, intervalInfo.StartTime.Local(), intervalInfo.EndTime.Local())
+		fmt.Printf("\tNetwork:        %s\n", getRewardNetworkName(rewardsInfoResponse, intervalInfo.RewardNetwork))
+		fmt.Printf("\tStaking:        %s\n", formatter.Rpl(&intervalInfo.CollateralRplAmount.Int))
+		if intervalInfo.ODaoRplAmount.Cmp(big.NewInt(0)) == 1 {
+			fmt.Printf("\tOracle DAO:     %s\n", formatter.Rpl(&intervalInfo.ODaoRplAmount.Int))
+		}
+		fmt.Printf("\tSmoothing Pool: %s\n\n", formatter.Eth(&intervalInfo.SmoothingPoolEthAmount.Int))
+	}
+}
+
+// Get the display name of the network an interval's rewards were routed to
+func getRewardNetworkName(rewardsInfoResponse api.NodeGetRewardsInfoResponse, network uint64) string {
+	if name, exists := rewardsInfoResponse.RewardNetworkNames[network]; exists {
+		return name
+	}
+	if network == 0 {
+		return "Ethereum"
+	}
+	return fmt.Sprintf("Network %d", network)
+}
+
+// Print the addresses that claimed rewards will be sent to, returning true if there's anything the user should review
+func printClaimRouting(formatter *format.Formatter, routing api.RewardsClaimRouting, claimRpl *big.Int, claimEth *big.Int, restakeAmountWei *big.Int) bool {
+	blankAddress := common.Address{}
+	hasWarnings := false
+
+	// Work out how much RPL actually leaves the node
+	sentRpl := big.NewInt(0).Set(claimRpl)
+	if restakeAmountWei != nil {
+		sentRpl.Sub(sentRpl, restakeAmountWei)
+	}
+
+	fmt.Println("Rewards will be sent to:")
+	fmt.Printf("\tETH: %s (primary withdrawal address)\n", routing.EthRecipient.Hex())
+	if routing.IsRplWithdrawalAddressSet {
+		fmt.Printf("\tRPL: %s (RPL withdrawal address)\n", routing.RplRecipient.Hex())
+	} else {
+		fmt.Printf("\tRPL: %s (primary withdrawal address)\n", routing.RplRecipient.Hex())
+	}
+	if restakeAmountWei != nil && restakeAmountWei.Sign() > 0 {
+		fmt.Printf("\t%s will be restaked on the node instead.\n", formatter.Rpl(restakeAmountWei))
+	}
+	fmt.Println()
+
+	// Pending changes don't take effect until they're confirmed, so the current address still receives the rewards
+	if claimEth.Sign() > 0 && routing.PendingEthRecipient != blankAddress {
+		fmt.Printf("%sWARNING: Your primary withdrawal address has a pending change to %s, but it has not been confirmed yet.\nThe ETH rewards will be sent to the current address %s.%s\n\n", colorYellow, routing.PendingEthRecipient.Hex(), routing.EthRecipient.Hex(), colorReset)
+		hasWarnings = true
+	}
+	if sentRpl.Sign() > 0 && routing.PendingRplRecipient != blankAddress {
+		fmt.Printf("%sWARNING: Your RPL withdrawal address has a pending change to %s, but it has not been confirmed yet.\nThe RPL rewards will be sent to the current address %s.%s\n\n", colorYellow, routing.PendingRplRecipient.Hex(), routing.RplRecipient.Hex(), colorReset)
+		hasWarnings = true
+	}
+
+	// Contracts may not be able to handle the rewards
+	if claimEth.Sign() > 0 && routing.EthRecipientIsContract {
+		fmt.Printf("%sWARNING: The ETH recipient %s is a contract. Please make sure it can receive and withdraw ETH.%s\n\n", colorYellow, routing.EthRecipient.Hex(), colorReset)
+		hasWarnings = true
+	}
+	if sentRpl.Sign() > 0 && routing.RplRecipientIsContract {
+		fmt.Printf("%sWARNING: The RPL recipient %s is a contract. Please make sure it can transfer RPL.%s\n\n", colorYellow, routing.RplRecipient.Hex(), colorReset)
+		hasWarnings = true
+	}
+
+	// Claims flagged by the compliance hook
+	for _, flag := range routing.ComplianceFlags {
+		if (flag.Asset == "ETH" && claimEth.Sign() > 0) || (flag.Asset == "RPL" && sentRpl.Sign() > 0) {
+			fmt.Printf("%sCOMPLIANCE FLAG: The %s claim was flagged for review: %s.%s\n\n", colorRed, flag.Asset, flag.Reason, colorReset)
+			hasWarnings = true
+		}
+	}
+	return hasWarnings
+}
+
 // Determine how much RPL to restake
-func getRestakeAmount(c *cli.Context, rewardsInfoResponse api.NodeGetRewardsInfoResponse, claimRpl *big.Int) (*big.Int, error) {
+func getRestakeAmount(c *cli.Context, formatter *format.Formatter, rewardsInfoResponse api.NodeGetRewardsInfoResponse, claimRpl *big.Int) (*big.Int, error) {
 
 	// Get the current collateral
 	currentBondedCollateral := float64(0)
@@ -278,7 +369,7 @@ func getRestakeAmount(c *cli.Context, rewardsInfoResponse api.NodeGetRewardsInfo
 		currentBorrowedCollateral = rewardsInfoResponse.BorrowedCollateralRatio
 		totalBondedCollateral = rplPrice * total / (float64(rewardsInfoResponse.ActiveMinipools)*32.0 - eth.WeiToEth(rewardsInfoResponse.EthMatched) - eth.WeiToEth(rewardsInfoResponse.PendingMatchAmount))
 		totalBorrowedCollateral = rplPrice * total / (eth.WeiToEth(rewardsInfoResponse.EthMatched) + eth.WeiToEth(rewardsInfoResponse.PendingMatchAmount))
-		fmt.Printf("You currently have %.6f RPL staked (%.2f%% borrowed collateral, %.2f%% bonded collateral).\n", currentRplStake, currentBorrowedCollateral*100, currentBondedCollateral*100)
+		fmt.Printf("You currently have %s staked (%.2f%% borrowed collateral, %.2f%% bonded collateral).\n", formatter.RplFloat(currentRplStake), currentBorrowedCollateral*100, currentBondedCollateral*100)
 	} else {
 		fmt.Println("You do not have any active minipools, so restaking RPL will not lead to any rewards.")
 	}
@@ -290,7 +381,7 @@ func getRestakeAmount(c *cli.Context, rewardsInfoResponse api.NodeGetRewardsInfo
 	if restakeAmountFlag == "all" {
 		// Restake everything with no regard for collateral level
 		total := availableRpl + currentRplStake
-		fmt.Printf("Automatically restaking all of the claimable RPL, which will bring you to a total of %.6f RPL staked (%.2f%% borrowed collateral, %.2f%% bonded collateral).\n", total, totalBorrowedCollateral*100, totalBondedCollateral*100)
+		fmt.Printf("Automatically restaking all of the claimable RPL, which will bring you to a total of %s staked (%.2f%% borrowed collateral, %.2f%% bonded collateral).\n", formatter.RplFloat(total), totalBorrowedCollateral*100, totalBondedCollateral*100)
 		restakeAmountWei = claimRpl
 	} else if restakeAmountFlag != "" {
 		// Restake a specific amount, capped at how much is available to claim
@@ -299,10 +390,10 @@ func getRestakeAmount(c *cli.Context, rewardsInfoResponse api.NodeGetRewardsInfo
 			return nil, fmt.Errorf("invalid restake amount '%s': %w", restakeAmountFlag, err)
 		}
 		if availableRpl < stakeAmount {
-			fmt.Printf("Limiting the automatic restake to all of the claimable RPL, which will bring you to a total of %.6f RPL staked (%.2f%% collateral).\n", total, totalBondedCollateral*100)
+			fmt.Printf("Limiting the automatic restake to all of the claimable RPL, which will bring you to a total of %s staked (%.2f%% collateral).\n", formatter.RplFloat(total), totalBondedCollateral*100)
 			restakeAmountWei = claimRpl
 		} else {
-			fmt.Printf("Automatically restaking %.6f RPL, which will bring you to a total of %.6f RPL staked (%.2f%% borrowed collateral, %.2f%% bonded collateral).\n", stakeAmount, total, totalBorrowedCollateral*100, totalBondedCollateral*100)
+			fmt.Printf("Automatically restaking %s, which will bring you to a total of %s staked (%.2f%% borrowed collateral, %.2f%% bonded collateral).\n", formatter.RplFloat(stakeAmount), formatter.RplFloat(total), totalBorrowedCollateral*100, totalBondedCollateral*100)
 			restakeAmountWei = eth.EthToWei(stakeAmount)
 		}
 	} else if c.Bool("yes") {
@@ -311,7 +402,7 @@ func getRestakeAmount(c *cli.Context, rewardsInfoResponse api.NodeGetRewardsInfo
 		restakeAmountWei = nil
 	} else {
 		// Prompt the user
-		collateralString := fmt.Sprintf("All %.6f RPL, which will bring you to %.2f%% borrowed collateral (%.2f%% bonded collateral)", availableRpl, totalBorrowedCollateral*100, totalBondedCollateral*100)
+		collateralString := fmt.Sprintf("All %s, which will bring you to %.2f%% borrowed collateral (%.2f%% bonded collateral)", formatter.RplFloat(availableRpl), totalBorrowedCollateral*100, totalBondedCollateral*100)
 		amountOptions := []string{
 			"None (do not restake any RPL)",
 			collateralString,
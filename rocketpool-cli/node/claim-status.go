@@ -0,0 +1,109 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Unclaimed rewards older than this are flagged so they don't get forgotten
+const staleUnclaimedRewardsAge time.Duration = 180 * 24 * time.Hour
+
+func getClaimStatus(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the claim status
+	fmt.Println("Scanning claim events, this may take a while the first time...")
+	response, err := rp.NodeClaimStatus()
+	if err != nil {
+		return err
+	}
+	if !response.Registered {
+		fmt.Println("This node is not currently registered.")
+		return nil
+	}
+	if len(response.Intervals) == 0 {
+		fmt.Println("Your node has not been part of any rewards intervals yet.")
+		return nil
+	}
+
+	// Print the table
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Interval\tEnded\tStatus\tRPL\tETH\tClaim Tx")
+	staleIntervals := []uint64{}
+	missingFiles := []uint64{}
+	mismatches := []uint64{}
+	for _, interval := range response.Intervals {
+		ended := interval.EndTime.Local().Format("2006-01-02")
+		rplAmount := "-"
+		ethAmount := "-"
+		if interval.ExpectedRpl != nil {
+			rplAmount = fmt.Sprintf("%.6f", eth.WeiToEth(interval.ExpectedRpl))
+			ethAmount = fmt.Sprintf("%.6f", eth.WeiToEth(interval.ExpectedEth))
+		} else if interval.ClaimEventFound {
+			rplAmount = fmt.Sprintf("%.6f", eth.WeiToEth(interval.ClaimedRpl))
+			ethAmount = fmt.Sprintf("%.6f", eth.WeiToEth(interval.ClaimedEth))
+		}
+
+		var status string
+		color := ""
+		tx := "-"
+		switch {
+		case interval.Claimed && interval.ClaimEventFound:
+			status = "claimed"
+			tx = interval.ClaimTxHash.Hex()
+		case interval.Claimed:
+			status = "claimed (no event found)"
+		case !interval.TreeFileExists || !interval.MerkleRootValid:
+			status = "unclaimed (missing file)"
+			color = colorYellow
+			missingFiles = append(missingFiles, interval.Index)
+		case time.Since(interval.EndTime) > staleUnclaimedRewardsAge:
+			status = "unclaimed (old)"
+			color = colorRed
+			staleIntervals = append(staleIntervals, interval.Index)
+		default:
+			status = "unclaimed"
+			color = colorGreen
+		}
+		if interval.AmountMismatch {
+			status = "claimed (amount mismatch)"
+			color = colorRed
+			mismatches = append(mismatches, interval.Index)
+		}
+		line := fmt.Sprintf("%d\t%s\t%s\t%s\t%s\t%s", interval.Index, ended, status, rplAmount, ethAmount, tx)
+		if color != "" {
+			line = color + line + colorReset
+		}
+		fmt.Fprintln(w, line)
+	}
+	w.Flush()
+	fmt.Println()
+
+	// Print the summary and warnings
+	fmt.Printf("Total unclaimed rewards: %.6f RPL and %.6f ETH.\n", eth.WeiToEth(response.UnclaimedRpl), eth.WeiToEth(response.UnclaimedEth))
+	if len(missingFiles) > 0 {
+		fmt.Printf("%sYou are missing valid rewards tree files for intervals %v, so their unclaimed amounts are not included above. Run `rocketpool node claim-rewards` to download them.%s\n", colorYellow, missingFiles, colorReset)
+	}
+	if len(staleIntervals) > 0 {
+		fmt.Printf("%sIntervals %v ended more than %d days ago and still haven't been claimed. Rewards don't expire, but consider claiming them with `rocketpool node claim-rewards`.%s\n", colorRed, staleIntervals, int(staleUnclaimedRewardsAge.Hours()/24), colorReset)
+	}
+	if len(mismatches) > 0 {
+		fmt.Printf("%sThe claimed amounts for intervals %v don't match your local rewards files. Your local files may be out of date or corrupt.%s\n", colorRed, mismatches, colorReset)
+	}
+	return nil
+
+}
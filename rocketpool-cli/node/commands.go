@@ -54,6 +54,99 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "leaderboard",
+				Usage:     "Show the top nodes on the network leaderboard and your node's rank",
+				UsageText: "rocketpool node leaderboard [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "count, n",
+						Usage: fmt.Sprintf("The number of top nodes to show (default %d)", defaultLeaderboardCount),
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getLeaderboard(c)
+
+				},
+			},
+
+			{
+				Name:      "verify-my-rewards",
+				Usage:     "Check your node's entry in the official rewards tree for an interval against your node's own chain data",
+				UsageText: "rocketpool node verify-my-rewards --interval value",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "interval, i",
+						Usage: "The rewards interval to verify",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					if !c.IsSet("interval") {
+						return fmt.Errorf("the --interval flag is required")
+					}
+
+					// Run
+					return verifyMyRewards(c, c.Uint64("interval"))
+
+				},
+			},
+
+			{
+				Name:      "onboard",
+				Aliases:   []string{"o"},
+				Usage:     "Set up a new node step by step: create or recover the wallet, register, stake RPL, set up the fee recipient, and optionally join the Smoothing Pool",
+				UsageText: "rocketpool node onboard [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "timezone, t",
+						Usage: "The timezone location to register the node with (in the format 'Country/City')",
+					},
+					cli.StringFlag{
+						Name:  "amount, a",
+						Usage: "The amount of RPL to stake (or 'all' for the node's entire RPL balance)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the summary of transactions and use the default gas prices",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("timezone") != "" {
+						if _, err := cliutils.ValidateTimezoneLocation("timezone location", c.String("timezone")); err != nil {
+							return err
+						}
+					}
+					if c.String("amount") != "" && c.String("amount") != "all" {
+						if _, err := cliutils.ValidatePositiveEthAmount("stake amount", c.String("amount")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return onboardNode(c)
+
+				},
+			},
+
 			{
 				Name:      "register",
 				Aliases:   []string{"r"},
@@ -117,6 +210,18 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "force",
 						Usage: "Force update the primary withdrawal address, bypassing the 'pending' state that requires a confirmation transaction from the new address",
 					},
+					cli.BoolFlag{
+						Name:  "pending, p",
+						Usage: "Put the new address into the 'pending' state and save the transaction that confirms it from the new address to a file (this is the default unless --force is used)",
+					},
+					cli.BoolFlag{
+						Name:  "allow-contract",
+						Usage: "Allow the new address to be a contract (by default, contract addresses are rejected since they may not be able to confirm the change or receive rewards)",
+					},
+					cli.StringFlag{
+						Name:  "artifact-path",
+						Usage: fmt.Sprintf("The file to save the confirmation transaction to (default '%s')", defaultConfirmationTxPath),
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -126,6 +231,11 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 					withdrawalAddress := c.Args().Get(0)
 
+					// Validate flags
+					if c.Bool("pending") && c.Bool("force") {
+						return fmt.Errorf("The --pending and --force flags cannot be used together.")
+					}
+
 					// Run
 					return setPrimaryWithdrawalAddress(c, withdrawalAddress)
 
@@ -156,6 +266,29 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "export-primary-withdrawal-address-confirmation",
+				Usage:     "Save the transaction that must be sent from the node's pending primary withdrawal address to confirm it",
+				UsageText: "rocketpool node export-primary-withdrawal-address-confirmation [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "artifact-path",
+						Usage: fmt.Sprintf("The file to save the confirmation transaction to (default '%s')", defaultConfirmationTxPath),
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return exportPrimaryWithdrawalAddressConfirmation(c)
+
+				},
+			},
+
 			{
 				Name:      "set-rpl-withdrawal-address",
 				Aliases:   []string{"srwa"},
@@ -283,6 +416,78 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "timezone-distribution",
+				Usage:     "Show how Rocket Pool nodes are distributed across timezones and regions",
+				UsageText: "rocketpool node timezone-distribution [options]",
+				Flags: []cli.Flag{
+					cli.IntFlag{
+						Name:  "count, n",
+						Usage: fmt.Sprintf("The number of top timezones to show (default %d)", defaultTimezoneCount),
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getTimezoneDistribution(c)
+
+				},
+			},
+
+			{
+				Name:      "contact-info",
+				Usage:     "Show the contact info published on a node's primary ENS name (defaults to this node)",
+				UsageText: "rocketpool node contact-info [address or ENS name]",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if c.NArg() > 1 {
+						return cliutils.ValidateArgCount(c, 1)
+					}
+					if c.NArg() == 1 {
+						if err := cliutils.ValidateAddressOrName("address", c.Args().Get(0)); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return getContactInfo(c)
+
+				},
+			},
+
+			{
+				Name:      "set-contact-info",
+				Usage:     fmt.Sprintf("Publish contact info on the node's primary ENS name; key must be one of: %s", strings.Join(cliutils.ContactInfoKeys, ", ")),
+				UsageText: "rocketpool node set-contact-info [options] key value",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm setting the record",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					key, err := cliutils.ValidateContactInfoKey("key", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return setContactInfo(c, key, c.Args().Get(1))
+
+				},
+			},
+
 			{
 				Name:      "swap-rpl",
 				Aliases:   []string{"p"},
@@ -432,6 +637,104 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "claim-l2-rewards",
+				Usage:     "Claim the rewards that were routed to Layer 2 networks on those networks",
+				UsageText: "rocketpool node claim-l2-rewards [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "network, n",
+						Usage: "Only claim the rewards routed to this reward network",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm rewards claims",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return nodeClaimL2Rewards(c)
+
+				},
+			},
+
+			{
+				Name:      "reward-network",
+				Usage:     "Check the network your node's rewards are routed to, and fix it if it's invalid",
+				UsageText: "rocketpool node reward-network [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "network, n",
+						Usage: "The reward network to switch to",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the change",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return nodeRewardNetwork(c)
+
+				},
+			},
+
+			{
+				Name:      "claim-status",
+				Usage:     "Show the claim history of every rewards interval and any rewards that are still unclaimed",
+				UsageText: "rocketpool node claim-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getClaimStatus(c)
+
+				},
+			},
+
+			{
+				Name:      "export-claim-bundle",
+				Usage:     "Save the amounts, Merkle proofs, and call data needed to claim rewards from a hardware or multisig wallet",
+				UsageText: "rocketpool node export-claim-bundle [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "intervals, i",
+						Usage: "A comma-separated list of the intervals to include (default all unclaimed intervals)",
+					},
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: fmt.Sprintf("The file to save the bundle to (default '%s')", defaultClaimBundlePath),
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return exportClaimBundle(c)
+
+				},
+			},
+
 			{
 				Name:      "withdraw-rpl",
 				Aliases:   []string{"i"},
@@ -622,7 +925,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 			{
 				Name:      "send",
 				Aliases:   []string{"n"},
-				Usage:     "Send ETH or tokens from the node account to an address. ENS names supported. <token> can be 'rpl', 'eth', 'fsrpl' (for the old RPL v1 token), 'reth', or the address of an arbitrary token you want to send (including the 0x prefix).",
+				Usage:     "Send ETH or tokens from the node account to an address. ENS names and address book labels supported. <token> can be 'rpl', 'eth', 'fsrpl' (for the old RPL v1 token), 'reth', or the address of an arbitrary token you want to send (including the 0x prefix).",
 				UsageText: "rocketpool node send [options] amount token to",
 				Flags: []cli.Flag{
 					cli.BoolFlag{
@@ -0,0 +1,118 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getContactInfo(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the address to look up, defaulting to the node's own
+	var address common.Address
+	if c.NArg() > 0 {
+		address, _, err = cliutils.ResolveAddress(rp, "address", c.Args().Get(0))
+		if err != nil {
+			return err
+		}
+	} else {
+		wallet, err := rp.WalletStatus()
+		if err != nil {
+			return err
+		}
+		if !wallet.WalletInitialized {
+			return fmt.Errorf("The node wallet is not initialized; please provide an address to look up.")
+		}
+		address = wallet.AccountAddress
+	}
+
+	// Get the contact info
+	response, err := rp.NodeContactInfo(address)
+	if err != nil {
+		return err
+	}
+	if response.EnsName == "" {
+		fmt.Printf("Node %s does not have a primary ENS name, so it has no contact info.\n", address.Hex())
+		return nil
+	}
+	fmt.Printf("Contact info for node %s (%s%s%s):\n", address.Hex(), colorGreen, response.EnsName, colorReset)
+	found := false
+	for _, key := range cliutils.ContactInfoKeys {
+		if value := response.Records[key]; value != "" {
+			fmt.Printf("  %-14s %s\n", key+":", value)
+			found = true
+		}
+	}
+	if !found {
+		fmt.Println("  (none published)")
+	}
+	return nil
+
+}
+
+func setContactInfo(c *cli.Context, key string, value string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check if the record can be set
+	canResponse, err := rp.CanSetNodeContactInfo(key, value)
+	if err != nil {
+		return err
+	}
+	if !canResponse.CanSet {
+		fmt.Println("Cannot set contact info:")
+		if canResponse.NoEnsName {
+			fmt.Println("The node does not have a primary ENS name. Please register one and set it as the reverse record for the node address first.")
+		}
+		if canResponse.NotAuthorized {
+			fmt.Printf("The node address is not authorized to update the records of %s.\n", canResponse.EnsName)
+		}
+		return nil
+	}
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canResponse.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to set the '%s' record of %s to '%s'?", key, canResponse.EnsName, value))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Set the record
+	response, err := rp.SetNodeContactInfo(key, value)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Setting contact info...\n")
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("The '%s' record of %s was successfully updated.\n", key, canResponse.EnsName)
+	return nil
+
+}
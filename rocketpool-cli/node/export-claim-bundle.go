@@ -0,0 +1,86 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// The default file to save the claim bundle to
+const defaultClaimBundlePath string = "claim-bundle.json"
+
+func exportClaimBundle(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the intervals to include, defaulting to all unclaimed ones
+	indices := []uint64{}
+	if c.String("intervals") != "" {
+		for _, element := range strings.Split(c.String("intervals"), ",") {
+			index, err := strconv.ParseUint(strings.TrimSpace(element), 10, 64)
+			if err != nil {
+				return fmt.Errorf("Invalid interval '%s': %w", element, err)
+			}
+			indices = append(indices, index)
+		}
+	} else {
+		rewardsInfoResponse, err := rp.GetRewardsInfo()
+		if err != nil {
+			return fmt.Errorf("error getting rewards info: %w", err)
+		}
+		if !rewardsInfoResponse.Registered {
+			fmt.Println("This node is not currently registered.")
+			return nil
+		}
+		for _, intervalInfo := range rewardsInfoResponse.UnclaimedIntervals {
+			indices = append(indices, intervalInfo.Index)
+		}
+		if len(rewardsInfoResponse.InvalidIntervals) > 0 {
+			fmt.Printf("%sNOTE: %d unclaimed intervals are missing valid rewards tree files and will not be included. Run `rocketpool node claim-rewards` to download them first.%s\n", colorYellow, len(rewardsInfoResponse.InvalidIntervals), colorReset)
+		}
+	}
+	if len(indices) == 0 {
+		fmt.Println("Your node does not have any unclaimed rewards to export.")
+		return nil
+	}
+
+	// Build the bundle
+	response, err := rp.NodeExportClaimBundle(indices)
+	if err != nil {
+		return err
+	}
+	bundle := response.Bundle
+
+	// Save it
+	path := c.String("output")
+	if path == "" {
+		path = defaultClaimBundlePath
+	}
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing claim bundle: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("error saving claim bundle to %s: %w", path, err)
+	}
+
+	// Print a summary
+	fmt.Printf("Saved a claim bundle for %d intervals to %s.\n", len(bundle.Intervals), path)
+	fmt.Printf("It will claim %.6f RPL and %.6f ETH.\n\n", eth.WeiToEth(&bundle.TotalRpl.Int), eth.WeiToEth(&bundle.TotalEth.Int))
+	fmt.Println(bundle.Instructions)
+	fmt.Printf("%sThe bundle only stays valid until any of its intervals are claimed, so don't claim them from the node in the meantime.%s\n", colorYellow, colorReset)
+	return nil
+
+}
@@ -0,0 +1,133 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// The default number of nodes to show on the leaderboard
+const defaultLeaderboardCount uint64 = 10
+
+func getLeaderboard(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the leaderboard
+	count := c.Uint64("count")
+	if count == 0 {
+		count = defaultLeaderboardCount
+	}
+	response, err := rp.NodeLeaderboard(count)
+	if err != nil {
+		return err
+	}
+	if response.Leaderboard == nil {
+		if !response.Enabled {
+			fmt.Println("The leaderboard is disabled. You can enable it in the Smartnode section of the `rocketpool service config` TUI.")
+		} else {
+			fmt.Println("The leaderboard hasn't been generated yet. Please check again later.")
+		}
+		return nil
+	}
+	leaderboard := response.Leaderboard
+
+	fmt.Printf("Leaderboard of %d nodes, generated at slot %d (%s).\n", response.TotalNodes, leaderboard.Slot, leaderboard.GeneratedTime.Local().Format("2006-01-02 15:04:05 MST"))
+	if leaderboard.HasInterval {
+		fmt.Printf("Effectiveness and smoothing pool shares are from interval %d.\n", leaderboard.Interval)
+	} else {
+		fmt.Println("No rewards files were available, so effectiveness and smoothing pool shares are not ranked.")
+	}
+	fmt.Println()
+
+	// Print the top nodes, highlighting this node
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "Rank\tNode\tWeight\tEffectiveness\tSmoothing Pool ETH\tShare\t")
+	nodeListed := false
+	for _, entry := range leaderboard.Nodes {
+		isNode := response.NodeEntry != nil && entry.Address == response.NodeEntry.Address
+		nodeListed = nodeListed || isNode
+		printLeaderboardEntry(writer, entry, response.EnsNames, isNode)
+	}
+	if response.NodeEntry != nil && !nodeListed {
+		fmt.Fprintln(writer, "...\t\t\t\t\t\t")
+		printLeaderboardEntry(writer, response.NodeEntry, response.EnsNames, true)
+	}
+	writer.Flush()
+
+	// Print this node's rank in each category
+	if response.NodeEntry != nil {
+		entry := response.NodeEntry
+		fmt.Println()
+		fmt.Printf("Your node is ranked %s of %d by weight", formatLeaderboardRank(entry.WeightRank), response.TotalNodes)
+		if entry.EffectivenessRank > 0 {
+			fmt.Printf(", %s by attestation effectiveness", formatLeaderboardRank(entry.EffectivenessRank))
+		}
+		if entry.SmoothingPoolRank > 0 {
+			fmt.Printf(", and %s by smoothing pool share", formatLeaderboardRank(entry.SmoothingPoolRank))
+		}
+		fmt.Println(".")
+	}
+
+	return nil
+
+}
+
+// Print a single row of the leaderboard
+func printLeaderboardEntry(writer *tabwriter.Writer, entry *rewards.LeaderboardEntry, ensNames map[common.Address]string, highlight bool) {
+	effectiveness := "-"
+	if entry.EffectivenessRank > 0 {
+		effectiveness = fmt.Sprintf("%.2f%%", entry.Effectiveness*100)
+	}
+	share := "-"
+	if entry.SmoothingPoolRank > 0 {
+		share = fmt.Sprintf("%.4f%%", entry.SmoothingPoolShare*100)
+	}
+	node := entry.Address.Hex()
+	if name, exists := ensNames[entry.Address]; exists {
+		node = fmt.Sprintf("%s (%s)", name, node)
+	}
+	start, end := "", ""
+	if highlight {
+		start, end = colorGreen, colorReset
+	}
+	fmt.Fprintf(writer, "%s%d\t%s\t%.4f\t%s\t%.6f\t%s%s\t\n",
+		start,
+		entry.WeightRank,
+		node,
+		math.RoundDown(eth.WeiToEth(&entry.Weight.Int), 4),
+		effectiveness,
+		math.RoundDown(eth.WeiToEth(new(big.Int).Set(&entry.SmoothingPoolEth.Int)), 6),
+		share,
+		end,
+	)
+}
+
+// Format a rank as an ordinal, e.g. 1st or 22nd
+func formatLeaderboardRank(rank int) string {
+	suffix := "th"
+	switch rank % 100 {
+	case 11, 12, 13:
+	default:
+		switch rank % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", rank, suffix)
+}
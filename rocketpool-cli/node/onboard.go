@@ -0,0 +1,411 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool-cli/wallet"
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// A transaction the onboarding wizard will send
+type onboardingStep struct {
+	description string
+	run         func(rp *rocketpool.Client, c *cli.Context) error
+}
+
+func onboardNode(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	fmt.Println("This wizard will walk you through setting up your node with Rocket Pool.")
+	fmt.Println("Steps that are already done are skipped, so if anything goes wrong you can run `rocketpool node onboard` again to pick up where you left off.")
+	fmt.Println()
+
+	// Step 1: the node wallet
+	fmt.Printf("%s=== Step 1: Node Wallet ===%s\n", colorGreen, colorReset)
+	walletStatus, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !walletStatus.WalletInitialized {
+		options := []string{
+			"Create a new wallet",
+			"Recover an existing wallet from its mnemonic",
+		}
+		selected, _ := cliutils.Select("Your node doesn't have a wallet yet. What would you like to do?", options)
+		if selected == 0 {
+			err = wallet.InitWallet(c)
+		} else {
+			err = wallet.RecoverWallet(c)
+		}
+		if err != nil {
+			return err
+		}
+		walletStatus, err = rp.WalletStatus()
+		if err != nil {
+			return err
+		}
+		if !walletStatus.WalletInitialized {
+			fmt.Println("The node wallet wasn't set up, so onboarding can't continue.")
+			return nil
+		}
+	}
+	fmt.Printf("Your node wallet is ready. Node account: %s\n\n", walletStatus.AccountAddress.Hex())
+
+	// Get the node status
+	status, err := rp.NodeStatus()
+	if err != nil {
+		return err
+	}
+	if status.AccountBalances.ETH.Cmp(big.NewInt(0)) == 0 {
+		fmt.Printf("Your node account doesn't have any ETH to pay for transactions with. Please send some ETH (and the RPL you want to stake) to %s, then run `rocketpool node onboard` again.\n", walletStatus.AccountAddress.Hex())
+		return nil
+	}
+	steps := []onboardingStep{}
+
+	// Step 2: registration and timezone
+	fmt.Printf("%s=== Step 2: Registration ===%s\n", colorGreen, colorReset)
+	if !status.Registered {
+		timezoneLocation := c.String("timezone")
+		if timezoneLocation == "" {
+			timezoneLocation = promptTimezone()
+		}
+		steps = append(steps, onboardingStep{
+			description: fmt.Sprintf("Register the node with Rocket Pool (timezone %s)", timezoneLocation),
+			run: func(rp *rocketpool.Client, c *cli.Context) error {
+				return onboardRegister(rp, c, timezoneLocation)
+			},
+		})
+	} else {
+		fmt.Printf("Your node is already registered, with the timezone %s.\n", status.TimezoneLocation)
+		if cliutils.Confirm("Would you like to change your timezone?") {
+			timezoneLocation := promptTimezone()
+			steps = append(steps, onboardingStep{
+				description: fmt.Sprintf("Change the node's timezone to %s", timezoneLocation),
+				run: func(rp *rocketpool.Client, c *cli.Context) error {
+					return onboardSetTimezone(rp, c, timezoneLocation)
+				},
+			})
+		}
+	}
+	fmt.Println()
+
+	// Step 3: staking RPL
+	fmt.Printf("%s=== Step 3: Staking RPL ===%s\n", colorGreen, colorReset)
+	rplBalance := status.AccountBalances.RPL
+	fmt.Printf("Your node has %.6f RPL staked, and %.6f RPL in its wallet.\n", math.RoundDown(eth.WeiToEth(status.RplStake), 6), math.RoundDown(eth.WeiToEth(rplBalance), 6))
+	if rplBalance.Cmp(big.NewInt(0)) == 0 {
+		fmt.Println("There's no RPL in the node wallet to stake, so this step will be skipped. You can stake RPL later with `rocketpool node stake-rpl`.")
+	} else {
+		amountWei, err := promptOnboardingRplStake(c, rplBalance)
+		if err != nil {
+			return err
+		}
+		if amountWei != nil {
+			allowance, err := rp.GetNodeStakeRplAllowance()
+			if err != nil {
+				return err
+			}
+			if allowance.Allowance.Cmp(amountWei) < 0 {
+				steps = append(steps, onboardingStep{
+					description: "Let the staking contract use the node's RPL",
+					run:         onboardApproveRpl,
+				})
+			}
+			steps = append(steps, onboardingStep{
+				description: fmt.Sprintf("Stake %.6f RPL", math.RoundDown(eth.WeiToEth(amountWei), 6)),
+				run: func(rp *rocketpool.Client, c *cli.Context) error {
+					return onboardStakeRpl(rp, c, amountWei)
+				},
+			})
+		}
+	}
+	fmt.Println()
+
+	// Step 4: the fee recipient
+	fmt.Printf("%s=== Step 4: Fee Recipient ===%s\n", colorGreen, colorReset)
+	fmt.Println("Your validators' priority fees and MEV go to your node's fee distributor contract, or to the Smoothing Pool if you join it. The Smartnode sets this fee recipient for you automatically.")
+	if status.IsFeeDistributorInitialized {
+		fmt.Println("Your fee distributor contract is already initialized.")
+	} else {
+		fmt.Println("Your fee distributor contract needs to be initialized before you can claim the rewards sent to it. The rewards accumulate either way, so this can wait until gas is cheap.")
+		if cliutils.Confirm("Would you like to initialize it now?") {
+			steps = append(steps, onboardingStep{
+				description: "Initialize the node's fee distributor contract",
+				run:         onboardInitializeFeeDistributor,
+			})
+		}
+	}
+	fmt.Println()
+
+	// Step 5: the Smoothing Pool
+	fmt.Printf("%s=== Step 5: Smoothing Pool ===%s\n", colorGreen, colorReset)
+	canJoin := true
+	if status.Registered {
+		spStatus, err := rp.NodeGetSmoothingPoolRegistrationStatus()
+		if err != nil {
+			return err
+		}
+		if spStatus.NodeRegistered {
+			fmt.Println("Your node is already in the Smoothing Pool.")
+			canJoin = false
+		} else if spStatus.TimeLeftUntilChangeable > 0 {
+			fmt.Printf("You have recently left the Smoothing Pool, so you can't join it again for %s.\n", spStatus.TimeLeftUntilChangeable)
+			canJoin = false
+		}
+	}
+	if canJoin {
+		fmt.Println("Members of the Smoothing Pool share the priority fees and MEV from all of their proposals, which gives you a steady income instead of relying on the luck of your own proposals.")
+		fmt.Println("You can see how many nodes have joined with `rocketpool network smoothing-pool-stats`, and you can leave after one full rewards interval.")
+		if cliutils.Confirm("Would you like to join the Smoothing Pool? (This is optional.)") {
+			steps = append(steps, onboardingStep{
+				description: "Join the Smoothing Pool (this restarts your validator client)",
+				run:         onboardJoinSmoothingPool,
+			})
+		}
+	}
+	fmt.Println()
+
+	// Summarize the transactions and confirm them
+	if len(steps) == 0 {
+		fmt.Println("Your node is fully set up; there's nothing left to do. When you're ready, you can create a minipool with `rocketpool node deposit`.")
+		return nil
+	}
+	fmt.Printf("%s=== Summary ===%s\n", colorGreen, colorReset)
+	fmt.Println("The following transactions will be sent, in order. You'll be asked for the gas price of each one.")
+	for i, step := range steps {
+		fmt.Printf("\t%d. %s\n", i+1, step.description)
+	}
+	fmt.Println()
+	if c.GlobalUint64("nonce") != 0 {
+		cliutils.PrintMultiTransactionNonceWarning()
+	}
+	if !(c.Bool("yes") || cliutils.Confirm("Would you like to continue?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Run the steps, stopping at the first failure
+	for i, step := range steps {
+		fmt.Printf("\n%s[%d/%d] %s%s\n", colorGreen, i+1, len(steps), step.description, colorReset)
+		if err := step.run(rp, c); err != nil {
+			return fmt.Errorf("%w\nOnboarding stopped at step %d (%s). Run `rocketpool node onboard` again to pick up where you left off.", err, i+1, step.description)
+		}
+
+		// If a custom nonce is set, increment it for the next transaction
+		if c.GlobalUint64("nonce") != 0 {
+			rp.IncrementCustomNonce()
+		}
+	}
+
+	// Log & return
+	fmt.Println()
+	fmt.Println("Your node has been onboarded! When you're ready, you can create a minipool with `rocketpool node deposit`.")
+	return nil
+
+}
+
+// Prompt for the amount of RPL to stake during onboarding, or nil to skip staking
+func promptOnboardingRplStake(c *cli.Context, rplBalance *big.Int) (*big.Int, error) {
+	amount := c.String("amount")
+	if amount == "" {
+		options := []string{
+			fmt.Sprintf("Your entire RPL balance (%.6f RPL)", math.RoundDown(eth.WeiToEth(rplBalance), 6)),
+			"A custom amount",
+			"Don't stake RPL now",
+		}
+		selected, _ := cliutils.Select("How much RPL would you like to stake?", options)
+		switch selected {
+		case 0:
+			return rplBalance, nil
+		case 2:
+			return nil, nil
+		}
+		amount = cliutils.Prompt("Please enter an amount of RPL to stake:", "^(0|[1-9]\\d*)(\\.\\d+)?$", "Invalid amount")
+	} else if amount == "all" {
+		return rplBalance, nil
+	}
+
+	stakeAmount, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid stake amount '%s': %w", amount, err)
+	}
+	amountWei := eth.EthToWei(stakeAmount)
+	if amountWei.Cmp(big.NewInt(0)) == 0 {
+		return nil, nil
+	}
+	if amountWei.Cmp(rplBalance) > 0 {
+		return nil, fmt.Errorf("Cannot stake %.6f RPL, because the node only has %.6f RPL.", stakeAmount, math.RoundDown(eth.WeiToEth(rplBalance), 6))
+	}
+	return amountWei, nil
+}
+
+// Register the node
+func onboardRegister(rp *rocketpool.Client, c *cli.Context, timezoneLocation string) error {
+	canRegister, err := rp.CanRegisterNode(timezoneLocation)
+	if err != nil {
+		return err
+	}
+	if !canRegister.CanRegister {
+		if canRegister.AlreadyRegistered {
+			fmt.Println("The node is already registered with Rocket Pool.")
+			return nil
+		}
+		return fmt.Errorf("Node registrations are currently disabled.")
+	}
+	if err := gas.AssignMaxFeeAndLimit(canRegister.GasInfo, rp, c.Bool("yes")); err != nil {
+		return err
+	}
+	response, err := rp.RegisterNode(timezoneLocation)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Registering node...\n")
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+	fmt.Println("The node was successfully registered with Rocket Pool.")
+	return nil
+}
+
+// Set the node's timezone
+func onboardSetTimezone(rp *rocketpool.Client, c *cli.Context, timezoneLocation string) error {
+	canResponse, err := rp.CanSetNodeTimezone(timezoneLocation)
+	if err != nil {
+		return err
+	}
+	if err := gas.AssignMaxFeeAndLimit(canResponse.GasInfo, rp, c.Bool("yes")); err != nil {
+		return err
+	}
+	response, err := rp.SetNodeTimezone(timezoneLocation)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Setting timezone...\n")
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+	fmt.Printf("The node's timezone was successfully updated to %s.\n", timezoneLocation)
+	return nil
+}
+
+// Approve the staking contract to use the node's RPL
+func onboardApproveRpl(rp *rocketpool.Client, c *cli.Context) error {
+	maxApproval := big.NewInt(2)
+	maxApproval = maxApproval.Exp(maxApproval, big.NewInt(256), nil)
+	maxApproval = maxApproval.Sub(maxApproval, big.NewInt(1))
+
+	approvalGas, err := rp.NodeStakeRplApprovalGas(maxApproval)
+	if err != nil {
+		return err
+	}
+	if err := gas.AssignMaxFeeAndLimit(approvalGas.GasInfo, rp, c.Bool("yes")); err != nil {
+		return err
+	}
+	response, err := rp.NodeStakeRplApprove(maxApproval)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Approving RPL for staking...\n")
+	cliutils.PrintTransactionHash(rp, response.ApproveTxHash)
+	if _, err = rp.WaitForTransaction(response.ApproveTxHash); err != nil {
+		return err
+	}
+	fmt.Println("Successfully approved staking access to RPL.")
+	return nil
+}
+
+// Stake RPL
+func onboardStakeRpl(rp *rocketpool.Client, c *cli.Context, amountWei *big.Int) error {
+	canStake, err := rp.CanNodeStakeRpl(amountWei)
+	if err != nil {
+		return err
+	}
+	if !canStake.CanStake {
+		if canStake.InsufficientBalance {
+			return fmt.Errorf("The node's RPL balance is insufficient.")
+		}
+		return fmt.Errorf("The RPL can't be staked.")
+	}
+	if err := gas.AssignMaxFeeAndLimit(canStake.GasInfo, rp, c.Bool("yes")); err != nil {
+		return err
+	}
+	response, err := rp.NodeStakeRpl(amountWei)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Staking RPL...\n")
+	cliutils.PrintTransactionHash(rp, response.StakeTxHash)
+	if _, err = rp.WaitForTransaction(response.StakeTxHash); err != nil {
+		return err
+	}
+	fmt.Printf("Successfully staked %.6f RPL.\n", math.RoundDown(eth.WeiToEth(amountWei), 6))
+	return nil
+}
+
+// Initialize the node's fee distributor
+func onboardInitializeFeeDistributor(rp *rocketpool.Client, c *cli.Context) error {
+	isInitializedResponse, err := rp.IsFeeDistributorInitialized()
+	if err != nil {
+		return err
+	}
+	if isInitializedResponse.IsInitialized {
+		fmt.Println("Your fee distributor contract is already initialized.")
+		return nil
+	}
+	gasResponse, err := rp.GetInitializeFeeDistributorGas()
+	if err != nil {
+		return err
+	}
+	if err := gas.AssignMaxFeeAndLimit(gasResponse.GasInfo, rp, c.Bool("yes")); err != nil {
+		return err
+	}
+	response, err := rp.InitializeFeeDistributor()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Initializing fee distributor contract...\n")
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+	fmt.Printf("Your fee distributor was successfully initialized at address %s.\n", gasResponse.Distributor.Hex())
+	return nil
+}
+
+// Join the Smoothing Pool
+func onboardJoinSmoothingPool(rp *rocketpool.Client, c *cli.Context) error {
+	canResponse, err := rp.CanNodeSetSmoothingPoolStatus(true)
+	if err != nil {
+		return err
+	}
+	if err := gas.AssignMaxFeeAndLimit(canResponse.GasInfo, rp, c.Bool("yes")); err != nil {
+		return err
+	}
+	response, err := rp.NodeSetSmoothingPoolStatus(true)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Joining the Smoothing Pool...\n")
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return fmt.Errorf("%w\nYour fee recipient will be automatically reset to your node's distributor in a few minutes, and your validator client will restart.", err)
+	}
+	fmt.Println("Successfully joined the Smoothing Pool.")
+	return nil
+}
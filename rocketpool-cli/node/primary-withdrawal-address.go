@@ -1,11 +1,11 @@
 package node
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"strconv"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
@@ -14,6 +14,9 @@ import (
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
+// The default file to save the withdrawal address confirmation transaction to
+const defaultConfirmationTxPath string = "withdrawal-address-confirmation.json"
+
 func setPrimaryWithdrawalAddress(c *cli.Context, withdrawalAddressOrENS string) error {
 
 	// Get RP client
@@ -23,27 +26,13 @@ func setPrimaryWithdrawalAddress(c *cli.Context, withdrawalAddressOrENS string)
 	}
 	defer rp.Close()
 
-	var withdrawalAddress common.Address
-	var withdrawalAddressString string
-	if strings.Contains(withdrawalAddressOrENS, ".") {
-		response, err := rp.ResolveEnsName(withdrawalAddressOrENS)
-		if err != nil {
-			return err
-		}
-		withdrawalAddress = response.Address
-		withdrawalAddressString = fmt.Sprintf("%s (%s)", withdrawalAddressOrENS, withdrawalAddress.Hex())
-	} else {
-		withdrawalAddress, err = cliutils.ValidateAddress("withdrawal address", withdrawalAddressOrENS)
-		if err != nil {
-			return err
-		}
-		withdrawalAddressString = withdrawalAddress.Hex()
+	withdrawalAddress, withdrawalAddressString, err := cliutils.ResolveAddress(rp, "withdrawal address", withdrawalAddressOrENS)
+	if err != nil {
+		return err
 	}
+	cliutils.WarnIfUnlabeled(rp, withdrawalAddress)
 
 	// Print the "pending" disclaimer
-	colorReset := "\033[0m"
-	colorRed := "\033[31m"
-	colorYellow := "\033[33m"
 	var confirm bool
 	fmt.Println("You are about to change your primary withdrawal address. All future ETH rewards/refunds will be sent there.\nIf you haven't set your RPL withdrawal address, RPL rewards will be sent there as well.")
 	if !c.Bool("force") {
@@ -51,6 +40,7 @@ func setPrimaryWithdrawalAddress(c *cli.Context, withdrawalAddressOrENS string)
 		fmt.Println("By default, this will put your new primary withdrawal address into a \"pending\" state.")
 		fmt.Println("Rocket Pool will continue to use your old primary withdrawal address until you confirm that you own the new address via the Rocket Pool website.")
 		fmt.Println("You will need to use a web3-compatible wallet (such as MetaMask) with your new address to confirm it.")
+		fmt.Println("The confirmation transaction will also be saved to a file, so you can sign and send it from the new address with any wallet that supports raw transactions.")
 		fmt.Printf("%sIf you cannot use such a wallet, or if you want to bypass this step and force Rocket Pool to use the new address immediately, please re-run this command with the \"--force\" flag.\n\n%s", colorYellow, colorReset)
 	} else {
 		confirm = true
@@ -63,6 +53,13 @@ func setPrimaryWithdrawalAddress(c *cli.Context, withdrawalAddressOrENS string)
 	if err != nil {
 		return err
 	}
+	if canResponse.IsContract {
+		if !c.Bool("allow-contract") {
+			fmt.Printf("%s%s is a contract. Contracts may not be able to confirm the change or receive rewards, so it has been rejected.\nIf you are sure this address is correct, re-run this command with the \"--allow-contract\" flag.%s\n", colorRed, withdrawalAddressString, colorReset)
+			return nil
+		}
+		fmt.Printf("%sNOTE: %s is a contract. Please make sure it can send the confirmation transaction and receive ETH.%s\n\n", colorYellow, withdrawalAddressString, colorReset)
+	}
 
 	if confirm {
 		// Prompt for a test transaction
@@ -137,10 +134,13 @@ func setPrimaryWithdrawalAddress(c *cli.Context, withdrawalAddressOrENS string)
 		}
 		if stakeUrl != "" {
 			fmt.Printf("The node's primary withdrawal address update to %s is now pending.\n"+
-				"To confirm it, please visit the Rocket Pool website (%s).", withdrawalAddressString, stakeUrl)
+				"To confirm it, please visit the Rocket Pool website (%s).\n", withdrawalAddressString, stakeUrl)
 		} else {
 			fmt.Printf("The node's primary withdrawal address update to %s is now pending.\n"+
-				"To confirm it, please visit the Rocket Pool website.", withdrawalAddressString)
+				"To confirm it, please visit the Rocket Pool website.\n", withdrawalAddressString)
+		}
+		if err := saveWithdrawalAddressConfirmationTx(c, rp); err != nil {
+			fmt.Printf("%sWARNING: could not save the confirmation transaction: %s%s\n", colorYellow, err.Error(), colorReset)
 		}
 	} else {
 		fmt.Printf("The node's primary withdrawal address was successfully set to %s.\n", withdrawalAddressString)
@@ -193,3 +193,53 @@ func confirmPrimaryWithdrawalAddress(c *cli.Context) error {
 	return nil
 
 }
+
+func exportPrimaryWithdrawalAddressConfirmation(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	return saveWithdrawalAddressConfirmationTx(c, rp)
+
+}
+
+// Save the transaction that confirms the node's pending primary withdrawal address to a file
+func saveWithdrawalAddressConfirmationTx(c *cli.Context, rp *rocketpool.Client) error {
+
+	// Get the transaction
+	response, err := rp.GetNodePrimaryWithdrawalAddressConfirmationTx()
+	if err != nil {
+		return err
+	}
+	if !response.HasPendingAddress {
+		fmt.Println("The node does not have a pending primary withdrawal address.")
+		return nil
+	}
+
+	// Save it
+	path := c.String("artifact-path")
+	if path == "" {
+		path = defaultConfirmationTxPath
+	}
+	data, err := json.MarshalIndent(response.Transaction, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing confirmation transaction: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("error saving confirmation transaction to %s: %w", path, err)
+	}
+
+	tx := response.Transaction
+	fmt.Printf("The confirmation transaction has been saved to %s.\n", path)
+	fmt.Printf("To confirm the change, send the following transaction %sfrom %s%s:\n", colorYellow, tx.From.Hex(), colorReset)
+	fmt.Printf("\tChain ID: %d\n", tx.ChainID)
+	fmt.Printf("\tTo:       %s\n", tx.To.Hex())
+	fmt.Printf("\tValue:    %s\n", tx.Value)
+	fmt.Printf("\tData:     %s\n", tx.Data)
+	return nil
+
+}
@@ -0,0 +1,128 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func nodeRewardNetwork(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the current network
+	status, err := rp.RewardNetworkStatus()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Your node's rewards are routed to %s (network %d).\n", status.NetworkName, status.RewardNetwork)
+	if status.IsValid {
+		fmt.Println("This network is enabled, so your rewards will be paid out on it.")
+		if !c.IsSet("network") {
+			return nil
+		}
+	} else {
+		fmt.Printf("%sThis network is not enabled by the Oracle DAO, so it is invalid.\n", colorYellow)
+		fmt.Printf("Rewards for nodes with an invalid reward network aren't lost, but they are paid out on Ethereum (network 0) instead of the network you chose.\n")
+		fmt.Printf("Set a valid network before the current interval ends at %s (in %s) so your next rewards are routed where you expect.%s\n", status.NextIntervalTime.Local().Format(time.RFC1123), time.Until(status.NextIntervalTime).Round(time.Minute), colorReset)
+	}
+	fmt.Println()
+
+	// Get the network to switch to
+	var network uint64
+	if c.IsSet("network") {
+		network = c.Uint64("network")
+	} else {
+		network, err = promptForRewardNetwork(status)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Check if it can be set
+	canSet, err := rp.CanSetRewardNetwork(network)
+	if err != nil {
+		return err
+	}
+	if !canSet.CanSet {
+		if canSet.NetworkDisabled {
+			fmt.Printf("Network %d is not enabled, so it can't be used as your reward network.\n", network)
+		}
+		if canSet.Unchanged {
+			fmt.Printf("Your node's rewards are already routed to network %d.\n", network)
+		}
+		return nil
+	}
+
+	// Only the primary withdrawal address can send the transaction
+	if !canSet.IsNodeWithdrawalAddress {
+		fmt.Printf("The reward network can only be changed by your node's primary withdrawal address, %s.\n", canSet.Transaction.From.Hex())
+		fmt.Println("Please send the following transaction from that address, for example with your wallet's custom transaction or contract interaction feature:")
+		fmt.Println()
+		fmt.Printf("\tFrom:  %s\n", canSet.Transaction.From.Hex())
+		fmt.Printf("\tTo:    %s (rocketNodeManager)\n", canSet.Transaction.To.Hex())
+		fmt.Printf("\tValue: 0 ETH\n")
+		fmt.Printf("\tData:  %s\n", canSet.Transaction.Data.String())
+		fmt.Println()
+		fmt.Println("Once it has been confirmed, run this command again to check that the new network is in place.")
+		return nil
+	}
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canSet.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to route your node's rewards to network %d?", network))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Set the network
+	response, err := rp.SetRewardNetwork(network)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Setting reward network...\n")
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Successfully routed your node's rewards to network %d.\n", network)
+	return nil
+
+}
+
+// Prompt for one of the enabled reward networks
+func promptForRewardNetwork(status api.NodeRewardNetworkStatusResponse) (uint64, error) {
+	options := []string{}
+	networks := []uint64{}
+	for _, option := range status.Networks {
+		if !option.Enabled || option.ID == status.RewardNetwork {
+			continue
+		}
+		options = append(options, fmt.Sprintf("%s (network %d)", option.Name, option.ID))
+		networks = append(networks, option.ID)
+	}
+	if len(options) == 0 {
+		return 0, fmt.Errorf("there are no other enabled reward networks to choose from")
+	}
+	selection, _ := cliutils.Select("Which network would you like your rewards routed to?", options)
+	return networks[selection], nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
 
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
@@ -19,6 +20,7 @@ func getRewards(c *cli.Context) error {
 		return err
 	}
 	defer rp.Close()
+	formatter := getFormatter(rp)
 
 	// Get eligible intervals
 	rewardsInfoResponse, err := rp.GetRewardsInfo()
@@ -88,9 +90,9 @@ func getRewards(c *cli.Context) error {
 	fmt.Printf("%sNOTE: Legacy rewards from pre-Redstone are temporarily not being included in the below figures. They will be added back in a future release. We apologize for the inconvenience!%s\n\n", colorYellow, colorReset)
 
 	fmt.Println("=== ETH ===")
-	fmt.Printf("You have earned %.4f ETH from the Beacon Chain (including your commissions) so far.\n", rewards.BeaconRewards)
-	fmt.Printf("You have claimed %.4f ETH from the Smoothing Pool.\n", rewards.CumulativeEthRewards)
-	fmt.Printf("You still have %.4f ETH in unclaimed Smoothing Pool rewards.\n", rewards.UnclaimedEthRewards)
+	fmt.Printf("You have earned %s from the Beacon Chain (including your commissions) so far.\n", formatter.EthFloat(rewards.BeaconRewards))
+	fmt.Printf("You have claimed %s from the Smoothing Pool.\n", formatter.EthFloat(rewards.CumulativeEthRewards))
+	fmt.Printf("You still have %s in unclaimed Smoothing Pool rewards.\n", formatter.EthFloat(rewards.UnclaimedEthRewards))
 
 	nextRewardsTime := rewards.LastCheckpoint.Add(rewards.RewardsInterval)
 	nextRewardsTimeString := cliutils.GetDateTimeString(uint64(nextRewardsTime.Unix()))
@@ -104,29 +106,39 @@ func getRewards(c *cli.Context) error {
 	fmt.Printf("It will end on %s (%s from now).\n", nextRewardsTimeString, timeToCheckpointString)
 
 	if rewards.UnclaimedRplRewards > 0 {
-		fmt.Printf("You currently have %f unclaimed RPL from staking rewards.\n", rewards.UnclaimedRplRewards)
+		fmt.Printf("You currently have %s in unclaimed staking rewards.\n", formatter.RplFloat(rewards.UnclaimedRplRewards))
 	}
 	if rewards.UnclaimedTrustedRplRewards > 0 {
-		fmt.Printf("You currently have %f unclaimed RPL from Oracle DAO duties.\n", rewards.UnclaimedTrustedRplRewards)
+		fmt.Printf("You currently have %s in unclaimed rewards from Oracle DAO duties.\n", formatter.RplFloat(rewards.UnclaimedTrustedRplRewards))
 	}
 
 	fmt.Println()
-	fmt.Printf("Your estimated RPL staking rewards for this cycle: %f RPL (this may change based on network activity).\n", rewards.EstimatedRewards)
-	fmt.Printf("Based on your current total stake of %f RPL, this is approximately %.2f%% APR.\n", rewards.TotalRplStake, rplApr)
-	fmt.Printf("Your node has received %f RPL staking rewards in total.\n", rewards.CumulativeRplRewards)
+	fmt.Printf("Your estimated RPL staking rewards for this cycle: %s (this may change based on network activity).\n", formatter.RplFloat(rewards.EstimatedRewards))
+	fmt.Printf("Based on your current total stake of %s, this is approximately %.2f%% APR.\n", formatter.RplFloat(rewards.TotalRplStake), rplApr)
+	fmt.Printf("Your node has received %s in staking rewards in total.\n", formatter.RplFloat(rewards.CumulativeRplRewards))
 
 	if rewards.Trusted {
 		rplTrustedApr := rewards.EstimatedTrustedRplRewards / rewards.TrustedRplBond / rewards.RewardsInterval.Hours() * (24 * 365) * 100
 
 		fmt.Println()
-		fmt.Printf("You will receive an estimated %f RPL in rewards for Oracle DAO duties (this may change based on network activity).\n", rewards.EstimatedTrustedRplRewards)
-		fmt.Printf("Based on your bond of %f RPL, this is approximately %.2f%% APR.\n", rewards.TrustedRplBond, rplTrustedApr)
-		fmt.Printf("Your node has received %f RPL Oracle DAO rewards in total.\n", rewards.CumulativeTrustedRplRewards)
+		fmt.Printf("You will receive an estimated %s in rewards for Oracle DAO duties (this may change based on network activity).\n", formatter.RplFloat(rewards.EstimatedTrustedRplRewards))
+		fmt.Printf("Based on your bond of %s, this is approximately %.2f%% APR.\n", formatter.RplFloat(rewards.TrustedRplBond), rplTrustedApr)
+		fmt.Printf("Your node has received %s in Oracle DAO rewards in total.\n", formatter.RplFloat(rewards.CumulativeTrustedRplRewards))
 	}
 
 	fmt.Println()
 	fmt.Println("You may claim these rewards at any time. You no longer need to claim them within this interval.")
 
+	// Show where the rewards will be sent
+	routing := rewardsInfoResponse.ClaimRouting
+	fmt.Printf("When claimed, ETH rewards will be sent to %s and RPL rewards will be sent to %s.\n", routing.EthRecipient.Hex(), routing.RplRecipient.Hex())
+	if routing.PendingEthRecipient != (common.Address{}) || routing.PendingRplRecipient != (common.Address{}) {
+		fmt.Printf("%sYou have a pending withdrawal address change. Rewards will keep going to the current addresses until it is confirmed.%s\n", colorYellow, colorReset)
+	}
+	for _, flag := range routing.ComplianceFlags {
+		fmt.Printf("%sCOMPLIANCE FLAG: The %s claim was flagged for review: %s.%s\n", colorRed, flag.Asset, flag.Reason, colorReset)
+	}
+
 	// Return
 	return nil
 
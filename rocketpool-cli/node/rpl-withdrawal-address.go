@@ -3,7 +3,6 @@ package node
 import (
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
@@ -23,22 +22,11 @@ func setRPLWithdrawalAddress(c *cli.Context, withdrawalAddressOrENS string) erro
 	}
 	defer rp.Close()
 
-	var withdrawalAddress common.Address
-	var withdrawalAddressString string
-	if strings.Contains(withdrawalAddressOrENS, ".") {
-		response, err := rp.ResolveEnsName(withdrawalAddressOrENS)
-		if err != nil {
-			return err
-		}
-		withdrawalAddress = response.Address
-		withdrawalAddressString = fmt.Sprintf("%s (%s)", withdrawalAddressOrENS, withdrawalAddress.Hex())
-	} else {
-		withdrawalAddress, err = cliutils.ValidateAddress("withdrawal address", withdrawalAddressOrENS)
-		if err != nil {
-			return err
-		}
-		withdrawalAddressString = withdrawalAddress.Hex()
+	withdrawalAddress, withdrawalAddressString, err := cliutils.ResolveAddress(rp, "withdrawal address", withdrawalAddressOrENS)
+	if err != nil {
+		return err
 	}
+	cliutils.WarnIfUnlabeled(rp, withdrawalAddress)
 
 	// Print the "pending" disclaimer
 	colorReset := "\033[0m"
@@ -2,9 +2,7 @@ package node
 
 import (
 	"fmt"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -21,21 +19,9 @@ func sendMessage(c *cli.Context, toAddressOrENS string, message []byte) error {
 	defer rp.Close()
 
 	// Get the address
-	var toAddress common.Address
-	var toAddressString string
-	if strings.Contains(toAddressOrENS, ".") {
-		response, err := rp.ResolveEnsName(toAddressOrENS)
-		if err != nil {
-			return err
-		}
-		toAddress = response.Address
-		toAddressString = fmt.Sprintf("%s (%s)", toAddressOrENS, toAddress.Hex())
-	} else {
-		toAddress, err = cliutils.ValidateAddress("to address", toAddressOrENS)
-		if err != nil {
-			return err
-		}
-		toAddressString = toAddress.Hex()
+	toAddress, toAddressString, err := cliutils.ResolveAddress(rp, "to address", toAddressOrENS)
+	if err != nil {
+		return err
 	}
 
 	// Get the gas estimate
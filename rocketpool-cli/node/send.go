@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
@@ -27,21 +26,9 @@ func nodeSend(c *cli.Context, amount float64, token string, toAddressOrENS strin
 	amountWei := eth.EthToWei(amount)
 
 	// Get the recipient
-	var toAddress common.Address
-	var toAddressString string
-	if strings.Contains(toAddressOrENS, ".") {
-		response, err := rp.ResolveEnsName(toAddressOrENS)
-		if err != nil {
-			return err
-		}
-		toAddress = response.Address
-		toAddressString = fmt.Sprintf("%s (%s)", toAddressOrENS, toAddress.Hex())
-	} else {
-		toAddress, err = cliutils.ValidateAddress("to address", toAddressOrENS)
-		if err != nil {
-			return err
-		}
-		toAddressString = toAddress.Hex()
+	toAddress, toAddressString, err := cliutils.ResolveAddress(rp, "to address", toAddressOrENS)
+	if err != nil {
+		return err
 	}
 
 	// Check tokens can be sent
@@ -64,6 +51,7 @@ func nodeSend(c *cli.Context, amount float64, token string, toAddressOrENS strin
 	}
 
 	// Prompt for confirmation
+	cliutils.WarnIfUnlabeled(rp, toAddress)
 	if strings.HasPrefix(token, "0x") {
 		fmt.Printf("Token address:   %s\n", token)
 		fmt.Printf("Token name:      %s\n", canSend.TokenName)
@@ -61,6 +61,8 @@ func getStatus(c *cli.Context) error {
 		}()
 	}
 
+	formatter := newFormatter(cfg)
+
 	// Print what network we're on
 	err = cliutils.PrintNetwork(cfg.GetNetwork(), isNew)
 	if err != nil {
@@ -87,20 +89,20 @@ func getStatus(c *cli.Context) error {
 	// Account address & balances
 	fmt.Printf("%s=== Account and Balances ===%s\n", colorGreen, colorReset)
 	fmt.Printf(
-		"The node %s%s%s has a balance of %.6f ETH and %.6f RPL.\n",
+		"The node %s%s%s has a balance of %s and %s.\n",
 		colorBlue,
 		status.AccountAddressFormatted,
 		colorReset,
-		math.RoundDown(eth.WeiToEth(status.AccountBalances.ETH), 6),
-		math.RoundDown(eth.WeiToEth(status.AccountBalances.RPL), 6))
+		formatter.Eth(status.AccountBalances.ETH),
+		formatter.Rpl(status.AccountBalances.RPL))
 	if status.AccountBalances.FixedSupplyRPL.Cmp(big.NewInt(0)) > 0 {
 		fmt.Printf("The node has a balance of %.6f old RPL which can be swapped for new RPL.\n", math.RoundDown(eth.WeiToEth(status.AccountBalances.FixedSupplyRPL), 6))
 	}
 	fmt.Printf(
-		"The node has %.6f ETH in its credit balance and %.6f ETH staked on its behalf. %.6f can be used to make new minipools.\n",
-		math.RoundDown(eth.WeiToEth(status.CreditBalance), 6),
-		math.RoundDown(eth.WeiToEth(status.EthOnBehalfBalance), 6),
-		math.RoundDown(eth.WeiToEth(status.UsableCreditAndEthOnBehalfBalance), 6),
+		"The node has %s in its credit balance and %s staked on its behalf. %s can be used to make new minipools.\n",
+		formatter.Eth(status.CreditBalance),
+		formatter.Eth(status.EthOnBehalfBalance),
+		formatter.Eth(status.UsableCreditAndEthOnBehalfBalance),
 	)
 
 	// Registered node details
@@ -202,8 +204,8 @@ func getStatus(c *cli.Context) error {
 		if status.IsRPLLockingAllowed {
 			fmt.Print("The node is allowed to lock RPL to create governance proposals/challenges.\n")
 			if status.NodeRPLLocked.Cmp(big.NewInt(0)) != 0 {
-				fmt.Printf("The node currently has %.6f RPL locked.\n",
-					math.RoundDown(eth.WeiToEth(status.NodeRPLLocked), 6))
+				fmt.Printf("The node currently has %s locked.\n",
+					formatter.Rpl(status.NodeRPLLocked))
 			}
 
 		} else {
@@ -215,12 +217,12 @@ func getStatus(c *cli.Context) error {
 		fmt.Printf("%s=== Primary Withdrawal Address ===%s\n", colorGreen, colorReset)
 		if !bytes.Equal(status.AccountAddress.Bytes(), status.PrimaryWithdrawalAddress.Bytes()) {
 			fmt.Printf(
-				"The node's primary withdrawal address %s%s%s has a balance of %.6f ETH and %.6f RPL.\n",
+				"The node's primary withdrawal address %s%s%s has a balance of %s and %s.\n",
 				colorBlue,
 				status.PrimaryWithdrawalAddressFormatted,
 				colorReset,
-				math.RoundDown(eth.WeiToEth(status.PrimaryWithdrawalBalances.ETH), 6),
-				math.RoundDown(eth.WeiToEth(status.PrimaryWithdrawalBalances.RPL), 6))
+				formatter.Eth(status.PrimaryWithdrawalBalances.ETH),
+				formatter.Rpl(status.PrimaryWithdrawalBalances.RPL))
 		} else {
 			fmt.Printf("%sThe node's primary withdrawal address has not been changed, so ETH rewards and minipool withdrawals will be sent to the node itself.\n", colorYellow)
 			fmt.Printf("Consider changing this to a cold wallet address that you control using the `set-withdrawal-address` command.\n%s", colorReset)
@@ -228,7 +230,8 @@ func getStatus(c *cli.Context) error {
 		fmt.Println("")
 		if status.PendingPrimaryWithdrawalAddress.Hex() != blankAddress.Hex() {
 			fmt.Printf("%sThe node's primary withdrawal address has a pending change to %s which has not been confirmed yet.\n", colorYellow, status.PendingPrimaryWithdrawalAddressFormatted)
-			fmt.Printf("Please visit the Rocket Pool website with a web3-compatible wallet to complete this change.%s\n", colorReset)
+			fmt.Printf("Please visit the Rocket Pool website with a web3-compatible wallet to complete this change.\n")
+			fmt.Printf("Alternatively, run `rocketpool node export-primary-withdrawal-address-confirmation` to save the confirmation transaction and send it from the new address.%s\n", colorReset)
 			fmt.Println("")
 		}
 
@@ -242,12 +245,12 @@ func getStatus(c *cli.Context) error {
 			fmt.Printf("The node's RPL withdrawal address has been explicitly set to the primary withdrawal address (%s%s%s).\n", colorBlue, status.RPLWithdrawalAddressFormatted, colorReset)
 		} else {
 			fmt.Printf(
-				"The node's RPL withdrawal address %s%s%s has a balance of %.6f ETH and %.6f RPL.\n",
+				"The node's RPL withdrawal address %s%s%s has a balance of %s and %s.\n",
 				colorBlue,
 				status.RPLWithdrawalAddressFormatted,
 				colorReset,
-				math.RoundDown(eth.WeiToEth(status.RPLWithdrawalBalances.ETH), 6),
-				math.RoundDown(eth.WeiToEth(status.RPLWithdrawalBalances.RPL), 6))
+				formatter.Eth(status.RPLWithdrawalBalances.ETH),
+				formatter.Rpl(status.RPLWithdrawalBalances.RPL))
 		}
 		fmt.Println("")
 		if status.PendingRPLWithdrawalAddress.Hex() != blankAddress.Hex() {
@@ -258,7 +261,7 @@ func getStatus(c *cli.Context) error {
 
 		// Fee distributor details
 		fmt.Printf("%s=== Fee Distributor and Smoothing Pool ===%s\n", colorGreen, colorReset)
-		fmt.Printf("The node's fee distributor %s%s%s has a balance of %.6f ETH.\n", colorBlue, status.FeeRecipientInfo.FeeDistributorAddress.Hex(), colorReset, math.RoundDown(eth.WeiToEth(status.FeeDistributorBalance), 6))
+		fmt.Printf("The node's fee distributor %s%s%s has a balance of %s.\n", colorBlue, status.FeeRecipientInfo.FeeDistributorAddress.Hex(), colorReset, formatter.Eth(status.FeeDistributorBalance))
 		if cfg.IsNativeMode && !status.FeeRecipientInfo.IsInSmoothingPool && !status.FeeRecipientInfo.IsInOptOutCooldown {
 			fmt.Printf("%sNOTE: You are in Native Mode; you MUST ensure that your Validator Client is using this address as its fee recipient!%s\n", colorYellow, colorReset)
 		}
@@ -312,8 +315,8 @@ func getStatus(c *cli.Context) error {
 		fmt.Println("NOTE: The following figures take *any pending bond reductions* into account.")
 		fmt.Println()
 		fmt.Printf(
-			"The node has a total stake of %.6f RPL.\n",
-			math.RoundDown(eth.WeiToEth(status.RplStake), 6))
+			"The node has a total stake of %s.\n",
+			formatter.Rpl(status.RplStake))
 		if status.BorrowedCollateralRatio > 0 {
 			rplTooLow := (status.RplStake.Cmp(status.MinimumRplStake) < 0)
 			rplTotalStake := math.RoundDown(eth.WeiToEth(status.RplStake), 6)
@@ -329,12 +332,12 @@ func getStatus(c *cli.Context) error {
 			}
 			if !hotfix.IsHoustonHotfixDeployed {
 				fmt.Printf(
-					"It must keep at least %.6f RPL staked to claim RPL rewards (10%% of borrowed ETH).\n", math.RoundDown(eth.WeiToEth(status.MinimumRplStake), 6))
+					"It must keep at least %s staked to claim RPL rewards (10%% of borrowed ETH).\n", formatter.Rpl(status.MinimumRplStake))
 				fmt.Printf(
 					"RPIP-30 is in effect and the node will gradually earn rewards in amounts above the previous limit of 150%% of bonded ETH. Read more at https://github.com/rocket-pool/RPIPs/blob/main/RPIPs/RPIP-30.md\n")
 				if rplTotalStake > rplWithdrawalLimit {
 					fmt.Printf(
-						"You can now withdraw down to %.6f RPL (%.0f%% of bonded eth)\n", math.RoundDown(eth.WeiToEth(status.MaximumRplStake), 6), (status.MaximumStakeFraction)*100)
+						"You can now withdraw down to %s (%.0f%% of bonded eth)\n", formatter.Rpl(status.MaximumRplStake), (status.MaximumStakeFraction)*100)
 				}
 				if rplTooLow {
 					fmt.Printf("%sWARNING: you are currently undercollateralized. You must stake at least %.6f more RPL in order to claim RPL rewards.%s\n", colorRed, math.RoundUp(eth.WeiToEth(big.NewInt(0).Sub(status.MinimumRplStake, status.RplStake)), 6), colorReset)
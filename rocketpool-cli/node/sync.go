@@ -7,6 +7,7 @@ import (
 
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -14,6 +15,7 @@ import (
 
 // Settings
 var ethClientRecentBlockThreshold, _ = time.ParseDuration("5m")
+var treeGenerationStaleThreshold, _ = time.ParseDuration("5m")
 
 func printClientStatus(status *api.ClientStatus, name string) {
 
@@ -47,6 +49,33 @@ func printSyncProgress(status *api.ClientManagerStatus, name string) {
 	printClientStatus(&status.FallbackClientStatus, fmt.Sprintf("fallback %s client", name))
 }
 
+func printTreeGenerationProgress(progress *rewards.TreeGenerationProgress) {
+
+	fmt.Println()
+	if progress.Complete {
+		fmt.Printf("The last rewards tree generation (interval %d) finished at %s.\n", progress.Index, progress.UpdateTime.Local().Format(time.RFC822))
+		return
+	}
+
+	// Generation that hasn't reported recently was most likely interrupted
+	if time.Since(progress.UpdateTime) > treeGenerationStaleThreshold {
+		fmt.Printf("The rewards tree generation for interval %d stopped reporting progress at %s (%0.2f%%); it may have been interrupted.\n", progress.Index, progress.UpdateTime.Local().Format(time.RFC822), progress.GetPercent())
+		return
+	}
+
+	fmt.Printf("Your node is generating the rewards tree for interval %d (epoch %d of %d, %0.2f%%).\n", progress.Index, progress.CurrentEpoch, progress.EndEpoch, progress.GetPercent())
+	if progress.EpochsPerSecond == 0 {
+		fmt.Println("\tThe processing rate isn't known yet.")
+		return
+	}
+	fmt.Printf("\tProcessing %0.2f epochs per second, expected to finish in %s.\n", progress.EpochsPerSecond, time.Until(progress.Eta).Round(time.Second))
+	if progress.WillMissDeadline() {
+		colorReset := "\033[0m"
+		colorYellow := "\033[33m"
+		fmt.Printf("%s\tWARNING: generation is not expected to finish before the next interval ends (%s).\n\tYour consensus client may be too slow to catch up.%s\n", colorYellow, progress.Deadline.Local().Format(time.RFC822), colorReset)
+	}
+}
+
 func getSyncProgress(c *cli.Context) error {
 
 	// Get RP client
@@ -100,6 +129,14 @@ func getSyncProgress(c *cli.Context) error {
 	// Print CC status
 	printSyncProgress(&status.BcStatus, "consensus")
 
+	// Print rewards tree generation status
+	if status.TreeGenerationError != "" {
+		fmt.Println()
+		fmt.Printf("The rewards tree generation progress is unavailable (%s).\n", status.TreeGenerationError)
+	} else if status.TreeGeneration != nil {
+		printTreeGenerationProgress(status.TreeGeneration)
+	}
+
 	// Return
 	return nil
 
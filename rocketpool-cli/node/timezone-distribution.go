@@ -0,0 +1,93 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The default number of timezones to show in the distribution
+const defaultTimezoneCount int = 15
+
+func getTimezoneDistribution(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the distribution
+	response, err := rp.NodeTimezoneDistribution()
+	if err != nil {
+		return err
+	}
+	if response.TotalNodes == 0 {
+		fmt.Println("There are no nodes registered with Rocket Pool yet.")
+		return nil
+	}
+
+	// Aggregate the timezones into regions
+	regionCounts := map[string]uint64{}
+	for _, timezone := range response.Timezones {
+		region, _, _ := strings.Cut(timezone.Timezone, "/")
+		regionCounts[region] += timezone.Count
+	}
+	regions := make([]api.TimezoneCount, 0, len(regionCounts))
+	for region, count := range regionCounts {
+		regions = append(regions, api.TimezoneCount{Timezone: region, Count: count})
+	}
+	sort.Slice(regions, func(i, j int) bool {
+		if regions[i].Count != regions[j].Count {
+			return regions[i].Count > regions[j].Count
+		}
+		return regions[i].Timezone < regions[j].Timezone
+	})
+	nodeRegion, _, _ := strings.Cut(response.NodeTimezone, "/")
+
+	fmt.Printf("There are %d nodes registered with Rocket Pool.\n\n", response.TotalNodes)
+
+	// Print the regions
+	fmt.Printf("%s=== Regions ===%s\n", colorGreen, colorReset)
+	printTimezoneCounts(regions, len(regions), response.TotalNodes, nodeRegion)
+
+	// Print the top timezones
+	count := c.Int("count")
+	if count <= 0 {
+		count = defaultTimezoneCount
+	}
+	fmt.Printf("\n%s=== Top Timezones ===%s\n", colorGreen, colorReset)
+	printTimezoneCounts(response.Timezones, count, response.TotalNodes, response.NodeTimezone)
+
+	if response.NodeTimezone != "" {
+		fmt.Printf("\nYour node is registered in the %s%s%s timezone.\n", colorYellow, response.NodeTimezone, colorReset)
+	}
+	return nil
+
+}
+
+// Print a table of timezone counts, highlighting the node's own entry
+func printTimezoneCounts(counts []api.TimezoneCount, limit int, totalNodes uint64, highlight string) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Name\tNodes\tShare")
+	for i, entry := range counts {
+		if i >= limit {
+			break
+		}
+		share := float64(entry.Count) / float64(totalNodes) * 100
+		line := fmt.Sprintf("%s\t%d\t%.2f%%", entry.Timezone, entry.Count, share)
+		if entry.Timezone == highlight {
+			line = colorYellow + line + colorReset
+		}
+		fmt.Fprintln(w, line)
+	}
+	w.Flush()
+}
@@ -21,7 +21,9 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/format"
 	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
 )
 
@@ -417,3 +419,22 @@ func warnIfVotingUninitialized(rp *rocketpool.Client, c *cli.Context, warningMes
 
 	return nil
 }
+
+// Get the formatter for ETH and RPL amounts from the user's display settings, warning if the fiat prices are unavailable
+func getFormatter(rp *rocketpool.Client) *format.Formatter {
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		fmt.Printf("%sWARNING: Couldn't load your display settings, so the defaults will be used: %s%s\n\n", colorYellow, err.Error(), colorReset)
+		return format.NewFormatterWithOptions(cfgtypes.DisplayUnit_Eth, cfgtypes.NumberLocale_Plain)
+	}
+	return newFormatter(cfg)
+}
+
+// Get the formatter for ETH and RPL amounts from the provided display settings, warning if the fiat prices are unavailable
+func newFormatter(cfg *config.RocketPoolConfig) *format.Formatter {
+	formatter := format.NewFormatter(cfg)
+	if err := formatter.GetPriceError(); err != nil {
+		fmt.Printf("%sWARNING: Couldn't get the prices for your display currency, so fiat values won't be shown: %s%s\n\n", colorYellow, err.Error(), colorReset)
+	}
+	return formatter
+}
@@ -0,0 +1,64 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func verifyMyRewards(c *cli.Context, interval uint64) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Verify the rewards
+	fmt.Printf("Verifying your node's rewards for interval %d; this may take a while if the rewards files need to be downloaded...\n", interval)
+	response, err := rp.VerifyMyRewards(interval)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Interval %d was generated with ruleset v%d and has a merkle root of %s.\n", response.Interval, response.RulesetVersion, response.MerkleRoot)
+	if !response.NodeInTree {
+		fmt.Println("Your node does not have an entry in this rewards tree.")
+	}
+	fmt.Println()
+
+	// Print each line
+	printRewardsLineVerification("Collateral RPL", "RPL", response.CollateralRpl)
+	printRewardsLineVerification("Oracle DAO RPL", "RPL", response.OracleDaoRpl)
+	printRewardsLineVerification("Smoothing Pool ETH", "ETH", response.SmoothingPoolEth)
+
+	// Print the verdict
+	if response.Matches {
+		fmt.Printf("%sEverything that could be checked matches the published rewards tree.%s\n", colorGreen, colorReset)
+	} else {
+		fmt.Printf("%sYour node's rewards do not match the published rewards tree.%s\n", colorRed, colorReset)
+		fmt.Println("Please check that your clients were fully synced and that you are using the correct network, then reach out to the Rocket Pool team on Discord with the output above.")
+	}
+	return nil
+
+}
+
+// Print the published and expected amounts for a single rewards line
+func printRewardsLineVerification(name string, unit string, line api.RewardsLineVerification) {
+	fmt.Printf("%s:\n", name)
+	fmt.Printf("\tPublished: %.6f %s\n", eth.WeiToEth(line.Published), unit)
+	if !line.Checked {
+		fmt.Printf("\t%sSkipped: %s.%s\n\n", colorYellow, line.Reason, colorReset)
+		return
+	}
+	fmt.Printf("\tExpected:  %.6f %s\n", eth.WeiToEth(line.Expected), unit)
+	if line.Published.Cmp(line.Expected) == 0 {
+		fmt.Printf("\t%sMatches.%s\n\n", colorGreen, colorReset)
+	} else {
+		difference := big.NewInt(0).Sub(line.Published, line.Expected)
+		fmt.Printf("\t%sMismatch: the published amount is off by %s wei.%s\n\n", colorRed, difference.String(), colorReset)
+	}
+}
@@ -105,15 +105,14 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 								Name:      "invite",
 								Aliases:   []string{"i"},
 								Usage:     "Propose inviting a new member",
-								UsageText: "rocketpool odao propose member invite member-address member-id member-url",
+								UsageText: "rocketpool odao propose member invite member-address-or-ens-name member-id member-url",
 								Action: func(c *cli.Context) error {
 
 									// Validate args
 									if err := cliutils.ValidateArgCount(c, 3); err != nil {
 										return err
 									}
-									memberAddress, err := cliutils.ValidateAddress("member address", c.Args().Get(0))
-									if err != nil {
+									if err := cliutils.ValidateAddressOrName("member address", c.Args().Get(0)); err != nil {
 										return err
 									}
 									memberId, err := cliutils.ValidateDAOMemberID("member ID", c.Args().Get(1))
@@ -122,7 +121,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 									}
 
 									// Run
-									return proposeInvite(c, memberAddress, memberId, c.Args().Get(2))
+									return proposeInvite(c, c.Args().Get(0), memberId, c.Args().Get(2))
 
 								},
 							},
@@ -153,7 +152,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 								Flags: []cli.Flag{
 									cli.StringFlag{
 										Name:  "member, m",
-										Usage: "The address of the member to propose kicking",
+										Usage: "The address or ENS name of the member to propose kicking",
 									},
 									cli.StringFlag{
 										Name:  "fine, f",
@@ -169,7 +168,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 									// Validate flags
 									if c.String("member") != "" {
-										if _, err := cliutils.ValidateAddress("member address", c.String("member")); err != nil {
+										if err := cliutils.ValidateAddressOrName("member address", c.String("member")); err != nil {
 											return err
 										}
 									}
@@ -633,7 +632,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Flags: []cli.Flag{
 					cli.StringFlag{
 						Name:  "refund-address, r",
-						Usage: "The address to refund the node's RPL bond to (or 'node')",
+						Usage: "The address or ENS name to refund the node's RPL bond to (or 'node')",
 					},
 					cli.BoolFlag{
 						Name:  "yes, y",
@@ -649,7 +648,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 					// Validate flags
 					if c.String("refund-address") != "" && c.String("refund-address") != "node" {
-						if _, err := cliutils.ValidateAddress("bond refund address", c.String("refund-address")); err != nil {
+						if err := cliutils.ValidateAddressOrName("bond refund address", c.String("refund-address")); err != nil {
 							return err
 						}
 					}
@@ -34,7 +34,10 @@ func leave(c *cli.Context) error {
 	} else if c.String("refund-address") != "" {
 
 		// Parse bond refund address
-		bondRefundAddress = common.HexToAddress(c.String("refund-address"))
+		bondRefundAddress, _, err = cliutils.ResolveAddress(rp, "bond refund address", c.String("refund-address"))
+		if err != nil {
+			return err
+		}
 
 	} else {
 
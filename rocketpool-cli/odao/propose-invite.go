@@ -3,7 +3,6 @@ package odao
 import (
 	"fmt"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/gas"
@@ -11,7 +10,7 @@ import (
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
-func proposeInvite(c *cli.Context, memberAddress common.Address, memberId, memberUrl string) error {
+func proposeInvite(c *cli.Context, memberAddressOrENS string, memberId, memberUrl string) error {
 
 	// Get RP client
 	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
@@ -20,6 +19,12 @@ func proposeInvite(c *cli.Context, memberAddress common.Address, memberId, membe
 	}
 	defer rp.Close()
 
+	// Get the member's address
+	memberAddress, _, err := cliutils.ResolveAddress(rp, "member address", memberAddressOrENS)
+	if err != nil {
+		return err
+	}
+
 	// Check if proposal can be made
 	canPropose, err := rp.CanProposeInviteToTNDAO(memberAddress, memberId, memberUrl)
 	if err != nil {
@@ -6,7 +6,6 @@ import (
 	"math/big"
 	"strconv"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
@@ -37,7 +36,10 @@ func proposeKick(c *cli.Context) error {
 	if c.String("member") != "" {
 
 		// Get matching member
-		selectedAddress := common.HexToAddress(c.String("member"))
+		selectedAddress, _, err := cliutils.ResolveAddress(rp, "member address", c.String("member"))
+		if err != nil {
+			return err
+		}
 		for _, member := range members.Members {
 			if bytes.Equal(member.Address.Bytes(), selectedAddress.Bytes()) {
 				selectedMember = member
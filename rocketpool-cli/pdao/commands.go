@@ -394,7 +394,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 									},
 									cli.StringFlag{
 										Name:  "address, a",
-										Usage: "The address of the entity being invited",
+										Usage: "The address or ENS name of the entity being invited",
 									},
 								},
 								Action: func(c *cli.Context) error {
@@ -450,7 +450,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 									},
 									cli.StringFlag{
 										Name:  "existing-address, e",
-										Usage: "The address of the existing member",
+										Usage: "The address or ENS name of the existing member",
 									},
 									cli.StringFlag{
 										Name:  "new-id, ni",
@@ -458,7 +458,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 									},
 									cli.StringFlag{
 										Name:  "new-address, na",
-										Usage: "The address of the new entity to invite",
+										Usage: "The address or ENS name of the new entity to invite",
 									},
 								},
 								Action: func(c *cli.Context) error {
@@ -20,9 +20,9 @@ func initializeVotingWithDelegate(c *cli.Context) error {
 	// Get the address
 	delegateAddressString := c.String("address")
 	if delegateAddressString == "" {
-		delegateAddressString = cliutils.Prompt("Please enter the delegate's address:", "^0x[0-9a-fA-F]{40}$", "Invalid member address")
+		delegateAddressString = cliutils.Prompt("Please enter the delegate's address or ENS name:", cliutils.AddressOrNamePattern, "Invalid member address")
 	}
-	delegateAddress, err := cliutils.ValidateAddress("delegateAddress", delegateAddressString)
+	delegateAddress, _, err := cliutils.ResolveAddress(rp, "delegateAddress", delegateAddressString)
 	if err != nil {
 		return err
 	}
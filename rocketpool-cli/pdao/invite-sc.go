@@ -30,9 +30,9 @@ func proposeSecurityCouncilInvite(c *cli.Context) error {
 	// Get the address
 	addressString := c.String("address")
 	if addressString == "" {
-		addressString = cliutils.Prompt("Please enter the member's address:", "^0x[0-9a-fA-F]{40}$", "Invalid member address")
+		addressString = cliutils.Prompt("Please enter the member's address or ENS name:", cliutils.AddressOrNamePattern, "Invalid member address")
 	}
-	address, err := cliutils.ValidateAddress("address", addressString)
+	address, _, err := cliutils.ResolveAddress(rp, "address", addressString)
 	if err != nil {
 		return err
 	}
@@ -30,9 +30,9 @@ func proposeOneTimeSpend(c *cli.Context) error {
 	// Get the recipient
 	recipientString := c.String("recipient")
 	if recipientString == "" {
-		recipientString = cliutils.Prompt("Please enter a recipient address for this spend:", "^0x[0-9a-fA-F]{40}$", "Invalid recipient address")
+		recipientString = cliutils.Prompt("Please enter a recipient address or ENS name for this spend:", cliutils.AddressOrNamePattern, "Invalid recipient address")
 	}
-	recipient, err := cliutils.ValidateAddress("recipient", recipientString)
+	recipient, _, err := cliutils.ResolveAddress(rp, "recipient", recipientString)
 	if err != nil {
 		return err
 	}
@@ -39,7 +39,7 @@ func proposeSecurityCouncilReplace(c *cli.Context) error {
 		oldID = member.ID
 		oldAddress = member.Address
 	} else {
-		oldAddress, err = cliutils.ValidateAddress("address", oldAddressString)
+		oldAddress, _, err = cliutils.ResolveAddress(rp, "address", oldAddressString)
 		if err != nil {
 			return err
 		}
@@ -69,9 +69,9 @@ func proposeSecurityCouncilReplace(c *cli.Context) error {
 	// Get the new address
 	newAddressString := c.String("new-address")
 	if newAddressString == "" {
-		newAddressString = cliutils.Prompt("Please enter the member's address:", "^0x[0-9a-fA-F]{40}$", "Invalid member address")
+		newAddressString = cliutils.Prompt("Please enter the member's address or ENS name:", cliutils.AddressOrNamePattern, "Invalid member address")
 	}
-	newAddress, err := cliutils.ValidateAddress("address", newAddressString)
+	newAddress, _, err := cliutils.ResolveAddress(rp, "address", newAddressString)
 	if err != nil {
 		return err
 	}
@@ -2,9 +2,7 @@ package pdao
 
 import (
 	"fmt"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/gas"
@@ -20,21 +18,9 @@ func pdaoSetVotingDelegate(c *cli.Context, nameOrAddress string) error {
 	}
 	defer rp.Close()
 
-	var address common.Address
-	var addressString string
-	if strings.Contains(nameOrAddress, ".") {
-		response, err := rp.ResolveEnsName(nameOrAddress)
-		if err != nil {
-			return err
-		}
-		address = response.Address
-		addressString = fmt.Sprintf("%s (%s)", nameOrAddress, address.Hex())
-	} else {
-		address, err = cliutils.ValidateAddress("delegate", nameOrAddress)
-		if err != nil {
-			return err
-		}
-		addressString = address.Hex()
+	address, addressString, err := cliutils.ResolveAddress(rp, "delegate", nameOrAddress)
+	if err != nil {
+		return err
 	}
 
 	// Get the gas estimation
@@ -0,0 +1,52 @@
+package reth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// The default number of days of exchange rate history to calculate the trailing APR over
+const defaultAprDays uint64 = 7
+
+func getApr(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the APR
+	days := c.Uint64("days")
+	if days == 0 {
+		days = defaultAprDays
+	}
+	fmt.Println("Approximating the smoothing pool rewards for the current interval, this may take a few minutes...")
+	response, err := rp.RethApr(days)
+	if err != nil {
+		return err
+	}
+
+	// Print the trailing APR
+	if response.HasRateApr {
+		fmt.Printf("Over the last %d days, the exchange rate grew at an annualized rate of %.2f%%.\n", response.RateAprDays, response.RateApr*100)
+	} else {
+		fmt.Printf("Your node hasn't recorded enough exchange rates in the last %d days to calculate a trailing APR. Make sure network totals recording is enabled in the Smartnode section of the `rocketpool service config` TUI.\n", response.RateAprDays)
+	}
+	fmt.Println()
+
+	// Print the smoothing pool projection
+	fmt.Printf("The smoothing pool has collected %.6f ETH over the last %s of the current rewards interval.\n", math.RoundDown(eth.WeiToEth(response.SmoothingPoolBalance), 6), response.IntervalElapsed.Round(time.Second))
+	fmt.Printf("The pool stakers' approximate share is %.6f ETH of the %.6f ETH backing rETH.\n", math.RoundDown(eth.WeiToEth(response.SmoothingPoolStakerShare), 6), math.RoundDown(eth.WeiToEth(response.TotalEthBalance), 6))
+	fmt.Printf("At this pace, the smoothing pool contributes roughly %.2f%% to the rETH APR.\n", response.SmoothingPoolApr*100)
+	fmt.Println("NOTE: the Oracle DAO includes this share in each exchange rate update, so it's already part of the trailing APR rather than in addition to it.")
+	return nil
+
+}
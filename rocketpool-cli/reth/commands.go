@@ -0,0 +1,86 @@
+package reth
+
+import (
+	"github.com/urfave/cli"
+
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Monitor rETH from the pool staker's perspective",
+		Subcommands: []cli.Command{
+
+			{
+				Name:      "status",
+				Aliases:   []string{"s"},
+				Usage:     "Show the rETH exchange rate, when it's next updated, and how much ETH can be deposited or burned",
+				UsageText: "rocketpool reth status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getStatus(c)
+
+				},
+			},
+
+			{
+				Name:      "rate-history",
+				Aliases:   []string{"r"},
+				Usage:     "Show the rETH exchange rate recorded by your node each day",
+				UsageText: "rocketpool reth rate-history [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "days, d",
+						Usage: "The number of days of history to show",
+						Value: defaultRateHistoryDays,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getRateHistory(c)
+
+				},
+			},
+
+			{
+				Name:      "apr",
+				Aliases:   []string{"a"},
+				Usage:     "Show the projected rETH APR from the recorded exchange rate and an approximation of the current smoothing pool rewards",
+				UsageText: "rocketpool reth apr [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "days, d",
+						Usage: "The number of days of exchange rate history to calculate the trailing APR over",
+						Value: defaultAprDays,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getApr(c)
+
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,77 @@
+package reth
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// The default number of days of exchange rate history to show
+const defaultRateHistoryDays uint64 = 14
+
+func getRateHistory(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the history
+	days := c.Uint64("days")
+	if days == 0 {
+		days = defaultRateHistoryDays
+	}
+	response, err := rp.RethRateHistory(days)
+	if err != nil {
+		return err
+	}
+	if len(response.Samples) == 0 {
+		if !response.Enabled {
+			fmt.Println("Network totals recording is disabled, so there is no exchange rate history. You can enable it in the Smartnode section of the `rocketpool service config` TUI.")
+		} else {
+			fmt.Printf("No exchange rates have been recorded in the last %d days yet. Please check again later.\n", days)
+		}
+		return nil
+	}
+
+	// Print the last rate recorded each day, with its change from the day before
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "Date\tEpoch\trETH Rate\tChange\t")
+	var previous *state.NetworkTotalsSample
+	for _, sample := range getDailySamples(response.Samples) {
+		change := ""
+		if previous != nil {
+			change = fmt.Sprintf("%+.6f", sample.RethExchangeRate-previous.RethExchangeRate)
+		}
+		fmt.Fprintf(writer, "%s\t%d\t%.6f\t%s\t\n", sample.Time.Local().Format("2006-01-02"), sample.Epoch, sample.RethExchangeRate, change)
+		previous = &sample
+	}
+	writer.Flush()
+	fmt.Println()
+
+	if response.HasApr {
+		fmt.Printf("Over these samples, the exchange rate grew at an annualized rate of %.2f%%.\n", response.Apr*100)
+	} else {
+		fmt.Println("There aren't enough samples to calculate an annualized rate yet.")
+	}
+	return nil
+
+}
+
+// Get the last sample recorded on each local day
+func getDailySamples(samples []state.NetworkTotalsSample) []state.NetworkTotalsSample {
+	daily := []state.NetworkTotalsSample{}
+	for _, sample := range samples {
+		date := sample.Time.Local().Format("2006-01-02")
+		if len(daily) > 0 && daily[len(daily)-1].Time.Local().Format("2006-01-02") == date {
+			daily[len(daily)-1] = sample
+			continue
+		}
+		daily = append(daily, sample)
+	}
+	return daily
+}
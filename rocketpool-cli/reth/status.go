@@ -0,0 +1,54 @@
+package reth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func getStatus(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get rETH status
+	status, err := rp.RethStatus()
+	if err != nil {
+		return err
+	}
+
+	// Print the exchange rate
+	fmt.Printf("1 rETH is worth %.6f ETH, with a supply of %.6f rETH.\n", status.ExchangeRate, math.RoundDown(eth.WeiToEth(status.TotalSupply), 6))
+	if !status.RateUpdatesEnabled {
+		fmt.Println("Exchange rate updates are currently disabled.")
+	} else {
+		if !status.LastRateUpdateTime.IsZero() {
+			fmt.Printf("The rate was last updated for block %d (%s).\n", status.LastRateUpdateBlock, status.LastRateUpdateTime.Local().Format(time.RFC1123))
+		}
+		fmt.Printf("The Oracle DAO updates the rate every %s; the next update is due at %s.\n", status.RateUpdateFrequency, status.NextRateUpdateTime.Local().Format(time.RFC1123))
+	}
+	fmt.Println()
+
+	// Print the deposit capacity
+	if !status.DepositEnabled {
+		fmt.Println("Deposits are currently disabled.")
+	} else {
+		fmt.Printf("The deposit pool has a balance of %.6f ETH and can accept another %.6f ETH.\n", math.RoundDown(eth.WeiToEth(status.DepositPoolBalance), 6), math.RoundDown(eth.WeiToEth(status.DepositCapacity), 6))
+		fmt.Printf("The minimum deposit is %.6f ETH, and deposits pay a fee of %.2f%%.\n", eth.WeiToEth(status.MinimumDeposit), eth.WeiToEth(status.DepositFee)*100)
+	}
+
+	// Print the burn capacity
+	fmt.Printf("Up to %.6f rETH (%.6f ETH) can currently be burned for ETH.\n", math.RoundDown(eth.WeiToEth(status.BurnCapacityReth), 6), math.RoundDown(eth.WeiToEth(status.BurnCapacity), 6))
+	fmt.Printf("The rETH contract's collateral rate is %.2f%% (target %.2f%%).\n", status.CollateralRate*100, status.TargetCollateralRate*100)
+	return nil
+
+}
@@ -8,12 +8,14 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/rocketpool-cli/auction"
+	"github.com/rocket-pool/smartnode/rocketpool-cli/completion"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/minipool"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/network"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/node"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/odao"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/pdao"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/queue"
+	"github.com/rocket-pool/smartnode/rocketpool-cli/reth"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/security"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/service"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/wallet"
@@ -47,6 +49,9 @@ A special thanks to the Rocket Pool community for all their contributions.
 	app.Version = shared.RocketPoolVersion
 	app.Copyright = "(c) 2024 Rocket Pool Pty Ltd"
 
+	// Let the CLI generate its own shell completion candidates
+	app.EnableBashCompletion = true
+
 	// Initialize app metadata
 	app.Metadata = make(map[string]interface{})
 
@@ -81,6 +86,10 @@ A special thanks to the Rocket Pool community for all their contributions.
 			Name:  "nonce",
 			Usage: "Use this flag to explicitly specify the nonce that this transaction should use, so it can override an existing 'stuck' transaction",
 		},
+		cli.BoolFlag{
+			Name:  "simulate",
+			Usage: "Do a dry run: simulate the command's transaction against the pending block and report what it would do (including any revert reason and balance changes) instead of sending it",
+		},
 		cli.BoolFlag{
 			Name:  "debug",
 			Usage: "Enable debug printing of API commands",
@@ -90,16 +99,23 @@ A special thanks to the Rocket Pool community for all their contributions.
 			Usage: "Some commands may print sensitive information to your terminal. " +
 				"Use this flag when nobody can see your screen to allow sensitive data to be printed without prompting",
 		},
+		cli.BoolFlag{
+			Name: "yes, no-prompt",
+			Usage: "Run non-interactively for use in scripts: automatically confirm every prompt, use the default gas settings, " +
+				"and abort instead of waiting if a command needs input that wasn't provided with a flag",
+		},
 	}
 
 	// Register commands
 	auction.RegisterCommands(app, "auction", []string{"a"})
+	completion.RegisterCommands(app, "completion", []string{})
 	minipool.RegisterCommands(app, "minipool", []string{"m"})
 	network.RegisterCommands(app, "network", []string{"e"})
 	node.RegisterCommands(app, "node", []string{"n"})
 	odao.RegisterCommands(app, "odao", []string{"o"})
 	pdao.RegisterCommands(app, "pdao", []string{"p"})
 	queue.RegisterCommands(app, "queue", []string{"q"})
+	reth.RegisterCommands(app, "reth", []string{"r"})
 	security.RegisterCommands(app, "security", []string{"c"})
 	service.RegisterCommands(app, "service", []string{"s"})
 	wallet.RegisterCommands(app, "wallet", []string{"w"})
@@ -112,6 +128,9 @@ A special thanks to the Rocket Pool community for all their contributions.
 			os.Exit(1)
 		}
 
+		// Enable non-interactive mode for scripted use
+		cliutils.SetNonInteractive(c.GlobalBool("yes"))
+
 		// If set, validate custom nonce
 		customNonce := c.GlobalString("nonce")
 		if customNonce != "" {
@@ -128,11 +147,24 @@ A special thanks to the Rocket Pool community for all their contributions.
 		return nil
 	}
 
+	// Shell completion candidates and scripts are read by the shell, so don't pad them with blank lines
+	padOutput := true
+	for _, arg := range os.Args[1:] {
+		if arg == "--generate-bash-completion" || arg == "completion" {
+			padOutput = false
+			break
+		}
+	}
+
 	// Run application
-	fmt.Println("")
+	if padOutput {
+		fmt.Println("")
+	}
 	if err := app.Run(os.Args); err != nil {
 		cliutils.PrettyPrintError(err)
 	}
-	fmt.Println("")
+	if padOutput {
+		fmt.Println("")
+	}
 
 }
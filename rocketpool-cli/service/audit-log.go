@@ -0,0 +1,86 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/audit"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// View the audit log of state-changing actions and verify its hash chain
+func viewAuditLog(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Read the log
+	path := cfg.Smartnode.GetAuditLogPath(false)
+	entries, err := audit.NewLog(path).Read()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Printf("The audit log at %s is empty.\n", path)
+		return nil
+	}
+
+	// Verify the hash chain
+	brokenIndex, err := audit.Verify(entries)
+	if err != nil {
+		return fmt.Errorf("error verifying audit log: %w", err)
+	}
+
+	// Get the entries to show
+	tail := c.String("tail")
+	start := 0
+	if tail != "all" {
+		count, err := strconv.Atoi(tail)
+		if err != nil || count < 1 {
+			return fmt.Errorf("Invalid tail value '%s'; it must be a positive number or \"all\".", tail)
+		}
+		if count < len(entries) {
+			start = len(entries) - count
+		}
+	}
+
+	// Print them
+	for i := start; i < len(entries); i++ {
+		entry := entries[i]
+		marker := ""
+		if brokenIndex != -1 && i >= brokenIndex {
+			marker = fmt.Sprintf(" %s[UNVERIFIED]%s", colorRed, colorReset)
+		}
+		fmt.Printf("%s#%d%s %s  %s%s\n", colorLightBlue, i+1, colorReset, entry.Time.Local().Format("2006-01-02 15:04:05"), entry.Command, marker)
+		if len(entry.Parameters) > 0 {
+			fmt.Printf("    Parameters: %s\n", strings.Join(entry.Parameters, " "))
+		}
+		if entry.TxHash != "" {
+			fmt.Printf("    Transaction: %s\n", entry.TxHash)
+		}
+		fmt.Printf("    Outcome: %s\n", entry.Outcome)
+	}
+	fmt.Println()
+
+	// Print the verification result
+	if brokenIndex == -1 {
+		fmt.Printf("%sThe hash chain of all %d entries in %s is intact.%s\n", colorGreen, len(entries), path, colorReset)
+	} else {
+		fmt.Printf("%sWARNING: the hash chain is broken at entry #%d. That entry or the one before it has been modified, removed, or reordered since it was recorded, so entries from that point on can't be trusted.%s\n", colorRed, brokenIndex+1, colorReset)
+	}
+	return nil
+
+}
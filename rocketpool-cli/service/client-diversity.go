@@ -0,0 +1,52 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/diversity"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Compare the configured clients against the network's client distribution and recommend minority clients
+func printClientDiversityAdvice(cfg *config.RocketPoolConfig) {
+
+	// The distribution data only covers Mainnet
+	if cfg.Smartnode.Network.Value.(cfgtypes.Network) != cfgtypes.Network_Mainnet {
+		return
+	}
+
+	distribution, err := diversity.GetDistribution(cfg.Smartnode.ClientDiversityUrl.Value.(string))
+	if err != nil {
+		fmt.Printf("%sCouldn't get the client distribution (%s), using the built-in estimate instead.%s\n", colorYellow, err.Error(), colorReset)
+	}
+
+	advice := []diversity.ClientAdvice{}
+	if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		advice = append(advice, distribution.AdviseExecutionClient(cfg.ExecutionClient.Value.(cfgtypes.ExecutionClient)))
+	}
+	cc, _ := cfg.GetSelectedConsensusClient()
+	advice = append(advice, distribution.AdviseConsensusClient(cc))
+
+	fmt.Println()
+	fmt.Printf("%s=== Client Diversity ===%s\n", colorGreen, colorReset)
+	fmt.Printf("Client shares are from the %s (%s).\n", distribution.Source, distribution.Date)
+	for _, clientAdvice := range advice {
+		switch clientAdvice.Risk {
+		case diversity.RiskLevel_Low:
+			fmt.Printf("%s runs on %.0f%% of the network, so %s\n", clientAdvice.Client, clientAdvice.Share*100, clientAdvice.RiskSummary)
+		default:
+			color := colorYellow
+			if clientAdvice.Risk == diversity.RiskLevel_Critical || clientAdvice.Risk == diversity.RiskLevel_High {
+				color = colorRed
+			}
+			fmt.Printf("%s%s runs on %.0f%% of the network (%s risk): %s%s\n", color, clientAdvice.Client, clientAdvice.Share*100, clientAdvice.Risk, clientAdvice.RiskSummary, colorReset)
+			if len(clientAdvice.Recommendations) > 0 {
+				fmt.Printf("Please consider switching to a minority client such as %s.\n", strings.Join(clientAdvice.Recommendations, ", "))
+			}
+		}
+	}
+	fmt.Println()
+
+}
@@ -164,6 +164,58 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					return configureService(c)
 
 				},
+				Subcommands: []cli.Command{
+					{
+						Name:      "gas",
+						Usage:     "View the gas ceilings of the node's automatic tasks and the cost confirmation thresholds of interactive commands",
+						UsageText: "rocketpool service config gas",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return showGasThresholds(c)
+
+						},
+						Subcommands: []cli.Command{
+							{
+								Name:      "task",
+								Usage:     "Set the max fee (in gwei) one of the node's automatic tasks will send transactions at, or 'clear' to use the Automatic TX Gas Threshold",
+								UsageText: "rocketpool service config gas task task-name gwei",
+								Action: func(c *cli.Context) error {
+
+									// Validate args
+									if err := cliutils.ValidateArgCount(c, 2); err != nil {
+										return err
+									}
+
+									// Run command
+									return setTaskGasThreshold(c, c.Args().Get(0), c.Args().Get(1))
+
+								},
+							},
+							{
+								Name:      "command",
+								Usage:     "Set the projected cost (in ETH) above which a command asks for confirmation before sending its transaction, or 'clear' to remove it. Use 'default' as the command to cover every command without its own threshold.",
+								UsageText: "rocketpool service config gas command \"command name\" eth",
+								Action: func(c *cli.Context) error {
+
+									// Validate args
+									if err := cliutils.ValidateArgCount(c, 2); err != nil {
+										return err
+									}
+
+									// Run command
+									return setCommandGasThreshold(c, c.Args().Get(0), c.Args().Get(1))
+
+								},
+							},
+						},
+					},
+				},
 			},
 
 			{
@@ -330,6 +382,30 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "audit-log",
+				Usage:     "View the log of state-changing actions taken by the CLI and daemons, and verify that it hasn't been tampered with",
+				UsageText: "rocketpool service audit-log [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "tail, t",
+						Usage: "The number of entries to show from the end of the log (number or \"all\")",
+						Value: "50",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return viewAuditLog(c)
+
+				},
+			},
+
 			{
 				Name:      "stats",
 				Aliases:   []string{"a"},
@@ -348,6 +424,123 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "export-profile",
+				Usage:     "Save the Smart Node's configuration, address book, and policies (but not its keys) to a portable profile file",
+				UsageText: "rocketpool service export-profile path",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run command
+					return exportProfile(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:      "import-profile",
+				Usage:     "Replace the Smart Node's configuration, address book, and policies with the ones in a profile file",
+				UsageText: "rocketpool service import-profile [options] path",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the import",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run command
+					return importProfile(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:      "switch-network",
+				Usage:     "Move the Smart Node to another network, or reset a testnet, backing up the old data folder",
+				UsageText: "rocketpool service switch-network [options] network",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "keep-wallet, k",
+						Usage: "Copy the node wallet into the new data folder (only between test networks)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the switch and start the service afterwards",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run command
+					return switchNetwork(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:      "probe-ec",
+				Usage:     "Check the Execution client's database and the disk for problems that would fail rewards tree generation",
+				UsageText: "rocketpool service probe-ec",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return probeEc(c)
+
+				},
+			},
+
+			{
+				Name:      "tune-resources",
+				Usage:     "Sample the containers' memory and CPU usage, and recommend (or apply) resource limits and client cache sizes",
+				UsageText: "rocketpool service tune-resources [options]",
+				Flags: []cli.Flag{
+					cli.UintFlag{
+						Name:  "samples, n",
+						Usage: "The number of times to sample the containers",
+						Value: 6,
+					},
+					cli.UintFlag{
+						Name:  "interval, i",
+						Usage: "The number of seconds between samples",
+						Value: 10,
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm saving the recommendations",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return tuneResources(c)
+
+				},
+			},
+
 			{
 				Name:      "compose",
 				Usage:     "View the Rocket Pool service docker compose config",
@@ -451,6 +644,23 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "telemetry-preview",
+				Usage:     "Show exactly what the anonymous telemetry report would send",
+				UsageText: "rocketpool service telemetry-preview",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return previewTelemetry(c)
+
+				},
+			},
+
 			{
 				Name:      "resync-eth1",
 				Usage:     fmt.Sprintf("%sDeletes the main ETH1 client's chain data and resyncs it from scratch. Only use this as a last resort!%s", colorRed, colorReset),
@@ -19,6 +19,11 @@ var alertingParametersNativeMode map[string]interface{} = map[string]interface{}
 	"alertEnabled_MinipoolStaked":              nil,
 	"alertEnabled_ExecutionClientSyncComplete": nil,
 	"alertEnabled_BeaconClientSyncComplete":    nil,
+	"alertEnabled_PendingWithdrawalAddress":    nil,
+	"alertEnabled_WatchtowerDutyMissed":        nil,
+	"alertEnabled_LowBalanceForecast":          nil,
+	"alertEnabled_ClockDrift":                  nil,
+	"alertEnabled_ProposalLookahead":           nil,
 }
 
 var alertingParametersDockerMode map[string]interface{} = map[string]interface{}{
@@ -43,6 +48,11 @@ var alertingParametersDockerMode map[string]interface{} = map[string]interface{}
 	"alertEnabled_MinipoolStaked":              nil,
 	"alertEnabled_ExecutionClientSyncComplete": nil,
 	"alertEnabled_BeaconClientSyncComplete":    nil,
+	"alertEnabled_PendingWithdrawalAddress":    nil,
+	"alertEnabled_WatchtowerDutyMissed":        nil,
+	"alertEnabled_LowBalanceForecast":          nil,
+	"alertEnabled_ClockDrift":                  nil,
+	"alertEnabled_ProposalLookahead":           nil,
 }
 
 // The page wrapper for the alerting config
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/gas/thresholds"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// The value that removes a threshold
+const clearThresholdValue string = "clear"
+
+// Print the gas thresholds for the node's automatic tasks and the interactive commands
+func showGasThresholds(c *cli.Context) error {
+
+	cfg, err := loadGasThresholdsConfig(c)
+	if err != nil {
+		return err
+	}
+	gasThresholds, err := thresholds.Load(cfg.Smartnode.GetGasThresholdsPath(false))
+	if err != nil {
+		return err
+	}
+
+	// Print the task ceilings
+	defaultTaskThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
+	fmt.Println("Automatic task gas ceilings (max fee, in gwei):")
+	for _, task := range thresholds.Tasks {
+		threshold, exists := gasThresholds.Tasks[task]
+		if exists {
+			fmt.Printf("\t%-28s %s%.2f%s\n", task, colorGreen, threshold, colorReset)
+		} else if task == thresholds.Task_AutoInitVotingPower {
+			fmt.Printf("\t%-28s %.2f (Auto-Init Voting Power Threshold)\n", task, cfg.Smartnode.AutoInitVPThreshold.Value.(float64))
+		} else {
+			fmt.Printf("\t%-28s %.2f (Automatic TX Gas Threshold)\n", task, defaultTaskThreshold)
+		}
+	}
+	fmt.Println()
+
+	// Print the command thresholds
+	fmt.Println("Interactive command confirmation thresholds (projected cost, in ETH):")
+	if len(gasThresholds.Commands) == 0 {
+		fmt.Println("\tNone; commands only ask for the usual confirmation.")
+	}
+	for _, command := range gasThresholds.GetCommandNames() {
+		fmt.Printf("\t%-28s %s%.6f%s\n", command, colorGreen, gasThresholds.Commands[command], colorReset)
+	}
+	fmt.Println()
+
+	fmt.Println("Use `rocketpool service config gas task <task> <gwei>` or `rocketpool service config gas command <command> <eth>` to change these.")
+	fmt.Printf("Use `%s` as the value to remove one, and `%s` as the command to set the threshold for every command that doesn't have its own.\n", clearThresholdValue, thresholds.DefaultCommand)
+	return nil
+
+}
+
+// Set or clear the gas ceiling for one of the node's automatic tasks
+func setTaskGasThreshold(c *cli.Context, task string, value string) error {
+
+	if !thresholds.IsTask(task) {
+		return fmt.Errorf("Unknown task '%s'; it must be one of: %s", task, strings.Join(thresholds.Tasks, ", "))
+	}
+	err := updateGasThresholds(c, func(gasThresholds *thresholds.Thresholds) error {
+		if value == clearThresholdValue {
+			delete(gasThresholds.Tasks, task)
+			return nil
+		}
+		threshold, err := parseThreshold(value)
+		if err != nil {
+			return err
+		}
+		gasThresholds.Tasks[task] = threshold
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Updated the gas ceiling for %s.\n", task)
+	fmt.Printf("%sThe node container reads this when it starts, so restart it with `rocketpool service start` to apply the change.%s\n", colorYellow, colorReset)
+	return nil
+
+}
+
+// Set or clear the confirmation threshold for an interactive command
+func setCommandGasThreshold(c *cli.Context, command string, value string) error {
+
+	command = strings.Join(strings.Fields(command), " ")
+	err := updateGasThresholds(c, func(gasThresholds *thresholds.Thresholds) error {
+		if value == clearThresholdValue {
+			delete(gasThresholds.Commands, command)
+			return nil
+		}
+		threshold, err := parseThreshold(value)
+		if err != nil {
+			return err
+		}
+		gasThresholds.Commands[command] = threshold
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Updated the confirmation threshold for `%s`.\n", command)
+	return nil
+
+}
+
+// Load the gas thresholds, apply a change, and save them
+func updateGasThresholds(c *cli.Context, update func(*thresholds.Thresholds) error) error {
+	cfg, err := loadGasThresholdsConfig(c)
+	if err != nil {
+		return err
+	}
+	path := cfg.Smartnode.GetGasThresholdsPath(false)
+	gasThresholds, err := thresholds.Load(path)
+	if err != nil {
+		return err
+	}
+	if err := update(gasThresholds); err != nil {
+		return err
+	}
+	return gasThresholds.Save(path)
+}
+
+// Load the Smartnode config to find the thresholds file
+func loadGasThresholdsConfig(c *cli.Context) (*config.RocketPoolConfig, error) {
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return nil, fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	return cfg, nil
+}
+
+// Parse a threshold value, which can't be negative
+func parseThreshold(value string) (float64, error) {
+	threshold, err := strconv.ParseFloat(value, 64)
+	if err != nil || threshold < 0 {
+		return 0, fmt.Errorf("Invalid threshold '%s'; it must be a non-negative number or '%s'.", value, clearThresholdValue)
+	}
+	return threshold, nil
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Probe the Execution client's database and the disk, and print the results
+func probeEc(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Run the probes
+	fmt.Println("Probing the Execution client's database and the disk; this may take a minute...")
+	response, err := rp.ProbeEc()
+	if err != nil {
+		return err
+	}
+
+	// Print the results
+	fmt.Println()
+	for _, probe := range response.Probes {
+		if probe.Healthy {
+			fmt.Printf("%s[OK]%s   %s: %s (%s)\n", colorGreen, colorReset, probe.Name, probe.Detail, probe.Duration.Round(time.Millisecond))
+		} else {
+			fmt.Printf("%s[WARN]%s %s: %s\n", colorYellow, colorReset, probe.Name, probe.Detail)
+		}
+	}
+	fmt.Println()
+	if response.Healthy {
+		fmt.Println("No problems were found.")
+	} else if !response.ArchiveEcIsSet {
+		fmt.Printf("%sSome probes failed, so tree generation may fail. If your Execution client has pruned the state it needs, set an Archive-Mode EC URL in the Smart Node section of `rocketpool service config`.%s\n", colorYellow, colorReset)
+	} else {
+		fmt.Printf("%sSome probes failed, so tree generation may fail. Please check your Execution client and disk.%s\n", colorYellow, colorReset)
+	}
+	return nil
+
+}
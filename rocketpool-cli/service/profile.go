@@ -0,0 +1,149 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/profile"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Export the Smart Node's configuration, address book, and policies to a profile file
+func exportProfile(c *cli.Context, path string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("No configuration detected. Please run `rocketpool service config` to set up your Smart Node first.")
+	}
+	configDir, dataDir, err := getProfileDirs(rp, cfg)
+	if err != nil {
+		return err
+	}
+
+	// Create the profile
+	nodeProfile, err := profile.Create(cfg, shared.RocketPoolVersion, configDir, dataDir)
+	if err != nil {
+		return err
+	}
+	path, err = homedir.Expand(path)
+	if err != nil {
+		return fmt.Errorf("Error expanding profile path: %w", err)
+	}
+	err = nodeProfile.Save(path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Saved your Smart Node profile for %s to %s. It includes your configuration and these files:\n", nodeProfile.Network, path)
+	for _, name := range nodeProfile.GetFileNames() {
+		fmt.Printf("\t%s\n", name)
+	}
+	fmt.Println()
+	fmt.Printf("%sThe profile doesn't include your node wallet or validator keys, but your settings may include notification tokens and URLs with credentials, so keep it private.%s\n", colorYellow, colorReset)
+	return nil
+
+}
+
+// Import a profile file, replacing the Smart Node's configuration, address book, and policies
+func importProfile(c *cli.Context, path string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the profile
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return fmt.Errorf("Error expanding profile path: %w", err)
+	}
+	nodeProfile, err := profile.Load(path)
+	if err != nil {
+		return err
+	}
+
+	// Build the new configuration from the profile, keeping this machine's directories and mode
+	oldCfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	isNative := c.GlobalIsSet("daemon-path")
+	cfg := config.NewRocketPoolConfig(rp.ConfigPath(), isNative)
+	err = cfg.Deserialize(nodeProfile.Settings)
+	if err != nil {
+		return fmt.Errorf("Error loading the profile's settings: %w", err)
+	}
+	cfg.RocketPoolDirectory = oldCfg.RocketPoolDirectory
+	cfg.IsNativeMode = isNative
+	if !isNew {
+		cfg.Smartnode.DataPath.Value = oldCfg.Smartnode.DataPath.Value
+	}
+	if nodeProfile.SmartnodeVersion != shared.RocketPoolVersion {
+		err = cfg.UpdateDefaults()
+		if err != nil {
+			return fmt.Errorf("Error upgrading the profile's settings from v%s: %w", nodeProfile.SmartnodeVersion, err)
+		}
+	}
+	configDir, dataDir, err := getProfileDirs(rp, cfg)
+	if err != nil {
+		return err
+	}
+
+	// Explain what will change
+	fmt.Printf("This profile was exported from Smart Node v%s on %s, for %s.\n", nodeProfile.SmartnodeVersion, nodeProfile.CreatedAt.Format("2006-01-02 15:04 MST"), nodeProfile.Network)
+	fmt.Println("Importing it will replace your configuration and these files:")
+	for _, name := range nodeProfile.GetFileNames() {
+		fmt.Printf("\t%s\n", name)
+	}
+	if !isNew {
+		fmt.Printf("Your data folder stays at %s.\n", dataDir)
+		if oldNetwork := fmt.Sprint(oldCfg.Smartnode.Network.Value); oldNetwork != nodeProfile.Network {
+			fmt.Printf("%sYour node is on %s, but the profile is for %s. Your data folder can't be used on the new network; use `rocketpool service switch-network %s` before importing it.%s\n", colorYellow, oldNetwork, nodeProfile.Network, nodeProfile.Network, colorReset)
+		}
+	}
+	fmt.Println()
+	if !(c.Bool("yes") || cliutils.Confirm("Would you like to import this profile?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Import it
+	err = nodeProfile.RestoreFiles(configDir, dataDir)
+	if err != nil {
+		return err
+	}
+	err = rp.SaveConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("Error saving configuration: %w", err)
+	}
+
+	fmt.Printf("%sThe profile has been imported.%s\n", colorGreen, colorReset)
+	fmt.Println("Please review it with `rocketpool service config`, then run `rocketpool service start` to apply it.")
+	return nil
+
+}
+
+// Get the expanded config and data folders for a profile
+func getProfileDirs(rp *rocketpool.Client, cfg *config.RocketPoolConfig) (string, string, error) {
+	configDir, err := homedir.Expand(rp.ConfigPath())
+	if err != nil {
+		return "", "", fmt.Errorf("Error expanding config path: %w", err)
+	}
+	dataDir, err := homedir.Expand(cfg.Smartnode.DataPath.Value.(string))
+	if err != nil {
+		return "", "", fmt.Errorf("Error expanding data path: %w", err)
+	}
+	return configDir, dataDir, nil
+}
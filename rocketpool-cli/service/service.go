@@ -20,6 +20,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/vcwatchdog"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	sharedConfig "github.com/rocket-pool/smartnode/shared/types/config"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -185,8 +186,49 @@ func serviceStatus(c *cli.Context) error {
 	}
 
 	// Print service status
-	return rp.PrintServiceStatus(getComposeFiles(c))
+	err = rp.PrintServiceStatus(getComposeFiles(c))
+	if err != nil {
+		return err
+	}
 
+	// Print the validator client watchdog's latest check; the daemons may not be running, so this is best-effort
+	watchdog, err := rp.GetVcWatchdogStatus()
+	if err == nil && watchdog.Enabled {
+		fmt.Println()
+		printVcWatchdogStatus(watchdog.VcStatus)
+	}
+	return nil
+
+}
+
+// Print the result of the validator client watchdog's latest check
+func printVcWatchdogStatus(status *vcwatchdog.Status) {
+	fmt.Printf("%s=== Validator Client ===%s\n", colorGreen, colorReset)
+	if status == nil {
+		fmt.Println("The validator client watchdog hasn't checked the validator client yet.")
+		return
+	}
+	fmt.Printf("Last checked %s.\n", status.CheckedAt.Local().Format("2006-01-02 15:04:05"))
+	if status.KeymanagerChecked && status.KeymanagerReachable {
+		fmt.Printf("The validator client is running and has %d of the node's %d active validator key(s) loaded.\n", status.ExpectedKeys-len(status.MissingKeys), status.ExpectedKeys)
+	}
+	if status.DutiesChecked {
+		fmt.Printf("%d of the node's %d active validator(s) earned rewards over the last check.\n", status.ValidatorsPerformed, status.ValidatorsChecked)
+	}
+	if status.Healthy {
+		fmt.Printf("%sThe validator client is healthy.%s\n", colorGreen, colorReset)
+	} else {
+		fmt.Printf("%sThe validator client has failed %d check(s) in a row:%s\n", colorRed, status.ConsecutiveFailures, colorReset)
+		for _, problem := range status.Problems {
+			fmt.Printf("%s\t- %s%s\n", colorRed, problem, colorReset)
+		}
+		for _, pubkey := range status.MissingKeys {
+			fmt.Printf("\tNot loaded: %s\n", pubkey.Hex())
+		}
+	}
+	if !status.LastRestart.IsZero() {
+		fmt.Printf("The watchdog last restarted the validator client at %s.\n", status.LastRestart.Local().Format("2006-01-02 15:04:05"))
+	}
 }
 
 // Configure the service
@@ -259,6 +301,9 @@ func configureService(c *cli.Context) error {
 		}
 		fmt.Println("Your changes have been saved!")
 
+		// Check the client selection against the network's client distribution
+		printClientDiversityAdvice(md.Config)
+
 		// Exit immediately if we're in native mode
 		if isNative {
 			fmt.Println("Please restart your daemon service for them to take effect.")
@@ -491,6 +536,12 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 		return fmt.Errorf("No configuration detected. Please run `rocketpool service config` to set up your Smart Node before running it.")
 	}
 
+	// Make sure the data folder belongs to the configured network
+	err = checkDataFolderNetwork(cfg)
+	if err != nil {
+		return err
+	}
+
 	// Check if this is a new install
 	isUpdate, err := rp.IsFirstRun()
 	if err != nil {
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The files in the data folder that make up the node wallet
+var walletFilenames = []string{"wallet", "password"}
+
+// Switch the Smart Node to another network (or reset a testnet), backing up the old data folder instead of deleting it
+func switchNetwork(c *cli.Context, networkName string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("No configuration detected. Please run `rocketpool service config` to set up your Smart Node first.")
+	}
+	if c.GlobalIsSet("daemon-path") {
+		return fmt.Errorf("Switching networks isn't supported in Native mode. Please follow the steps laid out in the Node Operator's guide (https://docs.rocketpool.net/guides/node/mainnet.html).")
+	}
+
+	// Validate the new network
+	oldNetwork := cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	newNetwork, err := parseNetwork(cfg, networkName)
+	if err != nil {
+		return err
+	}
+	isReset := newNetwork == oldNetwork
+	if isReset && newNetwork == cfgtypes.Network_Mainnet {
+		return fmt.Errorf("Your node is already on Mainnet, and Mainnet can't be reset.")
+	}
+	keepWallet := c.Bool("keep-wallet")
+	if keepWallet && (oldNetwork == cfgtypes.Network_Mainnet || newNetwork == cfgtypes.Network_Mainnet) {
+		return fmt.Errorf("The node wallet can only be kept when switching between test networks. Mainnet always starts with a fresh data folder, so no testnet wallet, keys, or records are reused on it (or vice versa).")
+	}
+
+	// Check the configuration works on the new network before touching anything
+	newCfg := cfg.CreateCopy()
+	newCfg.ChangeNetwork(newNetwork)
+	if newCfg.Smartnode.GetStorageAddress() == "" {
+		return fmt.Errorf("The Smart Node doesn't have the Rocket Pool contract addresses for %s.", newNetwork)
+	}
+	clearNetworkSpecificSettings(newCfg)
+
+	// Get the data folder paths
+	dataPath, err := homedir.Expand(cfg.Smartnode.DataPath.Value.(string))
+	if err != nil {
+		return fmt.Errorf("Error expanding data path: %w", err)
+	}
+	backupPath := fmt.Sprintf("%s-%s-%s", dataPath, oldNetwork, time.Now().Format("20060102-150405"))
+
+	// Explain what will happen
+	if isReset {
+		fmt.Printf("This will reset your node on %s.\n\n", newNetwork)
+	} else {
+		fmt.Printf("This will switch your node from %s to %s.\n\n", oldNetwork, newNetwork)
+	}
+	fmt.Println("The Smart Node will:")
+	fmt.Println("\t- Stop the service and remove its containers, including the Execution and Consensus clients' chain data (they will resync from scratch)")
+	fmt.Printf("\t- Move your data folder (your node wallet, validator keys, rewards trees, and records) to %s\n", backupPath)
+	if keepWallet {
+		fmt.Println("\t- Copy your node wallet and its password into the new data folder; your validator keys will be re-derived for the new network")
+	} else {
+		fmt.Println("\t- Create an empty data folder, so you will need to create or recover a node wallet afterwards")
+	}
+	fmt.Printf("\t- Update your configuration for %s, clearing the Checkpoint Sync URL and Archive-Mode EC URL\n", newNetwork)
+	if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_External || cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_External {
+		fmt.Printf("%sYou use externally-managed clients, so you must switch them to %s yourself.%s\n", colorYellow, newNetwork, colorReset)
+	}
+	fmt.Println()
+	if newNetwork == cfgtypes.Network_Mainnet {
+		fmt.Printf("%sYou are moving to Mainnet. Your node will use real ETH and real RPL.%s\n\n", colorYellow, colorReset)
+	}
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to continue?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Remove the old installation
+	fmt.Println("Removing the old installation...")
+	err = rp.StopService(getComposeFiles(c))
+	if err != nil {
+		return fmt.Errorf("Error removing the old installation: %w", err)
+	}
+
+	// Back up the data folder and make a new one for the new network
+	fmt.Printf("Moving the data folder to %s... ", backupPath)
+	err = rp.MoveDataFolder(dataPath, backupPath)
+	if err != nil {
+		return err
+	}
+	fmt.Println("done")
+	err = os.MkdirAll(filepath.Join(dataPath, "validators"), 0775)
+	if err != nil {
+		return fmt.Errorf("Error recreating data folder: %w", err)
+	}
+	if keepWallet {
+		fmt.Print("Copying the node wallet... ")
+		err = rp.CopyDataFiles(backupPath, dataPath, walletFilenames...)
+		if err != nil {
+			return fmt.Errorf("%w\nYour old data folder is at %s; please copy the files manually.", err, backupPath)
+		}
+		fmt.Println("done")
+	}
+	err = writeDataFolderNetwork(newCfg, newNetwork)
+	if err != nil {
+		return err
+	}
+
+	// Save the new configuration
+	err = rp.SaveConfig(newCfg)
+	if err != nil {
+		return fmt.Errorf("Error saving configuration: %w", err)
+	}
+	fmt.Printf("%sYour node is now configured for %s.%s\n\n", colorGreen, newNetwork, colorReset)
+
+	// Start the service
+	if c.Bool("yes") || cliutils.Confirm("Would you like to start the Smart Node services now?") {
+		err = startService(c, true)
+		if err != nil {
+			return err
+		}
+	} else {
+		fmt.Println("Please run `rocketpool service start` when you are ready to launch.")
+	}
+	fmt.Println()
+	if keepWallet {
+		fmt.Println("Once your clients have synced, run `rocketpool wallet rebuild` to re-derive the validator keys for your minipools on the new network.")
+	} else {
+		fmt.Println("Once the service is running, run `rocketpool wallet init` or `rocketpool wallet recover` to set up a node wallet.")
+	}
+	return nil
+
+}
+
+// Get a network from its name, making sure the Smart Node supports it
+func parseNetwork(cfg *config.RocketPoolConfig, networkName string) (cfgtypes.Network, error) {
+	names := []string{}
+	for _, option := range cfg.Smartnode.Network.Options {
+		network := option.Value.(cfgtypes.Network)
+		if strings.EqualFold(string(network), networkName) {
+			return network, nil
+		}
+		names = append(names, string(network))
+	}
+	return cfgtypes.Network_Unknown, fmt.Errorf("Unknown network '%s'; it must be one of: %s", networkName, strings.Join(names, ", "))
+}
+
+// Clear the settings that point at resources on a specific network
+func clearNetworkSpecificSettings(cfg *config.RocketPoolConfig) {
+	cfg.ConsensusCommon.CheckpointSyncProvider.Value = ""
+	cfg.Smartnode.ArchiveECUrl.Value = ""
+}
+
+// Record which network the data folder belongs to
+func writeDataFolderNetwork(cfg *config.RocketPoolConfig, network cfgtypes.Network) error {
+	path, err := homedir.Expand(cfg.Smartnode.GetNetworkMarkerPathInCLI())
+	if err != nil {
+		return fmt.Errorf("Error expanding network marker path: %w", err)
+	}
+	err = os.WriteFile(path, []byte(network), 0644)
+	if err != nil {
+		return fmt.Errorf("Error recording the data folder's network in %s: %w", path, err)
+	}
+	return nil
+}
+
+// Make sure the data folder belongs to the configured network, so a wallet, keys, or records from one network are
+// never used on another. Folders from before the network was recorded are assumed to belong to the configured one.
+func checkDataFolderNetwork(cfg *config.RocketPoolConfig) error {
+	network := cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	path, err := homedir.Expand(cfg.Smartnode.GetNetworkMarkerPathInCLI())
+	if err != nil {
+		return fmt.Errorf("Error expanding network marker path: %w", err)
+	}
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		if err := writeDataFolderNetwork(cfg, network); err != nil {
+			fmt.Printf("%sWARNING: %s%s\n", colorYellow, err.Error(), colorReset)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error reading the data folder's network from %s: %w", path, err)
+	}
+
+	dataNetwork := cfgtypes.Network(strings.TrimSpace(string(bytes)))
+	if dataNetwork != network {
+		return fmt.Errorf("Your data folder belongs to %s, but your Smart Node is configured for %s. To avoid reusing its wallet, keys, and records on the wrong network, the service won't start.\nPlease use `rocketpool service switch-network %s` to move to %s cleanly, or change the network back to %s.", dataNetwork, network, network, network, dataNetwork)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Print the anonymous telemetry report that would be submitted
+func previewTelemetry(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the report
+	response, err := rp.GetTelemetryPreview()
+	if err != nil {
+		return err
+	}
+
+	if !response.Enabled {
+		fmt.Println("Anonymous telemetry is disabled, so nothing is being sent. If you enable it, this is the report that would be sent each day:")
+	} else if response.Url == "" {
+		fmt.Printf("%sAnonymous telemetry is enabled but no Telemetry URL is set, so nothing is being sent.%s If you set one, this is the report that would be sent each day:\n", colorYellow, colorReset)
+	} else {
+		fmt.Printf("Anonymous telemetry is enabled. This is the report that is sent each day to %s:\n", response.Url)
+	}
+	fmt.Println()
+
+	bytes, err := json.MarshalIndent(response.Report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing telemetry report: %w", err)
+	}
+	fmt.Println(string(bytes))
+	return nil
+
+}
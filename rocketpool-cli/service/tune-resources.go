@@ -0,0 +1,182 @@
+package service
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/pbnjay/memory"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/tuning"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Sample the containers' resource usage and recommend memory limits, CPU limits, and client cache sizes
+func tuneResources(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smart Node.")
+	}
+
+	// Sample the containers
+	sampleCount := c.Uint("samples")
+	if sampleCount == 0 {
+		sampleCount = 1
+	}
+	interval := time.Duration(c.Uint("interval")) * time.Second
+	projectName := cfg.Smartnode.ProjectName.Value.(string)
+	samples := []tuning.Sample{}
+	fmt.Printf("Sampling the containers' resource usage %d times, %s apart...\n", sampleCount, interval)
+	for i := uint(0); i < sampleCount; i++ {
+		if i > 0 {
+			time.Sleep(interval)
+		}
+		output, err := rp.GetServiceResourceUsage(getComposeFiles(c))
+		if err != nil {
+			return fmt.Errorf("Error getting container resource usage: %w", err)
+		}
+		newSamples, err := tuning.ParseStats(output, projectName)
+		if err != nil {
+			return err
+		}
+		samples = append(samples, newSamples...)
+	}
+	usages := tuning.Summarize(samples)
+	if len(usages) == 0 {
+		fmt.Println("None of the Smart Node's containers are running; start them with `rocketpool service start` first.")
+		return nil
+	}
+
+	// Get the recommendations
+	services := make([]tuning.Service, 0, len(usages))
+	for _, usage := range usages {
+		service := tuning.Service{
+			Name:   usage.Service,
+			Client: getServiceClient(cfg, usage.Service),
+		}
+		if param := getCacheParameter(cfg, usage.Service); param != nil {
+			service.Cache = param.Value.(uint64)
+		}
+		services = append(services, service)
+	}
+	machine := tuning.Machine{
+		TotalMemory: memory.TotalMemory(),
+		Cpus:        runtime.NumCPU(),
+	}
+	recommendations := tuning.Recommend(machine, services, usages)
+
+	// Print them alongside the current limits
+	limitsPath := cfg.Smartnode.GetResourceLimitsPath()
+	limits, err := tuning.LoadLimits(limitsPath)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\nThis machine has %d MB of memory and %d CPU cores.\n\n", machine.TotalMemory>>20, machine.Cpus)
+	fmt.Printf("%-14s %-12s %10s %9s %9s %12s %12s\n", "Container", "Client", "Peak Mem", "Avg CPU", "Peak CPU", "Mem Limit", "CPU Limit")
+	cacheChanges := 0
+	for _, recommendation := range recommendations {
+		cpuLimit := "none"
+		if recommendation.Cpus > 0 {
+			cpuLimit = fmt.Sprintf("%.1f cores", recommendation.Cpus)
+		}
+		fmt.Printf("%-14s %-12s %7d MB %8.1f%% %8.1f%% %s%9d MB %12s%s\n",
+			recommendation.Service,
+			recommendation.Client,
+			recommendation.Usage.PeakMemory>>20,
+			recommendation.Usage.AverageCpu,
+			recommendation.Usage.PeakCpu,
+			colorGreen,
+			recommendation.MemLimit>>20,
+			cpuLimit,
+			colorReset,
+		)
+		if current, exists := limits.Services[recommendation.Service]; exists {
+			fmt.Printf("%-14s currently limited to mem_limit %s, cpus %s\n", "", current.MemLimit, current.Cpus)
+		}
+		if recommendation.ChangesCache() {
+			cacheChanges++
+			fmt.Printf("%-14s %s%s: %d MB -> %d MB%s\n", "", colorYellow, recommendation.CacheParam, recommendation.CurrentCache, recommendation.RecommendedCache, colorReset)
+		}
+		for _, note := range recommendation.Notes {
+			fmt.Printf("%-14s (%s)\n", "", note)
+		}
+	}
+	fmt.Println()
+	fmt.Println("Limits are based on the peak usage while sampling, so sample while the clients are under their usual load (not while syncing).")
+
+	// Apply them
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Would you like to save these limits to %s%s?", limitsPath, getCacheChangeSuffix(cacheChanges)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	for _, recommendation := range recommendations {
+		limits.Apply(recommendation)
+		if recommendation.ChangesCache() {
+			getCacheParameter(cfg, recommendation.Service).Value = recommendation.RecommendedCache
+		}
+	}
+	if err := limits.Save(limitsPath); err != nil {
+		return err
+	}
+	if cacheChanges > 0 {
+		if err := rp.SaveConfig(cfg); err != nil {
+			return fmt.Errorf("Error saving configuration: %w", err)
+		}
+	}
+
+	fmt.Println("The recommendations have been saved.")
+	fmt.Printf("%sRun `rocketpool service start` to apply them to the containers.%s\n", colorYellow, colorReset)
+	return nil
+
+}
+
+// Get the prompt suffix describing the cache changes
+func getCacheChangeSuffix(cacheChanges int) string {
+	if cacheChanges == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" and apply the %d cache size change(s) to your configuration", cacheChanges)
+}
+
+// Get the name of the client a container runs locally, blank if it isn't a client
+func getServiceClient(cfg *config.RocketPoolConfig, service string) string {
+	switch service {
+	case config.Eth1ContainerName:
+		if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+			return string(cfg.ExecutionClient.Value.(cfgtypes.ExecutionClient))
+		}
+	case config.Eth2ContainerName:
+		if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+			return string(cfg.ConsensusClient.Value.(cfgtypes.ConsensusClient))
+		}
+	}
+	return ""
+}
+
+// Get the cache or heap size parameter of the client a container runs, if it has one
+func getCacheParameter(cfg *config.RocketPoolConfig, service string) *cfgtypes.Parameter {
+	switch getServiceClient(cfg, service) {
+	case string(cfgtypes.ExecutionClient_Nethermind):
+		return &cfg.Nethermind.CacheSize
+	case string(cfgtypes.ExecutionClient_Reth):
+		return &cfg.Reth.CacheSize
+	case string(cfgtypes.ExecutionClient_Besu):
+		return &cfg.Besu.JvmHeapSize
+	case string(cfgtypes.ConsensusClient_Teku):
+		return &cfg.Teku.JvmHeapSize
+	}
+	return nil
+}
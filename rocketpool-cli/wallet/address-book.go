@@ -0,0 +1,184 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/addressbook"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func listAddressBook(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the address book
+	book, err := cliutils.LoadAddressBook(rp)
+	if err != nil {
+		return err
+	}
+	if len(book.Entries) == 0 {
+		fmt.Println("Your address book is empty. You can add addresses to it with `rocketpool wallet address-book add`.")
+		return nil
+	}
+
+	// Print the entries
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "Label\tAddress\t")
+	for _, entry := range book.Entries {
+		fmt.Fprintf(writer, "%s\t%s\t\n", entry.Label, entry.Address.Hex())
+	}
+	writer.Flush()
+	if book.IsEncrypted() {
+		fmt.Println()
+		fmt.Println("Your address book is encrypted.")
+	}
+	return nil
+
+}
+
+func addToAddressBook(c *cli.Context, label string, addressOrENS string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the address book
+	book, err := cliutils.LoadAddressBook(rp)
+	if err != nil {
+		return err
+	}
+	err = addressbook.ValidateLabel(label)
+	if err != nil {
+		return err
+	}
+	address, addressString, err := cliutils.ResolveAddress(rp, "address", addressOrENS)
+	if err != nil {
+		return err
+	}
+
+	// Check for an existing label
+	if existingAddress, exists := book.GetAddress(label); exists {
+		if existingAddress == address {
+			fmt.Printf("'%s' is already labeled %s.\n", label, address.Hex())
+			return nil
+		}
+		if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("'%s' is currently %s. Are you sure you want to change it to %s?", label, existingAddress.Hex(), addressString))) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+	if existingLabel, exists := book.GetLabel(address); exists {
+		fmt.Printf("%sNOTE: %s is also labeled '%s'.%s\n", colorYellow, address.Hex(), existingLabel, colorReset)
+	}
+
+	// Save it
+	err = book.Set(label, address)
+	if err != nil {
+		return err
+	}
+	err = book.Save()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Labeled %s as '%s'.\n", address.Hex(), label)
+	return nil
+
+}
+
+func removeFromAddressBook(c *cli.Context, label string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the address book
+	book, err := cliutils.LoadAddressBook(rp)
+	if err != nil {
+		return err
+	}
+
+	// Remove the label
+	if !book.Remove(label) {
+		return fmt.Errorf("'%s' isn't in your address book", label)
+	}
+	err = book.Save()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Removed '%s' from your address book.\n", label)
+	return nil
+
+}
+
+func encryptAddressBook(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the address book
+	book, err := cliutils.LoadAddressBook(rp)
+	if err != nil {
+		return err
+	}
+	if book.IsEncrypted() {
+		fmt.Println("Your address book is already encrypted. Its password will be changed.")
+	}
+
+	// Get the new password
+	var password string
+	for {
+		password = cliutils.PromptPassword("Please enter a password to encrypt your address book with:", "^.+$", "Please enter a password:")
+		confirmation := cliutils.PromptPassword("Please confirm your password:", "^.*$", "")
+		if password == confirmation {
+			break
+		}
+		fmt.Println("Password confirmation does not match.")
+		fmt.Println("")
+	}
+
+	// Save it
+	book.SetPassword(password)
+	err = book.Save()
+	if err != nil {
+		return err
+	}
+	fmt.Println("Your address book is now encrypted. You'll be asked for its password when a command uses it.")
+	fmt.Printf("To use it with --yes, set the %s environment variable to the password.\n", cliutils.AddressBookPasswordEnvVar)
+	return nil
+
+}
+
+func decryptAddressBook(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the address book
+	book, err := cliutils.LoadAddressBook(rp)
+	if err != nil {
+		return err
+	}
+	if !book.IsEncrypted() {
+		fmt.Println("Your address book isn't encrypted.")
+		return nil
+	}
+
+	// Save it
+	book.SetPassword("")
+	err = book.Save()
+	if err != nil {
+		return err
+	}
+	fmt.Println("Your address book is no longer encrypted.")
+	return nil
+
+}
@@ -68,7 +68,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Run
-					return initWallet(c)
+					return InitWallet(c)
 
 				},
 			},
@@ -125,7 +125,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Run
-					return recoverWallet(c)
+					return RecoverWallet(c)
 
 				},
 			},
@@ -231,6 +231,113 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:    "address-book",
+				Aliases: []string{"ab"},
+				Usage:   "Manage your local address book of labeled addresses, which you can use in place of addresses in other commands",
+				Subcommands: []cli.Command{
+
+					{
+						Name:      "list",
+						Aliases:   []string{"l"},
+						Usage:     "List the addresses in your address book",
+						UsageText: "rocketpool wallet address-book list",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return listAddressBook(c)
+
+						},
+					},
+
+					{
+						Name:      "add",
+						Aliases:   []string{"a"},
+						Usage:     "Label an address (or ENS name) in your address book, replacing the label's current address if it has one",
+						UsageText: "rocketpool wallet address-book add label address",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm replacing an existing label",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 2); err != nil {
+								return err
+							}
+							if err := cliutils.ValidateAddressOrName("address", c.Args().Get(1)); err != nil {
+								return err
+							}
+
+							// Run
+							return addToAddressBook(c, c.Args().Get(0), c.Args().Get(1))
+
+						},
+					},
+
+					{
+						Name:      "remove",
+						Aliases:   []string{"r"},
+						Usage:     "Remove a label from your address book",
+						UsageText: "rocketpool wallet address-book remove label",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run
+							return removeFromAddressBook(c, c.Args().Get(0))
+
+						},
+					},
+
+					{
+						Name:      "encrypt",
+						Aliases:   []string{"e"},
+						Usage:     "Encrypt your address book with a password, or change its password",
+						UsageText: "rocketpool wallet address-book encrypt",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return encryptAddressBook(c)
+
+						},
+					},
+
+					{
+						Name:      "decrypt",
+						Aliases:   []string{"d"},
+						Usage:     "Remove the password from your address book",
+						UsageText: "rocketpool wallet address-book decrypt",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return decryptAddressBook(c)
+
+						},
+					},
+				},
+			},
+
 			{
 				Name:      "purge",
 				Usage:     fmt.Sprintf("%sDeletes your node wallet, your validator keys, and restarts your Validator Client while preserving your chain data. WARNING: Only use this if you want to stop validating with this machine!%s", colorRed, colorReset),
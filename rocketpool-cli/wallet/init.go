@@ -10,7 +10,8 @@ import (
 	"github.com/rocket-pool/smartnode/shared/utils/term"
 )
 
-func initWallet(c *cli.Context) error {
+// Create a new node wallet, printing its mnemonic for the user to record
+func InitWallet(c *cli.Context) error {
 
 	// Get RP client
 	rp := rocketpool.NewClientFromCtx(c)
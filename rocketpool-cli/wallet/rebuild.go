@@ -73,6 +73,7 @@ func rebuildWallet(c *cli.Context) error {
 	} else {
 		fmt.Println("No validator keys were found.")
 	}
+	printUnmatchedValidatorKeys(response.UnmatchedValidatorKeys)
 	return nil
 
 }
@@ -12,7 +12,8 @@ import (
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
-func recoverWallet(c *cli.Context) error {
+// Recover the node wallet from a mnemonic, along with its validator keys
+func RecoverWallet(c *cli.Context) error {
 
 	// Get RP client
 	rp, ready, err := rocketpool.NewClientFromCtx(c).WithStatus()
@@ -130,6 +131,7 @@ func recoverWallet(c *cli.Context) error {
 			} else {
 				fmt.Println("No validator keys were found.")
 			}
+			printUnmatchedValidatorKeys(response.UnmatchedValidatorKeys)
 		}
 
 	} else {
@@ -172,6 +174,7 @@ func recoverWallet(c *cli.Context) error {
 			} else {
 				fmt.Println("No validator keys were found.")
 			}
+			printUnmatchedValidatorKeys(response.UnmatchedValidatorKeys)
 		}
 	}
 
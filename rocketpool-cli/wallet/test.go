@@ -107,6 +107,7 @@ func testRecovery(c *cli.Context) error {
 			} else {
 				fmt.Println("No validator keys were found.")
 			}
+			printUnmatchedValidatorKeys(response.UnmatchedValidatorKeys)
 		}
 
 	} else {
@@ -152,6 +153,7 @@ func testRecovery(c *cli.Context) error {
 			} else {
 				fmt.Println("No validator keys were found.")
 			}
+			printUnmatchedValidatorKeys(response.UnmatchedValidatorKeys)
 		}
 	}
 
@@ -24,6 +24,20 @@ import (
 const bold string = "\033[1m"
 const unbold string = "\033[0m"
 
+// Print the node's validators whose keys couldn't be recovered, and what to do about them
+func printUnmatchedValidatorKeys(unmatched []types.ValidatorPubkey) {
+	if len(unmatched) == 0 {
+		return
+	}
+	fmt.Printf("\n%sWARNING: the keys for %d of your minipools' validators couldn't be found:%s\n", colorYellow, len(unmatched), colorReset)
+	for _, pubkey := range unmatched {
+		fmt.Println(pubkey.Hex())
+	}
+	fmt.Println("They weren't derived from this mnemonic at any of the known derivation paths, and there are no keystores for them in the `custom-keys` folder of your data directory.")
+	fmt.Println("If these validators were created with another tool or mnemonic, put their keystores in `custom-keys` and their passwords in `custom-key-passwords`, then run `rocketpool wallet rebuild`.")
+	fmt.Println("Until then, the Smartnode can't attest or propose with them.")
+}
+
 // Prompt for a wallet password
 func promptPassword() string {
 	for {
@@ -16,9 +16,11 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool/api/node"
 	"github.com/rocket-pool/smartnode/rocketpool/api/odao"
 	"github.com/rocket-pool/smartnode/rocketpool/api/queue"
+	"github.com/rocket-pool/smartnode/rocketpool/api/reth"
 	apiservice "github.com/rocket-pool/smartnode/rocketpool/api/service"
 	"github.com/rocket-pool/smartnode/rocketpool/api/wallet"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/nonce"
 	apitypes "github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -35,10 +37,15 @@ func waitForTransaction(c *cli.Context, hash common.Hash) (*apitypes.APIResponse
 	if err != nil {
 		return nil, err
 	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Response
 	response := apitypes.APIResponse{}
-	_, err = utils.WaitForTransaction(rp.Client, hash)
+	receipt, err := utils.WaitForTransaction(rp.Client, hash)
+	ec.RecordTransactionResult(hash, receipt, err)
 	if err != nil {
 		return nil, err
 	}
@@ -59,6 +66,12 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 		Subcommands: []cli.Command{},
 	}
 
+	// Coordinate nonces with the daemons sending from the node wallet
+	command.Before = func(c *cli.Context) error {
+		services.EnableNonceCoordination(nonce.Priority_Routine)
+		return nil
+	}
+
 	// Don't show help message for api errors because of JSON serialisation
 	command.OnUsageError = func(context *cli.Context, err error, isSubcommand bool) error {
 		return err
@@ -72,6 +85,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 	odao.RegisterSubcommands(&command, "odao", []string{"o"})
 	pdao.RegisterSubcommands(&command, "pdao", []string{"p"})
 	queue.RegisterSubcommands(&command, "queue", []string{"q"})
+	reth.RegisterSubcommands(&command, "reth", []string{"r"})
 	security.RegisterSubcommands(&command, "security", []string{"c"})
 	apiservice.RegisterSubcommands(&command, "service", []string{"s"})
 	wallet.RegisterSubcommands(&command, "wallet", []string{"w"})
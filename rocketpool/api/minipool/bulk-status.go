@@ -0,0 +1,319 @@
+package minipool
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get one page of the node's minipool statuses. Unlike getStatus, this loads every minipool's contract data with a few
+// multicalls and only queries the Beacon Chain for the minipools on the requested page, so it stays fast for nodes with
+// hundreds of minipools. A page size of 0 returns every minipool.
+func getBulkStatus(c *cli.Context, includeFinalised bool, page uint64, pageSize uint64) (*api.MinipoolBulkStatusResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolBulkStatusResponse{
+		Page:     max(page, 1),
+		PageSize: pageSize,
+	}
+
+	// Get the contract data for all of the node's minipools
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	contractAddresses := cfg.Smartnode.GetStateManagerContracts()
+	contracts, err := rpstate.NewNetworkContracts(rp, contractAddresses.Multicaller, contractAddresses.BalanceBatcher, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating network contract binding: %w", err)
+	}
+	allDetails, err := rpstate.GetNodeNativeMinipoolDetails(rp, contracts, nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool details: %w", err)
+	}
+
+	// Select the page
+	mpds := []*rpstate.NativeMinipoolDetails{}
+	for i := range allDetails {
+		if allDetails[i].Finalised && !includeFinalised {
+			response.HiddenCount++
+			continue
+		}
+		mpds = append(mpds, &allDetails[i])
+	}
+	response.TotalCount = len(mpds)
+	if pageSize > 0 {
+		start := min((response.Page-1)*pageSize, uint64(len(mpds)))
+		end := min(start+pageSize, uint64(len(mpds)))
+		mpds = mpds[start:end]
+	}
+
+	// Get the page's validators, the current epoch, and the latest delegate
+	var wg errgroup.Group
+	var validators map[types.ValidatorPubkey]beacon.ValidatorStatus
+	var currentEpoch uint64
+	wg.Go(func() error {
+		var err error
+		validators, err = getCachedIndexValidatorStatuses(bc, cfg.Smartnode.GetValidatorIndexCachePath(true), mpds)
+		return err
+	})
+	wg.Go(func() error {
+		head, err := bc.GetBeaconHead()
+		if err == nil {
+			currentEpoch = head.Epoch
+		}
+		return err
+	})
+	wg.Go(func() error {
+		delegate, err := rp.GetContract("rocketMinipoolDelegate", nil)
+		if err != nil {
+			return fmt.Errorf("Error getting latest minipool delegate contract: %w", err)
+		}
+		response.LatestDelegate = *delegate.Address
+		return nil
+	})
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Get the node's share of the validators' balances
+	beaconBalances := make([]*big.Int, len(mpds))
+	for i, mpd := range mpds {
+		beaconBalances[i] = big.NewInt(0)
+		if validator := validators[mpd.Pubkey]; validator.Exists && validator.ActivationEpoch < currentEpoch {
+			beaconBalances[i] = eth.GweiToWei(float64(validator.Balance))
+		}
+	}
+	err = rpstate.CalculateCompleteMinipoolShares(rp, contracts, mpds, beaconBalances)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build the details
+	details := make([]api.MinipoolDetails, len(mpds))
+	for i, mpd := range mpds {
+		details[i] = getBulkMinipoolDetails(mpd, validators[mpd.Pubkey], currentEpoch)
+	}
+	if err := setQueuePositions(rp, details); err != nil {
+		return nil, err
+	}
+	if err := setMinipoolActionAvailability(rp, details); err != nil {
+		return nil, err
+	}
+	response.Minipools = details
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the validator statuses for the provided minipools, looking up the ones with cached indices by index and caching the
+// indices of any new ones
+func getCachedIndexValidatorStatuses(bc beacon.Client, cachePath string, mpds []*rpstate.NativeMinipoolDetails) (map[types.ValidatorPubkey]beacon.ValidatorStatus, error) {
+
+	// Sort the pubkeys by whether their index is cached
+	cache := loadValidatorIndexCache(cachePath)
+	indices := []string{}
+	pubkeys := []types.ValidatorPubkey{}
+	emptyPubkey := types.ValidatorPubkey{}
+	for _, mpd := range mpds {
+		if mpd.Pubkey == emptyPubkey {
+			continue
+		}
+		if index, exists := cache[mpd.Pubkey.Hex()]; exists {
+			indices = append(indices, index)
+		} else {
+			pubkeys = append(pubkeys, mpd.Pubkey)
+		}
+	}
+
+	// Look up the cached ones by index; if any of them don't match (e.g. the cache is from another network), fall back
+	// to their pubkeys
+	statuses := map[types.ValidatorPubkey]beacon.ValidatorStatus{}
+	if len(indices) > 0 {
+		indexStatuses, err := bc.GetValidatorStatusesByIndex(indices, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, status := range indexStatuses {
+			if cache[status.Pubkey.Hex()] == status.Index {
+				statuses[status.Pubkey] = status
+			}
+		}
+		for _, mpd := range mpds {
+			if _, exists := cache[mpd.Pubkey.Hex()]; exists && !statuses[mpd.Pubkey].Exists {
+				delete(cache, mpd.Pubkey.Hex())
+				pubkeys = append(pubkeys, mpd.Pubkey)
+			}
+		}
+	}
+
+	// Look up the rest by pubkey and cache their indices
+	if len(pubkeys) > 0 {
+		pubkeyStatuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+		if err != nil {
+			return nil, err
+		}
+		for pubkey, status := range pubkeyStatuses {
+			statuses[pubkey] = status
+			if status.Exists {
+				cache[pubkey.Hex()] = status.Index
+			}
+		}
+
+		// The cache is only an optimization, so failing to save it isn't fatal
+		_ = saveValidatorIndexCache(cachePath, cache)
+	}
+	return statuses, nil
+
+}
+
+// Load the validator index cache, which maps validator pubkeys to their indices; a missing or corrupt cache is empty
+func loadValidatorIndexCache(path string) map[string]string {
+	cache := map[string]string{}
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(bytes, &cache); err != nil {
+		return map[string]string{}
+	}
+	return cache
+}
+
+// Save the validator index cache
+func saveValidatorIndexCache(path string, cache map[string]string) error {
+	bytes, err := json.Marshal(cache)
+	if err != nil {
+		return fmt.Errorf("error serializing validator index cache: %w", err)
+	}
+	err = os.WriteFile(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing validator index cache %s: %w", path, err)
+	}
+	return nil
+}
+
+// Convert a minipool's multicall details to the API format. The minipool's token balances other than ETH aren't loaded.
+func getBulkMinipoolDetails(mpd *rpstate.NativeMinipoolDetails, validator beacon.ValidatorStatus, currentEpoch uint64) api.MinipoolDetails {
+
+	details := api.MinipoolDetails{
+		Address:         mpd.MinipoolAddress,
+		ValidatorPubkey: mpd.Pubkey,
+		Status: minipool.StatusDetails{
+			Status:      mpd.Status,
+			StatusBlock: mpd.StatusBlock.Uint64(),
+			StatusTime:  time.Unix(mpd.StatusTime.Int64(), 0),
+			IsVacant:    mpd.IsVacant,
+		},
+		DepositType: mpd.DepositType,
+		Node: minipool.NodeDetails{
+			Address:         mpd.NodeAddress,
+			Fee:             eth.WeiToEth(mpd.NodeFee),
+			DepositBalance:  mpd.NodeDepositBalance,
+			RefundBalance:   mpd.NodeRefundBalance,
+			DepositAssigned: mpd.NodeDepositAssigned,
+		},
+		User: minipool.UserDetails{
+			DepositBalance:      mpd.UserDepositBalance,
+			DepositAssigned:     mpd.UserDepositAssigned,
+			DepositAssignedTime: time.Unix(mpd.UserDepositAssignedTime.Int64(), 0),
+		},
+		Balances: tokens.Balances{
+			ETH:            mpd.Balance,
+			RETH:           big.NewInt(0),
+			RPL:            big.NewInt(0),
+			FixedSupplyRPL: big.NewInt(0),
+		},
+		NodeShareOfETHBalance: mpd.NodeShareOfBalance,
+		Finalised:             mpd.Finalised,
+		UseLatestDelegate:     mpd.UseLatestDelegate,
+		Delegate:              mpd.Delegate,
+		PreviousDelegate:      mpd.PreviousDelegate,
+		EffectiveDelegate:     mpd.EffectiveDelegate,
+		Penalties:             mpd.PenaltyCount.Uint64(),
+		ReduceBondTime:        time.Unix(mpd.ReduceBondTime.Int64(), 0),
+		ReduceBondCancelled:   mpd.ReduceBondCancelled,
+	}
+
+	// Get validator details if staking
+	if details.Status.Status == types.Staking || (details.Status.Status == types.Dissolved && !details.Finalised) {
+		details.Validator = api.ValidatorDetails{
+			Balance:     big.NewInt(0).Add(mpd.NodeDepositBalance, mpd.UserDepositBalance),
+			NodeBalance: big.NewInt(0).Set(mpd.NodeDepositBalance),
+		}
+		if validator.Exists {
+			details.Validator.Exists = true
+			details.Validator.Active = (validator.ActivationEpoch < currentEpoch && validator.ExitEpoch > currentEpoch)
+			details.Validator.Index = validator.Index
+			if validator.ActivationEpoch < currentEpoch {
+				details.Validator.Balance = eth.GweiToWei(float64(validator.Balance))
+				details.Validator.NodeBalance = mpd.NodeShareOfBeaconBalance
+			}
+		}
+	}
+
+	// Update & return
+	details.RefundAvailable = (details.Node.RefundBalance.Cmp(big.NewInt(0)) > 0) && (details.Balances.ETH.Cmp(details.Node.RefundBalance) >= 0)
+	details.CloseAvailable = (details.Status.Status == types.Dissolved)
+	details.WithdrawalAvailable = (details.Status.Status == types.Withdrawable)
+	return details
+
+}
+
+// Get the queue positions of the minipools that are still in the queue
+func setQueuePositions(rp *rocketpool.RocketPool, details []api.MinipoolDetails) error {
+	var wg errgroup.Group
+	wg.SetLimit(MinipoolDetailsBatchSize)
+	for i := range details {
+		if details[i].Status.Status != types.Initialized {
+			continue
+		}
+		i := i
+		wg.Go(func() error {
+			var err error
+			details[i].Queue, err = minipool.GetQueueDetails(rp, details[i].Address, nil)
+			return err
+		})
+	}
+	return wg.Wait()
+}
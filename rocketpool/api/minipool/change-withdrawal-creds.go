@@ -6,6 +6,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/urfave/cli"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
@@ -133,8 +134,17 @@ func canChangeWithdrawalCreds(c *cli.Context, minipoolAddress common.Address, mn
 		return nil, fmt.Errorf("withdrawal credentials mismatch for minipool %s (pubkey %s): should be %s but matching index %d provided %s", minipoolAddress.Hex(), pubkey.Hex(), beaconStatus.WithdrawalCredentials.Hex(), index, withdrawalPubkeyHash.Hex())
 	}
 
+	// Make sure the minipool is the right target for the new credentials
+	newCreds, err := validateWithdrawalCredsTarget(rp, minipoolAddress, pubkey)
+	if err != nil {
+		return nil, err
+	}
+
 	// Update & return response
 	response.CanChange = true
+	response.ValidatorIndex = beaconStatus.Index
+	response.CurrentCredentials = beaconStatus.WithdrawalCredentials
+	response.NewCredentials = newCreds
 	return &response, nil
 
 }
@@ -166,6 +176,11 @@ func changeWithdrawalCreds(c *cli.Context, minipoolAddress common.Address, mnemo
 		return nil, err
 	}
 
+	// Make sure the minipool is the right target for the new credentials before signing anything
+	if _, err := validateWithdrawalCredsTarget(rp, minipoolAddress, pubkey); err != nil {
+		return nil, err
+	}
+
 	// Get the index for this validator based on the mnemonic
 	index := uint(0)
 	validatorKeyPath := validator.ValidatorKeyPath
@@ -225,3 +240,28 @@ func changeWithdrawalCreds(c *cli.Context, minipoolAddress common.Address, mnemo
 	// Return response
 	return &response, nil
 }
+
+// Check that a minipool is the right target for its validator's new withdrawal credentials: Rocket Pool must have it
+// registered for the validator's pubkey, and must expect 0x01 credentials pointing to its address.
+// Returns the new credentials.
+func validateWithdrawalCredsTarget(rp *rocketpool.RocketPool, minipoolAddress common.Address, pubkey types.ValidatorPubkey) (common.Hash, error) {
+	registeredAddress, err := minipool.GetMinipoolByPubkey(rp, pubkey, nil)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error getting the minipool for pubkey %s: %w", pubkey.Hex(), err)
+	}
+	if registeredAddress != minipoolAddress {
+		return common.Hash{}, fmt.Errorf("pubkey %s belongs to minipool %s, not %s", pubkey.Hex(), registeredAddress.Hex(), minipoolAddress.Hex())
+	}
+
+	newCreds := common.Hash{}
+	newCreds[0] = 0x01
+	copy(newCreds[12:], minipoolAddress[:])
+	expectedCreds, err := minipool.GetMinipoolWithdrawalCredentials(rp, minipoolAddress, nil)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error getting the expected withdrawal credentials for minipool %s: %w", minipoolAddress.Hex(), err)
+	}
+	if expectedCreds != newCreds {
+		return common.Hash{}, fmt.Errorf("minipool %s expects withdrawal credentials %s, not %s", minipoolAddress.Hex(), expectedCreds.Hex(), newCreds.Hex())
+	}
+	return newCreds, nil
+}
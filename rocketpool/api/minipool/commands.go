@@ -33,6 +33,35 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "bulk-status",
+				Usage:     "Get one page of the node's minipools, using batched queries",
+				UsageText: "rocketpool api minipool bulk-status include-finalized page page-size",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					includeFinalised, err := cliutils.ValidateBool("include-finalized", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					page, err := cliutils.ValidateUint("page", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					pageSize, err := cliutils.ValidateUint("page-size", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getBulkStatus(c, includeFinalised, page, pageSize))
+					return nil
+
+				},
+			},
 
 			{
 				Name:      "can-stake",
@@ -314,6 +343,40 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "verify-status",
+				Usage:     "Cross-check the node's minipool statuses against their validators on the Beacon Chain",
+				UsageText: "rocketpool api minipool verify-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(verifyStatus(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "delegate-status",
+				Usage:     "Get the delegate contract status of the node's minipools",
+				UsageText: "rocketpool api minipool delegate-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getDelegateStatus(c))
+					return nil
+
+				},
+			},
 			{
 				Name:      "delegate-upgrade",
 				Usage:     "Upgrade this minipool to the latest network delegate contract",
@@ -653,6 +716,23 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "get-housekeeping-plan",
+				Usage:     "Find the node's refunds and balances worth claiming, ranked by their value after gas at current prices",
+				UsageText: "rocketpool api minipool get-housekeeping-plan",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getHousekeepingPlan(c))
+					return nil
+
+				},
+			},
 			{
 				Name:      "distribute-balance",
 				Usage:     "Distribute a minipool's ETH balance",
@@ -752,6 +832,64 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "get-bls-credentialed",
+				Usage:     "Find the node's minipools whose validators still have 0x00 (BLS) withdrawal credentials",
+				UsageText: "rocketpool api minipool get-bls-credentialed",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getBlsCredentialedMinipools(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-sweep-forecast",
+				Usage:     "Forecast when the Beacon Chain's withdrawal sweep will next reach each of the node's validators, and how much it will withdraw",
+				UsageText: "rocketpool api minipool get-sweep-forecast",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getMinipoolSweepForecast(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-exit-projection",
+				Usage:     "Project when minipools' validators would exit and be fully withdrawn if they were exited now, based on the Beacon Chain's exit queue",
+				UsageText: "rocketpool api minipool get-exit-projection minipool-addresses",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					minipoolAddresses, err := cliutils.ValidateAddresses("minipool addresses", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getMinipoolExitProjection(c, minipoolAddresses))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "get-rescue-dissolved-details-for-node",
 				Usage:     "Check all of the node's minipools for rescue eligibility, and return the details of the rescuable ones",
@@ -0,0 +1,127 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+func getDelegateStatus(c *cli.Context) (*api.DelegateStatusResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.DelegateStatusResponse{}
+
+	// Get the latest delegate
+	latestDelegate, err := rp.GetAddress("rocketMinipoolDelegate", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest minipool delegate: %w", err)
+	}
+	response.LatestDelegate = *latestDelegate
+	response.LatestVersion, err = rocketpool.GetContractVersion(rp, *latestDelegate, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest minipool delegate version: %w", err)
+	}
+
+	// Get the critical delegates; failing to get them shouldn't prevent reporting on the rest
+	criticalDelegates, err := rputils.GetCriticalDelegates(cfg.Smartnode.CriticalDelegatesUrl.Value.(string))
+	if err != nil {
+		response.CriticalDelegatesErr = err.Error()
+	}
+	response.CriticalDelegatesUsed = (cfg.Smartnode.CriticalDelegatesUrl.Value.(string) != "")
+
+	// Get the node's minipools
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the delegate details of each one
+	statuses := make([]api.MinipoolDelegateStatus, len(addresses))
+	var wg errgroup.Group
+	wg.SetLimit(16)
+	for i, address := range addresses {
+		i, address := i, address
+		wg.Go(func() error {
+			mp, err := minipool.NewMinipool(rp, address, nil)
+			if err != nil {
+				return err
+			}
+			status := api.MinipoolDelegateStatus{
+				Address: address,
+			}
+			status.Delegate, err = mp.GetDelegate(nil)
+			if err != nil {
+				return fmt.Errorf("error getting delegate of minipool %s: %w", address.Hex(), err)
+			}
+			status.UseLatestDelegate, err = mp.GetUseLatestDelegate(nil)
+			if err != nil {
+				return fmt.Errorf("error getting use-latest-delegate setting of minipool %s: %w", address.Hex(), err)
+			}
+			status.Finalised, err = mp.GetFinalised(nil)
+			if err != nil {
+				return fmt.Errorf("error getting finalised status of minipool %s: %w", address.Hex(), err)
+			}
+			statuses[i] = status
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Get the version of each delegate in use
+	versions := map[common.Address]uint8{
+		*latestDelegate: response.LatestVersion,
+	}
+	for i := range statuses {
+		status := &statuses[i]
+		version, exists := versions[status.Delegate]
+		if !exists {
+			version, err = rocketpool.GetContractVersion(rp, status.Delegate, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error getting version of delegate %s: %w", status.Delegate.Hex(), err)
+			}
+			versions[status.Delegate] = version
+		}
+		status.DelegateVersion = version
+		status.Outdated = (status.Delegate != *latestDelegate && !status.UseLatestDelegate)
+		if critical, exists := criticalDelegates[status.Delegate]; exists && status.Outdated {
+			status.Critical = true
+			status.CriticalReason = critical.Reason
+		}
+	}
+	response.Minipools = statuses
+
+	// Return response
+	return &response, nil
+
+}
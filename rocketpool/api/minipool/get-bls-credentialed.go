@@ -0,0 +1,91 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getBlsCredentialedMinipools(c *cli.Context) (*api.GetBlsCredentialedMinipoolsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GetBlsCredentialedMinipoolsResponse{
+		Minipools: []api.BlsCredentialedMinipool{},
+	}
+
+	// Get the node's minipools and their pubkeys
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	addresses, pubkeys, err := getNodeMinipoolPubkeys(rp, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the validators that still have 0x00 (BLS) withdrawal credentials
+	statuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validator statuses: %w", err)
+	}
+	for i, address := range addresses {
+		status, exists := statuses[pubkeys[i]]
+		if !exists || !status.Exists || status.WithdrawalCredentials[0] != 0x00 {
+			continue
+		}
+
+		mp, err := minipool.NewMinipool(rp, address, nil)
+		if err != nil {
+			return nil, err
+		}
+		details := api.BlsCredentialedMinipool{
+			Address:               address,
+			Pubkey:                pubkeys[i],
+			ValidatorIndex:        status.Index,
+			BeaconState:           status.Status,
+			WithdrawalCredentials: status.WithdrawalCredentials,
+		}
+		if mpv3, success := minipool.GetMinipoolAsV3(mp); success {
+			statusDetails, err := mpv3.GetStatusDetails(nil)
+			if err != nil {
+				return nil, fmt.Errorf("error getting status details for minipool %s: %w", address.Hex(), err)
+			}
+			details.MinipoolStatus = statusDetails.Status
+			details.IsVacant = statusDetails.IsVacant
+		} else {
+			details.MinipoolStatus, err = mp.GetStatus(nil)
+			if err != nil {
+				return nil, fmt.Errorf("error getting status of minipool %s: %w", address.Hex(), err)
+			}
+		}
+		response.Minipools = append(response.Minipools, details)
+	}
+
+	// Return response
+	return &response, nil
+
+}
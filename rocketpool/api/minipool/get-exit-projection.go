@@ -0,0 +1,97 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/exitqueue"
+	"github.com/rocket-pool/smartnode/shared/services/sweep"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getMinipoolExitProjection(c *cli.Context, minipoolAddresses []common.Address) (*api.GetMinipoolExitProjectionResponse, error) {
+
+	// Get services
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GetMinipoolExitProjectionResponse{
+		Minipools: []api.MinipoolExitProjection{},
+	}
+
+	// Get the validators
+	pubkeys := make([]types.ValidatorPubkey, len(minipoolAddresses))
+	for i, address := range minipoolAddresses {
+		pubkey, err := minipool.GetMinipoolPubkey(rp, address, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting pubkey for minipool %s: %w", address.Hex(), err)
+		}
+		pubkeys[i] = pubkey
+	}
+	statuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validator statuses: %w", err)
+	}
+	validators := make([]beacon.ValidatorStatus, len(minipoolAddresses))
+	for i, address := range minipoolAddresses {
+		status, exists := statuses[pubkeys[i]]
+		if !exists || !status.Exists {
+			return nil, fmt.Errorf("minipool %s doesn't have a validator on the Beacon Chain", address.Hex())
+		}
+		validators[i] = status
+	}
+
+	// Measure the exit queue and the sweep
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, err
+	}
+	queue, err := exitqueue.Measure(bc)
+	if err != nil {
+		return nil, fmt.Errorf("error measuring the exit queue: %w", err)
+	}
+	response.Queue = queue
+	progress, err := sweep.Measure(bc, eth2Config.EpochToSlot(queue.Epoch), sweep.DefaultSampleSlots)
+	if err != nil {
+		return nil, fmt.Errorf("error measuring the withdrawal sweep: %w", err)
+	}
+
+	// Project each validator's exit
+	projections, err := queue.ProjectValidators(validators, progress, eth2Config.SlotsPerEpoch)
+	if err != nil {
+		return nil, err
+	}
+	for i, projection := range projections {
+		response.Minipools = append(response.Minipools, api.MinipoolExitProjection{
+			Address:           minipoolAddresses[i],
+			ValidatorIndex:    validators[i].Index,
+			Initiated:         projection.Initiated,
+			ExitEpoch:         projection.ExitEpoch,
+			ExitTime:          eth2Config.GetSlotTime(eth2Config.EpochToSlot(projection.ExitEpoch)),
+			WithdrawableEpoch: projection.WithdrawableEpoch,
+			WithdrawableTime:  eth2Config.GetSlotTime(eth2Config.EpochToSlot(projection.WithdrawableEpoch)),
+			WithdrawalSlot:    projection.WithdrawalSlot,
+			WithdrawalTime:    eth2Config.GetSlotTime(projection.WithdrawalSlot),
+		})
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,103 @@
+package minipool
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/sweep"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getMinipoolSweepForecast(c *cli.Context) (*api.GetMinipoolSweepForecastResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GetMinipoolSweepForecastResponse{
+		Minipools: []api.MinipoolSweepForecast{},
+	}
+
+	// Measure the sweep
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, err
+	}
+	head, exists, err := bc.GetBeaconBlockHeader("head")
+	if err != nil {
+		return nil, fmt.Errorf("error getting head Beacon block: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("the Beacon Node doesn't have a head block")
+	}
+	progress, err := sweep.Measure(bc, head.Slot, sweep.DefaultSampleSlots)
+	if err != nil {
+		return nil, fmt.Errorf("error measuring the withdrawal sweep: %w", err)
+	}
+	response.Sweep = progress
+	response.CycleTime = time.Duration(progress.GetCycleSlots()*eth2Config.SecondsPerSlot) * time.Second
+
+	// Get the node's validators
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	addresses, pubkeys, err := getNodeMinipoolPubkeys(rp, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validator statuses: %w", err)
+	}
+
+	// Forecast each one's next sweep
+	for i, address := range addresses {
+		status, exists := statuses[pubkeys[i]]
+		if !exists || !status.Exists || status.Status == beacon.ValidatorState_WithdrawalDone {
+			continue
+		}
+		forecast, err := progress.ForecastValidator(status, head.Slot)
+		if err != nil {
+			return nil, fmt.Errorf("error forecasting the sweep for minipool %s: %w", address.Hex(), err)
+		}
+		response.Minipools = append(response.Minipools, api.MinipoolSweepForecast{
+			Address:         address,
+			ValidatorIndex:  status.Index,
+			NextSweepSlot:   forecast.Slot,
+			NextSweepTime:   time.Unix(int64(eth2Config.GenesisTime+forecast.Slot*eth2Config.SecondsPerSlot), 0),
+			FullWithdrawal:  forecast.Full,
+			ProjectedAmount: new(big.Int).Mul(new(big.Int).SetUint64(forecast.AmountGwei), big.NewInt(1e9)),
+		})
+	}
+	sort.Slice(response.Minipools, func(i, j int) bool {
+		return response.Minipools[i].NextSweepSlot < response.Minipools[j].NextSweepSlot
+	})
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,230 @@
+package minipool
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getHousekeepingPlan(c *cli.Context) (*api.GetHousekeepingPlanResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GetHousekeepingPlanResponse{
+		Actions:      []api.HousekeepingAction{},
+		Unprofitable: []api.HousekeepingAction{},
+	}
+
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, fmt.Errorf("error getting node account: %w", err)
+	}
+
+	// Get the price a transaction would pay right now
+	header, err := rp.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the latest block: %w", err)
+	}
+	tip, err := rp.Client.SuggestGasTipCap(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error getting the suggested priority fee: %w", err)
+	}
+	response.GasPrice = big.NewInt(0).Add(header.BaseFee, tip)
+
+	// Minipools with distributable balances; distributing also pays out their refunds
+	actions := []api.HousekeepingAction{}
+	distributeDetails, err := getDistributeBalanceDetails(c)
+	if err != nil {
+		return nil, err
+	}
+	refundCandidates := []api.MinipoolBalanceDistributionDetails{}
+	for _, details := range distributeDetails.Details {
+		if !details.CanDistribute {
+			refundCandidates = append(refundCandidates, details)
+			continue
+		}
+		value := details.Balance
+		if details.Status == types.Staking {
+			value = big.NewInt(0).Add(details.NodeShareOfBalance, details.Refund)
+		}
+		actions = append(actions, api.HousekeepingAction{
+			Kind:    api.HousekeepingAction_DistributeBalance,
+			Address: details.Address,
+			Value:   value,
+			GasInfo: details.GasInfo,
+		})
+	}
+
+	// Minipools that can't be distributed but still have refunds to claim
+	refunds, err := getRefundActions(rp, w, refundCandidates)
+	if err != nil {
+		return nil, err
+	}
+	actions = append(actions, refunds...)
+
+	// The fee distributor's balance
+	feeAction, err := getDistributeFeesAction(rp, w, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	if feeAction != nil {
+		actions = append(actions, *feeAction)
+	}
+
+	// Rank the actions by what they're worth after gas, keeping the ones that cost more than they return separate
+	for _, action := range actions {
+		action.GasCost = big.NewInt(0).Mul(response.GasPrice, big.NewInt(0).SetUint64(action.GasInfo.EstGasLimit))
+		action.NetValue = big.NewInt(0).Sub(action.Value, action.GasCost)
+		if action.NetValue.Sign() > 0 {
+			response.Actions = append(response.Actions, action)
+		} else {
+			response.Unprofitable = append(response.Unprofitable, action)
+		}
+	}
+	sort.SliceStable(response.Actions, func(i, j int) bool {
+		return response.Actions[i].NetValue.Cmp(response.Actions[j].NetValue) > 0
+	})
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the refund actions for minipools that have refunds and enough balance to pay them
+func getRefundActions(rp *rocketpool.RocketPool, w *wallet.Wallet, candidates []api.MinipoolBalanceDistributionDetails) ([]api.HousekeepingAction, error) {
+	refunds := make([]*api.HousekeepingAction, len(candidates))
+	for bsi := 0; bsi < len(candidates); bsi += MinipoolDetailsBatchSize {
+
+		// Get batch start & end index
+		msi := bsi
+		mei := bsi + MinipoolDetailsBatchSize
+		if mei > len(candidates) {
+			mei = len(candidates)
+		}
+
+		// Load details
+		var wg errgroup.Group
+		for mi := msi; mi < mei; mi++ {
+			mi := mi
+			wg.Go(func() error {
+				address := candidates[mi].Address
+				mp, err := minipool.NewMinipool(rp, address, nil)
+				if err != nil {
+					return fmt.Errorf("error creating binding for minipool %s: %w", address.Hex(), err)
+				}
+				refund, err := mp.GetNodeRefundBalance(nil)
+				if err != nil {
+					return fmt.Errorf("error getting refund balance of minipool %s: %w", address.Hex(), err)
+				}
+				if refund.Sign() == 0 {
+					return nil
+				}
+				balance, err := rp.Client.BalanceAt(context.Background(), address, nil)
+				if err != nil {
+					return fmt.Errorf("error getting balance of minipool %s: %w", address.Hex(), err)
+				}
+				if balance.Cmp(refund) < 0 {
+					return nil
+				}
+
+				opts, err := w.GetNodeAccountTransactor()
+				if err != nil {
+					return err
+				}
+				gasInfo, err := mp.EstimateRefundGas(opts)
+				if err != nil {
+					return fmt.Errorf("error estimating gas to refund minipool %s: %w", address.Hex(), err)
+				}
+				refunds[mi] = &api.HousekeepingAction{
+					Kind:    api.HousekeepingAction_Refund,
+					Address: address,
+					Value:   refund,
+					GasInfo: gasInfo,
+				}
+				return nil
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return nil, err
+		}
+
+	}
+
+	actions := []api.HousekeepingAction{}
+	for _, refund := range refunds {
+		if refund != nil {
+			actions = append(actions, *refund)
+		}
+	}
+	return actions, nil
+}
+
+// Get the action to distribute the node's fee distributor, or nil if it has nothing to distribute
+func getDistributeFeesAction(rp *rocketpool.RocketPool, w *wallet.Wallet, nodeAddress common.Address) (*api.HousekeepingAction, error) {
+	initialized, err := node.GetFeeDistributorInitialized(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if the fee distributor is initialized: %w", err)
+	}
+	if !initialized {
+		return nil, nil
+	}
+	distributorAddress, err := node.GetDistributorAddress(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := rp.Client.BalanceAt(context.Background(), distributorAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting fee distributor balance: %w", err)
+	}
+	if balance.Sign() == 0 {
+		return nil, nil
+	}
+
+	distributor, err := node.NewDistributor(rp, distributorAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	nodeShare, err := distributor.GetNodeShare(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting node share for distributor %s: %w", distributorAddress.Hex(), err)
+	}
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := distributor.EstimateDistributeGas(opts)
+	if err != nil {
+		return nil, fmt.Errorf("error estimating gas to distribute fee distributor %s: %w", distributorAddress.Hex(), err)
+	}
+	return &api.HousekeepingAction{
+		Kind:    api.HousekeepingAction_DistributeFees,
+		Address: distributorAddress,
+		Value:   nodeShare,
+		GasInfo: gasInfo,
+	}, nil
+}
@@ -37,6 +37,43 @@ func validateMinipoolOwner(mp minipool.Minipool, nodeAddress common.Address) err
 	return nil
 }
 
+// Get the addresses of a node's minipools and their validator pubkeys
+func getNodeMinipoolPubkeys(rp *rocketpool.RocketPool, nodeAddress common.Address) ([]common.Address, []types.ValidatorPubkey, error) {
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting minipool addresses: %w", err)
+	}
+	pubkeys := make([]types.ValidatorPubkey, len(addresses))
+	for bsi := 0; bsi < len(addresses); bsi += MinipoolDetailsBatchSize {
+
+		// Get batch start & end index
+		msi := bsi
+		mei := bsi + MinipoolDetailsBatchSize
+		if mei > len(addresses) {
+			mei = len(addresses)
+		}
+
+		// Load pubkeys
+		var wg errgroup.Group
+		for mi := msi; mi < mei; mi++ {
+			mi := mi
+			wg.Go(func() error {
+				pubkey, err := minipool.GetMinipoolPubkey(rp, addresses[mi], nil)
+				if err != nil {
+					return fmt.Errorf("error getting pubkey for minipool %s: %w", addresses[mi].Hex(), err)
+				}
+				pubkeys[mi] = pubkey
+				return nil
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return nil, nil, err
+		}
+
+	}
+	return addresses, pubkeys, nil
+}
+
 // Get all node minipool details
 func GetNodeMinipoolDetails(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address, legacyMinipoolQueueAddress *common.Address) ([]api.MinipoolDetails, error) {
 
@@ -121,23 +158,36 @@ func GetNodeMinipoolDetails(rp *rocketpool.RocketPool, bc beacon.Client, nodeAdd
 
 	}
 
+	// Check which actions are available
+	if err := setMinipoolActionAvailability(rp, details); err != nil {
+		return nil, err
+	}
+
+	// Return
+	return details, nil
+
+}
+
+// Check whether prelaunch minipools can be staked and vacant minipools can be promoted yet, and when they'll be dissolved
+func setMinipoolActionAvailability(rp *rocketpool.RocketPool, details []api.MinipoolDetails) error {
+
 	// Get the scrub period
 	scrubPeriodSeconds, err := trustednode.GetScrubPeriod(rp, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	scrubPeriod := time.Duration(scrubPeriodSeconds) * time.Second
 
 	// Get the dissolve timeout
 	timeout, err := protocol.GetMinipoolLaunchTimeout(rp, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Get the time of the latest block
 	latestEth1Block, err := rp.Client.HeaderByNumber(context.Background(), nil)
 	if err != nil {
-		return nil, fmt.Errorf("Can't get the latest block time: %w", err)
+		return fmt.Errorf("Can't get the latest block time: %w", err)
 	}
 	latestBlockTime := time.Unix(int64(latestEth1Block.Time), 0)
 
@@ -157,7 +207,7 @@ func GetNodeMinipoolDetails(rp *rocketpool.RocketPool, bc beacon.Client, nodeAdd
 	// Get the promotion scrub period
 	promotionScrubPeriodSeconds, err := trustednode.GetPromotionScrubPeriod(rp, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	promotionScrubPeriod := time.Duration(promotionScrubPeriodSeconds) * time.Second
 
@@ -174,8 +224,7 @@ func GetNodeMinipoolDetails(rp *rocketpool.RocketPool, bc beacon.Client, nodeAdd
 		}
 	}
 
-	// Return
-	return details, nil
+	return nil
 
 }
 
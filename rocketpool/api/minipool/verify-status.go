@@ -0,0 +1,215 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+func verifyStatus(c *cli.Context) (*api.VerifyMinipoolStatusResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.VerifyMinipoolStatusResponse{}
+
+	// Get the node's minipools
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the CL view of the minipools
+	validators, err := rputils.GetMinipoolValidators(rp, bc, addresses, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the EL view of the minipools
+	reconciliations := make([]api.MinipoolStatusReconciliation, len(addresses))
+	for bsi := 0; bsi < len(addresses); bsi += MinipoolDetailsBatchSize {
+
+		// Get batch start & end index
+		msi := bsi
+		mei := bsi + MinipoolDetailsBatchSize
+		if mei > len(addresses) {
+			mei = len(addresses)
+		}
+
+		// Load details
+		var wg errgroup.Group
+		for mi := msi; mi < mei; mi++ {
+			mi := mi
+			wg.Go(func() error {
+				address := addresses[mi]
+				mp, err := minipool.NewMinipool(rp, address, nil)
+				if err != nil {
+					return err
+				}
+				status, err := mp.GetStatus(nil)
+				if err != nil {
+					return fmt.Errorf("error getting status of minipool %s: %w", address.Hex(), err)
+				}
+				finalised, err := mp.GetFinalised(nil)
+				if err != nil {
+					return fmt.Errorf("error getting finalised status of minipool %s: %w", address.Hex(), err)
+				}
+				validator := validators[address]
+				reconciliations[mi] = api.MinipoolStatusReconciliation{
+					Address:         address,
+					ValidatorPubkey: validator.Pubkey,
+					ElStatus:        status,
+					Finalised:       finalised,
+					ClExists:        validator.Exists,
+					ClStatus:        validator.Status,
+					Issues:          reconcileMinipoolStatus(status, finalised, validator),
+				}
+				return nil
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return nil, err
+		}
+
+	}
+	response.Minipools = reconciliations
+
+	// Return response
+	return &response, nil
+
+}
+
+// Cross-check a minipool's EL status against its validator's CL status, returning any inconsistencies found
+func reconcileMinipoolStatus(status types.MinipoolStatus, finalised bool, validator beacon.ValidatorStatus) []api.MinipoolStatusIssue {
+
+	issues := []api.MinipoolStatusIssue{}
+	exited := false
+	withdrawn := false
+	active := false
+	slashed := false
+	switch validator.Status {
+	case beacon.ValidatorState_ActiveOngoing, beacon.ValidatorState_ActiveExiting:
+		active = true
+	case beacon.ValidatorState_ActiveSlashed:
+		active = true
+		slashed = true
+	case beacon.ValidatorState_ExitedUnslashed, beacon.ValidatorState_WithdrawalPossible:
+		exited = true
+	case beacon.ValidatorState_ExitedSlashed:
+		exited = true
+		slashed = true
+	case beacon.ValidatorState_WithdrawalDone:
+		exited = true
+		withdrawn = true
+	}
+
+	// Slashings always need attention
+	if slashed {
+		issues = append(issues, api.MinipoolStatusIssue{
+			Description: "The validator has been slashed on the Beacon Chain.",
+			Action:      "Make sure the validator keys are not loaded in more than one validator client, and check your validator client logs.",
+			Critical:    true,
+		})
+	}
+
+	// Finalised minipools are closed out on the EL, so the validator should be fully withdrawn
+	if finalised {
+		if validator.Exists && !withdrawn {
+			issues = append(issues, api.MinipoolStatusIssue{
+				Description: fmt.Sprintf("The minipool is finalised but its validator is still %s on the Beacon Chain.", validator.Status),
+				Action:      "Exit the validator with `rocketpool minipool exit` so its remaining balance can be withdrawn.",
+				Critical:    true,
+			})
+		}
+		return issues
+	}
+
+	switch status {
+	case types.Initialized, types.Prelaunch:
+		if exited {
+			issues = append(issues, api.MinipoolStatusIssue{
+				Description: fmt.Sprintf("The minipool is still in %s but its validator has already exited (%s).", status, validator.Status),
+				Action:      "Wait for the minipool to be dissolved, then close it with `rocketpool minipool close`.",
+				Critical:    true,
+			})
+		}
+
+	case types.Staking:
+		if !validator.Exists {
+			issues = append(issues, api.MinipoolStatusIssue{
+				Description: "The minipool is staking but its validator was not found on the Beacon Chain.",
+				Action:      "Make sure your Beacon Node is fully synced; if it is, the validator deposit may still be processing.",
+				Critical:    false,
+			})
+		} else if withdrawn {
+			issues = append(issues, api.MinipoolStatusIssue{
+				Description: "The validator has been fully withdrawn but the minipool is still staking.",
+				Action:      "Distribute the minipool's balance and finalise it with `rocketpool minipool distribute-balance` or `rocketpool minipool close`.",
+				Critical:    false,
+			})
+		} else if exited {
+			issues = append(issues, api.MinipoolStatusIssue{
+				Description: fmt.Sprintf("The validator has exited (%s) but its balance has not been fully withdrawn yet.", validator.Status),
+				Action:      "Wait for the Beacon Chain to sweep the balance to the minipool, then close it with `rocketpool minipool close`.",
+				Critical:    false,
+			})
+		}
+
+	case types.Withdrawable:
+		if active {
+			issues = append(issues, api.MinipoolStatusIssue{
+				Description: "The minipool is withdrawable but its validator is still active on the Beacon Chain.",
+				Action:      "Exit the validator with `rocketpool minipool exit`.",
+				Critical:    true,
+			})
+		}
+
+	case types.Dissolved:
+		if active {
+			issues = append(issues, api.MinipoolStatusIssue{
+				Description: "The minipool has been dissolved but its validator is active on the Beacon Chain.",
+				Action:      "Exit the validator with `rocketpool minipool exit`, then close the minipool with `rocketpool minipool close`.",
+				Critical:    true,
+			})
+		} else if validator.Exists {
+			issues = append(issues, api.MinipoolStatusIssue{
+				Description: "The minipool has been dissolved but has not been closed.",
+				Action:      "Close the minipool with `rocketpool minipool close` to recover its balance.",
+				Critical:    false,
+			})
+		}
+	}
+
+	return issues
+
+}
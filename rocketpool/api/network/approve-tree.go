@@ -0,0 +1,74 @@
+package network
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func canApproveRewardsTree(c *cli.Context, interval uint64) (*api.CanApproveRewardsTreeResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanApproveRewardsTreeResponse{}
+
+	// Load the report
+	reportPath := cfg.Smartnode.GetConsistencyGateReportPath(interval, true)
+	if _, err := os.Stat(reportPath); os.IsNotExist(err) {
+		return &response, nil
+	}
+	response.Report, err = rewards.LoadConsistencyGateReport(reportPath)
+	if err != nil {
+		return nil, err
+	}
+	response.ReportExists = true
+
+	// Check if it's already been approved
+	response.Approved, err = rewards.IsConsistencyGateOverridden(cfg.Smartnode.GetConsistencyGateOverridePath(interval, true), response.Report.MerkleRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func approveRewardsTree(c *cli.Context, interval uint64) (*api.ApproveRewardsTreeResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ApproveRewardsTreeResponse{}
+
+	// Approve the root in the report, so a tree that's regenerated afterwards has to pass or be approved again
+	report, err := rewards.LoadConsistencyGateReport(cfg.Smartnode.GetConsistencyGateReportPath(interval, true))
+	if err != nil {
+		return nil, err
+	}
+	if report.Passed {
+		return nil, fmt.Errorf("the rewards tree for interval %d passed the consistency gate, so it doesn't need to be approved", interval)
+	}
+	err = rewards.SaveConsistencyGateOverride(cfg.Smartnode.GetConsistencyGateOverridePath(interval, true), report.MerkleRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -117,11 +117,11 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 			{
 				Name:      "generate-rewards-tree",
 				Usage:     "Set a request marker for the watchtower to generate the rewards tree for the given interval",
-				UsageText: "rocketpool api network generate-rewards-tree index",
+				UsageText: "rocketpool api network generate-rewards-tree index memory-budget",
 				Action: func(c *cli.Context) error {
 
 					// Validate args
-					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
 						return err
 					}
 
@@ -129,9 +129,13 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					if err != nil {
 						return err
 					}
+					memoryBudget, err := cliutils.ValidateUint("memory-budget", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
 
 					// Run
-					api.PrintResponse(generateRewardsTree(c, index))
+					api.PrintResponse(generateRewardsTree(c, index, memoryBudget))
 					return nil
 
 				},
@@ -179,6 +183,259 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "minipool-performance",
+				Aliases:   []string{"mp"},
+				Usage:     "Get a page of the minipool performance file for the given interval, optionally filtered by node, pubkey, or missed attestation count",
+				UsageText: "rocketpool api network minipool-performance [options] interval",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "node",
+						Usage: "Only include minipools belonging to this node",
+					},
+					cli.StringFlag{
+						Name:  "pubkey",
+						Usage: "Only include the minipool with this validator pubkey",
+					},
+					cli.StringFlag{
+						Name:  "min-missed",
+						Usage: "Only include minipools that missed at least this many attestations",
+					},
+					cli.StringFlag{
+						Name:  "max-missed",
+						Usage: "Only include minipools that missed at most this many attestations",
+					},
+					cli.Uint64Flag{
+						Name:  "offset",
+						Usage: "The number of matching minipools to skip",
+					},
+					cli.Uint64Flag{
+						Name:  "limit",
+						Usage: "The maximum number of minipools to return",
+						Value: defaultMinipoolPerformancePageSize,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					interval, err := cliutils.ValidateUint("interval", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					query, err := getMinipoolPerformanceQuery(c)
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getMinipoolPerformancePage(c, interval, query))
+					return nil
+
+				},
+			},
+			{
+				Name:      "export-rewards-csv",
+				Usage:     "Export the rewards file and minipool performance file for the given interval as CSV files",
+				UsageText: "rocketpool api network export-rewards-csv interval",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					interval, err := cliutils.ValidateUint("interval", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(exportRewardsCSV(c, interval))
+					return nil
+
+				},
+			},
+			{
+				Name:      "state",
+				Usage:     "Get a snapshot of the full network state at a Beacon slot, optionally limited to some of its fields",
+				UsageText: "rocketpool api network state [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "slot",
+						Usage: "The Beacon slot to get the state for (defaults to the head slot)",
+					},
+					cli.StringFlag{
+						Name:  "fields",
+						Usage: "A comma-separated list of the fields to include: network, nodes, minipools, validators, odao, proposals (defaults to all of them)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					fields, err := getNetworkStateFields(c.String("fields"))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getNetworkState(c, c.Uint64("slot"), fields))
+					return nil
+
+				},
+			},
+			{
+				Name:      "totals-history",
+				Usage:     "Get the network totals recorded over the given number of days",
+				UsageText: "rocketpool api network totals-history days",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					days, err := cliutils.ValidateUint("days", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getNetworkTotalsHistory(c, days))
+					return nil
+
+				},
+			},
+			{
+				Name:      "intervals",
+				Usage:     "Get every rewards interval's on-chain submission and whether the local rewards tree file matches it, optionally downloading the files that don't",
+				UsageText: "rocketpool api network intervals download-missing",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					downloadMissing, err := cliutils.ValidateBool("download-missing", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getIntervals(c, downloadMissing))
+					return nil
+
+				},
+			},
+			{
+				Name:      "can-approve-rewards-tree",
+				Usage:     "Get the consistency gate report for the watchtower's rewards tree for an interval",
+				UsageText: "rocketpool api network can-approve-rewards-tree interval",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					interval, err := cliutils.ValidateUint("interval", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canApproveRewardsTree(c, interval))
+					return nil
+
+				},
+			},
+			{
+				Name:      "approve-rewards-tree",
+				Usage:     "Approve submitting the watchtower's rewards tree for an interval despite it failing the consistency gate",
+				UsageText: "rocketpool api network approve-rewards-tree interval",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					interval, err := cliutils.ValidateUint("interval", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(approveRewardsTree(c, interval))
+					return nil
+
+				},
+			},
+			{
+				Name:      "verify-attestation",
+				Usage:     "Verify the signer quorum's attestation over an interval's artifact manifest",
+				UsageText: "rocketpool api network verify-attestation interval",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					interval, err := cliutils.ValidateUint("interval", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(verifyAttestation(c, interval))
+					return nil
+
+				},
+			},
+			{
+				Name:      "verify-rewards-tree",
+				Usage:     "Regenerate an interval's rewards tree from its snapshot and compare it with the published tree and the on-chain Merkle root",
+				UsageText: "rocketpool api network verify-rewards-tree interval",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					interval, err := cliutils.ValidateUint("interval", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(verifyRewardsTree(c, interval))
+					return nil
+
+				},
+			},
+			{
+				Name:      "smoothing-pool-stats",
+				Usage:     "Get the network's smoothing pool membership and the node's expected share of the pool, with the registration changes made over the given number of days",
+				UsageText: "rocketpool api network smoothing-pool-stats days",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					days, err := cliutils.ValidateUint("days", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getSmoothingPoolStats(c, days))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "is-houston-hotfix-deployed",
 				Aliases:   []string{"ihhd"},
@@ -15,7 +15,6 @@ import (
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/urfave/cli"
-	"github.com/wealdtech/go-ens/v3"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -171,14 +170,9 @@ func getActiveDAOProposals(c *cli.Context) (*api.NetworkDAOProposalsResponse, er
 }
 
 func formatResolvedAddress(c *cli.Context, address common.Address) string {
-	rp, err := services.GetRocketPool(c)
-	if err != nil {
-		return address.Hex()
-	}
-
-	name, err := ens.ReverseResolve(rp.Client, address)
+	resolver, err := services.GetEnsResolver(c)
 	if err != nil {
 		return address.Hex()
 	}
-	return fmt.Sprintf("%s (%s)", name, address.Hex())
+	return resolver.FormatAddress(address)
 }
@@ -0,0 +1,95 @@
+package network
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func exportRewardsCSV(c *cli.Context, interval uint64) (*api.ExportRewardsCSVResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ExportRewardsCSVResponse{
+		Index: interval,
+	}
+
+	// Load the rewards file, preferring the SSZ copy and falling back to the JSON one for older intervals
+	var rewardsFile *rewards.LocalRewardsFile
+	sszPath := cfg.Smartnode.GetRewardsTreePath(interval, true, config.RewardsExtensionSSZ)
+	jsonPath := cfg.Smartnode.GetRewardsTreePath(interval, true, config.RewardsExtensionJSON)
+	if _, err := os.Stat(sszPath); err == nil {
+		rewardsFile, err = rewards.ReadLocalSSZRewardsFile(sszPath)
+		if err != nil {
+			return nil, err
+		}
+	} else if _, err := os.Stat(jsonPath); err == nil {
+		rewardsFile, err = rewards.ReadLocalRewardsFile(jsonPath)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, fmt.Errorf("the rewards file for interval %d is not on this machine; download it with `rocketpool network intervals --download` or generate it with `rocketpool network generate-rewards-tree` first", interval)
+	}
+
+	// Export it
+	err = writeCSVFile(cfg.Smartnode.GetRewardsTreePath(interval, true, config.RewardsExtensionCSV), func(w io.Writer) error {
+		return rewards.WriteRewardsCSV(w, rewardsFile.Impl())
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.RewardsPath = cfg.Smartnode.GetRewardsTreePath(interval, false, config.RewardsExtensionCSV)
+	response.NodeCount = len(rewardsFile.Impl().GetNodeAddresses())
+
+	// Export the minipool performance file if it's here; it isn't part of the published tree, so it may not be
+	performancePath := cfg.Smartnode.GetMinipoolPerformancePath(interval, true)
+	if _, err := os.Stat(performancePath); os.IsNotExist(err) {
+		response.MinipoolPerformanceMissing = true
+		return &response, nil
+	}
+	performanceFile, err := rewards.ReadLocalMinipoolPerformanceFile(performancePath)
+	if err != nil {
+		return nil, err
+	}
+	err = writeCSVFile(cfg.Smartnode.GetMinipoolPerformanceCsvPath(interval, true), func(w io.Writer) error {
+		return rewards.WriteMinipoolPerformanceCSV(w, interval, performanceFile.Impl())
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.MinipoolPerformancePath = cfg.Smartnode.GetMinipoolPerformanceCsvPath(interval, false)
+	response.MinipoolCount = len(performanceFile.Impl().GetMinipoolAddresses())
+
+	// Return response
+	return &response, nil
+
+}
+
+// Create a CSV file and write its contents
+func writeCSVFile(path string, write func(w io.Writer) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", path, err)
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error saving %s: %w", path, err)
+	}
+	return nil
+}
@@ -51,7 +51,7 @@ func canGenerateRewardsTree(c *cli.Context, index uint64) (*api.CanNetworkGenera
 
 }
 
-func generateRewardsTree(c *cli.Context, index uint64) (*api.NetworkGenerateRewardsTreeResponse, error) {
+func generateRewardsTree(c *cli.Context, index uint64, memoryBudget uint64) (*api.NetworkGenerateRewardsTreeResponse, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -66,12 +66,20 @@ func generateRewardsTree(c *cli.Context, index uint64) (*api.NetworkGenerateRewa
 	requestPath := cfg.Smartnode.GetRegenerateRewardsTreeRequestPath(index, true)
 	requestFile, err := os.Create(requestPath)
 	if requestFile != nil {
-		requestFile.Close()
+		defer requestFile.Close()
 	}
 	if err != nil {
 		return nil, fmt.Errorf("Error creating request marker: %w", err)
 	}
 
+	// Override the configured memory budget if one was provided
+	if memoryBudget > 0 {
+		_, err = fmt.Fprint(requestFile, memoryBudget)
+		if err != nil {
+			return nil, fmt.Errorf("Error writing memory budget to request marker: %w", err)
+		}
+	}
+
 	return &response, nil
 
 }
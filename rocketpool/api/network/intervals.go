@@ -0,0 +1,76 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getIntervals(c *cli.Context, downloadMissing bool) (*api.NetworkIntervalsResponse, error) {
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkIntervalsResponse{}
+
+	// Bring the interval registry up to date
+	currentIndex, err := rp.GetRewardIndex(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting current reward index: %w", err)
+	}
+	registryPath := cfg.Smartnode.GetIntervalRegistryPath(true)
+	registry, err := rewards.LoadIntervalRegistry(registryPath)
+	if err != nil {
+		return nil, err
+	}
+	added, err := registry.Update(rp, cfg, currentIndex.Uint64())
+	if err != nil {
+		return nil, fmt.Errorf("error updating interval registry: %w", err)
+	}
+	if added > 0 {
+		if err := rewards.SaveIntervalRegistry(registryPath, registry); err != nil {
+			return nil, err
+		}
+	}
+
+	// Check each interval's rewards tree file
+	for _, submission := range registry.GetSubmissions() {
+		status := api.NetworkIntervalStatus{
+			IntervalSubmission: submission,
+		}
+		status.Consistency, err = submission.CheckLocalFile(cfg, true)
+		if err != nil {
+			status.Detail = err.Error()
+		}
+		if downloadMissing && status.Consistency != rewards.IntervalConsistency_Consistent {
+			intervalInfo := submission.GetIntervalInfo()
+			if err := intervalInfo.DownloadRewardsFile(cfg, true); err != nil {
+				status.Detail = fmt.Sprintf("download failed: %s", err.Error())
+			} else {
+				status.Consistency = rewards.IntervalConsistency_Consistent
+				status.Detail = ""
+				status.Downloaded = true
+			}
+		}
+		response.Intervals = append(response.Intervals, status)
+	}
+
+	// Return response
+	return &response, nil
+
+}
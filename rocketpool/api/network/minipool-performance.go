@@ -0,0 +1,139 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+const (
+	defaultMinipoolPerformancePageSize uint64 = 100
+	maxMinipoolPerformancePageSize     uint64 = 1000
+)
+
+// Build a performance query from the command's flags
+func getMinipoolPerformanceQuery(c *cli.Context) (rewards.MinipoolPerformanceQuery, error) {
+	query := rewards.MinipoolPerformanceQuery{
+		Offset: c.Uint64("offset"),
+		Limit:  c.Uint64("limit"),
+	}
+	if query.Limit == 0 || query.Limit > maxMinipoolPerformancePageSize {
+		return query, fmt.Errorf("Invalid limit '%d' - must be between 1 and %d", query.Limit, maxMinipoolPerformancePageSize)
+	}
+	if c.String("node") != "" {
+		node, err := cliutils.ValidateAddress("node", c.String("node"))
+		if err != nil {
+			return query, err
+		}
+		query.Node = &node
+	}
+	if c.String("pubkey") != "" {
+		pubkey, err := cliutils.ValidatePubkey("pubkey", c.String("pubkey"))
+		if err != nil {
+			return query, err
+		}
+		query.Pubkey = pubkey.Hex()
+	}
+	if c.String("min-missed") != "" {
+		minMissed, err := cliutils.ValidateUint("min-missed", c.String("min-missed"))
+		if err != nil {
+			return query, err
+		}
+		query.MinMissed = &minMissed
+	}
+	if c.String("max-missed") != "" {
+		maxMissed, err := cliutils.ValidateUint("max-missed", c.String("max-missed"))
+		if err != nil {
+			return query, err
+		}
+		query.MaxMissed = &maxMissed
+	}
+	return query, nil
+}
+
+func getMinipoolPerformancePage(c *cli.Context, interval uint64, query rewards.MinipoolPerformanceQuery) (*api.MinipoolPerformancePageResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolPerformancePageResponse{
+		Index:  interval,
+		Offset: query.Offset,
+		Limit:  query.Limit,
+	}
+
+	// Load the performance file
+	performancePath := cfg.Smartnode.GetMinipoolPerformancePath(interval, true)
+	_, err = os.Stat(performancePath)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("the minipool performance file for interval %d is not on this machine; generate it with `rocketpool network generate-rewards-tree` first", interval)
+	}
+	performanceFile, err := rewards.ReadLocalMinipoolPerformanceFile(performancePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the index, building it if this file was saved before indices existed or was downloaded
+	indexPath := cfg.Smartnode.GetMinipoolPerformanceIndexPath(interval, true)
+	index, err := rewards.LoadMinipoolPerformanceIndex(indexPath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		index = rewards.NewMinipoolPerformanceIndex(interval, performanceFile.Impl(), nil)
+		_ = index.Save(indexPath)
+	}
+
+	// An index built without the minipool owners needs the node's minipools looked up to answer node queries
+	if query.Node != nil && !index.HasNodes() {
+		rp, err := services.GetRocketPool(c)
+		if err != nil {
+			return nil, err
+		}
+		minipools, err := minipool.GetNodeMinipoolAddresses(rp, *query.Node, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting minipools for node %s: %w", query.Node.Hex(), err)
+		}
+		index.SetNode(*query.Node, minipools)
+	}
+
+	// Run the query and fill in the performance details for the page
+	entries, total := index.Query(query)
+	response.Total = total
+	response.Minipools = make([]api.MinipoolPerformanceEntry, 0, len(entries))
+	for _, entry := range entries {
+		performance, exists := performanceFile.Impl().GetSmoothingPoolPerformance(entry.Minipool)
+		if !exists {
+			continue
+		}
+		response.Minipools = append(response.Minipools, api.MinipoolPerformanceEntry{
+			Minipool:                entry.Minipool,
+			Node:                    entry.Node,
+			Pubkey:                  entry.Pubkey,
+			SuccessfulAttestations:  performance.GetSuccessfulAttestationCount(),
+			MissedAttestations:      performance.GetMissedAttestationCount(),
+			MissingAttestationSlots: performance.GetMissingAttestationSlots(),
+			AttestationScore:        performance.GetAttestationScore(),
+			EthEarned:               performance.GetEthEarned(),
+			BonusEthEarned:          performance.GetBonusEthEarned(),
+			ConsensusIncome:         performance.GetConsensusIncome(),
+			AverageInclusionDelay:   performance.GetAverageInclusionDelay(),
+		})
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,76 @@
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getSmoothingPoolStats(c *cli.Context, days uint64) (*api.SmoothingPoolStatsResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SmoothingPoolStatsResponse{
+		HistoryEnabled: cfg.Smartnode.RecordNetworkTotals.Value.(bool),
+	}
+
+	// Get the current membership from the network state
+	m := state.NewNetworkStateManager(rp, cfg.Smartnode.GetStateManagerContracts(), bc, nil)
+	networkState, err := m.GetHeadState()
+	if err != nil {
+		return nil, fmt.Errorf("error getting network state: %w", err)
+	}
+	since := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+	response.SmoothingPoolBalance = networkState.NetworkDetails.SmoothingPoolBalance
+	response.Membership = state.GetSmoothingPoolMembership(networkState, since)
+
+	// Get the membership over time from the recorded network totals, skipping samples from before it was recorded
+	samples, err := state.LoadNetworkTotals(cfg.Smartnode.GetNetworkTotalsPath(), since)
+	if err != nil {
+		return nil, err
+	}
+	response.History = []state.NetworkTotalsSample{}
+	for _, sample := range samples {
+		if sample.SmoothingPoolNodes > 0 {
+			response.History = append(response.History, sample)
+		}
+	}
+
+	// Get the node's share if it's registered
+	if w.IsInitialized() {
+		nodeAccount, err := w.GetNodeAccount()
+		if err != nil {
+			return nil, err
+		}
+		_, response.IsNodeRegistered = networkState.NodeDetailsByAddress[nodeAccount.Address]
+		if response.IsNodeRegistered {
+			response.NodeShare = state.GetNodeSmoothingPoolShare(networkState, nodeAccount.Address, response.Membership)
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,115 @@
+package network
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The sections of the network state that can be requested
+const (
+	networkStateField_Network    string = "network"
+	networkStateField_Nodes      string = "nodes"
+	networkStateField_Minipools  string = "minipools"
+	networkStateField_Validators string = "validators"
+	networkStateField_Odao       string = "odao"
+	networkStateField_Proposals  string = "proposals"
+)
+
+var networkStateFields = []string{
+	networkStateField_Network,
+	networkStateField_Nodes,
+	networkStateField_Minipools,
+	networkStateField_Validators,
+	networkStateField_Odao,
+	networkStateField_Proposals,
+}
+
+// Parse a comma-separated list of network state fields; a blank list selects all of them
+func getNetworkStateFields(fieldsString string) (map[string]bool, error) {
+	fields := map[string]bool{}
+	if strings.TrimSpace(fieldsString) == "" {
+		for _, field := range networkStateFields {
+			fields[field] = true
+		}
+		return fields, nil
+	}
+
+	for _, element := range strings.Split(fieldsString, ",") {
+		field := strings.ToLower(strings.TrimSpace(element))
+		valid := false
+		for _, knownField := range networkStateFields {
+			if field == knownField {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("Invalid field '%s' - must be one of: %s", element, strings.Join(networkStateFields, ", "))
+		}
+		fields[field] = true
+	}
+	return fields, nil
+}
+
+func getNetworkState(c *cli.Context, slot uint64, fields map[string]bool) (*api.NetworkStateResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the state at the requested slot, or the head if one wasn't provided
+	m := state.NewNetworkStateManager(rp, cfg.Smartnode.GetStateManagerContracts(), bc, nil)
+	var networkState *state.NetworkState
+	if slot == 0 {
+		networkState, err = m.GetHeadState()
+	} else {
+		networkState, err = m.GetStateForSlot(slot)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting network state: %w", err)
+	}
+
+	// Response
+	response := api.NetworkStateResponse{
+		ElBlockNumber:    networkState.ElBlockNumber,
+		BeaconSlotNumber: networkState.BeaconSlotNumber,
+	}
+	if fields[networkStateField_Network] {
+		response.NetworkDetails = networkState.NetworkDetails
+	}
+	if fields[networkStateField_Nodes] {
+		response.NodeDetails = networkState.NodeDetails
+	}
+	if fields[networkStateField_Minipools] {
+		response.MinipoolDetails = networkState.MinipoolDetails
+	}
+	if fields[networkStateField_Validators] {
+		response.ValidatorDetails = networkState.ValidatorDetails
+	}
+	if fields[networkStateField_Odao] {
+		response.OracleDaoMemberDetails = networkState.OracleDaoMemberDetails
+	}
+	if fields[networkStateField_Proposals] {
+		response.ProtocolDaoProposalDetails = networkState.ProtocolDaoProposalDetails
+	}
+
+	// Return response
+	return &response, nil
+
+}
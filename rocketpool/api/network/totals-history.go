@@ -0,0 +1,37 @@
+package network
+
+import (
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getNetworkTotalsHistory(c *cli.Context, days uint64) (*api.NetworkTotalsHistoryResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkTotalsHistoryResponse{
+		Enabled: cfg.Smartnode.RecordNetworkTotals.Value.(bool),
+	}
+
+	// Load the samples
+	since := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+	samples, err := state.LoadNetworkTotals(cfg.Smartnode.GetNetworkTotalsPath(), since)
+	if err != nil {
+		return nil, err
+	}
+	response.Samples = samples
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,54 @@
+package network
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func verifyAttestation(c *cli.Context, interval uint64) (*api.VerifyAttestationResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.VerifyAttestationResponse{}
+
+	// Load the manifest and its attestation
+	metadata, err := rewards.LoadSubmissionMetadata(cfg.Smartnode.GetRewardsSubmissionPath(interval, true))
+	if err != nil {
+		return nil, err
+	}
+	attestation, err := rewards.LoadAttestation(cfg.Smartnode.GetRewardsAttestationPath(interval, true))
+	if err != nil {
+		return nil, err
+	}
+	response.ManifestHash, err = metadata.GetManifestHash()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check it against the configured quorum if there is one, otherwise against the quorum it claims
+	response.Signers = cfg.Smartnode.GetAttestationSigners()
+	response.Threshold = cfg.Smartnode.AttestationThreshold.Value.(uint64)
+	response.SignersPinned = len(response.Signers) > 0 && response.Threshold > 0
+	if !response.SignersPinned {
+		response.Signers = attestation.Signers
+		response.Threshold = attestation.Threshold
+	}
+	response.ValidSigners, err = attestation.Verify(metadata, response.Signers, response.Threshold)
+	if err != nil {
+		response.Problem = err.Error()
+	} else {
+		response.Valid = true
+	}
+
+	// Return response
+	return &response, nil
+
+}
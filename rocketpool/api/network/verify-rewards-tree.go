@@ -0,0 +1,120 @@
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+func verifyRewardsTree(c *cli.Context, interval uint64) (*api.VerifyRewardsTreeResponse, error) {
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.VerifyRewardsTreeResponse{
+		Index: interval,
+	}
+
+	// Make sure the interval has been submitted
+	currentIndex, err := rp.GetRewardIndex(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the current rewards interval: %w", err)
+	}
+	if interval >= currentIndex.Uint64() {
+		return nil, fmt.Errorf("interval %d hasn't been submitted yet; the current interval is %d", interval, currentIndex.Uint64())
+	}
+
+	// Get the on-chain submission and download the published tree, which has to match its root
+	submission, err := rprewards.GetIntervalSubmission(rp, cfg, interval)
+	if err != nil {
+		return nil, err
+	}
+	response.Submitter = submission.Submitter
+	response.TxHash = submission.TxHash
+	response.OnChainRoot = submission.MerkleRoot
+	info := submission.GetIntervalInfo()
+	published, err := info.FetchRewardsFile(cfg, true)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading the published rewards tree for interval %d: %w", interval, err)
+	}
+
+	// Get the snapshot the tree was generated from
+	rewardsClient := rprewards.NewRewardsExecutionClient(rp)
+	rewardsEvent, err := rewardsClient.GetRewardSnapshotEvent(cfg.Smartnode.GetPreviousRewardsPoolAddresses(), interval, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting event for interval %d: %w", interval, err)
+	}
+	response.ConsensusBlock = rewardsEvent.ConsensusBlock.Uint64()
+	response.ExecutionBlock = rewardsEvent.ExecutionBlock.Uint64()
+	elBlockHeader, err := rp.Client.HeaderByNumber(context.Background(), rewardsEvent.ExecutionBlock)
+	if err != nil {
+		return nil, fmt.Errorf("error getting execution block %d: %w", response.ExecutionBlock, err)
+	}
+
+	// Load the network state at the snapshot, using the archive EC if the primary one has pruned it
+	logger := log.NewColorLogger(NormalLogger)
+	generationPrefix := fmt.Sprintf("[Interval %d Verification]", interval)
+	client, err := eth1.GetBestApiClient(rp, cfg, func(message string) {
+		logger.Printlnf("%s %s", generationPrefix, message)
+	}, elBlockHeader.Number)
+	if err != nil {
+		return nil, err
+	}
+	stateManager := state.NewNetworkStateManager(client, cfg.Smartnode.GetStateManagerContracts(), bc, &logger)
+	networkState, err := stateManager.GetStateForSlot(response.ConsensusBlock)
+	if err != nil {
+		return nil, fmt.Errorf("error getting state for beacon slot %d: %w", response.ConsensusBlock, err)
+	}
+
+	// Regenerate the tree
+	snapshotEnd := &rprewards.SnapshotEnd{
+		ConsensusBlock: response.ConsensusBlock,
+		ExecutionBlock: response.ExecutionBlock,
+		Slot:           networkState.BeaconConfig.FirstSlotAtLeast(rewardsEvent.IntervalEndTime.Unix()),
+	}
+	treegen, err := rprewards.NewTreeGenerator(&logger, generationPrefix, rprewards.NewRewardsExecutionClient(client), cfg, bc, interval, rewardsEvent.IntervalStartTime, rewardsEvent.IntervalEndTime, snapshotEnd, elBlockHeader, rewardsEvent.IntervalsPassed.Uint64(), networkState)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Merkle tree generator: %w", err)
+	}
+	response.RulesetVersion = treegen.GetGeneratorRulesetVersion()
+	treeResult, err := treegen.GenerateTree()
+	if err != nil {
+		return nil, fmt.Errorf("error generating Merkle tree: %w", err)
+	}
+
+	// Compare it with the published tree
+	response.LocalRoot = common.HexToHash(treeResult.RewardsFile.GetMerkleRoot())
+	response.Matches = response.LocalRoot == response.OnChainRoot
+	response.Report = rprewards.DiffRewardsFiles(treeResult.RewardsFile, published)
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,212 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/l2"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func canClaimL2Rewards(c *cli.Context, network uint64, indicesString string) (*api.CanNodeClaimL2RewardsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanNodeClaimL2RewardsResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Connect to the network's distributor
+	distributor, err := getL2Distributor(cfg, network)
+	if err != nil {
+		return nil, err
+	}
+	defer distributor.Close()
+	response.NetworkName = distributor.Network.Name
+	response.ChainID = distributor.Network.ChainID
+
+	// Get the rewards
+	indices, amountRPL, amountETH, merkleProofs, err := getRewardsForIntervalsOnNetwork(rp, cfg, nodeAccount.Address, indicesString, network)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure each interval has been relayed to the network and hasn't been claimed there yet
+	for _, index := range indices {
+		root, err := distributor.GetMerkleRoot(index.Uint64(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if root == (common.Hash{}) {
+			response.UnrelayedIntervals = append(response.UnrelayedIntervals, index.Uint64())
+			continue
+		}
+		claimed, err := distributor.IsClaimed(index.Uint64(), nodeAccount.Address, nil)
+		if err != nil {
+			return nil, err
+		}
+		if claimed {
+			response.ClaimedIntervals = append(response.ClaimedIntervals, index.Uint64())
+		}
+	}
+	response.CanClaim = len(response.UnrelayedIntervals) == 0 && len(response.ClaimedIntervals) == 0
+	if !response.CanClaim {
+		return &response, nil
+	}
+
+	// Get gas estimate
+	opts, err := w.GetNodeAccountTransactorForChain(distributor.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := distributor.EstimateClaimGas(nodeAccount.Address, indices, amountRPL, amountETH, merkleProofs, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo = gasInfo
+	return &response, nil
+
+}
+
+func claimL2Rewards(c *cli.Context, network uint64, indicesString string) (*api.NodeClaimL2RewardsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeClaimL2RewardsResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Connect to the network's distributor
+	distributor, err := getL2Distributor(cfg, network)
+	if err != nil {
+		return nil, err
+	}
+	defer distributor.Close()
+	response.NetworkName = distributor.Network.Name
+
+	// Get the rewards
+	indices, amountRPL, amountETH, merkleProofs, err := getRewardsForIntervalsOnNetwork(rp, cfg, nodeAccount.Address, indicesString, network)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get transactor; the network's own client sets the fees and nonce
+	opts, err := w.GetNodeAccountTransactorForChain(distributor.ChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Claim rewards
+	hash, err := distributor.Claim(nodeAccount.Address, indices, amountRPL, amountETH, merkleProofs, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Wait for it here, since the CLI can only watch transactions on Ethereum
+	tx, _, err := distributor.Client().TransactionByHash(context.Background(), hash)
+	if err != nil {
+		return nil, fmt.Errorf("error getting claim transaction %s on %s: %w", hash.Hex(), distributor.Network.Name, err)
+	}
+	receipt, err := bind.WaitMined(context.Background(), distributor.Client(), tx)
+	if err != nil {
+		return nil, fmt.Errorf("error waiting for claim transaction %s on %s: %w", hash.Hex(), distributor.Network.Name, err)
+	}
+	response.Succeeded = receipt.Status == 1
+
+	// Return response
+	return &response, nil
+
+}
+
+// Connect to the rewards distributor of a configured Layer 2 network
+func getL2Distributor(cfg *config.RocketPoolConfig, network uint64) (*l2.Distributor, error) {
+	networks, err := l2.Load(cfg.Smartnode.GetRewardNetworksPath(true))
+	if err != nil {
+		return nil, err
+	}
+	settings := networks.Get(network)
+	if settings == nil {
+		return nil, fmt.Errorf("reward network %d isn't configured; add it to %s to claim on it", network, config.RewardNetworksFilename)
+	}
+	return l2.NewDistributor(settings)
+}
+
+// Remove the intervals that have already been claimed on their Layer 2 networks. Intervals on networks that aren't
+// configured or can't be reached are kept, since their status is unknown.
+func filterClaimedL2Intervals(networks *l2.Config, nodeAddress common.Address, intervals []rprewards.IntervalInfo) []rprewards.IntervalInfo {
+	distributors := map[uint64]*l2.Distributor{}
+	defer func() {
+		for _, distributor := range distributors {
+			if distributor != nil {
+				distributor.Close()
+			}
+		}
+	}()
+
+	unclaimed := []rprewards.IntervalInfo{}
+	for _, intervalInfo := range intervals {
+		distributor, exists := distributors[intervalInfo.RewardNetwork]
+		if !exists {
+			if settings := networks.Get(intervalInfo.RewardNetwork); settings != nil {
+				distributor, _ = l2.NewDistributor(settings)
+			}
+			distributors[intervalInfo.RewardNetwork] = distributor
+		}
+		if distributor != nil {
+			claimed, err := distributor.IsClaimed(intervalInfo.Index, nodeAddress, nil)
+			if err == nil && claimed {
+				continue
+			}
+		}
+		unclaimed = append(unclaimed, intervalInfo)
+	}
+	return unclaimed
+}
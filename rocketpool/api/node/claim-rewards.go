@@ -1,6 +1,7 @@
 package node
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"strconv"
@@ -18,6 +19,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/l2"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
@@ -80,8 +82,41 @@ func getRewardsInfo(c *cli.Context) (*api.NodeGetRewardsInfoResponse, error) {
 			response.InvalidIntervals = append(response.InvalidIntervals, intervalInfo)
 			continue
 		}
-		if intervalInfo.NodeExists {
-			response.UnclaimedIntervals = append(response.UnclaimedIntervals, intervalInfo)
+		if !intervalInfo.NodeExists {
+			continue
+		}
+		if intervalInfo.RewardNetwork != l2.MainnetRewardNetwork {
+			// Rewards routed to a Layer 2 network are claimed there, so Ethereum's claim status doesn't cover them
+			response.L2UnclaimedIntervals = append(response.L2UnclaimedIntervals, intervalInfo)
+			continue
+		}
+		response.UnclaimedIntervals = append(response.UnclaimedIntervals, intervalInfo)
+	}
+
+	// Drop the Layer 2 intervals that have already been claimed on their networks
+	networks, err := l2.Load(cfg.Smartnode.GetRewardNetworksPath(true))
+	if err != nil {
+		return nil, err
+	}
+	response.RewardNetworkNames = networks.GetNames()
+	if len(response.L2UnclaimedIntervals) > 0 {
+		response.L2UnclaimedIntervals = filterClaimedL2Intervals(networks, nodeAccount.Address, response.L2UnclaimedIntervals)
+		for _, intervalInfo := range response.L2UnclaimedIntervals {
+			response.RewardNetworkNames[intervalInfo.RewardNetwork] = networks.GetName(intervalInfo.RewardNetwork)
+		}
+	}
+
+	// Get the addresses the rewards will be sent to
+	response.ClaimRouting, err = getClaimRouting(rp, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	// Screen the claims with the compliance hook, if one is configured
+	if len(response.UnclaimedIntervals) > 0 {
+		response.ClaimRouting.ComplianceFlags, err = screenClaims(cfg, response.ClaimRouting, response.UnclaimedIntervals)
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -382,6 +417,11 @@ func claimAndStakeRewards(c *cli.Context, indicesString string, stakeAmount *big
 
 // Get the rewards for the provided interval indices
 func getRewardsForIntervals(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, nodeAddress common.Address, indicesString string) ([]*big.Int, []*big.Int, []*big.Int, [][]common.Hash, error) {
+	return getRewardsForIntervalsOnNetwork(rp, cfg, nodeAddress, indicesString, l2.MainnetRewardNetwork)
+}
+
+// Get the rewards for the provided interval indices, which must all have been routed to the provided reward network
+func getRewardsForIntervalsOnNetwork(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, nodeAddress common.Address, indicesString string, network uint64) ([]*big.Int, []*big.Int, []*big.Int, [][]common.Hash, error) {
 
 	// Get the indices
 	seenIndices := map[uint64]bool{}
@@ -423,6 +463,13 @@ func getRewardsForIntervals(rp *rocketpool.RocketPool, cfg *config.RocketPoolCon
 
 		// Get the rewards from it
 		if intervalInfo.NodeExists {
+			if intervalInfo.RewardNetwork != network {
+				if intervalInfo.RewardNetwork == l2.MainnetRewardNetwork {
+					return nil, nil, nil, nil, fmt.Errorf("the rewards for interval %d were paid out on Ethereum; claim them with `rocketpool node claim-rewards`", index.Uint64())
+				}
+				return nil, nil, nil, nil, fmt.Errorf("the rewards for interval %d were routed to reward network %d; claim them there with `rocketpool node claim-l2-rewards`", index.Uint64(), intervalInfo.RewardNetwork)
+			}
+
 			rplForInterval := big.NewInt(0)
 			rplForInterval.Add(rplForInterval, &intervalInfo.CollateralRplAmount.Int)
 			rplForInterval.Add(rplForInterval, &intervalInfo.ODaoRplAmount.Int)
@@ -440,3 +487,92 @@ func getRewardsForIntervals(rp *rocketpool.RocketPool, cfg *config.RocketPoolCon
 	return indices, amountRPL, amountETH, merkleProofs, nil
 
 }
+
+// Get the addresses that the node's claimed rewards will be sent to
+func getClaimRouting(rp *rocketpool.RocketPool, nodeAddress common.Address) (api.RewardsClaimRouting, error) {
+	details, err := node.GetNodeDetails(rp, nodeAddress, true, nil)
+	if err != nil {
+		return api.RewardsClaimRouting{}, fmt.Errorf("error getting node details: %w", err)
+	}
+
+	// RPL goes to the RPL withdrawal address if it's set, otherwise to the primary withdrawal address
+	routing := api.RewardsClaimRouting{
+		NodeAddress:               nodeAddress,
+		EthRecipient:              details.PrimaryWithdrawalAddress,
+		RplRecipient:              details.PrimaryWithdrawalAddress,
+		IsRplWithdrawalAddressSet: details.IsRPLWithdrawalAddressSet,
+		PendingEthRecipient:       details.PendingPrimaryWithdrawalAddress,
+		PendingRplRecipient:       details.PendingRPLWithdrawalAddress,
+	}
+	if details.IsRPLWithdrawalAddressSet {
+		routing.RplRecipient = details.RPLWithdrawalAddress
+	}
+
+	// Flag recipients that are contracts
+	routing.EthRecipientIsContract, err = isContract(rp, routing.EthRecipient)
+	if err != nil {
+		return api.RewardsClaimRouting{}, err
+	}
+	routing.RplRecipientIsContract, err = isContract(rp, routing.RplRecipient)
+	if err != nil {
+		return api.RewardsClaimRouting{}, err
+	}
+	return routing, nil
+}
+
+// Screen the recipients of the node's unclaimed rewards with the compliance hook
+func screenClaims(cfg *config.RocketPoolConfig, routing api.RewardsClaimRouting, intervals []rprewards.IntervalInfo) ([]rprewards.ComplianceFlag, error) {
+	hook, err := rprewards.GetComplianceHook(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the intervals with rewards of each type
+	rplIntervals := []uint64{}
+	ethIntervals := []uint64{}
+	for _, interval := range intervals {
+		if interval.CollateralRplAmount.Sign() > 0 || interval.ODaoRplAmount.Sign() > 0 {
+			rplIntervals = append(rplIntervals, interval.Index)
+		}
+		if interval.SmoothingPoolEthAmount.Sign() > 0 {
+			ethIntervals = append(ethIntervals, interval.Index)
+		}
+	}
+
+	claims := []rprewards.ClaimScreening{}
+	if len(rplIntervals) > 0 {
+		claims = append(claims, rprewards.ClaimScreening{
+			NodeAddress: routing.NodeAddress,
+			Recipient:   routing.RplRecipient,
+			Asset:       "RPL",
+			Intervals:   rplIntervals,
+		})
+	}
+	if len(ethIntervals) > 0 {
+		claims = append(claims, rprewards.ClaimScreening{
+			NodeAddress: routing.NodeAddress,
+			Recipient:   routing.EthRecipient,
+			Asset:       "ETH",
+			Intervals:   ethIntervals,
+		})
+	}
+
+	flags := []rprewards.ComplianceFlag{}
+	for _, claim := range claims {
+		claimFlags, err := hook.ScreenClaim(claim)
+		if err != nil {
+			return nil, fmt.Errorf("error screening %s claim to %s: %w", claim.Asset, claim.Recipient.Hex(), err)
+		}
+		flags = append(flags, claimFlags...)
+	}
+	return flags, nil
+}
+
+// Check if an address has contract code deployed to it
+func isContract(rp *rocketpool.RocketPool, address common.Address) (bool, error) {
+	code, err := rp.Client.CodeAt(context.Background(), address, nil)
+	if err != nil {
+		return false, fmt.Errorf("error checking code at %s: %w", address.Hex(), err)
+	}
+	return len(code) > 0, nil
+}
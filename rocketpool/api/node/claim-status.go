@@ -0,0 +1,144 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getClaimStatus(c *cli.Context) (*api.NodeClaimStatusResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeClaimStatusResponse{
+		UnclaimedRpl: big.NewInt(0),
+		UnclaimedEth: big.NewInt(0),
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.Registered, err = node.GetNodeExists(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !response.Registered {
+		return &response, nil
+	}
+
+	// Get the claimed and unclaimed intervals from the on-chain bitmap
+	unclaimed, claimed, err := rprewards.GetClaimStatus(rp, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update the claim index, starting from the snapshot of the earliest claimed interval
+	index, err := rprewards.LoadClaimIndex(cfg.Smartnode.GetClaimIndexPath())
+	if err != nil {
+		return nil, err
+	}
+	var earliestClaimBlock *big.Int
+	if len(claimed) > 0 {
+		event, err := rprewards.NewRewardsExecutionClient(rp).GetRewardSnapshotEvent(cfg.Smartnode.GetPreviousRewardsPoolAddresses(), claimed[0], nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the rewards event for interval %d: %w", claimed[0], err)
+		}
+		earliestClaimBlock = event.ExecutionBlock
+	}
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	err = index.Update(rp, nodeAccount.Address, earliestClaimBlock, big.NewInt(int64(eventLogInterval)))
+	if err != nil {
+		return nil, fmt.Errorf("error updating claim index: %w", err)
+	}
+	err = rprewards.SaveClaimIndex(cfg.Smartnode.GetClaimIndexPath(), index)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reconcile every interval against its rewards file and claim event
+	claimedSet := map[uint64]bool{}
+	for _, interval := range claimed {
+		claimedSet[interval] = true
+	}
+	intervals := append(append([]uint64{}, claimed...), unclaimed...)
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i] < intervals[j]
+	})
+	for _, interval := range intervals {
+		info, err := rprewards.GetIntervalInfo(rp, cfg, nodeAccount.Address, interval, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting info for interval %d: %w", interval, err)
+		}
+		status := api.IntervalClaimStatus{
+			Index:           interval,
+			StartTime:       info.StartTime,
+			EndTime:         info.EndTime,
+			Claimed:         claimedSet[interval],
+			TreeFileExists:  info.TreeFileExists,
+			MerkleRootValid: info.MerkleRootValid,
+			NodeExists:      info.NodeExists,
+		}
+		if info.NodeExists {
+			status.ExpectedRpl = big.NewInt(0).Add(&info.CollateralRplAmount.Int, &info.ODaoRplAmount.Int)
+			status.ExpectedEth = big.NewInt(0).Set(&info.SmoothingPoolEthAmount.Int)
+		}
+
+		if claim, exists := index.Claims[interval]; exists {
+			status.ClaimEventFound = true
+			status.ClaimedRpl = &claim.AmountRPL.Int
+			status.ClaimedEth = &claim.AmountETH.Int
+			status.ClaimBlock = claim.BlockNumber
+			status.ClaimTxHash = claim.TxHash
+			if status.ExpectedRpl != nil {
+				status.AmountMismatch = (status.ExpectedRpl.Cmp(status.ClaimedRpl) != 0 || status.ExpectedEth.Cmp(status.ClaimedEth) != 0)
+			}
+		}
+
+		// Only intervals whose rewards the node can verify locally contribute to the unclaimed totals
+		if !status.Claimed && status.ExpectedRpl != nil {
+			response.UnclaimedRpl.Add(response.UnclaimedRpl, status.ExpectedRpl)
+			response.UnclaimedEth.Add(response.UnclaimedEth, status.ExpectedEth)
+		}
+
+		// Skip intervals the node wasn't part of
+		if info.MerkleRootValid && !info.NodeExists {
+			continue
+		}
+		response.Intervals = append(response.Intervals, status)
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -184,6 +184,23 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "get-primary-withdrawal-address-confirmation-tx",
+				Usage:     "Get the transaction that must be sent from the node's pending primary withdrawal address to confirm it",
+				UsageText: "rocketpool api node get-primary-withdrawal-address-confirmation-tx",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getPrimaryWithdrawalAddressConfirmationTx(c))
+					return nil
+
+				},
+			},
 
 			{
 				Name:      "can-set-rpl-withdrawal-address",
@@ -317,6 +334,88 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "timezone-distribution",
+				Usage:     "Get the number of nodes registered in each timezone",
+				UsageText: "rocketpool api node timezone-distribution",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getTimezoneDistribution(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "contact-info",
+				Usage:     "Get the contact info a node operator has published on their node's primary ENS name",
+				UsageText: "rocketpool api node contact-info address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					address, err := cliutils.ValidateAddress("address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getContactInfo(c, address))
+					return nil
+
+				},
+			},
+			{
+				Name:      "can-set-contact-info",
+				Usage:     "Checks if the node can publish contact info on its primary ENS name",
+				UsageText: "rocketpool api node can-set-contact-info key value",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					key, err := cliutils.ValidateContactInfoKey("key", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canSetContactInfo(c, key, c.Args().Get(1)))
+					return nil
+
+				},
+			},
+			{
+				Name:      "set-contact-info",
+				Usage:     "Publish contact info on the node's primary ENS name",
+				UsageText: "rocketpool api node set-contact-info key value",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					key, err := cliutils.ValidateContactInfoKey("key", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(setContactInfo(c, key, c.Args().Get(1)))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-swap-rpl",
 				Usage:     "Check whether the node can swap old RPL for new RPL",
@@ -1185,6 +1284,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "claim-status",
+				Usage:     "Get the claim status of every rewards interval for the node, reconciled against the claim events and rewards files",
+				UsageText: "rocketpool api node claim-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getClaimStatus(c))
+					return nil
+
+				},
+			},
 			{
 				Name:      "can-claim-rewards",
 				Usage:     "Check if the rewards for the given intervals can be claimed",
@@ -1221,6 +1338,169 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "can-claim-l2-rewards",
+				Usage:     "Check if the rewards for the given intervals can be claimed on the Layer 2 network they were routed to",
+				UsageText: "rocketpool api node can-claim-l2-rewards network 0,1,2,5,6",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					network, err := cliutils.ValidateUint("network", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					indicesString := c.Args().Get(1)
+
+					// Run
+					api.PrintResponse(canClaimL2Rewards(c, network, indicesString))
+					return nil
+
+				},
+			},
+			{
+				Name:      "claim-l2-rewards",
+				Usage:     "Claim rewards for the given reward intervals on the Layer 2 network they were routed to",
+				UsageText: "rocketpool api node claim-l2-rewards network 0,1,2,5,6",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					network, err := cliutils.ValidateUint("network", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					indicesString := c.Args().Get(1)
+
+					// Run
+					api.PrintResponse(claimL2Rewards(c, network, indicesString))
+					return nil
+
+				},
+			},
+			{
+				Name:      "reward-network-status",
+				Usage:     "Get the network the node's rewards are routed to, and whether it's valid",
+				UsageText: "rocketpool api node reward-network-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRewardNetworkStatus(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "can-set-reward-network",
+				Usage:     "Check if the node's reward network can be changed, and build the transaction that changes it",
+				UsageText: "rocketpool api node can-set-reward-network network",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					network, err := cliutils.ValidateUint("network", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canSetRewardNetwork(c, network))
+					return nil
+
+				},
+			},
+			{
+				Name:      "set-reward-network",
+				Usage:     "Change the node's reward network; only possible if the node is its own primary withdrawal address",
+				UsageText: "rocketpool api node set-reward-network network",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					network, err := cliutils.ValidateUint("network", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(setRewardNetwork(c, network))
+					return nil
+
+				},
+			},
+			{
+				Name:      "export-claim-bundle",
+				Usage:     "Build a self-contained bundle for claiming the rewards of the given intervals from another wallet",
+				UsageText: "rocketpool api node export-claim-bundle 0,1,2,5,6",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					indicesString := c.Args().Get(0)
+
+					// Run
+					api.PrintResponse(exportClaimBundle(c, indicesString))
+					return nil
+
+				},
+			},
+			{
+				Name:      "verify-my-rewards",
+				Usage:     "Check the node's entry in the official rewards tree for an interval against the node's own chain data and the published minipool performance file",
+				UsageText: "rocketpool api node verify-my-rewards interval",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					interval, err := cliutils.ValidateUint("interval", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(verifyMyRewards(c, interval))
+					return nil
+
+				},
+			},
+			{
+				Name:      "effectiveness",
+				Usage:     "Get the attestation effectiveness of the node's validators for a rewards interval",
+				UsageText: "rocketpool api node effectiveness interval",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					interval, err := cliutils.ValidateUint("interval", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getEffectiveness(c, interval))
+					return nil
+
+				},
+			},
 			{
 				Name:      "can-claim-and-stake-rewards",
 				Usage:     "Check if the rewards for the given intervals can be claimed, and RPL restaked automatically",
@@ -1519,6 +1799,27 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "leaderboard",
+				Usage:     "Get the top nodes on the network leaderboard and the node's own rank",
+				UsageText: "rocketpool api node leaderboard count",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					count, err := cliutils.ValidatePositiveUint("count", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getLeaderboard(c, count))
+					return nil
+
+				},
+			},
 		},
 	})
 }
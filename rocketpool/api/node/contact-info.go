@@ -0,0 +1,201 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+	ens "github.com/wealdtech/go-ens/v3"
+	ensresolver "github.com/wealdtech/go-ens/v3/contracts/resolver"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+// Get the contact info a node operator has published in the text records of their node's primary ENS name
+func getContactInfo(c *cli.Context, address common.Address) (*api.NodeContactInfoResponse, error) {
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeContactInfoResponse{
+		Address: address,
+		Records: map[string]string{},
+	}
+
+	// Get the node's primary ENS name
+	name, err := ens.ReverseResolve(rp.Client, address)
+	if err != nil {
+		// No primary name, so there's no contact info
+		return &response, nil
+	}
+	response.EnsName = name
+
+	// Get the contact records
+	resolver, err := ens.NewResolver(rp.Client, name)
+	if err != nil {
+		return nil, fmt.Errorf("error getting resolver for %s: %w", name, err)
+	}
+	for _, key := range cliutils.ContactInfoKeys {
+		value, err := resolver.Text(key)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the %s record for %s: %w", key, name, err)
+		}
+		if value != "" {
+			response.Records[key] = value
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+func canSetContactInfo(c *cli.Context, key string, value string) (*api.CanSetNodeContactInfoResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanSetNodeContactInfoResponse{}
+
+	// Get the node's primary ENS name
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	name, err := ens.ReverseResolve(ec, nodeAccount.Address)
+	if err != nil {
+		response.NoEnsName = true
+		return &response, nil
+	}
+	response.EnsName = name
+
+	// Get gas estimate; this fails if the node isn't allowed to set records on its name
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	resolverAddress, data, err := getSetTextCall(c, name, key, value)
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := eth.EstimateSendTransactionGas(ec, resolverAddress, data, false, opts)
+	if err != nil {
+		response.NotAuthorized = true
+		return &response, nil
+	}
+	response.GasInfo = gasInfo
+	response.CanSet = true
+	return &response, nil
+
+}
+
+func setContactInfo(c *cli.Context, key string, value string) (*api.SetNodeContactInfoResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SetNodeContactInfoResponse{}
+
+	// Get the node's primary ENS name
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	name, err := ens.ReverseResolve(ec, nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("node %s does not have a primary ENS name: %w", nodeAccount.Address.Hex(), err)
+	}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Set the record
+	resolverAddress, data, err := getSetTextCall(c, name, key, value)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := eth.SendTransaction(ec, resolverAddress, w.GetChainID(), data, false, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error setting the %s record for %s: %w", key, name, err)
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the resolver address and calldata for setting a text record on an ENS name
+func getSetTextCall(c *cli.Context, name string, key string, value string) (common.Address, []byte, error) {
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	resolver, err := ens.NewResolver(ec, name)
+	if err != nil {
+		return common.Address{}, nil, fmt.Errorf("error getting resolver for %s: %w", name, err)
+	}
+	nameHash, err := ens.NameHash(name)
+	if err != nil {
+		return common.Address{}, nil, fmt.Errorf("error hashing %s: %w", name, err)
+	}
+	resolverAbi, err := abi.JSON(strings.NewReader(ensresolver.ContractABI))
+	if err != nil {
+		return common.Address{}, nil, fmt.Errorf("error parsing resolver ABI: %w", err)
+	}
+	data, err := resolverAbi.Pack("setText", nameHash, key, value)
+	if err != nil {
+		return common.Address{}, nil, fmt.Errorf("error creating setText call: %w", err)
+	}
+	return resolver.ContractAddr, data, nil
+}
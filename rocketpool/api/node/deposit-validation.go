@@ -0,0 +1,168 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	prdeposit "github.com/prysmaticlabs/prysm/v5/contracts/deposit"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/deposits"
+)
+
+// How many EL blocks to search the deposit contract for deposits the Beacon Chain hasn't processed yet
+const pendingDepositLookbackBlocks = 50000
+
+// Validate a new validator's deposit data before it is submitted, so a deposit that would be scrubbed
+// or lost is never sent
+func validatePreDeposit(c *cli.Context, rp *rocketpool.RocketPool, bc beacon.Client, eth2Config beacon.Eth2Config, minipoolAddress common.Address, depositAmount uint64, pubkey rptypes.ValidatorPubkey, withdrawalCredentials common.Hash, signature rptypes.ValidatorSignature, depositDataRoot common.Hash) error {
+
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the Beacon Node and Rocket Pool agree on the deposit contract
+	rpDepositContract, err := rp.GetAddress("casperDeposit", nil)
+	if err != nil {
+		return fmt.Errorf("Error getting Casper deposit contract: %w", err)
+	}
+	beaconDepositContract, err := bc.GetEth2DepositContract()
+	if err != nil {
+		return fmt.Errorf("Error getting beacon client deposit contract: %w", err)
+	}
+	if beaconDepositContract.ChainID != uint64(cfg.Smartnode.GetChainID()) {
+		return fmt.Errorf("Your Beacon Node is on chain %d but Rocket Pool is on chain %d. Your funds have not been deposited for your own safety.", beaconDepositContract.ChainID, cfg.Smartnode.GetChainID())
+	}
+	if beaconDepositContract.Address != *rpDepositContract {
+		return fmt.Errorf("Your Beacon Node uses deposit contract %s but Rocket Pool uses %s. Your funds have not been deposited for your own safety.", beaconDepositContract.Address.Hex(), rpDepositContract.Hex())
+	}
+
+	// Make sure the withdrawal credentials point to the minipool
+	expectedCredentials := common.Hash{}
+	expectedCredentials[0] = 0x01
+	copy(expectedCredentials[12:], minipoolAddress.Bytes())
+	if withdrawalCredentials != expectedCredentials {
+		return fmt.Errorf("The withdrawal credentials %s do not match the expected credentials %s for minipool %s. Your funds have not been deposited for your own safety.", withdrawalCredentials.Hex(), expectedCredentials.Hex(), minipoolAddress.Hex())
+	}
+
+	// Make sure the amount is the prestake amount
+	expectedAmount := uint64(eth.WeiToGwei(eth.EthToWei(prestakeDepositAmount)))
+	if depositAmount != expectedAmount {
+		return fmt.Errorf("The deposit amount of %d gwei does not match the expected prestake amount of %d gwei. Your funds have not been deposited for your own safety.", depositAmount, expectedAmount)
+	}
+
+	// Make sure the signature verifies against the deposit domain and the root matches the data
+	err = validateDepositInfo(eth2Config, depositAmount, pubkey, withdrawalCredentials, signature)
+	if err != nil {
+		return fmt.Errorf("Your deposit failed the validation safety check: %w\n"+
+			"For your safety, this deposit will not be submitted and your ETH will not be staked.\n"+
+			"PLEASE REPORT THIS TO THE ROCKET POOL DEVELOPERS and include the following information:\n"+
+			"\tDomain Type: 0x%s\n"+
+			"\tGenesis Fork Version: 0x%s\n"+
+			"\tGenesis Validator Root: 0x%s\n"+
+			"\tDeposit Amount: %d gwei\n"+
+			"\tValidator Pubkey: %s\n"+
+			"\tWithdrawal Credentials: %s\n"+
+			"\tSignature: %s\n",
+			err,
+			hex.EncodeToString(eth2types.DomainDeposit[:]),
+			hex.EncodeToString(eth2Config.GenesisForkVersion),
+			hex.EncodeToString(eth2types.ZeroGenesisValidatorsRoot),
+			depositAmount,
+			pubkey.Hex(),
+			withdrawalCredentials.Hex(),
+			signature.Hex(),
+		)
+	}
+	depositData := &ethpb.Deposit_Data{
+		PublicKey:             pubkey.Bytes(),
+		WithdrawalCredentials: withdrawalCredentials.Bytes(),
+		Amount:                depositAmount,
+		Signature:             signature.Bytes(),
+	}
+	expectedRoot, err := depositData.HashTreeRoot()
+	if err != nil {
+		return fmt.Errorf("Error computing the deposit data root: %w", err)
+	}
+	if !bytes.Equal(expectedRoot[:], depositDataRoot.Bytes()) {
+		return fmt.Errorf("The deposit data root %s does not match the deposit data (expected %s). Your funds have not been deposited for your own safety.", depositDataRoot.Hex(), common.Hash(expectedRoot).Hex())
+	}
+
+	// Make sure a validator with this pubkey doesn't already exist
+	status, err := bc.GetValidatorStatus(pubkey, nil)
+	if err != nil {
+		return fmt.Errorf("Error checking for existing validator status: %w\nYour funds have not been deposited for your own safety.", err)
+	}
+	if status.Exists {
+		return fmt.Errorf("**** ALERT ****\n"+
+			"Your minipool %s has the following as a validator pubkey:\n\t%s\n"+
+			"This key is already in use by validator %s on the Beacon chain!\n"+
+			"Rocket Pool will not allow you to deposit this validator for your own safety so you do not get slashed.\n"+
+			"PLEASE REPORT THIS TO THE ROCKET POOL DEVELOPERS.\n"+
+			"***************\n", minipoolAddress.Hex(), pubkey.Hex(), status.Index)
+	}
+
+	// Make sure there isn't a deposit for this pubkey that the Beacon Chain hasn't processed yet
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return err
+	}
+	latestBlock, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return fmt.Errorf("Error getting the latest block number: %w", err)
+	}
+	startBlock := uint64(0)
+	if latestBlock > pendingDepositLookbackBlocks {
+		startBlock = latestBlock - pendingDepositLookbackBlocks
+	}
+	indexer := deposits.NewIndexer(rp, cfg.Smartnode.GetDepositIndexPath(), uint64(eventLogInterval), deposits.DefaultRetentionBlocks)
+	pendingDeposits, err := indexer.GetDeposits(map[rptypes.ValidatorPubkey]bool{pubkey: true}, startBlock)
+	if err != nil {
+		return fmt.Errorf("Error checking for pending deposits: %w\nYour funds have not been deposited for your own safety.", err)
+	}
+	if pending := pendingDeposits[pubkey]; len(pending) > 0 {
+		return fmt.Errorf("**** ALERT ****\n"+
+			"Your minipool %s has the following as a validator pubkey:\n\t%s\n"+
+			"A deposit for this key was already made in transaction %s (block %d) with withdrawal credentials %s, but it has not been processed by the Beacon Chain yet.\n"+
+			"Rocket Pool will not allow you to deposit this validator for your own safety.\n"+
+			"PLEASE REPORT THIS TO THE ROCKET POOL DEVELOPERS.\n"+
+			"***************\n", minipoolAddress.Hex(), pubkey.Hex(), pending[0].TxHash.Hex(), pending[0].BlockNumber, pending[0].WithdrawalCredentials.Hex())
+	}
+
+	return nil
+
+}
+
+// Verify a deposit's signature against the deposit domain of the active network
+func validateDepositInfo(eth2Config beacon.Eth2Config, depositAmount uint64, pubkey rptypes.ValidatorPubkey, withdrawalCredentials common.Hash, signature rptypes.ValidatorSignature) error {
+
+	// Get the deposit domain based on the eth2 config
+	depositDomain, err := signing.ComputeDomain(eth2types.DomainDeposit, eth2Config.GenesisForkVersion, eth2types.ZeroGenesisValidatorsRoot)
+	if err != nil {
+		return err
+	}
+
+	// Create the deposit struct
+	depositData := new(ethpb.Deposit_Data)
+	depositData.Amount = depositAmount
+	depositData.PublicKey = pubkey.Bytes()
+	depositData.WithdrawalCredentials = withdrawalCredentials.Bytes()
+	depositData.Signature = signature.Bytes()
+
+	// Validate the signature
+	err = prdeposit.VerifyDepositSignature(depositData, depositDomain)
+	return err
+
+}
@@ -2,14 +2,12 @@ package node
 
 import (
 	"context"
-	"encoding/hex"
 	"fmt"
 	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
 	"github.com/rocket-pool/rocketpool-go/deposit"
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/node"
@@ -20,15 +18,11 @@ import (
 	"github.com/urfave/cli"
 	"golang.org/x/sync/errgroup"
 
-	prdeposit "github.com/prysmaticlabs/prysm/v5/contracts/deposit"
-	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/rocket-pool/smartnode/shared/services"
-	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
-	eth2types "github.com/wealdtech/go-eth2-types/v2"
 )
 
 const (
@@ -214,28 +208,10 @@ func canNodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt
 	pubKey := rptypes.BytesToValidatorPubkey(depositData.PublicKey)
 	signature := rptypes.BytesToValidatorSignature(depositData.Signature)
 
-	// Do a final sanity check
-	err = validateDepositInfo(eth2Config, uint64(depositAmount), pubKey, withdrawalCredentials, signature)
+	// Run the pre-deposit safety checks
+	err = validatePreDeposit(c, rp, bc, eth2Config, minipoolAddress, depositAmount, pubKey, withdrawalCredentials, signature, depositDataRoot)
 	if err != nil {
-		return nil, fmt.Errorf("Your deposit failed the validation safety check: %w\n"+
-			"For your safety, this deposit will not be submitted and your ETH will not be staked.\n"+
-			"PLEASE REPORT THIS TO THE ROCKET POOL DEVELOPERS and include the following information:\n"+
-			"\tDomain Type: 0x%s\n"+
-			"\tGenesis Fork Version: 0x%s\n"+
-			"\tGenesis Validator Root: 0x%s\n"+
-			"\tDeposit Amount: %d gwei\n"+
-			"\tValidator Pubkey: %s\n"+
-			"\tWithdrawal Credentials: %s\n"+
-			"\tSignature: %s\n",
-			err,
-			hex.EncodeToString(eth2types.DomainDeposit[:]),
-			hex.EncodeToString(eth2Config.GenesisForkVersion),
-			hex.EncodeToString(eth2types.ZeroGenesisValidatorsRoot),
-			depositAmount,
-			pubKey.Hex(),
-			withdrawalCredentials.Hex(),
-			signature.Hex(),
-		)
+		return nil, err
 	}
 
 	// Run the deposit gas estimator
@@ -374,42 +350,10 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *b
 	pubKey := rptypes.BytesToValidatorPubkey(depositData.PublicKey)
 	signature := rptypes.BytesToValidatorSignature(depositData.Signature)
 
-	// Make sure a validator with this pubkey doesn't already exist
-	status, err := bc.GetValidatorStatus(pubKey, nil)
-	if err != nil {
-		return nil, fmt.Errorf("Error checking for existing validator status: %w\nYour funds have not been deposited for your own safety.", err)
-	}
-	if status.Exists {
-		return nil, fmt.Errorf("**** ALERT ****\n"+
-			"Your minipool %s has the following as a validator pubkey:\n\t%s\n"+
-			"This key is already in use by validator %s on the Beacon chain!\n"+
-			"Rocket Pool will not allow you to deposit this validator for your own safety so you do not get slashed.\n"+
-			"PLEASE REPORT THIS TO THE ROCKET POOL DEVELOPERS.\n"+
-			"***************\n", minipoolAddress.Hex(), pubKey.Hex(), status.Index)
-	}
-
-	// Do a final sanity check
-	err = validateDepositInfo(eth2Config, depositAmount, pubKey, withdrawalCredentials, signature)
+	// Run the pre-deposit safety checks
+	err = validatePreDeposit(c, rp, bc, eth2Config, minipoolAddress, depositAmount, pubKey, withdrawalCredentials, signature, depositDataRoot)
 	if err != nil {
-		return nil, fmt.Errorf("Your deposit failed the validation safety check: %w\n"+
-			"For your safety, this deposit will not be submitted and your ETH will not be staked.\n"+
-			"PLEASE REPORT THIS TO THE ROCKET POOL DEVELOPERS and include the following information:\n"+
-			"\tDomain Type: 0x%s\n"+
-			"\tGenesis Fork Version: 0x%s\n"+
-			"\tGenesis Validator Root: 0x%s\n"+
-			"\tDeposit Amount: %d gwei\n"+
-			"\tValidator Pubkey: %s\n"+
-			"\tWithdrawal Credentials: %s\n"+
-			"\tSignature: %s\n",
-			err,
-			hex.EncodeToString(eth2types.DomainDeposit[:]),
-			hex.EncodeToString(eth2Config.GenesisForkVersion),
-			hex.EncodeToString(eth2types.ZeroGenesisValidatorsRoot),
-			depositAmount,
-			pubKey.Hex(),
-			withdrawalCredentials.Hex(),
-			signature.Hex(),
-		)
+		return nil, err
 	}
 
 	// Override the provided pending TX if requested
@@ -454,24 +398,3 @@ func nodeDeposit(c *cli.Context, amountWei *big.Int, minNodeFee float64, salt *b
 	return &response, nil
 
 }
-
-func validateDepositInfo(eth2Config beacon.Eth2Config, depositAmount uint64, pubkey rptypes.ValidatorPubkey, withdrawalCredentials common.Hash, signature rptypes.ValidatorSignature) error {
-
-	// Get the deposit domain based on the eth2 config
-	depositDomain, err := signing.ComputeDomain(eth2types.DomainDeposit, eth2Config.GenesisForkVersion, eth2types.ZeroGenesisValidatorsRoot)
-	if err != nil {
-		return err
-	}
-
-	// Create the deposit struct
-	depositData := new(ethpb.Deposit_Data)
-	depositData.Amount = depositAmount
-	depositData.PublicKey = pubkey.Bytes()
-	depositData.WithdrawalCredentials = withdrawalCredentials.Bytes()
-	depositData.Signature = signature.Bytes()
-
-	// Validate the signature
-	err = prdeposit.VerifyDepositSignature(depositData, depositDomain)
-	return err
-
-}
@@ -0,0 +1,60 @@
+package node
+
+import (
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getEffectiveness(c *cli.Context, interval uint64) (*api.NodeEffectivenessResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeEffectivenessResponse{
+		Interval: interval,
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the node's minipools
+	addresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the effectiveness of the node's validators
+	effectivenessFile, err := rprewards.GetValidatorEffectiveness(cfg, interval, true)
+	if err != nil {
+		return nil, err
+	}
+	response.HasInclusionDelays = effectivenessFile.HasInclusionDelays
+	response.Validators = effectivenessFile.Filter(addresses)
+	response.Effectiveness = rprewards.GetCombinedEffectiveness(response.Validators)
+
+	// Return response
+	return &response, nil
+
+}
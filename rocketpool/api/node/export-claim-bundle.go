@@ -0,0 +1,142 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The current version of the claim bundle format
+const claimBundleVersion int = 1
+
+func exportClaimBundle(c *cli.Context, indicesString string) (*api.NodeExportClaimBundleResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeExportClaimBundleResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the requested intervals haven't been claimed yet
+	unclaimed, _, err := rprewards.GetClaimStatus(rp, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	unclaimedSet := map[uint64]bool{}
+	for _, interval := range unclaimed {
+		unclaimedSet[interval] = true
+	}
+
+	// Build the bundle entry for each interval
+	bundle := api.ClaimBundle{
+		Version:     claimBundleVersion,
+		Network:     fmt.Sprint(cfg.Smartnode.Network.Value),
+		ChainID:     cfg.Smartnode.GetChainID(),
+		CreatedAt:   time.Now().UTC(),
+		NodeAddress: nodeAccount.Address,
+		Value:       "0",
+		TotalRpl:    rprewards.NewQuotedBigInt(0),
+		TotalEth:    rprewards.NewQuotedBigInt(0),
+	}
+	indices := []*big.Int{}
+	amountRpl := []*big.Int{}
+	amountEth := []*big.Int{}
+	merkleProofs := [][]common.Hash{}
+	seenIndices := map[uint64]bool{}
+	for _, element := range strings.Split(indicesString, ",") {
+		index, err := strconv.ParseUint(element, 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert index %s to a number: %w", element, err)
+		}
+		if seenIndices[index] {
+			continue
+		}
+		seenIndices[index] = true
+		if !unclaimedSet[index] {
+			return nil, fmt.Errorf("interval %d has already been claimed or hasn't finished yet", index)
+		}
+
+		// Get the rewards and proof from the tree file
+		intervalInfo, err := rprewards.GetIntervalInfo(rp, cfg, nodeAccount.Address, index, nil)
+		if err != nil {
+			return nil, err
+		}
+		if !intervalInfo.TreeFileExists {
+			return nil, fmt.Errorf("rewards tree file '%s' doesn't exist", intervalInfo.TreeFilePath)
+		}
+		if !intervalInfo.MerkleRootValid {
+			return nil, fmt.Errorf("merkle root for rewards tree file '%s' doesn't match the canonical merkle root for interval %d", intervalInfo.TreeFilePath, index)
+		}
+		if !intervalInfo.NodeExists {
+			return nil, fmt.Errorf("the node does not have any rewards for interval %d", index)
+		}
+
+		rpl := big.NewInt(0).Add(&intervalInfo.CollateralRplAmount.Int, &intervalInfo.ODaoRplAmount.Int)
+		eth := big.NewInt(0).Set(&intervalInfo.SmoothingPoolEthAmount.Int)
+		bundle.Intervals = append(bundle.Intervals, api.ClaimBundleInterval{
+			Index:       index,
+			MerkleRoot:  intervalInfo.MerkleRoot,
+			AmountRpl:   rprewards.QuotedBigIntFromBigInt(rpl),
+			AmountEth:   rprewards.QuotedBigIntFromBigInt(eth),
+			MerkleProof: intervalInfo.MerkleProof,
+		})
+		bundle.TotalRpl.Add(&bundle.TotalRpl.Int, rpl)
+		bundle.TotalEth.Add(&bundle.TotalEth.Int, eth)
+
+		indices = append(indices, big.NewInt(0).SetUint64(index))
+		amountRpl = append(amountRpl, rpl)
+		amountEth = append(amountEth, eth)
+		merkleProofs = append(merkleProofs, intervalInfo.MerkleProof)
+	}
+
+	// Build the claim call
+	distributor, err := rp.GetContract("rocketMerkleDistributorMainnet", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the Merkle distributor contract: %w", err)
+	}
+	data, err := distributor.ABI.Pack("claim", nodeAccount.Address, indices, amountRpl, amountEth, merkleProofs)
+	if err != nil {
+		return nil, fmt.Errorf("error packing claim call: %w", err)
+	}
+	bundle.To = *distributor.Address
+	bundle.Data = hexutil.Encode(data)
+	bundle.Instructions = fmt.Sprintf("Send a transaction with the provided data and a value of 0 to %s on chain %d. "+
+		"It must be sent from the node address %s or its primary withdrawal address. "+
+		"ETH will be sent to the primary withdrawal address, and RPL to the RPL withdrawal address if one is set.",
+		bundle.To.Hex(), bundle.ChainID, nodeAccount.Address.Hex())
+
+	// Return response
+	response.Bundle = bundle
+	return &response, nil
+
+}
@@ -0,0 +1,70 @@
+package node
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getLeaderboard(c *cli.Context, count uint64) (*api.NodeLeaderboardResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeLeaderboardResponse{
+		Enabled: cfg.Smartnode.EnableLeaderboard.Value.(bool),
+	}
+
+	// Load the leaderboard
+	leaderboard, err := rprewards.LoadLeaderboard(cfg.Smartnode.GetLeaderboardPath())
+	if err != nil {
+		return nil, err
+	}
+	if leaderboard == nil {
+		return &response, nil
+	}
+	response.TotalNodes = len(leaderboard.Nodes)
+
+	// Find the node's own entry
+	nodeAccount, err := w.GetNodeAccount()
+	if err == nil {
+		entry, exists := leaderboard.GetEntry(nodeAccount.Address)
+		if exists {
+			response.NodeEntry = entry
+		}
+	}
+
+	// Only return the top nodes
+	if uint64(len(leaderboard.Nodes)) > count {
+		leaderboard.Nodes = leaderboard.Nodes[:count]
+	}
+	response.Leaderboard = leaderboard
+
+	// Look up the names of the listed nodes
+	addresses := make([]common.Address, 0, len(leaderboard.Nodes)+1)
+	for _, entry := range leaderboard.Nodes {
+		addresses = append(addresses, entry.Address)
+	}
+	if response.NodeEntry != nil {
+		addresses = append(addresses, response.NodeEntry.Address)
+	}
+	resolver, err := services.GetEnsResolver(c)
+	if err == nil {
+		response.EnsNames = resolver.GetNames(addresses)
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/rocket-pool/rocketpool-go/storage"
 	"github.com/urfave/cli"
 
@@ -49,6 +50,12 @@ func canSetPrimaryWithdrawalAddress(c *cli.Context, withdrawalAddress common.Add
 	}
 	response.GasInfo = gasInfo
 
+	// Check if the new address is a contract, which may not be able to confirm the change
+	response.IsContract, err = isContract(rp, withdrawalAddress)
+	if err != nil {
+		return nil, err
+	}
+
 	// Return response
 	response.CanSet = true
 	return &response, nil
@@ -217,3 +224,61 @@ func confirmPrimaryWithdrawalAddress(c *cli.Context) (*api.ConfirmNodePrimaryWit
 	return &response, nil
 
 }
+
+func getPrimaryWithdrawalAddressConfirmationTx(c *cli.Context) (*api.GetNodePrimaryWithdrawalAddressConfirmationTxResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.GetNodePrimaryWithdrawalAddressConfirmationTxResponse{}
+
+	// Get the node's account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the pending withdrawal address
+	pendingAddress, err := storage.GetNodePendingWithdrawalAddress(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if pendingAddress == (common.Address{}) {
+		return &response, nil
+	}
+	response.HasPendingAddress = true
+
+	// Build the confirmation transaction
+	data, err := rp.RocketStorageContract.ABI.Pack("confirmWithdrawalAddress", nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("error packing confirmWithdrawalAddress call: %w", err)
+	}
+	response.Transaction = api.WithdrawalAddressConfirmationTx{
+		ChainID:     cfg.Smartnode.GetChainID(),
+		NodeAddress: nodeAccount.Address,
+		From:        pendingAddress,
+		To:          *rp.RocketStorageContract.Address,
+		Value:       "0",
+		Data:        hexutil.Encode(data),
+		Description: fmt.Sprintf("Confirm %s as the primary withdrawal address of Rocket Pool node %s. This transaction must be sent from %s.", pendingAddress.Hex(), nodeAccount.Address.Hex(), pendingAddress.Hex()),
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,234 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/storage"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/l2"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+func getRewardNetworkStatus(c *cli.Context) (*api.NodeRewardNetworkStatusResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeRewardNetworkStatusResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the current network and whether it's valid
+	response.RewardNetwork, err = node.GetRewardNetwork(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	networks, err := l2.Load(cfg.Smartnode.GetRewardNetworksPath(true))
+	if err != nil {
+		return nil, err
+	}
+	response.NetworkName = networks.GetName(response.RewardNetwork)
+	response.IsValid, err = rputils.IsRewardNetworkEnabled(rp, response.RewardNetwork, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the networks the node could switch to
+	response.Networks, err = getRewardNetworkOptions(rp, networks)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the primary withdrawal address can change the network
+	response.WithdrawalAddress, err = storage.GetNodeWithdrawalAddress(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.IsNodeWithdrawalAddress = response.WithdrawalAddress == nodeAccount.Address
+
+	// Get the end of the current interval, which is when the network is read for the node's rewards
+	intervalStart, err := rewards.GetClaimIntervalTimeStart(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	intervalTime, err := rewards.GetClaimIntervalTime(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.NextIntervalTime = intervalStart.Add(intervalTime)
+
+	return &response, nil
+
+}
+
+func canSetRewardNetwork(c *cli.Context, network uint64) (*api.CanNodeSetRewardNetworkResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanNodeSetRewardNetworkResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the network
+	enabled, err := rputils.IsRewardNetworkEnabled(rp, network, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.NetworkDisabled = !enabled
+	currentNetwork, err := node.GetRewardNetwork(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.Unchanged = currentNetwork == network
+	response.CanSet = !(response.NetworkDisabled || response.Unchanged)
+	if !response.CanSet {
+		return &response, nil
+	}
+
+	// Build the transaction for the primary withdrawal address
+	withdrawalAddress, err := storage.GetNodeWithdrawalAddress(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.IsNodeWithdrawalAddress = withdrawalAddress == nodeAccount.Address
+	response.Transaction, err = rputils.GetSetRewardNetworkTx(rp, nodeAccount.Address, withdrawalAddress, network, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get gas estimate if the node can send it itself
+	if response.IsNodeWithdrawalAddress {
+		opts, err := w.GetNodeAccountTransactor()
+		if err != nil {
+			return nil, err
+		}
+		response.GasInfo, err = rputils.EstimateSetRewardNetworkGas(rp, nodeAccount.Address, network, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &response, nil
+
+}
+
+func setRewardNetwork(c *cli.Context, network uint64) (*api.NodeSetRewardNetworkResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeSetRewardNetworkResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// The node can only send the transaction if it's its own withdrawal address
+	withdrawalAddress, err := storage.GetNodeWithdrawalAddress(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if withdrawalAddress != nodeAccount.Address {
+		return nil, fmt.Errorf("the reward network can only be changed by the node's primary withdrawal address %s", withdrawalAddress.Hex())
+	}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Set the network
+	hash, err := rputils.SetRewardNetwork(rp, nodeAccount.Address, network, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get Ethereum and the configured Layer 2 networks, and whether each one is enabled
+func getRewardNetworkOptions(rp *rocketpool.RocketPool, networks *l2.Config) ([]api.RewardNetworkOption, error) {
+	ids := []uint64{l2.MainnetRewardNetwork}
+	for _, network := range networks.Networks {
+		ids = append(ids, network.ID)
+	}
+
+	options := []api.RewardNetworkOption{}
+	for _, id := range ids {
+		enabled, err := rputils.IsRewardNetworkEnabled(rp, id, nil)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, api.RewardNetworkOption{
+			ID:      id,
+			Name:    networks.GetName(id),
+			Enabled: enabled,
+		})
+	}
+	return options, nil
+}
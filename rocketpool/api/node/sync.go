@@ -4,6 +4,7 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
@@ -37,6 +38,14 @@ func getSyncProgress(c *cli.Context) (*api.NodeSyncProgressResponse, error) {
 	bcStatus := bcMgr.CheckStatus()
 	response.BcStatus = *bcStatus
 
+	// Get the progress of the latest rewards tree generation, if there is one; a file that can't be read is reported
+	// but treated like a missing one, so it doesn't hide the client status
+	response.TreeGeneration, err = rprewards.LoadTreeGenerationProgress(cfg.Smartnode.GetTreegenProgressPath(true))
+	if err != nil {
+		response.TreeGeneration = nil
+		response.TreeGenerationError = err.Error()
+	}
+
 	// Return response
 	return &response, nil
 
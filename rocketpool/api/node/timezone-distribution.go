@@ -0,0 +1,86 @@
+package node
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The number of nodes to count per call to the node manager
+const timezoneCountBatchSize uint64 = 1000
+
+func getTimezoneDistribution(c *cli.Context) (*api.NodeTimezoneDistributionResponse, error) {
+
+	// Get services
+	if err := services.RequireEthClientSynced(c); err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeTimezoneDistributionResponse{}
+
+	// Get the node count
+	nodeCount, err := node.GetNodeCount(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.TotalNodes = nodeCount
+
+	// Count the nodes in each timezone, in batches
+	counts := map[string]uint64{}
+	for offset := uint64(0); offset < nodeCount; offset += timezoneCountBatchSize {
+		timezoneCounts, err := node.GetNodeCountPerTimezone(rp, big.NewInt(int64(offset)), big.NewInt(int64(timezoneCountBatchSize)), nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, timezoneCount := range timezoneCounts {
+			counts[timezoneCount.Timezone] += timezoneCount.Count.Uint64()
+		}
+	}
+
+	// Sort the timezones by the number of nodes in them
+	for timezone, count := range counts {
+		response.Timezones = append(response.Timezones, api.TimezoneCount{
+			Timezone: timezone,
+			Count:    count,
+		})
+	}
+	sort.Slice(response.Timezones, func(i, j int) bool {
+		if response.Timezones[i].Count != response.Timezones[j].Count {
+			return response.Timezones[i].Count > response.Timezones[j].Count
+		}
+		return response.Timezones[i].Timezone < response.Timezones[j].Timezone
+	})
+
+	// Get the node's own timezone if it's registered
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err == nil {
+		exists, err := node.GetNodeExists(rp, nodeAccount.Address, nil)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			response.NodeTimezone, err = node.GetNodeTimezoneLocation(rp, nodeAccount.Address, nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
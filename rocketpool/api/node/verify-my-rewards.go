@@ -0,0 +1,175 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Collateral rewards have been a straight share of node weight since ruleset v9
+const minCollateralWeightRuleset uint64 = 9
+
+func verifyMyRewards(c *cli.Context, interval uint64) (*api.NodeVerifyMyRewardsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeVerifyMyRewardsResponse{
+		Interval: interval,
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the official rewards file, downloading it if it's missing or doesn't match the canonical root
+	intervalInfo, err := rprewards.GetIntervalInfo(rp, cfg, nodeAccount.Address, interval, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting interval %d info: %w", interval, err)
+	}
+	if !intervalInfo.TreeFileExists || !intervalInfo.MerkleRootValid {
+		err = intervalInfo.DownloadRewardsFile(cfg, true)
+		if err != nil {
+			return nil, fmt.Errorf("error downloading the rewards file for interval %d: %w", interval, err)
+		}
+	}
+	localRewardsFile, err := rprewards.ReadLocalRewardsFile(intervalInfo.TreeFilePath)
+	if err != nil {
+		return nil, err
+	}
+	rewardsFile := localRewardsFile.Impl()
+	response.RulesetVersion = rewardsFile.GetRulesetVersion()
+	response.MerkleRoot = rewardsFile.GetMerkleRoot()
+
+	// Get the node's published rewards
+	response.NodeInTree = rewardsFile.HasRewardsFor(nodeAccount.Address)
+	response.CollateralRpl.Published = big.NewInt(0)
+	response.OracleDaoRpl.Published = big.NewInt(0)
+	response.SmoothingPoolEth.Published = big.NewInt(0)
+	if response.NodeInTree {
+		response.CollateralRpl.Published.Set(rewardsFile.GetNodeCollateralRpl(nodeAccount.Address))
+		response.OracleDaoRpl.Published.Set(rewardsFile.GetNodeOracleDaoRpl(nodeAccount.Address))
+		response.SmoothingPoolEth.Published.Set(rewardsFile.GetNodeSmoothingPoolEth(nodeAccount.Address))
+	}
+
+	// Get the node's state at the end of the interval
+	m := state.NewNetworkStateManager(rp, cfg.Smartnode.GetStateManagerContracts(), bc, nil)
+	nodeState, _, err := m.GetStateForNodeAtSlot(nodeAccount.Address, rewardsFile.GetConsensusEndBlock(), false)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the node's state at the end of interval %d (slot %d): %w", interval, rewardsFile.GetConsensusEndBlock(), err)
+	}
+
+	// Collateral RPL is the node's share of the collateral rewards by weight
+	if response.RulesetVersion < minCollateralWeightRuleset {
+		response.CollateralRpl.Reason = fmt.Sprintf("ruleset v%d blends RPL stake and weight across the whole network, so it can't be checked for a single node", response.RulesetVersion)
+	} else {
+		nodeWeights, _, err := nodeState.CalculateNodeWeights()
+		if err != nil {
+			return nil, fmt.Errorf("error calculating the node's weight: %w", err)
+		}
+		totalCollateralRpl := big.NewInt(0).Mul(nodeState.NetworkDetails.PendingRPLRewards, nodeState.NetworkDetails.NodeOperatorRewardsPercent)
+		totalCollateralRpl.Div(totalCollateralRpl, eth.EthToWei(1))
+		response.CollateralRpl.Expected = big.NewInt(0)
+		nodeWeight := nodeWeights[nodeAccount.Address]
+		totalNodeWeight := rewardsFile.GetTotalNodeWeight()
+		if nodeWeight != nil && nodeWeight.Sign() > 0 && totalNodeWeight != nil && totalNodeWeight.Sign() > 0 {
+			response.CollateralRpl.Expected.Mul(totalCollateralRpl, nodeWeight)
+			response.CollateralRpl.Expected.Quo(response.CollateralRpl.Expected, totalNodeWeight)
+		}
+		response.CollateralRpl.Checked = true
+	}
+
+	// oDAO RPL depends on every member's participation, so only non-members can be checked (they should get nothing)
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(int64(rewardsFile.GetExecutionEndBlock())),
+	}
+	isMember, err := trustednode.GetMemberExists(rp, nodeAccount.Address, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if the node was an oDAO member at the end of interval %d: %w", interval, err)
+	}
+	if isMember {
+		response.OracleDaoRpl.Reason = "oDAO rewards depend on the participation of every member, so they can't be checked for a single node"
+	} else {
+		response.OracleDaoRpl.Expected = big.NewInt(0)
+		response.OracleDaoRpl.Checked = true
+	}
+
+	// Smoothing pool ETH is what the published performance file credits the node's minipools with
+	performanceFile, err := getMinipoolPerformanceFile(cfg, &intervalInfo, rewardsFile)
+	if err != nil {
+		response.SmoothingPoolEth.Reason = fmt.Sprintf("the minipool performance file couldn't be loaded: %s", err.Error())
+	} else {
+		response.SmoothingPoolEth.Expected = big.NewInt(0)
+		for _, mpd := range nodeState.MinipoolDetailsByNode[nodeAccount.Address] {
+			performance, exists := performanceFile.GetSmoothingPoolPerformance(mpd.MinipoolAddress)
+			if !exists {
+				continue
+			}
+			response.SmoothingPoolEth.Expected.Add(response.SmoothingPoolEth.Expected, performance.GetEthEarned())
+			response.SmoothingPoolEth.Expected.Add(response.SmoothingPoolEth.Expected, performance.GetBonusEthEarned())
+		}
+		response.SmoothingPoolEth.Checked = true
+	}
+
+	// Everything that could be checked has to match
+	response.Matches = true
+	for _, line := range []*api.RewardsLineVerification{&response.CollateralRpl, &response.OracleDaoRpl, &response.SmoothingPoolEth} {
+		if line.Checked && line.Published.Cmp(line.Expected) != 0 {
+			response.Matches = false
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Load the minipool performance file for an interval, downloading it if it isn't on disk yet
+func getMinipoolPerformanceFile(cfg *config.RocketPoolConfig, intervalInfo *rprewards.IntervalInfo, rewardsFile rprewards.IRewardsFile) (rprewards.IMinipoolPerformanceFile, error) {
+	path := cfg.Smartnode.GetMinipoolPerformancePath(intervalInfo.Index, true)
+	_, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return intervalInfo.DownloadMinipoolPerformanceFile(cfg, true, rewardsFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error checking for the minipool performance file at %s: %w", path, err)
+	}
+	localPerformanceFile, err := rprewards.ReadLocalMinipoolPerformanceFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return localPerformanceFile.Impl(), nil
+}
@@ -13,7 +13,6 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
-	"github.com/wealdtech/go-ens/v3"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/rocket-pool/rocketpool-go/network"
@@ -204,16 +203,11 @@ func getStatus(c *cli.Context) (*api.PDAOStatusResponse, error) {
 }
 
 func formatResolvedAddress(c *cli.Context, address common.Address) string {
-	rp, err := services.GetRocketPool(c)
-	if err != nil {
-		return address.Hex()
-	}
-
-	name, err := ens.ReverseResolve(rp.Client, address)
+	resolver, err := services.GetEnsResolver(c)
 	if err != nil {
 		return address.Hex()
 	}
-	return fmt.Sprintf("%s (%s)", name, address.Hex())
+	return resolver.FormatAddress(address)
 }
 
 func GetSnapshotVotedProposals(apiDomain string, space string, nodeAddress common.Address, delegate common.Address) (*api.SnapshotVotedProposals, error) {
@@ -0,0 +1,92 @@
+package reth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/reth"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const AprLogColor = color.FgHiWhite
+
+func getApr(c *cli.Context, days uint64) (*api.RethAprResponse, error) {
+
+	// Get services
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RethAprResponse{
+		RateAprDays: days,
+	}
+
+	// Get the trailing APR from the recorded exchange rate
+	since := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+	samples, err := state.LoadNetworkTotals(cfg.Smartnode.GetNetworkTotalsPath(), since)
+	if err != nil {
+		return nil, err
+	}
+	response.RateApr, response.HasRateApr = reth.GetSamplesApr(samples)
+
+	// Get the network state at the head
+	m := state.NewNetworkStateManager(rp, cfg.Smartnode.GetStateManagerContracts(), bc, nil)
+	networkState, err := m.GetHeadState()
+	if err != nil {
+		return nil, fmt.Errorf("error getting network state: %w", err)
+	}
+	response.TotalEthBalance = networkState.NetworkDetails.TotalETHBalance
+	response.SmoothingPoolBalance = networkState.NetworkDetails.SmoothingPoolBalance
+
+	// Approximate the pool stakers' share of the smoothing pool the same way the Oracle DAO does for balance updates
+	elHeader, err := rp.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(networkState.ElBlockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("error getting the header for block %d: %w", networkState.ElBlockNumber, err)
+	}
+	slotTime := networkState.BeaconConfig.GetSlotTime(networkState.BeaconSlotNumber)
+	startTime := networkState.NetworkDetails.IntervalStart
+	response.IntervalElapsed = slotTime.Sub(startTime)
+	intervalsPassed := response.IntervalElapsed / networkState.NetworkDetails.IntervalDuration
+	snapshotEnd := &rprewards.SnapshotEnd{
+		Slot:           networkState.BeaconSlotNumber,
+		ConsensusBlock: networkState.BeaconSlotNumber,
+		ExecutionBlock: networkState.ElBlockNumber,
+	}
+	logger := log.NewColorLogger(AprLogColor)
+	treegen, err := rprewards.NewTreeGenerator(&logger, "[rETH APR]", rprewards.NewRewardsExecutionClient(rp), cfg, bc, networkState.NetworkDetails.RewardIndex, startTime, slotTime, snapshotEnd, elHeader, uint64(intervalsPassed), networkState)
+	if err != nil {
+		return nil, fmt.Errorf("error creating merkle tree generator to approximate share of smoothing pool: %w", err)
+	}
+	response.SmoothingPoolStakerShare, err = treegen.ApproximateStakerShareOfSmoothingPool()
+	if err != nil {
+		return nil, fmt.Errorf("error getting approximate share of smoothing pool: %w", err)
+	}
+	response.SmoothingPoolApr = reth.GetSmoothingPoolApr(response.SmoothingPoolStakerShare, response.TotalEthBalance, response.IntervalElapsed)
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,84 @@
+package reth
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Register subcommands
+func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
+	command.Subcommands = append(command.Subcommands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Monitor rETH from the pool staker's perspective",
+		Subcommands: []cli.Command{
+
+			{
+				Name:      "status",
+				Aliases:   []string{"s"},
+				Usage:     "Get the rETH exchange rate, the next rate update, and the deposit and burn capacity",
+				UsageText: "rocketpool api reth status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getStatus(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "rate-history",
+				Aliases:   []string{"r"},
+				Usage:     "Get the rETH exchange rate recorded over the given number of days",
+				UsageText: "rocketpool api reth rate-history days",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					days, err := cliutils.ValidateUint("days", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRateHistory(c, days))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "apr",
+				Aliases:   []string{"a"},
+				Usage:     "Get the projected rETH APR from the recorded exchange rate and an approximation of the smoothing pool rewards, using the rate recorded over the given number of days",
+				UsageText: "rocketpool api reth apr days",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					days, err := cliutils.ValidateUint("days", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getApr(c, days))
+					return nil
+
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,44 @@
+package reth
+
+import (
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/reth"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getRateHistory(c *cli.Context, days uint64) (*api.RethRateHistoryResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RethRateHistoryResponse{
+		Enabled: cfg.Smartnode.RecordNetworkTotals.Value.(bool),
+		Samples: []state.NetworkTotalsSample{},
+	}
+
+	// Get the exchange rate from the recorded network totals, skipping samples that don't have one
+	since := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+	samples, err := state.LoadNetworkTotals(cfg.Smartnode.GetNetworkTotalsPath(), since)
+	if err != nil {
+		return nil, err
+	}
+	for _, sample := range samples {
+		if sample.RethExchangeRate > 0 {
+			response.Samples = append(response.Samples, sample)
+		}
+	}
+	response.Apr, response.HasApr = reth.GetSamplesApr(response.Samples)
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,159 @@
+package reth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/deposit"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/reth"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getStatus(c *cli.Context) (*api.RethStatusResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RethStatusResponse{}
+
+	// Data
+	var wg errgroup.Group
+	var assignDepositsEnabled bool
+	var maximumPoolSize *big.Int
+	var queueCapacity minipool.QueueCapacity
+
+	// Get the exchange rate and supply
+	wg.Go(func() error {
+		var err error
+		response.ExchangeRate, err = tokens.GetRETHExchangeRate(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.TotalSupply, err = tokens.GetRETHTotalSupply(rp, nil)
+		return err
+	})
+
+	// Get the collateral that can cover burns
+	wg.Go(func() error {
+		var err error
+		response.CollateralRate, err = tokens.GetRETHCollateralRate(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.TargetCollateralRate, err = protocol.GetTargetRethCollateralRate(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.BurnCapacity, err = tokens.GetRETHTotalCollateral(rp, nil)
+		return err
+	})
+
+	// Get the deposit settings
+	wg.Go(func() error {
+		var err error
+		response.DepositEnabled, err = protocol.GetDepositEnabled(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		assignDepositsEnabled, err = protocol.GetAssignDepositsEnabled(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.MinimumDeposit, err = protocol.GetMinimumDeposit(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		maximumPoolSize, err = protocol.GetMaximumDepositPoolSize(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.DepositFee, err = protocol.GetDepositFee(rp, nil)
+		return err
+	})
+
+	// Get the deposit pool and the minipool queue
+	wg.Go(func() error {
+		var err error
+		response.DepositPoolBalance, err = deposit.GetBalance(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		queueCapacity, err = minipool.GetQueueCapacity(rp, nil)
+		return err
+	})
+
+	// Get the rate update settings
+	wg.Go(func() error {
+		var err error
+		response.RateUpdatesEnabled, err = protocol.GetSubmitBalancesEnabled(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.RateUpdateFrequency, err = protocol.GetSubmitBalancesFrequency(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.LastRateUpdateBlock, err = network.GetBalancesBlock(rp, nil)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Get the deposit and burn capacity
+	response.DepositCapacity = reth.GetDepositCapacity(response.DepositEnabled, assignDepositsEnabled, maximumPoolSize, response.DepositPoolBalance, queueCapacity.Effective)
+	response.BurnCapacityReth, err = tokens.GetRETHValueOfETH(rp, response.BurnCapacity, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the rETH value of the burn capacity: %w", err)
+	}
+
+	// Get when the rate was last updated and when it's next due
+	if response.LastRateUpdateBlock > 0 {
+		header, err := rp.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(response.LastRateUpdateBlock))
+		if err != nil {
+			return nil, fmt.Errorf("error getting the header for block %d: %w", response.LastRateUpdateBlock, err)
+		}
+		response.LastRateUpdateTime = time.Unix(int64(header.Time), 0)
+	}
+	if response.RateUpdatesEnabled {
+		reference := time.Unix(cfg.Smartnode.PriceBalanceSubmissionReferenceTimestamp.Value.(int64), 0)
+		response.NextRateUpdateTime = reth.GetNextRateUpdate(response.LastRateUpdateTime, reference, response.RateUpdateFrequency)
+	}
+
+	// Return response
+	return &response, nil
+
+}
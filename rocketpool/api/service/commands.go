@@ -3,6 +3,7 @@ package service
 import (
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
@@ -27,7 +28,9 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(terminateDataFolder(c))
+					response, err := terminateDataFolder(c)
+					services.RecordAuditAction(c, err)
+					api.PrintResponse(response, err)
 					return nil
 
 				},
@@ -69,6 +72,60 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "get-vc-watchdog-status",
+				Usage:     "Gets the result of the validator client watchdog's latest check",
+				UsageText: "rocketpool api service get-vc-watchdog-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getVcWatchdogStatus(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "probe-ec",
+				Usage:     "Probes the Execution client's database and the disk for problems that would fail tree generation",
+				UsageText: "rocketpool api service probe-ec",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(probeEc(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "telemetry-preview",
+				Usage:     "Gets the anonymous telemetry report that would be submitted",
+				UsageText: "rocketpool api service telemetry-preview",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getTelemetryPreview(c))
+					return nil
+
+				},
+			},
 		},
 	})
 }
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/ecprobe"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Probes the Execution client's database and the disk for problems that would fail tree generation
+func probeEc(c *cli.Context) (*api.ProbeEcResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Run the probes
+	report := ecprobe.Run(cfg, rp, cfg.Smartnode.GetRewardsTreeDirectory(true))
+
+	// Response
+	response := api.ProbeEcResponse{
+		Healthy:        report.Healthy,
+		ArchiveEcIsSet: cfg.Smartnode.ArchiveECUrl.Value.(string) != "",
+		Probes:         make([]api.EcProbeResult, len(report.Probes)),
+	}
+	for i, probe := range report.Probes {
+		response.Probes[i] = api.EcProbeResult{
+			Name:     probe.Name,
+			Healthy:  probe.Healthy,
+			Detail:   probe.Detail,
+			Duration: probe.Duration,
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
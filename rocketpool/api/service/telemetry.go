@@ -0,0 +1,40 @@
+package service
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/telemetry"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Gets the telemetry report that would be submitted
+func getTelemetryPreview(c *cli.Context) (*api.TelemetryPreviewResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TelemetryPreviewResponse{
+		Enabled: cfg.Smartnode.EnableTelemetry.Value.(bool),
+		Url:     cfg.Smartnode.TelemetryUrl.Value.(string),
+	}
+
+	// Build the report
+	response.Report = telemetry.NewReport(cfg, ec.CheckStatus(cfg), bc.CheckStatus())
+
+	// Return response
+	return &response, nil
+
+}
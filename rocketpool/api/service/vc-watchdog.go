@@ -0,0 +1,37 @@
+package service
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/vcwatchdog"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Gets the result of the validator client watchdog's latest check
+func getVcWatchdogStatus(c *cli.Context) (*api.VcWatchdogStatusResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.VcWatchdogStatusResponse{}
+	response.Enabled = cfg.Smartnode.VcWatchdogPolicy.Value.(cfgtypes.VcWatchdogPolicy) != cfgtypes.VcWatchdogPolicy_Disabled
+	if !response.Enabled {
+		return &response, nil
+	}
+
+	// Load the latest check
+	response.VcStatus, err = vcwatchdog.LoadStatus(cfg.Smartnode.GetVcWatchdogStatusPath())
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
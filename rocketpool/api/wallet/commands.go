@@ -3,6 +3,7 @@ package wallet
 import (
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
@@ -51,7 +52,9 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(setPassword(c, password))
+					response, err := setPassword(c, password)
+					services.RecordAuditAction(c, err)
+					api.PrintResponse(response, err)
 					return nil
 
 				},
@@ -76,7 +79,9 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(initWallet(c))
+					response, err := initWallet(c)
+					services.RecordAuditAction(c, err)
+					api.PrintResponse(response, err)
 					return nil
 
 				},
@@ -114,7 +119,9 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(recoverWallet(c, mnemonic))
+					response, err := recoverWallet(c, mnemonic)
+					services.RecordAuditAction(c, err)
+					api.PrintResponse(response, err)
 					return nil
 
 				},
@@ -147,7 +154,9 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(searchAndRecoverWallet(c, mnemonic, address))
+					response, err := searchAndRecoverWallet(c, mnemonic, address)
+					services.RecordAuditAction(c, err)
+					api.PrintResponse(response, err)
 					return nil
 
 				},
@@ -166,7 +175,9 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(rebuildWallet(c))
+					response, err := rebuildWallet(c)
+					services.RecordAuditAction(c, err)
+					api.PrintResponse(response, err)
 					return nil
 
 				},
@@ -36,7 +36,7 @@ func rebuildWallet(c *cli.Context) (*api.RebuildWalletResponse, error) {
 	}
 
 	// Recover validator keys
-	response.ValidatorKeys, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, false)
+	response.ValidatorKeys, response.UnmatchedValidatorKeys, err = walletutils.RecoverMinipoolKeys(c, rp, nodeAccount.Address, w, false)
 	if err != nil {
 		return nil, err
 	}
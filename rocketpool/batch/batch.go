@@ -0,0 +1,173 @@
+package batch
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fatih/color"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/onboarding"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+const (
+	BatchColor = color.FgHiGreen
+)
+
+// The outcome of a node's plan
+type nodeResult struct {
+	onboarding.NodePlan
+	Transactions []common.Hash `json:"transactions,omitempty"`
+	Error        string        `json:"error,omitempty"`
+}
+
+// Register batch registration command
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Register and configure the nodes in a manifest, each with its own keystore or remote signer. Without --execute, only the plan is shown.",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "manifest, m",
+				Usage: "The YAML manifest of nodes to register and configure",
+			},
+			cli.BoolFlag{
+				Name:  "execute, x",
+				Usage: "Send the planned transactions instead of only showing them",
+			},
+			cli.StringFlag{
+				Name:  "output, o",
+				Usage: "The file to write each node's plan and results to, as JSON",
+			},
+			cli.Float64Flag{
+				Name:  "max-fee, f",
+				Usage: "The max fee (including the priority fee) you want each transaction to use, in gwei. Leave it unset to use the Execution client's suggestion.",
+			},
+			cli.Float64Flag{
+				Name:  "max-priority-fee, i",
+				Usage: "The max priority fee you want each transaction to use, in gwei. Leave it unset to use the Execution client's suggestion.",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			return run(c)
+		},
+	})
+}
+
+// Plan and optionally execute the manifest
+func run(c *cli.Context) error {
+
+	if c.String("manifest") == "" {
+		return fmt.Errorf("the --manifest flag is required")
+	}
+	logger := log.NewColorLogger(BatchColor)
+
+	// Load the manifest and make sure it's for this chain
+	manifest, err := onboarding.LoadManifest(c.String("manifest"))
+	if err != nil {
+		return err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	chainID := big.NewInt(int64(cfg.Smartnode.GetChainID()))
+	if chainID.Uint64() != manifest.ChainID {
+		return fmt.Errorf("the manifest is for chain %d, but the Smartnode is configured for chain %d", manifest.ChainID, chainID.Uint64())
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return err
+	}
+
+	// Connect to every node's signer up front, so a bad keystore or unreachable signer is caught before anything is sent
+	signers := make([]*onboarding.NodeSigner, len(manifest.Nodes))
+	defer func() {
+		for _, signer := range signers {
+			if signer != nil {
+				signer.Close()
+			}
+		}
+	}()
+	for i, entry := range manifest.Nodes {
+		signers[i], err = onboarding.NewNodeSigner(entry, chainID)
+		if err != nil {
+			return fmt.Errorf("node [%s]: %w", entry.Name, err)
+		}
+	}
+
+	// Plan each node
+	results := make([]*nodeResult, len(manifest.Nodes))
+	stepCount := 0
+	for i, entry := range manifest.Nodes {
+		state, err := onboarding.GetNodeChainState(rp, signers[i].Address, nil)
+		if err != nil {
+			return fmt.Errorf("node [%s]: %w", entry.Name, err)
+		}
+		plan := onboarding.PlanNode(entry, signers[i].Address, state)
+		results[i] = &nodeResult{NodePlan: plan}
+		stepCount += len(plan.Steps)
+
+		logger.Printlnf("%s (%s):", plan.Name, plan.Address.Hex())
+		if len(plan.Steps) == 0 && len(plan.Problems) == 0 {
+			logger.Println("\tAlready matches the manifest.")
+		}
+		for _, step := range plan.Steps {
+			logger.Printlnf("\t%s", step.Description)
+		}
+		for _, problem := range plan.Problems {
+			logger.Printlnf("\tPROBLEM: %s", problem)
+		}
+	}
+	logger.Printlnf("%d transaction(s) planned across %d node(s).", stepCount, len(results))
+
+	// Execute each node's plan
+	if c.Bool("execute") {
+		var maxFee, maxPriorityFee *big.Int
+		if c.IsSet("max-fee") {
+			maxFee = eth.GweiToWei(c.Float64("max-fee"))
+		}
+		if c.IsSet("max-priority-fee") {
+			maxPriorityFee = eth.GweiToWei(c.Float64("max-priority-fee"))
+		}
+		failures := 0
+		for i, result := range results {
+			if len(result.Steps) == 0 && len(result.Problems) == 0 {
+				continue
+			}
+			opts := signers[i].GetTransactor(maxFee, maxPriorityFee)
+			result.Transactions, err = onboarding.ExecutePlan(rp, result.NodePlan, opts, func(message string) {
+				logger.Println(message)
+			})
+			if err != nil {
+				// Keep going, since the nodes are independent
+				result.Error = err.Error()
+				failures++
+				logger.Printlnf("%s failed: %s", result.Name, err.Error())
+			}
+		}
+		logger.Printlnf("Done; %d node(s) failed.", failures)
+	}
+
+	// Save the plan and results
+	if c.String("output") != "" {
+		bytes, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error serializing results: %w", err)
+		}
+		if err := os.WriteFile(c.String("output"), bytes, 0644); err != nil {
+			return fmt.Errorf("error writing results to %s: %w", c.String("output"), err)
+		}
+		logger.Printlnf("Saved the results to %s.", c.String("output"))
+	}
+	return nil
+
+}
@@ -0,0 +1,72 @@
+package cacheproxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/cacheproxy"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+const (
+	CacheProxyColor = color.FgHiCyan
+
+	// The most responses to hold in memory at once
+	maxCacheEntries int = 10000
+
+	// How often to log the cache statistics
+	statsInterval time.Duration = 10 * time.Minute
+)
+
+// Register cache proxy command
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Run a caching proxy that deduplicates identical Execution client and Beacon Node requests from the other Smartnode daemons",
+		Action: func(c *cli.Context) error {
+			return run(c)
+		},
+	})
+}
+
+// Run the proxy
+func run(c *cli.Context) error {
+
+	// Get the clients to forward to
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	ecUrl, bcUrl, err := services.GetPrimaryClientUrls(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Start watching the chain heads
+	logger := log.NewColorLogger(CacheProxyColor)
+	proxy := cacheproxy.NewProxy(ecUrl, bcUrl, maxCacheEntries, &logger)
+	go proxy.WatchHeads(context.Background())
+
+	// Log the hit rate now and then
+	go func() {
+		for {
+			time.Sleep(statsInterval)
+			hits, misses := proxy.GetStats()
+			logger.Printlnf("Cache hits: %d, misses: %d.", hits, misses)
+		}
+	}()
+
+	// Serve
+	listenAddress := fmt.Sprintf("0.0.0.0:%d", cfg.Smartnode.CachingProxyPort.Value)
+	logger.Printlnf("Caching requests to %s and %s on %s.", ecUrl, bcUrl, listenAddress)
+	return http.ListenAndServe(listenAddress, proxy.Handler())
+
+}
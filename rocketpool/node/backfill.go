@@ -0,0 +1,160 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/backfill"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/store"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Backfill settings
+const (
+	backfillStepDelay           = 2 * time.Second
+	backfillMaxStepsPerRun      = 10
+	claimIndexBackfillBatchSize = 100000
+)
+
+// Backfill historical data task
+type runBackfill struct {
+	c            *cli.Context
+	log          log.ColorLogger
+	cfg          *config.RocketPoolConfig
+	w            *wallet.Wallet
+	rp           *rocketpool.RocketPool
+	orchestrator *backfill.Orchestrator
+}
+
+// Create backfill historical data task
+func newRunBackfill(c *cli.Context, logger log.ColorLogger, daemonStore *store.Store) (*runBackfill, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the orchestrator
+	task := &runBackfill{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		w:   w,
+		rp:  rp,
+	}
+	task.orchestrator, err = backfill.NewOrchestrator(daemonStore, cfg.Smartnode.GetBackfillProgressPath(), backfillStepDelay, backfillMaxStepsPerRun, &task.log)
+	if err != nil {
+		return nil, err
+	}
+
+	// Register the jobs
+	task.orchestrator.Register(&claimIndexBackfillJob{
+		cfg: cfg,
+		w:   w,
+		rp:  rp,
+	})
+
+	// Return task
+	return task, nil
+
+}
+
+// Run any pending backfill jobs
+func (t *runBackfill) run(state *state.NetworkState) error {
+
+	if t.orchestrator.IsComplete() {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Backfilling historical chain data...")
+
+	// Run the jobs
+	return t.orchestrator.Run()
+
+}
+
+// Builds the node's reward claim index from the distributor's event history
+type claimIndexBackfillJob struct {
+	cfg *config.RocketPoolConfig
+	w   *wallet.Wallet
+	rp  *rocketpool.RocketPool
+
+	// Cached on the first step
+	nodeAddress        common.Address
+	earliestClaimBlock *big.Int
+	initialized        bool
+}
+
+func (j *claimIndexBackfillJob) Name() string {
+	return "claim-index"
+}
+
+func (j *claimIndexBackfillJob) Priority() int {
+	return 0
+}
+
+func (j *claimIndexBackfillJob) Step() (bool, error) {
+
+	// Find the snapshot block of the earliest claimed interval
+	if !j.initialized {
+		nodeAccount, err := j.w.GetNodeAccount()
+		if err != nil {
+			return false, err
+		}
+		_, claimed, err := rprewards.GetClaimStatus(j.rp, nodeAccount.Address)
+		if err != nil {
+			return false, err
+		}
+		if len(claimed) == 0 {
+			// Nothing has been claimed yet so there's no history to backfill
+			return true, nil
+		}
+		event, err := rprewards.NewRewardsExecutionClient(j.rp).GetRewardSnapshotEvent(j.cfg.Smartnode.GetPreviousRewardsPoolAddresses(), claimed[0], nil)
+		if err != nil {
+			return false, fmt.Errorf("error getting the rewards event for interval %d: %w", claimed[0], err)
+		}
+		j.nodeAddress = nodeAccount.Address
+		j.earliestClaimBlock = event.ExecutionBlock
+		j.initialized = true
+	}
+
+	// Index the next batch of blocks
+	eventLogInterval, err := j.cfg.GetEventLogInterval()
+	if err != nil {
+		return false, err
+	}
+	index, err := rprewards.LoadClaimIndex(j.cfg.Smartnode.GetClaimIndexPath())
+	if err != nil {
+		return false, err
+	}
+	complete, err := index.UpdateBatch(j.rp, j.nodeAddress, j.earliestClaimBlock, big.NewInt(int64(eventLogInterval)), claimIndexBackfillBatchSize)
+	if err != nil {
+		return false, fmt.Errorf("error updating claim index: %w", err)
+	}
+	err = rprewards.SaveClaimIndex(j.cfg.Smartnode.GetClaimIndexPath(), index)
+	if err != nil {
+		return false, err
+	}
+	return complete, nil
+
+}
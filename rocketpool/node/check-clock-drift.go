@@ -0,0 +1,107 @@
+package node
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/clock"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often to check the clock
+const clockDriftCheckInterval time.Duration = 5 * time.Minute
+
+// How long to wait for an NTP server to answer
+const ntpQueryTimeout time.Duration = 5 * time.Second
+
+// How often to re-send the alert while the clock is drifting
+const clockDriftAlertInterval time.Duration = 6 * time.Hour
+
+// Check clock drift task
+type checkClockDrift struct {
+	c             *cli.Context
+	log           log.ColorLogger
+	cfg           *config.RocketPoolConfig
+	monitor       *clock.Monitor
+	lastRun       time.Time
+	lastAlertTime time.Time
+}
+
+// Create check clock drift task
+func newCheckClockDrift(c *cli.Context, logger log.ColorLogger) (*checkClockDrift, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the NTP servers
+	servers := []string{}
+	for _, server := range strings.Split(cfg.Smartnode.NtpServers.Value.(string), ",") {
+		server = strings.TrimSpace(server)
+		if server != "" {
+			servers = append(servers, server)
+		}
+	}
+	threshold := time.Duration(cfg.Smartnode.ClockDriftThresholdMs.Value.(uint64)) * time.Millisecond
+
+	// Return task
+	return &checkClockDrift{
+		c:       c,
+		log:     logger,
+		cfg:     cfg,
+		monitor: clock.NewMonitor(servers, threshold, ntpQueryTimeout),
+	}, nil
+
+}
+
+// Check the system clock against NTP, pausing the slot-sensitive tasks and alerting while it's drifting
+func (t *checkClockDrift) run(state *state.NetworkState) error {
+
+	if time.Since(t.lastRun) < clockDriftCheckInterval {
+		return nil
+	}
+	t.lastRun = time.Now()
+
+	// Check the clock; if no server answers, its state is unknown, so the tasks aren't paused
+	status, err := t.monitor.Check()
+	if err != nil {
+		return fmt.Errorf("error checking the system clock, slot-sensitive tasks will not be paused: %w", err)
+	}
+	if !status.Drifting {
+		if status.Changed {
+			t.log.Printlnf("The system clock is back in sync with %s (%s away), resuming slot-sensitive tasks.", status.Server, status.Offset.Abs().Round(time.Millisecond))
+			if err := alerting.AlertClockDriftResolved(t.cfg, status.Offset, status.Server); err != nil {
+				t.log.Printlnf("Error sending clock drift resolved alert: %s", err.Error())
+			}
+		}
+		return nil
+	}
+	if status.Changed {
+		t.log.Printlnf("WARNING: the system clock is %s away from %s. Slot-sensitive tasks will be paused until it's back in sync; make sure a time synchronization service is running.", status.Offset.Abs().Round(time.Millisecond), status.Server)
+	}
+
+	// Don't repeat the alert too often
+	if !status.Changed && time.Since(t.lastAlertTime) < clockDriftAlertInterval {
+		return nil
+	}
+	if err := alerting.AlertClockDrift(t.cfg, status.Offset, status.Server); err != nil {
+		t.log.Printlnf("Error sending clock drift alert: %s", err.Error())
+	}
+	t.lastAlertTime = time.Now()
+	return nil
+
+}
+
+// Get whether the slot-sensitive tasks should be paused because the clock is drifting
+func (t *checkClockDrift) isDrifting() bool {
+	return t.monitor.IsDrifting()
+}
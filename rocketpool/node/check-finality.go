@@ -0,0 +1,130 @@
+package node
+
+import (
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/finality"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often to re-send the alert while finality is stalled
+const finalityStalledAlertInterval time.Duration = 30 * time.Minute
+
+// How far ahead to project the inactivity leak (one day)
+const inactivityLeakProjectionEpochs uint64 = 225
+
+// Check finality task
+type checkFinality struct {
+	c             *cli.Context
+	log           log.ColorLogger
+	cfg           *config.RocketPoolConfig
+	w             *wallet.Wallet
+	monitor       *finality.Monitor
+	lastAlertTime time.Time
+}
+
+// Create check finality task
+func newCheckFinality(c *cli.Context, logger log.ColorLogger) (*checkFinality, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkFinality{
+		c:       c,
+		log:     logger,
+		cfg:     cfg,
+		w:       w,
+		monitor: finality.NewMonitor(bc, cfg.Smartnode.FinalityStallEpochs.Value.(uint64)),
+	}, nil
+
+}
+
+// Check the chain's finality, switching in and out of degraded mode and alerting on stalls
+func (t *checkFinality) run(state *state.NetworkState) error {
+
+	// Check finality
+	status, err := t.monitor.Check()
+	if err != nil {
+		return err
+	}
+	if !status.Stalled {
+		if status.Changed {
+			t.log.Printlnf("The Beacon Chain has finalized epoch %d, leaving degraded mode.", status.FinalizedEpoch)
+			if err := alerting.AlertFinalityRecovered(t.cfg, status.FinalizedEpoch); err != nil {
+				t.log.Printlnf("Error sending finality recovered alert: %s", err.Error())
+			}
+		}
+		return nil
+	}
+	if status.Changed {
+		t.log.Printlnf("WARNING: the Beacon Chain hasn't finalized since epoch %d (%d epochs ago). Entering degraded mode; snapshot-dependent tasks will be paused until it finalizes again.", status.FinalizedEpoch, status.EpochsSinceFinality)
+	}
+
+	// Don't repeat the alert too often
+	if !status.Changed && time.Since(t.lastAlertTime) < finalityStalledAlertInterval {
+		return nil
+	}
+
+	// Project the inactivity leak for the node's active validators
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	validatorCount := 0
+	projectedLossGwei := uint64(0)
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAccount.Address] {
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !isActiveValidator(validator) {
+			continue
+		}
+		validatorCount++
+		projectedLossGwei += finality.ProjectInactivityLeak(validator.EffectiveBalance, status.EpochsSinceFinality, inactivityLeakProjectionEpochs)
+	}
+	projectedLossEth := eth.WeiToEth(eth.GweiToWei(float64(projectedLossGwei)))
+	if validatorCount > 0 {
+		t.log.Printlnf("If the node's %d active validator(s) go offline now and finality doesn't return for another %d epochs, they will lose about %.6f ETH to the inactivity leak.", validatorCount, inactivityLeakProjectionEpochs, projectedLossEth)
+	}
+
+	if err := alerting.AlertFinalityStalled(t.cfg, status.EpochsSinceFinality, validatorCount, projectedLossEth, inactivityLeakProjectionEpochs); err != nil {
+		t.log.Printlnf("Error sending finality stalled alert: %s", err.Error())
+	}
+	t.lastAlertTime = time.Now()
+	return nil
+
+}
+
+// Get whether the node is in degraded mode because finality is stalled
+func (t *checkFinality) isDegraded() bool {
+	return t.monitor.IsStalled()
+}
+
+// Check if a validator is active on the Beacon Chain, and therefore subject to the inactivity leak
+func isActiveValidator(validator beacon.ValidatorStatus) bool {
+	switch validator.Status {
+	case beacon.ValidatorState_ActiveOngoing, beacon.ValidatorState_ActiveExiting, beacon.ValidatorState_ActiveSlashed:
+		return true
+	default:
+		return false
+	}
+}
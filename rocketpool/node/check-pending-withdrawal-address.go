@@ -0,0 +1,86 @@
+package node
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often to re-send the alert while a withdrawal address change is still pending
+const pendingWithdrawalAddressAlertInterval time.Duration = 30 * time.Minute
+
+// Check pending withdrawal address task
+type checkPendingWithdrawalAddress struct {
+	c             *cli.Context
+	log           log.ColorLogger
+	cfg           *config.RocketPoolConfig
+	w             *wallet.Wallet
+	lastPending   common.Address
+	lastAlertTime time.Time
+}
+
+// Create check pending withdrawal address task
+func newCheckPendingWithdrawalAddress(c *cli.Context, logger log.ColorLogger) (*checkPendingWithdrawalAddress, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkPendingWithdrawalAddress{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		w:   w,
+	}, nil
+
+}
+
+// Check for a pending withdrawal address change and alert on it
+func (t *checkPendingWithdrawalAddress) run(state *state.NetworkState) error {
+
+	// Get the node's details
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	details, exists := state.NodeDetailsByAddress[nodeAccount.Address]
+	if !exists || !details.Exists {
+		return nil
+	}
+
+	// Nothing to do if there's no pending change
+	pending := details.PendingWithdrawalAddress
+	if pending == (common.Address{}) {
+		t.lastPending = common.Address{}
+		return nil
+	}
+
+	// Don't repeat the alert too often for the same address
+	if pending == t.lastPending && time.Since(t.lastAlertTime) < pendingWithdrawalAddressAlertInterval {
+		return nil
+	}
+
+	t.log.Printlnf("WARNING: the node's primary withdrawal address has a pending change to %s which has not been confirmed yet.", pending.Hex())
+	if err := alerting.AlertPendingWithdrawalAddress(t.cfg, nodeAccount.Address, pending); err != nil {
+		t.log.Printlnf("Error sending pending withdrawal address alert: %s", err.Error())
+	}
+	t.lastPending = pending
+	t.lastAlertTime = time.Now()
+	return nil
+
+}
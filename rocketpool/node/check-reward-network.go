@@ -0,0 +1,103 @@
+package node
+
+import (
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// How often to re-send the alert while the node's reward network is still invalid
+const invalidRewardNetworkAlertInterval time.Duration = 6 * time.Hour
+
+// Check reward network task
+type checkRewardNetwork struct {
+	c             *cli.Context
+	log           log.ColorLogger
+	cfg           *config.RocketPoolConfig
+	w             *wallet.Wallet
+	rp            *rocketpool.RocketPool
+	lastInvalid   uint64
+	lastAlertTime time.Time
+}
+
+// Create check reward network task
+func newCheckRewardNetwork(c *cli.Context, logger log.ColorLogger) (*checkRewardNetwork, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkRewardNetwork{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		w:   w,
+		rp:  rp,
+	}, nil
+
+}
+
+// Check that the node's reward network is enabled, and alert if it isn't
+func (t *checkRewardNetwork) run(state *state.NetworkState) error {
+
+	// Get the node's details
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	details, exists := state.NodeDetailsByAddress[nodeAccount.Address]
+	if !exists || !details.Exists || details.RewardNetwork == nil {
+		return nil
+	}
+
+	// Network 0 is Ethereum itself, which is always valid
+	network := details.RewardNetwork.Uint64()
+	if network == 0 {
+		t.lastAlertTime = time.Time{}
+		return nil
+	}
+	enabled, err := rputils.IsRewardNetworkEnabled(t.rp, network, nil)
+	if err != nil {
+		return err
+	}
+	if enabled {
+		t.lastAlertTime = time.Time{}
+		return nil
+	}
+
+	// Don't repeat the alert too often for the same network
+	if network == t.lastInvalid && time.Since(t.lastAlertTime) < invalidRewardNetworkAlertInterval {
+		return nil
+	}
+
+	nextInterval := state.NetworkDetails.IntervalStart.Add(state.NetworkDetails.IntervalDuration)
+	t.log.Printlnf("WARNING: the node's rewards are routed to network %d, which is not enabled. They will be paid out on Ethereum (network 0) instead.", network)
+	t.log.Printlnf("Run `rocketpool node reward-network` to set a valid network before the current interval ends at %s.", nextInterval.Local().Format(time.RFC1123))
+	if err := alerting.AlertInvalidRewardNetwork(t.cfg, nodeAccount.Address, network, nextInterval); err != nil {
+		t.log.Printlnf("Error sending invalid reward network alert: %s", err.Error())
+	}
+	t.lastInvalid = network
+	t.lastAlertTime = time.Now()
+	return nil
+
+}
@@ -0,0 +1,155 @@
+package collectors
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+)
+
+// Represents the collector for the attestation effectiveness of the node's validators
+type EffectivenessCollector struct {
+	// The rewards interval the effectiveness was scored over
+	interval *prometheus.Desc
+
+	// The effectiveness of each validator
+	effectiveness *prometheus.Desc
+
+	// The fraction of each validator's duties that were included on chain
+	participation *prometheus.Desc
+
+	// The mean inclusion delay of each validator's attestations
+	inclusionDelay *prometheus.Desc
+
+	// The combined effectiveness of all of the node's validators
+	nodeEffectiveness *prometheus.Desc
+
+	// The Smartnode config
+	cfg *config.RocketPoolConfig
+
+	// The node's address
+	nodeAddress common.Address
+
+	// The thread-safe locker for the network state
+	stateLocker *StateLocker
+
+	// The effectiveness file for the latest interval, which only changes once per interval
+	cachedFile *rprewards.ValidatorEffectivenessFile
+	cacheLock  sync.Mutex
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new EffectivenessCollector instance
+func NewEffectivenessCollector(cfg *config.RocketPoolConfig, nodeAddress common.Address, stateLocker *StateLocker) *EffectivenessCollector {
+	subsystem := "effectiveness"
+	return &EffectivenessCollector{
+		interval: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "interval"),
+			"The rewards interval the effectiveness metrics were scored over",
+			nil, nil,
+		),
+		effectiveness: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "validator"),
+			"The attestation effectiveness of the validator over the latest rewards interval, as a fraction",
+			[]string{"minipool", "pubkey"}, nil,
+		),
+		participation: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "validator_participation"),
+			"The fraction of the validator's attestations that were included on chain over the latest rewards interval",
+			[]string{"minipool", "pubkey"}, nil,
+		),
+		inclusionDelay: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "validator_inclusion_delay"),
+			"The mean inclusion delay of the validator's attestations over the latest rewards interval, in slots",
+			[]string{"minipool", "pubkey"}, nil,
+		),
+		nodeEffectiveness: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "node"),
+			"The combined attestation effectiveness of the node's validators over the latest rewards interval, as a fraction",
+			nil, nil,
+		),
+		cfg:         cfg,
+		nodeAddress: nodeAddress,
+		stateLocker: stateLocker,
+		logPrefix:   "Effectiveness Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *EffectivenessCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.interval
+	channel <- collector.effectiveness
+	channel <- collector.participation
+	channel <- collector.inclusionDelay
+	channel <- collector.nodeEffectiveness
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *EffectivenessCollector) Collect(channel chan<- prometheus.Metric) {
+	// Get the latest state
+	state := collector.stateLocker.GetState()
+	if state == nil {
+		return
+	}
+
+	// Get the latest completed interval
+	if state.NetworkDetails.RewardIndex == 0 {
+		return
+	}
+	interval := state.NetworkDetails.RewardIndex - 1
+
+	// Get the effectiveness file for it
+	effectivenessFile, err := collector.getEffectivenessFile(interval)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			collector.logError(err)
+		}
+		return
+	}
+
+	// Get the node's validators
+	minipools := []common.Address{}
+	for _, mpd := range state.MinipoolDetailsByNode[collector.nodeAddress] {
+		minipools = append(minipools, mpd.MinipoolAddress)
+	}
+	validators := effectivenessFile.Filter(minipools)
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.interval, prometheus.GaugeValue, float64(interval))
+	channel <- prometheus.MustNewConstMetric(
+		collector.nodeEffectiveness, prometheus.GaugeValue, rprewards.GetCombinedEffectiveness(validators))
+	for _, validator := range validators {
+		minipool := validator.Minipool.Hex()
+		channel <- prometheus.MustNewConstMetric(
+			collector.effectiveness, prometheus.GaugeValue, validator.Effectiveness, minipool, validator.Pubkey)
+		channel <- prometheus.MustNewConstMetric(
+			collector.participation, prometheus.GaugeValue, validator.Participation, minipool, validator.Pubkey)
+		if effectivenessFile.HasInclusionDelays {
+			channel <- prometheus.MustNewConstMetric(
+				collector.inclusionDelay, prometheus.GaugeValue, validator.AverageInclusionDelay, minipool, validator.Pubkey)
+		}
+	}
+}
+
+// Get the effectiveness file for an interval, loading it if it isn't cached
+func (collector *EffectivenessCollector) getEffectivenessFile(interval uint64) (*rprewards.ValidatorEffectivenessFile, error) {
+	collector.cacheLock.Lock()
+	defer collector.cacheLock.Unlock()
+
+	if collector.cachedFile != nil && collector.cachedFile.Index == interval {
+		return collector.cachedFile, nil
+	}
+	effectivenessFile, err := rprewards.GetValidatorEffectiveness(collector.cfg, interval, true)
+	if err != nil {
+		return nil, err
+	}
+	collector.cachedFile = effectivenessFile
+	return effectivenessFile, nil
+}
+
+// Log error messages
+func (collector *EffectivenessCollector) logError(err error) {
+	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+}
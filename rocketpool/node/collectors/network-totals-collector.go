@@ -0,0 +1,101 @@
+package collectors
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// Represents the collector for the recorded network totals
+type NetworkTotalsCollector struct {
+	// The epoch of the latest sample
+	epoch *prometheus.Desc
+
+	// The total weight of every node on the network
+	totalNodeWeight *prometheus.Desc
+
+	// The ETH balance of the smoothing pool
+	smoothingPoolBalance *prometheus.Desc
+
+	// The rETH exchange rate
+	rethExchangeRate *prometheus.Desc
+
+	// The number of active minipools
+	activeMinipools *prometheus.Desc
+
+	// The Smartnode config
+	cfg *config.RocketPoolConfig
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new NetworkTotalsCollector instance
+func NewNetworkTotalsCollector(cfg *config.RocketPoolConfig) *NetworkTotalsCollector {
+	subsystem := "network_totals"
+	return &NetworkTotalsCollector{
+		epoch: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "epoch"),
+			"The epoch of the latest recorded network totals",
+			nil, nil,
+		),
+		totalNodeWeight: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "total_node_weight"),
+			"The total weight of every node on the network",
+			nil, nil,
+		),
+		smoothingPoolBalance: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "smoothing_pool_eth_balance"),
+			"The ETH balance of the smoothing pool",
+			nil, nil,
+		),
+		rethExchangeRate: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "reth_exchange_rate"),
+			"The ETH value of 1 rETH",
+			nil, nil,
+		),
+		activeMinipools: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "active_minipools"),
+			"The number of staking minipools on the network",
+			nil, nil,
+		),
+		cfg:       cfg,
+		logPrefix: "Network Totals Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *NetworkTotalsCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.epoch
+	channel <- collector.totalNodeWeight
+	channel <- collector.smoothingPoolBalance
+	channel <- collector.rethExchangeRate
+	channel <- collector.activeMinipools
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *NetworkTotalsCollector) Collect(channel chan<- prometheus.Metric) {
+	// Get the latest sample
+	sample, err := state.LoadLatestNetworkTotalsSample(collector.cfg.Smartnode.GetNetworkTotalsPath())
+	if err != nil {
+		collector.logError(err)
+		return
+	}
+	if sample == nil {
+		return
+	}
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.epoch, prometheus.GaugeValue, float64(sample.Epoch))
+	channel <- prometheus.MustNewConstMetric(
+		collector.totalNodeWeight, prometheus.GaugeValue, eth.WeiToEth(sample.TotalNodeWeight))
+	channel <- prometheus.MustNewConstMetric(
+		collector.smoothingPoolBalance, prometheus.GaugeValue, eth.WeiToEth(sample.SmoothingPoolBalance))
+	channel <- prometheus.MustNewConstMetric(
+		collector.rethExchangeRate, prometheus.GaugeValue, sample.RethExchangeRate)
+	channel <- prometheus.MustNewConstMetric(
+		collector.activeMinipools, prometheus.GaugeValue, float64(sample.ActiveMinipools))
+}
+
+// Log error messages
+func (collector *NetworkTotalsCollector) logError(err error) {
+	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+}
@@ -3,6 +3,7 @@ package node
 import (
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/docker/docker/client"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -19,12 +20,20 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/gas/thresholds"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/sweep"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
+// Minipools whose next sweep is closer than this aren't distributed yet, so one distribution covers the incoming ETH
+var sweepDeferWindow, _ = time.ParseDuration("6h")
+
+// How often to re-measure the withdrawal sweep; its position is extrapolated in between
+var sweepMeasureInterval, _ = time.ParseDuration("1h")
+
 // Distribute minipools task
 type distributeMinipools struct {
 	c                   *cli.Context
@@ -41,6 +50,8 @@ type distributeMinipools struct {
 	maxFee              *big.Int
 	maxPriorityFee      *big.Int
 	gasLimit            uint64
+	sweepProgress       *sweep.Progress
+	sweepMeasured       time.Time
 }
 
 // Create distribute minipools task
@@ -69,7 +80,10 @@ func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distribute
 	}
 
 	// Check if auto-distributing is disabled
-	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
+	gasThreshold, err := thresholds.GetTaskThreshold(cfg, thresholds.Task_DistributeMinipools, cfg.Smartnode.AutoTxGasThreshold.Value.(float64))
+	if err != nil {
+		return nil, err
+	}
 	distributeThreshold := cfg.Smartnode.DistributeThreshold.Value.(float64)
 	disabled := false
 	if gasThreshold == 0 {
@@ -196,9 +210,13 @@ func (t *distributeMinipools) getDistributableMinipools(nodeAddress common.Addre
 			// Ignore minipools with distributable balances >= 8 ETH
 			continue
 		}
-		if mpd.DistributableBalance.Cmp(t.distributeThreshold) >= 0 {
-			distributableMinipools = append(distributableMinipools, mpd)
+		if mpd.DistributableBalance.Cmp(t.distributeThreshold) < 0 {
+			continue
+		}
+		if t.isSweepImminent(mpd, state) {
+			continue
 		}
+		distributableMinipools = append(distributableMinipools, mpd)
 	}
 
 	// Return
@@ -206,6 +224,41 @@ func (t *distributeMinipools) getDistributableMinipools(nodeAddress common.Addre
 
 }
 
+// Check if the withdrawal sweep is about to send more ETH to a minipool, in which case it's cheaper to distribute it
+// afterwards. If the sweep can't be measured, minipools are distributed as usual.
+func (t *distributeMinipools) isSweepImminent(mpd *rpstate.NativeMinipoolDetails, state *state.NetworkState) bool {
+	validator, exists := state.ValidatorDetails[mpd.Pubkey]
+	if !exists || !validator.Exists {
+		return false
+	}
+
+	// Measure the sweep if it's been a while
+	if t.sweepProgress == nil || time.Since(t.sweepMeasured) > sweepMeasureInterval {
+		progress, err := sweep.Measure(t.bc, state.BeaconSlotNumber, sweep.DefaultSampleSlots)
+		if err != nil {
+			t.log.Printlnf("WARNING: couldn't measure the withdrawal sweep: %s", err.Error())
+			return false
+		}
+		t.sweepProgress = &progress
+		t.sweepMeasured = time.Now()
+	}
+
+	forecast, err := t.sweepProgress.ForecastValidator(validator, state.BeaconSlotNumber)
+	if err != nil {
+		t.log.Printlnf("WARNING: couldn't forecast the withdrawal sweep for minipool %s: %s", mpd.MinipoolAddress.Hex(), err.Error())
+		return false
+	}
+	if forecast.AmountGwei == 0 {
+		return false
+	}
+	timeUntilSweep := time.Duration((forecast.Slot-state.BeaconSlotNumber)*state.BeaconConfig.SecondsPerSlot) * time.Second
+	if timeUntilSweep > sweepDeferWindow {
+		return false
+	}
+	t.log.Printlnf("Minipool %s will be swept in about %s, so it will be distributed afterwards.", mpd.MinipoolAddress.Hex(), timeUntilSweep.Round(time.Minute))
+	return true
+}
+
 // Distribute a minipool
 func (t *distributeMinipools) distributeMinipool(mpd *rpstate.NativeMinipoolDetails, callOpts *bind.CallOpts) (bool, error) {
 
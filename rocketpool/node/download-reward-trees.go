@@ -2,7 +2,6 @@ package node
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/docker/docker/client"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
@@ -67,7 +66,8 @@ func newDownloadRewardsTrees(c *cli.Context, logger log.ColorLogger) (*downloadR
 
 }
 
-// Manage fee recipient
+// Record new interval submissions in the interval registry, and download any rewards tree files that are missing or don't
+// match their submission
 func (d *downloadRewardsTrees) run(state *state.NetworkState) error {
 
 	// Wait for eth client to sync
@@ -75,6 +75,22 @@ func (d *downloadRewardsTrees) run(state *state.NetworkState) error {
 		return err
 	}
 
+	// Update the interval registry
+	registryPath := d.cfg.Smartnode.GetIntervalRegistryPath(true)
+	registry, err := rprewards.LoadIntervalRegistry(registryPath)
+	if err != nil {
+		return err
+	}
+	added, err := registry.Update(d.rp, d.cfg, state.NetworkDetails.RewardIndex)
+	if added > 0 {
+		if saveErr := rprewards.SaveIntervalRegistry(registryPath, registry); saveErr != nil {
+			return saveErr
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("error updating interval registry: %w", err)
+	}
+
 	// Check if the user opted into downloading rewards files
 	if d.cfg.Smartnode.RewardsTreeMode.Value.(cfgtypes.RewardsMode) != cfgtypes.RewardsMode_Download {
 		return nil
@@ -83,40 +99,27 @@ func (d *downloadRewardsTrees) run(state *state.NetworkState) error {
 	// Log
 	d.log.Println("Checking for new rewards tree files to download...")
 
-	// Get node account
-	nodeAccount, err := d.w.GetNodeAccount()
-	if err != nil {
-		return err
-	}
-
-	// Get the current interval
-	currentIndex := state.NetworkDetails.RewardIndex
-
-	// Check for missing intervals
-	missingIntervals := []uint64{}
-	for i := uint64(0); i < currentIndex; i++ {
-		// Check if the tree file exists
-		treeFilePath := d.cfg.Smartnode.GetRewardsTreePath(i, true, config.RewardsExtensionJSON)
-		_, err = os.Stat(treeFilePath)
-		if os.IsNotExist(err) {
-			d.log.Printlnf("You are missing the rewards tree file for interval %d.", i)
-			missingIntervals = append(missingIntervals, i)
-		} else if err != nil {
-			return fmt.Errorf("error checking if rewards interval %d file exists: %w", i, err)
+	// Check for missing or inconsistent intervals
+	downloads := []rprewards.IntervalSubmission{}
+	for _, submission := range registry.GetSubmissions() {
+		consistency, err := submission.CheckLocalFile(d.cfg, true)
+		switch consistency {
+		case rprewards.IntervalConsistency_Consistent:
+			continue
+		case rprewards.IntervalConsistency_Missing:
+			d.log.Printlnf("You are missing the rewards tree file for interval %d.", submission.Index)
+		case rprewards.IntervalConsistency_Unreadable:
+			d.log.Printlnf("The rewards tree file for interval %d can't be read (%s), it will be downloaded again.", submission.Index, err.Error())
+		case rprewards.IntervalConsistency_RootMismatch:
+			d.log.Printlnf("The rewards tree file for interval %d doesn't match its on-chain Merkle root, it will be downloaded again.", submission.Index)
 		}
+		downloads = append(downloads, submission)
 	}
 
-	if len(missingIntervals) == 0 {
-		return nil
-	}
-
-	// Download missing intervals
-	for _, missingInterval := range missingIntervals {
-		fmt.Printf("Downloading interval %d file... ", missingInterval)
-		intervalInfo, err := rprewards.GetIntervalInfo(d.rp, d.cfg, nodeAccount.Address, missingInterval, nil)
-		if err != nil {
-			return fmt.Errorf("error getting interval %d info: %w", missingInterval, err)
-		}
+	// Download them
+	for _, submission := range downloads {
+		fmt.Printf("Downloading interval %d file... ", submission.Index)
+		intervalInfo := submission.GetIntervalInfo()
 		err = intervalInfo.DownloadRewardsFile(d.cfg, true)
 		if err != nil {
 			fmt.Println()
@@ -0,0 +1,229 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rputils "github.com/rocket-pool/rocketpool-go/utils"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/forecast"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often to update the forecast
+const walletForecastInterval time.Duration = time.Hour
+
+// How far back to measure the node wallet's burn rate
+const burnRateWindow time.Duration = 7 * 24 * time.Hour
+
+// Alert when the balance is forecast to drop below the reserve within this many days
+const lowBalanceHorizonDays float64 = 3
+
+// How often to repeat the low balance alert, and to top the wallet up
+const (
+	lowBalanceAlertInterval time.Duration = 24 * time.Hour
+	autoTopUpCooldown       time.Duration = 24 * time.Hour
+)
+
+// The gas limit of a plain ETH transfer
+const transferGasLimit uint64 = 21000
+
+// Forecast wallet balance task
+type forecastWalletBalance struct {
+	c             *cli.Context
+	log           log.ColorLogger
+	cfg           *config.RocketPoolConfig
+	w             *wallet.Wallet
+	ec            rocketpool.ExecutionClient
+	ledger        *forecast.Ledger
+	lastRun       time.Time
+	lastAlertTime time.Time
+	lastTopUpTime time.Time
+}
+
+// Create forecast wallet balance task
+func newForecastWalletBalance(c *cli.Context, logger log.ColorLogger) (*forecastWalletBalance, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &forecastWalletBalance{
+		c:      c,
+		log:    logger,
+		cfg:    cfg,
+		w:      w,
+		ec:     ec,
+		ledger: forecast.NewLedger(cfg.Smartnode.GetGasSpendLedgerPath(true)),
+	}, nil
+
+}
+
+// Forecast when the node wallet will be too low for its next duties, alerting and topping it up if it will be soon
+func (t *forecastWalletBalance) run(state *state.NetworkState) error {
+
+	duties := t.cfg.Smartnode.LowBalanceDuties.Value.(uint64)
+	if duties == 0 || time.Since(t.lastRun) < walletForecastInterval {
+		return nil
+	}
+	t.lastRun = time.Now()
+
+	// Get the node's balance and recent spending
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	balance, err := t.ec.BalanceAt(context.Background(), nodeAccount.Address, nil)
+	if err != nil {
+		return fmt.Errorf("error getting node wallet balance: %w", err)
+	}
+	spends, err := t.ledger.Read(time.Now().Add(-burnRateWindow))
+	if err != nil {
+		return err
+	}
+	walletForecast := forecast.NewForecast(balance, spends, time.Now(), duties)
+	if walletForecast.Transactions == 0 {
+		return nil
+	}
+	t.log.Printlnf("The node wallet has %.6f ETH and spent %.6f ETH a day on %d automated transactions over the last %s.", walletForecast.BalanceEth, walletForecast.BurnRateEthPerDay, walletForecast.Transactions, walletForecast.Window.Round(time.Hour))
+	if walletForecast.DaysUntilReserve >= lowBalanceHorizonDays {
+		return nil
+	}
+
+	// Warn about it
+	if walletForecast.DaysUntilReserve <= 0 {
+		t.log.Printlnf("WARNING: the node wallet needs about %.6f ETH for its next %d automated transactions, but only has %.6f ETH.", walletForecast.ReserveEth, duties, walletForecast.BalanceEth)
+	} else {
+		t.log.Printlnf("WARNING: at this rate, the node wallet will drop below the %.6f ETH it needs for its next %d automated transactions in about %.1f days.", walletForecast.ReserveEth, duties, walletForecast.DaysUntilReserve)
+	}
+	if time.Since(t.lastAlertTime) >= lowBalanceAlertInterval {
+		if err := alerting.AlertLowBalanceForecast(t.cfg, nodeAccount.Address, walletForecast.BalanceEth, walletForecast.ReserveEth, duties, walletForecast.DaysUntilReserve); err != nil {
+			t.log.Printlnf("Error sending low balance alert: %s", err.Error())
+		}
+		t.lastAlertTime = time.Now()
+	}
+
+	// Top it up once it's below the reserve
+	if walletForecast.DaysUntilReserve <= 0 {
+		if err := t.topUp(nodeAccount.Address); err != nil {
+			return fmt.Errorf("error topping up the node wallet: %w", err)
+		}
+	}
+	return nil
+
+}
+
+// Send the configured top-up amount from the funding address to the node wallet, if automatic top-ups are enabled
+func (t *forecastWalletBalance) topUp(nodeAddress common.Address) error {
+
+	amount := t.cfg.Smartnode.AutoTopUpAmount.Value.(float64)
+	fundingAddressString := t.cfg.Smartnode.AutoTopUpFundingAddress.Value.(string)
+	if amount <= 0 || fundingAddressString == "" || time.Since(t.lastTopUpTime) < autoTopUpCooldown {
+		return nil
+	}
+
+	// Don't retry a failed top-up until the cooldown has passed, so a broken setup can't drain the funding address
+	t.lastTopUpTime = time.Now()
+
+	// Load the funding key
+	if !common.IsHexAddress(fundingAddressString) {
+		return fmt.Errorf("the funding address [%s] is not a valid address", fundingAddressString)
+	}
+	fundingAddress := common.HexToAddress(fundingAddressString)
+	keyPath := t.cfg.Smartnode.GetAutoTopUpFundingKeyPath()
+	keyBytes, err := os.ReadFile(keyPath)
+	if err != nil {
+		return fmt.Errorf("error reading the funding key from %s: %w", keyPath, err)
+	}
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(string(keyBytes)), "0x"))
+	if err != nil {
+		return fmt.Errorf("error parsing the funding key in %s: %w", keyPath, err)
+	}
+	if crypto.PubkeyToAddress(key.PublicKey) != fundingAddress {
+		return fmt.Errorf("the funding key in %s doesn't belong to the funding address %s", keyPath, fundingAddress.Hex())
+	}
+
+	// Get the fees
+	ctx := context.Background()
+	header, err := t.ec.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error getting the latest block: %w", err)
+	}
+	tip, err := t.ec.SuggestGasTipCap(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting the suggested priority fee: %w", err)
+	}
+	maxFee := new(big.Int).Add(new(big.Int).Mul(header.BaseFee, big.NewInt(2)), tip)
+
+	// Make sure the funding address can afford it
+	value := eth.EthToWei(amount)
+	cost := new(big.Int).Add(value, new(big.Int).Mul(maxFee, new(big.Int).SetUint64(transferGasLimit)))
+	fundingBalance, err := t.ec.BalanceAt(ctx, fundingAddress, nil)
+	if err != nil {
+		return fmt.Errorf("error getting the funding address balance: %w", err)
+	}
+	if fundingBalance.Cmp(cost) < 0 {
+		return fmt.Errorf("the funding address %s only has %.6f ETH, but the top-up needs up to %.6f ETH", fundingAddress.Hex(), eth.WeiToEth(fundingBalance), eth.WeiToEth(cost))
+	}
+
+	// Send it
+	nonce, err := t.ec.PendingNonceAt(ctx, fundingAddress)
+	if err != nil {
+		return fmt.Errorf("error getting the funding address nonce: %w", err)
+	}
+	chainID := new(big.Int).SetUint64(uint64(t.cfg.Smartnode.GetChainID()))
+	tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: tip,
+		GasFeeCap: maxFee,
+		Gas:       transferGasLimit,
+		To:        &nodeAddress,
+		Value:     value,
+	}), types.LatestSignerForChainID(chainID), key)
+	if err != nil {
+		return fmt.Errorf("error signing the top-up transaction: %w", err)
+	}
+	t.log.Printlnf("Sending %.6f ETH from the funding address %s to the node wallet...", amount, fundingAddress.Hex())
+	err = t.ec.SendTransaction(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("error sending the top-up transaction: %w", err)
+	}
+	t.log.Printlnf("Top-up transaction %s has been submitted, waiting for it to be validated...", tx.Hash().Hex())
+	receipt, err := rputils.WaitForTransaction(t.ec, tx.Hash())
+	if err != nil {
+		return fmt.Errorf("error waiting for the top-up transaction: %w", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("the top-up transaction %s failed", tx.Hash().Hex())
+	}
+	t.log.Printlnf("Topped up the node wallet with %.6f ETH.", amount)
+	return nil
+
+}
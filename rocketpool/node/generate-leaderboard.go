@@ -0,0 +1,118 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often the leaderboard is regenerated
+var leaderboardInterval, _ = time.ParseDuration("6h")
+
+// Generate leaderboard task
+type generateLeaderboard struct {
+	c             *cli.Context
+	log           log.ColorLogger
+	cfg           *config.RocketPoolConfig
+	rp            *rocketpool.RocketPool
+	bc            beacon.Client
+	m             *state.NetworkStateManager
+	lastGenerated time.Time
+}
+
+// Create generate leaderboard task
+func newGenerateLeaderboard(c *cli.Context, logger log.ColorLogger) (*generateLeaderboard, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &generateLeaderboard{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		rp:  rp,
+		bc:  bc,
+		m:   state.NewNetworkStateManager(rp, cfg.Smartnode.GetStateManagerContracts(), bc, &logger),
+	}, nil
+
+}
+
+// Generate the leaderboard
+func (t *generateLeaderboard) run(nodeState *state.NetworkState) error {
+
+	// Only regenerate periodically, since this needs the state of the entire network
+	if time.Since(t.lastGenerated) < leaderboardInterval {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Generating the node leaderboard...")
+
+	// The node's own state only includes its own details, so get the full network state
+	networkState, err := t.m.GetHeadState()
+	if err != nil {
+		return fmt.Errorf("error getting network state for the leaderboard: %w", err)
+	}
+
+	// Get the rewards and performance files for the latest finished interval, if they've been downloaded or generated
+	var rewardsFile rprewards.IRewardsFile
+	var performanceFile rprewards.IMinipoolPerformanceFile
+	currentIndex := nodeState.NetworkDetails.RewardIndex
+	if currentIndex > 0 {
+		interval := currentIndex - 1
+		localRewardsFile, err := rprewards.ReadLocalRewardsFile(t.cfg.Smartnode.GetRewardsTreePath(interval, true, config.RewardsExtensionJSON))
+		if err == nil {
+			rewardsFile = localRewardsFile.Impl()
+		} else {
+			t.log.Printlnf("Rewards file for interval %d is not available, smoothing pool shares will not be ranked (%s).", interval, err.Error())
+		}
+
+		performancePath := t.cfg.Smartnode.GetMinipoolPerformancePath(interval, true)
+		if _, err := os.Stat(performancePath); err == nil {
+			localPerformanceFile, err := rprewards.ReadLocalMinipoolPerformanceFile(performancePath)
+			if err != nil {
+				return err
+			}
+			performanceFile = localPerformanceFile.Impl()
+		} else {
+			t.log.Printlnf("Minipool performance file for interval %d is not available, attestation effectiveness will not be ranked.", interval)
+		}
+	}
+
+	// Generate and save the leaderboard
+	leaderboard, err := rprewards.GenerateLeaderboard(fmt.Sprint(t.cfg.Smartnode.Network.Value), networkState, rewardsFile, performanceFile)
+	if err != nil {
+		return err
+	}
+	err = rprewards.SaveLeaderboard(t.cfg.Smartnode.GetLeaderboardPath(), leaderboard)
+	if err != nil {
+		return err
+	}
+
+	t.lastGenerated = time.Now()
+	t.log.Printlnf("Saved the leaderboard for %d nodes to %s.", len(leaderboard.Nodes), t.cfg.Smartnode.GetLeaderboardPath())
+	return nil
+
+}
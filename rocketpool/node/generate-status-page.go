@@ -0,0 +1,236 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/mirror"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/statuspage"
+	"github.com/rocket-pool/smartnode/shared/services/store"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The version of the status page schema in the daemon store
+const statusPageSchemaVersion uint64 = 1
+
+// The key of the last generation time in the status page's daemon store bucket
+const statusPageLastGeneratedKey string = "lastGenerated"
+
+// Generate status page task
+type generateStatusPage struct {
+	c             *cli.Context
+	log           log.ColorLogger
+	cfg           *config.RocketPoolConfig
+	w             *wallet.Wallet
+	rp            *rocketpool.RocketPool
+	bucket        *store.Bucket
+	startTime     time.Time
+	lastGenerated time.Time
+}
+
+// Create generate status page task
+func newGenerateStatusPage(c *cli.Context, logger log.ColorLogger, daemonStore *store.Store) (*generateStatusPage, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the rate limit across restarts
+	bucket, err := daemonStore.Bucket("status-page", statusPageSchemaVersion, nil)
+	if err != nil {
+		return nil, err
+	}
+	var lastGenerated time.Time
+	_, err = bucket.Get(statusPageLastGeneratedKey, &lastGenerated)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &generateStatusPage{
+		c:             c,
+		log:           logger,
+		cfg:           cfg,
+		w:             w,
+		rp:            rp,
+		bucket:        bucket,
+		startTime:     time.Now().UTC(),
+		lastGenerated: lastGenerated,
+	}, nil
+
+}
+
+// Generate the status page and publish it
+func (t *generateStatusPage) run(state *state.NetworkState) error {
+
+	// Only regenerate once per interval
+	if time.Since(t.lastGenerated) < t.cfg.Smartnode.GetStatusPageInterval() {
+		return nil
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Build the status
+	status := statuspage.Status{
+		Network:       fmt.Sprint(t.cfg.Smartnode.Network.Value),
+		GeneratedTime: time.Now().UTC(),
+		Slot:          state.BeaconSlotNumber,
+		RunningSince:  t.startTime,
+	}
+	if t.cfg.Smartnode.StatusPageShowAddress.Value == true {
+		status.NodeAddress = &nodeAccount.Address
+	}
+	if node, exists := state.NodeDetailsByAddress[nodeAccount.Address]; exists {
+		status.SmoothingPoolOptedIn = node.SmoothingPoolRegistrationState
+	}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAccount.Address] {
+		status.Validators.Total++
+		switch {
+		case mpd.Status == types.Dissolved:
+			status.Validators.Dissolved++
+		case mpd.Finalised || mpd.Status == types.Withdrawable:
+			status.Validators.Exited++
+		case mpd.Status == types.Staking:
+			if isValidatorExited(state.ValidatorDetails[mpd.Pubkey]) {
+				status.Validators.Exited++
+			} else {
+				status.Validators.Staking++
+			}
+		default:
+			status.Validators.Pending++
+		}
+	}
+	t.addEffectiveness(state, nodeAccount.Address, &status)
+	t.addLastClaim(nodeAccount.Address, &status)
+
+	// Save it, then upload it if any buckets are configured
+	paths, err := statuspage.Write(t.cfg.Smartnode.GetStatusPagePath(), &status)
+	if err != nil {
+		return err
+	}
+	t.setLastGenerated(status.GeneratedTime)
+	t.log.Printlnf("Saved the status page to %s.", t.cfg.Smartnode.GetStatusPagePath())
+
+	uploader, err := mirror.Load(t.cfg.Smartnode.GetStatusPageBucketsPath())
+	if err != nil {
+		return err
+	}
+	if uploader == nil {
+		return nil
+	}
+	urls, err := uploader.Upload(paths)
+	for _, url := range urls[statuspage.HtmlFilename] {
+		t.log.Printlnf("Published the status page to %s.", url)
+	}
+	if err != nil {
+		return fmt.Errorf("error publishing the status page: %w", err)
+	}
+	return nil
+
+}
+
+// Add the node's attestation effectiveness from the latest finished interval's performance file, if it's available
+func (t *generateStatusPage) addEffectiveness(state *state.NetworkState, nodeAddress common.Address, status *statuspage.Status) {
+	if state.NetworkDetails.RewardIndex == 0 {
+		return
+	}
+	interval := state.NetworkDetails.RewardIndex - 1
+	performancePath := t.cfg.Smartnode.GetMinipoolPerformancePath(interval, true)
+	if _, err := os.Stat(performancePath); err != nil {
+		return
+	}
+	localPerformanceFile, err := rprewards.ReadLocalMinipoolPerformanceFile(performancePath)
+	if err != nil {
+		t.log.Printlnf("WARNING: couldn't read the minipool performance file for interval %d: %s", interval, err.Error())
+		return
+	}
+	performanceFile := localPerformanceFile.Impl()
+
+	successful := uint64(0)
+	total := uint64(0)
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAddress] {
+		performance, exists := performanceFile.GetSmoothingPoolPerformance(mpd.MinipoolAddress)
+		if !exists {
+			continue
+		}
+		successful += performance.GetSuccessfulAttestationCount()
+		total += performance.GetSuccessfulAttestationCount() + performance.GetMissedAttestationCount()
+	}
+	if total == 0 {
+		return
+	}
+	effectiveness := float64(successful) / float64(total)
+	status.Effectiveness = &effectiveness
+	status.EffectivenessInterval = interval
+}
+
+// Add the node's most recent rewards claim from the claim index, if it's been indexed
+func (t *generateStatusPage) addLastClaim(nodeAddress common.Address, status *statuspage.Status) {
+	index, err := rprewards.LoadClaimIndex(t.cfg.Smartnode.GetClaimIndexPath())
+	if err != nil || index.NodeAddress != nodeAddress {
+		return
+	}
+	claims := index.GetClaims()
+	if len(claims) == 0 {
+		return
+	}
+	latest := claims[0]
+	for _, claim := range claims {
+		if claim.BlockNumber > latest.BlockNumber {
+			latest = claim
+		}
+	}
+	header, err := t.rp.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(latest.BlockNumber))
+	if err != nil {
+		return
+	}
+	status.LastClaim = &statuspage.LastClaim{
+		Interval: latest.Interval,
+		Time:     time.Unix(int64(header.Time), 0).UTC(),
+	}
+}
+
+// Record when the page was generated, so restarts don't regenerate it early
+func (t *generateStatusPage) setLastGenerated(generated time.Time) {
+	t.lastGenerated = generated
+	if err := t.bucket.Put(statusPageLastGeneratedKey, generated); err != nil {
+		t.log.Printlnf("WARNING: couldn't save the status page generation time: %s", err.Error())
+	}
+}
+
+// Check if a validator has left the Beacon Chain
+func isValidatorExited(validator beacon.ValidatorStatus) bool {
+	switch validator.Status {
+	case beacon.ValidatorState_ExitedUnslashed, beacon.ValidatorState_ExitedSlashed, beacon.ValidatorState_WithdrawalPossible, beacon.ValidatorState_WithdrawalDone:
+		return true
+	}
+	return false
+}
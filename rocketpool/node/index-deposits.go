@@ -0,0 +1,48 @@
+package node
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/deposits"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Index deposits task
+type indexDeposits struct {
+	c       *cli.Context
+	log     log.ColorLogger
+	indexer *deposits.Indexer
+}
+
+// Create index deposits task
+func newIndexDeposits(c *cli.Context, logger log.ColorLogger) (*indexDeposits, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &indexDeposits{
+		c:       c,
+		log:     logger,
+		indexer: deposits.NewIndexer(rp, cfg.Smartnode.GetDepositIndexPath(), uint64(eventLogInterval), deposits.DefaultRetentionBlocks),
+	}, nil
+
+}
+
+// Bring the deposit contract index up to date, so pre-deposit validation doesn't have to scan the contract itself
+func (t *indexDeposits) run(state *state.NetworkState) error {
+	return t.indexer.Sync()
+}
@@ -12,11 +12,12 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/health"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
 )
 
-func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *collectors.StateLocker) error {
+func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *collectors.StateLocker, healthMonitor *health.Monitor) error {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -44,12 +45,12 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 		return err
 	}
 
-	// Return if metrics are disabled
+	// Only serve the health endpoints if metrics are disabled
 	if cfg.EnableMetrics.Value == false {
 		if strings.ToLower(os.Getenv("ENABLE_METRICS")) == "true" {
 			logger.Printlnf("ENABLE_METRICS override set to true, will start Metrics exporter anyway!")
 		} else {
-			return nil
+			return runHealthServer(c, logger, healthMonitor)
 		}
 	}
 
@@ -68,6 +69,7 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	trustedNodeCollector := collectors.NewTrustedNodeCollector(rp, bc, nodeAccount.Address, cfg, stateLocker)
 	beaconCollector := collectors.NewBeaconCollector(rp, bc, ec, nodeAccount.Address, stateLocker)
 	smoothingPoolCollector := collectors.NewSmoothingPoolCollector(rp, ec, stateLocker)
+	effectivenessCollector := collectors.NewEffectivenessCollector(cfg, nodeAccount.Address, stateLocker)
 
 	// Set up Prometheus
 	registry := prometheus.NewRegistry()
@@ -80,6 +82,13 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	registry.MustRegister(trustedNodeCollector)
 	registry.MustRegister(beaconCollector)
 	registry.MustRegister(smoothingPoolCollector)
+	registry.MustRegister(effectivenessCollector)
+
+	// Set up the network totals if they're being recorded
+	if cfg.Smartnode.RecordNetworkTotals.Value.(bool) {
+		networkTotalsCollector := collectors.NewNetworkTotalsCollector(cfg)
+		registry.MustRegister(networkTotalsCollector)
+	}
 
 	// Set up snapshot checking if enabled
 	if cfg.Smartnode.GetRocketSignerRegistryAddress() != "" {
@@ -101,12 +110,14 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	logger.Printlnf("Starting metrics exporter on %s:%d.", metricsAddress, metricsPort)
 	metricsPath := "/metrics"
 	http.Handle(metricsPath, handler)
+	healthMonitor.RegisterHandlers(http.DefaultServeMux)
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(`<html>
             <head><title>Rocket Pool Metrics Exporter</title></head>
             <body>
             <h1>Rocket Pool Metrics Exporter</h1>
             <p><a href='` + metricsPath + `'>Metrics</a></p>
+            <p><a href='` + health.LivenessPath + `'>Liveness</a> | <a href='` + health.ReadinessPath + `'>Readiness</a></p>
             </body>
             </html>`,
 		))
@@ -119,3 +130,16 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	return nil
 
 }
+
+// Run an HTTP server with just the liveness and readiness endpoints
+func runHealthServer(c *cli.Context, logger log.ColorLogger, healthMonitor *health.Monitor) error {
+	metricsAddress := c.GlobalString("metricsAddress")
+	metricsPort := c.GlobalUint("metricsPort")
+	logger.Printlnf("Metrics are disabled; serving only the health endpoints on %s:%d.", metricsAddress, metricsPort)
+	healthMonitor.RegisterHandlers(http.DefaultServeMux)
+	err := http.ListenAndServe(fmt.Sprintf("%s:%d", metricsAddress, metricsPort), nil)
+	if err != nil {
+		return fmt.Errorf("Error running HTTP server: %w", err)
+	}
+	return nil
+}
@@ -16,11 +16,17 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/events"
+	"github.com/rocket-pool/smartnode/shared/services/gas/thresholds"
+	"github.com/rocket-pool/smartnode/shared/services/health"
+	"github.com/rocket-pool/smartnode/shared/services/nonce"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/store"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/teku"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
@@ -29,6 +35,9 @@ var tasksInterval, _ = time.ParseDuration("5m")
 var taskCooldown, _ = time.ParseDuration("10s")
 var totalEffectiveStakeCooldown, _ = time.ParseDuration("1h")
 
+// How long the task loop can go without starting a new iteration before the daemon is considered dead
+var taskLoopTimeout, _ = time.ParseDuration("30m")
+
 const (
 	MaxConcurrentEth1Requests = 200
 
@@ -42,6 +51,23 @@ const (
 	VerifyPdaoPropsColor         = color.FgYellow
 	AutoInitVotingPowerColor     = color.FgHiYellow
 	DistributeMinipoolsColor     = color.FgHiGreen
+	GenerateLeaderboardColor     = color.FgCyan
+	RecordNetworkTotalsColor     = color.FgHiBlue
+	PublishEventsColor           = color.FgGreen
+	SubmitTelemetryColor         = color.FgHiMagenta
+	GenerateStatusPageColor      = color.FgHiGreen
+	PendingWithdrawalColor       = color.FgHiRed
+	CheckRewardNetworkColor      = color.FgHiRed
+	CheckFinalityColor           = color.FgRed
+	WatchValidatorClientColor    = color.FgHiYellow
+	UpgradeDelegatesColor        = color.FgHiBlue
+	BackfillColor                = color.FgHiCyan
+	IndexDepositsColor           = color.FgCyan
+	RelayClaimsColor             = color.FgHiMagenta
+	ForecastWalletBalanceColor   = color.FgHiCyan
+	CheckClockDriftColor         = color.FgHiRed
+	NotifyProposalsColor         = color.FgHiGreen
+	NotifyExitsColor             = color.FgHiYellow
 	ErrorColor                   = color.FgRed
 	WarningColor                 = color.FgYellow
 	UpdateColor                  = color.FgHiWhite
@@ -62,6 +88,9 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 // Run daemon
 func run(c *cli.Context) error {
 
+	// Coordinate nonces with the other processes sending from the node wallet
+	services.EnableNonceCoordination(nonce.Priority_Routine)
+
 	// Handle the initial fee recipient file deployment
 	err := deployDefaultFeeRecipientFile(c)
 	if err != nil {
@@ -116,6 +145,12 @@ func run(c *cli.Context) error {
 	errorLog := log.NewColorLogger(ErrorColor)
 	updateLog := log.NewColorLogger(UpdateColor)
 
+	// Open the store the tasks persist their progress in
+	daemonStore, err := store.Open(cfg.Smartnode.GetNodeDaemonStorePath())
+	if err != nil {
+		return err
+	}
+
 	// Create the state manager
 	m := state.NewNetworkStateManager(rp, cfg.Smartnode.GetStateManagerContracts(), bc, &updateLog)
 	stateLocker := collectors.NewStateLocker()
@@ -149,6 +184,58 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	checkPendingWithdrawalAddress, err := newCheckPendingWithdrawalAddress(c, log.NewColorLogger(PendingWithdrawalColor))
+	if err != nil {
+		return err
+	}
+	checkRewardNetwork, err := newCheckRewardNetwork(c, log.NewColorLogger(CheckRewardNetworkColor))
+	if err != nil {
+		return err
+	}
+	runBackfill, err := newRunBackfill(c, log.NewColorLogger(BackfillColor), daemonStore)
+	if err != nil {
+		return err
+	}
+	indexDeposits, err := newIndexDeposits(c, log.NewColorLogger(IndexDepositsColor))
+	if err != nil {
+		return err
+	}
+	checkFinality, err := newCheckFinality(c, log.NewColorLogger(CheckFinalityColor))
+	if err != nil {
+		return err
+	}
+	checkClockDrift, err := newCheckClockDrift(c, log.NewColorLogger(CheckClockDriftColor))
+	if err != nil {
+		return err
+	}
+	notifyProposals, err := newNotifyProposals(c, log.NewColorLogger(NotifyProposalsColor))
+	if err != nil {
+		return err
+	}
+	notifyExits, err := newNotifyExits(c, log.NewColorLogger(NotifyExitsColor))
+	if err != nil {
+		return err
+	}
+	forecastWalletBalance, err := newForecastWalletBalance(c, log.NewColorLogger(ForecastWalletBalanceColor))
+	if err != nil {
+		return err
+	}
+	var watchValidatorClient *watchValidatorClient
+	// Make sure the user opted into the validator client watchdog
+	if cfg.Smartnode.VcWatchdogPolicy.Value.(cfgtypes.VcWatchdogPolicy) != cfgtypes.VcWatchdogPolicy_Disabled {
+		watchValidatorClient, err = newWatchValidatorClient(c, log.NewColorLogger(WatchValidatorClientColor))
+		if err != nil {
+			return err
+		}
+	}
+	var upgradeDelegates *upgradeDelegates
+	// Make sure the user opted into automatic delegate upgrades
+	if cfg.Smartnode.AutoUpgradeDelegates.Value.(cfgtypes.DelegateUpgradeMode) != cfgtypes.DelegateUpgradeMode_Disabled {
+		upgradeDelegates, err = newUpgradeDelegates(c, log.NewColorLogger(UpgradeDelegatesColor))
+		if err != nil {
+			return err
+		}
+	}
 	var verifyPdaoProps *verifyPdaoProps
 	// Make sure the user opted into this duty
 	verifyEnabled := cfg.Smartnode.VerifyProposals.Value.(bool)
@@ -160,13 +247,74 @@ func run(c *cli.Context) error {
 	}
 	var autoInitVotingPower *autoInitVotingPower
 	// Make sure the user opted into this duty
-	AutoInitVPThreshold := cfg.Smartnode.AutoInitVPThreshold.Value.(float64)
+	AutoInitVPThreshold, err := thresholds.GetTaskThreshold(cfg, thresholds.Task_AutoInitVotingPower, cfg.Smartnode.AutoInitVPThreshold.Value.(float64))
+	if err != nil {
+		return err
+	}
 	if AutoInitVPThreshold != 0 {
 		autoInitVotingPower, err = newAutoInitVotingPower(c, log.NewColorLogger(AutoInitVotingPowerColor), AutoInitVPThreshold)
 		if err != nil {
 			return err
 		}
 	}
+	var generateLeaderboard *generateLeaderboard
+	// Make sure the user opted into the leaderboard
+	if cfg.Smartnode.EnableLeaderboard.Value.(bool) {
+		generateLeaderboard, err = newGenerateLeaderboard(c, log.NewColorLogger(GenerateLeaderboardColor))
+		if err != nil {
+			return err
+		}
+	}
+	var recordNetworkTotals *recordNetworkTotals
+	// Make sure the user opted into recording the network totals
+	if cfg.Smartnode.RecordNetworkTotals.Value.(bool) {
+		recordNetworkTotals, err = newRecordNetworkTotals(c, log.NewColorLogger(RecordNetworkTotalsColor))
+		if err != nil {
+			return err
+		}
+	}
+	var publishEvents *publishEvents
+	// Make sure the user set up somewhere to publish events to
+	eventPublisher, err := events.NewPublisher(cfg)
+	if err != nil {
+		return err
+	}
+	if eventPublisher != nil {
+		publishEvents, err = newPublishEvents(c, log.NewColorLogger(PublishEventsColor), eventPublisher, daemonStore)
+		if err != nil {
+			return err
+		}
+	}
+	var relayClaims *relayClaims
+	// Make sure the user set up a list of nodes to claim for
+	if cfg.Smartnode.GetClaimsRelayerNodesPath() != "" {
+		relayClaims, err = newRelayClaims(c, log.NewColorLogger(RelayClaimsColor))
+		if err != nil {
+			return err
+		}
+	}
+	var submitTelemetry *submitTelemetry
+	// Make sure the user opted into telemetry
+	if cfg.Smartnode.EnableTelemetry.Value.(bool) {
+		submitTelemetry, err = newSubmitTelemetry(c, log.NewColorLogger(SubmitTelemetryColor))
+		if err != nil {
+			return err
+		}
+	}
+	var generateStatusPage *generateStatusPage
+	// Make sure the user opted into the status page
+	if cfg.Smartnode.EnableStatusPage.Value.(bool) {
+		generateStatusPage, err = newGenerateStatusPage(c, log.NewColorLogger(GenerateStatusPageColor), daemonStore)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Report the daemon's health to orchestrators
+	healthMonitor := health.NewMonitor(taskLoopTimeout, func() error {
+		_, err := w.GetNodeAccount()
+		return err
+	})
 
 	// Wait group to handle the various threads
 	wg := new(sync.WaitGroup)
@@ -181,8 +329,11 @@ func run(c *cli.Context) error {
 		wasExecutionClientSynced := true
 		wasBeaconClientSynced := true
 		for {
+			healthMonitor.Heartbeat()
+
 			// Check the EC status
 			err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
+			healthMonitor.SetExecutionClientStatus(err)
 			if err != nil {
 				wasExecutionClientSynced = false
 				errorLog.Printlnf("Execution client not synced: %s. Waiting for sync...", err.Error())
@@ -198,6 +349,7 @@ func run(c *cli.Context) error {
 
 			// Check the BC status
 			err = services.WaitBeaconClientSynced(c, false) // Force refresh the primary / fallback BC status
+			healthMonitor.SetBeaconClientStatus(err)
 			if err != nil {
 				// NOTE: if not synced, it returns an error - so there isn't necessarily an underlying issue
 				wasBeaconClientSynced = false
@@ -226,12 +378,63 @@ func run(c *cli.Context) error {
 			}
 			stateLocker.UpdateState(state, totalEffectiveStake)
 
+			// Check for a finality stall, which pauses the snapshot-dependent tasks
+			if err := checkFinality.run(state); err != nil {
+				errorLog.Println(err)
+			}
+			degraded := checkFinality.isDegraded()
+
+			// Check for clock drift, which pauses the slot-sensitive tasks
+			if err := checkClockDrift.run(state); err != nil {
+				errorLog.Println(err)
+			}
+			drifting := checkClockDrift.isDrifting()
+
+			// Publish any protocol events since the last run
+			if publishEvents != nil {
+				if err := publishEvents.run(state); err != nil {
+					errorLog.Println(err)
+				}
+			}
+
+			// Make sure the validator client is working
+			if watchValidatorClient != nil {
+				if err := watchValidatorClient.run(state); err != nil {
+					errorLog.Println(err)
+				}
+			}
+
+			// Look up the node's upcoming block proposals and record the outcome of past ones
+			if err := notifyProposals.run(state); err != nil {
+				errorLog.Println(err)
+			}
+
+			// Project when the node's exiting validators will exit and be withdrawn
+			if err := notifyExits.run(state); err != nil {
+				errorLog.Println(err)
+			}
+
 			// Manage the fee recipient for the node
 			if err := manageFeeRecipient.run(state); err != nil {
 				errorLog.Println(err)
 			}
 			time.Sleep(taskCooldown)
 
+			// Check for a pending withdrawal address change
+			if err := checkPendingWithdrawalAddress.run(state); err != nil {
+				errorLog.Println(err)
+			}
+
+			// Make sure the node's reward network is valid
+			if err := checkRewardNetwork.run(state); err != nil {
+				errorLog.Println(err)
+			}
+
+			// Forecast the node wallet's balance and top it up if needed
+			if err := forecastWalletBalance.run(state); err != nil {
+				errorLog.Println(err)
+			}
+
 			// Run the rewards download check
 			if err := downloadRewardsTrees.run(state); err != nil {
 				errorLog.Println(err)
@@ -272,17 +475,80 @@ func run(c *cli.Context) error {
 			}
 			time.Sleep(taskCooldown)
 
-			// Run the reduce bond check
-			if err := reduceBonds.run(state); err != nil {
-				errorLog.Println(err)
+			// Run the claims relayer
+			if relayClaims != nil {
+				if err := relayClaims.run(state); err != nil {
+					errorLog.Println(err)
+				}
+				time.Sleep(taskCooldown)
+			}
+
+			// Run the reduce bond and minipool promotion checks
+			if !drifting {
+				if err := reduceBonds.run(state); err != nil {
+					errorLog.Println(err)
+				}
+				time.Sleep(taskCooldown)
+
+				if err := promoteMinipools.run(state); err != nil {
+					errorLog.Println(err)
+				}
 			}
-			time.Sleep(taskCooldown)
 
-			// Run the minipool promotion check
-			if err := promoteMinipools.run(state); err != nil {
+			// Run the delegate upgrade check
+			if upgradeDelegates != nil {
+				time.Sleep(taskCooldown)
+				if err := upgradeDelegates.run(state); err != nil {
+					errorLog.Println(err)
+				}
+			}
+
+			// Keep the deposit contract index up to date
+			time.Sleep(taskCooldown)
+			if err := indexDeposits.run(state); err != nil {
 				errorLog.Println(err)
 			}
 
+			// Run any pending historical data backfills
+			if !degraded && !drifting {
+				time.Sleep(taskCooldown)
+				if err := runBackfill.run(state); err != nil {
+					errorLog.Println(err)
+				}
+			}
+
+			// Run the leaderboard generation
+			if generateLeaderboard != nil && !degraded && !drifting {
+				time.Sleep(taskCooldown)
+				if err := generateLeaderboard.run(state); err != nil {
+					errorLog.Println(err)
+				}
+			}
+
+			// Record the network totals
+			if recordNetworkTotals != nil && !degraded && !drifting {
+				time.Sleep(taskCooldown)
+				if err := recordNetworkTotals.run(state); err != nil {
+					errorLog.Println(err)
+				}
+			}
+
+			// Run the telemetry submission
+			if submitTelemetry != nil {
+				time.Sleep(taskCooldown)
+				if err := submitTelemetry.run(state); err != nil {
+					errorLog.Println(err)
+				}
+			}
+
+			// Update the status page
+			if generateStatusPage != nil {
+				time.Sleep(taskCooldown)
+				if err := generateStatusPage.run(state); err != nil {
+					errorLog.Println(err)
+				}
+			}
+
 			time.Sleep(tasksInterval)
 		}
 		wg.Done()
@@ -290,7 +556,7 @@ func run(c *cli.Context) error {
 
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), stateLocker)
+		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), stateLocker, healthMonitor)
 		if err != nil {
 			errorLog.Println(err)
 		}
@@ -0,0 +1,108 @@
+package node
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/exitqueue"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/sweep"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Notify exits task
+type notifyExits struct {
+	c        *cli.Context
+	log      log.ColorLogger
+	cfg      *config.RocketPoolConfig
+	w        *wallet.Wallet
+	bc       beacon.Client
+	notified map[types.ValidatorPubkey]bool
+}
+
+// Create notify exits task
+func newNotifyExits(c *cli.Context, logger log.ColorLogger) (*notifyExits, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &notifyExits{
+		c:        c,
+		log:      logger,
+		cfg:      cfg,
+		w:        w,
+		bc:       bc,
+		notified: map[types.ValidatorPubkey]bool{},
+	}, nil
+
+}
+
+// Find the node's validators that have entered the exit queue, and send a notification with when each one will exit and be
+// fully withdrawn
+func (t *notifyExits) run(state *state.NetworkState) error {
+
+	// Get the node's validators that are waiting to exit
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	exiting := []beacon.ValidatorStatus{}
+	minipools := map[types.ValidatorPubkey]common.Address{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAccount.Address] {
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !isActiveValidator(validator) || validator.ExitEpoch == exitqueue.FarFutureEpoch || t.notified[mpd.Pubkey] {
+			continue
+		}
+		exiting = append(exiting, validator)
+		minipools[mpd.Pubkey] = mpd.MinipoolAddress
+	}
+	if len(exiting) == 0 {
+		return nil
+	}
+
+	// Project when their balances will be withdrawn
+	progress, err := sweep.Measure(t.bc, state.BeaconSlotNumber, sweep.DefaultSampleSlots)
+	if err != nil {
+		return fmt.Errorf("error measuring the withdrawal sweep: %w", err)
+	}
+	for _, validator := range exiting {
+		minipoolAddress := minipools[validator.Pubkey]
+		index, err := strconv.ParseUint(validator.Index, 10, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing validator index [%s]: %w", validator.Index, err)
+		}
+		exitTime := state.BeaconConfig.GetSlotTime(state.BeaconConfig.EpochToSlot(validator.ExitEpoch))
+		withdrawalSlot := progress.GetNextSweepSlot(index, state.BeaconConfig.EpochToSlot(validator.WithdrawableEpoch))
+		withdrawalTime := state.BeaconConfig.GetSlotTime(withdrawalSlot)
+		t.notified[validator.Pubkey] = true
+
+		t.log.Printlnf("Validator %s (minipool %s) is in the exit queue. It will exit in epoch %d at %s, and its balance should be withdrawn around %s.", validator.Index, minipoolAddress.Hex(), validator.ExitEpoch, exitTime.Format(time.RFC1123), withdrawalTime.Format(time.RFC1123))
+		if err := alerting.AlertValidatorExitProjection(t.cfg, minipoolAddress, validator.Index, validator.ExitEpoch, exitTime, withdrawalTime); err != nil {
+			t.log.Printlnf("Error sending validator exit projection alert: %s", err.Error())
+		}
+	}
+	return nil
+
+}
@@ -0,0 +1,323 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/mevboost"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// How old a validator's latest MEV-Boost relay registration can be before it's considered stale; validator clients
+// re-register every epoch
+const maxRegistrationAge time.Duration = time.Hour
+
+// Proposal outcomes
+const (
+	proposalOutcome_Proposed string = "proposed"
+	proposalOutcome_Missed   string = "missed"
+)
+
+// A block proposal one of the node's validators is assigned to
+type upcomingProposal struct {
+	Slot                 uint64
+	ValidatorIndex       string
+	Pubkey               types.ValidatorPubkey
+	MinipoolAddress      common.Address
+	ExpectedFeeRecipient common.Address
+	RegistrationProblems []string
+}
+
+// The outcome of a block proposal, as recorded in the proposal ledger
+type proposalRecord struct {
+	Time                 time.Time      `json:"time"`
+	Slot                 uint64         `json:"slot"`
+	ValidatorIndex       string         `json:"validatorIndex"`
+	MinipoolAddress      common.Address `json:"minipoolAddress"`
+	Outcome              string         `json:"outcome"`
+	ExecutionBlockNumber uint64         `json:"executionBlockNumber,omitempty"`
+	FeeRecipient         common.Address `json:"feeRecipient,omitempty"`
+	FeeRecipientCorrect  bool           `json:"feeRecipientCorrect"`
+	RegistrationProblems []string       `json:"registrationProblems,omitempty"`
+}
+
+// Notify proposals task
+type notifyProposals struct {
+	c                *cli.Context
+	log              log.ColorLogger
+	cfg              *config.RocketPoolConfig
+	w                *wallet.Wallet
+	rp               *rocketpool.RocketPool
+	bc               beacon.Client
+	ledgerPath       string
+	lastCheckedEpoch uint64
+	pending          map[uint64]upcomingProposal
+}
+
+// Create notify proposals task
+func newNotifyProposals(c *cli.Context, logger log.ColorLogger) (*notifyProposals, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &notifyProposals{
+		c:          c,
+		log:        logger,
+		cfg:        cfg,
+		w:          w,
+		rp:         rp,
+		bc:         bc,
+		ledgerPath: cfg.Smartnode.GetProposalLedgerPath(true),
+		pending:    map[uint64]upcomingProposal{},
+	}, nil
+
+}
+
+// Look up the node's block proposals for the next epoch, and record the outcome of the ones that have finalized
+func (t *notifyProposals) run(state *state.NetworkState) error {
+
+	// Get the Beacon head
+	head, err := t.bc.GetBeaconHead()
+	if err != nil {
+		return fmt.Errorf("error getting Beacon head: %w", err)
+	}
+
+	// Record the outcomes first, so they aren't held up by a lookahead error
+	if err := t.recordOutcomes(state, head.FinalizedEpoch); err != nil {
+		return err
+	}
+
+	// Proposer duties can be looked up one epoch ahead; the task loop can take longer than an epoch, so catch up on the
+	// current one too if it was skipped
+	startEpoch := max(head.Epoch, t.lastCheckedEpoch+1)
+	for epoch := startEpoch; epoch <= head.Epoch+1; epoch++ {
+		if err := t.lookAhead(state, epoch); err != nil {
+			return err
+		}
+		t.lastCheckedEpoch = epoch
+	}
+	return nil
+
+}
+
+// Find the node's proposals in the provided epoch, checking their MEV-Boost registrations and sending a notification
+func (t *notifyProposals) lookAhead(state *state.NetworkState, epoch uint64) error {
+
+	// Get the node's active validators
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	indices := []string{}
+	minipools := map[string]common.Address{}
+	pubkeys := map[string]types.ValidatorPubkey{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAccount.Address] {
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !isActiveValidator(validator) {
+			continue
+		}
+		indices = append(indices, validator.Index)
+		minipools[validator.Index] = mpd.MinipoolAddress
+		pubkeys[validator.Index] = mpd.Pubkey
+	}
+	if len(indices) == 0 {
+		return nil
+	}
+
+	// Get their proposals
+	slotsByIndex, err := t.bc.GetValidatorProposerSlots(indices, epoch)
+	if err != nil {
+		return err
+	}
+	if len(slotsByIndex) == 0 {
+		return nil
+	}
+
+	// Get the fee recipient their blocks should use
+	feeRecipientInfo, err := rputils.GetFeeRecipientInfo(t.rp, t.bc, nodeAccount.Address, state)
+	if err != nil {
+		return fmt.Errorf("error getting fee recipient info: %w", err)
+	}
+	feeRecipient := feeRecipientInfo.FeeDistributorAddress
+	if feeRecipientInfo.IsInSmoothingPool || feeRecipientInfo.IsInOptOutCooldown {
+		feeRecipient = feeRecipientInfo.SmoothingPoolAddress
+	}
+
+	for index, slots := range slotsByIndex {
+		registrationProblems := t.checkRegistrations(pubkeys[index], feeRecipient)
+		for _, slot := range slots {
+			if _, exists := t.pending[slot]; exists {
+				continue
+			}
+			proposal := upcomingProposal{
+				Slot:                 slot,
+				ValidatorIndex:       index,
+				Pubkey:               pubkeys[index],
+				MinipoolAddress:      minipools[index],
+				ExpectedFeeRecipient: feeRecipient,
+				RegistrationProblems: registrationProblems,
+			}
+			t.pending[slot] = proposal
+
+			// Don't notify about proposals that have already happened
+			slotTime := getSlotTime(state, slot)
+			if slotTime.Before(time.Now()) {
+				continue
+			}
+			t.log.Printlnf("Validator %s (minipool %s) will propose the block in slot %d at %s.", index, proposal.MinipoolAddress.Hex(), slot, slotTime.Format(time.RFC1123))
+			for _, problem := range registrationProblems {
+				t.log.Printlnf("WARNING: its MEV-Boost registration may be stale: %s.", problem)
+			}
+			if err := alerting.AlertProposalLookahead(t.cfg, proposal.MinipoolAddress, index, slot, slotTime, registrationProblems); err != nil {
+				t.log.Printlnf("Error sending proposal lookahead alert: %s", err.Error())
+			}
+		}
+	}
+	return nil
+
+}
+
+// Check a validator's registrations with the enabled MEV-Boost relays, returning any problems with them
+func (t *notifyProposals) checkRegistrations(pubkey types.ValidatorPubkey, feeRecipient common.Address) []string {
+
+	// The relays are only known when the Smartnode manages MEV-Boost
+	if t.cfg.EnableMevBoost.Value != true || t.cfg.MevBoost.Mode.Value.(cfgtypes.Mode) != cfgtypes.Mode_Local {
+		return nil
+	}
+
+	network := t.cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	problems := []string{}
+	now := time.Now()
+	for _, relay := range t.cfg.MevBoost.GetEnabledMevRelays() {
+		registration, err := mevboost.GetRegistration(relay.Urls[network], pubkey)
+		if err != nil {
+			t.log.Printlnf("Error checking the MEV-Boost registration with %s: %s", relay.Name, err.Error())
+			continue
+		}
+		if problem := registration.GetProblem(now, maxRegistrationAge, feeRecipient); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+	return problems
+
+}
+
+// Record the outcomes of the pending proposals that have finalized in the proposal ledger
+func (t *notifyProposals) recordOutcomes(state *state.NetworkState, finalizedEpoch uint64) error {
+
+	slots := []uint64{}
+	for slot := range t.pending {
+		if slot/state.BeaconConfig.SlotsPerEpoch <= finalizedEpoch {
+			slots = append(slots, slot)
+		}
+	}
+	if len(slots) == 0 {
+		return nil
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+
+	records := []proposalRecord{}
+	for _, slot := range slots {
+		proposal := t.pending[slot]
+		block, exists, err := t.bc.GetBeaconBlock(strconv.FormatUint(slot, 10))
+		if err != nil {
+			return fmt.Errorf("error getting the block for slot %d: %w", slot, err)
+		}
+
+		record := proposalRecord{
+			Time:                 getSlotTime(state, slot),
+			Slot:                 slot,
+			ValidatorIndex:       proposal.ValidatorIndex,
+			MinipoolAddress:      proposal.MinipoolAddress,
+			Outcome:              proposalOutcome_Missed,
+			RegistrationProblems: proposal.RegistrationProblems,
+		}
+		if exists && block.ProposerIndex == proposal.ValidatorIndex {
+			record.Outcome = proposalOutcome_Proposed
+			record.ExecutionBlockNumber = block.ExecutionBlockNumber
+			record.FeeRecipient = block.FeeRecipient
+			record.FeeRecipientCorrect = block.FeeRecipient == proposal.ExpectedFeeRecipient
+			t.log.Printlnf("Validator %s proposed the block in slot %d (execution block %d).", proposal.ValidatorIndex, slot, block.ExecutionBlockNumber)
+			if !record.FeeRecipientCorrect {
+				t.log.Printlnf("WARNING: the block used fee recipient %s instead of %s.", block.FeeRecipient.Hex(), proposal.ExpectedFeeRecipient.Hex())
+			}
+		} else {
+			t.log.Printlnf("WARNING: validator %s missed its proposal in slot %d.", proposal.ValidatorIndex, slot)
+		}
+		records = append(records, record)
+	}
+
+	if err := appendProposalLedger(t.ledgerPath, records); err != nil {
+		return err
+	}
+	for _, slot := range slots {
+		delete(t.pending, slot)
+	}
+	return nil
+
+}
+
+// Add records to the proposal ledger
+func appendProposalLedger(path string, records []proposalRecord) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating proposal ledger directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening proposal ledger %s: %w", path, err)
+	}
+	defer file.Close()
+
+	for _, record := range records {
+		bytes, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("error serializing proposal record: %w", err)
+		}
+		_, err = file.Write(append(bytes, '\n'))
+		if err != nil {
+			return fmt.Errorf("error writing proposal ledger %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Get the time of a slot
+func getSlotTime(state *state.NetworkState, slot uint64) time.Time {
+	genesisTime := time.Unix(int64(state.BeaconConfig.GenesisTime), 0)
+	return genesisTime.Add(time.Duration(slot*state.BeaconConfig.SecondsPerSlot) * time.Second)
+}
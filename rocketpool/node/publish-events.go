@@ -0,0 +1,163 @@
+package node
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/events"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/store"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The version of the publish events cursor schema in the daemon store
+const publishEventsSchemaVersion uint64 = 1
+
+// The key of the publish events cursor in its daemon store bucket
+const publishEventsCursorKey string = "cursor"
+
+// What was observed on the previous run, so only changes are published
+type publishEventsCursor struct {
+	Block              uint64                  `json:"block"`
+	DepositPoolBalance *big.Int                `json:"depositPoolBalance"`
+	QueueLength        *big.Int                `json:"queueLength"`
+	RewardIndex        uint64                  `json:"rewardIndex"`
+	AssignedMinipools  map[common.Address]bool `json:"assignedMinipools"`
+	StakingMinipools   map[common.Address]bool `json:"stakingMinipools"`
+}
+
+// Publish events task
+type publishEvents struct {
+	c         *cli.Context
+	log       log.ColorLogger
+	w         *wallet.Wallet
+	publisher *events.Publisher
+	bucket    *store.Bucket
+
+	initialized bool
+	cursor      publishEventsCursor
+}
+
+// Create publish events task
+func newPublishEvents(c *cli.Context, logger log.ColorLogger, publisher *events.Publisher, daemonStore *store.Store) (*publishEvents, error) {
+
+	// Get services
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resume from the previous run's cursor so changes made while the daemon was down are still published
+	bucket, err := daemonStore.Bucket("publish-events", publishEventsSchemaVersion, nil)
+	if err != nil {
+		return nil, err
+	}
+	cursor := publishEventsCursor{}
+	initialized, err := bucket.Get(publishEventsCursorKey, &cursor)
+	if err != nil {
+		return nil, err
+	}
+	if cursor.AssignedMinipools == nil {
+		cursor.AssignedMinipools = map[common.Address]bool{}
+	}
+	if cursor.StakingMinipools == nil {
+		cursor.StakingMinipools = map[common.Address]bool{}
+	}
+
+	// Return task
+	return &publishEvents{
+		c:           c,
+		log:         logger,
+		w:           w,
+		publisher:   publisher,
+		bucket:      bucket,
+		initialized: initialized,
+		cursor:      cursor,
+	}, nil
+
+}
+
+// Publish the protocol events that happened since the last run
+func (t *publishEvents) run(state *state.NetworkState) error {
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Find the events
+	newEvents := []events.Event{}
+	newEvent := func(eventType events.EventType, data interface{}) {
+		newEvents = append(newEvents, events.Event{
+			Type:  eventType,
+			Node:  nodeAccount.Address,
+			Block: state.ElBlockNumber,
+			Slot:  state.BeaconSlotNumber,
+			Time:  time.Now().UTC(),
+			Data:  data,
+		})
+	}
+
+	details := state.NetworkDetails
+	if t.initialized && (details.DepositPoolBalance.Cmp(t.cursor.DepositPoolBalance) != 0 || details.QueueLength.Cmp(t.cursor.QueueLength) != 0) {
+		newEvent(events.EventType_DepositPoolChanged, events.DepositPoolData{
+			Balance:       details.DepositPoolBalance,
+			UserBalance:   details.DepositPoolUserBalance,
+			QueueLength:   details.QueueLength,
+			QueueCapacity: details.QueueCapacity.Total,
+		})
+	}
+	if t.initialized && details.RewardIndex > t.cursor.RewardIndex {
+		// The reward index is the interval in progress, so the snapshot that was just submitted is the one before it
+		for index := t.cursor.RewardIndex; index < details.RewardIndex; index++ {
+			newEvent(events.EventType_RewardsSnapshot, events.RewardsSnapshotData{
+				Index: index,
+			})
+		}
+	}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAccount.Address] {
+		data := events.MinipoolData{
+			Minipool:           mpd.MinipoolAddress,
+			Pubkey:             mpd.Pubkey.Hex(),
+			NodeDepositBalance: mpd.NodeDepositBalance,
+			UserDepositBalance: mpd.UserDepositBalance,
+		}
+		if mpd.UserDepositAssigned && !t.cursor.AssignedMinipools[mpd.MinipoolAddress] {
+			t.cursor.AssignedMinipools[mpd.MinipoolAddress] = true
+			if t.initialized {
+				newEvent(events.EventType_DepositAssigned, data)
+			}
+		}
+		if mpd.Status == rptypes.Staking && !t.cursor.StakingMinipools[mpd.MinipoolAddress] {
+			t.cursor.StakingMinipools[mpd.MinipoolAddress] = true
+			if t.initialized {
+				newEvent(events.EventType_MinipoolLaunched, data)
+			}
+		}
+	}
+
+	// Publish them
+	for _, event := range newEvents {
+		if err := t.publisher.Publish(event); err != nil {
+			t.log.Printlnf("WARNING: %s", err.Error())
+			continue
+		}
+		t.log.Printlnf("Published %s event.", event.Type)
+	}
+
+	// Save the cursor once the events are out; a fresh install only records the starting point so it doesn't replay everything
+	t.cursor.Block = state.ElBlockNumber
+	t.cursor.DepositPoolBalance = details.DepositPoolBalance
+	t.cursor.QueueLength = details.QueueLength
+	t.cursor.RewardIndex = details.RewardIndex
+	t.initialized = true
+	return t.bucket.Put(publishEventsCursorKey, t.cursor)
+
+}
@@ -0,0 +1,98 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Record network totals task
+type recordNetworkTotals struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	rp          *rocketpool.RocketPool
+	bc          beacon.Client
+	m           *state.NetworkStateManager
+	path        string
+	retention   time.Duration
+	lastEpoch   uint64
+	hasRecorded bool
+}
+
+// Create record network totals task
+func newRecordNetworkTotals(c *cli.Context, logger log.ColorLogger) (*recordNetworkTotals, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	retentionDays := cfg.Smartnode.NetworkTotalsRetentionDays.Value.(uint16)
+	return &recordNetworkTotals{
+		c:         c,
+		log:       logger,
+		cfg:       cfg,
+		rp:        rp,
+		bc:        bc,
+		m:         state.NewNetworkStateManager(rp, cfg.Smartnode.GetStateManagerContracts(), bc, &logger),
+		path:      cfg.Smartnode.GetNetworkTotalsPath(),
+		retention: time.Duration(retentionDays) * 24 * time.Hour,
+	}, nil
+
+}
+
+// Record the network totals for the current epoch
+func (t *recordNetworkTotals) run(nodeState *state.NetworkState) error {
+
+	// Only record once per epoch
+	epoch := nodeState.BeaconSlotNumber / nodeState.BeaconConfig.SlotsPerEpoch
+	if t.hasRecorded && epoch <= t.lastEpoch {
+		return nil
+	}
+
+	// Log
+	t.log.Printlnf("Recording network totals for epoch %d...", epoch)
+
+	// The node's own state only includes its own details, so get the full network state
+	networkState, err := t.m.GetHeadState()
+	if err != nil {
+		return fmt.Errorf("error getting network state for the network totals: %w", err)
+	}
+
+	// Take and save the sample
+	sample, err := state.NewNetworkTotalsSample(networkState)
+	if err != nil {
+		return err
+	}
+	err = state.SaveNetworkTotalsSample(t.path, sample, t.retention)
+	if err != nil {
+		return err
+	}
+
+	t.lastEpoch = sample.Epoch
+	t.hasRecorded = true
+	t.log.Printlnf("Recorded network totals: %.2f total node weight, %.6f ETH in the smoothing pool, %.6f rETH exchange rate, %d active minipools.",
+		eth.WeiToEth(sample.TotalNodeWeight), eth.WeiToEth(sample.SmoothingPoolBalance), sample.RethExchangeRate, sample.ActiveMinipools)
+	return nil
+
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/gas/thresholds"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -74,7 +75,10 @@ func newReduceBonds(c *cli.Context, logger log.ColorLogger) (*reduceBonds, error
 	}
 
 	// Check if auto-bond-reduction is disabled
-	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
+	gasThreshold, err := thresholds.GetTaskThreshold(cfg, thresholds.Task_ReduceBonds, cfg.Smartnode.AutoTxGasThreshold.Value.(float64))
+	if err != nil {
+		return nil, err
+	}
 	disabled := false
 	if gasThreshold == 0 {
 		logger.Println("Automatic tx gas threshold is 0, disabling auto-reduce.")
@@ -0,0 +1,413 @@
+package node
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/gas/thresholds"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How many times to try estimating a claim's gas when the client fails, before leaving it for the next run
+	relayClaimEstimateAttempts int = 3
+
+	// How long to wait between attempts at estimating a claim's gas
+	relayClaimEstimateRetryDelay time.Duration = 5 * time.Second
+)
+
+// Relay claims task
+type relayClaims struct {
+	c              *cli.Context
+	log            log.ColorLogger
+	cfg            *config.RocketPoolConfig
+	w              *wallet.Wallet
+	rp             *rocketpool.RocketPool
+	nodesPath      string
+	ledgerPath     string
+	gasThreshold   float64
+	disabled       bool
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+	gasLimit       uint64
+}
+
+// A claim built for a delegating node
+type relayedClaim struct {
+	node         common.Address
+	indices      []*big.Int
+	amountRPL    []*big.Int
+	amountETH    []*big.Int
+	merkleProofs [][]common.Hash
+	hash         common.Hash
+}
+
+// A record of a claim the relayer submitted and the fee it paid for it
+type relayedClaimRecord struct {
+	Node      common.Address          `json:"node"`
+	Intervals []uint64                `json:"intervals"`
+	TxHash    common.Hash             `json:"txHash"`
+	Succeeded bool                    `json:"succeeded"`
+	GasUsed   uint64                  `json:"gasUsed"`
+	FeeWei    *rprewards.QuotedBigInt `json:"feeWei"`
+	AmountRPL *rprewards.QuotedBigInt `json:"amountRpl"`
+	AmountETH *rprewards.QuotedBigInt `json:"amountEth"`
+	Time      time.Time               `json:"time"`
+}
+
+// Create relay claims task
+func newRelayClaims(c *cli.Context, logger log.ColorLogger) (*relayClaims, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if relaying is disabled
+	gasThreshold, err := thresholds.GetTaskThreshold(cfg, thresholds.Task_RelayClaims, cfg.Smartnode.AutoTxGasThreshold.Value.(float64))
+	if err != nil {
+		return nil, err
+	}
+	disabled := false
+	if gasThreshold == 0 {
+		logger.Println("Automatic tx gas threshold is 0, disabling the claims relayer.")
+		disabled = true
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested max fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Return task
+	return &relayClaims{
+		c:              c,
+		log:            logger,
+		cfg:            cfg,
+		w:              w,
+		rp:             rp,
+		nodesPath:      cfg.Smartnode.GetClaimsRelayerNodesPath(),
+		ledgerPath:     cfg.Smartnode.GetClaimsRelayerLedgerPath(),
+		gasThreshold:   gasThreshold,
+		disabled:       disabled,
+		maxFee:         maxFee,
+		maxPriorityFee: priorityFee,
+		gasLimit:       0,
+	}, nil
+
+}
+
+// Claim rewards for the delegating nodes
+func (t *relayClaims) run(state *state.NetworkState) error {
+
+	// Check if the relayer is disabled
+	if t.disabled {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Checking for rewards to claim on behalf of delegating nodes...")
+
+	// Load the delegating nodes; the list is reread each time so it can be edited without restarting the daemon
+	nodes, err := loadClaimsRelayerNodes(t.nodesPath)
+	if err != nil {
+		return err
+	}
+
+	// Build the claims
+	claims := []*relayedClaim{}
+	for _, node := range nodes {
+		claim, err := t.buildClaim(node)
+		if err != nil {
+			t.log.Printlnf("Could not build a claim for node %s: %s", node.Hex(), err.Error())
+			continue
+		}
+		if claim != nil {
+			claims = append(claims, claim)
+		}
+	}
+	if len(claims) == 0 {
+		return nil
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Submit every claim first, then wait for them all so they can land in the same block
+	t.log.Printlnf("Submitting claims for %d node(s)...", len(claims))
+	submitted := []*relayedClaim{}
+	for _, claim := range claims {
+		if err := t.submitClaim(claim, maxFee); err != nil {
+			t.log.Printlnf("Could not submit the claim for node %s: %s", claim.node.Hex(), err.Error())
+			continue
+		}
+		if claim.hash != (common.Hash{}) {
+			submitted = append(submitted, claim)
+		}
+	}
+
+	records := []relayedClaimRecord{}
+	for _, claim := range submitted {
+		if err := api.PrintAndWaitForTransaction(t.cfg, claim.hash, t.rp.Client, &t.log); err != nil {
+			t.log.Printlnf("Could not confirm the claim for node %s: %s", claim.node.Hex(), err.Error())
+			continue
+		}
+		record, err := t.getClaimRecord(claim)
+		if err != nil {
+			t.log.Printlnf("Could not get the fee paid for the claim for node %s: %s", claim.node.Hex(), err.Error())
+			continue
+		}
+		if record.Succeeded {
+			t.log.Printlnf("Successfully claimed %d interval(s) for node %s, paying %.6f ETH in fees.", len(record.Intervals), claim.node.Hex(), eth.WeiToEth(&record.FeeWei.Int))
+		} else {
+			t.log.Printlnf("The claim for node %s reverted, paying %.6f ETH in fees.", claim.node.Hex(), eth.WeiToEth(&record.FeeWei.Int))
+		}
+		records = append(records, record)
+	}
+
+	// Record the fees
+	return appendClaimsRelayerLedger(t.ledgerPath, records)
+
+}
+
+// Build a claim for all of a node's unclaimed intervals, or nil if there's nothing to claim
+func (t *relayClaims) buildClaim(node common.Address) (*relayedClaim, error) {
+	unclaimed, _, err := rprewards.GetClaimStatus(t.rp, node)
+	if err != nil {
+		return nil, fmt.Errorf("error getting claim status: %w", err)
+	}
+
+	claim := &relayedClaim{
+		node: node,
+	}
+	for _, interval := range unclaimed {
+		intervalInfo, err := rprewards.GetIntervalInfo(t.rp, t.cfg, node, interval, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting info for interval %d: %w", interval, err)
+		}
+		if !intervalInfo.TreeFileExists || !intervalInfo.MerkleRootValid || !intervalInfo.NodeExists {
+			// Intervals without a valid local tree will be picked up once the tree has been downloaded
+			continue
+		}
+		if intervalInfo.RewardNetwork != 0 {
+			// Rewards routed to a Layer 2 network can only be claimed on that network
+			continue
+		}
+
+		rplForInterval := big.NewInt(0)
+		rplForInterval.Add(rplForInterval, &intervalInfo.CollateralRplAmount.Int)
+		rplForInterval.Add(rplForInterval, &intervalInfo.ODaoRplAmount.Int)
+
+		ethForInterval := big.NewInt(0)
+		ethForInterval.Add(ethForInterval, &intervalInfo.SmoothingPoolEthAmount.Int)
+
+		claim.indices = append(claim.indices, big.NewInt(0).SetUint64(interval))
+		claim.amountRPL = append(claim.amountRPL, rplForInterval)
+		claim.amountETH = append(claim.amountETH, ethForInterval)
+		claim.merkleProofs = append(claim.merkleProofs, intervalInfo.MerkleProof)
+	}
+	if len(claim.indices) == 0 {
+		return nil, nil
+	}
+	return claim, nil
+}
+
+// Submit a claim without waiting for it; the claim's hash is left empty if it was skipped
+func (t *relayClaims) submitClaim(claim *relayedClaim, maxFee *big.Int) error {
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+
+	// Only a node or its withdrawal address can claim the node's rewards, so the estimate reverts for nodes that don't
+	// use this node as their withdrawal address. Other errors come from the client and are worth retrying.
+	var gasInfo rocketpool.GasInfo
+	for attempt := 1; ; attempt++ {
+		gasInfo, err = rewards.EstimateClaimGas(t.rp, claim.node, claim.indices, claim.amountRPL, claim.amountETH, claim.merkleProofs, opts)
+		if err == nil || isExecutionReverted(err) || attempt == relayClaimEstimateAttempts {
+			break
+		}
+		t.log.Printlnf("Could not estimate the gas of the claim for node %s (attempt %d of %d), retrying: %s", claim.node.Hex(), attempt, relayClaimEstimateAttempts, err.Error())
+		time.Sleep(relayClaimEstimateRetryDelay)
+	}
+	if err != nil {
+		if isExecutionReverted(err) {
+			t.log.Printlnf("Skipping node %s, only the node or its withdrawal address can claim its rewards: %s", claim.node.Hex(), err.Error())
+			return nil
+		}
+		return fmt.Errorf("error estimating the gas of the claim: %w", err)
+	}
+	var gas *big.Int
+	if t.gasLimit != 0 {
+		gas = new(big.Int).SetUint64(t.gasLimit)
+	} else {
+		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+	}
+
+	// Print the gas info
+	t.log.Printlnf("Claiming %d interval(s) for node %s...", len(claim.indices), claim.node.Hex())
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
+		return nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = GetPriorityFee(t.maxPriorityFee, maxFee)
+	opts.GasLimit = gas.Uint64()
+
+	// Claim
+	hash, err := rewards.Claim(t.rp, claim.node, claim.indices, claim.amountRPL, claim.amountETH, claim.merkleProofs, opts)
+	if err != nil {
+		return err
+	}
+	claim.hash = hash
+	return nil
+
+}
+
+// Get the ledger record for a confirmed claim, including the fee it actually paid
+func (t *relayClaims) getClaimRecord(claim *relayedClaim) (relayedClaimRecord, error) {
+	receipt, err := t.rp.Client.TransactionReceipt(context.Background(), claim.hash)
+	if err != nil {
+		return relayedClaimRecord{}, err
+	}
+
+	fee := new(big.Int).SetUint64(receipt.GasUsed)
+	if receipt.EffectiveGasPrice != nil {
+		fee.Mul(fee, receipt.EffectiveGasPrice)
+	}
+	record := relayedClaimRecord{
+		Node:      claim.node,
+		TxHash:    claim.hash,
+		Succeeded: receipt.Status == 1,
+		GasUsed:   receipt.GasUsed,
+		FeeWei:    rprewards.NewQuotedBigInt(0),
+		AmountRPL: rprewards.NewQuotedBigInt(0),
+		AmountETH: rprewards.NewQuotedBigInt(0),
+		Time:      time.Now().UTC(),
+	}
+	record.FeeWei.Set(fee)
+	for i, index := range claim.indices {
+		record.Intervals = append(record.Intervals, index.Uint64())
+		record.AmountRPL.Add(&record.AmountRPL.Int, claim.amountRPL[i])
+		record.AmountETH.Add(&record.AmountETH.Int, claim.amountETH[i])
+	}
+	return record, nil
+}
+
+// Load the delegating nodes, one address per line with optional `#` comments
+func loadClaimsRelayerNodes(path string) ([]common.Address, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening claims relayer node list %s: %w", path, err)
+	}
+	defer file.Close()
+
+	nodes := []common.Address{}
+	seen := map[common.Address]bool{}
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !common.IsHexAddress(line) {
+			return nil, fmt.Errorf("claims relayer node list %s has an invalid address on line %d: [%s]", path, lineNumber, line)
+		}
+		node := common.HexToAddress(line)
+		if !seen[node] {
+			nodes = append(nodes, node)
+			seen[node] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading claims relayer node list %s: %w", path, err)
+	}
+	return nodes, nil
+}
+
+// Add records to the claims relayer's fee ledger
+func appendClaimsRelayerLedger(path string, records []relayedClaimRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	ledger := []relayedClaimRecord{}
+	bytes, err := os.ReadFile(path)
+	if err == nil {
+		if err := json.Unmarshal(bytes, &ledger); err != nil {
+			return fmt.Errorf("error deserializing claims relayer ledger %s: %w", path, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error reading claims relayer ledger %s: %w", path, err)
+	}
+
+	ledger = append(ledger, records...)
+	bytes, err = json.Marshal(ledger)
+	if err != nil {
+		return fmt.Errorf("error serializing claims relayer ledger: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return fmt.Errorf("error writing claims relayer ledger %s: %w", path, err)
+	}
+	return nil
+}
+
+// Check if an error is a contract revert rather than a problem reaching the client
+func isExecutionReverted(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "execution reverted")
+}
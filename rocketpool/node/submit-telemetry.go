@@ -0,0 +1,78 @@
+package node
+
+import (
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/telemetry"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often telemetry is submitted
+var telemetryInterval, _ = time.ParseDuration("24h")
+
+// Submit telemetry task
+type submitTelemetry struct {
+	c             *cli.Context
+	log           log.ColorLogger
+	cfg           *config.RocketPoolConfig
+	lastSubmitted time.Time
+}
+
+// Create submit telemetry task
+func newSubmitTelemetry(c *cli.Context, logger log.ColorLogger) (*submitTelemetry, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &submitTelemetry{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+	}, nil
+
+}
+
+// Submit telemetry
+func (t *submitTelemetry) run(state *state.NetworkState) error {
+
+	// Only submit once per interval
+	if time.Since(t.lastSubmitted) < telemetryInterval {
+		return nil
+	}
+	url := t.cfg.Smartnode.TelemetryUrl.Value.(string)
+	if url == "" {
+		return nil
+	}
+
+	// Get the client statuses
+	ec, err := services.GetEthClient(t.c)
+	if err != nil {
+		return err
+	}
+	bc, err := services.GetBeaconClient(t.c)
+	if err != nil {
+		return err
+	}
+	ecStatus := ec.CheckStatus(t.cfg)
+	bcStatus := bc.CheckStatus()
+
+	// Submit the report; only try once per interval even if it fails so a broken endpoint isn't spammed
+	t.lastSubmitted = time.Now()
+	t.log.Printlnf("Submitting anonymous telemetry to %s...", url)
+	err = telemetry.Submit(url, telemetry.NewReport(t.cfg, ecStatus, bcStatus))
+	if err != nil {
+		return err
+	}
+	t.log.Println("Telemetry submitted.")
+	return nil
+
+}
@@ -0,0 +1,232 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/gas/thresholds"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Upgrade delegates task
+type upgradeDelegates struct {
+	c              *cli.Context
+	log            log.ColorLogger
+	cfg            *config.RocketPoolConfig
+	w              *wallet.Wallet
+	rp             *rocketpool.RocketPool
+	mode           cfgtypes.DelegateUpgradeMode
+	gasThreshold   float64
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+	gasLimit       uint64
+}
+
+// Create upgrade delegates task
+func newUpgradeDelegates(c *cli.Context, logger log.ColorLogger) (*upgradeDelegates, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := cfg.Smartnode.AutoUpgradeDelegates.Value.(cfgtypes.DelegateUpgradeMode)
+	if mode == cfgtypes.DelegateUpgradeMode_Critical && cfg.Smartnode.CriticalDelegatesUrl.Value.(string) == "" {
+		logger.Println("WARNING: automatic upgrades of critical delegates are enabled but no critical delegate list URL is set, so no minipools will be upgraded.")
+	}
+
+	gasThreshold, err := thresholds.GetTaskThreshold(cfg, thresholds.Task_UpgradeDelegates, cfg.Smartnode.AutoTxGasThreshold.Value.(float64))
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested max fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Return task
+	return &upgradeDelegates{
+		c:              c,
+		log:            logger,
+		cfg:            cfg,
+		w:              w,
+		rp:             rp,
+		mode:           mode,
+		gasThreshold:   gasThreshold,
+		maxFee:         maxFee,
+		maxPriorityFee: priorityFee,
+		gasLimit:       0,
+	}, nil
+
+}
+
+// Upgrade the delegates of outdated minipools
+func (t *upgradeDelegates) run(state *state.NetworkState) error {
+
+	// Log
+	t.log.Println("Checking for minipools with outdated delegates...")
+
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the latest delegate
+	latestDelegate, err := t.rp.GetAddress("rocketMinipoolDelegate", opts)
+	if err != nil {
+		return fmt.Errorf("error getting latest minipool delegate: %w", err)
+	}
+
+	// Get the critical delegates
+	criticalDelegates, err := rputils.GetCriticalDelegates(t.cfg.Smartnode.CriticalDelegatesUrl.Value.(string))
+	if err != nil {
+		return fmt.Errorf("error getting critical delegates: %w", err)
+	}
+
+	// Get the minipools to upgrade
+	minipools := []*rpstate.NativeMinipoolDetails{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAccount.Address] {
+		if mpd.Finalised || mpd.UseLatestDelegate || mpd.Delegate == *latestDelegate {
+			continue
+		}
+		critical, isCritical := criticalDelegates[mpd.Delegate]
+		if isCritical {
+			t.log.Printlnf("Minipool %s is using critical delegate %s (%s).", mpd.MinipoolAddress.Hex(), mpd.Delegate.Hex(), critical.Reason)
+		} else if t.mode != cfgtypes.DelegateUpgradeMode_All {
+			continue
+		}
+		minipools = append(minipools, mpd)
+	}
+	if len(minipools) == 0 {
+		return nil
+	}
+
+	// Log
+	t.log.Printlnf("%d minipool(s) will be upgraded to delegate %s...", len(minipools), latestDelegate.Hex())
+
+	// Upgrade minipools
+	for _, mpd := range minipools {
+		_, err := t.upgradeDelegate(mpd, *latestDelegate, opts)
+		if err != nil {
+			t.log.Println(fmt.Errorf("Could not upgrade the delegate of minipool %s: %w", mpd.MinipoolAddress.Hex(), err))
+			return err
+		}
+	}
+
+	// Return
+	return nil
+
+}
+
+// Upgrade a minipool's delegate
+func (t *upgradeDelegates) upgradeDelegate(mpd *rpstate.NativeMinipoolDetails, latestDelegate common.Address, callOpts *bind.CallOpts) (bool, error) {
+
+	// Log
+	t.log.Printlnf("Upgrading the delegate of minipool %s...", mpd.MinipoolAddress.Hex())
+
+	// Get the minipool binding
+	mp, err := minipool.NewMinipoolFromVersion(t.rp, mpd.MinipoolAddress, mpd.Version, callOpts)
+	if err != nil {
+		return false, fmt.Errorf("cannot create binding for minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
+	}
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return false, err
+	}
+
+	// Get the gas limit
+	gasInfo, err := mp.EstimateDelegateUpgradeGas(opts)
+	if err != nil {
+		return false, fmt.Errorf("Could not estimate the gas required to upgrade the delegate: %w", err)
+	}
+	var gas *big.Int
+	if t.gasLimit != 0 {
+		gas = new(big.Int).SetUint64(t.gasLimit)
+	} else {
+		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// Print the gas info
+	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
+		return false, nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = GetPriorityFee(t.maxPriorityFee, maxFee)
+	opts.GasLimit = gas.Uint64()
+
+	// Upgrade the delegate
+	hash, err := mp.DelegateUpgrade(opts)
+	if err != nil {
+		return false, err
+	}
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	if err != nil {
+		return false, err
+	}
+
+	// Log
+	t.log.Printlnf("Successfully upgraded minipool %s to delegate %s.", mpd.MinipoolAddress.Hex(), latestDelegate.Hex())
+
+	// Return
+	return true, nil
+
+}
@@ -0,0 +1,140 @@
+package node
+
+import (
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/vcwatchdog"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// The shortest time between restarts of the validator client, so a restart has time to take effect
+const validatorClientRestartCooldown time.Duration = 30 * time.Minute
+
+// How often to re-send the alert while the validator client stays unhealthy
+const validatorClientAlertInterval time.Duration = 30 * time.Minute
+
+// Watch validator client task
+type watchValidatorClient struct {
+	c             *cli.Context
+	log           log.ColorLogger
+	cfg           *config.RocketPoolConfig
+	w             *wallet.Wallet
+	bc            beacon.Client
+	d             *client.Client
+	policy        cfgtypes.VcWatchdogPolicy
+	threshold     uint64
+	watchdog      *vcwatchdog.Watchdog
+	lastAlertTime time.Time
+}
+
+// Create watch validator client task
+func newWatchValidatorClient(c *cli.Context, logger log.ColorLogger) (*watchValidatorClient, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	threshold := cfg.Smartnode.VcWatchdogFailureChecks.Value.(uint64)
+	if threshold == 0 {
+		threshold = 1
+	}
+	return &watchValidatorClient{
+		c:         c,
+		log:       logger,
+		cfg:       cfg,
+		w:         w,
+		bc:        bc,
+		d:         d,
+		policy:    cfg.Smartnode.VcWatchdogPolicy.Value.(cfgtypes.VcWatchdogPolicy),
+		threshold: threshold,
+		watchdog:  vcwatchdog.NewWatchdog(cfg.Smartnode.VcKeymanagerUrl.Value.(string), cfg.Smartnode.GetVcKeymanagerTokenPath()),
+	}, nil
+
+}
+
+// Check the validator client, and alert or restart it per the policy if it stays unhealthy
+func (t *watchValidatorClient) run(state *state.NetworkState) error {
+
+	// Get the node's active validators
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	validators := map[types.ValidatorPubkey]beacon.ValidatorStatus{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAccount.Address] {
+		status, exists := state.ValidatorDetails[mpd.Pubkey]
+		if exists && isActiveValidator(status) {
+			validators[mpd.Pubkey] = status
+		}
+	}
+	if len(validators) == 0 {
+		return nil
+	}
+
+	// Check the validator client and save the result for `rocketpool service status`
+	status := t.watchdog.Check(validators, state.BeaconSlotNumber, state.BeaconConfig.SlotsPerEpoch)
+	defer func() {
+		if err := status.Save(t.cfg.Smartnode.GetVcWatchdogStatusPath()); err != nil {
+			t.log.Printlnf("WARNING: %s", err.Error())
+		}
+	}()
+	if status.Healthy {
+		return nil
+	}
+	for _, problem := range status.Problems {
+		t.log.Printlnf("Validator client check failed (%d in a row): %s.", status.ConsecutiveFailures, problem)
+	}
+	if status.ConsecutiveFailures < t.threshold {
+		return nil
+	}
+
+	// Restart the validator client if allowed
+	restarted := false
+	if t.policy == cfgtypes.VcWatchdogPolicy_Restart && time.Since(t.watchdog.GetLastRestart()) >= validatorClientRestartCooldown {
+		if err := validator.RestartValidator(t.cfg, t.bc, &t.log, t.d); err != nil {
+			t.log.Printlnf("Error restarting the validator client: %s", err.Error())
+		} else {
+			t.watchdog.RecordRestart()
+			status.LastRestart = t.watchdog.GetLastRestart()
+			restarted = true
+		}
+	}
+
+	// Don't repeat the alert too often
+	if !restarted && time.Since(t.lastAlertTime) < validatorClientAlertInterval {
+		return nil
+	}
+	if err := alerting.AlertValidatorClientUnhealthy(t.cfg, status.Problems, status.ConsecutiveFailures, restarted); err != nil {
+		t.log.Printlnf("Error sending validator client unhealthy alert: %s", err.Error())
+	}
+	t.lastAlertTime = time.Now()
+	return nil
+
+}
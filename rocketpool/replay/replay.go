@@ -0,0 +1,78 @@
+package replay
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+const (
+	ReplayColor = color.FgHiCyan
+)
+
+// Register chain snapshot replay command
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Regenerate a rewards tree from a chain snapshot without any Execution or Beacon clients, and check that it matches the recorded Merkle root",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "snapshot, s",
+				Usage: "The chain snapshot to replay",
+			},
+			cli.StringFlag{
+				Name:  "output, o",
+				Usage: "The file to write the regenerated rewards tree to, as JSON",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			return run(c)
+		},
+	})
+}
+
+// Replay the chain snapshot
+func run(c *cli.Context) error {
+
+	if c.String("snapshot") == "" {
+		return fmt.Errorf("the --snapshot flag is required")
+	}
+	logger := log.NewColorLogger(ReplayColor)
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+
+	result, manifest, err := rewards.ReplayChainSnapshot(&logger, "[Replay]", cfg, c.String("snapshot"))
+	if err != nil {
+		return err
+	}
+
+	// Save the regenerated tree
+	if c.String("output") != "" {
+		bytes, err := result.RewardsFile.Serialize()
+		if err != nil {
+			return fmt.Errorf("error serializing rewards tree: %w", err)
+		}
+		if err := os.WriteFile(c.String("output"), bytes, 0644); err != nil {
+			return fmt.Errorf("error writing rewards tree to %s: %w", c.String("output"), err)
+		}
+		logger.Printlnf("Saved the rewards tree to %s.", c.String("output"))
+	}
+
+	root := result.RewardsFile.GetMerkleRoot()
+	if root != manifest.MerkleRoot {
+		return fmt.Errorf("the regenerated Merkle root for interval %d is %s, but the snapshot recorded %s", manifest.Index, root, manifest.MerkleRoot)
+	}
+	logger.Printlnf("The regenerated Merkle root for interval %d matches the snapshot: %s", manifest.Index, root)
+	return nil
+
+}
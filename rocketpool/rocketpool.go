@@ -7,7 +7,11 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/rocketpool/api"
+	"github.com/rocket-pool/smartnode/rocketpool/batch"
+	"github.com/rocket-pool/smartnode/rocketpool/cacheproxy"
 	"github.com/rocket-pool/smartnode/rocketpool/node"
+	"github.com/rocket-pool/smartnode/rocketpool/replay"
+	"github.com/rocket-pool/smartnode/rocketpool/signer"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower"
 	"github.com/rocket-pool/smartnode/shared"
 	apiutils "github.com/rocket-pool/smartnode/shared/utils/api"
@@ -70,12 +74,25 @@ func main() {
 			Name:  "use-protected-api",
 			Usage: "Set this to true to use the Flashbots Protect RPC instead of your local Execution Client. Useful to ensure your transactions aren't front-run.",
 		},
+		cli.BoolFlag{
+			Name:  "simulate",
+			Usage: "Simulate transactions against the pending block and report what they would do instead of sending them",
+		},
+		cli.StringFlag{
+			Name:   "test-wallet",
+			Usage:  "Use a throwaway node wallet with keys derived from this seed `name` instead of the wallet on disk. Anyone who knows the name can derive the keys, so this is only for devnets; it's refused on Mainnet.",
+			EnvVar: "ROCKETPOOL_TEST_WALLET",
+		},
 	}
 
 	// Register commands
 	api.RegisterCommands(app, "api", []string{"a"})
 	node.RegisterCommands(app, "node", []string{"n"})
 	watchtower.RegisterCommands(app, "watchtower", []string{"w"})
+	signer.RegisterCommands(app, "remote-signer", []string{})
+	cacheproxy.RegisterCommands(app, "cache-proxy", []string{})
+	batch.RegisterCommands(app, "batch-register", []string{})
+	replay.RegisterCommands(app, "replay-chain-snapshot", []string{})
 
 	// Get command being run
 	var commandName string
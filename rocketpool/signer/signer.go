@@ -0,0 +1,92 @@
+package signer
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fatih/color"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/remotesigner"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+const (
+	SignerColor = color.FgHiMagenta
+)
+
+// Register signer command
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Run a remote signer that holds the node key and signs Oracle DAO transactions for a watchtower on another machine",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "listen, l",
+				Usage: "The address to serve signing requests on",
+				Value: "0.0.0.0:9190",
+			},
+			cli.StringFlag{
+				Name:  "ca-cert",
+				Usage: "The CA certificate that watchtower client certificates must be signed by",
+			},
+			cli.StringFlag{
+				Name:  "cert",
+				Usage: "The signer's TLS certificate",
+			},
+			cli.StringFlag{
+				Name:  "key",
+				Usage: "The signer's TLS private key",
+			},
+			cli.StringFlag{
+				Name:  "policy, p",
+				Usage: "The YAML file describing which transactions the signer will sign",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			return run(c)
+		},
+	})
+}
+
+// Run the signer
+func run(c *cli.Context) error {
+
+	for _, flag := range []string{"ca-cert", "cert", "key", "policy"} {
+		if c.String(flag) == "" {
+			return fmt.Errorf("the --%s flag is required", flag)
+		}
+	}
+
+	// Load the policy
+	policy, err := remotesigner.LoadPolicy(c.String("policy"))
+	if err != nil {
+		return err
+	}
+
+	// Wait for the node wallet and get the node key
+	if err := services.WaitNodeWallet(c, true); err != nil {
+		return err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return err
+	}
+	keyBytes, err := w.GetNodePrivateKeyBytes()
+	if err != nil {
+		return fmt.Errorf("error getting node key: %w", err)
+	}
+	key, err := crypto.ToECDSA(keyBytes)
+	if err != nil {
+		return fmt.Errorf("error parsing node key: %w", err)
+	}
+
+	// Serve
+	logger := log.NewColorLogger(SignerColor)
+	server := remotesigner.NewServer(key, policy, &logger)
+	return server.Serve(c.String("listen"), c.String("ca-cert"), c.String("cert"), c.String("key"))
+
+}
@@ -0,0 +1,287 @@
+package watchtower
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/events"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Challenge unresponsive members task
+type challengeMembers struct {
+	c         *cli.Context
+	log       log.ColorLogger
+	errLog    log.ColorLogger
+	cfg       *config.RocketPoolConfig
+	w         *wallet.Wallet
+	rp        *rocketpool.RocketPool
+	publisher *events.Publisher
+
+	// The expired challenges that have already been reported, so they're only published once
+	reportedExpirations map[common.Address]time.Time
+}
+
+// Create challenge unresponsive members task
+func newChallengeMembers(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, publisher *events.Publisher) (*challengeMembers, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &challengeMembers{
+		c:                   c,
+		log:                 logger,
+		errLog:              errorLogger,
+		cfg:                 cfg,
+		w:                   w,
+		rp:                  rp,
+		publisher:           publisher,
+		reportedExpirations: map[common.Address]time.Time{},
+	}, nil
+
+}
+
+// Challenge unresponsive members, and follow up on the challenges this node made
+func (t *challengeMembers) run(state *state.NetworkState) error {
+
+	// Check if challenging is enabled
+	if !t.cfg.Smartnode.ChallengeUnresponsiveMembers.Value.(bool) {
+		return nil
+	}
+
+	// Wait for eth client to sync
+	if err := services.WaitEthClientSynced(t.c, true); err != nil {
+		return err
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Log
+	t.log.Println("Checking for unresponsive Oracle DAO members...")
+
+	// Follow up on the challenges this node made
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+	genesisTime := time.Unix(int64(state.BeaconConfig.GenesisTime), 0)
+	blockTime := genesisTime.Add(time.Duration(state.BeaconSlotNumber*state.BeaconConfig.SecondsPerSlot) * time.Second)
+	for _, member := range state.OracleDaoMemberDetails {
+		if !member.IsChallenged {
+			continue
+		}
+		challenge, err := getChallengeInfo(t.rp, member.Address, opts)
+		if err != nil {
+			return err
+		}
+		if challenge.challenger != nodeAccount.Address || !blockTime.After(challenge.deadline) {
+			continue
+		}
+		if err := t.handleExpiredChallenge(nodeAccount.Address, challenge); err != nil {
+			t.errLog.Println(fmt.Errorf("error handling the expired challenge against member %s: %w", member.Address.Hex(), err))
+		}
+	}
+
+	// Find the members that haven't submitted anything since the start of the inactivity period
+	unresponsiveMembers, err := t.getUnresponsiveMembers(nodeAccount.Address, state, blockTime)
+	if err != nil {
+		return err
+	}
+	if len(unresponsiveMembers) == 0 {
+		return nil
+	}
+
+	// Challenge the first one; the challenge cooldown means the contract would reject any others for a while
+	memberAddress := unresponsiveMembers[0]
+	if err := t.challengeMember(nodeAccount.Address, memberAddress); err != nil {
+		t.errLog.Println(fmt.Errorf("error challenging member %s: %w", memberAddress.Hex(), err))
+	}
+
+	// Return
+	return nil
+
+}
+
+// Get the members that haven't submitted balances or prices during the inactivity period
+func (t *challengeMembers) getUnresponsiveMembers(nodeAddress common.Address, state *state.NetworkState, blockTime time.Time) ([]common.Address, error) {
+
+	// Get the start of the inactivity period; EL blocks are assumed to be one per slot, which errs on the side of looking further back
+	inactivityPeriod := time.Duration(t.cfg.Smartnode.ChallengeInactivityHours.Value.(uint64)) * time.Hour
+	periodStart := blockTime.Add(-inactivityPeriod)
+	blockCount := uint64(inactivityPeriod.Seconds()) / state.BeaconConfig.SecondsPerSlot
+	if blockCount >= state.ElBlockNumber {
+		return nil, nil
+	}
+	fromBlock := state.ElBlockNumber - blockCount
+
+	// Get the members that submitted something in the period
+	eventLogInterval, err := t.cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	intervalSize := big.NewInt(int64(eventLogInterval))
+	balanceSubmitters, err := network.GetLatestBalancesSubmissions(t.rp, fromBlock, intervalSize, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting balances submissions since block %d: %w", fromBlock, err)
+	}
+	priceSubmitters, err := network.GetLatestPricesSubmissions(t.rp, fromBlock, intervalSize, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting price submissions since block %d: %w", fromBlock, err)
+	}
+	activeMembers := map[common.Address]bool{}
+	for _, address := range append(balanceSubmitters, priceSubmitters...) {
+		activeMembers[address] = true
+	}
+
+	// Everyone else who was a member for the whole period is unresponsive
+	unresponsiveMembers := []common.Address{}
+	for _, member := range state.OracleDaoMemberDetails {
+		if member.Address == nodeAddress || member.IsChallenged || activeMembers[member.Address] || member.JoinedTime.After(periodStart) {
+			continue
+		}
+		t.log.Printlnf("Member %s (%s) hasn't submitted network balances or RPL prices in the last %s.", member.Address.Hex(), member.ID, inactivityPeriod)
+		unresponsiveMembers = append(unresponsiveMembers, member.Address)
+	}
+	return unresponsiveMembers, nil
+
+}
+
+// Challenge a member
+func (t *challengeMembers) challengeMember(nodeAddress common.Address, memberAddress common.Address) error {
+
+	// Log
+	t.log.Printlnf("Challenging member %s...", memberAddress.Hex())
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+
+	// Get the gas limit
+	gasInfo, err := trustednode.EstimateMakeChallengeGas(t.rp, memberAddress, opts)
+	if err != nil {
+		return fmt.Errorf("Could not estimate the gas required to challenge the member: %w", err)
+	}
+
+	// Print the gas info
+	maxFee := eth.GweiToWei(utils.GetWatchtowerMaxFee(t.cfg))
+	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, &t.log, maxFee, 0) {
+		return nil
+	}
+
+	// Set the gas settings
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = eth.GweiToWei(utils.GetWatchtowerPrioFee(t.cfg))
+	opts.GasLimit = gasInfo.SafeGasLimit
+
+	// Make the challenge
+	hash, err := trustednode.MakeChallenge(t.rp, memberAddress, opts)
+	if err != nil {
+		return err
+	}
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	t.log.Printlnf("Successfully challenged member %s.", memberAddress.Hex())
+	challenge, err := getChallengeInfo(t.rp, memberAddress, nil)
+	if err != nil {
+		return err
+	}
+	publishChallengeEvent(t.publisher, &t.log, events.EventType_ChallengeMade, nodeAddress, challenge, false)
+	return nil
+
+}
+
+// Report a challenge this node made that the member didn't respond to, and remove the member if the policy allows it
+func (t *challengeMembers) handleExpiredChallenge(nodeAddress common.Address, challenge challengeInfo) error {
+
+	// Only report it if it's not going to be decided here
+	if !t.cfg.Smartnode.RemoveChallengedMembers.Value.(bool) {
+		if t.reportedExpirations[challenge.member] != challenge.time {
+			t.reportedExpirations[challenge.member] = challenge.time
+			t.log.Printlnf("Member %s didn't respond to this node's challenge by %s and can now be removed from the Oracle DAO by deciding the challenge.", challenge.member.Hex(), challenge.deadline.Format(time.RFC3339))
+			publishChallengeEvent(t.publisher, &t.log, events.EventType_ChallengeExpired, nodeAddress, challenge, false)
+		}
+		return nil
+	}
+
+	// Log
+	t.log.Printlnf("Member %s didn't respond to this node's challenge by %s, removing it from the Oracle DAO...", challenge.member.Hex(), challenge.deadline.Format(time.RFC3339))
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+
+	// Get the gas limit
+	gasInfo, err := trustednode.EstimateDecideChallengeGas(t.rp, challenge.member, opts)
+	if err != nil {
+		return fmt.Errorf("Could not estimate the gas required to decide the challenge: %w", err)
+	}
+
+	// Print the gas info
+	maxFee := eth.GweiToWei(utils.GetWatchtowerMaxFee(t.cfg))
+	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, &t.log, maxFee, 0) {
+		return nil
+	}
+
+	// Set the gas settings
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = eth.GweiToWei(utils.GetWatchtowerPrioFee(t.cfg))
+	opts.GasLimit = gasInfo.SafeGasLimit
+
+	// Decide the challenge
+	hash, err := trustednode.DecideChallenge(t.rp, challenge.member, opts)
+	if err != nil {
+		return err
+	}
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	if err != nil {
+		return err
+	}
+
+	// Log & return
+	t.log.Printlnf("Successfully removed member %s from the Oracle DAO.", challenge.member.Hex())
+	publishChallengeEvent(t.publisher, &t.log, events.EventType_ChallengeExpired, nodeAddress, challenge, true)
+	return nil
+
+}
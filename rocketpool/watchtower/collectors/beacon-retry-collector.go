@@ -0,0 +1,83 @@
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon/client"
+)
+
+// Represents the collector for the retry metrics of the Beacon client used for tree generation
+type BeaconRetryCollector struct {
+
+	// The number of requests sent to the Beacon Node, not counting retries
+	requestsDesc *prometheus.Desc
+
+	// The number of retries, by the kind of failure that caused them
+	retriesDesc *prometheus.Desc
+
+	// The number of requests for missing resources (such as missed slots) that were skipped without retrying
+	notFoundSkipsDesc *prometheus.Desc
+
+	// The number of requests that still failed after any retries
+	failuresDesc *prometheus.Desc
+
+	// The total time spent waiting between retries
+	retryWaitSecondsDesc *prometheus.Desc
+
+	// Gets the latest retry metrics
+	getMetrics func() client.RetryMetrics
+}
+
+// Create a new BeaconRetryCollector instance
+func NewBeaconRetryCollector(getMetrics func() client.RetryMetrics) *BeaconRetryCollector {
+	subsystem := "beacon_requests"
+	return &BeaconRetryCollector{
+		requestsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "total"),
+			"The number of requests sent to the Beacon Node, not counting retries",
+			nil, nil,
+		),
+		retriesDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "retries_total"),
+			"The number of retries, by the kind of failure that caused them",
+			[]string{"reason"}, nil,
+		),
+		notFoundSkipsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "not_found_total"),
+			"The number of requests for missing resources (such as missed slots) that were skipped without retrying",
+			nil, nil,
+		),
+		failuresDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "failures_total"),
+			"The number of requests that still failed after any retries",
+			nil, nil,
+		),
+		retryWaitSecondsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "retry_wait_seconds_total"),
+			"The total time spent waiting between retries",
+			nil, nil,
+		),
+		getMetrics: getMetrics,
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *BeaconRetryCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.requestsDesc
+	channel <- collector.retriesDesc
+	channel <- collector.notFoundSkipsDesc
+	channel <- collector.failuresDesc
+	channel <- collector.retryWaitSecondsDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *BeaconRetryCollector) Collect(channel chan<- prometheus.Metric) {
+	metrics := collector.getMetrics()
+	channel <- prometheus.MustNewConstMetric(
+		collector.requestsDesc, prometheus.CounterValue, float64(metrics.Requests))
+	channel <- prometheus.MustNewConstMetric(
+		collector.retriesDesc, prometheus.CounterValue, float64(metrics.OverloadedRetries), "overloaded")
+	channel <- prometheus.MustNewConstMetric(
+		collector.retriesDesc, prometheus.CounterValue, float64(metrics.TimeoutRetries), "timeout")
+	channel <- prometheus.MustNewConstMetric(
+		collector.notFoundSkipsDesc, prometheus.CounterValue, float64(metrics.NotFoundSkips))
+	channel <- prometheus.MustNewConstMetric(
+		collector.failuresDesc, prometheus.CounterValue, float64(metrics.Failures))
+	channel <- prometheus.MustNewConstMetric(
+		collector.retryWaitSecondsDesc, prometheus.CounterValue, metrics.RetryWait.Seconds())
+}
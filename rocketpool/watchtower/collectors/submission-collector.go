@@ -0,0 +1,104 @@
+package collectors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The results of the latest submission in a category
+type submissionResult struct {
+	inclusionDelay float64
+	dueDelay       float64
+	feeBumps       float64
+	maxFee         float64
+}
+
+// Represents the collector for the watchtower submission inclusion metrics
+type SubmissionCollector struct {
+
+	// The seconds between the first broadcast of the latest submission and its inclusion
+	inclusionDelayDesc *prometheus.Desc
+
+	// The seconds between the latest submission becoming due and its inclusion
+	dueDelayDesc *prometheus.Desc
+
+	// The number of times the latest submission's fees were bumped before it was included
+	feeBumpsDesc *prometheus.Desc
+
+	// The max fee (in gwei) the latest submission was included with
+	maxFeeDesc *prometheus.Desc
+
+	// The latest results, by submission category
+	results map[string]submissionResult
+
+	// Mutex
+	UpdateLock *sync.Mutex
+}
+
+// Create a new SubmissionCollector instance
+func NewSubmissionCollector() *SubmissionCollector {
+	subsystem := "submission"
+	labels := []string{"category"}
+	return &SubmissionCollector{
+		inclusionDelayDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "inclusion_delay_seconds"),
+			"The seconds between the first broadcast of the latest submission and its inclusion",
+			labels, nil,
+		),
+		dueDelayDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "due_delay_seconds"),
+			"The seconds between the latest submission becoming due and its inclusion",
+			labels, nil,
+		),
+		feeBumpsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "fee_bumps"),
+			"The number of times the latest submission's fees were bumped before it was included",
+			labels, nil,
+		),
+		maxFeeDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "max_fee_gwei"),
+			"The max fee (in gwei) the latest submission was included with",
+			labels, nil,
+		),
+		results:    map[string]submissionResult{},
+		UpdateLock: &sync.Mutex{},
+	}
+}
+
+// Record the inclusion of a submission
+func (collector *SubmissionCollector) RecordInclusion(category string, inclusionDelay time.Duration, dueDelay time.Duration, feeBumps int, maxFeeGwei float64) {
+	collector.UpdateLock.Lock()
+	defer collector.UpdateLock.Unlock()
+	collector.results[category] = submissionResult{
+		inclusionDelay: inclusionDelay.Seconds(),
+		dueDelay:       dueDelay.Seconds(),
+		feeBumps:       float64(feeBumps),
+		maxFee:         maxFeeGwei,
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *SubmissionCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.inclusionDelayDesc
+	channel <- collector.dueDelayDesc
+	channel <- collector.feeBumpsDesc
+	channel <- collector.maxFeeDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *SubmissionCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Sync
+	collector.UpdateLock.Lock()
+	defer collector.UpdateLock.Unlock()
+
+	// Update all of the metrics
+	for category, result := range collector.results {
+		channel <- prometheus.MustNewConstMetric(
+			collector.inclusionDelayDesc, prometheus.GaugeValue, result.inclusionDelay, category)
+		channel <- prometheus.MustNewConstMetric(
+			collector.dueDelayDesc, prometheus.GaugeValue, result.dueDelay, category)
+		channel <- prometheus.MustNewConstMetric(
+			collector.feeBumpsDesc, prometheus.GaugeValue, result.feeBumps, category)
+		channel <- prometheus.MustNewConstMetric(
+			collector.maxFeeDesc, prometheus.GaugeValue, result.maxFee, category)
+	}
+}
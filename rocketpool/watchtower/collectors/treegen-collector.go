@@ -0,0 +1,93 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for the rewards tree generation progress metrics
+type TreegenCollector struct {
+
+	// The rewards interval currently being generated
+	indexDesc *prometheus.Desc
+
+	// The percentage of the interval's epochs that have been processed
+	progressDesc *prometheus.Desc
+
+	// The number of epochs processed per second
+	epochsPerSecondDesc *prometheus.Desc
+
+	// The estimated number of seconds until generation completes
+	etaSecondsDesc *prometheus.Desc
+
+	// Whether generation is expected to finish after its deadline
+	behindDeadlineDesc *prometheus.Desc
+
+	// Counters
+	Index           float64
+	Progress        float64
+	EpochsPerSecond float64
+	EtaSeconds      float64
+	BehindDeadline  float64
+
+	// Mutex
+	UpdateLock *sync.Mutex
+}
+
+// Create a new TreegenCollector instance
+func NewTreegenCollector() *TreegenCollector {
+	subsystem := "treegen"
+	return &TreegenCollector{
+		indexDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "index"),
+			"The rewards interval currently being generated",
+			nil, nil,
+		),
+		progressDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "progress"),
+			"The percentage of the interval's epochs that have been processed",
+			nil, nil,
+		),
+		epochsPerSecondDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "epochs_per_second"),
+			"The number of epochs processed per second",
+			nil, nil,
+		),
+		etaSecondsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "eta_seconds"),
+			"The estimated number of seconds until generation completes",
+			nil, nil,
+		),
+		behindDeadlineDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "behind_deadline"),
+			"Whether generation is expected to finish after its deadline",
+			nil, nil,
+		),
+		UpdateLock: &sync.Mutex{},
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *TreegenCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.indexDesc
+	channel <- collector.progressDesc
+	channel <- collector.epochsPerSecondDesc
+	channel <- collector.etaSecondsDesc
+	channel <- collector.behindDeadlineDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *TreegenCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Sync
+	collector.UpdateLock.Lock()
+	defer collector.UpdateLock.Unlock()
+
+	// Update all of the metrics
+	channel <- prometheus.MustNewConstMetric(
+		collector.indexDesc, prometheus.GaugeValue, collector.Index)
+	channel <- prometheus.MustNewConstMetric(
+		collector.progressDesc, prometheus.GaugeValue, collector.Progress)
+	channel <- prometheus.MustNewConstMetric(
+		collector.epochsPerSecondDesc, prometheus.GaugeValue, collector.EpochsPerSecond)
+	channel <- prometheus.MustNewConstMetric(
+		collector.etaSecondsDesc, prometheus.GaugeValue, collector.EtaSeconds)
+	channel <- prometheus.MustNewConstMetric(
+		collector.behindDeadlineDesc, prometheus.GaugeValue, collector.BehindDeadline)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
@@ -37,10 +38,12 @@ type generateRewardsTree struct {
 	bc        beacon.Client
 	lock      *sync.Mutex
 	isRunning bool
+
+	treegenCollector *collectors.TreegenCollector
 }
 
 // Create generate rewards Merkle Tree task
-func newGenerateRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*generateRewardsTree, error) {
+func newGenerateRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, treegenCollector *collectors.TreegenCollector) (*generateRewardsTree, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -51,7 +54,8 @@ func newGenerateRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger
 	if err != nil {
 		return nil, err
 	}
-	bc, err := services.GetBeaconClient(c)
+	// Tree generation is a heavy historical workload, so keep it off the VC's Beacon Node when possible
+	bc, err := services.GetHistoricalBeaconClient(c)
 	if err != nil {
 		return nil, err
 	}
@@ -71,6 +75,8 @@ func newGenerateRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger
 		rp:        rp,
 		lock:      lock,
 		isRunning: false,
+
+		treegenCollector: treegenCollector,
 	}
 
 	return generator, nil
@@ -112,8 +118,21 @@ func (t *generateRewardsTree) run() error {
 				return fmt.Errorf("Error parsing index from [%s]: %w", filename, err)
 			}
 
-			// Delete the file
+			// Read the memory budget override, if the request has one
 			path := filepath.Join(requestDir, filename)
+			var memoryBudget uint64
+			contents, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("Error reading request file [%s]: %w", path, err)
+			}
+			if budgetString := strings.TrimSpace(string(contents)); budgetString != "" {
+				memoryBudget, err = strconv.ParseUint(budgetString, 0, 64)
+				if err != nil {
+					return fmt.Errorf("Error parsing memory budget from [%s]: %w", path, err)
+				}
+			}
+
+			// Delete the file
 			err = os.Remove(path)
 			if err != nil {
 				return fmt.Errorf("Error removing request file [%s]: %w", path, err)
@@ -123,7 +142,7 @@ func (t *generateRewardsTree) run() error {
 			t.lock.Lock()
 			t.isRunning = true
 			t.lock.Unlock()
-			go t.generateRewardsTree(index)
+			go t.generateRewardsTree(index, memoryBudget)
 
 			// Return after the first request, do others at other intervals
 			return nil
@@ -133,7 +152,7 @@ func (t *generateRewardsTree) run() error {
 	return nil
 }
 
-func (t *generateRewardsTree) generateRewardsTree(index uint64) {
+func (t *generateRewardsTree) generateRewardsTree(index uint64, memoryBudget uint64) {
 
 	// Begin generation of the tree
 	generationPrefix := fmt.Sprintf("[Interval %d Tree]", index)
@@ -233,11 +252,11 @@ func (t *generateRewardsTree) generateRewardsTree(index uint64) {
 	}
 
 	// Generate the tree
-	t.generateRewardsTreeImpl(client, index, generationPrefix, rewardsEvent, elBlockHeader, state)
+	t.generateRewardsTreeImpl(client, index, memoryBudget, generationPrefix, rewardsEvent, elBlockHeader, state)
 }
 
 // Implementation for rewards tree generation using a viable EC
-func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool, index uint64, generationPrefix string, rewardsEvent rewards.RewardsEvent, elBlockHeader *types.Header, state *state.NetworkState) {
+func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool, index uint64, memoryBudget uint64, generationPrefix string, rewardsEvent rewards.RewardsEvent, elBlockHeader *types.Header, state *state.NetworkState) {
 
 	// Determine the end of the interval
 	snapshotEnd := &rprewards.SnapshotEnd{
@@ -253,6 +272,11 @@ func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool,
 		t.handleError(fmt.Errorf("%s Error creating Merkle tree generator: %w", generationPrefix, err))
 		return
 	}
+	treegen.SetProgressCallback(time.Time{}, newTreegenProgressReporter(t.cfg, &t.log, generationPrefix, t.treegenCollector))
+	if memoryBudget > 0 {
+		t.log.Printlnf("%s Using the requested memory budget of %d MB.", generationPrefix, memoryBudget)
+		treegen.SetMemoryBudget(memoryBudget)
+	}
 	treeResult, err := treegen.GenerateTree()
 	if err != nil {
 		t.handleError(fmt.Errorf("%s Error generating Merkle tree: %w", generationPrefix, err))
@@ -281,6 +305,9 @@ func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool,
 		t.handleError(fmt.Errorf("%s failed to save rewards artifacts: %w", generationPrefix, err))
 		return
 	}
+	saveIntervalManifest(t.cfg, index, func(message string) {
+		t.log.Printlnf("%s %s", generationPrefix, message)
+	})
 
 	t.log.Printlnf("%s Merkle tree generation complete!", generationPrefix)
 	t.lock.Lock()
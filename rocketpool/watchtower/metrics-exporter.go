@@ -10,11 +10,12 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/health"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
 )
 
-func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *collectors.ScrubCollector, bondReductionCollector *collectors.BondReductionCollector, soloMigrationCollector *collectors.SoloMigrationCollector) error {
+func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *collectors.ScrubCollector, bondReductionCollector *collectors.BondReductionCollector, soloMigrationCollector *collectors.SoloMigrationCollector, treegenCollector *collectors.TreegenCollector, submissionCollector *collectors.SubmissionCollector, beaconRetryCollector *collectors.BeaconRetryCollector, healthMonitor *health.Monitor) error {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -22,12 +23,12 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *co
 		return err
 	}
 
-	// Return if metrics are disabled
+	// Only serve the health endpoints if metrics are disabled
 	if cfg.EnableMetrics.Value == false {
 		if strings.ToLower(os.Getenv("ENABLE_METRICS")) == "true" {
 			logger.Printlnf("ENABLE_METRICS override set to true, will start Metrics exporter anyway!")
 		} else {
-			return nil
+			return runHealthServer(c, logger, healthMonitor)
 		}
 	}
 
@@ -36,6 +37,9 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *co
 	registry.MustRegister(scrubCollector)
 	registry.MustRegister(bondReductionCollector)
 	registry.MustRegister(soloMigrationCollector)
+	registry.MustRegister(treegenCollector)
+	registry.MustRegister(submissionCollector)
+	registry.MustRegister(beaconRetryCollector)
 	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 
 	// Start the HTTP server
@@ -44,12 +48,14 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *co
 	logger.Printlnf("Starting metrics exporter on %s:%d.", metricsAddress, metricsPort)
 	metricsPath := "/metrics"
 	http.Handle(metricsPath, handler)
+	healthMonitor.RegisterHandlers(http.DefaultServeMux)
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(`<html>
             <head><title>Rocket Pool Watchtower Metrics Exporter</title></head>
             <body>
             <h1>Rocket Pool Watchtower Metrics Exporter</h1>
             <p><a href='` + metricsPath + `'>Metrics</a></p>
+            <p><a href='` + health.LivenessPath + `'>Liveness</a> | <a href='` + health.ReadinessPath + `'>Readiness</a></p>
             </body>
             </html>`,
 		))
@@ -62,3 +68,16 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *co
 	return nil
 
 }
+
+// Run an HTTP server with just the liveness and readiness endpoints
+func runHealthServer(c *cli.Context, logger log.ColorLogger, healthMonitor *health.Monitor) error {
+	metricsAddress := c.GlobalString("metricsAddress")
+	metricsPort := c.GlobalUint("metricsPort")
+	logger.Printlnf("Metrics are disabled; serving only the health endpoints on %s:%d.", metricsAddress, metricsPort)
+	healthMonitor.RegisterHandlers(http.DefaultServeMux)
+	err := http.ListenAndServe(fmt.Sprintf("%s:%d", metricsAddress, metricsPort), nil)
+	if err != nil {
+		return fmt.Errorf("Error running HTTP server: %w", err)
+	}
+	return nil
+}
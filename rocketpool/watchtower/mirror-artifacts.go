@@ -0,0 +1,130 @@
+package watchtower
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ipfs/go-cid"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/mirror"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+)
+
+// Upload the rewards artifacts to the configured mirrors, then record their CIDs and mirror URLs in the interval's
+// submission metadata. If the node is part of a signer quorum, the artifact manifest's attestation is uploaded with them.
+// Mirroring only improves the artifacts' availability, so failures are reported but don't stop the submission.
+func saveSubmissionMetadata(cfg *config.RocketPoolConfig, w *wallet.Wallet, rewardsFile rprewards.IRewardsFile, primaryCid cid.Cid, cids map[string]cid.Cid, printMessage func(string)) {
+	index := rewardsFile.GetIndex()
+	metadata := &rprewards.SubmissionMetadata{
+		Index:       index,
+		Network:     fmt.Sprint(cfg.Smartnode.Network.Value),
+		MerkleRoot:  rewardsFile.GetMerkleRoot(),
+		PrimaryCid:  primaryCid.String(),
+		Cids:        make(map[string]string, len(cids)),
+		GeneratedAt: time.Now().UTC(),
+	}
+	filenames := make([]string, 0, len(cids))
+	for filename, fileCid := range cids {
+		metadata.Cids[filename] = fileCid.String()
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	// Attest to the manifest
+	if attestationPath := attestManifest(cfg, w, metadata, printMessage); attestationPath != "" {
+		filenames = append(filenames, filepath.Base(attestationPath))
+	}
+
+	uploader, err := mirror.Load(cfg.Smartnode.GetArtifactMirrorsPath(true))
+	if err != nil {
+		printMessage(fmt.Sprintf("WARNING: Couldn't load the artifact mirror settings, so the artifacts won't be mirrored: %s", err.Error()))
+	} else if uploader != nil {
+		printMessage("Uploading the rewards artifacts to the mirrors...")
+		paths := make([]string, len(filenames))
+		for i, filename := range filenames {
+			paths[i] = filepath.Join(cfg.Smartnode.GetRewardsTreeDirectory(true), filename)
+		}
+		metadata.MirrorUrls, err = uploader.Upload(paths)
+		if err != nil {
+			printMessage(fmt.Sprintf("WARNING: Some artifacts couldn't be mirrored: %s", err.Error()))
+		}
+		for _, filename := range filenames {
+			for _, url := range metadata.MirrorUrls[filename] {
+				printMessage(fmt.Sprintf("\t%s - %s", filename, url))
+			}
+		}
+	}
+
+	path := cfg.Smartnode.GetRewardsSubmissionPath(index, true)
+	if err := metadata.Save(path); err != nil {
+		printMessage(fmt.Sprintf("WARNING: %s", err.Error()))
+		return
+	}
+	printMessage(fmt.Sprintf("Saved the submission metadata to %s.", path))
+}
+
+// Sign the interval's artifact manifest and combine the signature with the co-signers' detached signatures. Returns the
+// path of the saved attestation, or an empty string if the quorum's threshold wasn't met.
+func attestManifest(cfg *config.RocketPoolConfig, w *wallet.Wallet, metadata *rprewards.SubmissionMetadata, printMessage func(string)) string {
+	threshold := cfg.Smartnode.AttestationThreshold.Value.(uint64)
+	if threshold == 0 {
+		return ""
+	}
+
+	manifestHash, err := metadata.GetManifestHash()
+	if err != nil {
+		printMessage(fmt.Sprintf("WARNING: Couldn't attest to the artifact manifest: %s", err.Error()))
+		return ""
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		printMessage(fmt.Sprintf("WARNING: Couldn't attest to the artifact manifest: %s", err.Error()))
+		return ""
+	}
+	signature, err := rprewards.SignManifest(metadata.Index, manifestHash, nodeAccount.Address, w.SignMessage)
+	if err != nil {
+		printMessage(fmt.Sprintf("WARNING: Couldn't attest to the artifact manifest: %s", err.Error()))
+		return ""
+	}
+
+	// Save the node's own signature so it can be shared with the co-signers
+	signaturesDir := cfg.Smartnode.GetManifestSignaturesDirectory(true)
+	if err := rprewards.SaveManifestSignature(signaturesDir, signature); err != nil {
+		printMessage(fmt.Sprintf("WARNING: %s", err.Error()))
+	}
+	printMessage(fmt.Sprintf("Signed artifact manifest %s.", manifestHash.Hex()))
+
+	signatures, err := rprewards.LoadManifestSignatures(signaturesDir, metadata.Index, manifestHash)
+	if err != nil {
+		printMessage(fmt.Sprintf("WARNING: %s", err.Error()))
+	}
+	signatures = append(signatures, signature)
+	attestation, err := rprewards.AggregateAttestation(metadata.Index, manifestHash, cfg.Smartnode.GetAttestationSigners(), threshold, signatures)
+	if err != nil {
+		printMessage(fmt.Sprintf("WARNING: The artifact manifest attestation won't be published: %s. Co-signers' detached signatures go in %s.", err.Error(), signaturesDir))
+		return ""
+	}
+
+	path := cfg.Smartnode.GetRewardsAttestationPath(metadata.Index, true)
+	if err := attestation.Save(path); err != nil {
+		printMessage(fmt.Sprintf("WARNING: %s", err.Error()))
+		return ""
+	}
+	printMessage(fmt.Sprintf("Saved the attestation with %d of %d quorum signatures to %s.", len(attestation.Signatures), len(attestation.Signers), path))
+	return path
+}
+
+// Save the manifest of the interval's artifacts and link them into the configured layout for downstream tools. The
+// artifacts are already saved, so failures are reported but don't stop tree generation.
+func saveIntervalManifest(cfg *config.RocketPoolConfig, index uint64, printMessage func(string)) {
+	manifest, err := rprewards.SaveIntervalManifest(cfg.Smartnode, index, true)
+	if err != nil {
+		printMessage(fmt.Sprintf("WARNING: Couldn't save the manifest of the interval's artifacts: %s", err.Error()))
+		return
+	}
+	printMessage(fmt.Sprintf("Saved the manifest of the interval's %d artifacts to %s.", len(manifest.Artifacts), cfg.Smartnode.GetRewardsManifestPath(index, false)))
+}
@@ -0,0 +1,392 @@
+package watchtower
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How long after a submission time the watchtower has to submit a duty before it counts as missed
+const dutyWindow time.Duration = time.Hour
+
+// How far behind the chain head the Execution client can be before a missed duty is blamed on sync lag
+const dutySyncLag time.Duration = 5 * time.Minute
+
+// The node wallet's balance below which a missed duty is blamed on the node not being able to pay for it
+const dutyMinBalanceEth float64 = 0.1
+
+// The reasons a duty can be missed
+type dutyMissCause string
+
+const (
+	dutyMissCause_SyncLag      dutyMissCause = "sync-lag"
+	dutyMissCause_LowBalance   dutyMissCause = "low-balance"
+	dutyMissCause_Revert       dutyMissCause = "revert"
+	dutyMissCause_Error        dutyMissCause = "error"
+	dutyMissCause_NotAttempted dutyMissCause = "not-attempted"
+)
+
+// A record of a missed duty, appended to the missed duties log
+type missedDuty struct {
+	Duty              string        `json:"duty"`
+	SubmissionTime    time.Time     `json:"submissionTime"`
+	TargetBlock       uint64        `json:"targetBlock,omitempty"`
+	DetectedAt        time.Time     `json:"detectedAt"`
+	Cause             dutyMissCause `json:"cause"`
+	Detail            string        `json:"detail"`
+	Hint              string        `json:"hint"`
+	ConsecutiveMisses uint64        `json:"consecutiveMisses"`
+}
+
+// The submission a duty is currently expected to make
+type dutyTarget struct {
+	lastSubmissionBlock uint64
+	submissionTimestamp uint64
+	targetBlock         uint64
+	targetErr           error
+	submitted           bool
+	reported            bool
+}
+
+// A periodic submission the watchtower is responsible for
+type watchtowerDuty struct {
+	name                   string
+	isEnabled              func(*state.NetworkState) bool
+	getLastSubmissionBlock func(*state.NetworkState) uint64
+	getInterval            func(*state.NetworkState) uint64
+	hasSubmitted           func(nodeAddress common.Address, target *dutyTarget) (bool, error)
+	getLastError           func() error
+
+	pending           *dutyTarget
+	consecutiveMisses uint64
+}
+
+// Monitor missed duties task
+type monitorMissedDuties struct {
+	c      *cli.Context
+	log    log.ColorLogger
+	errLog log.ColorLogger
+	cfg    *config.RocketPoolConfig
+	rp     *rocketpool.RocketPool
+	ec     rocketpool.ExecutionClient
+	bc     beacon.Client
+	duties []*watchtowerDuty
+
+	// The last time the clients were found out of sync, and why
+	lock            sync.Mutex
+	lastSyncProblem time.Time
+	syncProblem     string
+}
+
+// Create monitor missed duties task
+func newMonitorMissedDuties(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, submitRplPrice *submitRplPrice, submitNetworkBalances *submitNetworkBalances) (*monitorMissedDuties, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// The duties to watch
+	duties := []*watchtowerDuty{
+		{
+			name: "RPL price",
+			isEnabled: func(state *state.NetworkState) bool {
+				return state.NetworkDetails.SubmitPricesEnabled
+			},
+			getLastSubmissionBlock: func(state *state.NetworkState) uint64 {
+				return state.NetworkDetails.PricesBlock
+			},
+			getInterval: func(state *state.NetworkState) uint64 {
+				return state.NetworkDetails.PricesSubmissionFrequency
+			},
+			hasSubmitted: func(nodeAddress common.Address, target *dutyTarget) (bool, error) {
+				return submitRplPrice.hasSubmittedBlockPrices(nodeAddress, target.targetBlock, target.submissionTimestamp)
+			},
+			getLastError: submitRplPrice.getLastError,
+		},
+		{
+			name: "network balance",
+			isEnabled: func(state *state.NetworkState) bool {
+				return state.NetworkDetails.SubmitBalancesEnabled
+			},
+			getLastSubmissionBlock: func(state *state.NetworkState) uint64 {
+				return state.NetworkDetails.BalancesBlock
+			},
+			getInterval: func(state *state.NetworkState) uint64 {
+				return state.NetworkDetails.BalancesSubmissionFrequency
+			},
+			hasSubmitted: func(nodeAddress common.Address, target *dutyTarget) (bool, error) {
+				return submitNetworkBalances.hasSubmittedBlockBalances(nodeAddress, target.targetBlock)
+			},
+			getLastError: submitNetworkBalances.getLastError,
+		},
+	}
+
+	// Return task
+	return &monitorMissedDuties{
+		c:      c,
+		log:    logger,
+		errLog: errorLogger,
+		cfg:    cfg,
+		rp:     rp,
+		ec:     ec,
+		bc:     bc,
+		duties: duties,
+	}, nil
+
+}
+
+// Record the result of the latest client sync check, so duties missed while the clients were behind are blamed on it
+func (t *monitorMissedDuties) setSyncStatus(err error) {
+	if err == nil {
+		return
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.lastSyncProblem = time.Now()
+	t.syncProblem = err.Error()
+}
+
+// Forget the pending submissions while this instance isn't responsible for the duties, such as when it's on standby
+// or finality has stalled, so it doesn't report duties it was never expected to make
+func (t *monitorMissedDuties) reset() {
+	for _, duty := range t.duties {
+		duty.pending = nil
+	}
+}
+
+// Check whether the watchtower made each of the duties it's responsible for within their windows
+func (t *monitorMissedDuties) run(state *state.NetworkState, nodeAddress common.Address) error {
+
+	latestHeader, err := t.rp.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("error getting the latest block: %w", err)
+	}
+
+	for _, duty := range t.duties {
+		if !duty.isEnabled(state) {
+			duty.pending = nil
+			continue
+		}
+		if err := t.checkDuty(duty, state, nodeAddress, latestHeader.Time); err != nil {
+			t.errLog.Printlnf("Error checking the %s duty: %s", duty.name, err.Error())
+		}
+	}
+	return nil
+
+}
+
+// Check a single duty, reporting it if the Oracle DAO settled its submission without this node or its window passed
+func (t *monitorMissedDuties) checkDuty(duty *watchtowerDuty, state *state.NetworkState, nodeAddress common.Address, latestBlockTime uint64) error {
+
+	// Check the pending submission once the Oracle DAO has reached consensus on it
+	lastSubmissionBlock := duty.getLastSubmissionBlock(state)
+	if duty.pending != nil && duty.pending.lastSubmissionBlock != lastSubmissionBlock {
+		settled := duty.pending
+		duty.pending = nil
+		if !settled.reported {
+			submitted, err := t.wasSubmitted(duty, settled, nodeAddress)
+			if err != nil {
+				return err
+			}
+			if submitted {
+				duty.consecutiveMisses = 0
+			} else {
+				t.reportMiss(duty, settled, nodeAddress, "the Oracle DAO reached consensus without this node's submission")
+			}
+		}
+	}
+
+	// Get the submission that's due, if there is one
+	lastSubmissionHeader, err := t.rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(lastSubmissionBlock))
+	if err != nil {
+		return fmt.Errorf("error getting the last submission block: %w", err)
+	}
+	interval := duty.getInterval(state)
+	if interval == 0 || lastSubmissionHeader.Time+interval > latestBlockTime {
+		return nil
+	}
+	referenceTimestamp := t.cfg.Smartnode.PriceBalanceSubmissionReferenceTimestamp.Value.(int64)
+	submissionTimestamp, err := utils.FindNextSubmissionTimestamp(int64(latestBlockTime), referenceTimestamp, int64(interval))
+	if err != nil {
+		return err
+	}
+	if duty.pending == nil || duty.pending.submissionTimestamp != uint64(submissionTimestamp) {
+		duty.pending = &dutyTarget{
+			lastSubmissionBlock: lastSubmissionBlock,
+			submissionTimestamp: uint64(submissionTimestamp),
+		}
+	}
+	pending := duty.pending
+
+	// Find the target block, which needs the submission time's epoch to be finalized
+	if pending.targetBlock == 0 {
+		_, _, targetBlockHeader, err := utils.FindNextSubmissionTarget(t.rp, state.BeaconConfig, t.bc, t.ec, lastSubmissionBlock, referenceTimestamp, int64(interval))
+		if err != nil {
+			pending.targetErr = err
+		} else {
+			pending.targetBlock = targetBlockHeader.Number.Uint64()
+			pending.targetErr = nil
+		}
+	}
+
+	// Check the submission once its window has passed
+	deadline := time.Unix(submissionTimestamp, 0).Add(dutyWindow)
+	if pending.reported || pending.submitted || time.Now().Before(deadline) {
+		return nil
+	}
+	submitted, err := t.wasSubmitted(duty, pending, nodeAddress)
+	if err != nil {
+		return err
+	}
+	if !submitted {
+		t.reportMiss(duty, pending, nodeAddress, fmt.Sprintf("this node didn't submit it within %s", dutyWindow))
+	}
+	return nil
+
+}
+
+// Check if the node submitted a duty's pending submission
+func (t *monitorMissedDuties) wasSubmitted(duty *watchtowerDuty, target *dutyTarget, nodeAddress common.Address) (bool, error) {
+	if target.submitted {
+		return true, nil
+	}
+	if target.targetBlock == 0 {
+		// Without a target block the watchtower couldn't have submitted it either
+		return false, nil
+	}
+	submitted, err := duty.hasSubmitted(nodeAddress, target)
+	if err != nil {
+		return false, fmt.Errorf("error checking the %s submission for block %d: %w", duty.name, target.targetBlock, err)
+	}
+	target.submitted = submitted
+	return submitted, nil
+}
+
+// Find out why a duty was missed, then log, record, and alert about it
+func (t *monitorMissedDuties) reportMiss(duty *watchtowerDuty, target *dutyTarget, nodeAddress common.Address, summary string) {
+	target.reported = true
+	duty.consecutiveMisses++
+
+	cause, detail, hint := t.diagnose(duty, target, nodeAddress)
+	record := missedDuty{
+		Duty:              duty.name,
+		SubmissionTime:    time.Unix(int64(target.submissionTimestamp), 0).UTC(),
+		TargetBlock:       target.targetBlock,
+		DetectedAt:        time.Now().UTC(),
+		Cause:             cause,
+		Detail:            detail,
+		Hint:              hint,
+		ConsecutiveMisses: duty.consecutiveMisses,
+	}
+
+	t.errLog.Printlnf("WARNING: the watchtower missed the %s submission for %s: %s.", duty.name, record.SubmissionTime.Format(time.RFC1123), summary)
+	t.errLog.Printlnf("Cause: %s (%s). %s", cause, detail, hint)
+	if err := t.saveMissedDuty(record); err != nil {
+		t.errLog.Printlnf("Error recording the missed duty: %s", err.Error())
+	}
+	if err := alerting.AlertWatchtowerDutyMissed(t.cfg, duty.name, record.SubmissionTime, string(cause), detail, hint, duty.consecutiveMisses); err != nil {
+		t.errLog.Printlnf("Error sending the missed duty alert: %s", err.Error())
+	}
+}
+
+// Find the most likely reason a duty was missed, and a hint for fixing it
+func (t *monitorMissedDuties) diagnose(duty *watchtowerDuty, target *dutyTarget, nodeAddress common.Address) (dutyMissCause, string, string) {
+	submissionTime := time.Unix(int64(target.submissionTimestamp), 0)
+	lastError := duty.getLastError()
+
+	// Check if the clients were behind
+	t.lock.Lock()
+	lastSyncProblem, syncProblem := t.lastSyncProblem, t.syncProblem
+	t.lock.Unlock()
+	if lastSyncProblem.After(submissionTime) {
+		return dutyMissCause_SyncLag, syncProblem, "Make sure your Execution and Beacon clients are synced and have enough peers, and consider adding fallback clients."
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	header, err := t.ec.HeaderByNumber(ctx, nil)
+	cancel()
+	if err == nil {
+		lag := time.Since(time.Unix(int64(header.Time), 0))
+		if lag > dutySyncLag {
+			return dutyMissCause_SyncLag, fmt.Sprintf("the Execution client's head is %s old", lag.Round(time.Second)), "Make sure your Execution client is synced and has enough peers, and consider adding a fallback client."
+		}
+	}
+
+	// Check if the node can pay for the duty
+	ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
+	balance, err := t.ec.BalanceAt(ctx, nodeAddress, nil)
+	cancel()
+	isOutOfFunds := lastError != nil && strings.Contains(strings.ToLower(lastError.Error()), "insufficient funds")
+	if isOutOfFunds || (err == nil && eth.WeiToEth(balance) < dutyMinBalanceEth) {
+		detail := "the transaction couldn't pay for its gas"
+		if err == nil {
+			detail = fmt.Sprintf("the node wallet has %.6f ETH", eth.WeiToEth(balance))
+		}
+		return dutyMissCause_LowBalance, detail, fmt.Sprintf("Send at least %.1f ETH to the node wallet (%s) so it can pay for its duty transactions.", dutyMinBalanceEth, nodeAddress.Hex())
+	}
+
+	// Check how the last attempt failed
+	if lastError != nil {
+		if strings.Contains(strings.ToLower(lastError.Error()), "revert") {
+			return dutyMissCause_Revert, lastError.Error(), "Compare the values it submitted with the other Oracle DAO members', and enable Trace Failed Duties to capture where the transaction reverted."
+		}
+		return dutyMissCause_Error, lastError.Error(), "Check the watchtower's logs around the submission time for the full error."
+	}
+	if target.targetErr != nil {
+		return dutyMissCause_Error, fmt.Sprintf("the target block couldn't be found: %s", target.targetErr.Error()), "Make sure your Beacon client is synced and the chain is finalizing."
+	}
+	return dutyMissCause_NotAttempted, "the watchtower never tried to submit it", "Make sure the watchtower is running and not stuck, and that this instance holds the duty lease if you run more than one."
+}
+
+// Append a missed duty to the missed duties log
+func (t *monitorMissedDuties) saveMissedDuty(record missedDuty) error {
+	path := t.cfg.Smartnode.GetMissedDutiesPath(true)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating folder for %s: %w", path, err)
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("error serializing missed duty: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", path, err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("error writing to %s: %w", path, err)
+	}
+	return nil
+}
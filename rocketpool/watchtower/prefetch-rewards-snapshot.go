@@ -0,0 +1,219 @@
+package watchtower
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/tokens"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How long before the interval boundary to start watching for the snapshot slot. This must be longer than the task loop
+// interval so the prefetch starts right at the boundary instead of on the first loop after it.
+const snapshotPrefetchLeadTime time.Duration = 15 * time.Minute
+
+// The snapshot details and network state loaded ahead of tree generation
+type prefetchedSnapshot struct {
+	index           uint64
+	intervalsPassed uint64
+	snapshotEnd     *rprewards.SnapshotEnd
+	elBlockHeader   *types.Header
+	state           *state.NetworkState
+}
+
+// Prefetch rewards snapshot task
+type prefetchRewardsSnapshot struct {
+	c         *cli.Context
+	log       *log.ColorLogger
+	errLog    *log.ColorLogger
+	cfg       *config.RocketPoolConfig
+	rp        *rocketpool.RocketPool
+	ec        rocketpool.ExecutionClient
+	bc        beacon.Client
+	lock      *sync.Mutex
+	isRunning bool
+	snapshot  *prefetchedSnapshot
+}
+
+// Create prefetch rewards snapshot task
+func newPrefetchRewardsSnapshot(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*prefetchRewardsSnapshot, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	// Use the same Beacon Node as tree generation so the state matches what it would have loaded itself
+	bc, err := services.GetHistoricalBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &prefetchRewardsSnapshot{
+		c:      c,
+		log:    &logger,
+		errLog: &errorLogger,
+		cfg:    cfg,
+		rp:     rp,
+		ec:     ec,
+		bc:     bc,
+		lock:   &sync.Mutex{},
+	}, nil
+
+}
+
+// Start prefetching the next rewards snapshot if the interval boundary is coming up
+func (t *prefetchRewardsSnapshot) run(state *state.NetworkState) error {
+
+	// Check if prefetching is enabled
+	if !t.cfg.Smartnode.PrefetchRewardsSnapshot.Value.(bool) {
+		return nil
+	}
+
+	// Get the interval timing, adjusting for the first interval like tree generation does
+	startTime := state.NetworkDetails.IntervalStart
+	intervalTime := state.NetworkDetails.IntervalDuration
+	if startTime == time.Unix(0, 0) {
+		opts := &bind.CallOpts{
+			BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+		}
+		var err error
+		startTime, err = tokens.GetRPLInflationIntervalStartTime(t.rp, opts)
+		if err != nil {
+			return fmt.Errorf("start time is zero, but error getting Rocket Pool deployment block: %w", err)
+		}
+	}
+
+	// Get the boundary the next tree will snapshot at: either the one that has already passed, or the upcoming one
+	genesisTime := time.Unix(int64(state.BeaconConfig.GenesisTime), 0)
+	stateTime := genesisTime.Add(time.Duration(state.BeaconConfig.SecondsPerSlot*state.BeaconSlotNumber) * time.Second)
+	intervalsPassed := uint64(stateTime.Sub(startTime) / intervalTime)
+	if intervalsPassed == 0 {
+		intervalsPassed = 1
+		if startTime.Add(intervalTime).Sub(stateTime) > snapshotPrefetchLeadTime {
+			return nil
+		}
+	}
+	endTime := startTime.Add(intervalTime * time.Duration(intervalsPassed))
+	index := state.NetworkDetails.RewardIndex
+
+	// Ignore intervals that already have a tree or a prefetch
+	if _, err := os.Stat(t.cfg.Smartnode.GetRewardsTreePath(index, true, config.RewardsExtensionJSON)); err == nil {
+		return nil
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.isRunning || (t.snapshot != nil && t.snapshot.index == index && t.snapshot.intervalsPassed == intervalsPassed) {
+		return nil
+	}
+
+	// Start the prefetch in the background, since it has to wait for the snapshot slot
+	t.isRunning = true
+	t.snapshot = nil
+	go func() {
+		snapshot, err := t.prefetch(index, intervalsPassed, endTime, state.BeaconConfig)
+		if err != nil {
+			t.errLog.Println(fmt.Errorf("error prefetching the rewards snapshot for interval %d: %w", index, err))
+		}
+
+		t.lock.Lock()
+		t.snapshot = snapshot
+		t.isRunning = false
+		t.lock.Unlock()
+	}()
+	return nil
+
+}
+
+// Wait for the snapshot slot to be proposed, then load the snapshot details and the network state for it
+func (t *prefetchRewardsSnapshot) prefetch(index uint64, intervalsPassed uint64, endTime time.Time, eth2Config beacon.Eth2Config) (*prefetchedSnapshot, error) {
+
+	// Wait until the epoch with the target slot is over
+	targetSlot, targetSlotEpoch := getSnapshotTargetSlot(endTime, eth2Config)
+	t.log.Printlnf("Rewards interval %d ends at %s; the snapshot for it will be prefetched once slot %d has passed.", index, endTime, targetSlot)
+	deadline := endTime.Add(snapshotPrefetchLeadTime)
+	for {
+		beaconHead, err := t.bc.GetBeaconHead()
+		if err != nil {
+			return nil, fmt.Errorf("error getting Beacon head: %w", err)
+		}
+		if beaconHead.Epoch > targetSlotEpoch {
+			break
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("the Beacon Chain didn't reach epoch %d by %s", targetSlotEpoch+1, deadline)
+		}
+		time.Sleep(time.Duration(eth2Config.SecondsPerSlot) * time.Second)
+	}
+
+	// Get the snapshot blocks. They aren't finalized yet, so tree generation will check them again before using the state.
+	prefetchStart := time.Now()
+	snapshotEnd, err := findSnapshotBlock(t.bc, t.log, targetSlot)
+	if err != nil {
+		return nil, err
+	}
+	elBlockHeader, err := t.ec.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(snapshotEnd.ExecutionBlock))
+	if err != nil {
+		return nil, fmt.Errorf("error getting EL block %d: %w", snapshotEnd.ExecutionBlock, err)
+	}
+
+	// Load the network state
+	mgr := state.NewNetworkStateManager(t.rp, t.cfg.Smartnode.GetStateManagerContracts(), t.bc, t.log)
+	snapshotState, err := mgr.GetStateForSlot(snapshotEnd.ConsensusBlock)
+	if err != nil {
+		return nil, fmt.Errorf("error getting network state for Beacon slot %d: %w", snapshotEnd.ConsensusBlock, err)
+	}
+
+	t.log.Printlnf("Prefetched the rewards snapshot for interval %d (Beacon slot %d, EL block %d) in %s.", index, snapshotEnd.ConsensusBlock, snapshotEnd.ExecutionBlock, time.Since(prefetchStart))
+	return &prefetchedSnapshot{
+		index:           index,
+		intervalsPassed: intervalsPassed,
+		snapshotEnd:     snapshotEnd,
+		elBlockHeader:   elBlockHeader,
+		state:           snapshotState,
+	}, nil
+
+}
+
+// Take the prefetched network state for a finalized snapshot, if it was prefetched and the blocks still match.
+// The state is handed over rather than kept, since it's only needed once per interval.
+func (t *prefetchRewardsSnapshot) takeState(index uint64, snapshotEnd *rprewards.SnapshotEnd, elBlockHeader *types.Header) *state.NetworkState {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	snapshot := t.snapshot
+	if snapshot == nil || snapshot.state == nil || snapshot.index != index {
+		return nil
+	}
+	if snapshot.snapshotEnd.ConsensusBlock != snapshotEnd.ConsensusBlock || snapshot.elBlockHeader.Hash() != elBlockHeader.Hash() {
+		t.log.Printlnf("The prefetched rewards snapshot for interval %d (Beacon slot %d, EL block %s) doesn't match the finalized one, ignoring it.", index, snapshot.snapshotEnd.ConsensusBlock, snapshot.elBlockHeader.Hash().Hex())
+		snapshot.state = nil
+		return nil
+	}
+	networkState := snapshot.state
+	snapshot.state = nil
+	return networkState
+}
@@ -0,0 +1,72 @@
+package watchtower
+
+import (
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/ecprobe"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often to probe the Execution client's database and the disk
+const ecProbeInterval time.Duration = 6 * time.Hour
+
+// Probe Execution client task
+type probeExecutionClient struct {
+	c       *cli.Context
+	log     log.ColorLogger
+	errLog  log.ColorLogger
+	cfg     *config.RocketPoolConfig
+	rp      *rocketpool.RocketPool
+	lastRun time.Time
+}
+
+// Create probe Execution client task
+func newProbeExecutionClient(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*probeExecutionClient, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &probeExecutionClient{
+		c:      c,
+		log:    logger,
+		errLog: errorLogger,
+		cfg:    cfg,
+		rp:     rp,
+	}, nil
+
+}
+
+// Check the Execution client's ancient store, the state tree generation needs, and the disk's latency, so problems
+// are found before they fail a tree generation run
+func (t *probeExecutionClient) run() error {
+
+	if time.Since(t.lastRun) < ecProbeInterval {
+		return nil
+	}
+	t.lastRun = time.Now()
+
+	t.log.Println("Probing the Execution client's database and the disk...")
+	report := ecprobe.Run(t.cfg, t.rp, t.cfg.Smartnode.GetRewardsTreeDirectory(true))
+	for _, probe := range report.Probes {
+		if probe.Healthy {
+			t.log.Printlnf("%s: %s.", probe.Name, probe.Detail)
+		} else {
+			t.errLog.Printlnf("WARNING: the %s probe failed, so tree generation may fail: %s.", probe.Name, probe.Detail)
+		}
+	}
+	return nil
+
+}
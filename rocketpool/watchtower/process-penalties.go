@@ -27,6 +27,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	fee "github.com/rocket-pool/smartnode/rocketpool/node"
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -520,7 +521,11 @@ func (t *processPenalties) submitPenalty(minipoolAddress common.Address, block *
 	opts.GasTipCap = fee.GetPriorityFee(t.maxPriorityFee, maxFee)
 	opts.GasLimit = gas.Uint64()
 
-	hash, err := network.SubmitPenalty(t.rp, minipoolAddress, slotBig, opts)
+	txRp, err := utils.GetTransactionRocketPool(t.cfg, t.rp, utils.TxCategory_Penalty)
+	if err != nil {
+		return err
+	}
+	hash, err := network.SubmitPenalty(txRp, minipoolAddress, slotBig, opts)
 	if err != nil {
 		return fmt.Errorf("Error submitting penalty against %s for block %d: %w", minipoolAddress.Hex(), block.Slot, err)
 	}
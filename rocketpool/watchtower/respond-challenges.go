@@ -2,33 +2,57 @@ package watchtower
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/events"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
+// Storage keys for the challenge made against an Oracle DAO member
+const (
+	challengedTimeKey string = "dao.trusted.member.challenged.time"
+	challengedByKey   string = "dao.trusted.member.challenged.by"
+)
+
 // Respond to challenges task
 type respondChallenges struct {
-	c   *cli.Context
-	log log.ColorLogger
-	cfg *config.RocketPoolConfig
-	w   *wallet.Wallet
-	rp  *rocketpool.RocketPool
-	m   *state.NetworkStateManager
+	c         *cli.Context
+	log       log.ColorLogger
+	cfg       *config.RocketPoolConfig
+	w         *wallet.Wallet
+	rp        *rocketpool.RocketPool
+	m         *state.NetworkStateManager
+	publisher *events.Publisher
+
+	// The challenge that has already been announced, so it's only published once
+	announcedChallengeTime time.Time
+}
+
+// The details of a challenge against an Oracle DAO member
+type challengeInfo struct {
+	member     common.Address
+	challenger common.Address
+	time       time.Time
+	deadline   time.Time
 }
 
 // Create respond to challenges task
-func newRespondChallenges(c *cli.Context, logger log.ColorLogger, m *state.NetworkStateManager) (*respondChallenges, error) {
+func newRespondChallenges(c *cli.Context, logger log.ColorLogger, m *state.NetworkStateManager, publisher *events.Publisher) (*respondChallenges, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -46,12 +70,13 @@ func newRespondChallenges(c *cli.Context, logger log.ColorLogger, m *state.Netwo
 
 	// Return task
 	return &respondChallenges{
-		c:   c,
-		log: logger,
-		cfg: cfg,
-		w:   w,
-		rp:  rp,
-		m:   m,
+		c:         c,
+		log:       logger,
+		cfg:       cfg,
+		w:         w,
+		rp:        rp,
+		m:         m,
+		publisher: publisher,
 	}, nil
 
 }
@@ -82,8 +107,21 @@ func (t *respondChallenges) run() error {
 		return nil
 	}
 
+	// Get the challenge details
+	challenge, err := getChallengeInfo(t.rp, nodeAccount.Address, nil)
+	if err != nil {
+		return err
+	}
+	if challenge.time != t.announcedChallengeTime {
+		t.announcedChallengeTime = challenge.time
+		publishChallengeEvent(t.publisher, &t.log, events.EventType_ChallengeReceived, nodeAccount.Address, challenge, false)
+	}
+
 	// Log
-	t.log.Printlnf("Node %s has an active challenge against it, responding...", nodeAccount.Address.Hex())
+	t.log.Printlnf("Node %s was challenged by %s at %s and must respond by %s, responding...", nodeAccount.Address.Hex(), challenge.challenger.Hex(), challenge.time.Format(time.RFC3339), challenge.deadline.Format(time.RFC3339))
+	if time.Now().After(challenge.deadline) {
+		t.log.Println("WARNING: the challenge window has already passed, so the node can be removed from the Oracle DAO at any time.")
+	}
 
 	// Get transactor
 	opts, err := t.w.GetNodeAccountTransactor()
@@ -122,6 +160,53 @@ func (t *respondChallenges) run() error {
 
 	// Log & return
 	t.log.Printlnf("Successfully responded to challenge against node %s.", nodeAccount.Address.Hex())
+	publishChallengeEvent(t.publisher, &t.log, events.EventType_ChallengeResponded, nodeAccount.Address, challenge, false)
 	return nil
 
 }
+
+// Get the details of the challenge against an Oracle DAO member
+func getChallengeInfo(rp *rocketpool.RocketPool, memberAddress common.Address, opts *bind.CallOpts) (challengeInfo, error) {
+	challengedTime, err := rp.RocketStorage.GetUint(opts, crypto.Keccak256Hash([]byte(challengedTimeKey), memberAddress.Bytes()))
+	if err != nil {
+		return challengeInfo{}, fmt.Errorf("error getting the challenge time for member %s: %w", memberAddress.Hex(), err)
+	}
+	challenger, err := rp.RocketStorage.GetAddress(opts, crypto.Keccak256Hash([]byte(challengedByKey), memberAddress.Bytes()))
+	if err != nil {
+		return challengeInfo{}, fmt.Errorf("error getting the challenger of member %s: %w", memberAddress.Hex(), err)
+	}
+	window, err := tnsettings.GetChallengeWindow(rp, opts)
+	if err != nil {
+		return challengeInfo{}, fmt.Errorf("error getting the challenge window: %w", err)
+	}
+
+	challengeTime := time.Unix(challengedTime.Int64(), 0)
+	return challengeInfo{
+		member:     memberAddress,
+		challenger: challenger,
+		time:       challengeTime,
+		deadline:   challengeTime.Add(time.Duration(window) * time.Second),
+	}, nil
+}
+
+// Publish an event about a challenge, if the watchtower has somewhere to publish events to
+func publishChallengeEvent(publisher *events.Publisher, logger *log.ColorLogger, eventType events.EventType, nodeAddress common.Address, challenge challengeInfo, removed bool) {
+	if publisher == nil {
+		return
+	}
+	err := publisher.Publish(events.Event{
+		Type: eventType,
+		Node: nodeAddress,
+		Time: time.Now().UTC(),
+		Data: events.ChallengeData{
+			Member:     challenge.member,
+			Challenger: challenge.challenger,
+			Time:       challenge.time,
+			Deadline:   challenge.deadline,
+			Removed:    removed,
+		},
+	})
+	if err != nil {
+		logger.Printlnf("WARNING: %s", err.Error())
+	}
+}
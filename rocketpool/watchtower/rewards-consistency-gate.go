@@ -0,0 +1,107 @@
+package watchtower
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+)
+
+// Run the consistency gate on a freshly generated rewards tree and save its report.
+// Returns true if the tree can be submitted, either because it passed or because its failure was approved manually.
+func (t *submitRewardsTree_Stateless) runConsistencyGate(treeResult *rprewards.GenerateTreeResult, smoothingPoolBalance *big.Int, regenerate func() (rprewards.IRewardsFile, error)) (bool, error) {
+	t.printMessage("Running the consistency gate, which includes generating the tree a second time...")
+	inputs := rprewards.ConsistencyGateInputs{
+		RewardsFile:             treeResult.RewardsFile,
+		MinipoolPerformanceFile: treeResult.MinipoolPerformanceFile,
+		SmoothingPoolBalance:    smoothingPoolBalance,
+		BeaconClient:            t.bc,
+		Regenerate:              regenerate,
+	}
+	return t.enforceConsistencyGate(inputs)
+}
+
+// Check an existing rewards tree against the consistency gate before resubmitting it.
+// Trees that already have a report for their root reuse it; otherwise the gate is run without regenerating the tree.
+// Returns true if the tree can be submitted.
+func (t *submitRewardsTree_Stateless) checkExistingConsistencyGate(rewardsFile rprewards.IRewardsFile) (bool, error) {
+	index := rewardsFile.GetIndex()
+	reportPath := t.cfg.Smartnode.GetConsistencyGateReportPath(index, true)
+	if _, err := os.Stat(reportPath); err == nil {
+		report, err := rprewards.LoadConsistencyGateReport(reportPath)
+		if err != nil {
+			return false, err
+		}
+		if strings.EqualFold(report.MerkleRoot, rewardsFile.GetMerkleRoot()) {
+			return t.isGateOpen(report)
+		}
+	}
+
+	inputs := rprewards.ConsistencyGateInputs{
+		RewardsFile:  rewardsFile,
+		BeaconClient: t.bc,
+	}
+	performanceFile, err := rprewards.ReadLocalMinipoolPerformanceFile(t.cfg.Smartnode.GetMinipoolPerformancePath(index, true))
+	if err != nil {
+		t.printMessage(fmt.Sprintf("WARNING: couldn't load the minipool performance file, so bonus caps can't be checked: %s", err.Error()))
+	} else {
+		inputs.MinipoolPerformanceFile = performanceFile.Impl()
+	}
+	return t.enforceConsistencyGate(inputs)
+}
+
+// Run the gate, save its report, and decide whether the tree can be submitted
+func (t *submitRewardsTree_Stateless) enforceConsistencyGate(inputs rprewards.ConsistencyGateInputs) (bool, error) {
+	index := inputs.RewardsFile.GetIndex()
+	if index > 0 {
+		previousPath := t.cfg.Smartnode.GetRewardsTreePath(index-1, true, config.RewardsExtensionJSON)
+		previousFile, err := rprewards.ReadLocalRewardsFile(previousPath)
+		if err != nil {
+			t.printMessage(fmt.Sprintf("WARNING: couldn't load the rewards file for interval %d: %s", index-1, err.Error()))
+		} else {
+			inputs.PreviousRewardsFile = previousFile.Impl()
+		}
+	}
+
+	report, err := rprewards.RunConsistencyGate(inputs)
+	if err != nil {
+		return false, fmt.Errorf("error running the consistency gate: %w", err)
+	}
+	reportPath := t.cfg.Smartnode.GetConsistencyGateReportPath(index, true)
+	if err := report.Save(reportPath); err != nil {
+		return false, err
+	}
+	for _, check := range report.Checks {
+		switch {
+		case check.Skipped:
+			t.printMessage(fmt.Sprintf("Consistency gate: %s skipped.", check.Name))
+		case check.Passed:
+			t.printMessage(fmt.Sprintf("Consistency gate: %s passed.", check.Name))
+		default:
+			for _, problem := range check.Problems {
+				t.printMessage(fmt.Sprintf("Consistency gate: %s FAILED: %s", check.Name, problem))
+			}
+		}
+	}
+	return t.isGateOpen(report)
+}
+
+// Check if a tree's gate report allows it to be submitted
+func (t *submitRewardsTree_Stateless) isGateOpen(report *rprewards.ConsistencyGateReport) (bool, error) {
+	if report.Passed {
+		return true, nil
+	}
+	overridden, err := rprewards.IsConsistencyGateOverridden(t.cfg.Smartnode.GetConsistencyGateOverridePath(report.Index, true), report.MerkleRoot)
+	if err != nil {
+		return false, err
+	}
+	if overridden {
+		t.printMessage(fmt.Sprintf("The tree for interval %d failed %d consistency check(s), but it was approved manually.", report.Index, len(report.GetFailedChecks())))
+		return true, nil
+	}
+	t.printMessage(fmt.Sprintf("The tree for interval %d failed %d consistency check(s) and will not be submitted automatically. Review the report at %s, then run `rocketpool network approve-rewards-tree %d` to submit it anyway.", report.Index, len(report.GetFailedChecks()), t.cfg.Smartnode.GetConsistencyGateReportPath(report.Index, false), report.Index))
+	return false, nil
+}
@@ -0,0 +1,51 @@
+package watchtower
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+)
+
+// Compare the tree the Oracle DAO reached consensus on with the one this node generated for the same interval.
+// If their roots differ, the consensus tree is downloaded and diffed against the local one, the report is saved,
+// and an alert names where they first diverge.
+func (t *submitRewardsTree_Stateless) checkRootDivergence(nodeAddress common.Address, index uint64) error {
+	info, err := rprewards.GetIntervalInfo(t.rp, t.cfg, nodeAddress, index, nil)
+	if err != nil {
+		return fmt.Errorf("error getting info for interval %d: %w", index, err)
+	}
+	if !info.TreeFileExists || info.MerkleRootValid {
+		return nil
+	}
+
+	localFile, err := rprewards.ReadLocalRewardsFile(info.TreeFilePath)
+	if err != nil {
+		return err
+	}
+	local := localFile.Impl()
+	t.log.Printlnf("WARNING: the consensus Merkle root for interval %d is %s, but this node generated %s. Downloading the consensus tree to compare them...", index, info.MerkleRoot.Hex(), local.GetMerkleRoot())
+
+	consensus, err := info.FetchRewardsFile(t.cfg, true)
+	if err != nil {
+		return fmt.Errorf("error downloading the consensus tree for interval %d: %w", index, err)
+	}
+	report := rprewards.DiffRewardsFiles(local, consensus)
+	reportPath := t.cfg.Smartnode.GetRootDivergenceReportPath(index, true)
+	if err := report.Save(reportPath); err != nil {
+		return err
+	}
+
+	firstNode := ""
+	if report.FirstDivergentNode != nil {
+		firstNode = report.FirstDivergentNode.Hex()
+		t.log.Printlnf("The trees first diverge at node %s (%s).", firstNode, report.FirstDivergentCategory)
+	} else {
+		t.log.Printlnf("The trees first diverge in their %s.", report.FirstDivergentCategory)
+	}
+	t.log.Printlnf("Found %d difference(s); the full report was saved to %s.", len(report.Divergences), reportPath)
+
+	return alerting.AlertRewardsRootDivergence(t.cfg, index, report.LocalRoot, report.ConsensusRoot, firstNode, report.FirstDivergentCategory, len(report.Divergences))
+}
@@ -0,0 +1,58 @@
+package watchtower
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// The stage of the scrub check that detected a problem with a minipool
+type scrubStage string
+
+const (
+	scrubStage_Beacon          scrubStage = "beacon"
+	scrubStage_Prestake        scrubStage = "prestake"
+	scrubStage_DepositContract scrubStage = "deposit-contract"
+	scrubStage_Safety          scrubStage = "safety"
+)
+
+// A record of why a minipool was scrubbed, kept so the decision can be audited later
+type scrubEvidence struct {
+	Minipool            common.Address        `json:"minipool"`
+	Pubkey              types.ValidatorPubkey `json:"pubkey"`
+	Stage               scrubStage            `json:"stage"`
+	DetectedAt          time.Time             `json:"detectedAt"`
+	ExpectedCredentials common.Hash           `json:"expectedCredentials"`
+	ActualCredentials   common.Hash           `json:"actualCredentials,omitempty"`
+	DepositTxHash       common.Hash           `json:"depositTxHash,omitempty"`
+	DepositBlock        uint64                `json:"depositBlock,omitempty"`
+	DepositTxIndex      uint                  `json:"depositTxIndex,omitempty"`
+	Details             string                `json:"details,omitempty"`
+	VoteTxHash          common.Hash           `json:"voteTxHash,omitempty"`
+	VoteError           string                `json:"voteError,omitempty"`
+}
+
+// Save the evidence for a scrub to the evidence folder
+func (e *scrubEvidence) save(folder string) (string, error) {
+	err := os.MkdirAll(folder, 0755)
+	if err != nil {
+		return "", fmt.Errorf("error creating scrub evidence folder %s: %w", folder, err)
+	}
+
+	bytes, err := json.MarshalIndent(e, "", "\t")
+	if err != nil {
+		return "", fmt.Errorf("error serializing scrub evidence for minipool %s: %w", e.Minipool.Hex(), err)
+	}
+
+	path := filepath.Join(folder, fmt.Sprintf("%s-%d.json", e.Minipool.Hex(), e.DetectedAt.Unix()))
+	err = os.WriteFile(path, bytes, 0644)
+	if err != nil {
+		return "", fmt.Errorf("error saving scrub evidence to %s: %w", path, err)
+	}
+	return path, nil
+}
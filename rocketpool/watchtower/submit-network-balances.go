@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -18,6 +19,7 @@ import (
 	"github.com/urfave/cli"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
@@ -25,7 +27,6 @@ import (
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
-	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
@@ -44,8 +45,12 @@ type submitNetworkBalances struct {
 	ec        rocketpool.ExecutionClient
 	rp        *rocketpool.RocketPool
 	bc        beacon.Client
+	treegenBc beacon.Client
 	lock      *sync.Mutex
 	isRunning bool
+	lastError error
+
+	submissionCollector *collectors.SubmissionCollector
 }
 
 // Network balance info
@@ -67,7 +72,7 @@ type minipoolBalanceDetails struct {
 }
 
 // Create submit network balances task
-func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*submitNetworkBalances, error) {
+func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, submissionCollector *collectors.SubmissionCollector) (*submitNetworkBalances, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -90,6 +95,10 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogge
 	if err != nil {
 		return nil, err
 	}
+	treegenBc, err := services.GetHistoricalBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
 
 	// Return task
 	lock := &sync.Mutex{}
@@ -102,8 +111,11 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogge
 		ec:        ec,
 		rp:        rp,
 		bc:        bc,
+		treegenBc: treegenBc,
 		lock:      lock,
 		isRunning: false,
+
+		submissionCollector: submissionCollector,
 	}, nil
 
 }
@@ -227,6 +239,7 @@ func (t *submitNetworkBalances) run(state *state.NetworkState) error {
 		t.log.Printlnf("%s Balance report complete.", logPrefix)
 		t.lock.Lock()
 		t.isRunning = false
+		t.lastError = nil
 		t.lock.Unlock()
 	}()
 	// Return
@@ -239,9 +252,17 @@ func (t *submitNetworkBalances) handleError(err error) {
 	t.errLog.Println("*** Balance report failed. ***")
 	t.lock.Lock()
 	t.isRunning = false
+	t.lastError = err
 	t.lock.Unlock()
 }
 
+// Get the error that made the last report fail, or nil if it succeeded
+func (t *submitNetworkBalances) getLastError() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.lastError
+}
+
 // Check whether balances for a block has already been submitted by the node
 func (t *submitNetworkBalances) hasSubmittedBlockBalances(nodeAddress common.Address, blockNumber uint64) (bool, error) {
 
@@ -356,9 +377,15 @@ func (t *submitNetworkBalances) getNetworkBalances(elBlockHeader *types.Header,
 			ExecutionBlock: state.ElBlockNumber,
 		}
 
+		// The approximation has to match the other Oracle DAO members' approximations
+		err := rprewards.CheckCanonicalAccountingPolicies(t.cfg.Smartnode.RewardsAccountingPolicy.Value.(string))
+		if err != nil {
+			return fmt.Errorf("error approximating share of smoothing pool: %w", err)
+		}
+
 		// Approximate the staker's share of the smoothing pool balance
 		// NOTE: this will use the "vanilla" variant of treegen, without rolling records, to retain parity with other Oracle DAO nodes that aren't using rolling records
-		treegen, err := rprewards.NewTreeGenerator(t.log, "[Balances]", rprewards.NewRewardsExecutionClient(client), t.cfg, t.bc, currentIndex, startTime, endTime, snapshotEnd, elBlockHeader, uint64(intervalsPassed), state)
+		treegen, err := rprewards.NewTreeGenerator(t.log, "[Balances]", rprewards.NewRewardsExecutionClient(client), t.cfg, t.treegenBc, currentIndex, startTime, endTime, snapshotEnd, elBlockHeader, uint64(intervalsPassed), state)
 		if err != nil {
 			return fmt.Errorf("error creating merkle tree generator to approximate share of smoothing pool: %w", err)
 		}
@@ -520,27 +547,17 @@ func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 		}
 	}
 
-	// Print the gas info
-	maxFee := eth.GweiToWei(utils.GetWatchtowerMaxFee(t.cfg))
-	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
-		return nil
-	}
-
-	// Set the gas settings
-	opts.GasFeeCap = maxFee
-	opts.GasTipCap = eth.GweiToWei(utils.GetWatchtowerPrioFee(t.cfg))
-	opts.GasLimit = gasInfo.SafeGasLimit
-	var hash common.Hash
-	// Submit balances
-	hash, err = network.SubmitBalances(t.rp, balances.Block, balances.SlotTimestamp, totalEth, balances.MinipoolsStaking, balances.RETHSupply, opts)
+	// Submit balances; they're due as soon as the target slot has passed
+	txRp, err := utils.GetTransactionRocketPool(t.cfg, t.rp, utils.TxCategory_NetworkSubmission)
 	if err != nil {
-		return fmt.Errorf("error submitting balances: %w", err)
+		return err
 	}
-
-	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	dueTime := time.Unix(int64(balances.SlotTimestamp), 0)
+	err = utils.SendSubmission(t.cfg, t.rp, t.log, t.submissionCollector, utils.TxCategory_NetworkSubmission, dueTime, gasInfo, opts, func(opts *bind.TransactOpts) (common.Hash, error) {
+		return network.SubmitBalances(txRp, balances.Block, balances.SlotTimestamp, totalEth, balances.MinipoolsStaking, balances.RETHSupply, opts)
+	})
 	if err != nil {
-		return fmt.Errorf("error waiting for transaction: %w", err)
+		return fmt.Errorf("error submitting balances: %w", err)
 	}
 
 	// Log
@@ -18,16 +18,16 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/tokens"
-	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/events"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
-	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
 	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -36,22 +36,34 @@ import (
 
 // Submit rewards Merkle Tree task
 type submitRewardsTree_Stateless struct {
-	c                *cli.Context
-	log              *log.ColorLogger
-	errLog           *log.ColorLogger
-	cfg              *config.RocketPoolConfig
-	w                *wallet.Wallet
-	rp               *rocketpool.RocketPool
-	ec               rocketpool.ExecutionClient
-	bc               beacon.Client
-	lock             *sync.Mutex
-	isRunning        bool
-	generationPrefix string
-	m                *state.NetworkStateManager
+	c                   *cli.Context
+	log                 *log.ColorLogger
+	errLog              *log.ColorLogger
+	cfg                 *config.RocketPoolConfig
+	w                   *wallet.Wallet
+	rp                  *rocketpool.RocketPool
+	ec                  rocketpool.ExecutionClient
+	bc                  beacon.Client
+	lock                *sync.Mutex
+	isRunning           bool
+	generationPrefix    string
+	m                   *state.NetworkStateManager
+	treegenCollector    *collectors.TreegenCollector
+	submissionCollector *collectors.SubmissionCollector
+	prefetcher          *prefetchRewardsSnapshot
+	publisher           *events.Publisher
+
+	// The reward index seen on the previous run, for noticing when consensus is reached
+	lastRewardIndex      uint64
+	lastRewardIndexKnown bool
+
+	// The latest interval whose end was published, so it's only published once
+	endedIndex          uint64
+	endedIndexPublished bool
 }
 
 // Create submit rewards Merkle Tree task
-func newSubmitRewardsTree_Stateless(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager) (*submitRewardsTree_Stateless, error) {
+func newSubmitRewardsTree_Stateless(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager, treegenCollector *collectors.TreegenCollector, submissionCollector *collectors.SubmissionCollector, prefetcher *prefetchRewardsSnapshot, publisher *events.Publisher) (*submitRewardsTree_Stateless, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -66,7 +78,8 @@ func newSubmitRewardsTree_Stateless(c *cli.Context, logger log.ColorLogger, erro
 	if err != nil {
 		return nil, err
 	}
-	bc, err := services.GetBeaconClient(c)
+	// Tree generation is a heavy historical workload, so keep it off the VC's Beacon Node when possible
+	bc, err := services.GetHistoricalBeaconClient(c)
 	if err != nil {
 		return nil, err
 	}
@@ -77,18 +90,22 @@ func newSubmitRewardsTree_Stateless(c *cli.Context, logger log.ColorLogger, erro
 
 	lock := &sync.Mutex{}
 	generator := &submitRewardsTree_Stateless{
-		c:                c,
-		log:              &logger,
-		errLog:           &errorLogger,
-		cfg:              cfg,
-		ec:               ec,
-		bc:               bc,
-		w:                w,
-		rp:               rp,
-		lock:             lock,
-		isRunning:        false,
-		generationPrefix: "[Merkle Tree]",
-		m:                m,
+		c:                   c,
+		log:                 &logger,
+		errLog:              &errorLogger,
+		cfg:                 cfg,
+		ec:                  ec,
+		bc:                  bc,
+		w:                   w,
+		rp:                  rp,
+		lock:                lock,
+		isRunning:           false,
+		generationPrefix:    "[Merkle Tree]",
+		m:                   m,
+		treegenCollector:    treegenCollector,
+		submissionCollector: submissionCollector,
+		prefetcher:          prefetcher,
+		publisher:           publisher,
 	}
 
 	return generator, nil
@@ -124,6 +141,20 @@ func (t *submitRewardsTree_Stateless) Run(nodeTrusted bool, state *state.Network
 		}
 	}
 
+	// The reward index moves on once the Oracle DAO reaches consensus on an interval's tree
+	if t.lastRewardIndexKnown {
+		for index := t.lastRewardIndex; index < state.NetworkDetails.RewardIndex; index++ {
+			t.publishIntervalEvent(events.EventType_RewardsConsensusReached, events.RewardsIntervalData{
+				Index: index,
+			})
+			if err := t.checkRootDivergence(nodeAccount.Address, index); err != nil {
+				t.errLog.Printlnf("Error checking the consensus rewards tree for interval %d against the local one: %s", index, err.Error())
+			}
+		}
+	}
+	t.lastRewardIndex = state.NetworkDetails.RewardIndex
+	t.lastRewardIndexKnown = true
+
 	// Log
 	t.log.Println("Checking for rewards checkpoint...")
 
@@ -173,6 +204,20 @@ func (t *submitRewardsTree_Stateless) Run(nodeTrusted bool, state *state.Network
 	currentIndex := state.NetworkDetails.RewardIndex
 	currentIndexBig := big.NewInt(0).SetUint64(currentIndex)
 
+	// Announce that the interval's snapshot has been reached
+	if !t.endedIndexPublished || t.endedIndex != currentIndex {
+		t.publishIntervalEvent(events.EventType_RewardsIntervalEnded, events.RewardsIntervalData{
+			Index:           currentIndex,
+			IntervalsPassed: uint64(intervalsPassed),
+			StartTime:       startTime,
+			EndTime:         endTime,
+			ConsensusBlock:  snapshotBeaconBlock,
+			ExecutionBlock:  elBlockIndex,
+		})
+		t.endedIndex = currentIndex
+		t.endedIndexPublished = true
+	}
+
 	// Check if rewards generation is already running
 	t.lock.Lock()
 	if t.isRunning {
@@ -212,6 +257,17 @@ func (t *submitRewardsTree_Stateless) Run(nodeTrusted bool, state *state.Network
 
 		proofWrapper := localRewardsFile.Impl()
 
+		// Hold the tree back if it fails the consistency gate
+		if t.cfg.Smartnode.RewardsConsistencyGate.Value == true {
+			open, err := t.checkExistingConsistencyGate(proofWrapper)
+			if err != nil {
+				return fmt.Errorf("Error running the consistency gate: %w", err)
+			}
+			if !open {
+				return nil
+			}
+		}
+
 		// Save the compressed file and get the CID for it
 		_, cid, err := localRewardsFile.CreateCompressedFileAndCid()
 		if err != nil {
@@ -221,7 +277,7 @@ func (t *submitRewardsTree_Stateless) Run(nodeTrusted bool, state *state.Network
 		t.printMessage(fmt.Sprintf("Calculated rewards tree CID: %s", cid))
 
 		// Submit to the contracts
-		err = t.submitRewardsSnapshot(currentIndexBig, snapshotBeaconBlock, elBlockIndex, proofWrapper, cid.String(), big.NewInt(int64(intervalsPassed)))
+		err = t.submitRewardsSnapshot(currentIndexBig, snapshotBeaconBlock, elBlockIndex, proofWrapper, cid.String(), big.NewInt(int64(intervalsPassed)), startTime, endTime)
 		if err != nil {
 			return fmt.Errorf("Error submitting rewards snapshot: %w", err)
 		}
@@ -320,21 +376,61 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 	}
 	t.log.Printlnf("Rewards checkpoint has passed, starting Merkle tree generation for interval %d in the background.\n%s Snapshot Beacon block = %d, EL block = %d, running from %s to %s", currentIndex, t.generationPrefix, snapshotBeaconBlock, elBlockIndex, startTime, endTime)
 
-	// Create a new state gen manager
-	mgr := state.NewNetworkStateManager(rp, t.cfg.Smartnode.GetStateManagerContracts(), t.bc, t.log)
+	intervalData := events.RewardsIntervalData{
+		Index:           currentIndex,
+		IntervalsPassed: uint64(intervalsPassed),
+		StartTime:       startTime,
+		EndTime:         endTime,
+		ConsensusBlock:  snapshotBeaconBlock,
+		ExecutionBlock:  elBlockIndex,
+	}
+	t.publishIntervalEvent(events.EventType_RewardsTreeGenerationStarted, intervalData)
 
-	// Create a new state for the target block
-	state, err := mgr.GetStateForSlot(snapshotBeaconBlock)
+	// Use the prefetched state for the target block if there is one, otherwise create it
+	networkState := t.prefetcher.takeState(currentIndex, snapshotEnd, snapshotElBlockHeader)
+	if networkState != nil {
+		t.printMessage("Using the prefetched network state for the snapshot.")
+	} else {
+		mgr := state.NewNetworkStateManager(rp, t.cfg.Smartnode.GetStateManagerContracts(), t.bc, t.log)
+		var err error
+		networkState, err = mgr.GetStateForSlot(snapshotBeaconBlock)
+		if err != nil {
+			return fmt.Errorf("couldn't get network state for EL block %d, Beacon slot %d: %w", elBlockIndex, snapshotBeaconBlock, err)
+		}
+	}
+
+	// Check the smoothing pool's balance before the tree uses it
+	if t.cfg.Smartnode.TraceSmoothingPoolFlows.Value == true {
+		t.traceSmoothingPoolFlows(rp, currentIndex, elBlockIndex, networkState)
+	}
+
+	// Refuse to generate a tree that won't match the other Oracle DAO members' trees
+	err := rprewards.CheckCanonicalAccountingPolicies(t.cfg.Smartnode.RewardsAccountingPolicy.Value.(string))
 	if err != nil {
-		return fmt.Errorf("couldn't get network state for EL block %d, Beacon slot %d: %w", elBlockIndex, snapshotBeaconBlock, err)
+		return fmt.Errorf("Can't generate the rewards tree: %w", err)
 	}
 
 	// Generate the rewards file
-	treegen, err := rprewards.NewTreeGenerator(t.log, t.generationPrefix, rprewards.NewRewardsExecutionClient(rp), t.cfg, t.bc, currentIndex, startTime, endTime, snapshotEnd, snapshotElBlockHeader, uint64(intervalsPassed), state)
+	treegen, err := rprewards.NewTreeGenerator(t.log, t.generationPrefix, rprewards.NewRewardsExecutionClient(rp), t.cfg, t.bc, currentIndex, startTime, endTime, snapshotEnd, snapshotElBlockHeader, uint64(intervalsPassed), networkState)
 	if err != nil {
 		return fmt.Errorf("Error creating Merkle tree generator: %w", err)
 	}
-	treeResult, err := treegen.GenerateTree()
+
+	// Report progress, warning if generation won't finish before the next interval ends
+	intervalTime := endTime.Sub(startTime) / intervalsPassed
+	treegen.SetProgressCallback(endTime.Add(intervalTime), newTreegenProgressReporter(t.cfg, t.log, t.generationPrefix, t.treegenCollector))
+
+	var treeResult *rprewards.GenerateTreeResult
+	if t.cfg.Smartnode.ExportChainSnapshots.Value == true {
+		// Record the chain data the tree is generated from so anyone can reproduce it
+		snapshotPath := t.cfg.Smartnode.GetChainSnapshotPath(currentIndex, true)
+		treeResult, err = treegen.GenerateTreeWithChainSnapshot(snapshotPath, networkState)
+		if err == nil {
+			t.printMessage(fmt.Sprintf("Saved chain snapshot to %s", snapshotPath))
+		}
+	} else {
+		treeResult, err = treegen.GenerateTree()
+	}
 	if err != nil {
 		return fmt.Errorf("Error generating Merkle tree: %w", err)
 	}
@@ -352,18 +448,58 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 	for filename, cid := range cids {
 		t.printMessage(fmt.Sprintf("\t%s - CID %s", filename, cid.String()))
 	}
+	intervalData.MerkleRoot = rewardsFile.GetMerkleRoot()
+	intervalData.TreeCid = cid.String()
+	t.publishIntervalEvent(events.EventType_RewardsTreeGenerationFinished, intervalData)
 
 	if nodeTrusted {
 		t.printMessage(fmt.Sprintf("Calculated rewards tree CID: %s", cid))
 
+		// Make sure an independent implementation agrees with the tree before submitting it
+		if t.cfg.Smartnode.RewardsTreeCrossCheck.Value == true {
+			t.printMessage("Cross-checking the rewards tree...")
+			err = rprewards.CrossCheckRewardsFile(rewardsFile)
+			if err != nil {
+				return fmt.Errorf("Rewards tree failed the cross-check, so it will not be submitted: %w", err)
+			}
+			t.printMessage("Cross-check passed.")
+		}
+
+		// Hold the tree back if it fails the consistency gate
+		if t.cfg.Smartnode.RewardsConsistencyGate.Value == true {
+			regenerate := func() (rprewards.IRewardsFile, error) {
+				treegen, err := rprewards.NewTreeGenerator(t.log, t.generationPrefix, rprewards.NewRewardsExecutionClient(rp), t.cfg, t.bc, currentIndex, startTime, endTime, snapshotEnd, snapshotElBlockHeader, uint64(intervalsPassed), networkState)
+				if err != nil {
+					return nil, err
+				}
+				result, err := treegen.GenerateTree()
+				if err != nil {
+					return nil, err
+				}
+				return result.RewardsFile, nil
+			}
+			open, err := t.runConsistencyGate(treeResult, networkState.NetworkDetails.SmoothingPoolBalance, regenerate)
+			if err != nil {
+				return fmt.Errorf("Error running the consistency gate: %w", err)
+			}
+			if !open {
+				return nil
+			}
+		}
+
+		// Mirror the artifacts and record where they can be found
+		saveSubmissionMetadata(t.cfg, t.w, rewardsFile, cid, cids, t.printMessage)
+		saveIntervalManifest(t.cfg, currentIndex, t.printMessage)
+
 		// Submit to the contracts
-		err = t.submitRewardsSnapshot(big.NewInt(int64(currentIndex)), snapshotBeaconBlock, elBlockIndex, rewardsFile, cid.String(), big.NewInt(int64(intervalsPassed)))
+		err = t.submitRewardsSnapshot(big.NewInt(int64(currentIndex)), snapshotBeaconBlock, elBlockIndex, rewardsFile, cid.String(), big.NewInt(int64(intervalsPassed)), startTime, endTime)
 		if err != nil {
 			return fmt.Errorf("Error submitting rewards snapshot: %w", err)
 		}
 
 		t.printMessage(fmt.Sprintf("Successfully submitted rewards snapshot for interval %d.", currentIndex))
 	} else {
+		saveIntervalManifest(t.cfg, currentIndex, t.printMessage)
 		t.printMessage(fmt.Sprintf("Successfully generated rewards snapshot for interval %d.", currentIndex))
 	}
 
@@ -372,7 +508,13 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 }
 
 // Submit rewards info to the contracts
-func (t *submitRewardsTree_Stateless) submitRewardsSnapshot(index *big.Int, consensusBlock uint64, executionBlock uint64, rewardsFile rprewards.IRewardsFile, cid string, intervalsPassed *big.Int) error {
+func (t *submitRewardsTree_Stateless) submitRewardsSnapshot(index *big.Int, consensusBlock uint64, executionBlock uint64, rewardsFile rprewards.IRewardsFile, cid string, intervalsPassed *big.Int, startTime time.Time, endTime time.Time) error {
+
+	// Make sure the tree picks up where the previous interval left off before submitting it
+	err := t.checkContinuity(rewardsFile)
+	if err != nil {
+		return err
+	}
 
 	treeRootBytes, err := hex.DecodeString(hexutil.RemovePrefix(rewardsFile.GetMerkleRoot()))
 	if err != nil {
@@ -429,33 +571,129 @@ func (t *submitRewardsTree_Stateless) submitRewardsSnapshot(index *big.Int, cons
 		}
 	}
 
-	// Print the gas info
-	maxFee := eth.GweiToWei(utils.GetWatchtowerMaxFee(t.cfg))
-	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
-		return nil
+	// Submit the rewards snapshot; it's due as soon as the interval ends
+	txRp, err := utils.GetTransactionRocketPool(t.cfg, t.rp, utils.TxCategory_RewardsSubmission)
+	if err != nil {
+		return err
 	}
-
-	opts.GasFeeCap = maxFee
-	opts.GasTipCap = eth.GweiToWei(utils.GetWatchtowerPrioFee(t.cfg))
-	opts.GasLimit = gasInfo.SafeGasLimit
-
-	// Submit RPL price
-	hash, err := rewards.SubmitRewardSnapshot(t.rp, submission, opts)
+	err = utils.SendSubmission(t.cfg, t.rp, t.log, t.submissionCollector, utils.TxCategory_RewardsSubmission, endTime, gasInfo, opts, func(opts *bind.TransactOpts) (common.Hash, error) {
+		return rewards.SubmitRewardSnapshot(txRp, submission, opts)
+	})
 	if err != nil {
 		return err
 	}
 
-	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	// Only announce the submission once the contracts have it, since it may have been skipped due to high fees
+	submitted, err := t.hasSubmittedTree(opts.From, index)
 	if err != nil {
-		return err
+		t.log.Printlnf("WARNING: error checking if the rewards snapshot submission was confirmed: %s", err.Error())
+	} else if submitted {
+		t.publishIntervalEvent(events.EventType_RewardsTreeSubmitted, events.RewardsIntervalData{
+			Index:           index.Uint64(),
+			IntervalsPassed: intervalsPassed.Uint64(),
+			StartTime:       startTime,
+			EndTime:         endTime,
+			ConsensusBlock:  consensusBlock,
+			ExecutionBlock:  executionBlock,
+			MerkleRoot:      rewardsFile.GetMerkleRoot(),
+			TreeCid:         cid,
+		})
 	}
 
 	// Return
 	return nil
 }
 
+// Publish a rewards interval lifecycle event, if the watchtower has somewhere to publish events to
+func (t *submitRewardsTree_Stateless) publishIntervalEvent(eventType events.EventType, data events.RewardsIntervalData) {
+	if t.publisher == nil {
+		return
+	}
+	var nodeAddress common.Address
+	if nodeAccount, err := t.w.GetNodeAccount(); err == nil {
+		nodeAddress = nodeAccount.Address
+	}
+	err := t.publisher.Publish(events.Event{
+		Type: eventType,
+		Node: nodeAddress,
+		Time: time.Now().UTC(),
+		Data: data,
+	})
+	if err != nil {
+		t.log.Printlnf("WARNING: %s", err.Error())
+	}
+}
+
 // Get the first finalized, successful consensus block that occurred after the given target time
+// Check a rewards file for continuity with the previous interval's file
+func (t *submitRewardsTree_Stateless) checkContinuity(rewardsFile rprewards.IRewardsFile) error {
+	index := rewardsFile.GetIndex()
+	if index == 0 {
+		return nil
+	}
+
+	// Load the previous interval's file
+	previousPath := t.cfg.Smartnode.GetRewardsTreePath(index-1, true, config.RewardsExtensionJSON)
+	previousFile, err := rprewards.ReadLocalRewardsFile(previousPath)
+	if err != nil {
+		t.printMessage(fmt.Sprintf("WARNING: couldn't load the rewards file for interval %d, so continuity with it can't be checked: %s", index-1, err.Error()))
+		return nil
+	}
+
+	violations, err := rprewards.CheckIntervalContinuity(previousFile.Impl(), rewardsFile, t.bc)
+	if err != nil {
+		return fmt.Errorf("error checking continuity with interval %d: %w", index-1, err)
+	}
+	if len(violations) > 0 {
+		for _, violation := range violations {
+			t.printMessage(fmt.Sprintf("Continuity violation: %s", violation.String()))
+		}
+		return fmt.Errorf("the tree for interval %d failed %d continuity check(s) against interval %d and will not be submitted", index, len(violations), index-1)
+	}
+	t.printMessage(fmt.Sprintf("Interval %d passed continuity checks against interval %d.", index, index-1))
+	return nil
+}
+
+// Trace the smoothing pool's ETH flows since the previous interval's snapshot, reporting any that can't be explained.
+// The trace is advisory, so failures are reported but don't stop tree generation.
+func (t *submitRewardsTree_Stateless) traceSmoothingPoolFlows(rp *rocketpool.RocketPool, index uint64, elBlockIndex uint64, networkState *state.NetworkState) {
+	if index == 0 {
+		return
+	}
+	t.printMessage("Tracing the smoothing pool's ETH flows...")
+
+	// Get the previous interval's snapshot and distribution blocks
+	client := rprewards.NewRewardsExecutionClient(rp)
+	previousEvent, err := client.GetRewardSnapshotEvent(t.cfg.Smartnode.GetPreviousRewardsPoolAddresses(), index-1, nil)
+	if err != nil {
+		t.printMessage(fmt.Sprintf("WARNING: couldn't trace the smoothing pool's flows: %s", err.Error()))
+		return
+	}
+	distributionBlock, err := rprewards.GetIntervalExecutionBlock(rp, index-1)
+	if err != nil {
+		t.printMessage(fmt.Sprintf("WARNING: couldn't trace the smoothing pool's flows: %s", err.Error()))
+		return
+	}
+
+	tracer := rprewards.NewSmoothingPoolFlowTracer(rp, t.bc, networkState, distributionBlock)
+	report, err := tracer.Trace(index, previousEvent.ExecutionBlock.Uint64()+1, elBlockIndex)
+	if err != nil {
+		t.printMessage(fmt.Sprintf("WARNING: couldn't trace the smoothing pool's flows: %s", err.Error()))
+		return
+	}
+	t.printMessage(fmt.Sprintf("Traced %d smoothing pool flows between blocks %d and %d.", len(report.Flows), report.StartBlock, report.EndBlock))
+	for _, problem := range report.Problems {
+		t.printMessage(fmt.Sprintf("WARNING: unexplained smoothing pool flow: %s", problem))
+	}
+
+	path := t.cfg.Smartnode.GetSmoothingPoolFlowsPath(index, true)
+	if err := report.Save(path); err != nil {
+		t.printMessage(fmt.Sprintf("WARNING: %s", err.Error()))
+		return
+	}
+	t.printMessage(fmt.Sprintf("Saved the smoothing pool flow report to %s.", path))
+}
+
 func (t *submitRewardsTree_Stateless) getSnapshotEnd(endTime time.Time, state *state.NetworkState) (*rprewards.SnapshotEnd, error) {
 
 	// Get the beacon head
@@ -465,19 +703,29 @@ func (t *submitRewardsTree_Stateless) getSnapshotEnd(endTime time.Time, state *s
 	}
 
 	// Get the target block number
-	eth2Config := state.BeaconConfig
-	genesisTime := time.Unix(int64(eth2Config.GenesisTime), 0)
-	totalTimespan := endTime.Sub(genesisTime)
-	targetSlot := uint64(math.Ceil(totalTimespan.Seconds() / float64(eth2Config.SecondsPerSlot)))
-	targetSlotEpoch := targetSlot / eth2Config.SlotsPerEpoch
-	targetSlot = targetSlotEpoch*eth2Config.SlotsPerEpoch + (eth2Config.SlotsPerEpoch - 1) // The target slot becomes the last one in the Epoch
-	requiredEpoch := targetSlotEpoch + 1                                                   // The smoothing pool requires 1 epoch beyond the target to be finalized, to check for late attestations
+	targetSlot, targetSlotEpoch := getSnapshotTargetSlot(endTime, state.BeaconConfig)
+	requiredEpoch := targetSlotEpoch + 1 // The smoothing pool requires 1 epoch beyond the target to be finalized, to check for late attestations
 
 	// Check if the required epoch is finalized yet
 	if beaconHead.FinalizedEpoch < requiredEpoch {
 		return nil, fmt.Errorf("Snapshot end time = %s, slot (epoch) = %d (%d)... waiting until epoch %d is finalized (currently %d).", endTime, targetSlot, targetSlotEpoch, requiredEpoch, beaconHead.FinalizedEpoch)
 	}
 
+	return findSnapshotBlock(t.bc, t.log, targetSlot)
+}
+
+// Get the slot a rewards snapshot ending at the given time targets, which is the last one in its epoch, and that slot's epoch
+func getSnapshotTargetSlot(endTime time.Time, eth2Config beacon.Eth2Config) (uint64, uint64) {
+	genesisTime := time.Unix(int64(eth2Config.GenesisTime), 0)
+	totalTimespan := endTime.Sub(genesisTime)
+	targetSlot := uint64(math.Ceil(totalTimespan.Seconds() / float64(eth2Config.SecondsPerSlot)))
+	targetSlotEpoch := targetSlot / eth2Config.SlotsPerEpoch
+	targetSlot = targetSlotEpoch*eth2Config.SlotsPerEpoch + (eth2Config.SlotsPerEpoch - 1) // The target slot becomes the last one in the Epoch
+	return targetSlot, targetSlotEpoch
+}
+
+// Find the snapshot block for a target slot, which is the latest proposed block at or before it
+func findSnapshotBlock(bc beacon.Client, logger *log.ColorLogger, targetSlot uint64) (*rprewards.SnapshotEnd, error) {
 	out := &rprewards.SnapshotEnd{
 		Slot: targetSlot,
 	}
@@ -485,19 +733,19 @@ func (t *submitRewardsTree_Stateless) getSnapshotEnd(endTime time.Time, state *s
 	// Get the first successful block
 	for {
 		// Try to get the current block
-		block, exists, err := t.bc.GetBeaconBlock(fmt.Sprint(targetSlot))
+		block, exists, err := bc.GetBeaconBlock(fmt.Sprint(targetSlot))
 		if err != nil {
 			return nil, fmt.Errorf("Error getting Beacon block %d: %w", targetSlot, err)
 		}
 
 		// If the block was missing, try the previous one
 		if !exists {
-			t.log.Printlnf("Slot %d was missing, trying the previous one...", targetSlot)
+			logger.Printlnf("Slot %d was missing, trying the previous one...", targetSlot)
 			targetSlot--
 			continue
 		}
 
-		// Ok, we have the first proposed finalized block - this is the one to use for the snapshot!
+		// Ok, we have the first proposed block - this is the one to use for the snapshot!
 		out.ConsensusBlock = targetSlot
 		out.ExecutionBlock = block.ExecutionBlockNumber
 		break
@@ -7,6 +7,7 @@ import (
 	"math/big"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -20,6 +21,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
@@ -250,10 +252,13 @@ type submitRplPrice struct {
 	bc        beacon.Client
 	lock      *sync.Mutex
 	isRunning bool
+	lastError error
+
+	submissionCollector *collectors.SubmissionCollector
 }
 
 // Create submit RPL price task
-func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*submitRplPrice, error) {
+func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, submissionCollector *collectors.SubmissionCollector) (*submitRplPrice, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -289,6 +294,8 @@ func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.C
 		rp:     rp,
 		bc:     bc,
 		lock:   lock,
+
+		submissionCollector: submissionCollector,
 	}, nil
 
 }
@@ -444,6 +451,7 @@ func (t *submitRplPrice) run(state *state.NetworkState) error {
 		t.log.Printlnf("%s Price report complete.", logPrefix)
 		t.lock.Lock()
 		t.isRunning = false
+		t.lastError = nil
 		t.lock.Unlock()
 	}()
 
@@ -457,9 +465,17 @@ func (t *submitRplPrice) handleError(err error) {
 	t.errLog.Println("*** Price report failed. ***")
 	t.lock.Lock()
 	t.isRunning = false
+	t.lastError = err
 	t.lock.Unlock()
 }
 
+// Get the error that made the last report fail, or nil if it succeeded
+func (t *submitRplPrice) getLastError() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.lastError
+}
+
 // Check whether prices for a block has already been submitted by the node
 func (t *submitRplPrice) hasSubmittedBlockPrices(nodeAddress common.Address, blockNumber uint64, slotTimestamp uint64) (bool, error) {
 
@@ -577,26 +593,15 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, slotTimestamp uint64
 		return fmt.Errorf("Could not estimate the gas required to submit RPL price: %w", err)
 	}
 
-	// Print the gas info
-	maxFee := eth.GweiToWei(utils.GetWatchtowerMaxFee(t.cfg))
-	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
-		return nil
-	}
-
-	// Set the gas settings
-	opts.GasFeeCap = maxFee
-	opts.GasTipCap = eth.GweiToWei(utils.GetWatchtowerPrioFee(t.cfg))
-	opts.GasLimit = gasInfo.SafeGasLimit
-
-	var hash common.Hash
-	// Submit RPL price
-	hash, err = network.SubmitPrices(t.rp, blockNumber, slotTimestamp, rplPrice, opts)
+	// Submit RPL price; it's due as soon as the target slot has passed
+	txRp, err := utils.GetTransactionRocketPool(t.cfg, t.rp, utils.TxCategory_NetworkSubmission)
 	if err != nil {
 		return err
 	}
-
-	// Print TX info and wait for it to be included in a block
-	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
+	dueTime := time.Unix(int64(slotTimestamp), 0)
+	err = utils.SendSubmission(t.cfg, t.rp, t.log, t.submissionCollector, utils.TxCategory_NetworkSubmission, dueTime, gasInfo, opts, func(opts *bind.TransactOpts) (common.Hash, error) {
+		return network.SubmitPrices(txRp, blockNumber, slotTimestamp, rplPrice, opts)
+	})
 	if err != nil {
 		return err
 	}
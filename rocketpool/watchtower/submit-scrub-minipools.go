@@ -14,10 +14,10 @@ import (
 	"github.com/rocket-pool/rocketpool-go/minipool"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/types"
-	rputils "github.com/rocket-pool/rocketpool-go/utils"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
 	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
 
 	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
@@ -25,6 +25,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/deposits"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -50,6 +51,7 @@ type submitScrubMinipools struct {
 	bc        beacon.Client
 	it        *iterationData
 	coll      *collectors.ScrubCollector
+	indexer   *deposits.Indexer
 	lock      *sync.Mutex
 	isRunning bool
 }
@@ -106,6 +108,10 @@ func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger
 	if err != nil {
 		return nil, err
 	}
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
 
 	// Return task
 	lock := &sync.Mutex{}
@@ -119,6 +125,7 @@ func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger
 		ec:        ec,
 		bc:        bc,
 		coll:      coll,
+		indexer:   deposits.NewIndexer(rp, cfg.Smartnode.GetWatchtowerDepositIndexPath(), uint64(eventLogInterval), deposits.DefaultRetentionBlocks),
 		lock:      lock,
 		isRunning: false,
 	}, nil
@@ -284,7 +291,7 @@ func (t *submitScrubMinipools) initializeMinipoolDetails(minipools []rpstate.Nat
 
 // Step 1: Verify the Beacon Chain credentials for a minipool if they're present
 func (t *submitScrubMinipools) verifyBeaconWithdrawalCredentials(state *state.NetworkState) error {
-	minipoolsToScrub := []minipool.Minipool{}
+	minipoolsToScrub := map[minipool.Minipool]*scrubEvidence{}
 
 	// Get the withdrawal credentials on Beacon for each validator if they exist
 	for minipool, details := range t.it.minipools {
@@ -301,7 +308,15 @@ func (t *submitScrubMinipools) verifyBeaconWithdrawalCredentials(state *state.Ne
 				t.log.Printlnf("\tExpected creds: %s", expectedCreds.Hex())
 				t.log.Printlnf("\tActual creds: %s", beaconCreds.Hex())
 				t.log.Println("======================================")
-				minipoolsToScrub = append(minipoolsToScrub, minipool)
+				minipoolsToScrub[minipool] = &scrubEvidence{
+					Minipool:            minipool.GetAddress(),
+					Pubkey:              pubkey,
+					Stage:               scrubStage_Beacon,
+					DetectedAt:          time.Now(),
+					ExpectedCredentials: expectedCreds,
+					ActualCredentials:   beaconCreds,
+					Details:             fmt.Sprintf("validator %s was seen on the Beacon Chain with mismatched withdrawal credentials", status.Index),
+				}
 				t.it.badOnBeaconCount++
 			} else {
 				// This minipool's credentials match, it's clean.
@@ -315,12 +330,7 @@ func (t *submitScrubMinipools) verifyBeaconWithdrawalCredentials(state *state.Ne
 	}
 
 	// Scrub the offending minipools
-	for _, minipool := range minipoolsToScrub {
-		err := t.submitVoteScrubMinipool(minipool)
-		if err != nil {
-			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
-		}
-	}
+	t.scrubMinipools(minipoolsToScrub)
 
 	return nil
 }
@@ -368,14 +378,33 @@ func (t *submitScrubMinipools) getEth1SearchArtifacts(state *state.NetworkState)
 // Step 2: Verify the MinipoolPrestaked event of each minipool
 func (t *submitScrubMinipools) verifyPrestakeEvents() {
 
-	minipoolsToScrub := []minipool.Minipool{}
+	minipoolsToScrub := map[minipool.Minipool]*scrubEvidence{}
+
+	// Get the MinipoolPrestaked events in parallel since each one is a separate log search
+	prestakeEvents := make(map[minipool.Minipool]minipool.PrestakeData, len(t.it.minipools))
+	var eventLock sync.Mutex
+	var wg errgroup.Group
+	wg.SetLimit(MinipoolBatchSize)
+	for mp := range t.it.minipools {
+		mp := mp
+		wg.Go(func() error {
+			prestakeData, err := mp.GetPrestakeEvent(t.it.eventLogInterval, nil)
+			if err != nil {
+				t.log.Printlnf("Error getting prestake event for minipool %s: %s", mp.GetAddress().Hex(), err.Error())
+				return nil
+			}
+			eventLock.Lock()
+			prestakeEvents[mp] = prestakeData
+			eventLock.Unlock()
+			return nil
+		})
+	}
+	_ = wg.Wait()
 
 	weiPerGwei := big.NewInt(int64(eth.WeiPerGwei))
-	for minipool := range t.it.minipools {
-		// Get the MinipoolPrestaked event
-		prestakeData, err := minipool.GetPrestakeEvent(t.it.eventLogInterval, nil)
-		if err != nil {
-			t.log.Printlnf("Error getting prestake event for minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
+	for minipool, details := range t.it.minipools {
+		prestakeData, exists := prestakeEvents[minipool]
+		if !exists {
 			continue
 		}
 
@@ -390,7 +419,7 @@ func (t *submitScrubMinipools) verifyPrestakeEvents() {
 		depositData.Signature = prestakeData.Signature.Bytes()
 
 		// Validate the signature
-		err = prdeposit.VerifyDepositSignature(depositData, t.it.depositDomain)
+		err := prdeposit.VerifyDepositSignature(depositData, t.it.depositDomain)
 		if err != nil {
 			// The signature is illegal
 			t.log.Println("=== SCRUB DETECTED ON PRESTAKE EVENT ===")
@@ -399,7 +428,15 @@ func (t *submitScrubMinipools) verifyPrestakeEvents() {
 			t.log.Println("========================================")
 
 			// Remove this minipool from the list of things to process in the next step
-			minipoolsToScrub = append(minipoolsToScrub, minipool)
+			minipoolsToScrub[minipool] = &scrubEvidence{
+				Minipool:            minipool.GetAddress(),
+				Pubkey:              details.pubkey,
+				Stage:               scrubStage_Prestake,
+				DetectedAt:          time.Now(),
+				ExpectedCredentials: details.expectedWithdrawalCredentials,
+				ActualCredentials:   prestakeData.WithdrawalCredentials,
+				Details:             fmt.Sprintf("the prestake event emitted at %s has an invalid signature: %s", prestakeData.Time, err.Error()),
+			}
 			t.it.badPrestakeCount++
 			delete(t.it.minipools, minipool)
 		} else {
@@ -409,19 +446,14 @@ func (t *submitScrubMinipools) verifyPrestakeEvents() {
 	}
 
 	// Scrub the offending minipools
-	for _, minipool := range minipoolsToScrub {
-		err := t.submitVoteScrubMinipool(minipool)
-		if err != nil {
-			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
-		}
-	}
+	t.scrubMinipools(minipoolsToScrub)
 
 }
 
 // Step 3: Verify minipools by their deposits
 func (t *submitScrubMinipools) verifyDeposits() error {
 
-	minipoolsToScrub := []minipool.Minipool{}
+	minipoolsToScrub := map[minipool.Minipool]*scrubEvidence{}
 
 	// Create a "hashset" of the remaining pubkeys
 	pubkeys := make(map[types.ValidatorPubkey]bool, len(t.it.minipools))
@@ -429,8 +461,11 @@ func (t *submitScrubMinipools) verifyDeposits() error {
 		pubkeys[details.pubkey] = true
 	}
 
-	// Get the deposits from the deposit contract
-	depositMap, err := rputils.GetDeposits(t.rp, pubkeys, t.it.startBlock, t.it.eventLogInterval, nil)
+	// Get the deposits from the index, catching it up first; if that fails the index scans the missing blocks itself
+	if err := t.indexer.Sync(); err != nil {
+		t.log.Printlnf("WARNING: error updating the deposit index: %s", err.Error())
+	}
+	depositMap, err := t.indexer.GetDeposits(pubkeys, t.it.startBlock.Uint64())
 	if err != nil {
 		return err
 	}
@@ -473,7 +508,18 @@ func (t *submitScrubMinipools) verifyDeposits() error {
 					t.log.Printlnf("\tExpected creds: %s", expectedCreds.Hex())
 					t.log.Printlnf("\tActual creds: %s", actualCreds.Hex())
 					t.log.Println("==========================================")
-					minipoolsToScrub = append(minipoolsToScrub, minipool)
+					minipoolsToScrub[minipool] = &scrubEvidence{
+						Minipool:            minipool.GetAddress(),
+						Pubkey:              details.pubkey,
+						Stage:               scrubStage_DepositContract,
+						DetectedAt:          time.Now(),
+						ExpectedCredentials: expectedCreds,
+						ActualCredentials:   actualCreds,
+						DepositTxHash:       deposit.TxHash,
+						DepositBlock:        deposit.BlockNumber,
+						DepositTxIndex:      deposit.TxIndex,
+						Details:             fmt.Sprintf("the first valid deposit for this pubkey (deposit %d of %d) used mismatched withdrawal credentials", depositIndex+1, len(deposits)),
+					}
 					t.it.badOnDepositContract++
 				} else {
 					t.it.goodOnDepositContract++
@@ -487,12 +533,7 @@ func (t *submitScrubMinipools) verifyDeposits() error {
 	}
 
 	// Scrub the offending minipools
-	for _, minipool := range minipoolsToScrub {
-		err := t.submitVoteScrubMinipool(minipool)
-		if err != nil {
-			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
-		}
-	}
+	t.scrubMinipools(minipoolsToScrub)
 
 	return nil
 
@@ -502,7 +543,7 @@ func (t *submitScrubMinipools) verifyDeposits() error {
 // This should never be used, it's simply here as a redundant check
 func (t *submitScrubMinipools) checkSafetyScrub(state *state.NetworkState) error {
 
-	minipoolsToScrub := []minipool.Minipool{}
+	minipoolsToScrub := map[minipool.Minipool]*scrubEvidence{}
 
 	// Warn if there are any remaining minipools - this should never happen
 	remainingMinipools := len(t.it.minipools)
@@ -539,7 +580,15 @@ func (t *submitScrubMinipools) checkSafetyScrub(state *state.NetworkState) error
 			t.log.Printlnf("\tTime since prelaunch: %s", time.Since(statusTime))
 			t.log.Printlnf("\tSafety scrub period: %s", safetyPeriod)
 			t.log.Println("=============================")
-			minipoolsToScrub = append(minipoolsToScrub, minipool)
+			details := t.it.minipools[minipool]
+			minipoolsToScrub[minipool] = &scrubEvidence{
+				Minipool:            minipool.GetAddress(),
+				Pubkey:              details.pubkey,
+				Stage:               scrubStage_Safety,
+				DetectedAt:          time.Now(),
+				ExpectedCredentials: details.expectedWithdrawalCredentials,
+				Details:             fmt.Sprintf("no valid deposit was found %s after the minipool entered prelaunch (safety period %s)", t.it.stateBlockTime.Sub(statusTime), safetyPeriod),
+			}
 			t.it.safetyScrubs++
 			// Remove this minipool from the list of things to process in the next step
 			delete(t.it.minipools, minipool)
@@ -547,19 +596,34 @@ func (t *submitScrubMinipools) checkSafetyScrub(state *state.NetworkState) error
 	}
 
 	// Scrub the offending minipools
-	for _, minipool := range minipoolsToScrub {
-		err := t.submitVoteScrubMinipool(minipool)
+	t.scrubMinipools(minipoolsToScrub)
+
+	return nil
+
+}
+
+// Vote to scrub the provided minipools and save the evidence for each one
+func (t *submitScrubMinipools) scrubMinipools(minipoolsToScrub map[minipool.Minipool]*scrubEvidence) {
+	evidenceFolder := t.cfg.Smartnode.GetScrubEvidenceFolder(true)
+	for minipool, evidence := range minipoolsToScrub {
+		hash, err := t.submitVoteScrubMinipool(minipool)
 		if err != nil {
 			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
+			evidence.VoteError = err.Error()
 		}
-	}
-
-	return nil
+		evidence.VoteTxHash = hash
 
+		path, err := evidence.save(evidenceFolder)
+		if err != nil {
+			t.log.Printlnf("WARNING: Couldn't save the scrub evidence for minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
+		} else {
+			t.log.Printlnf("Saved the scrub evidence for minipool %s to %s.", minipool.GetAddress().Hex(), path)
+		}
+	}
 }
 
 // Submit minipool scrub status
-func (t *submitScrubMinipools) submitVoteScrubMinipool(mp minipool.Minipool) error {
+func (t *submitScrubMinipools) submitVoteScrubMinipool(mp minipool.Minipool) (common.Hash, error) {
 
 	// Log
 	t.log.Printlnf("Voting to scrub minipool %s...", mp.GetAddress().Hex())
@@ -567,19 +631,19 @@ func (t *submitScrubMinipools) submitVoteScrubMinipool(mp minipool.Minipool) err
 	// Get transactor
 	opts, err := t.w.GetNodeAccountTransactor()
 	if err != nil {
-		return err
+		return common.Hash{}, err
 	}
 
 	// Get the gas limit
 	gasInfo, err := mp.EstimateVoteScrubGas(opts)
 	if err != nil {
-		return fmt.Errorf("Could not estimate the gas required to voteScrub the minipool: %w", err)
+		return common.Hash{}, fmt.Errorf("Could not estimate the gas required to voteScrub the minipool: %w", err)
 	}
 
 	// Print the gas info
 	maxFee := eth.GweiToWei(utils.GetWatchtowerMaxFee(t.cfg))
 	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, &t.log, maxFee, 0) {
-		return nil
+		return common.Hash{}, fmt.Errorf("the gas price is too high to vote to scrub")
 	}
 
 	// Set the gas settings
@@ -590,20 +654,20 @@ func (t *submitScrubMinipools) submitVoteScrubMinipool(mp minipool.Minipool) err
 	// Dissolve
 	hash, err := mp.VoteScrub(opts)
 	if err != nil {
-		return fmt.Errorf("error voting to scrub minipool %s: %w", mp.GetAddress().Hex(), err)
+		return common.Hash{}, fmt.Errorf("error voting to scrub minipool %s: %w", mp.GetAddress().Hex(), err)
 	}
 
 	// Print TX info and wait for it to be included in a block
 	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
 	if err != nil {
-		return err
+		return hash, err
 	}
 
 	// Log
 	t.log.Printlnf("Successfully voted to scrub the minipool %s.", mp.GetAddress().Hex())
 
 	// Return
-	return nil
+	return hash, nil
 
 }
 
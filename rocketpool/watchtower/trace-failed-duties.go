@@ -0,0 +1,64 @@
+package watchtower
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/tracer"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The contracts the watchtower's duties call, so traces can name them
+var dutyContractNames = []string{
+	"rocketDAONodeTrusted",
+	"rocketDAONodeTrustedActions",
+	"rocketDAONodeTrustedSettingsMinipool",
+	"rocketDAOProtocolProposal",
+	"rocketDAOProtocolVerifier",
+	"rocketMinipoolBondReducer",
+	"rocketMinipoolManager",
+	"rocketNetworkBalances",
+	"rocketNetworkPenalties",
+	"rocketNetworkPrices",
+	"rocketNetworkSnapshots",
+	"rocketRewardsPool",
+}
+
+// Trace the watchtower's failed duty transactions if the user enabled it
+func configureDutyTracer(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, ec *services.ExecutionClientManager) {
+	if !cfg.Smartnode.TraceFailedDuties.Value.(bool) {
+		return
+	}
+
+	logger := log.NewColorLogger(ErrorColor)
+	ec.SetCallTracer(tracer.NewTracer(cfg.Smartnode.GetDutyTraceFolder(true), &logger, newContractNameResolver(rp)))
+	logger.Println("Failed duty transactions will be traced.")
+}
+
+// Create a resolver for the names of the Rocket Pool contracts. The addresses are looked up on first use, since the
+// Execution client may not be ready when the watchtower starts.
+func newContractNameResolver(rp *rocketpool.RocketPool) tracer.NameResolver {
+	var lock sync.Mutex
+	var names map[common.Address]string
+	return func(address common.Address) string {
+		lock.Lock()
+		defer lock.Unlock()
+		if names == nil {
+			addresses, err := rp.GetAddresses(nil, dutyContractNames...)
+			if err != nil {
+				return ""
+			}
+			names = map[common.Address]string{
+				*rp.RocketStorageContract.Address: "rocketStorage",
+			}
+			for i, name := range dutyContractNames {
+				names[*addresses[i]] = name
+			}
+		}
+		return names[address]
+	}
+}
@@ -0,0 +1,50 @@
+package watchtower
+
+import (
+	"time"
+
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Create a callback for tree generation progress that saves it for `rocketpool node sync`, updates the metrics,
+// and warns if the generation isn't expected to finish before its deadline
+func newTreegenProgressReporter(cfg *config.RocketPoolConfig, logger *log.ColorLogger, generationPrefix string, collector *collectors.TreegenCollector) func(rprewards.TreeGenerationProgress) {
+	warned := false
+	progressPath := cfg.Smartnode.GetTreegenProgressPath(true)
+	return func(progress rprewards.TreeGenerationProgress) {
+		// Save the progress
+		err := rprewards.SaveTreeGenerationProgress(progressPath, progress)
+		if err != nil {
+			logger.Printlnf("%s WARNING: couldn't save tree generation progress: %s", generationPrefix, err.Error())
+		}
+
+		// Update the metrics
+		etaSeconds := float64(0)
+		if !progress.Eta.IsZero() {
+			etaSeconds = time.Until(progress.Eta).Seconds()
+			if etaSeconds < 0 {
+				etaSeconds = 0
+			}
+		}
+		behindDeadline := float64(0)
+		if progress.WillMissDeadline() {
+			behindDeadline = 1
+		}
+		collector.UpdateLock.Lock()
+		collector.Index = float64(progress.Index)
+		collector.Progress = progress.GetPercent()
+		collector.EpochsPerSecond = progress.EpochsPerSecond
+		collector.EtaSeconds = etaSeconds
+		collector.BehindDeadline = behindDeadline
+		collector.UpdateLock.Unlock()
+
+		// Warn once if the generation is falling behind
+		if progress.WillMissDeadline() && !warned {
+			logger.Printlnf("%s WARNING: at %.2f epochs per second, generation is expected to finish at %s which is after the next interval ends (%s). Your Beacon Node may be too slow to catch up.", generationPrefix, progress.EpochsPerSecond, progress.Eta.Format(time.RFC3339), progress.Deadline.Format(time.RFC3339))
+			warned = true
+		}
+	}
+}
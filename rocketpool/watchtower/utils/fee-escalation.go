@@ -0,0 +1,330 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+const (
+	// The smallest fee increase a replacement transaction can have; clients reject replacements below 10%
+	MinFeeBumpFactor float64 = 1.125
+
+	// How often to check whether an escalating submission has been included
+	feeEscalationPollInterval time.Duration = 12 * time.Second
+
+	// How often to bump the fees of an escalating submission that hasn't been included
+	feeEscalationBumpInterval time.Duration = time.Minute
+
+	// How long to keep waiting for an escalating submission after its fees reached the hard cap
+	feeEscalationGracePeriod time.Duration = 30 * time.Minute
+)
+
+// The fee schedule for a watchtower submission, in gwei
+type FeeEscalation struct {
+	StartMaxFee float64
+	StartTipFee float64
+	HardCap     float64
+	Window      time.Duration
+}
+
+// A submission's transaction, sent with the provided gas settings
+type SubmissionSender func(opts *bind.TransactOpts) (common.Hash, error)
+
+// An execution client that records transaction outcomes in the audit log
+type transactionResultRecorder interface {
+	RecordTransactionResult(hash common.Hash, receipt *types.Receipt, waitErr error)
+}
+
+// Check if deadline-aware fee escalation is enabled for watchtower submissions
+func IsFeeEscalationEnabled(cfg *config.RocketPoolConfig) bool {
+	return cfg.Smartnode.WatchtowerFeeEscalation.Value.(bool)
+}
+
+// Get the fee schedule for a submission, starting just above the current base fee
+func GetFeeEscalation(cfg *config.RocketPoolConfig, baseFeeGwei float64) FeeEscalation {
+	hardCap := cfg.Smartnode.WatchtowerFeeHardCap.Value.(float64)
+	tip := GetWatchtowerPrioFee(cfg)
+	if hardCap < tip {
+		hardCap = tip
+	}
+	startMaxFee := math.Min(2*baseFeeGwei+tip, hardCap)
+	return FeeEscalation{
+		StartMaxFee: startMaxFee,
+		StartTipFee: tip,
+		HardCap:     hardCap,
+		Window:      time.Duration(cfg.Smartnode.WatchtowerFeeEscalationWindow.Value.(uint64)) * time.Minute,
+	}
+}
+
+// Get the fees a submission should be sent with once the given time has passed since it became due.
+// The fees rise geometrically from the starting fees and reach the hard cap at the end of the window.
+func (e FeeEscalation) GetScheduledFees(sinceDue time.Duration) (float64, float64) {
+	progress := 1.0
+	if e.Window > 0 {
+		progress = math.Max(0, math.Min(1, float64(sinceDue)/float64(e.Window)))
+	}
+	if e.StartMaxFee <= 0 {
+		return e.HardCap, math.Min(e.StartTipFee, e.HardCap)
+	}
+
+	multiplier := math.Pow(e.HardCap/e.StartMaxFee, progress)
+	maxFee := math.Min(e.StartMaxFee*multiplier, e.HardCap)
+	tipFee := math.Min(e.StartTipFee*multiplier, maxFee)
+	return maxFee, tipFee
+}
+
+// Get the fees for a replacement of a transaction sent with the previous fees.
+// Replacements must raise both fees enough for clients to accept them, so the result is false once the max fee
+// has reached the hard cap and can't be raised any further.
+func (e FeeEscalation) GetBumpedFees(previousMaxFee float64, previousTipFee float64, sinceDue time.Duration) (float64, float64, bool) {
+	if previousMaxFee*MinFeeBumpFactor > e.HardCap {
+		return previousMaxFee, previousTipFee, false
+	}
+	maxFee, tipFee := e.GetScheduledFees(sinceDue)
+	maxFee = math.Max(maxFee, previousMaxFee*MinFeeBumpFactor)
+	tipFee = math.Min(math.Max(tipFee, previousTipFee*MinFeeBumpFactor), maxFee)
+	return maxFee, tipFee, true
+}
+
+// Send a watchtower submission and wait for it to be included.
+// If fee escalation is enabled, it starts with a low fee and is replaced with higher fees until it's included,
+// never exceeding the hard cap. Otherwise it uses the static watchtower max and priority fees.
+func SendSubmission(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, logger *log.ColorLogger, collector *collectors.SubmissionCollector, category TxCategory, dueTime time.Time, gasInfo rocketpool.GasInfo, opts *bind.TransactOpts, send SubmissionSender) error {
+
+	// Use the static fees if escalation is disabled
+	if !IsFeeEscalationEnabled(cfg) {
+		maxFee := eth.GweiToWei(GetWatchtowerMaxFee(cfg))
+		if !api.PrintAndCheckGasInfo(gasInfo, false, 0, logger, maxFee, 0) {
+			return nil
+		}
+		opts.GasFeeCap = maxFee
+		opts.GasTipCap = eth.GweiToWei(GetWatchtowerPrioFee(cfg))
+		opts.GasLimit = gasInfo.SafeGasLimit
+
+		firstSent := time.Now()
+		hash, err := send(opts)
+		if err != nil {
+			return err
+		}
+		err = api.PrintAndWaitForTransaction(cfg, hash, rp.Client, logger)
+		if err != nil {
+			return err
+		}
+		recordInclusion(collector, category, firstSent, dueTime, 0, GetWatchtowerMaxFee(cfg))
+		return nil
+	}
+
+	// Start the schedule from the current base fee
+	header, err := rp.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("error getting the latest block header: %w", err)
+	}
+	baseFee := 0.0
+	if header.BaseFee != nil {
+		baseFee = eth.WeiToGwei(header.BaseFee)
+	}
+	escalation := GetFeeEscalation(cfg, baseFee)
+	maxFee, tipFee := escalation.GetScheduledFees(time.Since(dueTime))
+	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, logger, eth.GweiToWei(escalation.HardCap), 0) {
+		return nil
+	}
+	logger.Printlnf("Fee escalation is enabled; starting with a max fee of %.2f gwei and a priority fee of %.2f gwei, rising to %.2f gwei by %s.",
+		maxFee, tipFee, escalation.HardCap, dueTime.Add(escalation.Window).Local().Format(time.RFC1123))
+
+	// Capture the nonce of the first transaction so its replacements use the same one.
+	// It's taken from the signed transaction since nonce coordination may have replaced the one it was built with.
+	signer := opts.Signer
+	opts.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		signedTx, err := signer(address, tx)
+		if err == nil && opts.Nonce == nil {
+			opts.Nonce = new(big.Int).SetUint64(signedTx.Nonce())
+		}
+		return signedTx, err
+	}
+	opts.GasLimit = gasInfo.SafeGasLimit
+	opts.GasFeeCap = eth.GweiToWei(maxFee)
+	opts.GasTipCap = eth.GweiToWei(tipFee)
+
+	// Send the first transaction
+	firstSent := time.Now()
+	hash, err := send(opts)
+	if err != nil {
+		return err
+	}
+	logger.Printlnf("Transaction has been submitted with hash %s.", hash.Hex())
+	hashes := []common.Hash{hash}
+	bumps := 0
+	lastBump := firstSent
+
+	// Give up if none of the transactions have been included well after the fees reached the hard cap
+	deadline := dueTime.Add(escalation.Window)
+	if deadline.Before(firstSent) {
+		deadline = firstSent
+	}
+	deadline = deadline.Add(feeEscalationGracePeriod)
+
+	// Get the receipt of whichever transaction was included, if any
+	findReceipt := func() (common.Hash, *types.Receipt) {
+		for _, hash := range hashes {
+			receipt, err := rp.Client.TransactionReceipt(context.Background(), hash)
+			if errors.Is(err, ethereum.NotFound) {
+				continue
+			}
+			if err != nil {
+				logger.Printlnf("WARNING: error checking transaction %s: %s", hash.Hex(), err.Error())
+				continue
+			}
+			return hash, receipt
+		}
+		return common.Hash{}, nil
+	}
+
+	// Finish up once one of the transactions has been included
+	handleReceipt := func(hash common.Hash, receipt *types.Receipt) error {
+		var waitErr error
+		if receipt.Status == 0 {
+			waitErr = fmt.Errorf("Transaction failed with status 0")
+		}
+		if recorder, ok := rp.Client.(transactionResultRecorder); ok {
+			recorder.RecordTransactionResult(hash, receipt, waitErr)
+		}
+		if waitErr != nil {
+			return fmt.Errorf("Error waiting for transaction: %w", waitErr)
+		}
+		logger.Printlnf("Transaction %s was included after %s with %d fee bump(s).", hash.Hex(), time.Since(firstSent).Round(time.Second), bumps)
+		recordInclusion(collector, category, firstSent, dueTime, bumps, maxFee)
+		return nil
+	}
+
+	// Check if the submission's nonce has been used, and if so, whether one of our transactions used it.
+	// Once it has been used, none of the remaining transactions can ever be included.
+	checkNonce := func() (bool, error) {
+		confirmedNonce, err := rp.Client.NonceAt(context.Background(), opts.From, nil)
+		if err != nil {
+			logger.Printlnf("WARNING: error getting the confirmed nonce of %s: %s", opts.From.Hex(), err.Error())
+			return false, nil
+		}
+		if confirmedNonce <= opts.Nonce.Uint64() {
+			return false, nil
+		}
+
+		// One of ours may have been included since the receipts were last checked
+		hash, receipt := findReceipt()
+		if receipt != nil {
+			return true, handleReceipt(hash, receipt)
+		}
+		return true, fmt.Errorf("nonce %d of %s was used by a transaction other than the submission", opts.Nonce.Uint64(), opts.From.Hex())
+	}
+
+	// Wait for one of the transactions to be included, bumping the fees along the way
+	for {
+		time.Sleep(feeEscalationPollInterval)
+
+		hash, receipt := findReceipt()
+		if receipt != nil {
+			return handleReceipt(hash, receipt)
+		}
+		if done, err := checkNonce(); done {
+			return err
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("submission was not included by %s after %d fee bump(s)", deadline.Local().Format(time.RFC1123), bumps)
+		}
+
+		// Bump the fees if it's been long enough
+		if time.Since(lastBump) < feeEscalationBumpInterval {
+			continue
+		}
+		newMaxFee, newTipFee, bumped := escalation.GetBumpedFees(maxFee, tipFee, time.Since(dueTime))
+		if !bumped {
+			// The fees are at the hard cap, so re-broadcast the last transaction in case it was dropped from the mempool
+			if isAnyTransactionKnown(rp, hashes) {
+				continue
+			}
+			newMaxFee, newTipFee = maxFee, tipFee
+			logger.Println("None of the transactions are known to the execution client anymore; re-broadcasting the last one.")
+		}
+		opts.GasFeeCap = eth.GweiToWei(newMaxFee)
+		opts.GasTipCap = eth.GweiToWei(newTipFee)
+		lastBump = time.Now()
+		newHash, err := send(opts)
+		if err != nil {
+			// A "nonce too low" error means a transaction with this nonce was included, which may or may not be ours
+			if isNonceTooLowError(err) {
+				if done, err := checkNonce(); done {
+					return err
+				}
+				continue
+			}
+
+			// Another replacement may still be pending with fees the node considers high enough; keep waiting on it
+			if isReplacementError(err) {
+				logger.Printlnf("Could not replace the transaction with a max fee of %.2f gwei: %s", newMaxFee, err.Error())
+				continue
+			}
+			return err
+		}
+		if !bumped {
+			if !slices.Contains(hashes, newHash) {
+				hashes = append(hashes, newHash)
+			}
+			continue
+		}
+		maxFee, tipFee = newMaxFee, newTipFee
+		bumps++
+		hashes = append(hashes, newHash)
+		logger.Printlnf("Bumped the fees to a max fee of %.2f gwei and a priority fee of %.2f gwei with transaction %s.", maxFee, tipFee, newHash.Hex())
+	}
+
+}
+
+// Check if the execution client still knows about any of the given transactions
+func isAnyTransactionKnown(rp *rocketpool.RocketPool, hashes []common.Hash) bool {
+	for _, hash := range hashes {
+		_, _, err := rp.Client.TransactionByHash(context.Background(), hash)
+		if !errors.Is(err, ethereum.NotFound) {
+			// Assume it's still known if the lookup failed for any other reason
+			return true
+		}
+	}
+	return false
+}
+
+// Record a submission's inclusion in the metrics
+func recordInclusion(collector *collectors.SubmissionCollector, category TxCategory, firstSent time.Time, dueTime time.Time, bumps int, maxFee float64) {
+	if collector == nil {
+		return
+	}
+	now := time.Now()
+	collector.RecordInclusion(string(category), now.Sub(firstSent), now.Sub(dueTime), bumps, maxFee)
+}
+
+// Check if an error from sending a replacement transaction means it should just be retried later
+func isReplacementError(err error) bool {
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "underpriced") ||
+		strings.Contains(message, "already known")
+}
+
+// Check if an error from sending a transaction means its nonce has already been used
+func isNonceTooLowError(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "nonce too low")
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// Categories of watchtower transactions that can be routed through a private relay
+type TxCategory string
+
+const (
+	TxCategory_RewardsSubmission TxCategory = "rewards-submission"
+	TxCategory_Penalty           TxCategory = "penalty"
+	TxCategory_NetworkSubmission TxCategory = "network-submission"
+)
+
+// The binding that sends transactions through the private relay, created on first use
+var relayRocketPool *rocketpool.RocketPool
+var relayLock sync.Mutex
+
+// Get the Rocket Pool binding to send a transaction of the given category with.
+// If the private relay is enabled for the category, the returned binding broadcasts through it; otherwise rp is returned as-is.
+func GetTransactionRocketPool(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, category TxCategory) (*rocketpool.RocketPool, error) {
+	relayUrl := cfg.Smartnode.PrivateRelayUrl.Value.(string)
+	if relayUrl == "" || !isPrivateRelayEnabled(cfg, category) {
+		return rp, nil
+	}
+
+	relayLock.Lock()
+	defer relayLock.Unlock()
+	if relayRocketPool != nil {
+		return relayRocketPool, nil
+	}
+
+	relayClient, err := services.NewPrivateRelayClient(rp.Client, relayUrl)
+	if err != nil {
+		return nil, err
+	}
+	relayRocketPool, err = rocketpool.NewRocketPool(relayClient, common.HexToAddress(cfg.Smartnode.GetStorageAddress()))
+	if err != nil {
+		relayRocketPool = nil
+		return nil, fmt.Errorf("error creating private relay binding: %w", err)
+	}
+	return relayRocketPool, nil
+}
+
+// Check if the private relay is enabled for a transaction category
+func isPrivateRelayEnabled(cfg *config.RocketPoolConfig, category TxCategory) bool {
+	switch category {
+	case TxCategory_RewardsSubmission:
+		return cfg.Smartnode.PrivateRelayRewardsSubmissions.Value.(bool)
+	case TxCategory_Penalty:
+		return cfg.Smartnode.PrivateRelayPenalties.Value.(bool)
+	case TxCategory_NetworkSubmission:
+		return cfg.Smartnode.PrivateRelayNetworkSubmissions.Value.(bool)
+	default:
+		return false
+	}
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -46,3 +47,60 @@ func TestFindNextSubmission(t *testing.T) {
 		t.Fatalf("Should have error when using a reference date in the future")
 	}
 }
+
+func TestFeeEscalation(t *testing.T) {
+	escalation := FeeEscalation{
+		StartMaxFee: 5,
+		StartTipFee: 1,
+		HardCap:     500,
+		Window:      time.Hour,
+	}
+
+	// Before the submission is due, it should use the starting fees
+	maxFee, tipFee := escalation.GetScheduledFees(-time.Minute)
+	if maxFee != 5 || tipFee != 1 {
+		t.Fatalf("Expected starting fees of 5 / 1, got %.2f / %.2f", maxFee, tipFee)
+	}
+
+	// Halfway through the window, the fees should be at the geometric midpoint
+	maxFee, _ = escalation.GetScheduledFees(30 * time.Minute)
+	if maxFee < 49.9 || maxFee > 50.1 {
+		t.Fatalf("Expected a max fee of 50 halfway through the window, got %.2f", maxFee)
+	}
+
+	// After the window, they should be at the hard cap
+	maxFee, tipFee = escalation.GetScheduledFees(2 * time.Hour)
+	if maxFee != 500 || tipFee != 100 {
+		t.Fatalf("Expected fees of 500 / 100 after the window, got %.2f / %.2f", maxFee, tipFee)
+	}
+
+	// Bumps must raise both fees by the minimum replacement factor even if the schedule hasn't moved
+	maxFee, tipFee, bumped := escalation.GetBumpedFees(5, 1, 0)
+	if !bumped || maxFee != 5*MinFeeBumpFactor || tipFee != MinFeeBumpFactor {
+		t.Fatalf("Expected a minimum bump to %.4f / %.4f, got %.4f / %.4f (bumped = %t)", 5*MinFeeBumpFactor, MinFeeBumpFactor, maxFee, tipFee, bumped)
+	}
+
+	// Bumps must never exceed the hard cap
+	_, _, bumped = escalation.GetBumpedFees(450, 90, 2*time.Hour)
+	if bumped {
+		t.Fatalf("Expected no bump past the hard cap")
+	}
+}
+
+func TestReplacementErrors(t *testing.T) {
+	// A used nonce can never be replaced, so it must not be treated as a retryable replacement error
+	nonceErr := errors.New("nonce too low: next nonce 12, tx nonce 11")
+	if isReplacementError(nonceErr) {
+		t.Fatalf("Expected \"nonce too low\" not to be a replacement error")
+	}
+	if !isNonceTooLowError(nonceErr) {
+		t.Fatalf("Expected \"nonce too low\" to be detected")
+	}
+
+	if !isReplacementError(errors.New("replacement transaction underpriced")) {
+		t.Fatalf("Expected an underpriced replacement to be a replacement error")
+	}
+	if isNonceTooLowError(errors.New("already known")) {
+		t.Fatalf("Expected \"already known\" not to be a nonce error")
+	}
+}
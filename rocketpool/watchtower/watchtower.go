@@ -18,6 +18,12 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/election"
+	"github.com/rocket-pool/smartnode/shared/services/events"
+	"github.com/rocket-pool/smartnode/shared/services/finality"
+	"github.com/rocket-pool/smartnode/shared/services/health"
+	"github.com/rocket-pool/smartnode/shared/services/nonce"
+	"github.com/rocket-pool/smartnode/shared/services/remotesigner"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
@@ -27,10 +33,15 @@ var minTasksInterval, _ = time.ParseDuration("4m")
 var maxTasksInterval, _ = time.ParseDuration("6m")
 var taskCooldown, _ = time.ParseDuration("5s")
 
+// How long the task loop can go without starting a new iteration before the daemon is considered dead.
+// This is generous because escalating submissions hold the loop until they're included.
+var taskLoopTimeout, _ = time.ParseDuration("3h")
+
 const (
 	MaxConcurrentEth1Requests = 200
 
 	RespondChallengesColor         = color.FgWhite
+	ChallengeMembersColor          = color.FgHiRed
 	ClaimRplRewardsColor           = color.FgGreen
 	SubmitRplPriceColor            = color.FgYellow
 	SubmitNetworkBalancesColor     = color.FgYellow
@@ -45,6 +56,7 @@ const (
 	CheckSoloMigrationsColor       = color.FgCyan
 	FinalizeProposalsColor         = color.FgMagenta
 	UpdateColor                    = color.FgHiWhite
+	LeadershipColor                = color.FgHiBlue
 )
 
 // Register watchtower command
@@ -62,9 +74,17 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 // Run daemon
 func run(c *cli.Context) error {
 
+	// Coordinate nonces with the other processes sending from the node wallet
+	services.EnableNonceCoordination(nonce.Priority_Duty)
+
 	// Configure
 	configureHTTP()
 
+	// Use the remote signer for the node account if there is one
+	if err := configureRemoteSigner(c); err != nil {
+		return err
+	}
+
 	// Wait until node is registered
 	if err := services.WaitNodeRegistered(c, true); err != nil {
 		return err
@@ -87,6 +107,13 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return err
+	}
+
+	// Trace failed duty transactions if requested
+	configureDutyTracer(cfg, rp, ec)
 
 	// Print the current mode
 	if cfg.IsNativeMode {
@@ -99,9 +126,17 @@ func run(c *cli.Context) error {
 	scrubCollector := collectors.NewScrubCollector()
 	bondReductionCollector := collectors.NewBondReductionCollector()
 	soloMigrationCollector := collectors.NewSoloMigrationCollector()
+	treegenCollector := collectors.NewTreegenCollector()
+	submissionCollector := collectors.NewSubmissionCollector()
+	treegenBc, err := services.GetHistoricalBeaconClient(c)
+	if err != nil {
+		return err
+	}
+	beaconRetryCollector := collectors.NewBeaconRetryCollector(treegenBc.GetRetryMetrics)
 
 	// Initialize error logger
 	errorLog := log.NewColorLogger(ErrorColor)
+	warningLog := log.NewColorLogger(WarningColor)
 	updateLog := log.NewColorLogger(UpdateColor)
 
 	// Create the state manager
@@ -113,19 +148,40 @@ func run(c *cli.Context) error {
 		return fmt.Errorf("error getting node account: %w", err)
 	}
 
+	// Get somewhere to publish challenge events to, if the user set one up
+	eventPublisher, err := events.NewPublisher(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Decide which instance sends the Oracle DAO duties if the user runs more than one
+	elector, err := election.NewElector(cfg)
+	if err != nil {
+		return err
+	}
+	leadershipLog := log.NewColorLogger(LeadershipColor)
+
 	// Initialize tasks
-	respondChallenges, err := newRespondChallenges(c, log.NewColorLogger(RespondChallengesColor), m)
+	respondChallenges, err := newRespondChallenges(c, log.NewColorLogger(RespondChallengesColor), m, eventPublisher)
 	if err != nil {
 		return fmt.Errorf("error during respond-to-challenges check: %w", err)
 	}
-	submitRplPrice, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor), errorLog)
+	challengeMembers, err := newChallengeMembers(c, log.NewColorLogger(ChallengeMembersColor), errorLog, eventPublisher)
+	if err != nil {
+		return fmt.Errorf("error during challenge-members check: %w", err)
+	}
+	submitRplPrice, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor), errorLog, submissionCollector)
 	if err != nil {
 		return fmt.Errorf("error during rpl price check: %w", err)
 	}
-	submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewColorLogger(SubmitNetworkBalancesColor), errorLog)
+	submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewColorLogger(SubmitNetworkBalancesColor), errorLog, submissionCollector)
 	if err != nil {
 		return fmt.Errorf("error during network balances check: %w", err)
 	}
+	monitorMissedDuties, err := newMonitorMissedDuties(c, log.NewColorLogger(SubmitRplPriceColor), warningLog, submitRplPrice, submitNetworkBalances)
+	if err != nil {
+		return fmt.Errorf("error during missed duty check: %w", err)
+	}
 	dissolveTimedOutMinipools, err := newDissolveTimedOutMinipools(c, log.NewColorLogger(DissolveTimedOutMinipoolsColor))
 	if err != nil {
 		return fmt.Errorf("error during timed-out minipools check: %w", err)
@@ -134,8 +190,12 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error during scrub check: %w", err)
 	}
+	prefetchRewardsSnapshot, err := newPrefetchRewardsSnapshot(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog)
+	if err != nil {
+		return fmt.Errorf("error during rewards snapshot prefetch check: %w", err)
+	}
 	var submitRewardsTree_Stateless *submitRewardsTree_Stateless
-	submitRewardsTree_Stateless, err = newSubmitRewardsTree_Stateless(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m)
+	submitRewardsTree_Stateless, err = newSubmitRewardsTree_Stateless(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m, treegenCollector, submissionCollector, prefetchRewardsSnapshot, eventPublisher)
 	if err != nil {
 		return fmt.Errorf("error during stateless rewards tree check: %w", err)
 	}
@@ -143,10 +203,14 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error during penalties check: %w", err)
 	}*/
-	generateRewardsTree, err := newGenerateRewardsTree(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog)
+	generateRewardsTree, err := newGenerateRewardsTree(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, treegenCollector)
 	if err != nil {
 		return fmt.Errorf("error during manual tree generation check: %w", err)
 	}
+	probeExecutionClient, err := newProbeExecutionClient(c, log.NewColorLogger(SubmitRewardsTreeColor), warningLog)
+	if err != nil {
+		return fmt.Errorf("error during Execution client probe check: %w", err)
+	}
 	cancelBondReductions, err := newCancelBondReductions(c, log.NewColorLogger(CancelBondsColor), errorLog, bondReductionCollector)
 	if err != nil {
 		return fmt.Errorf("error during bond reduction cancel check: %w", err)
@@ -160,9 +224,18 @@ func run(c *cli.Context) error {
 		return fmt.Errorf("error creating finalize-pdao-proposals task: %w", err)
 	}
 
+	// Watch for finality stalls, which pause the duties that depend on network snapshots
+	finalityMonitor := finality.NewMonitor(bc, cfg.Smartnode.FinalityStallEpochs.Value.(uint64))
+
 	intervalDelta := maxTasksInterval - minTasksInterval
 	secondsDelta := intervalDelta.Seconds()
 
+	// Report the daemon's health to orchestrators
+	healthMonitor := health.NewMonitor(taskLoopTimeout, func() error {
+		_, err := w.GetNodeAccount()
+		return err
+	})
+
 	// Wait group to handle the various threads
 	wg := new(sync.WaitGroup)
 	wg.Add(2)
@@ -173,9 +246,12 @@ func run(c *cli.Context) error {
 			// Randomize the next interval
 			randomSeconds := rand.Intn(int(secondsDelta))
 			interval := time.Duration(randomSeconds)*time.Second + minTasksInterval
+			healthMonitor.Heartbeat()
 
 			// Check the EC status
 			err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
+			healthMonitor.SetExecutionClientStatus(err)
+			monitorMissedDuties.setSyncStatus(err)
 			if err != nil {
 				errorLog.Println(err)
 				time.Sleep(taskCooldown)
@@ -184,6 +260,8 @@ func run(c *cli.Context) error {
 
 			// Check the BC status
 			err = services.WaitBeaconClientSynced(c, false) // Force refresh the primary / fallback BC status
+			healthMonitor.SetBeaconClientStatus(err)
+			monitorMissedDuties.setSyncStatus(err)
 			if err != nil {
 				errorLog.Println(err)
 				time.Sleep(taskCooldown)
@@ -199,6 +277,19 @@ func run(c *cli.Context) error {
 				continue
 			}
 
+			// Check finality
+			finalityStatus, err := finalityMonitor.Check()
+			if err != nil {
+				errorLog.Println(err)
+			} else if finalityStatus.Changed {
+				if finalityStatus.Stalled {
+					warningLog.Printlnf("WARNING: the Beacon Chain hasn't finalized since epoch %d (%d epochs ago). Entering degraded mode; network balance, RPL price, and rewards tree submissions will be paused until it finalizes again.", finalityStatus.FinalizedEpoch, finalityStatus.EpochsSinceFinality)
+				} else {
+					warningLog.Printlnf("The Beacon Chain has finalized epoch %d, leaving degraded mode.", finalityStatus.FinalizedEpoch)
+				}
+			}
+			degraded := finalityMonitor.IsStalled()
+
 			// Check if on the Oracle DAO
 			isOnOdao, err := isOnOracleDAO(rp, nodeAccount.Address, latestBlock)
 			if err != nil {
@@ -213,6 +304,59 @@ func run(c *cli.Context) error {
 			}
 			time.Sleep(taskCooldown)
 
+			// Probe the EC's database and the disk ahead of tree generation
+			if isOnOdao {
+				if err := probeExecutionClient.run(); err != nil {
+					errorLog.Println(err)
+				}
+			}
+
+			if isOnOdao {
+				// Check if this instance should send the duties
+				leadership, err := elector.Check()
+				if err != nil {
+					errorLog.Println(err)
+				}
+				if leadership.Changed {
+					if leadership.Leader {
+						leadershipLog.Printlnf("Watchtower %s holds the lease and will send the Oracle DAO duties.", elector.GetInstanceID())
+					} else if leadership.Holder != "" {
+						leadershipLog.Printlnf("Watchtower %s is on standby; %s holds the lease and is sending the Oracle DAO duties.", elector.GetInstanceID(), leadership.Holder)
+					} else {
+						leadershipLog.Printlnf("Watchtower %s is on standby until it can take the lease.", elector.GetInstanceID())
+					}
+				}
+
+				// Stay hot on standby: keep the network state and rewards snapshot loaded, but leave the duties to the leader
+				if !leadership.Leader {
+					monitorMissedDuties.reset()
+					state, err := updateNetworkState(m, &updateLog, latestBlock)
+					if err != nil {
+						errorLog.Println(err)
+					} else if !degraded {
+						if err := prefetchRewardsSnapshot.run(state); err != nil {
+							errorLog.Println(err)
+						}
+					}
+					time.Sleep(interval)
+					continue
+				}
+
+				// Give other instances in delay mode a chance to send the duties first
+				if delay := elector.GetStandbyDelay(); delay > 0 {
+					leadershipLog.Printlnf("Waiting %s before checking for duties so redundant watchtowers don't send them at the same time...", delay.Round(time.Second))
+					time.Sleep(delay)
+
+					// Refresh the block so the duties see anything another instance sent in the meantime
+					latestBlock, err = m.GetLatestBeaconBlock()
+					if err != nil {
+						errorLog.Println(fmt.Errorf("error getting latest Beacon block: %w", err))
+						time.Sleep(taskCooldown)
+						continue
+					}
+				}
+			}
+
 			if isOnOdao {
 				// Run the challenge check
 				if err := respondChallenges.run(); err != nil {
@@ -228,23 +372,38 @@ func run(c *cli.Context) error {
 					continue
 				}
 
-				// Run the network balance submission check
-				if err := submitNetworkBalances.run(state); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
+				// Run the snapshot-dependent submissions unless finality is stalled
+				if !degraded {
+					// Run the network balance submission check
+					if err := submitNetworkBalances.run(state); err != nil {
+						errorLog.Println(err)
+					}
+					time.Sleep(taskCooldown)
 
-				// Run the rewards tree submission check
-				if err := submitRewardsTree_Stateless.Run(isOnOdao, state, latestBlock.Slot); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
+					// Run the rewards snapshot prefetch check
+					if err := prefetchRewardsSnapshot.run(state); err != nil {
+						errorLog.Println(err)
+					}
 
-				// Run the price submission check
-				if err := submitRplPrice.run(state); err != nil {
-					errorLog.Println(err)
+					// Run the rewards tree submission check
+					if err := submitRewardsTree_Stateless.Run(isOnOdao, state, latestBlock.Slot); err != nil {
+						errorLog.Println(err)
+					}
+					time.Sleep(taskCooldown)
+
+					// Run the price submission check
+					if err := submitRplPrice.run(state); err != nil {
+						errorLog.Println(err)
+					}
+					time.Sleep(taskCooldown)
+
+					// Check that the submissions above weren't missed
+					if err := monitorMissedDuties.run(state, nodeAccount.Address); err != nil {
+						errorLog.Println(err)
+					}
+				} else {
+					monitorMissedDuties.reset()
 				}
-				time.Sleep(taskCooldown)
 
 				// Run the minipool dissolve check
 				if err := dissolveTimedOutMinipools.run(state); err != nil {
@@ -274,6 +433,12 @@ func run(c *cli.Context) error {
 				if err := checkSoloMigrations.run(state); err != nil {
 					errorLog.Println(err)
 				}
+				time.Sleep(taskCooldown)
+
+				// Run the unresponsive member challenge check
+				if err := challengeMembers.run(state); err != nil {
+					errorLog.Println(err)
+				}
 				/*time.Sleep(taskCooldown)
 
 				// Run the fee recipient penalty check
@@ -281,7 +446,7 @@ func run(c *cli.Context) error {
 					errorLog.Println(err)
 				}*/
 				// DISABLED until MEV-Boost can support it
-			} else {
+			} else if !degraded {
 				// Run the rewards tree submission check
 				if err := submitRewardsTree_Stateless.Run(isOnOdao, nil, latestBlock.Slot); err != nil {
 					errorLog.Println(err)
@@ -295,7 +460,7 @@ func run(c *cli.Context) error {
 
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), scrubCollector, bondReductionCollector, soloMigrationCollector)
+		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), scrubCollector, bondReductionCollector, soloMigrationCollector, treegenCollector, submissionCollector, beaconRetryCollector, healthMonitor)
 		if err != nil {
 			errorLog.Println(err)
 		}
@@ -317,6 +482,35 @@ func configureHTTP() {
 
 }
 
+// Connect to the remote signer and use it for the node account, if one is configured
+func configureRemoteSigner(c *cli.Context) error {
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	endpoint := cfg.Smartnode.RemoteSignerEndpoint.Value.(string)
+	if endpoint == "" {
+		return nil
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return err
+	}
+
+	signer, err := remotesigner.NewClient(
+		endpoint,
+		cfg.Smartnode.GetRemoteSignerFilePath(&cfg.Smartnode.RemoteSignerCaCert),
+		cfg.Smartnode.GetRemoteSignerFilePath(&cfg.Smartnode.RemoteSignerClientCert),
+		cfg.Smartnode.GetRemoteSignerFilePath(&cfg.Smartnode.RemoteSignerClientKey),
+	)
+	if err != nil {
+		return err
+	}
+	w.SetRemoteSigner(signer)
+	fmt.Printf("Using the remote signer at %s for node account %s.\n", endpoint, signer.Address().Hex())
+	return nil
+}
+
 // Update the latest network state at each cycle
 func updateNetworkState(m *state.NetworkStateManager, log *log.ColorLogger, block beacon.BeaconBlock) (*state.NetworkState, error) {
 	log.Print("Getting latest network state... ")
@@ -0,0 +1,178 @@
+package addressbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// The address book's filename in the CLI's config directory
+const Filename string = "address-book.json"
+
+// Labels can't look like addresses or ENS names, so they can be used in place of either
+var labelPattern = regexp.MustCompile("^[A-Za-z0-9][A-Za-z0-9 _-]{0,31}$")
+
+// A labeled address
+type Entry struct {
+	Label   string         `json:"label"`
+	Address common.Address `json:"address"`
+}
+
+// The address book as it's saved to disk; the entries are either stored directly or encrypted into Crypto
+type addressBookFile struct {
+	Entries []Entry                `json:"entries,omitempty"`
+	Crypto  map[string]interface{} `json:"crypto,omitempty"`
+}
+
+// A local list of labeled addresses, optionally encrypted with a password
+type AddressBook struct {
+	Entries  []Entry
+	path     string
+	password string
+}
+
+// Load the address book, or an empty one if it doesn't exist yet.
+// getPassword is only called if the address book is encrypted.
+func Load(path string, getPassword func() (string, error)) (*AddressBook, error) {
+	book := &AddressBook{
+		Entries: []Entry{},
+		path:    path,
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return book, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading address book from [%s]: %w", path, err)
+	}
+
+	var file addressBookFile
+	err = json.Unmarshal(data, &file)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing address book from [%s]: %w", path, err)
+	}
+	if file.Crypto == nil {
+		if file.Entries != nil {
+			book.Entries = file.Entries
+		}
+		return book, nil
+	}
+
+	// Decrypt the entries
+	book.password, err = getPassword()
+	if err != nil {
+		return nil, err
+	}
+	decrypted, err := eth2ks.New().Decrypt(file.Crypto, book.password)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting address book (is the password correct?): %w", err)
+	}
+	err = json.Unmarshal(decrypted, &book.Entries)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing decrypted address book: %w", err)
+	}
+	return book, nil
+}
+
+// Check if the address book is encrypted
+func (b *AddressBook) IsEncrypted() bool {
+	return b.password != ""
+}
+
+// Set the password the address book is encrypted with when it's saved; a blank password saves it unencrypted
+func (b *AddressBook) SetPassword(password string) {
+	b.password = password
+}
+
+// Save the address book
+func (b *AddressBook) Save() error {
+	sort.Slice(b.Entries, func(i, j int) bool {
+		return strings.ToLower(b.Entries[i].Label) < strings.ToLower(b.Entries[j].Label)
+	})
+	file := addressBookFile{
+		Entries: b.Entries,
+	}
+	if b.password != "" {
+		entryBytes, err := json.Marshal(b.Entries)
+		if err != nil {
+			return fmt.Errorf("error serializing address book entries: %w", err)
+		}
+		file.Crypto, err = eth2ks.New(eth2ks.WithCipher("scrypt")).Encrypt(entryBytes, b.password)
+		if err != nil {
+			return fmt.Errorf("error encrypting address book: %w", err)
+		}
+		file.Entries = nil
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing address book: %w", err)
+	}
+
+	err = files.WriteFileAtomic(b.path, data, 0600)
+	if err != nil {
+		return fmt.Errorf("error saving address book: %w", err)
+	}
+	return nil
+}
+
+// Add an address under a label, replacing the label's existing address if it has one
+func (b *AddressBook) Set(label string, address common.Address) error {
+	err := ValidateLabel(label)
+	if err != nil {
+		return err
+	}
+	for i, entry := range b.Entries {
+		if strings.EqualFold(entry.Label, label) {
+			b.Entries[i] = Entry{Label: label, Address: address}
+			return nil
+		}
+	}
+	b.Entries = append(b.Entries, Entry{Label: label, Address: address})
+	return nil
+}
+
+// Remove a label; returns false if it wasn't in the address book
+func (b *AddressBook) Remove(label string) bool {
+	for i, entry := range b.Entries {
+		if strings.EqualFold(entry.Label, label) {
+			b.Entries = append(b.Entries[:i], b.Entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Get the address with a label (ignoring case)
+func (b *AddressBook) GetAddress(label string) (common.Address, bool) {
+	for _, entry := range b.Entries {
+		if strings.EqualFold(entry.Label, label) {
+			return entry.Address, true
+		}
+	}
+	return common.Address{}, false
+}
+
+// Get the label of an address
+func (b *AddressBook) GetLabel(address common.Address) (string, bool) {
+	for _, entry := range b.Entries {
+		if entry.Address == address {
+			return entry.Label, true
+		}
+	}
+	return "", false
+}
+
+// Check that a label can be used in the address book
+func ValidateLabel(label string) error {
+	if common.IsHexAddress(label) || !labelPattern.MatchString(label) {
+		return fmt.Errorf("invalid label '%s': labels must be 1-32 letters, numbers, spaces, dashes, or underscores, starting with a letter or number", label)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package alerting
 import (
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -178,6 +179,341 @@ func AlertMinipoolStaked(cfg *config.RocketPoolConfig, minipoolAddress common.Ad
 }
 
 // Gets various settings for an alert based on whether a process succeeded or failed.
+// Sends an alert when the node's primary withdrawal address has a pending change that hasn't been confirmed yet.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertPendingWithdrawalAddress(cfg *config.RocketPoolConfig, nodeAddress common.Address, pendingAddress common.Address) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertPendingWithdrawalAddress.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_PendingWithdrawalAddress.Value != true {
+		logMessage("alert for PendingWithdrawalAddress is disabled, not sending.")
+		return nil
+	}
+
+	alert := createAlert(
+		fmt.Sprintf("PendingWithdrawalAddress-%s", pendingAddress.Hex()),
+		"Withdrawal Address Change Pending",
+		fmt.Sprintf("The primary withdrawal address of node %s has a pending change to %s. It will not take effect until it is confirmed from the new address. If you did not request this change, investigate immediately.", nodeAddress.Hex(), pendingAddress.Hex()),
+		SeverityWarning,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{
+			"node":           nodeAddress.Hex(),
+			"pendingAddress": pendingAddress.Hex(),
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the node's reward network isn't enabled, so its rewards will be paid out on Ethereum instead.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertInvalidRewardNetwork(cfg *config.RocketPoolConfig, nodeAddress common.Address, network uint64, nextInterval time.Time) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertInvalidRewardNetwork.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_InvalidRewardNetwork.Value != true {
+		logMessage("alert for InvalidRewardNetwork is disabled, not sending.")
+		return nil
+	}
+
+	alert := createAlert(
+		fmt.Sprintf("InvalidRewardNetwork-%d", network),
+		"Invalid Reward Network",
+		fmt.Sprintf("Node %s has its rewards routed to network %d, which is not enabled, so they will be paid out on Ethereum instead. Run `rocketpool node reward-network` to set a valid network before the next interval ends at %s.", nodeAddress.Hex(), network, nextInterval.UTC().Format(time.RFC1123)),
+		SeverityWarning,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{
+			"node":    nodeAddress.Hex(),
+			"network": fmt.Sprint(network),
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the Beacon Chain has stopped finalizing, with the inactivity penalties the node's validators would take if
+// they went offline for the projection period.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertFinalityStalled(cfg *config.RocketPoolConfig, epochsSinceFinality uint64, validatorCount int, projectedLossEth float64, projectionEpochs uint64) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertFinalityStalled.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_FinalityStalled.Value != true {
+		logMessage("alert for FinalityStalled is disabled, not sending.")
+		return nil
+	}
+
+	alert := createAlert(
+		"FinalityStalled",
+		"Beacon Chain Finality Stalled",
+		fmt.Sprintf("The Beacon Chain has not finalized for %d epochs, and the node has paused its snapshot-dependent tasks until it does. The inactivity leak is in effect: if the node's %d active validator(s) go offline now and finality doesn't return for another %d epochs, they will lose about %.6f ETH. Make sure your clients stay online.", epochsSinceFinality, validatorCount, projectionEpochs, projectedLossEth),
+		SeverityCritical,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the Beacon Chain has started finalizing again after a stall.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertFinalityRecovered(cfg *config.RocketPoolConfig, finalizedEpoch uint64) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertFinalityRecovered.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_FinalityStalled.Value != true {
+		logMessage("alert for FinalityStalled is disabled, not sending.")
+		return nil
+	}
+
+	alert := createAlert(
+		"FinalityRecovered",
+		"Beacon Chain Finality Recovered",
+		fmt.Sprintf("The Beacon Chain has finalized epoch %d, and the node has resumed its normal tasks.", finalizedEpoch),
+		SeverityInfo,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityInfo)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the validator client watchdog finds the validator client unhealthy.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertValidatorClientUnhealthy(cfg *config.RocketPoolConfig, problems []string, failedChecks uint64, restarted bool) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertValidatorClientUnhealthy.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_ValidatorClientUnhealthy.Value != true {
+		logMessage("alert for ValidatorClientUnhealthy is disabled, not sending.")
+		return nil
+	}
+
+	action := "Please check its logs."
+	if restarted {
+		action = "The node has restarted it; please check its logs if this keeps happening."
+	}
+	alert := createAlert(
+		"ValidatorClientUnhealthy",
+		"Validator Client Unhealthy",
+		fmt.Sprintf("The validator client has failed %d health check(s) in a row: %s. %s", failedChecks, strings.Join(problems, "; "), action),
+		SeverityCritical,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the watchtower missed an Oracle DAO duty it was responsible for, with its cause and how to fix it.
+// Repeated misses of the same duty are critical.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertWatchtowerDutyMissed(cfg *config.RocketPoolConfig, duty string, submissionTime time.Time, cause string, detail string, hint string, consecutiveMisses uint64) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertWatchtowerDutyMissed.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_WatchtowerDutyMissed.Value != true {
+		logMessage("alert for WatchtowerDutyMissed is disabled, not sending.")
+		return nil
+	}
+
+	severity := SeverityWarning
+	if consecutiveMisses > 1 {
+		severity = SeverityCritical
+	}
+	alert := createAlert(
+		fmt.Sprintf("WatchtowerDutyMissed-%s-%d", duty, submissionTime.Unix()),
+		"Watchtower Duty Missed",
+		fmt.Sprintf("The watchtower missed the %s submission for %s (%d in a row). Cause: %s (%s). %s", duty, submissionTime.UTC().Format(time.RFC1123), consecutiveMisses, cause, detail, hint),
+		severity,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{
+			"duty":  duty,
+			"cause": cause,
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the node wallet's balance is forecast to drop below the amount needed for its next duties.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertLowBalanceForecast(cfg *config.RocketPoolConfig, nodeAddress common.Address, balanceEth float64, reserveEth float64, duties uint64, daysUntilReserve float64) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertLowBalanceForecast.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_LowBalanceForecast.Value != true {
+		logMessage("alert for LowBalanceForecast is disabled, not sending.")
+		return nil
+	}
+
+	severity := SeverityWarning
+	forecast := fmt.Sprintf("will drop below that in about %.1f days at its current burn rate", daysUntilReserve)
+	if daysUntilReserve <= 0 {
+		severity = SeverityCritical
+		forecast = "is already below that"
+	}
+	alert := createAlert(
+		"LowBalanceForecast",
+		"Node Wallet Balance Low",
+		fmt.Sprintf("Node %s has %.6f ETH. Its next %d automated transactions need about %.6f ETH, and its balance %s. Send more ETH to the node wallet so its duties aren't missed.", nodeAddress.Hex(), balanceEth, duties, reserveEth, forecast),
+		severity,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{
+			"node": nodeAddress.Hex(),
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the system clock has drifted too far from NTP time.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertClockDrift(cfg *config.RocketPoolConfig, offset time.Duration, server string) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertClockDrift.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_ClockDrift.Value != true {
+		logMessage("alert for ClockDrift is disabled, not sending.")
+		return nil
+	}
+
+	alert := createAlert(
+		"ClockDrift",
+		"System Clock Drifting",
+		fmt.Sprintf("The system clock is %s away from %s, and the node has paused its slot-sensitive tasks until it's back in sync. Make sure a time synchronization service (such as chrony or systemd-timesyncd) is running.", offset.Abs().Round(time.Millisecond), server),
+		SeverityWarning,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the system clock is back in sync after drifting.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertClockDriftResolved(cfg *config.RocketPoolConfig, offset time.Duration, server string) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertClockDriftResolved.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_ClockDrift.Value != true {
+		logMessage("alert for ClockDrift is disabled, not sending.")
+		return nil
+	}
+
+	alert := createAlert(
+		"ClockDriftResolved",
+		"System Clock Back In Sync",
+		fmt.Sprintf("The system clock is back in sync with %s (%s away), and the node has resumed its slot-sensitive tasks.", server, offset.Abs().Round(time.Millisecond)),
+		SeverityInfo,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityInfo)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when one of the node's validators is about to propose a block, including any problems with its MEV-Boost
+// relay registrations.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertProposalLookahead(cfg *config.RocketPoolConfig, minipoolAddress common.Address, validatorIndex string, slot uint64, slotTime time.Time, registrationProblems []string) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertProposalLookahead.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_ProposalLookahead.Value != true {
+		logMessage("alert for ProposalLookahead is disabled, not sending.")
+		return nil
+	}
+
+	severity := SeverityInfo
+	description := fmt.Sprintf("Validator %s (minipool %s) will propose the block in slot %d at %s. Make sure your clients stay online until then.", validatorIndex, minipoolAddress.Hex(), slot, slotTime.UTC().Format(time.RFC1123))
+	if len(registrationProblems) > 0 {
+		severity = SeverityWarning
+		description += fmt.Sprintf(" Its MEV-Boost registration may be stale, so the block could be built locally instead: %s.", strings.Join(registrationProblems, "; "))
+	}
+	alert := createAlert(
+		"ProposalLookahead",
+		"Upcoming Block Proposal",
+		description,
+		severity,
+		strfmt.DateTime(slotTime.Add(DefaultEndsAtDurationForSeverityInfo)),
+		map[string]string{
+			"minipool": minipoolAddress.Hex(),
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the Oracle DAO finalized a rewards tree whose Merkle root doesn't match the one this node generated,
+// naming the first node and category where the two trees diverge.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertRewardsRootDivergence(cfg *config.RocketPoolConfig, index uint64, localRoot string, consensusRoot string, firstNode string, category string, divergences int) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertRewardsRootDivergence.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_RewardsRootDivergence.Value != true {
+		logMessage("alert for RewardsRootDivergence is disabled, not sending.")
+		return nil
+	}
+
+	location := fmt.Sprintf("in its %s", category)
+	if firstNode != "" {
+		location = fmt.Sprintf("first at node %s (%s)", firstNode, category)
+	}
+	alert := createAlert(
+		fmt.Sprintf("RewardsRootDivergence-%d", index),
+		"Rewards Tree Root Diverged",
+		fmt.Sprintf("The finalized rewards tree for interval %d has root %s, but this node generated %s. The trees differ in %d place(s), %s. See the divergence report in the rewards tree folder for details.", index, consensusRoot, localRoot, divergences, location),
+		SeverityCritical,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{
+			"interval": fmt.Sprint(index),
+			"category": category,
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when one of the node's validators has started exiting, with the projected times it will exit and be
+// fully withdrawn.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertValidatorExitProjection(cfg *config.RocketPoolConfig, minipoolAddress common.Address, validatorIndex string, exitEpoch uint64, exitTime time.Time, withdrawalTime time.Time) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertValidatorExitProjection.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_ValidatorExitProjection.Value != true {
+		logMessage("alert for ValidatorExitProjection is disabled, not sending.")
+		return nil
+	}
+
+	alert := createAlert(
+		fmt.Sprintf("ValidatorExitProjection-%s", minipoolAddress.Hex()),
+		"Validator Exiting",
+		fmt.Sprintf("Validator %s (minipool %s) is in the exit queue and will exit in epoch %d at %s. Keep it running until then. Its balance should be fully withdrawn around %s, after which the minipool can be closed.", validatorIndex, minipoolAddress.Hex(), exitEpoch, exitTime.UTC().Format(time.RFC1123), withdrawalTime.UTC().Format(time.RFC1123)),
+		SeverityInfo,
+		strfmt.DateTime(exitTime),
+		map[string]string{
+			"minipool": minipoolAddress.Hex(),
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
 func getAlertSettingsForEvent(succeeded bool) (strfmt.DateTime, Severity, string) {
 	endsAt := strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityInfo))
 	severity := SeverityInfo
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/smartnode/shared/services/audit"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/forecast"
+	"github.com/urfave/cli"
+)
+
+// Records the transactions sent by the current command in the audit log, and the fees the daemons pay for them in the
+// gas spend ledger
+type transactionAuditor struct {
+	log        *audit.Log
+	spends     *forecast.Ledger
+	source     string
+	command    string
+	parameters []string
+}
+
+// Create a transaction auditor for the command being run
+func newTransactionAuditor(c *cli.Context, cfg *config.RocketPoolConfig) *transactionAuditor {
+	source, command := getAuditCommand(c)
+	auditor := &transactionAuditor{
+		log:        audit.NewLog(cfg.Smartnode.GetAuditLogPath(true)),
+		source:     source,
+		command:    command,
+		parameters: c.Args(),
+	}
+
+	// Only the daemons' transactions are automated, so only they count towards the node wallet's burn rate
+	if source == "node" || source == "watchtower" {
+		auditor.spends = forecast.NewLedger(cfg.Smartnode.GetGasSpendLedgerPath(true))
+	}
+	return auditor
+}
+
+// Record a transaction and whether it was sent successfully
+func (a *transactionAuditor) recordTransaction(tx *types.Transaction, sendErr error) {
+	parameters := append([]string{}, a.parameters...)
+	if tx.To() != nil {
+		parameters = append(parameters, fmt.Sprintf("to=%s", tx.To().Hex()))
+	}
+	if len(tx.Data()) >= 4 {
+		parameters = append(parameters, fmt.Sprintf("method=0x%s", hex.EncodeToString(tx.Data()[:4])))
+	}
+	if tx.Value() != nil && tx.Value().Sign() > 0 {
+		parameters = append(parameters, fmt.Sprintf("value=%s", tx.Value().String()))
+	}
+	parameters = append(parameters, fmt.Sprintf("nonce=%d", tx.Nonce()))
+
+	outcome := "submitted"
+	if sendErr != nil {
+		outcome = fmt.Sprintf("failed to submit: %s", sendErr.Error())
+	}
+	recordAuditEntry(a.log, audit.Entry{
+		Source:     a.source,
+		Command:    a.command,
+		Parameters: parameters,
+		TxHash:     tx.Hash().Hex(),
+		Outcome:    outcome,
+	})
+}
+
+// Record the outcome of a transaction once it has been included in a block
+func (a *transactionAuditor) recordTransactionResult(hash common.Hash, receipt *types.Receipt, waitErr error) {
+	outcome := "succeeded"
+	if waitErr != nil {
+		outcome = fmt.Sprintf("unknown: %s", waitErr.Error())
+	} else if receipt.Status != types.ReceiptStatusSuccessful {
+		outcome = "reverted"
+	}
+	recordAuditEntry(a.log, audit.Entry{
+		Source:  a.source,
+		Command: a.command,
+		TxHash:  hash.Hex(),
+		Outcome: outcome,
+	})
+
+	// Record the fee, which is paid even if the transaction reverted
+	if a.spends == nil || receipt == nil || receipt.EffectiveGasPrice == nil {
+		return
+	}
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+	err := a.spends.Record(forecast.Spend{
+		Time:    time.Now().UTC(),
+		Source:  a.source,
+		Command: a.command,
+		TxHash:  hash.Hex(),
+		FeeWei:  fee,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: couldn't record the transaction fee in the gas spend ledger: %s\n", err.Error())
+	}
+}
+
+// Record a state-changing action that doesn't involve a transaction in the audit log.
+// Parameters are deliberately not recorded, since these actions can take secrets such as passwords or mnemonics.
+func RecordAuditAction(c *cli.Context, actionErr error) {
+	cfg, err := GetConfig(c)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: couldn't record action in the audit log: %s\n", err.Error())
+		return
+	}
+	source, command := getAuditCommand(c)
+	outcome := "succeeded"
+	if actionErr != nil {
+		outcome = fmt.Sprintf("failed: %s", actionErr.Error())
+	}
+	recordAuditEntry(audit.NewLog(cfg.Smartnode.GetAuditLogPath(true)), audit.Entry{
+		Source:  source,
+		Command: command,
+		Outcome: outcome,
+	})
+}
+
+// Write an entry to the audit log; failures are reported but never stop the action itself
+func recordAuditEntry(log *audit.Log, entry audit.Entry) {
+	err := log.Record(entry)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "WARNING: couldn't record action in the audit log: %s\n", err.Error())
+	}
+}
+
+// Get the process (api, node, or watchtower) and full command path for the command being run
+func getAuditCommand(c *cli.Context) (string, string) {
+	command := c.Command.HelpName
+	if command == "" {
+		command = c.App.Name
+	}
+	source := command
+	fields := strings.Fields(command)
+	if len(fields) > 1 {
+		source = fields[1]
+	}
+	return source, command
+}
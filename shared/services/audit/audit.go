@@ -0,0 +1,162 @@
+package audit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// A single action recorded in the audit log
+type Entry struct {
+	Time         time.Time `json:"time"`
+	Source       string    `json:"source"`
+	Command      string    `json:"command"`
+	Parameters   []string  `json:"parameters,omitempty"`
+	TxHash       string    `json:"txHash,omitempty"`
+	Outcome      string    `json:"outcome"`
+	PreviousHash string    `json:"previousHash"`
+	Hash         string    `json:"hash"`
+}
+
+// An append-only log of state-changing actions taken by the CLI and daemons.
+// Each entry includes the hash of the entry before it, so any modification, removal, or reordering of
+// earlier entries breaks the chain and can be detected with Verify.
+type Log struct {
+	path string
+}
+
+// Create a new audit log handle for the file at the provided path
+func NewLog(path string) *Log {
+	return &Log{
+		path: path,
+	}
+}
+
+// Append an entry to the log. The time and hashes are filled in automatically.
+func (l *Log) Record(entry Entry) error {
+	err := os.MkdirAll(filepath.Dir(l.path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating audit log directory: %w", err)
+	}
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening audit log: %w", err)
+	}
+	defer file.Close()
+
+	// The API, node, and watchtower all write to the same log, so lock it while appending
+	err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX)
+	if err != nil {
+		return fmt.Errorf("error locking audit log: %w", err)
+	}
+	defer func() {
+		_ = syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+	}()
+
+	// Chain the new entry to the last one
+	entries, err := readEntries(file)
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		entry.PreviousHash = entries[len(entries)-1].Hash
+	} else {
+		entry.PreviousHash = ""
+	}
+	entry.Time = time.Now().UTC()
+	entry.Hash, err = getEntryHash(entry)
+	if err != nil {
+		return err
+	}
+
+	bytes, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error serializing audit log entry: %w", err)
+	}
+	_, err = file.Write(append(bytes, '\n'))
+	if err != nil {
+		return fmt.Errorf("error writing audit log entry: %w", err)
+	}
+	return nil
+}
+
+// Read all of the entries in the log. A missing log is treated as an empty one.
+func (l *Log) Read() ([]Entry, error) {
+	file, err := os.Open(l.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return []Entry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening audit log: %w", err)
+	}
+	defer file.Close()
+	return readEntries(file)
+}
+
+// Check the hash chain of a list of entries.
+// Returns the index of the first entry that doesn't match its hash or doesn't link to the entry before it, or -1 if the chain is intact.
+func Verify(entries []Entry) (int, error) {
+	previousHash := ""
+	for i, entry := range entries {
+		if entry.PreviousHash != previousHash {
+			return i, nil
+		}
+		hash, err := getEntryHash(entry)
+		if err != nil {
+			return i, err
+		}
+		if hash != entry.Hash {
+			return i, nil
+		}
+		previousHash = entry.Hash
+	}
+	return -1, nil
+}
+
+// Parse every entry in a log file
+func readEntries(file *os.File) ([]Entry, error) {
+	_, err := file.Seek(0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error reading audit log: %w", err)
+	}
+
+	entries := []Entry{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry Entry
+		err := json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing audit log line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// Get the hash of an entry, which covers every field (including the previous entry's hash) except the hash itself
+func getEntryHash(entry Entry) (string, error) {
+	entry.Hash = ""
+	bytes, err := json.Marshal(entry)
+	if err != nil {
+		return "", fmt.Errorf("error serializing audit log entry: %w", err)
+	}
+	hash := sha256.Sum256(bytes)
+	return hex.EncodeToString(hash[:]), nil
+}
@@ -0,0 +1,50 @@
+package audit
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestHashChain(t *testing.T) {
+	log := NewLog(filepath.Join(t.TempDir(), "audit-log.jsonl"))
+	for _, command := range []string{"rocketpool api node stake-rpl", "rocketpool api wait", "rocketpool api wallet set-password"} {
+		err := log.Record(Entry{
+			Source:  "api",
+			Command: command,
+			Outcome: "succeeded",
+		})
+		if err != nil {
+			t.Fatalf("error recording entry: %s", err.Error())
+		}
+	}
+
+	entries, err := log.Read()
+	if err != nil {
+		t.Fatalf("error reading log: %s", err.Error())
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+	brokenIndex, err := Verify(entries)
+	if err != nil {
+		t.Fatalf("error verifying log: %s", err.Error())
+	}
+	if brokenIndex != -1 {
+		t.Fatalf("expected an intact chain, but it broke at entry %d", brokenIndex)
+	}
+
+	// Modifying an entry should break the chain at that entry
+	modified := append([]Entry{}, entries...)
+	modified[1].Outcome = "reverted"
+	brokenIndex, _ = Verify(modified)
+	if brokenIndex != 1 {
+		t.Fatalf("expected a modified entry to break the chain at entry 1, got %d", brokenIndex)
+	}
+
+	// Removing an entry should break the chain at the entry after it
+	removed := []Entry{entries[0], entries[2]}
+	brokenIndex, _ = Verify(removed)
+	if brokenIndex != 1 {
+		t.Fatalf("expected a removed entry to break the chain at entry 1, got %d", brokenIndex)
+	}
+}
@@ -0,0 +1,204 @@
+package backfill
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/rocket-pool/smartnode/shared/services/store"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Retry delays for failed jobs, doubling with each consecutive failure up to the max
+const (
+	initialRetryDelay = time.Minute
+	maxRetryDelay     = 6 * time.Hour
+)
+
+// A source of historical chain data that a freshly installed node needs to catch up on
+type Job interface {
+	// A unique name for the job, used to persist its progress
+	Name() string
+
+	// Jobs with lower priority values are run first
+	Priority() int
+
+	// Perform one bounded unit of work, returning true once the job has caught up to the chain head
+	Step() (bool, error)
+}
+
+// The persisted progress of a single job
+type JobProgress struct {
+	Complete    bool      `json:"complete"`
+	Steps       uint64    `json:"steps"`
+	Failures    int       `json:"failures"`
+	LastError   string    `json:"lastError,omitempty"`
+	LastRun     time.Time `json:"lastRun"`
+	NextAttempt time.Time `json:"nextAttempt"`
+	CompletedAt time.Time `json:"completedAt"`
+}
+
+// The version of the backfill progress schema in the daemon store
+const progressSchemaVersion uint64 = 1
+
+// Runs backfill jobs in priority order with rate limiting, persisting their progress so work is never repeated across restarts
+type Orchestrator struct {
+	bucket         *store.Bucket
+	jobs           []Job
+	progress       map[string]*JobProgress
+	stepDelay      time.Duration
+	maxStepsPerRun int
+	log            *log.ColorLogger
+}
+
+// Create a new orchestrator, loading any saved progress from the daemon store.
+// Progress saved by older versions in the standalone file at legacyPath is moved into the store.
+// stepDelay is the pause between consecutive steps and maxStepsPerRun caps the work done on each call to Run.
+func NewOrchestrator(daemonStore *store.Store, legacyPath string, stepDelay time.Duration, maxStepsPerRun int, logger *log.ColorLogger) (*Orchestrator, error) {
+	bucket, err := daemonStore.Bucket("backfill", progressSchemaVersion, nil)
+	if err != nil {
+		return nil, err
+	}
+	o := &Orchestrator{
+		bucket:         bucket,
+		jobs:           []Job{},
+		progress:       map[string]*JobProgress{},
+		stepDelay:      stepDelay,
+		maxStepsPerRun: maxStepsPerRun,
+		log:            logger,
+	}
+
+	// Load the saved progress
+	for _, name := range bucket.Keys() {
+		progress := &JobProgress{}
+		if _, err := bucket.Get(name, progress); err != nil {
+			return nil, err
+		}
+		o.progress[name] = progress
+	}
+	if len(o.progress) > 0 {
+		return o, nil
+	}
+
+	// Move the progress over from the legacy file if there is one
+	data, err := os.ReadFile(legacyPath)
+	if os.IsNotExist(err) {
+		return o, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading backfill progress: %w", err)
+	}
+	if err := json.Unmarshal(data, &o.progress); err != nil {
+		return nil, fmt.Errorf("error deserializing backfill progress: %w", err)
+	}
+	if err := o.save(); err != nil {
+		return nil, err
+	}
+	if err := os.Remove(legacyPath); err != nil {
+		return nil, fmt.Errorf("error removing legacy backfill progress file: %w", err)
+	}
+	return o, nil
+}
+
+// Add a job to the orchestrator
+func (o *Orchestrator) Register(job Job) {
+	o.jobs = append(o.jobs, job)
+	sort.SliceStable(o.jobs, func(i, j int) bool {
+		return o.jobs[i].Priority() < o.jobs[j].Priority()
+	})
+	if _, exists := o.progress[job.Name()]; !exists {
+		o.progress[job.Name()] = &JobProgress{}
+	}
+}
+
+// Check if every registered job has caught up
+func (o *Orchestrator) IsComplete() bool {
+	for _, job := range o.jobs {
+		if !o.progress[job.Name()].Complete {
+			return false
+		}
+	}
+	return true
+}
+
+// Get the progress of each registered job
+func (o *Orchestrator) GetProgress() map[string]JobProgress {
+	progress := make(map[string]JobProgress, len(o.jobs))
+	for _, job := range o.jobs {
+		progress[job.Name()] = *o.progress[job.Name()]
+	}
+	return progress
+}
+
+// Run the pending jobs, highest priority first, until they're all complete or the step budget is spent.
+// A failing job is backed off and the next one is tried so one broken data source doesn't block the rest.
+func (o *Orchestrator) Run() error {
+	steps := 0
+	for _, job := range o.jobs {
+		progress := o.progress[job.Name()]
+		for !progress.Complete && steps < o.maxStepsPerRun {
+			if time.Now().Before(progress.NextAttempt) {
+				break
+			}
+			if steps > 0 {
+				time.Sleep(o.stepDelay)
+			}
+			steps++
+
+			complete, err := job.Step()
+			progress.LastRun = time.Now()
+			if err != nil {
+				progress.Failures++
+				progress.LastError = err.Error()
+				progress.NextAttempt = progress.LastRun.Add(getRetryDelay(progress.Failures))
+				o.log.Printlnf("Backfill job [%s] failed (attempt %d), retrying after %s: %s", job.Name(), progress.Failures, progress.NextAttempt.Format(time.RFC3339), err.Error())
+				if err := o.save(); err != nil {
+					return err
+				}
+				break
+			}
+
+			progress.Steps++
+			progress.Failures = 0
+			progress.LastError = ""
+			progress.NextAttempt = time.Time{}
+			if complete {
+				progress.Complete = true
+				progress.CompletedAt = progress.LastRun
+				o.log.Printlnf("Backfill job [%s] is complete after %d step(s).", job.Name(), progress.Steps)
+			}
+			if err := o.save(); err != nil {
+				return err
+			}
+		}
+		if steps >= o.maxStepsPerRun {
+			break
+		}
+	}
+	return nil
+}
+
+// Save the job progress to the daemon store
+func (o *Orchestrator) save() error {
+	for name, progress := range o.progress {
+		if err := o.bucket.Put(name, progress); err != nil {
+			return fmt.Errorf("error saving backfill progress: %w", err)
+		}
+	}
+	return nil
+}
+
+// Get the delay before retrying a job that has failed the given number of times in a row
+func getRetryDelay(failures int) time.Duration {
+	delay := initialRetryDelay
+	for i := 1; i < failures; i++ {
+		delay *= 2
+		if delay >= maxRetryDelay {
+			return maxRetryDelay
+		}
+	}
+	return delay
+}
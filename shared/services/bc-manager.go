@@ -3,6 +3,7 @@ package services
 import (
 	"fmt"
 	"math/big"
+	"net/http"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -11,6 +12,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/beacon/client"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/faults"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -38,48 +40,23 @@ type bcFunction1 func(beacon.Client) (interface{}, error)
 type bcFunction2 func(beacon.Client) (interface{}, interface{}, error)
 
 // Creates a new BeaconClientManager instance based on the Rocket Pool config
-func NewBeaconClientManager(cfg *config.RocketPoolConfig) (*BeaconClientManager, error) {
+func NewBeaconClientManager(cfg *config.RocketPoolConfig, faultInjector *faults.Injector) (*BeaconClientManager, error) {
 
-	// Primary CC
-	var primaryProvider string
-	var selectedCC cfgtypes.ConsensusClient
-	if cfg.IsNativeMode {
-		primaryProvider = cfg.Native.CcHttpUrl.Value.(string)
-		selectedCC = cfg.Native.ConsensusClient.Value.(cfgtypes.ConsensusClient)
-	} else if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
-		primaryProvider = fmt.Sprintf("http://%s:%d", bnContainerName, cfg.ConsensusCommon.ApiPort.Value.(uint16))
-		selectedCC = cfg.ConsensusClient.Value.(cfgtypes.ConsensusClient)
-	} else if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_External {
-		selectedConsensusConfig, err := cfg.GetSelectedConsensusClientConfig()
-		if err != nil {
-			return nil, err
-		}
-		primaryProvider = selectedConsensusConfig.(cfgtypes.ExternalConsensusConfig).GetApiUrl()
-		selectedCC = cfg.ExternalConsensusClient.Value.(cfgtypes.ConsensusClient)
-	} else {
-		return nil, fmt.Errorf("Unknown Consensus client mode '%v'", cfg.ConsensusClientMode.Value)
+	primaryProvider, fallbackProvider, err := getBeaconProviders(cfg)
+	if err != nil {
+		return nil, err
 	}
 
-	// Fallback CC
-	var fallbackProvider string
-	if cfg.UseFallbackClients.Value == true {
-		if cfg.IsNativeMode {
-			fallbackProvider = cfg.FallbackNormal.CcHttpUrl.Value.(string)
-		} else {
-			switch selectedCC {
-			case cfgtypes.ConsensusClient_Prysm:
-				fallbackProvider = cfg.FallbackPrysm.CcHttpUrl.Value.(string)
-			default:
-				fallbackProvider = cfg.FallbackNormal.CcHttpUrl.Value.(string)
-			}
-		}
+	// Send the primary BN's requests through the caching proxy if it's enabled
+	if cfg.Smartnode.UseCachingProxy() {
+		primaryProvider = cfg.Smartnode.GetCachingProxyBcUrl()
 	}
 
 	var primaryBc beacon.Client
 	var fallbackBc beacon.Client
-	primaryBc = client.NewStandardHttpClient(primaryProvider)
+	primaryBc = client.NewStandardHttpClientWithTransport(primaryProvider, faultInjector.Wrap(faults.Client_Beacon, http.DefaultTransport))
 	if fallbackProvider != "" {
-		fallbackBc = client.NewStandardHttpClient(fallbackProvider)
+		fallbackBc = client.NewStandardHttpClientWithTransport(fallbackProvider, faultInjector.Wrap(faults.Client_Beacon, http.DefaultTransport))
 	}
 
 	return &BeaconClientManager{
@@ -92,6 +69,37 @@ func NewBeaconClientManager(cfg *config.RocketPoolConfig) (*BeaconClientManager,
 
 }
 
+// Creates a new BeaconClientManager for heavy historical workloads (tree generation and rolling records) that only uses the dedicated
+// historical Beacon Node, so those queries never land on the Beacon Node serving the Validator Client.
+// Returns nil if no dedicated Beacon Node has been configured.
+func NewHistoricalBeaconClientManager(cfg *config.RocketPoolConfig, faultInjector *faults.Injector) (*BeaconClientManager, error) {
+
+	historicalProvider := strings.TrimSpace(cfg.Smartnode.HistoricalBeaconUrl.Value.(string))
+	if historicalProvider == "" {
+		return nil, nil
+	}
+
+	// Make sure the dedicated node isn't one the VC is already using
+	primaryProvider, fallbackProvider, err := getBeaconProviders(cfg)
+	if err != nil {
+		return nil, err
+	}
+	for _, vcProvider := range []string{primaryProvider, fallbackProvider} {
+		if vcProvider != "" && normalizeBeaconUrl(vcProvider) == normalizeBeaconUrl(historicalProvider) {
+			return nil, fmt.Errorf("the historical Beacon Node (%s) is also used by the Validator Client; it must be a separate Beacon Node", historicalProvider)
+		}
+	}
+
+	// Deliberately no fallback here, since the only other Beacon Nodes are the ones serving the VC
+	return &BeaconClientManager{
+		primaryBc:     client.NewStandardHttpClientWithTransport(historicalProvider, faultInjector.Wrap(faults.Client_Beacon, http.DefaultTransport)),
+		logger:        log.NewColorLogger(color.FgHiBlue),
+		primaryReady:  true,
+		fallbackReady: false,
+	}, nil
+
+}
+
 /// ======================
 /// BeaconClient Functions
 /// ======================
@@ -216,6 +224,28 @@ func (m *BeaconClientManager) GetValidatorStatuses(pubkeys []types.ValidatorPubk
 	return result.(map[types.ValidatorPubkey]beacon.ValidatorStatus), nil
 }
 
+// Get multiple validators' statuses by their indices
+func (m *BeaconClientManager) GetValidatorStatusesByIndex(indices []string, opts *beacon.ValidatorStatusOptions) (map[string]beacon.ValidatorStatus, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetValidatorStatusesByIndex(indices, opts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string]beacon.ValidatorStatus), nil
+}
+
+// Get the statuses of every validator in one of the provided states
+func (m *BeaconClientManager) GetValidatorStatusesByState(states []beacon.ValidatorState, opts *beacon.ValidatorStatusOptions) ([]beacon.ValidatorStatus, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetValidatorStatusesByState(states, opts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]beacon.ValidatorStatus), nil
+}
+
 // Get a validator's index
 func (m *BeaconClientManager) GetValidatorIndex(pubkey types.ValidatorPubkey) (string, error) {
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
@@ -249,6 +279,17 @@ func (m *BeaconClientManager) GetValidatorProposerDuties(indices []string, epoch
 	return result.(map[string]uint64), nil
 }
 
+// Get the slots the provided validators are assigned to propose in the given epoch
+func (m *BeaconClientManager) GetValidatorProposerSlots(indices []string, epoch uint64) (map[string][]uint64, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetValidatorProposerSlots(indices, epoch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string][]uint64), nil
+}
+
 // Get the Beacon chain's domain data
 func (m *BeaconClientManager) GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error) {
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
@@ -331,6 +372,17 @@ func (m *BeaconClientManager) GetValidatorBalances(indices []string, opts *beaco
 	return result.(map[string]*big.Int), nil
 }
 
+// Get the combined retry activity of the primary and fallback clients
+func (m *BeaconClientManager) GetRetryMetrics() client.RetryMetrics {
+	metrics := client.RetryMetrics{}
+	for _, bc := range []beacon.Client{m.primaryBc, m.fallbackBc} {
+		if httpClient, ok := bc.(*client.StandardHttpClient); ok {
+			metrics.Add(httpClient.GetRetryMetrics())
+		}
+	}
+	return metrics
+}
+
 /// ==================
 /// Internal Functions
 /// ==================
@@ -526,3 +578,50 @@ func (m *BeaconClientManager) runFunction2(function bcFunction2) (interface{}, i
 func (m *BeaconClientManager) isDisconnected(err error) bool {
 	return strings.Contains(err.Error(), "dial tcp")
 }
+
+// Get the URLs of the primary and fallback Beacon Nodes, which are the ones the Validator Client uses
+func getBeaconProviders(cfg *config.RocketPoolConfig) (string, string, error) {
+
+	// Primary CC
+	var primaryProvider string
+	var selectedCC cfgtypes.ConsensusClient
+	if cfg.IsNativeMode {
+		primaryProvider = cfg.Native.CcHttpUrl.Value.(string)
+		selectedCC = cfg.Native.ConsensusClient.Value.(cfgtypes.ConsensusClient)
+	} else if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		primaryProvider = fmt.Sprintf("http://%s:%d", bnContainerName, cfg.ConsensusCommon.ApiPort.Value.(uint16))
+		selectedCC = cfg.ConsensusClient.Value.(cfgtypes.ConsensusClient)
+	} else if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_External {
+		selectedConsensusConfig, err := cfg.GetSelectedConsensusClientConfig()
+		if err != nil {
+			return "", "", err
+		}
+		primaryProvider = selectedConsensusConfig.(cfgtypes.ExternalConsensusConfig).GetApiUrl()
+		selectedCC = cfg.ExternalConsensusClient.Value.(cfgtypes.ConsensusClient)
+	} else {
+		return "", "", fmt.Errorf("Unknown Consensus client mode '%v'", cfg.ConsensusClientMode.Value)
+	}
+
+	// Fallback CC
+	var fallbackProvider string
+	if cfg.UseFallbackClients.Value == true {
+		if cfg.IsNativeMode {
+			fallbackProvider = cfg.FallbackNormal.CcHttpUrl.Value.(string)
+		} else {
+			switch selectedCC {
+			case cfgtypes.ConsensusClient_Prysm:
+				fallbackProvider = cfg.FallbackPrysm.CcHttpUrl.Value.(string)
+			default:
+				fallbackProvider = cfg.FallbackNormal.CcHttpUrl.Value.(string)
+			}
+		}
+	}
+
+	return primaryProvider, fallbackProvider, nil
+
+}
+
+// Normalize a Beacon Node URL so equivalent URLs can be compared
+func normalizeBeaconUrl(url string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(url)), "/")
+}
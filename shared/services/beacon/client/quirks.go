@@ -0,0 +1,135 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/goccy/go-json"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// The ways a BN implementation's responses deviate from the Beacon API spec.
+// Everything else about a response is handled the same way for every implementation.
+type clientQuirks struct {
+	// Statuses besides 404 the BN uses for blocks that don't exist (such as missed slots), along with
+	// the messages in the response body that tell them apart from genuine errors
+	missingBlockStatuses []int
+	missingBlockMessages [][]byte
+
+	// Statuses the BN uses to refuse requests while it's syncing, along with the messages that identify them.
+	// These aren't retried since the BN won't finish syncing within the retry window.
+	syncingStatuses []int
+	syncingMessages [][]byte
+}
+
+// The quirks of implementations that follow the spec, or that couldn't be identified
+var standardQuirks = clientQuirks{}
+
+// The quirks of each implementation; ones that aren't listed follow the spec
+var implementationQuirks = map[beacon.ClientImplementation]clientQuirks{
+	// Prysm's gRPC gateway reports blocks it can't find as internal errors
+	beacon.ClientImplementation_Prysm: {
+		missingBlockStatuses: []int{http.StatusInternalServerError},
+		missingBlockMessages: [][]byte{[]byte("NotFound"), []byte("not found"), []byte("Could not find")},
+	},
+
+	// Teku refuses state and block requests with a 503 until it's synced
+	beacon.ClientImplementation_Teku: {
+		syncingStatuses: []int{http.StatusServiceUnavailable},
+		syncingMessages: [][]byte{[]byte("currently syncing")},
+	},
+}
+
+// Get the quirks of the given implementation
+func getClientQuirks(implementation beacon.ClientImplementation) clientQuirks {
+	if quirks, exists := implementationQuirks[implementation]; exists {
+		return quirks
+	}
+	return standardQuirks
+}
+
+// Check if a response means the requested block doesn't exist
+func (q clientQuirks) isMissingBlock(status int, body []byte) bool {
+	if status == http.StatusNotFound {
+		return true
+	}
+	return slices.Contains(q.missingBlockStatuses, status) && containsAny(body, q.missingBlockMessages)
+}
+
+// Check if a response means the BN won't serve the request until it's synced
+func (q clientQuirks) isSyncing(status int, body []byte) bool {
+	return slices.Contains(q.syncingStatuses, status) && containsAny(body, q.syncingMessages)
+}
+
+// Check if the body contains any of the given messages
+func containsAny(body []byte, messages [][]byte) bool {
+	for _, message := range messages {
+		if bytes.Contains(body, message) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the BN implementation, detecting it if it hasn't been already
+func (c *StandardHttpClient) GetClientImplementation() (beacon.ClientImplementation, error) {
+	if implementation := c.implementation.Load(); implementation != nil {
+		return *implementation, nil
+	}
+
+	// This bypasses the retry logic, since that relies on the implementation's quirks
+	response, err := c.httpClient.Get(fmt.Sprintf(RequestUrlFormat, c.providerAddress, RequestNodeVersionPath))
+	if err != nil {
+		return beacon.ClientImplementation_Unknown, fmt.Errorf("Could not get node version: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return beacon.ClientImplementation_Unknown, fmt.Errorf("Could not get node version: %w", err)
+	}
+
+	// A BN that doesn't serve its version is treated as following the spec
+	implementation := beacon.ClientImplementation_Unknown
+	if response.StatusCode == http.StatusOK {
+		var version NodeVersionResponse
+		if err := json.Unmarshal(body, &version); err != nil {
+			return beacon.ClientImplementation_Unknown, fmt.Errorf("Could not decode node version: %w", err)
+		}
+		implementation = beacon.ParseClientImplementation(version.Data.Version)
+	} else if ClassifyError(nil, response.StatusCode) != ErrorClass_Permanent {
+		return beacon.ClientImplementation_Unknown, fmt.Errorf("Could not get node version: HTTP status %d; response body: '%s'", response.StatusCode, string(body))
+	}
+	c.implementation.Store(&implementation)
+	return implementation, nil
+}
+
+// Get the BN implementation's quirks, falling back to the spec's behavior if it can't be detected right now
+func (c *StandardHttpClient) getQuirks() clientQuirks {
+	implementation, err := c.GetClientImplementation()
+	if err != nil {
+		return standardQuirks
+	}
+	return getClientQuirks(implementation)
+}
+
+// Check if a response means the requested block doesn't exist; a plain 404 doesn't need the implementation
+func (c *StandardHttpClient) isMissingBlock(status int, body []byte) bool {
+	if status == http.StatusNotFound {
+		return true
+	}
+	if status == http.StatusOK {
+		return false
+	}
+	return c.getQuirks().isMissingBlock(status, body)
+}
+
+// Check if a response means the BN won't serve the request until it's synced
+func (c *StandardHttpClient) isSyncing(status int, body []byte) bool {
+	return c.getQuirks().isSyncing(status, body)
+}
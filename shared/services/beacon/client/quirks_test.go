@@ -0,0 +1,229 @@
+package client
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// How a BN implementation responds to the requests the conformance tests make
+type implementationFixture struct {
+	implementation beacon.ClientImplementation
+	version        string
+
+	// The envelope around response data, which has different optional fields on each implementation
+	envelope string
+
+	// How the implementation reports a missed slot
+	missingStatus int
+	missingBody   string
+}
+
+var implementationFixtures = []implementationFixture{
+	{
+		implementation: beacon.ClientImplementation_Lighthouse,
+		version:        "Lighthouse/v4.5.0-441fc16/x86_64-linux",
+		envelope:       `{"version":"capella","execution_optimistic":false,"finalized":true,"data":%s}`,
+		missingStatus:  http.StatusNotFound,
+		missingBody:    `{"code":404,"message":"NOT_FOUND: beacon block at slot 101","stacktraces":[]}`,
+	},
+	{
+		implementation: beacon.ClientImplementation_Prysm,
+		version:        "Prysm/v4.2.1 (linux amd64)",
+		envelope:       `{"version":"capella","execution_optimistic":false,"data":%s}`,
+		missingStatus:  http.StatusInternalServerError,
+		missingBody:    `{"code":500,"message":"Could not get block from block ID: rpc error: code = NotFound desc = Could not find requested block"}`,
+	},
+	{
+		implementation: beacon.ClientImplementation_Teku,
+		version:        "teku/v24.1.0/linux-x86_64/-eclipseadoptium-openjdk64bitservervm-java-17",
+		envelope:       `{"version":"capella","execution_optimistic":false,"finalized":true,"data":%s}`,
+		missingStatus:  http.StatusNotFound,
+		missingBody:    `{"code":404,"message":"Not found"}`,
+	},
+	{
+		implementation: beacon.ClientImplementation_Nimbus,
+		version:        "Nimbus/v24.1.2-d4b3f7-stateofus",
+		envelope:       `{"version":"capella","data":%s}`,
+		missingStatus:  http.StatusNotFound,
+		missingBody:    `{"code":404,"message":"Block not found"}`,
+	},
+	{
+		implementation: beacon.ClientImplementation_Lodestar,
+		version:        "Lodestar/v1.15.0/b3c9d3a (linux x64)",
+		envelope:       `{"version":"capella","execution_optimistic":false,"data":%s}`,
+		missingStatus:  http.StatusNotFound,
+		missingBody:    `{"statusCode":404,"error":"Not Found","message":"No block found for id '101'"}`,
+	},
+}
+
+const (
+	testBlock = `{"message":{"slot":"100","proposer_index":"7","body":{` +
+		`"eth1_data":{"deposit_root":"0x00","deposit_count":"1","block_hash":"0x00"},` +
+		`"attestations":[{"aggregation_bits":"0x0f","data":{"slot":"99","index":"2"}}],` +
+		`"execution_payload":{"fee_recipient":"0x1111111111111111111111111111111111111111","block_number":"1000",` +
+		`"withdrawals":[{"index":"1","validator_index":"7","address":"0x2222222222222222222222222222222222222222","amount":"1000"}]}}}}`
+	testPreMergeBlock = `{"message":{"slot":"50","proposer_index":"3","body":{` +
+		`"eth1_data":{"deposit_root":"0x00","deposit_count":"1","block_hash":"0x00"},"attestations":[]}}}`
+	testBlockHeader  = `{"root":"0x00","canonical":true,"header":{"message":{"slot":"100","proposer_index":"7"}}}`
+	testAttestations = `[{"aggregation_bits":"0x0f","data":{"slot":"99","index":"2"}}]`
+)
+
+// Serve the fixture's responses, counting the requests other than the node version
+func newImplementationServer(fixture implementationFixture) (*httptest.Server, *atomic.Int32) {
+	calls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == RequestNodeVersionPath {
+			fmt.Fprintf(w, `{"data":{"version":"%s"}}`, fixture.version)
+			return
+		}
+		calls.Add(1)
+		switch r.URL.Path {
+		case fmt.Sprintf(RequestBeaconBlockPath, "100"):
+			fmt.Fprintf(w, fixture.envelope, testBlock)
+		case fmt.Sprintf(RequestBeaconBlockPath, "50"):
+			fmt.Fprintf(w, fixture.envelope, testPreMergeBlock)
+		case fmt.Sprintf(RequestBeaconBlockHeaderPath, "100"):
+			fmt.Fprintf(w, fixture.envelope, testBlockHeader)
+		case fmt.Sprintf(RequestAttestationsPath, "100"):
+			fmt.Fprintf(w, fixture.envelope, testAttestations)
+		default:
+			if strings.HasSuffix(r.URL.Path, "/101") || strings.HasSuffix(r.URL.Path, "/101/attestations") {
+				w.WriteHeader(fixture.missingStatus)
+				w.Write([]byte(fixture.missingBody))
+				return
+			}
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	return server, calls
+}
+
+// Every implementation should produce the same results for the calls treegen and the watchtower rely on
+func TestImplementationConformance(t *testing.T) {
+	expectedAttestations := []beacon.AttestationInfo{{AggregationBits: []byte{0x0f}, SlotIndex: 99, CommitteeIndex: 2}}
+	expectedBlock := beacon.BeaconBlock{
+		Slot:                 100,
+		ProposerIndex:        "7",
+		HasExecutionPayload:  true,
+		Attestations:         expectedAttestations,
+		FeeRecipient:         common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		ExecutionBlockNumber: 1000,
+		Withdrawals: []beacon.WithdrawalInfo{{
+			ValidatorIndex: "7",
+			Address:        common.HexToAddress("0x2222222222222222222222222222222222222222"),
+			Amount:         big.NewInt(1000e9),
+		}},
+	}
+
+	for _, fixture := range implementationFixtures {
+		t.Run(string(fixture.implementation), func(t *testing.T) {
+			server, calls := newImplementationServer(fixture)
+			defer server.Close()
+			c := NewStandardHttpClient(server.URL)
+			c.SetRetryPolicy(testRetryPolicy)
+
+			implementation, err := c.GetClientImplementation()
+			if err != nil || implementation != fixture.implementation {
+				t.Fatalf("expected %s, got %s (err: %v)", fixture.implementation, implementation, err)
+			}
+
+			block, exists, err := c.GetBeaconBlock("100")
+			if err != nil || !exists {
+				t.Fatalf("expected block 100, got exists=%t, err=%v", exists, err)
+			}
+			if !reflect.DeepEqual(block, expectedBlock) {
+				t.Fatalf("unexpected block: %+v", block)
+			}
+
+			block, exists, err = c.GetBeaconBlock("50")
+			if err != nil || !exists || block.HasExecutionPayload || len(block.Withdrawals) != 0 {
+				t.Fatalf("expected pre-merge block 50, got %+v (exists=%t, err=%v)", block, exists, err)
+			}
+
+			header, exists, err := c.GetBeaconBlockHeader("100")
+			if err != nil || !exists || header.Slot != 100 || header.ProposerIndex != "7" {
+				t.Fatalf("unexpected header %+v (exists=%t, err=%v)", header, exists, err)
+			}
+
+			attestations, exists, err := c.GetAttestations("100")
+			if err != nil || !exists || !reflect.DeepEqual(attestations, expectedAttestations) {
+				t.Fatalf("unexpected attestations %+v (exists=%t, err=%v)", attestations, exists, err)
+			}
+
+			// Missed slots are reported differently, but should all come back as missing without retrying
+			calls.Store(0)
+			if _, exists, err := c.GetBeaconBlock("101"); err != nil || exists {
+				t.Fatalf("expected block 101 to be missing, got exists=%t, err=%v", exists, err)
+			}
+			if _, exists, err := c.GetBeaconBlockHeader("101"); err != nil || exists {
+				t.Fatalf("expected header 101 to be missing, got exists=%t, err=%v", exists, err)
+			}
+			if _, exists, err := c.GetAttestations("101"); err != nil || exists {
+				t.Fatalf("expected attestations 101 to be missing, got exists=%t, err=%v", exists, err)
+			}
+			if calls.Load() != 3 {
+				t.Fatalf("missed slots shouldn't be retried, but got %d calls", calls.Load())
+			}
+		})
+	}
+}
+
+// Genuine errors shouldn't be mistaken for missing blocks just because the status matches a quirk
+func TestMissingBlockQuirkNeedsMessage(t *testing.T) {
+	quirks := getClientQuirks(beacon.ClientImplementation_Prysm)
+	if quirks.isMissingBlock(http.StatusInternalServerError, []byte(`{"code":500,"message":"Could not get state"}`)) {
+		t.Fatal("a generic internal error was treated as a missing block")
+	}
+	if standardQuirks.isMissingBlock(http.StatusInternalServerError, []byte(`{"message":"NotFound"}`)) {
+		t.Fatal("only Prysm reports missing blocks as internal errors")
+	}
+}
+
+// Teku refuses requests while it's syncing, which shouldn't be retried
+func TestSyncingNotRetried(t *testing.T) {
+	calls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == RequestNodeVersionPath {
+			w.Write([]byte(`{"data":{"version":"teku/v24.1.0/linux-x86_64"}}`))
+			return
+		}
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"code":503,"message":"Beacon node is currently syncing and not serving request on that endpoint"}`))
+	}))
+	defer server.Close()
+	c := NewStandardHttpClient(server.URL)
+	c.SetRetryPolicy(testRetryPolicy)
+
+	body, status, err := c.getRequest(fmt.Sprintf(RequestBeaconBlockPath, "100"))
+	if err != nil || status != http.StatusServiceUnavailable || !strings.Contains(string(body), "currently syncing") {
+		t.Fatalf("expected the syncing response, got %d '%s' (err: %v)", status, string(body), err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("syncing responses shouldn't be retried, but got %d calls", calls.Load())
+	}
+	if metrics := c.GetRetryMetrics(); metrics.OverloadedRetries != 0 || metrics.Failures != 1 {
+		t.Fatalf("unexpected metrics: %+v", metrics)
+	}
+}
+
+func TestParseClientImplementation(t *testing.T) {
+	for _, fixture := range implementationFixtures {
+		if implementation := beacon.ParseClientImplementation(fixture.version); implementation != fixture.implementation {
+			t.Fatalf("expected %s from '%s', got %s", fixture.implementation, fixture.version, implementation)
+		}
+	}
+	if implementation := beacon.ParseClientImplementation("Grandine/0.4.0"); implementation != beacon.ClientImplementation_Unknown {
+		t.Fatalf("expected an unknown implementation, got %s", implementation)
+	}
+}
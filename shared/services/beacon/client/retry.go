@@ -0,0 +1,185 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// The kinds of failure a Beacon Node request can have, which determine whether and how it's retried
+type ErrorClass int
+
+const (
+	// The request succeeded
+	ErrorClass_None ErrorClass = iota
+
+	// The resource doesn't exist, such as a missed slot; it's returned right away since retrying won't change it
+	ErrorClass_NotFound
+
+	// The Beacon Node is overloaded or rate limiting; it's retried with exponential backoff
+	ErrorClass_Overloaded
+
+	// The request timed out; it's retried with backoff, but fewer times since each attempt is slow
+	ErrorClass_Timeout
+
+	// The Beacon Node couldn't be reached; it's returned right away so the client manager can fail over
+	ErrorClass_Connection
+
+	// Any other failure, which retrying won't fix
+	ErrorClass_Permanent
+)
+
+// Classify the outcome of a Beacon Node request
+func ClassifyError(err error, status int) ErrorClass {
+	if err != nil {
+		var netErr net.Error
+		if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+			return ErrorClass_Timeout
+		}
+		var opErr *net.OpError
+		if errors.As(err, &opErr) {
+			return ErrorClass_Connection
+		}
+		return ErrorClass_Permanent
+	}
+
+	switch status {
+	case http.StatusNotFound:
+		return ErrorClass_NotFound
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable:
+		return ErrorClass_Overloaded
+	case http.StatusGatewayTimeout, http.StatusRequestTimeout:
+		return ErrorClass_Timeout
+	}
+	if status >= 400 {
+		return ErrorClass_Permanent
+	}
+	return ErrorClass_None
+}
+
+// How GET requests to the Beacon Node are retried
+type RetryPolicy struct {
+	// The most attempts an overloaded request gets, including the first
+	MaxAttempts int
+
+	// The most attempts a request that times out gets, including the first
+	MaxTimeoutAttempts int
+
+	// The delay before the first retry, which doubles with each retry up to MaxDelay
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+}
+
+// The retry policy clients use unless they're given a different one
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:        6,
+	MaxTimeoutAttempts: 3,
+	InitialDelay:       500 * time.Millisecond,
+	MaxDelay:           30 * time.Second,
+}
+
+// Get the number of attempts a request with the given failure gets, including the first
+func (p RetryPolicy) getMaxAttempts(class ErrorClass) int {
+	switch class {
+	case ErrorClass_Overloaded:
+		return p.MaxAttempts
+	case ErrorClass_Timeout:
+		return p.MaxTimeoutAttempts
+	default:
+		return 1
+	}
+}
+
+// Get the delay before the given retry (starting at 1), honoring the Beacon Node's Retry-After header if it sent one
+func (p RetryPolicy) getDelay(retry int, retryAfter string) time.Duration {
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		delay := time.Duration(seconds) * time.Second
+		if delay > p.MaxDelay {
+			return p.MaxDelay
+		}
+		return delay
+	}
+	delay := p.InitialDelay
+	for i := 1; i < retry; i++ {
+		delay *= 2
+		if delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	return delay
+}
+
+// The retry activity of a Beacon client since it was created
+type RetryMetrics struct {
+	// The number of GET requests sent, not counting retries
+	Requests uint64
+
+	// The number of retries for overloaded and timed out requests
+	OverloadedRetries uint64
+	TimeoutRetries    uint64
+
+	// The number of requests for resources that don't exist, such as missed slots, which were skipped without retrying
+	NotFoundSkips uint64
+
+	// The number of requests that still failed after any retries
+	Failures uint64
+
+	// The total time spent waiting between retries
+	RetryWait time.Duration
+}
+
+// Add another client's retry activity to this one
+func (m *RetryMetrics) Add(other RetryMetrics) {
+	m.Requests += other.Requests
+	m.OverloadedRetries += other.OverloadedRetries
+	m.TimeoutRetries += other.TimeoutRetries
+	m.NotFoundSkips += other.NotFoundSkips
+	m.Failures += other.Failures
+	m.RetryWait += other.RetryWait
+}
+
+// The counters behind RetryMetrics, which are updated concurrently
+type retryCounters struct {
+	requests          atomic.Uint64
+	overloadedRetries atomic.Uint64
+	timeoutRetries    atomic.Uint64
+	notFoundSkips     atomic.Uint64
+	failures          atomic.Uint64
+	retryWait         atomic.Int64
+}
+
+func (c *retryCounters) snapshot() RetryMetrics {
+	return RetryMetrics{
+		Requests:          c.requests.Load(),
+		OverloadedRetries: c.overloadedRetries.Load(),
+		TimeoutRetries:    c.timeoutRetries.Load(),
+		NotFoundSkips:     c.notFoundSkips.Load(),
+		Failures:          c.failures.Load(),
+		RetryWait:         time.Duration(c.retryWait.Load()),
+	}
+}
+
+// Record a retry of the given class and the time waited before it
+func (c *retryCounters) recordRetry(class ErrorClass, delay time.Duration) {
+	if class == ErrorClass_Timeout {
+		c.timeoutRetries.Add(1)
+	} else {
+		c.overloadedRetries.Add(1)
+	}
+	c.retryWait.Add(int64(delay))
+}
+
+// Record the final outcome of a request
+func (c *retryCounters) recordOutcome(class ErrorClass) {
+	switch class {
+	case ErrorClass_None:
+	case ErrorClass_NotFound:
+		c.notFoundSkips.Add(1)
+	default:
+		c.failures.Add(1)
+	}
+}
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts:        4,
+	MaxTimeoutAttempts: 2,
+	InitialDelay:       time.Millisecond,
+	MaxDelay:           4 * time.Millisecond,
+}
+
+// Serve the given statuses in order, then 200s; the node version isn't served, so the BN is treated as following the spec
+func newStatusServer(statuses []int) (*httptest.Server, *atomic.Int32) {
+	calls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == RequestNodeVersionPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		call := int(calls.Add(1)) - 1
+		if call < len(statuses) {
+			w.WriteHeader(statuses[call])
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	return server, calls
+}
+
+func TestRetryOverloaded(t *testing.T) {
+	server, calls := newStatusServer([]int{http.StatusServiceUnavailable, http.StatusTooManyRequests})
+	defer server.Close()
+	c := NewStandardHttpClient(server.URL)
+	c.SetRetryPolicy(testRetryPolicy)
+
+	_, status, err := c.getRequest("/test")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if status != http.StatusOK || calls.Load() != 3 {
+		t.Fatalf("expected a 200 after 3 calls, got %d after %d", status, calls.Load())
+	}
+	metrics := c.GetRetryMetrics()
+	if metrics.Requests != 1 || metrics.OverloadedRetries != 2 || metrics.Failures != 0 {
+		t.Fatalf("unexpected metrics: %+v", metrics)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	server, calls := newStatusServer([]int{http.StatusGatewayTimeout, http.StatusGatewayTimeout, http.StatusGatewayTimeout})
+	defer server.Close()
+	c := NewStandardHttpClient(server.URL)
+	c.SetRetryPolicy(testRetryPolicy)
+
+	_, status, _ := c.getRequest("/test")
+	if status != http.StatusGatewayTimeout || calls.Load() != int32(testRetryPolicy.MaxTimeoutAttempts) {
+		t.Fatalf("expected a 504 after %d calls, got %d after %d", testRetryPolicy.MaxTimeoutAttempts, status, calls.Load())
+	}
+	metrics := c.GetRetryMetrics()
+	if metrics.TimeoutRetries != 1 || metrics.Failures != 1 {
+		t.Fatalf("unexpected metrics: %+v", metrics)
+	}
+}
+
+func TestRetrySkipsNotFound(t *testing.T) {
+	server, calls := newStatusServer([]int{http.StatusNotFound})
+	defer server.Close()
+	c := NewStandardHttpClient(server.URL)
+	c.SetRetryPolicy(testRetryPolicy)
+
+	_, exists, err := c.GetBeaconBlock("100")
+	if err != nil || exists {
+		t.Fatalf("expected a missing block, got exists=%t, err=%v", exists, err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("missed slots shouldn't be retried, but got %d calls", calls.Load())
+	}
+	if metrics := c.GetRetryMetrics(); metrics.NotFoundSkips != 1 {
+		t.Fatalf("unexpected metrics: %+v", metrics)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	delays := []time.Duration{}
+	for retry := 1; retry <= 4; retry++ {
+		delays = append(delays, testRetryPolicy.getDelay(retry, ""))
+	}
+	expected := []time.Duration{time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond, 4 * time.Millisecond}
+	for i := range expected {
+		if delays[i] != expected[i] {
+			t.Fatalf("retry %d should wait %s, but waits %s", i+1, expected[i], delays[i])
+		}
+	}
+	if delay := DefaultRetryPolicy.getDelay(1, "3"); delay != 3*time.Second {
+		t.Fatalf("Retry-After should be honored, but waited %s", delay)
+	}
+}
+
+func TestBalancesSafeRetriesSyncStatus(t *testing.T) {
+	// Serve a synced BN whose sync status fails with an error the retry policy won't retry on the first call
+	genesisTime := time.Now().Add(-time.Hour).Unix()
+	syncCalls := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case RequestEth2ConfigPath:
+			fmt.Fprint(w, `{"data":{"SECONDS_PER_SLOT":"12","SLOTS_PER_EPOCH":"32","CAPELLA_FORK_VERSION":"0x03000000","EPOCHS_PER_SYNC_COMMITTEE_PERIOD":"256"}}`)
+		case RequestGenesisPath:
+			fmt.Fprintf(w, `{"data":{"genesis_time":"%d","genesis_fork_version":"0x00000000","genesis_validators_root":"0x00"}}`, genesisTime)
+		case RequestSyncStatusPath:
+			if syncCalls.Add(1) == 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			headSlot := (time.Now().Unix() - genesisTime) / 12
+			fmt.Fprintf(w, `{"data":{"head_slot":"%d","sync_distance":"0","is_syncing":false,"el_offline":false}}`, headSlot)
+		case fmt.Sprintf(RequestValidatorBalancesPath, "head"):
+			fmt.Fprint(w, `{"data":[{"index":"1","balance":"32000000000"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	eth2ConfigCache.Store(nil)
+	defer eth2ConfigCache.Store(nil)
+	c := NewStandardHttpClient(server.URL)
+	c.SetRetryPolicy(testRetryPolicy)
+
+	balances, err := c.GetValidatorBalancesSafe([]string{"1"}, nil)
+	if err != nil {
+		t.Fatalf("a failed sync status check should be retried, but got: %s", err.Error())
+	}
+	if syncCalls.Load() != 2 {
+		t.Fatalf("expected 2 sync status checks, got %d", syncCalls.Load())
+	}
+	if balance, exists := balances["1"]; !exists || balance.String() != "32000000000000000000" {
+		t.Fatalf("unexpected balances: %v", balances)
+	}
+}
@@ -31,6 +31,7 @@ const (
 	RequestUrlFormat   = "%s%s"
 	RequestContentType = "application/json"
 
+	RequestNodeVersionPath                 = "/eth/v1/node/version"
 	RequestSyncStatusPath                  = "/eth/v1/node/syncing"
 	RequestEth2ConfigPath                  = "/eth/v1/config/spec"
 	RequestEth2DepositContractMethod       = "/eth/v1/config/deposit_contract"
@@ -48,22 +49,55 @@ const (
 	RequestValidatorProposerDuties         = "/eth/v1/validator/duties/proposer/%s"
 	RequestWithdrawalCredentialsChangePath = "/eth/v1/beacon/pool/bls_to_execution_changes"
 
-	MaxRequestValidatorsCount     = 600
-	threadLimit               int = 12
+	MaxRequestValidatorsCount         = 600
+	MaxPostRequestValidatorsCount     = 10000
+	threadLimit                   int = 12
 )
 
 // Beacon client using the standard Beacon HTTP REST API (https://ethereum.github.io/beacon-APIs/)
 type StandardHttpClient struct {
 	providerAddress string
+	httpClient      *http.Client
+
+	// Set once the BN rejects the POST variants of the validator endpoints, so we stop trying them
+	postValidatorsUnsupported atomic.Bool
+
+	// How GET requests are retried, and what those retries have done so far
+	retryPolicy   RetryPolicy
+	retryCounters retryCounters
+
+	// The BN implementation, detected the first time its quirks are needed
+	implementation atomic.Pointer[beacon.ClientImplementation]
 }
 
 // Create a new client instance
 func NewStandardHttpClient(providerAddress string) *StandardHttpClient {
 	return &StandardHttpClient{
 		providerAddress: providerAddress,
+		httpClient:      http.DefaultClient,
+		retryPolicy:     DefaultRetryPolicy,
 	}
 }
 
+// Create a new client instance that sends its requests through the provided transport
+func NewStandardHttpClientWithTransport(providerAddress string, transport http.RoundTripper) *StandardHttpClient {
+	return &StandardHttpClient{
+		providerAddress: providerAddress,
+		httpClient:      &http.Client{Transport: transport},
+		retryPolicy:     DefaultRetryPolicy,
+	}
+}
+
+// Change how the client retries GET requests
+func (c *StandardHttpClient) SetRetryPolicy(policy RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+// Get the client's retry activity since it was created
+func (c *StandardHttpClient) GetRetryMetrics() RetryMetrics {
+	return c.retryCounters.snapshot()
+}
+
 // Close the client connection
 func (c *StandardHttpClient) Close() error {
 	return nil
@@ -83,8 +117,11 @@ func (c *StandardHttpClient) GetSyncStatus() (beacon.SyncStatus, error) {
 		return beacon.SyncStatus{}, err
 	}
 
-	// Calculate the progress
-	progress := float64(syncStatus.Data.HeadSlot) / float64(syncStatus.Data.HeadSlot+syncStatus.Data.SyncDistance)
+	// Calculate the progress; a node that's just started can report a head slot and sync distance of 0
+	progress := float64(0)
+	if syncStatus.Data.HeadSlot+syncStatus.Data.SyncDistance > 0 {
+		progress = float64(syncStatus.Data.HeadSlot) / float64(syncStatus.Data.HeadSlot+syncStatus.Data.SyncDistance)
+	}
 
 	// Return response
 	return beacon.SyncStatus{
@@ -274,9 +311,10 @@ func (c *StandardHttpClient) GetValidatorBalances(indices []string, opts *beacon
 
 	count := len(indices)
 	data := make(map[string]*big.Int, count)
-	for i := 0; i < count; i += MaxRequestValidatorsCount {
+	batchSize := c.getValidatorBatchSize()
+	for i := 0; i < count; i += batchSize {
 		i := i
-		max := i + MaxRequestValidatorsCount
+		max := i + batchSize
 		if max > count {
 			max = count
 		}
@@ -435,6 +473,110 @@ func (c *StandardHttpClient) GetValidatorStatuses(pubkeys []types.ValidatorPubke
 
 }
 
+// Get multiple validators' statuses by their indices, which the BN can look up faster than pubkeys; validators that
+// don't exist are omitted
+func (c *StandardHttpClient) GetValidatorStatusesByIndex(indices []string, opts *beacon.ValidatorStatusOptions) (map[string]beacon.ValidatorStatus, error) {
+
+	// Get validators
+	statuses := make(map[string]beacon.ValidatorStatus, len(indices))
+	if len(indices) == 0 {
+		return statuses, nil
+	}
+	validators, err := c.getValidatorsByOpts(indices, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build validator status map
+	for _, validator := range validators.Data {
+		statuses[validator.Index] = beacon.ValidatorStatus{
+			Pubkey:                     types.BytesToValidatorPubkey(validator.Validator.Pubkey),
+			Index:                      validator.Index,
+			WithdrawalCredentials:      common.BytesToHash(validator.Validator.WithdrawalCredentials),
+			Balance:                    uint64(validator.Balance),
+			EffectiveBalance:           uint64(validator.Validator.EffectiveBalance),
+			Status:                     beacon.ValidatorState(validator.Status),
+			Slashed:                    validator.Validator.Slashed,
+			ActivationEligibilityEpoch: uint64(validator.Validator.ActivationEligibilityEpoch),
+			ActivationEpoch:            uint64(validator.Validator.ActivationEpoch),
+			ExitEpoch:                  uint64(validator.Validator.ExitEpoch),
+			WithdrawableEpoch:          uint64(validator.Validator.WithdrawableEpoch),
+			Exists:                     true,
+		}
+	}
+
+	// Return
+	return statuses, nil
+
+}
+
+// Get the statuses of every validator in one of the provided states. This downloads every matching validator, so it can
+// be slow for states that cover most of the Beacon Chain (such as the active ones).
+func (c *StandardHttpClient) GetValidatorStatusesByState(states []beacon.ValidatorState, opts *beacon.ValidatorStatusOptions) ([]beacon.ValidatorStatus, error) {
+
+	// Get state ID
+	var stateId string
+	if opts == nil {
+		stateId = "head"
+	} else if opts.Slot != nil {
+		stateId = strconv.FormatInt(int64(*opts.Slot), 10)
+	} else if opts.Epoch != nil {
+
+		// Get eth2 config
+		eth2Config, err := c.getEth2Config()
+		if err != nil {
+			return nil, err
+		}
+
+		// Get slot number
+		slot := *opts.Epoch * uint64(eth2Config.Data.SlotsPerEpoch)
+		stateId = strconv.FormatInt(int64(slot), 10)
+
+	} else {
+		return nil, fmt.Errorf("must specify a slot or epoch when calling GetValidatorStatusesByState")
+	}
+
+	// Get validators
+	stateNames := make([]string, len(states))
+	for i, state := range states {
+		stateNames[i] = string(state)
+	}
+	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestValidatorsPath, stateId) + "?status=" + strings.Join(stateNames, ","))
+	if err != nil {
+		return nil, fmt.Errorf("Could not get validators: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("Could not get validators: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var validators ValidatorsResponse
+	if err := json.Unmarshal(responseBody, &validators); err != nil {
+		return nil, fmt.Errorf("Could not decode validators: %w", err)
+	}
+
+	// Build validator status list
+	statuses := make([]beacon.ValidatorStatus, 0, len(validators.Data))
+	for _, validator := range validators.Data {
+		statuses = append(statuses, beacon.ValidatorStatus{
+			Pubkey:                     types.BytesToValidatorPubkey(validator.Validator.Pubkey),
+			Index:                      validator.Index,
+			WithdrawalCredentials:      common.BytesToHash(validator.Validator.WithdrawalCredentials),
+			Balance:                    uint64(validator.Balance),
+			EffectiveBalance:           uint64(validator.Validator.EffectiveBalance),
+			Status:                     beacon.ValidatorState(validator.Status),
+			Slashed:                    validator.Validator.Slashed,
+			ActivationEligibilityEpoch: uint64(validator.Validator.ActivationEligibilityEpoch),
+			ActivationEpoch:            uint64(validator.Validator.ActivationEpoch),
+			ExitEpoch:                  uint64(validator.Validator.ExitEpoch),
+			WithdrawableEpoch:          uint64(validator.Validator.WithdrawableEpoch),
+			Exists:                     true,
+		})
+	}
+
+	// Return
+	return statuses, nil
+
+}
+
 // Get whether validators have sync duties to perform at given epoch
 func (c *StandardHttpClient) GetValidatorSyncDuties(indices []string, epoch uint64) (map[string]bool, error) {
 	// Return if there are not validators to check
@@ -507,6 +649,38 @@ func (c *StandardHttpClient) GetValidatorProposerDuties(indices []string, epoch
 	return proposerMap, nil
 }
 
+// Get the slots the provided validators are assigned to propose in the given epoch; validators without any are omitted
+func (c *StandardHttpClient) GetValidatorProposerSlots(indices []string, epoch uint64) (map[string][]uint64, error) {
+
+	// Perform the request
+	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestValidatorProposerDuties, strconv.FormatUint(epoch, 10)))
+	if err != nil {
+		return nil, fmt.Errorf("Could not get validator proposer duties: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("Could not get validator proposer duties: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+
+	var response ProposerDutiesResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("Could not decode validator proposer duties data: %w", err)
+	}
+
+	// Map the results
+	wanted := make(map[string]bool, len(indices))
+	for _, index := range indices {
+		wanted[index] = true
+	}
+	slotMap := make(map[string][]uint64)
+	for _, duty := range response.Data {
+		if wanted[duty.ValidatorIndex] {
+			slotMap[duty.ValidatorIndex] = append(slotMap[duty.ValidatorIndex], uint64(duty.Slot))
+		}
+	}
+
+	return slotMap, nil
+}
+
 // Get a validator's index
 func (c *StandardHttpClient) GetValidatorIndex(pubkey types.ValidatorPubkey) (string, error) {
 
@@ -664,9 +838,13 @@ func (c *StandardHttpClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock,
 		beaconBlock.Attestations = append(beaconBlock.Attestations, info)
 	}
 
-	// Add withdrawals
-	beaconBlock.Withdrawals = make([]beacon.WithdrawalInfo, 0, len(block.Data.Message.Body.ExecutionPayload.Withdrawals))
-	for _, withdrawal := range block.Data.Message.Body.ExecutionPayload.Withdrawals {
+	// Add withdrawals, which are part of the execution payload
+	withdrawals := []Withdrawal{}
+	if block.Data.Message.Body.ExecutionPayload != nil {
+		withdrawals = block.Data.Message.Body.ExecutionPayload.Withdrawals
+	}
+	beaconBlock.Withdrawals = make([]beacon.WithdrawalInfo, 0, len(withdrawals))
+	for _, withdrawal := range withdrawals {
 		amount, ok := new(big.Int).SetString(withdrawal.Amount, 10)
 		if !ok {
 			return beacon.BeaconBlock{}, false, fmt.Errorf("Error decoding withdrawal amount for withdrawal for address %s of block %s: %s", withdrawal.Address, blockId, withdrawal.Amount)
@@ -817,8 +995,55 @@ func (c *StandardHttpClient) getFork(stateId string) (ForkResponse, error) {
 	return fork, nil
 }
 
-// Get validator balances
+// Get validator balances, preferring a single POST over chunked GETs when the BN supports it
 func (c *StandardHttpClient) getValidatorBalances(stateId string, indices []string) (ValidatorBalancesResponse, error) {
+	if len(indices) > 0 && !c.postValidatorsUnsupported.Load() {
+		responseBody, status, err := c.postRequest(fmt.Sprintf(RequestValidatorBalancesPath, stateId), indices)
+		if err != nil {
+			return ValidatorBalancesResponse{}, fmt.Errorf("Could not get validator balances: %w", err)
+		}
+		if !isPostValidatorsSupported(status) {
+			// Only stop using POST if the GET version works, since a 404 can also mean the state isn't available
+			response, err := c.getValidatorBalancesChunked(stateId, indices)
+			if err == nil {
+				c.postValidatorsUnsupported.Store(true)
+			}
+			return response, err
+		}
+		if status != http.StatusOK {
+			return ValidatorBalancesResponse{}, fmt.Errorf("Could not get validator balances: HTTP status %d; response body: '%s'", status, string(responseBody))
+		}
+		var balances ValidatorBalancesResponse
+		if err := json.Unmarshal(responseBody, &balances); err != nil {
+			return ValidatorBalancesResponse{}, fmt.Errorf("Could not decode validator balances: %w", err)
+		}
+		return balances, nil
+	}
+	return c.getValidatorBalancesChunked(stateId, indices)
+}
+
+// Get validator balances with GET requests, splitting the indices so the query string stays within the BN's limits
+func (c *StandardHttpClient) getValidatorBalancesChunked(stateId string, indices []string) (ValidatorBalancesResponse, error) {
+	if len(indices) <= MaxRequestValidatorsCount {
+		return c.getValidatorBalancesGet(stateId, indices)
+	}
+	balances := ValidatorBalancesResponse{}
+	for i := 0; i < len(indices); i += MaxRequestValidatorsCount {
+		max := i + MaxRequestValidatorsCount
+		if max > len(indices) {
+			max = len(indices)
+		}
+		chunk, err := c.getValidatorBalancesGet(stateId, indices[i:max])
+		if err != nil {
+			return ValidatorBalancesResponse{}, err
+		}
+		balances.Data = append(balances.Data, chunk.Data...)
+	}
+	return balances, nil
+}
+
+// Get validator balances with a single GET request
+func (c *StandardHttpClient) getValidatorBalancesGet(stateId string, indices []string) (ValidatorBalancesResponse, error) {
 	var query string
 	if len(indices) > 0 {
 		query = fmt.Sprintf("?id=%s", strings.Join(indices, ","))
@@ -837,8 +1062,55 @@ func (c *StandardHttpClient) getValidatorBalances(stateId string, indices []stri
 	return balances, nil
 }
 
-// Get validators
+// Get validators, preferring a single POST over chunked GETs when the BN supports it
 func (c *StandardHttpClient) getValidators(stateId string, pubkeys []string) (ValidatorsResponse, error) {
+	if len(pubkeys) > 0 && !c.postValidatorsUnsupported.Load() {
+		responseBody, status, err := c.postRequest(fmt.Sprintf(RequestValidatorsPath, stateId), ValidatorsRequest{Ids: pubkeys})
+		if err != nil {
+			return ValidatorsResponse{}, fmt.Errorf("Could not get validators: %w", err)
+		}
+		if !isPostValidatorsSupported(status) {
+			// Only stop using POST if the GET version works, since a 404 can also mean the state isn't available
+			response, err := c.getValidatorsChunked(stateId, pubkeys)
+			if err == nil {
+				c.postValidatorsUnsupported.Store(true)
+			}
+			return response, err
+		}
+		if status != http.StatusOK {
+			return ValidatorsResponse{}, fmt.Errorf("Could not get validators: HTTP status %d; response body: '%s'", status, string(responseBody))
+		}
+		var validators ValidatorsResponse
+		if err := json.Unmarshal(responseBody, &validators); err != nil {
+			return ValidatorsResponse{}, fmt.Errorf("Could not decode validators: %w", err)
+		}
+		return validators, nil
+	}
+	return c.getValidatorsChunked(stateId, pubkeys)
+}
+
+// Get validators with GET requests, splitting the IDs so the query string stays within the BN's limits
+func (c *StandardHttpClient) getValidatorsChunked(stateId string, pubkeys []string) (ValidatorsResponse, error) {
+	if len(pubkeys) <= MaxRequestValidatorsCount {
+		return c.getValidatorsGet(stateId, pubkeys)
+	}
+	validators := ValidatorsResponse{}
+	for i := 0; i < len(pubkeys); i += MaxRequestValidatorsCount {
+		max := i + MaxRequestValidatorsCount
+		if max > len(pubkeys) {
+			max = len(pubkeys)
+		}
+		chunk, err := c.getValidatorsGet(stateId, pubkeys[i:max])
+		if err != nil {
+			return ValidatorsResponse{}, err
+		}
+		validators.Data = append(validators.Data, chunk.Data...)
+	}
+	return validators, nil
+}
+
+// Get validators with a single GET request
+func (c *StandardHttpClient) getValidatorsGet(stateId string, pubkeys []string) (ValidatorsResponse, error) {
 	var query string
 	if len(pubkeys) > 0 {
 		query = fmt.Sprintf("?id=%s", strings.Join(pubkeys, ","))
@@ -887,9 +1159,10 @@ func (c *StandardHttpClient) getValidatorsByOpts(pubkeysOrIndices []string, opts
 	validFlags := make([]bool, count)
 	var wg errgroup.Group
 	wg.SetLimit(threadLimit)
-	for i := 0; i < count; i += MaxRequestValidatorsCount {
+	batchSize := c.getValidatorBatchSize()
+	for i := 0; i < count; i += batchSize {
 		i := i
-		max := i + MaxRequestValidatorsCount
+		max := i + batchSize
 		if max > count {
 			max = count
 		}
@@ -924,6 +1197,23 @@ func (c *StandardHttpClient) getValidatorsByOpts(pubkeysOrIndices []string, opts
 	return ValidatorsResponse{Data: trueData}, nil
 }
 
+// Get the number of validators to request at once, which depends on whether the BN accepts POSTed ID lists
+func (c *StandardHttpClient) getValidatorBatchSize() int {
+	if c.postValidatorsUnsupported.Load() {
+		return MaxRequestValidatorsCount
+	}
+	return MaxPostRequestValidatorsCount
+}
+
+// Check whether the status of a POST to one of the validator endpoints indicates the BN may not support the POST variant
+func isPostValidatorsSupported(status int) bool {
+	switch status {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusUnsupportedMediaType, http.StatusNotImplemented:
+		return false
+	}
+	return true
+}
+
 // Send voluntary exit request
 func (c *StandardHttpClient) postVoluntaryExit(request VoluntaryExitRequest) error {
 	responseBody, status, err := c.postRequest(RequestVoluntaryExitPath, request)
@@ -942,7 +1232,7 @@ func (c *StandardHttpClient) getAttestations(blockId string) (AttestationsRespon
 	if err != nil {
 		return AttestationsResponse{}, false, fmt.Errorf("Could not get attestations data for slot %s: %w", blockId, err)
 	}
-	if status == http.StatusNotFound {
+	if c.isMissingBlock(status, responseBody) {
 		return AttestationsResponse{}, false, nil
 	}
 	if status != http.StatusOK {
@@ -961,7 +1251,7 @@ func (c *StandardHttpClient) getBeaconBlock(blockId string) (BeaconBlockResponse
 	if err != nil {
 		return BeaconBlockResponse{}, false, fmt.Errorf("Could not get beacon block data: %w", err)
 	}
-	if status == http.StatusNotFound {
+	if c.isMissingBlock(status, responseBody) {
 		return BeaconBlockResponse{}, false, nil
 	}
 	if status != http.StatusOK {
@@ -980,7 +1270,7 @@ func (c *StandardHttpClient) getBeaconBlockHeader(blockId string) (BeaconBlockHe
 	if err != nil {
 		return BeaconBlockHeaderResponse{}, false, fmt.Errorf("could not get beacon block header data: %w", err)
 	}
-	if status == http.StatusNotFound {
+	if c.isMissingBlock(status, responseBody) {
 		return BeaconBlockHeaderResponse{}, false, nil
 	}
 	if status != http.StatusOK {
@@ -1076,16 +1366,50 @@ func (c *StandardHttpClient) postWithdrawalCredentialsChange(request BLSToExecut
 	return nil
 }
 
-// Make a GET request but do not read its body yet (allows buffered decoding)
+// Make a GET request but do not read its body yet (allows buffered decoding).
+// Requests that fail because the BN is overloaded or slow are retried with backoff according to the retry policy;
+// anything else, including 404s for missed slots and BNs that refuse requests while syncing, is returned right away.
 func (c *StandardHttpClient) getRequestReader(requestPath string) (io.ReadCloser, int, error) {
 
-	// Send request
-	response, err := http.Get(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath))
-	if err != nil {
-		return nil, 0, err
-	}
+	c.retryCounters.requests.Add(1)
+	for attempt := 1; ; attempt++ {
+		// Send request
+		response, err := c.httpClient.Get(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath))
+		status := 0
+		retryAfter := ""
+		if err == nil {
+			status = response.StatusCode
+			retryAfter = response.Header.Get("Retry-After")
+		}
 
-	return response.Body, response.StatusCode, nil
+		// Return anything that shouldn't be retried, or has run out of attempts
+		class := ClassifyError(err, status)
+		if class == ErrorClass_Overloaded {
+			var body []byte
+			body, err = io.ReadAll(response.Body)
+			_ = response.Body.Close()
+			response.Body = io.NopCloser(bytes.NewReader(body))
+			if err == nil && c.isSyncing(status, body) {
+				class = ErrorClass_Permanent
+			}
+		}
+		if attempt >= c.retryPolicy.getMaxAttempts(class) {
+			c.retryCounters.recordOutcome(class)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Body, response.StatusCode, nil
+		}
+
+		// Discard the failed response and wait before trying again
+		if response != nil {
+			_, _ = io.Copy(io.Discard, response.Body)
+			_ = response.Body.Close()
+		}
+		delay := c.retryPolicy.getDelay(attempt, retryAfter)
+		c.retryCounters.recordRetry(class, delay)
+		time.Sleep(delay)
+	}
 }
 
 // Make a GET request to the beacon node and read the body of the response
@@ -1121,7 +1445,7 @@ func (c *StandardHttpClient) postRequest(requestPath string, requestBody interfa
 	requestBodyReader := bytes.NewReader(requestBodyBytes)
 
 	// Send request
-	response, err := http.Post(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), RequestContentType, requestBodyReader)
+	response, err := c.httpClient.Post(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), RequestContentType, requestBodyReader)
 	if err != nil {
 		return []byte{}, 0, err
 	}
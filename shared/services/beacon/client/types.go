@@ -28,8 +28,16 @@ type BLSToExecutionChangeRequest struct {
 	Message   BLSToExecutionChangeMessage `json:"message"`
 	Signature byteArray                   `json:"signature"`
 }
+type ValidatorsRequest struct {
+	Ids []string `json:"ids"`
+}
 
 // Response types
+type NodeVersionResponse struct {
+	Data struct {
+		Version string `json:"version"`
+	} `json:"data"`
+}
 type SyncStatusResponse struct {
 	Data struct {
 		HeadSlot     uinteger `json:"head_slot"`
@@ -153,7 +161,8 @@ type ProposerDutiesResponse struct {
 	Data []ProposerDuty `json:"data"`
 }
 type ProposerDuty struct {
-	ValidatorIndex string `json:"validator_index"`
+	ValidatorIndex string   `json:"validator_index"`
+	Slot           uinteger `json:"slot"`
 }
 
 type CommitteesResponse struct {
@@ -0,0 +1,30 @@
+package beacon
+
+import "strings"
+
+// The Beacon Node implementation behind a client, as reported by its node version endpoint
+type ClientImplementation string
+
+const (
+	ClientImplementation_Unknown    ClientImplementation = "unknown"
+	ClientImplementation_Lighthouse ClientImplementation = "lighthouse"
+	ClientImplementation_Lodestar   ClientImplementation = "lodestar"
+	ClientImplementation_Nimbus     ClientImplementation = "nimbus"
+	ClientImplementation_Prysm      ClientImplementation = "prysm"
+	ClientImplementation_Teku       ClientImplementation = "teku"
+)
+
+// Identify the implementation from a node version string, such as "Lighthouse/v4.5.0-441fc16/x86_64-linux"
+func ParseClientImplementation(version string) ClientImplementation {
+	name, _, _ := strings.Cut(strings.TrimSpace(version), "/")
+	switch implementation := ClientImplementation(strings.ToLower(name)); implementation {
+	case ClientImplementation_Lighthouse,
+		ClientImplementation_Lodestar,
+		ClientImplementation_Nimbus,
+		ClientImplementation_Prysm,
+		ClientImplementation_Teku:
+		return implementation
+	default:
+		return ClientImplementation_Unknown
+	}
+}
@@ -0,0 +1,441 @@
+package cacheproxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How often to check the chain heads for changes
+const headPollInterval time.Duration = 2 * time.Second
+
+// The EC methods whose responses can be cached, and the position of their block parameter (-1 if they don't have one)
+var cacheableEcMethods = map[string]int{
+	"eth_chainId":               -1,
+	"eth_blockNumber":           -1,
+	"eth_getBlockByNumber":      0,
+	"eth_getBlockByHash":        0,
+	"eth_getHeaderByNumber":     0,
+	"eth_getHeaderByHash":       0,
+	"eth_call":                  1,
+	"eth_getBalance":            1,
+	"eth_getCode":               1,
+	"eth_getStorageAt":          2,
+	"eth_getTransactionCount":   1,
+	"eth_getTransactionReceipt": -1,
+	"eth_getLogs":               -1,
+}
+
+// Returned by fetches whose response should be passed to the caller but not cached
+var errUncacheable = errors.New("response can't be cached")
+
+// BN paths that must always be passed straight through
+var uncacheableBcPrefixes = []string{
+	"/eth/v1/events",
+	"/eth/v1/node/",
+}
+
+// Deduplicates and caches identical requests to an Execution client and a Beacon Node
+type Proxy struct {
+	ecUrl      string
+	bcUrl      string
+	httpClient *http.Client
+	cache      *cache
+	group      singleflight.Group
+	log        *log.ColorLogger
+}
+
+// A cached EC result or BN response
+type response struct {
+	status      int
+	contentType string
+	body        []byte
+}
+
+// A JSON-RPC request or response
+type rpcMessage struct {
+	JsonRpc string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   json.RawMessage `json:"error,omitempty"`
+}
+
+// Create a proxy for the provided Execution client and Beacon Node, caching up to maxEntries responses
+func NewProxy(ecUrl string, bcUrl string, maxEntries int, logger *log.ColorLogger) *Proxy {
+	return &Proxy{
+		ecUrl:      strings.TrimSuffix(ecUrl, "/"),
+		bcUrl:      strings.TrimSuffix(bcUrl, "/"),
+		httpClient: &http.Client{},
+		cache:      newCache(maxEntries),
+		log:        logger,
+	}
+}
+
+// Get the handler that serves the EC on the EC path and the BN under the BN path
+func (p *Proxy) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(config.CachingProxyEcPath, p.serveEc)
+	mux.HandleFunc(config.CachingProxyBcPath+"/", p.serveBc)
+	return mux
+}
+
+// Watch the chain heads until the context is cancelled, dropping the cached responses that depend on them whenever they move
+func (p *Proxy) WatchHeads(ctx context.Context) {
+	ticker := time.NewTicker(headPollInterval)
+	defer ticker.Stop()
+
+	lastHead := ""
+	for {
+		head, err := p.getHeads(ctx)
+		if err != nil {
+			p.log.Printlnf("WARNING: couldn't check the chain heads: %s", err.Error())
+		} else if head != lastHead {
+			p.cache.dropHeadDependent()
+			lastHead = head
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Get the number of cache hits and misses so far
+func (p *Proxy) GetStats() (uint64, uint64) {
+	p.cache.lock.Lock()
+	defer p.cache.lock.Unlock()
+	return p.cache.hits, p.cache.misses
+}
+
+// Get the EC's latest block number and the BN's head root, combined into one string
+func (p *Proxy) getHeads(ctx context.Context) (string, error) {
+	request := []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`)
+	ecHead, err := p.send(ctx, http.MethodPost, p.ecUrl, "application/json", request)
+	if err != nil {
+		return "", fmt.Errorf("error getting the EC head: %w", err)
+	}
+	bcHead, err := p.send(ctx, http.MethodGet, p.bcUrl+"/eth/v1/beacon/headers/head", "", nil)
+	if err != nil {
+		return "", fmt.Errorf("error getting the BN head: %w", err)
+	}
+
+	// Only the head root matters from the BN response
+	header := struct {
+		Data struct {
+			Root string `json:"root"`
+		} `json:"data"`
+	}{}
+	if err := json.Unmarshal(bcHead.body, &header); err != nil {
+		return "", fmt.Errorf("error decoding the BN head: %w", err)
+	}
+	return string(ecHead.body) + header.Data.Root, nil
+}
+
+// Serve an EC request, answering single cacheable JSON-RPC calls from the cache
+func (p *Proxy) serveEc(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Pass batches and anything that can't be cached straight through
+	request := rpcMessage{}
+	if err := json.Unmarshal(body, &request); err != nil {
+		p.forward(w, r, p.ecUrl, body)
+		return
+	}
+	immutable, cacheable := getEcCacheability(request)
+	if !cacheable {
+		p.forward(w, r, p.ecUrl, body)
+		return
+	}
+
+	// Get the result, sharing it with any identical requests in flight
+	key := "ec:" + request.Method + ":" + string(request.Params)
+	result, err := p.getOrFetch(key, immutable, func() (*response, error) {
+		upstream, err := p.send(context.Background(), http.MethodPost, p.ecUrl, "application/json", body)
+		if err != nil {
+			return nil, err
+		}
+		reply := rpcMessage{}
+		if upstream.status != http.StatusOK || json.Unmarshal(upstream.body, &reply) != nil || reply.Error != nil {
+			// Return errors to the caller without caching them
+			return upstream, errUncacheable
+		}
+		return &response{status: http.StatusOK, contentType: "application/json", body: reply.Result}, nil
+	})
+	if errors.Is(err, errUncacheable) {
+		writeResponse(w, result)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	// Answer with the caller's own request ID
+	reply, err := json.Marshal(rpcMessage{
+		JsonRpc: "2.0",
+		Id:      request.Id,
+		Result:  result.body,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeResponse(w, &response{status: http.StatusOK, contentType: "application/json", body: reply})
+}
+
+// Serve a BN request, answering GET requests from the cache
+func (p *Proxy) serveBc(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, config.CachingProxyBcPath)
+	upstreamUrl := p.bcUrl + path
+	if r.URL.RawQuery != "" {
+		upstreamUrl += "?" + r.URL.RawQuery
+	}
+
+	// Pass anything that can't be cached straight through
+	immutable, cacheable := getBcCacheability(r.Method, path)
+	if !cacheable {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		p.forward(w, r, upstreamUrl, body)
+		return
+	}
+
+	// Get the response, sharing it with any identical requests in flight
+	key := "bc:" + path + "?" + r.URL.RawQuery
+	result, err := p.getOrFetch(key, immutable, func() (*response, error) {
+		upstream, err := p.send(context.Background(), http.MethodGet, upstreamUrl, "", nil)
+		if err != nil {
+			return nil, err
+		}
+		if upstream.status != http.StatusOK {
+			return upstream, errUncacheable
+		}
+		return upstream, nil
+	})
+	if err != nil && !errors.Is(err, errUncacheable) {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	writeResponse(w, result)
+}
+
+// Get a response from the cache, or fetch it once for every caller waiting on the same key.
+// Fetches don't use the caller's context, since other callers may be waiting on them.
+func (p *Proxy) getOrFetch(key string, immutable bool, fetch func() (*response, error)) (*response, error) {
+	if cached := p.cache.get(key); cached != nil {
+		return cached, nil
+	}
+	result, err, _ := p.group.Do(key, func() (interface{}, error) {
+		result, err := fetch()
+		if err == nil {
+			p.cache.put(key, result, immutable)
+		}
+		return result, err
+	})
+	if result == nil {
+		return nil, err
+	}
+	return result.(*response), err
+}
+
+// Forward a request as-is
+func (p *Proxy) forward(w http.ResponseWriter, r *http.Request, url string, body []byte) {
+	result, err := p.send(r.Context(), r.Method, url, r.Header.Get("Content-Type"), body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	writeResponse(w, result)
+}
+
+// Send a request upstream and read the response
+func (p *Proxy) send(ctx context.Context, method string, url string, contentType string, body []byte) (*response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	upstream, err := p.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer upstream.Body.Close()
+	responseBody, err := io.ReadAll(upstream.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &response{
+		status:      upstream.StatusCode,
+		contentType: upstream.Header.Get("Content-Type"),
+		body:        responseBody,
+	}, nil
+}
+
+// Write a response to the caller
+func writeResponse(w http.ResponseWriter, result *response) {
+	if result.contentType != "" {
+		w.Header().Set("Content-Type", result.contentType)
+	}
+	w.WriteHeader(result.status)
+	_, _ = w.Write(result.body)
+}
+
+// Check if an EC request can be cached, and if so, whether it refers to a specific block by hash and will never change
+func getEcCacheability(request rpcMessage) (bool, bool) {
+	blockParam, exists := cacheableEcMethods[request.Method]
+	if !exists {
+		return false, false
+	}
+	if request.Method == "eth_chainId" {
+		return true, true
+	}
+	if request.Method == "eth_getBlockByHash" || request.Method == "eth_getHeaderByHash" {
+		return true, true
+	}
+	if blockParam < 0 {
+		return false, true
+	}
+
+	params := []json.RawMessage{}
+	if err := json.Unmarshal(request.Params, &params); err != nil {
+		return false, false
+	}
+	if blockParam >= len(params) {
+		// The block defaults to latest
+		return false, true
+	}
+	block := strings.Trim(string(params[blockParam]), `"`)
+	if block == "pending" {
+		return false, false
+	}
+
+	// Block hashes are either given directly or as an EIP-1898 object
+	if strings.Contains(block, "blockHash") || (strings.HasPrefix(block, "0x") && len(block) == 66) {
+		return true, true
+	}
+	return false, true
+}
+
+// Check if a BN request can be cached, and if so, whether it refers to a specific root and will never change
+func getBcCacheability(method string, path string) (bool, bool) {
+	if method != http.MethodGet {
+		return false, false
+	}
+	for _, prefix := range uncacheableBcPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return false, false
+		}
+	}
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "0x") && len(segment) == 66 {
+			return true, true
+		}
+	}
+	return false, true
+}
+
+// The cached responses
+type cache struct {
+	lock       sync.Mutex
+	maxEntries int
+	entries    map[string]*cacheEntry
+	order      []string
+	hits       uint64
+	misses     uint64
+}
+
+// A cached response, and whether it stays valid when the head moves
+type cacheEntry struct {
+	response  *response
+	immutable bool
+}
+
+// Create a cache that holds up to maxEntries responses
+func newCache(maxEntries int) *cache {
+	return &cache{
+		maxEntries: maxEntries,
+		entries:    map[string]*cacheEntry{},
+		order:      []string{},
+	}
+}
+
+// Get a cached response, or nil if there isn't one
+func (c *cache) get(key string) *response {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, exists := c.entries[key]
+	if !exists {
+		c.misses++
+		return nil
+	}
+	c.hits++
+	return entry.response
+}
+
+// Cache a response, evicting the oldest ones if the cache is full
+func (c *cache) put(key string, result *response, immutable bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, exists := c.entries[key]; !exists {
+		c.order = append(c.order, key)
+	}
+	c.entries[key] = &cacheEntry{
+		response:  result,
+		immutable: immutable,
+	}
+	for len(c.entries) > c.maxEntries && len(c.order) > 0 {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+}
+
+// Drop every response that depends on the chain head
+func (c *cache) dropHeadDependent() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	order := make([]string, 0, len(c.order))
+	for _, key := range c.order {
+		entry, exists := c.entries[key]
+		if !exists {
+			continue
+		}
+		if !entry.immutable {
+			delete(c.entries, key)
+			continue
+		}
+		order = append(order, key)
+	}
+	c.order = order
+}
@@ -0,0 +1,88 @@
+package cacheproxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/fatih/color"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+func TestCacheProxy(t *testing.T) {
+	var ecRequests atomic.Int32
+	var bcRequests atomic.Int32
+	ec := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ecRequests.Add(1)
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":7,"result":"0x10"}`))
+	}))
+	defer ec.Close()
+	bc := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bcRequests.Add(1)
+		_, _ = w.Write([]byte(`{"data":{"root":"0x01"}}`))
+	}))
+	defer bc.Close()
+
+	logger := log.NewColorLogger(color.FgWhite)
+	proxy := NewProxy(ec.URL, bc.URL, 100, &logger)
+	server := httptest.NewServer(proxy.Handler())
+	defer server.Close()
+
+	post := func(body string) string {
+		response, err := http.Post(server.URL+"/ec", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		bytes, _ := io.ReadAll(response.Body)
+		return string(bytes)
+	}
+	get := func(path string) {
+		response, err := http.Get(server.URL + "/bc" + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = response.Body.Close()
+	}
+
+	// Identical calls are only sent once, and each caller gets its own ID back
+	post(`{"jsonrpc":"2.0","id":1,"method":"eth_getBalance","params":["0x1","latest"]}`)
+	reply := post(`{"jsonrpc":"2.0","id":2,"method":"eth_getBalance","params":["0x1","latest"]}`)
+	if ecRequests.Load() != 1 {
+		t.Fatalf("expected 1 EC request, got %d", ecRequests.Load())
+	}
+	if reply != `{"jsonrpc":"2.0","id":2,"result":"0x10"}` {
+		t.Fatalf("unexpected reply %s", reply)
+	}
+
+	// Pending state and uncacheable methods always go through
+	post(`{"jsonrpc":"2.0","id":3,"method":"eth_getBalance","params":["0x1","pending"]}`)
+	post(`{"jsonrpc":"2.0","id":4,"method":"eth_sendRawTransaction","params":["0x00"]}`)
+	post(`{"jsonrpc":"2.0","id":4,"method":"eth_sendRawTransaction","params":["0x00"]}`)
+	if ecRequests.Load() != 4 {
+		t.Fatalf("expected 4 EC requests, got %d", ecRequests.Load())
+	}
+
+	// BN requests are cached too, but never node requests
+	get("/eth/v1/beacon/states/head/finality_checkpoints")
+	get("/eth/v1/beacon/states/head/finality_checkpoints")
+	get("/eth/v1/node/syncing")
+	get("/eth/v1/node/syncing")
+	if bcRequests.Load() != 3 {
+		t.Fatalf("expected 3 BN requests, got %d", bcRequests.Load())
+	}
+
+	// Moving the head drops everything but the responses for specific hashes and roots
+	blockHash := "0x" + strings.Repeat("ab", 32)
+	post(`{"jsonrpc":"2.0","id":5,"method":"eth_getBalance","params":["0x1","` + blockHash + `"]}`)
+	proxy.cache.dropHeadDependent()
+	post(`{"jsonrpc":"2.0","id":6,"method":"eth_getBalance","params":["0x1","` + blockHash + `"]}`)
+	post(`{"jsonrpc":"2.0","id":7,"method":"eth_getBalance","params":["0x1","latest"]}`)
+	if ecRequests.Load() != 6 {
+		t.Fatalf("expected 6 EC requests, got %d", ecRequests.Load())
+	}
+}
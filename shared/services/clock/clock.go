@@ -0,0 +1,169 @@
+package clock
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	// The size of an NTP packet without extensions
+	ntpPacketSize int = 48
+
+	// The number of seconds between the NTP epoch (1900) and the Unix epoch (1970)
+	ntpEpochOffset uint64 = 2208988800
+
+	// Leap indicator 0, version 4, client mode
+	ntpClientHeader byte = 0x23
+
+	ntpModeServer   byte   = 4
+	ntpMaxStratum   byte   = 15
+	ntpDefaultPort  string = "123"
+	originateOffset int    = 24
+	receiveOffset   int    = 32
+	transmitOffset  int    = 40
+)
+
+// The result of a clock drift check
+type Status struct {
+	// The NTP server that answered
+	Server string
+
+	// How far the local clock is from the server's; positive if the local clock is behind
+	Offset time.Duration
+
+	// True if the offset is beyond the threshold
+	Drifting bool
+
+	// True if Drifting is different from the previous check
+	Changed bool
+}
+
+// Watches the host clock for drift against a set of NTP servers
+type Monitor struct {
+	servers   []string
+	threshold time.Duration
+	timeout   time.Duration
+	drifting  bool
+}
+
+// Create a monitor that considers the clock drifting when it's more than threshold away from the first NTP server that
+// answers; a threshold of 0 disables it
+func NewMonitor(servers []string, threshold time.Duration, timeout time.Duration) *Monitor {
+	return &Monitor{
+		servers:   servers,
+		threshold: threshold,
+		timeout:   timeout,
+	}
+}
+
+// Check the host clock's drift. If no server answers, the clock's state is unknown, so it's no longer considered drifting.
+func (m *Monitor) Check() (Status, error) {
+	if m.threshold == 0 || len(m.servers) == 0 {
+		return Status{}, nil
+	}
+
+	errs := []error{}
+	for _, server := range m.servers {
+		offset, err := QueryOffset(server, m.timeout)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", server, err))
+			continue
+		}
+
+		status := Status{
+			Server: server,
+			Offset: offset,
+		}
+		status.Drifting = offset.Abs() > m.threshold
+		status.Changed = status.Drifting != m.drifting
+		m.drifting = status.Drifting
+		return status, nil
+	}
+	m.drifting = false
+	return Status{}, fmt.Errorf("error querying NTP servers: %w", errors.Join(errs...))
+}
+
+// Get whether the clock was drifting at the latest check
+func (m *Monitor) IsDrifting() bool {
+	return m.drifting
+}
+
+// Get the offset of the local clock from the provided NTP server, using a single SNTP exchange.
+// The server can include a port; it defaults to 123.
+func QueryOffset(server string, timeout time.Duration) (time.Duration, error) {
+	address := server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		address = net.JoinHostPort(strings.Trim(server, "[]"), ntpDefaultPort)
+	}
+	conn, err := net.DialTimeout("udp", address, timeout)
+	if err != nil {
+		return 0, fmt.Errorf("error connecting: %w", err)
+	}
+	defer conn.Close()
+	err = conn.SetDeadline(time.Now().Add(timeout))
+	if err != nil {
+		return 0, fmt.Errorf("error setting deadline: %w", err)
+	}
+
+	// Send the request with the local time as its transmit timestamp, which the server echoes back
+	request := make([]byte, ntpPacketSize)
+	request[0] = ntpClientHeader
+	sent := time.Now()
+	binary.BigEndian.PutUint64(request[transmitOffset:], toNtpTime(sent))
+	_, err = conn.Write(request)
+	if err != nil {
+		return 0, fmt.Errorf("error sending request: %w", err)
+	}
+
+	response := make([]byte, ntpPacketSize)
+	n, err := conn.Read(response)
+	received := time.Now()
+	if err != nil {
+		return 0, fmt.Errorf("error reading response: %w", err)
+	}
+	return parseResponse(response[:n], request[transmitOffset:], sent, received)
+}
+
+// Get the clock offset from an NTP response, given the request's transmit timestamp and when it was sent and received
+func parseResponse(response []byte, originate []byte, sent time.Time, received time.Time) (time.Duration, error) {
+	if len(response) < ntpPacketSize {
+		return 0, fmt.Errorf("response is %d bytes, expected at least %d", len(response), ntpPacketSize)
+	}
+	if mode := response[0] & 0x07; mode != ntpModeServer {
+		return 0, fmt.Errorf("response has mode %d, expected %d", mode, ntpModeServer)
+	}
+	if stratum := response[1]; stratum == 0 || stratum > ntpMaxStratum {
+		return 0, fmt.Errorf("server is unsynchronized or refused the request (stratum %d)", stratum)
+	}
+	if string(response[originateOffset:originateOffset+8]) != string(originate) {
+		return 0, fmt.Errorf("response doesn't match the request")
+	}
+	transmit := binary.BigEndian.Uint64(response[transmitOffset:])
+	if transmit == 0 {
+		return 0, fmt.Errorf("response has no transmit time")
+	}
+
+	// offset = ((serverReceive - sent) + (serverTransmit - received)) / 2
+	serverReceive := fromNtpTime(binary.BigEndian.Uint64(response[receiveOffset:]))
+	serverTransmit := fromNtpTime(transmit)
+	return (serverReceive.Sub(sent) + serverTransmit.Sub(received)) / 2, nil
+}
+
+// Convert a time to a 32.32 fixed point NTP timestamp
+func toNtpTime(t time.Time) uint64 {
+	nanos := uint64(t.UnixNano())
+	seconds := nanos/1e9 + ntpEpochOffset
+	fraction := (nanos % 1e9) << 32 / 1e9
+	return seconds<<32 | fraction
+}
+
+// Convert a 32.32 fixed point NTP timestamp to a time
+func fromNtpTime(ntpTime uint64) time.Time {
+	seconds := ntpTime>>32 - ntpEpochOffset
+	nanos := (ntpTime & 0xffffffff) * 1e9 >> 32
+	return time.Unix(int64(seconds), int64(nanos))
+}
@@ -0,0 +1,77 @@
+package clock
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+func TestNtpTimeRoundTrip(t *testing.T) {
+	now := time.Unix(1700000000, 123456789)
+	converted := fromNtpTime(toNtpTime(now))
+	if diff := converted.Sub(now).Abs(); diff > time.Nanosecond {
+		t.Fatalf("expected %s, got %s", now, converted)
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	// The request takes 40ms each way, and the server's clock is 250ms ahead of the local one
+	sent := time.Unix(1700000000, 0)
+	received := sent.Add(100 * time.Millisecond)
+	skew := 250 * time.Millisecond
+	originate := make([]byte, 8)
+	binary.BigEndian.PutUint64(originate, toNtpTime(sent))
+	response := newResponse(originate, sent.Add(40*time.Millisecond+skew), sent.Add(60*time.Millisecond+skew))
+
+	offset, err := parseResponse(response, originate, sent, received)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := (offset - skew).Abs(); diff > time.Microsecond {
+		t.Fatalf("expected an offset of %s, got %s", skew, offset)
+	}
+}
+
+func TestParseResponseRejectsBadPackets(t *testing.T) {
+	sent := time.Unix(1700000000, 0)
+	originate := make([]byte, 8)
+	binary.BigEndian.PutUint64(originate, toNtpTime(sent))
+	otherOriginate := make([]byte, 8)
+	binary.BigEndian.PutUint64(otherOriginate, toNtpTime(sent.Add(time.Second)))
+
+	tests := map[string][]byte{
+		"short":           newResponse(originate, sent, sent)[:40],
+		"kiss of death":   newResponse(originate, sent, sent),
+		"wrong originate": newResponse(otherOriginate, sent, sent),
+		"client mode":     newResponse(originate, sent, sent),
+	}
+	tests["kiss of death"][1] = 0
+	tests["client mode"][0] = ntpClientHeader
+	for name, response := range tests {
+		if _, err := parseResponse(response, originate, sent, sent); err == nil {
+			t.Errorf("%s: response was accepted", name)
+		}
+	}
+}
+
+func TestUnreachableServersClearDrift(t *testing.T) {
+	monitor := NewMonitor([]string{"127.0.0.1:1"}, time.Second, 200*time.Millisecond)
+	monitor.drifting = true
+	if _, err := monitor.Check(); err == nil {
+		t.Fatal("expected an error from an unreachable server")
+	}
+	if monitor.IsDrifting() {
+		t.Fatal("expected the clock not to be considered drifting when its state is unknown")
+	}
+}
+
+// Build a server response to the request with the provided transmit timestamp
+func newResponse(originate []byte, serverReceive time.Time, serverTransmit time.Time) []byte {
+	response := make([]byte, ntpPacketSize)
+	response[0] = 0x24 // Version 4, server mode
+	response[1] = 2
+	copy(response[originateOffset:], originate)
+	binary.BigEndian.PutUint64(response[receiveOffset:], toNtpTime(serverReceive))
+	binary.BigEndian.PutUint64(response[transmitOffset:], toNtpTime(serverTransmit))
+	return response
+}
@@ -72,6 +72,16 @@ type AlertmanagerConfig struct {
 	AlertEnabled_MinipoolStaked              config.Parameter `yaml:"alertEnabled_MinipoolStaked,omitempty"`
 	AlertEnabled_ExecutionClientSyncComplete config.Parameter `yaml:"alertEnabled_ExecutionClientSyncComplete,omitempty"`
 	AlertEnabled_BeaconClientSyncComplete    config.Parameter `yaml:"alertEnabled_BeaconClientSyncComplete,omitempty"`
+	AlertEnabled_PendingWithdrawalAddress    config.Parameter `yaml:"alertEnabled_PendingWithdrawalAddress,omitempty"`
+	AlertEnabled_FinalityStalled             config.Parameter `yaml:"alertEnabled_FinalityStalled,omitempty"`
+	AlertEnabled_InvalidRewardNetwork        config.Parameter `yaml:"alertEnabled_InvalidRewardNetwork,omitempty"`
+	AlertEnabled_ValidatorClientUnhealthy    config.Parameter `yaml:"alertEnabled_ValidatorClientUnhealthy,omitempty"`
+	AlertEnabled_WatchtowerDutyMissed        config.Parameter `yaml:"alertEnabled_WatchtowerDutyMissed,omitempty"`
+	AlertEnabled_LowBalanceForecast          config.Parameter `yaml:"alertEnabled_LowBalanceForecast,omitempty"`
+	AlertEnabled_ClockDrift                  config.Parameter `yaml:"alertEnabled_ClockDrift,omitempty"`
+	AlertEnabled_ProposalLookahead           config.Parameter `yaml:"alertEnabled_ProposalLookahead,omitempty"`
+	AlertEnabled_RewardsRootDivergence       config.Parameter `yaml:"alertEnabled_RewardsRootDivergence,omitempty"`
+	AlertEnabled_ValidatorExitProjection     config.Parameter `yaml:"alertEnabled_ValidatorExitProjection,omitempty"`
 }
 
 func NewAlertmanagerConfig(cfg *RocketPoolConfig) *AlertmanagerConfig {
@@ -226,6 +236,46 @@ func NewAlertmanagerConfig(cfg *RocketPoolConfig) *AlertmanagerConfig {
 		AlertEnabled_BeaconClientSyncComplete: createParameterForAlertEnablement(
 			"BeaconClientSyncComplete",
 			"beacon client is synced"),
+
+		AlertEnabled_PendingWithdrawalAddress: createParameterForAlertEnablement(
+			"PendingWithdrawalAddress",
+			"withdrawal address change is pending"),
+
+		AlertEnabled_FinalityStalled: createParameterForAlertEnablement(
+			"FinalityStalled",
+			"beacon chain finality is stalled"),
+
+		AlertEnabled_InvalidRewardNetwork: createParameterForAlertEnablement(
+			"InvalidRewardNetwork",
+			"the node's reward network is invalid"),
+
+		AlertEnabled_ValidatorClientUnhealthy: createParameterForAlertEnablement(
+			"ValidatorClientUnhealthy",
+			"the validator client is unhealthy"),
+
+		AlertEnabled_WatchtowerDutyMissed: createParameterForAlertEnablement(
+			"WatchtowerDutyMissed",
+			"the watchtower missed an Oracle DAO duty"),
+
+		AlertEnabled_LowBalanceForecast: createParameterForAlertEnablement(
+			"LowBalanceForecast",
+			"the node wallet will soon be too low for its duties"),
+
+		AlertEnabled_ClockDrift: createParameterForAlertEnablement(
+			"ClockDrift",
+			"the system clock is drifting"),
+
+		AlertEnabled_ProposalLookahead: createParameterForAlertEnablement(
+			"ProposalLookahead",
+			"a validator will propose a block in the next epoch"),
+
+		AlertEnabled_RewardsRootDivergence: createParameterForAlertEnablement(
+			"RewardsRootDivergence",
+			"the finalized rewards tree doesn't match the one this node generated"),
+
+		AlertEnabled_ValidatorExitProjection: createParameterForAlertEnablement(
+			"ValidatorExitProjection",
+			"a validator has started exiting, with when it will exit and be withdrawn"),
 	}
 }
 
@@ -269,6 +319,16 @@ func (cfg *AlertmanagerConfig) GetParameters() []*config.Parameter {
 		&cfg.AlertEnabled_MinipoolStaked,
 		&cfg.AlertEnabled_ExecutionClientSyncComplete,
 		&cfg.AlertEnabled_BeaconClientSyncComplete,
+		&cfg.AlertEnabled_PendingWithdrawalAddress,
+		&cfg.AlertEnabled_FinalityStalled,
+		&cfg.AlertEnabled_InvalidRewardNetwork,
+		&cfg.AlertEnabled_ValidatorClientUnhealthy,
+		&cfg.AlertEnabled_WatchtowerDutyMissed,
+		&cfg.AlertEnabled_LowBalanceForecast,
+		&cfg.AlertEnabled_ClockDrift,
+		&cfg.AlertEnabled_ProposalLookahead,
+		&cfg.AlertEnabled_RewardsRootDivergence,
+		&cfg.AlertEnabled_ValidatorExitProjection,
 	}
 }
 
@@ -28,6 +28,7 @@ const (
 	rootConfigName string = "root"
 
 	ApiContainerName          string = "api"
+	CacheProxyContainerName   string = "cache-proxy"
 	Eth1ContainerName         string = "eth1"
 	Eth1FallbackContainerName string = "eth1-fallback"
 	Eth2ContainerName         string = "eth2"
@@ -0,0 +1,313 @@
+package deposits
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	rpgoutils "github.com/rocket-pool/rocketpool-go/utils"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+const (
+	// The version of the index file's layout
+	indexFileVersion uint64 = 1
+
+	// Blocks this close to the head can still be re-orged out, so they're never persisted and are scanned on every query
+	ReorgSafetyWindow uint64 = 96
+
+	// How far back the index keeps deposits by default; this covers the scrub check and pre-deposit validation lookbacks
+	DefaultRetentionBlocks uint64 = 120000
+)
+
+// The persisted deposit index
+type indexFile struct {
+	Version         uint64                  `json:"version"`
+	DepositContract common.Address          `json:"depositContract"`
+	StartBlock      uint64                  `json:"startBlock"`
+	LastBlock       uint64                  `json:"lastBlock"`
+	LastBlockHash   common.Hash             `json:"lastBlockHash"`
+	Deposits        []rpgoutils.DepositData `json:"deposits"`
+}
+
+// Maintains a local index of the Beacon deposit contract's deposits over a rolling window of recent blocks, so checks
+// that need a validator's deposits don't have to scan the contract's logs every time.
+// Only blocks outside of the re-org safety window are indexed; the window itself is scanned on every query.
+type Indexer struct {
+	rp               *rocketpool.RocketPool
+	path             string
+	eventLogInterval uint64
+	retentionBlocks  uint64
+	index            *indexFile
+	lock             sync.Mutex
+}
+
+// Create an indexer that keeps its index at the given path
+func NewIndexer(rp *rocketpool.RocketPool, path string, eventLogInterval uint64, retentionBlocks uint64) *Indexer {
+	return &Indexer{
+		rp:               rp,
+		path:             path,
+		eventLogInterval: eventLogInterval,
+		retentionBlocks:  retentionBlocks,
+	}
+}
+
+// Bring the index up to the re-org safe head, rewinding it first if the last indexed block was re-orged out, then
+// save it to disk
+func (i *Indexer) Sync() error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	depositContract, err := i.rp.GetAddress("casperDeposit", nil)
+	if err != nil {
+		return fmt.Errorf("error getting deposit contract address: %w", err)
+	}
+	if err := i.load(*depositContract); err != nil {
+		return err
+	}
+	latestBlock, err := i.rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return fmt.Errorf("error getting latest block: %w", err)
+	}
+	if latestBlock <= ReorgSafetyWindow {
+		return nil
+	}
+	safeBlock := latestBlock - ReorgSafetyWindow
+
+	// Start over if the index is empty or too far behind to be worth catching up
+	retentionStart := uint64(0)
+	if safeBlock > i.retentionBlocks {
+		retentionStart = safeBlock - i.retentionBlocks
+	}
+	if i.index.LastBlock == 0 || i.index.LastBlock < retentionStart {
+		i.index = &indexFile{
+			Version:         indexFileVersion,
+			DepositContract: *depositContract,
+			StartBlock:      retentionStart,
+			Deposits:        []rpgoutils.DepositData{},
+		}
+		if retentionStart > 0 {
+			i.index.LastBlock = retentionStart - 1
+		}
+	} else {
+		// Make sure the last indexed block is still canonical, and rewind past the safety window if it isn't
+		header, err := i.rp.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(i.index.LastBlock))
+		if err != nil {
+			return fmt.Errorf("error getting header for block %d: %w", i.index.LastBlock, err)
+		}
+		if header.Hash() != i.index.LastBlockHash {
+			rewindTo := i.index.StartBlock
+			if i.index.LastBlock > i.index.StartBlock+ReorgSafetyWindow {
+				rewindTo = i.index.LastBlock - ReorgSafetyWindow
+			}
+			i.truncate(rewindTo)
+		}
+	}
+	if safeBlock <= i.index.LastBlock {
+		return nil
+	}
+
+	// Add the new deposits
+	deposits, err := i.scan(*depositContract, i.index.LastBlock+1, safeBlock)
+	if err != nil {
+		return err
+	}
+	i.index.Deposits = append(i.index.Deposits, deposits...)
+	header, err := i.rp.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(safeBlock))
+	if err != nil {
+		return fmt.Errorf("error getting header for block %d: %w", safeBlock, err)
+	}
+	i.index.LastBlock = safeBlock
+	i.index.LastBlockHash = header.Hash()
+
+	// Drop the deposits that have aged out of the window
+	if retentionStart > i.index.StartBlock {
+		first := sort.Search(len(i.index.Deposits), func(j int) bool {
+			return i.index.Deposits[j].BlockNumber >= retentionStart
+		})
+		i.index.Deposits = i.index.Deposits[first:]
+		i.index.StartBlock = retentionStart
+	}
+
+	return i.save()
+}
+
+// Get the deposits for the provided pubkeys made on or after the given block, including the ones in the re-org safety
+// window. Deposits are sorted by block and transaction index.
+// Anything the index doesn't cover, such as blocks before its window or after its last sync, is scanned from the
+// deposit contract directly.
+func (i *Indexer) GetDeposits(pubkeys map[rptypes.ValidatorPubkey]bool, fromBlock uint64) (map[rptypes.ValidatorPubkey][]rpgoutils.DepositData, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	depositContract, err := i.rp.GetAddress("casperDeposit", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting deposit contract address: %w", err)
+	}
+	if err := i.load(*depositContract); err != nil {
+		return nil, err
+	}
+	latestBlock, err := i.rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest block: %w", err)
+	}
+
+	deposits := []rpgoutils.DepositData{}
+	scanFrom := fromBlock
+	if i.index.LastBlock > 0 && fromBlock <= i.index.LastBlock {
+		// Scan the part of the range before the index's window
+		if fromBlock < i.index.StartBlock {
+			older, err := i.scan(*depositContract, fromBlock, i.index.StartBlock-1)
+			if err != nil {
+				return nil, err
+			}
+			deposits = append(deposits, older...)
+		}
+
+		// Use the index for the rest
+		for _, deposit := range i.index.Deposits {
+			if deposit.BlockNumber >= fromBlock {
+				deposits = append(deposits, deposit)
+			}
+		}
+		scanFrom = i.index.LastBlock + 1
+	}
+
+	// Scan everything newer than the index, including the re-org safety window
+	if scanFrom <= latestBlock {
+		recent, err := i.scan(*depositContract, scanFrom, latestBlock)
+		if err != nil {
+			return nil, err
+		}
+		deposits = append(deposits, recent...)
+	}
+
+	depositMap := map[rptypes.ValidatorPubkey][]rpgoutils.DepositData{}
+	for _, deposit := range deposits {
+		if pubkeys[deposit.Pubkey] {
+			depositMap[deposit.Pubkey] = append(depositMap[deposit.Pubkey], deposit)
+		}
+	}
+	return depositMap, nil
+}
+
+// Load the index from disk if it hasn't been loaded yet, discarding it if it was built for a different deposit contract
+func (i *Indexer) load(depositContract common.Address) error {
+	if i.index != nil && i.index.DepositContract == depositContract {
+		return nil
+	}
+	i.index = &indexFile{
+		Version:         indexFileVersion,
+		DepositContract: depositContract,
+		Deposits:        []rpgoutils.DepositData{},
+	}
+
+	bytes, err := os.ReadFile(i.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading deposit index %s: %w", i.path, err)
+	}
+	index := &indexFile{}
+	if err := json.Unmarshal(bytes, index); err != nil {
+		// The index can always be rebuilt, so a damaged one is just started over
+		return nil
+	}
+	if index.Version != indexFileVersion || index.DepositContract != depositContract {
+		return nil
+	}
+	i.index = index
+	return nil
+}
+
+// Save the index to disk
+func (i *Indexer) save() error {
+	bytes, err := json.Marshal(i.index)
+	if err != nil {
+		return fmt.Errorf("error serializing deposit index: %w", err)
+	}
+	if err := files.WriteFileAtomic(i.path, bytes, 0644); err != nil {
+		return fmt.Errorf("error saving deposit index: %w", err)
+	}
+	return nil
+}
+
+// Remove everything after the given block from the index
+func (i *Indexer) truncate(lastBlock uint64) {
+	end := sort.Search(len(i.index.Deposits), func(j int) bool {
+		return i.index.Deposits[j].BlockNumber > lastBlock
+	})
+	i.index.Deposits = i.index.Deposits[:end]
+	i.index.LastBlock = lastBlock
+	i.index.LastBlockHash = common.Hash{}
+	if lastBlock > 0 {
+		header, err := i.rp.Client.HeaderByNumber(context.Background(), new(big.Int).SetUint64(lastBlock))
+		if err == nil {
+			i.index.LastBlockHash = header.Hash()
+		}
+	}
+}
+
+// Get every deposit made in the given block range, sorted by block and transaction index
+func (i *Indexer) scan(depositContract common.Address, fromBlock uint64, toBlock uint64) ([]rpgoutils.DepositData, error) {
+	depositAbi, err := abi.JSON(strings.NewReader(contracts.BeaconDepositABI))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding deposit contract ABI: %w", err)
+	}
+	depositEvent := depositAbi.Events["DepositEvent"]
+
+	var intervalSize *big.Int
+	if i.eventLogInterval > 0 {
+		intervalSize = new(big.Int).SetUint64(i.eventLogInterval)
+	}
+	logs, err := eth.GetLogs(i.rp, []common.Address{depositContract}, [][]common.Hash{{depositEvent.ID}}, intervalSize, new(big.Int).SetUint64(fromBlock), new(big.Int).SetUint64(toBlock), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error scanning blocks %d to %d for deposits: %w", fromBlock, toBlock, err)
+	}
+
+	deposits := make([]rpgoutils.DepositData, 0, len(logs))
+	for _, log := range logs {
+		event := rpgoutils.BeaconDepositEvent{}
+		if err := depositAbi.UnpackIntoInterface(&event, "DepositEvent", log.Data); err != nil {
+			return nil, fmt.Errorf("error decoding deposit in transaction %s: %w", log.TxHash.Hex(), err)
+		}
+
+		// The amount is a little-endian uint64
+		var amount uint64
+		if err := binary.Read(bytes.NewReader(event.Amount), binary.LittleEndian, &amount); err != nil {
+			return nil, fmt.Errorf("error decoding deposit amount in transaction %s: %w", log.TxHash.Hex(), err)
+		}
+		deposits = append(deposits, rpgoutils.DepositData{
+			Pubkey:                rptypes.BytesToValidatorPubkey(event.Pubkey),
+			WithdrawalCredentials: common.BytesToHash(event.WithdrawalCredentials),
+			Amount:                amount,
+			Signature:             rptypes.BytesToValidatorSignature(event.Signature),
+			TxHash:                log.TxHash,
+			BlockNumber:           log.BlockNumber,
+			TxIndex:               log.TxIndex,
+		})
+	}
+	sort.SliceStable(deposits, func(a, b int) bool {
+		if deposits[a].BlockNumber == deposits[b].BlockNumber {
+			return deposits[a].TxIndex < deposits[b].TxIndex
+		}
+		return deposits[a].BlockNumber < deposits[b].BlockNumber
+	})
+	return deposits, nil
+}
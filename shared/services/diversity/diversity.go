@@ -0,0 +1,181 @@
+package diversity
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Timeout for downloading the distribution from the data source
+const fetchTimeout = 10 * time.Second
+
+// Supermajority thresholds; a client above these can stop finality or finalize an invalid chain on its own if it has a bug
+const (
+	finalityThreshold      float64 = 1.0 / 3.0
+	majorityThreshold      float64 = 0.5
+	supermajorityThreshold float64 = 2.0 / 3.0
+)
+
+// The risk to a node operator's validators if their client has a consensus bug
+type RiskLevel string
+
+const (
+	RiskLevel_Low      RiskLevel = "low"
+	RiskLevel_Medium   RiskLevel = "medium"
+	RiskLevel_High     RiskLevel = "high"
+	RiskLevel_Critical RiskLevel = "critical"
+)
+
+// The share of the network's validators running each client, as fractions between 0 and 1
+type Distribution struct {
+	Source    string                               `json:"source"`
+	Date      string                               `json:"date"`
+	Execution map[cfgtypes.ExecutionClient]float64 `json:"execution"`
+	Consensus map[cfgtypes.ConsensusClient]float64 `json:"consensus"`
+}
+
+// The advice for a single client
+type ClientAdvice struct {
+	Client          string
+	Share           float64
+	Risk            RiskLevel
+	RiskSummary     string
+	Recommendations []string
+}
+
+// Approximate Mainnet distribution used when no data source is configured or it can't be reached
+func GetBuiltInDistribution() *Distribution {
+	return &Distribution{
+		Source: "built-in estimate",
+		Date:   "2024-10",
+		Execution: map[cfgtypes.ExecutionClient]float64{
+			cfgtypes.ExecutionClient_Geth:       0.53,
+			cfgtypes.ExecutionClient_Nethermind: 0.30,
+			cfgtypes.ExecutionClient_Besu:       0.09,
+			cfgtypes.ExecutionClient_Reth:       0.02,
+		},
+		Consensus: map[cfgtypes.ConsensusClient]float64{
+			cfgtypes.ConsensusClient_Lighthouse: 0.36,
+			cfgtypes.ConsensusClient_Prysm:      0.34,
+			cfgtypes.ConsensusClient_Teku:       0.17,
+			cfgtypes.ConsensusClient_Nimbus:     0.10,
+			cfgtypes.ConsensusClient_Lodestar:   0.02,
+		},
+	}
+}
+
+// Download the current distribution from a data source that serves it as JSON
+func FetchDistribution(url string) (*Distribution, error) {
+	client := http.Client{
+		Timeout: fetchTimeout,
+	}
+	response, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("error getting client distribution from %s: %w", url, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("client distribution source %s returned status %d", url, response.StatusCode)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading client distribution from %s: %w", url, err)
+	}
+	var distribution Distribution
+	err = json.Unmarshal(body, &distribution)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing client distribution from %s: %w", url, err)
+	}
+	if len(distribution.Execution) == 0 || len(distribution.Consensus) == 0 {
+		return nil, fmt.Errorf("client distribution from %s is missing execution or consensus shares", url)
+	}
+	if distribution.Source == "" {
+		distribution.Source = url
+	}
+	return &distribution, nil
+}
+
+// Get the distribution from the data source if one is provided, falling back to the built-in estimate
+func GetDistribution(url string) (*Distribution, error) {
+	if url == "" {
+		return GetBuiltInDistribution(), nil
+	}
+	distribution, err := FetchDistribution(url)
+	if err != nil {
+		return GetBuiltInDistribution(), err
+	}
+	return distribution, nil
+}
+
+// Get the advice for an Execution client
+func (d *Distribution) AdviseExecutionClient(selected cfgtypes.ExecutionClient) ClientAdvice {
+	shares := make(map[string]float64, len(d.Execution))
+	for client, share := range d.Execution {
+		shares[string(client)] = share
+	}
+	return advise(string(selected), shares)
+}
+
+// Get the advice for a Consensus client
+func (d *Distribution) AdviseConsensusClient(selected cfgtypes.ConsensusClient) ClientAdvice {
+	shares := make(map[string]float64, len(d.Consensus))
+	for client, share := range d.Consensus {
+		shares[string(client)] = share
+	}
+	return advise(string(selected), shares)
+}
+
+// Assess the correlated-failure risk of a client and recommend minority alternatives
+func advise(selected string, shares map[string]float64) ClientAdvice {
+	share := shares[selected]
+	advice := ClientAdvice{
+		Client: selected,
+		Share:  share,
+		Risk:   GetRiskLevel(share),
+	}
+
+	switch advice.Risk {
+	case RiskLevel_Critical:
+		advice.RiskSummary = "a bug in this client could finalize an invalid chain. Validators that follow it could not return to the correct chain without being slashed, and would suffer a long inactivity leak if they stayed offline instead."
+	case RiskLevel_High:
+		advice.RiskSummary = "a bug in this client would stop the chain from finalizing and put its validators into an inactivity leak, and it is close to being able to finalize an invalid chain on its own."
+	case RiskLevel_Medium:
+		advice.RiskSummary = "a bug in this client could stop the chain from finalizing, putting its validators into an inactivity leak until it's fixed."
+	default:
+		advice.RiskSummary = "a bug in this client would only affect its own validators, which would miss rewards until it's fixed."
+	}
+
+	// Recommend every client below the finality threshold, smallest share first
+	if advice.Risk != RiskLevel_Low {
+		for client, clientShare := range shares {
+			if client != selected && clientShare < finalityThreshold {
+				advice.Recommendations = append(advice.Recommendations, client)
+			}
+		}
+		sort.Slice(advice.Recommendations, func(i, j int) bool {
+			return shares[advice.Recommendations[i]] < shares[advice.Recommendations[j]]
+		})
+	}
+	return advice
+}
+
+// Get the risk level of running a client with the given share of the network
+func GetRiskLevel(share float64) RiskLevel {
+	switch {
+	case share >= supermajorityThreshold:
+		return RiskLevel_Critical
+	case share >= majorityThreshold:
+		return RiskLevel_High
+	case share >= finalityThreshold:
+		return RiskLevel_Medium
+	default:
+		return RiskLevel_Low
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"net/http"
 	"strings"
 	"time"
 
@@ -12,8 +13,12 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/fatih/color"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/faults"
+	"github.com/rocket-pool/smartnode/shared/services/nonce"
+	"github.com/rocket-pool/smartnode/shared/services/tracer"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -29,13 +34,52 @@ type ExecutionClientManager struct {
 	primaryReady    bool
 	fallbackReady   bool
 	ignoreSyncCheck bool
+	nonces          *nonce.Coordinator
+	auditor         *transactionAuditor
+	tracer          *tracer.Tracer
+	simulate        bool
 }
 
 // This is a signature for a wrapped ethclient.Client function
 type ecFunction func(*ethclient.Client) (interface{}, error)
 
 // Creates a new ExecutionClientManager instance based on the Rocket Pool config
-func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientManager, error) {
+func NewExecutionClientManager(cfg *config.RocketPoolConfig, faultInjector *faults.Injector) (*ExecutionClientManager, error) {
+
+	primaryEcUrl, fallbackEcUrl := getExecutionClientUrls(cfg)
+
+	// Send the primary EC's requests through the caching proxy if it's enabled
+	if cfg.Smartnode.UseCachingProxy() {
+		primaryEcUrl = cfg.Smartnode.GetCachingProxyEcUrl()
+	}
+
+	primaryEc, err := dialExecutionClient(primaryEcUrl, faultInjector)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to primary EC at [%s]: %w", primaryEcUrl, err)
+	}
+
+	var fallbackEc *ethclient.Client
+	if fallbackEcUrl != "" {
+		fallbackEc, err = dialExecutionClient(fallbackEcUrl, faultInjector)
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to fallback EC at [%s]: %w", fallbackEcUrl, err)
+		}
+	}
+
+	return &ExecutionClientManager{
+		primaryEcUrl:  primaryEcUrl,
+		fallbackEcUrl: fallbackEcUrl,
+		primaryEc:     primaryEc,
+		fallbackEc:    fallbackEc,
+		logger:        log.NewColorLogger(color.FgYellow),
+		primaryReady:  true,
+		fallbackReady: fallbackEc != nil,
+	}, nil
+
+}
+
+// Get the URLs of the primary and fallback ECs from the config. The fallback is blank if there isn't one.
+func getExecutionClientUrls(cfg *config.RocketPoolConfig) (string, string) {
 
 	var primaryEcUrl string
 	var fallbackEcUrl string
@@ -64,29 +108,23 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 		}
 	}
 
-	primaryEc, err := ethclient.Dial(primaryEcUrl)
-	if err != nil {
-		return nil, fmt.Errorf("error connecting to primary EC at [%s]: %w", primaryEcUrl, err)
-	}
-
-	var fallbackEc *ethclient.Client
-	if fallbackEcUrl != "" {
-		fallbackEc, err = ethclient.Dial(fallbackEcUrl)
-		if err != nil {
-			return nil, fmt.Errorf("error connecting to fallback EC at [%s]: %w", fallbackEcUrl, err)
-		}
-	}
+	return primaryEcUrl, fallbackEcUrl
 
-	return &ExecutionClientManager{
-		primaryEcUrl:  primaryEcUrl,
-		fallbackEcUrl: fallbackEcUrl,
-		primaryEc:     primaryEc,
-		fallbackEc:    fallbackEc,
-		logger:        log.NewColorLogger(color.FgYellow),
-		primaryReady:  true,
-		fallbackReady: fallbackEc != nil,
-	}, nil
+}
 
+// Connect to an Execution client, sending HTTP requests through the fault injector if there is one
+func dialExecutionClient(url string, faultInjector *faults.Injector) (*ethclient.Client, error) {
+	if faultInjector == nil {
+		return ethclient.Dial(url)
+	}
+	httpClient := &http.Client{
+		Transport: faultInjector.Wrap(faults.Client_Execution, http.DefaultTransport),
+	}
+	rpcClient, err := rpc.DialOptions(context.Background(), url, rpc.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, err
+	}
+	return ethclient.NewClient(rpcClient), nil
 }
 
 /// ========================
@@ -200,6 +238,9 @@ func (p *ExecutionClientManager) EstimateGas(ctx context.Context, call ethereum.
 		return client.EstimateGas(ctx, call)
 	})
 	if err != nil {
+		if p.tracer != nil && strings.Contains(strings.ToLower(err.Error()), "execution reverted") {
+			p.traceCall(call, nil, "gas estimation")
+		}
 		return 0, err
 	}
 	return result.(uint64), err
@@ -207,12 +248,91 @@ func (p *ExecutionClientManager) EstimateGas(ctx context.Context, call ethereum.
 
 // SendTransaction injects the transaction into the pending pool for execution.
 func (p *ExecutionClientManager) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if p.simulate {
+		return p.simulateTransaction(ctx, tx)
+	}
 	_, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
 		return nil, client.SendTransaction(ctx, tx)
 	})
+	p.ReleaseNonce(tx, err == nil)
+	p.RecordTransaction(tx, err)
 	return err
 }
 
+// Record a transaction that was sent (or failed to send) in the audit log, if auditing is enabled.
+// This is called automatically by SendTransaction, and must be called by anything that sends transactions elsewhere.
+func (p *ExecutionClientManager) RecordTransaction(tx *types.Transaction, sendErr error) {
+	if p.auditor == nil {
+		return
+	}
+	p.auditor.recordTransaction(tx, sendErr)
+}
+
+// Record the outcome of a transaction in the audit log once it has been included in a block, if auditing is enabled.
+// Reverted transactions are also traced if tracing is enabled.
+func (p *ExecutionClientManager) RecordTransactionResult(hash common.Hash, receipt *types.Receipt, waitErr error) {
+	if p.tracer != nil && receipt != nil && receipt.Status != types.ReceiptStatusSuccessful {
+		p.traceTransaction(hash, receipt)
+	}
+	if p.auditor == nil {
+		return
+	}
+	p.auditor.recordTransactionResult(hash, receipt, waitErr)
+}
+
+// Enable tracing of calls that revert during gas estimation and of transactions that revert once included in a block
+func (p *ExecutionClientManager) SetCallTracer(callTracer *tracer.Tracer) {
+	p.tracer = callTracer
+}
+
+// Re-simulate a reverted transaction on top of the block before the one it was included in
+func (p *ExecutionClientManager) traceTransaction(hash common.Hash, receipt *types.Receipt) {
+	tx, _, err := p.TransactionByHash(context.Background(), hash)
+	if err != nil {
+		p.logger.Printlnf("Error getting reverted transaction %s to trace it: %s", hash.Hex(), err.Error())
+		return
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		p.logger.Printlnf("Error getting the sender of reverted transaction %s to trace it: %s", hash.Hex(), err.Error())
+		return
+	}
+	call := ethereum.CallMsg{
+		From:  sender,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+	p.traceCall(call, new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1)), fmt.Sprintf("transaction %s", hash.Hex()))
+}
+
+// Trace a failed call with whichever client is working
+func (p *ExecutionClientManager) traceCall(call ethereum.CallMsg, blockNumber *big.Int, label string) {
+	_, _ = p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		p.tracer.TraceCall(client.Client(), call, blockNumber, label)
+		return nil, nil
+	})
+}
+
+// Release nonces reserved by the node wallet's coordinator once the transactions using them are sent
+func (p *ExecutionClientManager) SetNonceCoordinator(coordinator *nonce.Coordinator) {
+	p.nonces = coordinator
+}
+
+// Finish the nonce reservation for a transaction, if nonce coordination is enabled.
+// This is called automatically by SendTransaction, and must be called by anything that sends transactions elsewhere.
+func (p *ExecutionClientManager) ReleaseNonce(tx *types.Transaction, sent bool) {
+	if p.nonces == nil {
+		return
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return
+	}
+	p.nonces.Release(sender, tx, sent)
+}
+
 /// ==========================
 /// ContractFilterer Functions
 /// ==========================
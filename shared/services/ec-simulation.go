@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/rocket-pool/smartnode/shared/services/simulation"
+)
+
+// An account's state in a prestateTracer diff
+type prestateAccount struct {
+	Balance *hexutil.Big `json:"balance"`
+}
+
+// The output of the prestateTracer in diff mode
+type prestateDiff struct {
+	Pre  map[common.Address]prestateAccount `json:"pre"`
+	Post map[common.Address]prestateAccount `json:"post"`
+}
+
+// Simulate a signed transaction against the pending block instead of sending it, and record what it would do
+func (p *ExecutionClientManager) simulateTransaction(ctx context.Context, tx *types.Transaction) error {
+	p.ReleaseNonce(tx, false)
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("error getting the sender of the simulated transaction: %w", err)
+	}
+	call := ethereum.CallMsg{
+		From:  sender,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+	report := simulation.Report{
+		From:           sender,
+		To:             tx.To(),
+		Value:          tx.Value(),
+		Nonce:          tx.Nonce(),
+		GasLimit:       tx.Gas(),
+		MaxFee:         tx.GasFeeCap(),
+		MaxPriorityFee: tx.GasTipCap(),
+		MaxGasCost:     new(big.Int).Mul(tx.GasFeeCap(), new(big.Int).SetUint64(tx.Gas())),
+		BalanceChanges: []simulation.BalanceChange{},
+	}
+
+	// Run it at the pending block
+	_, err = p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return client.PendingCallContract(ctx, call)
+	})
+	if err != nil {
+		if p.isDisconnected(err) {
+			return err
+		}
+		report.RevertReason = getSimulatedRevertReason(err)
+	} else {
+		report.Success = true
+	}
+
+	// Estimate the gas it would actually use and what that would cost at the current base fee
+	estimateCall := call
+	estimateCall.Gas = 0
+	gasEstimate, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return client.EstimateGas(ctx, estimateCall)
+	})
+	if err == nil {
+		report.GasEstimate = gasEstimate.(uint64)
+	}
+	gasPrice := new(big.Int).Set(tx.GasFeeCap())
+	header, err := p.HeaderByNumber(ctx, nil)
+	if err == nil && header.BaseFee != nil {
+		effectivePrice := new(big.Int).Add(header.BaseFee, tx.GasTipCap())
+		if effectivePrice.Cmp(gasPrice) < 0 {
+			gasPrice = effectivePrice
+		}
+	}
+	gasUsed := report.GasEstimate
+	if gasUsed == 0 {
+		gasUsed = tx.Gas()
+	}
+	report.ExpectedGasCost = new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasUsed))
+
+	// Project the ETH balance changes
+	if report.Success {
+		p.addBalanceChanges(ctx, call, &report)
+	}
+
+	simulation.Record(report)
+	return simulation.ErrSimulated
+}
+
+// Add the ETH balance changes a successful call would make to a report, including the sender's gas cost
+func (p *ExecutionClientManager) addBalanceChanges(ctx context.Context, call ethereum.CallMsg, report *simulation.Report) {
+	changes := map[common.Address]*big.Int{}
+	var diff prestateDiff
+	_, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return nil, client.Client().CallContext(ctx, &diff, "debug_traceCall", toSimulationCallArg(call), "latest", map[string]interface{}{
+			"tracer":       "prestateTracer",
+			"tracerConfig": map[string]bool{"diffMode": true},
+		})
+	})
+	if err == nil {
+		for address, post := range diff.Post {
+			pre, exists := diff.Pre[address]
+			if post.Balance == nil || !exists || pre.Balance == nil {
+				continue
+			}
+			change := new(big.Int).Sub(post.Balance.ToInt(), pre.Balance.ToInt())
+			if change.Sign() != 0 {
+				changes[address] = change
+			}
+		}
+	} else {
+		// Without a trace, only the value transfer itself is known
+		report.BalanceChangeNote = "The Execution client couldn't trace the transaction, so only its direct value transfer is included."
+		if call.Value != nil && call.Value.Sign() > 0 {
+			changes[call.From] = new(big.Int).Neg(call.Value)
+			if call.To != nil {
+				changes[*call.To] = new(big.Int).Set(call.Value)
+			}
+		}
+	}
+
+	// The trace runs without a gas price, so add the sender's gas cost
+	senderChange, exists := changes[call.From]
+	if !exists {
+		senderChange = big.NewInt(0)
+	}
+	changes[call.From] = senderChange.Sub(senderChange, report.ExpectedGasCost)
+
+	for address, change := range changes {
+		before, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+			return client.PendingBalanceAt(ctx, address)
+		})
+		if err != nil {
+			continue
+		}
+		beforeBalance := before.(*big.Int)
+		report.BalanceChanges = append(report.BalanceChanges, simulation.BalanceChange{
+			Address: address,
+			Before:  beforeBalance,
+			After:   new(big.Int).Add(beforeBalance, change),
+			Change:  change,
+		})
+	}
+}
+
+// Get the reason a simulated call reverted, decoding the revert data if the client returned it
+func getSimulatedRevertReason(err error) string {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if bytes, decodeErr := hexutil.Decode(data); decodeErr == nil {
+				if reason, unpackErr := abi.UnpackRevert(bytes); unpackErr == nil {
+					return reason
+				}
+				if len(bytes) >= 4 {
+					return fmt.Sprintf("custom error %s", hexutil.Encode(bytes[:4]))
+				}
+			}
+		}
+	}
+	return strings.TrimPrefix(err.Error(), "execution reverted: ")
+}
+
+// Convert a call to the argument debug_traceCall expects
+func toSimulationCallArg(call ethereum.CallMsg) map[string]interface{} {
+	arg := map[string]interface{}{
+		"from":  call.From,
+		"data":  hexutil.Bytes(call.Data),
+		"input": hexutil.Bytes(call.Data),
+	}
+	if call.To != nil {
+		arg["to"] = call.To
+	}
+	if call.Value != nil {
+		arg["value"] = (*hexutil.Big)(call.Value)
+	}
+	if call.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(call.Gas)
+	}
+	return arg
+}
@@ -0,0 +1,276 @@
+package ecprobe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+// Clients move blocks older than this many blocks from their live database into their freezer (ancient store)
+const freezerThreshold uint64 = 90000
+
+// The number of places in the freezer to read headers from
+const freezerSamples uint64 = 16
+
+// The number of synced writes the disk latency probe makes, and how large each one is
+const (
+	diskLatencyWrites    int = 20
+	diskLatencyWriteSize int = 4096
+)
+
+// Synced write latencies above these are too slow to load the large states tree generation needs in time
+const (
+	slowAverageSync time.Duration = 10 * time.Millisecond
+	slowMaxSync     time.Duration = 100 * time.Millisecond
+)
+
+// How long each probe query can take
+const queryTimeout = 30 * time.Second
+
+// The result of a single probe
+type ProbeResult struct {
+	Name     string        `json:"name"`
+	Healthy  bool          `json:"healthy"`
+	Detail   string        `json:"detail"`
+	Duration time.Duration `json:"duration"`
+}
+
+// The results of all of the probes
+type Report struct {
+	CheckedAt time.Time     `json:"checkedAt"`
+	Healthy   bool          `json:"healthy"`
+	Probes    []ProbeResult `json:"probes"`
+}
+
+// Run all of the probes against the primary EC (and the archive EC if one is set), writing the disk latency probe's
+// file to the provided folder
+func Run(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, dataDir string) Report {
+	report := Report{
+		CheckedAt: time.Now(),
+		Healthy:   true,
+		Probes:    []ProbeResult{},
+	}
+	add := func(result ProbeResult) {
+		report.Probes = append(report.Probes, result)
+		report.Healthy = report.Healthy && result.Healthy
+	}
+
+	// Check the freezer
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	head, err := rp.Client.BlockNumber(ctx)
+	cancel()
+	if err != nil {
+		add(ProbeResult{Name: "freezer", Detail: fmt.Sprintf("error getting the latest block: %s", err.Error())})
+	} else {
+		add(ProbeFreezer(rp.Client, head))
+	}
+
+	// Check the state at the last rewards snapshot, which regenerating or verifying its tree needs
+	add(probeSnapshotState(cfg, rp))
+
+	// Check the disk
+	add(ProbeDiskLatency(dataDir))
+	return report
+}
+
+// Read headers from evenly spaced places in the freezer, checking that each one exists and is linked to the next.
+// A missing header is a gap in the ancient store, and a broken link means it's corrupted.
+func ProbeFreezer(ec rocketpool.ExecutionClient, head uint64) ProbeResult {
+	start := time.Now()
+	result := ProbeResult{
+		Name: "freezer",
+	}
+	if head <= freezerThreshold+1 {
+		result.Healthy = true
+		result.Detail = "the chain is too short to have a freezer yet"
+		return result
+	}
+
+	last := head - freezerThreshold - 1
+	for i := uint64(0); i < freezerSamples; i++ {
+		number := i * last / (freezerSamples - 1)
+		if number == last {
+			number--
+		}
+		header, err := getHeader(ec, number)
+		if err != nil {
+			result.Detail = err.Error()
+			result.Duration = time.Since(start)
+			return result
+		}
+		child, err := getHeader(ec, number+1)
+		if err != nil {
+			result.Detail = err.Error()
+			result.Duration = time.Since(start)
+			return result
+		}
+		if child.ParentHash != header.Hash() {
+			result.Detail = fmt.Sprintf("block %d's parent hash is %s, but block %d's hash is %s, so the ancient store is corrupted", number+1, child.ParentHash.Hex(), number, header.Hash().Hex())
+			result.Duration = time.Since(start)
+			return result
+		}
+	}
+
+	result.Healthy = true
+	result.Detail = fmt.Sprintf("%d linked header pairs were read from blocks 0 to %d", freezerSamples, last)
+	result.Duration = time.Since(start)
+	return result
+}
+
+// Write small files with a sync after each one, checking the disk responds quickly enough
+func ProbeDiskLatency(dir string) ProbeResult {
+	start := time.Now()
+	result := ProbeResult{
+		Name: "disk-latency",
+	}
+
+	file, err := os.CreateTemp(dir, ".latency-probe-*")
+	if err != nil {
+		result.Detail = fmt.Sprintf("error creating a file in %s: %s", dir, err.Error())
+		return result
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	data := make([]byte, diskLatencyWriteSize)
+	var total, slowest time.Duration
+	for i := 0; i < diskLatencyWrites; i++ {
+		writeStart := time.Now()
+		if _, err := file.Write(data); err != nil {
+			result.Detail = fmt.Sprintf("error writing to %s: %s", file.Name(), err.Error())
+			return result
+		}
+		if err := file.Sync(); err != nil {
+			result.Detail = fmt.Sprintf("error syncing %s: %s", file.Name(), err.Error())
+			return result
+		}
+		latency := time.Since(writeStart)
+		total += latency
+		slowest = max(slowest, latency)
+	}
+
+	average := total / time.Duration(diskLatencyWrites)
+	result.Healthy = average <= slowAverageSync && slowest <= slowMaxSync
+	result.Detail = fmt.Sprintf("synced writes to %s took %s on average and %s at most", dir, average.Round(time.Microsecond), slowest.Round(time.Microsecond))
+	if !result.Healthy {
+		result.Detail += fmt.Sprintf(" (the limits are %s and %s), so the disk may be too slow or failing", slowAverageSync, slowMaxSync)
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+// Check that the state at the last rewards snapshot can be read from the primary EC, or the archive EC if it's pruned
+func probeSnapshotState(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool) ProbeResult {
+	start := time.Now()
+	result := ProbeResult{
+		Name: "snapshot-state",
+	}
+
+	// Get the last snapshot's block
+	currentIndex, err := rp.GetRewardIndex(nil)
+	if err != nil {
+		result.Detail = fmt.Sprintf("error getting the current rewards interval: %s", err.Error())
+		return result
+	}
+	if currentIndex.Uint64() == 0 {
+		result.Healthy = true
+		result.Detail = "there are no rewards snapshots yet"
+		return result
+	}
+	index := currentIndex.Uint64() - 1
+	event, err := rprewards.NewRewardsExecutionClient(rp).GetRewardSnapshotEvent(cfg.Smartnode.GetPreviousRewardsPoolAddresses(), index, nil)
+	if err != nil {
+		result.Detail = fmt.Sprintf("error getting interval %d's snapshot: %s", index, err.Error())
+		return result
+	}
+	block := event.ExecutionBlock.Uint64()
+
+	// Try the primary EC
+	err = probeState(cfg, rp, block)
+	if err == nil {
+		result.Healthy = true
+		result.Detail = fmt.Sprintf("the primary EC has the state for interval %d's snapshot at block %d", index, block)
+		result.Duration = time.Since(start)
+		return result
+	}
+	if !eth1.IsMissingStateError(err) {
+		result.Detail = fmt.Sprintf("error reading the state for interval %d's snapshot at block %d: %s", index, block, err.Error())
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	// Fall back to the archive EC like tree generation does
+	archiveEcUrl := cfg.Smartnode.ArchiveECUrl.Value.(string)
+	if archiveEcUrl == "" {
+		result.Detail = fmt.Sprintf("the primary EC has pruned the state for interval %d's snapshot at block %d and no archive EC is set, so its tree can't be regenerated", index, block)
+		result.Duration = time.Since(start)
+		return result
+	}
+	ec, err := ethclient.Dial(archiveEcUrl)
+	if err != nil {
+		result.Detail = fmt.Sprintf("error connecting to the archive EC: %s", err.Error())
+		return result
+	}
+	defer ec.Close()
+	archiveRp, err := rocketpool.NewRocketPool(ec, common.HexToAddress(cfg.Smartnode.GetStorageAddress()))
+	if err != nil {
+		result.Detail = fmt.Sprintf("error creating a Rocket Pool client for the archive EC: %s", err.Error())
+		return result
+	}
+	err = probeState(cfg, archiveRp, block)
+	if err != nil {
+		result.Detail = fmt.Sprintf("the primary EC has pruned the state for interval %d's snapshot at block %d, and the archive EC can't provide it either: %s", index, block, err.Error())
+	} else {
+		result.Healthy = true
+		result.Detail = fmt.Sprintf("the primary EC has pruned the state for interval %d's snapshot at block %d, but the archive EC has it", index, block)
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+// Read the rETH address from the state at a block, checking it matches the expected one
+func probeState(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, block uint64) error {
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(block),
+	}
+	address, err := rp.RocketStorage.GetAddress(opts, crypto.Keccak256Hash([]byte("contract.addressrocketTokenRETH")))
+	if err != nil {
+		return err
+	}
+	if address != cfg.Smartnode.GetRethAddress() {
+		return fmt.Errorf("the rETH address at block %d is %s, but it should be %s", block, address.Hex(), cfg.Smartnode.GetRethAddress().Hex())
+	}
+	return nil
+}
+
+// Get a header, describing a missing one as a gap
+func getHeader(ec rocketpool.ExecutionClient, number uint64) (*types.Header, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+	header, err := ec.HeaderByNumber(ctx, big.NewInt(0).SetUint64(number))
+	if errors.Is(err, ethereum.NotFound) || (err == nil && header == nil) {
+		return nil, fmt.Errorf("block %d is missing, so the ancient store has a gap", number)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting block %d: %w", number, err)
+	}
+	if header.Number.Uint64() != number {
+		return nil, fmt.Errorf("block %d was returned when block %d was requested, so the ancient store is corrupted", header.Number.Uint64(), number)
+	}
+	return header, nil
+}
@@ -0,0 +1,222 @@
+package election
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+const (
+	// How long a lease lasts if its holder stops renewing it
+	LeaseDuration time.Duration = 2 * time.Minute
+
+	// How often every instance tries to take or renew the lease
+	campaignInterval time.Duration = 30 * time.Second
+
+	// How long to wait after writing the lease before reading it back, so an instance that wrote it at the same time
+	// has finished overwriting it
+	settleTime time.Duration = 2 * time.Second
+)
+
+// The lease file shared by the instances
+type lease struct {
+	Holder  string    `json:"holder"`
+	Expires time.Time `json:"expires"`
+}
+
+// This instance's role at the latest check
+type Status struct {
+	// True if this instance should send the duties
+	Leader bool
+
+	// The instance holding the lease, if it's known
+	Holder string
+
+	// True if Leader is different from the previous check, or this is the first check
+	Changed bool
+}
+
+// Decides which of several redundant instances sends duties, so they aren't submitted twice
+type Elector struct {
+	mode       cfgtypes.WatchtowerRedundancyMode
+	instanceID string
+	leasePath  string
+	maxDelay   time.Duration
+
+	lock     sync.Mutex
+	leader   bool
+	holder   string
+	expires  time.Time
+	err      error
+	checked  bool
+	reported bool
+}
+
+// Create an elector for the redundancy mode in the config. In lease mode, it starts campaigning for the lease in the
+// background straight away.
+func NewElector(cfg *config.RocketPoolConfig) (*Elector, error) {
+	e := &Elector{
+		mode:     cfg.Smartnode.WatchtowerRedundancyMode.Value.(cfgtypes.WatchtowerRedundancyMode),
+		maxDelay: time.Duration(cfg.Smartnode.WatchtowerStandbyMaxDelay.Value.(uint16)) * time.Second,
+	}
+	if e.mode != cfgtypes.WatchtowerRedundancyMode_Lease {
+		return e, nil
+	}
+
+	e.instanceID = cfg.Smartnode.WatchtowerInstanceID.Value.(string)
+	if e.instanceID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("error getting hostname for the watchtower instance ID: %w", err)
+		}
+		e.instanceID = hostname
+	}
+	e.leasePath = cfg.Smartnode.GetWatchtowerLeasePath()
+	err := os.MkdirAll(filepath.Dir(e.leasePath), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("error creating lease folder for [%s]: %w", e.leasePath, err)
+	}
+
+	e.campaign()
+	go func() {
+		for {
+			time.Sleep(campaignInterval)
+			e.campaign()
+		}
+	}()
+	return e, nil
+}
+
+// Get the name of this instance in the lease file
+func (e *Elector) GetInstanceID() string {
+	return e.instanceID
+}
+
+// Check whether this instance should send the duties. Any error is from the latest campaign for the lease; the status
+// is still valid when there is one.
+func (e *Elector) Check() (Status, error) {
+	if e.mode != cfgtypes.WatchtowerRedundancyMode_Lease {
+		return Status{Leader: true}, nil
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	status := Status{
+		Leader:  e.leader,
+		Holder:  e.holder,
+		Changed: !e.checked || e.leader != e.reported,
+	}
+	e.checked = true
+	e.reported = e.leader
+	return status, e.err
+}
+
+// Get a random amount of time to wait before checking for duties, so instances in delay mode don't check and send them
+// at the same time. It's always 0 in the other modes.
+func (e *Elector) GetStandbyDelay() time.Duration {
+	if e.mode != cfgtypes.WatchtowerRedundancyMode_Delay || e.maxDelay <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(e.maxDelay)))
+}
+
+// Take the lease if it's free or expired, or renew it if this instance holds it
+func (e *Elector) campaign() {
+	current, err := e.readLease()
+	if err != nil {
+		e.fail(err)
+		return
+	}
+	now := time.Now()
+	if current != nil && current.Holder != e.instanceID && now.Before(current.Expires) {
+		e.set(false, current.Holder, time.Time{})
+		return
+	}
+
+	// Write the lease, then make sure no other instance overwrote it at the same time
+	expires := now.Add(LeaseDuration)
+	err = e.writeLease(lease{
+		Holder:  e.instanceID,
+		Expires: expires,
+	})
+	if err != nil {
+		e.fail(err)
+		return
+	}
+	time.Sleep(settleTime)
+	current, err = e.readLease()
+	if err != nil {
+		e.fail(err)
+		return
+	}
+	if current == nil || current.Holder != e.instanceID {
+		holder := ""
+		if current != nil {
+			holder = current.Holder
+		}
+		e.set(false, holder, time.Time{})
+		return
+	}
+	e.set(true, e.instanceID, expires)
+}
+
+// Record the result of a campaign
+func (e *Elector) set(leader bool, holder string, expires time.Time) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.leader = leader
+	e.holder = holder
+	e.expires = expires
+	e.err = nil
+}
+
+// Record a failed campaign. The leader keeps its role until its lease would expire, since the other instances can't
+// take it over before then either.
+func (e *Elector) fail(err error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.leader && time.Now().After(e.expires) {
+		e.leader = false
+		e.holder = ""
+	}
+	e.err = err
+}
+
+// Read the lease file, or nil if there isn't one yet
+func (e *Elector) readLease() (*lease, error) {
+	bytes, err := os.ReadFile(e.leasePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading watchtower lease from [%s]: %w", e.leasePath, err)
+	}
+	var current lease
+	err = json.Unmarshal(bytes, &current)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing watchtower lease from [%s]: %w", e.leasePath, err)
+	}
+	return &current, nil
+}
+
+// Write the lease file
+func (e *Elector) writeLease(newLease lease) error {
+	bytes, err := json.Marshal(newLease)
+	if err != nil {
+		return fmt.Errorf("error serializing watchtower lease: %w", err)
+	}
+
+	err = files.WriteFileAtomic(e.leasePath, bytes, 0600)
+	if err != nil {
+		return fmt.Errorf("error saving watchtower lease: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The protocol events that can be published
+type EventType string
+
+const (
+	EventType_DepositPoolChanged EventType = "deposit_pool_changed"
+	EventType_DepositAssigned    EventType = "deposit_assigned"
+	EventType_MinipoolLaunched   EventType = "minipool_launched"
+	EventType_RewardsSnapshot    EventType = "rewards_snapshot"
+
+	// Oracle DAO challenges, published by the watchtower
+	EventType_ChallengeReceived  EventType = "challenge_received"
+	EventType_ChallengeResponded EventType = "challenge_responded"
+	EventType_ChallengeMade      EventType = "challenge_made"
+	EventType_ChallengeExpired   EventType = "challenge_expired"
+
+	// The rewards interval lifecycle, published by the watchtower
+	EventType_RewardsIntervalEnded          EventType = "rewards_interval_ended"
+	EventType_RewardsTreeGenerationStarted  EventType = "rewards_tree_generation_started"
+	EventType_RewardsTreeGenerationFinished EventType = "rewards_tree_generation_finished"
+	EventType_RewardsTreeSubmitted          EventType = "rewards_tree_submitted"
+	EventType_RewardsConsensusReached       EventType = "rewards_consensus_reached"
+)
+
+// The envelope every event is published in
+type Event struct {
+	Type    EventType      `json:"type"`
+	Network string         `json:"network"`
+	Node    common.Address `json:"node"`
+	Block   uint64         `json:"block"`
+	Slot    uint64         `json:"slot"`
+	Time    time.Time      `json:"time"`
+	Data    interface{}    `json:"data"`
+}
+
+// The data for a deposit_pool_changed event
+type DepositPoolData struct {
+	Balance       *big.Int `json:"balance"`
+	UserBalance   *big.Int `json:"userBalance"`
+	QueueLength   *big.Int `json:"queueLength"`
+	QueueCapacity *big.Int `json:"queueCapacity"`
+}
+
+// The data for deposit_assigned and minipool_launched events
+type MinipoolData struct {
+	Minipool           common.Address `json:"minipool"`
+	Pubkey             string         `json:"pubkey"`
+	NodeDepositBalance *big.Int       `json:"nodeDepositBalance"`
+	UserDepositBalance *big.Int       `json:"userDepositBalance"`
+}
+
+// The data for a rewards_snapshot event
+type RewardsSnapshotData struct {
+	Index uint64 `json:"index"`
+}
+
+// The data for the Oracle DAO challenge events
+type ChallengeData struct {
+	Member     common.Address `json:"member"`
+	Challenger common.Address `json:"challenger"`
+	Time       time.Time      `json:"time"`
+	Deadline   time.Time      `json:"deadline"`
+	Removed    bool           `json:"removed,omitempty"`
+}
+
+// The data for the rewards interval lifecycle events; the tree fields are only set once the tree has been generated,
+// and the snapshot fields are unset for rewards_consensus_reached
+type RewardsIntervalData struct {
+	Index           uint64    `json:"index"`
+	IntervalsPassed uint64    `json:"intervalsPassed,omitempty"`
+	StartTime       time.Time `json:"startTime,omitempty"`
+	EndTime         time.Time `json:"endTime,omitempty"`
+	ConsensusBlock  uint64    `json:"consensusBlock,omitempty"`
+	ExecutionBlock  uint64    `json:"executionBlock,omitempty"`
+	MerkleRoot      string    `json:"merkleRoot,omitempty"`
+	TreeCid         string    `json:"treeCid,omitempty"`
+}
+
+// A destination that events are published to
+type sink interface {
+	// The name of the sink, for error messages
+	name() string
+
+	// Send a serialized event of the given type
+	publish(eventType EventType, payload []byte) error
+}
+
+// Publishes events to every configured sink
+type Publisher struct {
+	network string
+	sinks   []sink
+	types   map[EventType]bool
+}
+
+// Create a publisher for the sinks in the config, or nil if none are configured
+func NewPublisher(cfg *config.RocketPoolConfig) (*Publisher, error) {
+	topic := cfg.Smartnode.EventTopic.Value.(string)
+	sinks := []sink{}
+	if url := cfg.Smartnode.EventWebhookUrl.Value.(string); url != "" {
+		sinks = append(sinks, newWebhookSink(url))
+	}
+	if url := cfg.Smartnode.EventNatsUrl.Value.(string); url != "" {
+		natsSink, err := newNatsSink(url, topic)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, natsSink)
+	}
+	if url := cfg.Smartnode.EventMqttUrl.Value.(string); url != "" {
+		mqttSink, err := newMqttSink(url, topic)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, mqttSink)
+	}
+	if len(sinks) == 0 {
+		return nil, nil
+	}
+
+	// Limit the events to the configured types, if there are any
+	var types map[EventType]bool
+	for _, eventType := range strings.Split(cfg.Smartnode.EventTypes.Value.(string), ",") {
+		eventType = strings.TrimSpace(eventType)
+		if eventType == "" {
+			continue
+		}
+		if types == nil {
+			types = map[EventType]bool{}
+		}
+		types[EventType(eventType)] = true
+	}
+
+	return &Publisher{
+		network: fmt.Sprint(cfg.Smartnode.Network.Value),
+		sinks:   sinks,
+		types:   types,
+	}, nil
+}
+
+// Publish an event to every sink; a failing sink doesn't stop the others from receiving it.
+// Events whose type isn't enabled are ignored.
+func (p *Publisher) Publish(event Event) error {
+	if p.types != nil && !p.types[event.Type] {
+		return nil
+	}
+	event.Network = p.network
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error serializing %s event: %w", event.Type, err)
+	}
+
+	errs := []error{}
+	for _, s := range p.sinks {
+		if err := s.publish(event.Type, payload); err != nil {
+			errs = append(errs, fmt.Errorf("error publishing %s event to %s: %w", event.Type, s.name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
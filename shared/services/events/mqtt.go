@@ -0,0 +1,149 @@
+package events
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"time"
+)
+
+// MQTT 3.1.1 control packet types
+const (
+	mqttConnect    byte = 0x10
+	mqttConnack    byte = 0x20
+	mqttPublish    byte = 0x30
+	mqttDisconnect byte = 0xe0
+)
+
+// Publishes each event to an MQTT topic named <topic>/<event type> at QoS 0.
+// Events are infrequent, so this uses a short-lived connection instead of a persistent client.
+type mqttSink struct {
+	address  string
+	useTls   bool
+	user     string
+	password string
+	topic    string
+}
+
+func newMqttSink(rawUrl string, topic string) (*mqttSink, error) {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MQTT URL [%s]: %w", rawUrl, err)
+	}
+	sink := &mqttSink{
+		topic: topic,
+	}
+	defaultPort := "1883"
+	switch parsedUrl.Scheme {
+	case "mqtt", "tcp":
+	case "mqtts", "ssl":
+		sink.useTls = true
+		defaultPort = "8883"
+	default:
+		return nil, fmt.Errorf("invalid MQTT URL [%s]: only mqtt://, tcp://, mqtts://, and ssl:// URLs are supported", rawUrl)
+	}
+	sink.address = parsedUrl.Host
+	if parsedUrl.Port() == "" {
+		sink.address = net.JoinHostPort(parsedUrl.Hostname(), defaultPort)
+	}
+	if parsedUrl.User != nil {
+		sink.user = parsedUrl.User.Username()
+		sink.password, _ = parsedUrl.User.Password()
+	}
+	return sink, nil
+}
+
+func (s *mqttSink) name() string {
+	return "MQTT"
+}
+
+func (s *mqttSink) publish(eventType EventType, payload []byte) error {
+	var conn net.Conn
+	var err error
+	dialer := &net.Dialer{Timeout: brokerTimeout}
+	if s.useTls {
+		conn, err = tls.DialWithDialer(dialer, "tcp", s.address, nil)
+	} else {
+		conn, err = dialer.Dial("tcp", s.address)
+	}
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(brokerTimeout))
+
+	// Connect with a clean session
+	var body bytes.Buffer
+	writeMqttString(&body, "MQTT")
+	body.WriteByte(4) // Protocol level 3.1.1
+	flags := byte(0x02)
+	if s.user != "" {
+		flags |= 0x80
+		if s.password != "" {
+			flags |= 0x40
+		}
+	}
+	body.WriteByte(flags)
+	_ = binary.Write(&body, binary.BigEndian, uint16(60)) // Keep alive
+	writeMqttString(&body, fmt.Sprintf("rocketpool-%d", time.Now().UnixNano()))
+	if s.user != "" {
+		writeMqttString(&body, s.user)
+		if s.password != "" {
+			writeMqttString(&body, s.password)
+		}
+	}
+	if err := writeMqttPacket(conn, mqttConnect, body.Bytes()); err != nil {
+		return err
+	}
+
+	// Make sure the broker accepted the connection
+	connack := make([]byte, 4)
+	if _, err := io.ReadFull(conn, connack); err != nil {
+		return fmt.Errorf("error reading connection acknowledgement: %w", err)
+	}
+	if connack[0] != mqttConnack {
+		return fmt.Errorf("unexpected response to connection: packet type 0x%x", connack[0])
+	}
+	if connack[3] != 0 {
+		return fmt.Errorf("broker refused the connection with return code %d", connack[3])
+	}
+
+	// Publish and disconnect cleanly, which makes the broker process the publish
+	body.Reset()
+	writeMqttString(&body, fmt.Sprintf("%s/%s", s.topic, eventType))
+	body.Write(payload)
+	if err := writeMqttPacket(conn, mqttPublish, body.Bytes()); err != nil {
+		return err
+	}
+	return writeMqttPacket(conn, mqttDisconnect, nil)
+}
+
+// Write a control packet with its variable-length remaining length header
+func writeMqttPacket(w io.Writer, packetType byte, body []byte) error {
+	packet := []byte{packetType}
+	length := len(body)
+	for {
+		digit := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			digit |= 0x80
+		}
+		packet = append(packet, digit)
+		if length == 0 {
+			break
+		}
+	}
+	packet = append(packet, body...)
+	_, err := w.Write(packet)
+	return err
+}
+
+// Write a length-prefixed UTF-8 string
+func writeMqttString(buffer *bytes.Buffer, value string) {
+	_ = binary.Write(buffer, binary.BigEndian, uint16(len(value)))
+	buffer.WriteString(value)
+}
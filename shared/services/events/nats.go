@@ -0,0 +1,103 @@
+package events
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// How long to wait when connecting to and publishing on a message broker
+const brokerTimeout = 10 * time.Second
+
+// Publishes each event to a NATS subject named <topic>.<event type>.
+// Events are infrequent, so this uses a short-lived connection with the plain text protocol instead of a persistent client.
+type natsSink struct {
+	address  string
+	user     string
+	password string
+	topic    string
+}
+
+func newNatsSink(rawUrl string, topic string) (*natsSink, error) {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NATS URL [%s]: %w", rawUrl, err)
+	}
+	if parsedUrl.Scheme != "nats" {
+		return nil, fmt.Errorf("invalid NATS URL [%s]: only nats:// URLs are supported", rawUrl)
+	}
+	address := parsedUrl.Host
+	if parsedUrl.Port() == "" {
+		address = net.JoinHostPort(parsedUrl.Hostname(), "4222")
+	}
+	sink := &natsSink{
+		address: address,
+		topic:   topic,
+	}
+	if parsedUrl.User != nil {
+		sink.user = parsedUrl.User.Username()
+		sink.password, _ = parsedUrl.User.Password()
+	}
+	return sink, nil
+}
+
+func (s *natsSink) name() string {
+	return "NATS"
+}
+
+func (s *natsSink) publish(eventType EventType, payload []byte) error {
+	conn, err := net.DialTimeout("tcp", s.address, brokerTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(brokerTimeout))
+	reader := bufio.NewReader(conn)
+
+	// The server greets every connection with its info
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("error reading server info: %w", err)
+	}
+	if !strings.HasPrefix(line, "INFO") {
+		return fmt.Errorf("unexpected greeting from server: %s", strings.TrimSpace(line))
+	}
+
+	// Connect, publish, and ping so the server's pong confirms it processed everything before it
+	options := map[string]interface{}{
+		"verbose":  false,
+		"pedantic": false,
+		"name":     "rocketpool",
+	}
+	if s.user != "" {
+		options["user"] = s.user
+		options["pass"] = s.password
+	}
+	connect, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+	subject := fmt.Sprintf("%s.%s", s.topic, eventType)
+	message := fmt.Sprintf("CONNECT %s\r\nPUB %s %d\r\n%s\r\nPING\r\n", connect, subject, len(payload), payload)
+	if _, err := conn.Write([]byte(message)); err != nil {
+		return err
+	}
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("error waiting for the server to acknowledge the event: %w", err)
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("server rejected the event: %s", line)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package exitqueue
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/sweep"
+)
+
+// Exit queue parameters from the consensus spec (Capella and Deneb)
+const (
+	FarFutureEpoch                   uint64 = 0xffffffffffffffff
+	MinPerEpochChurnLimit            uint64 = 4
+	ChurnLimitQuotient               uint64 = 65536
+	MaxSeedLookahead                 uint64 = 4
+	MinValidatorWithdrawabilityDelay uint64 = 256
+)
+
+// The validator states that count towards the churn limit
+var activeStates = []beacon.ValidatorState{
+	beacon.ValidatorState_ActiveOngoing,
+	beacon.ValidatorState_ActiveExiting,
+	beacon.ValidatorState_ActiveSlashed,
+}
+
+// The state of the exit queue at an epoch
+type Queue struct {
+	// The epoch the queue was measured at
+	Epoch uint64 `json:"epoch"`
+
+	// The number of active validators, which sets the churn limit
+	ActiveValidatorCount uint64 `json:"activeValidatorCount"`
+
+	// How many validators can exit per epoch
+	ChurnLimit uint64 `json:"churnLimit"`
+
+	// The number of validators that have initiated an exit but haven't exited yet
+	Length uint64 `json:"length"`
+
+	// The latest exit epoch assigned to a validator in the queue, or 0 if the queue is empty
+	LastExitEpoch uint64 `json:"lastExitEpoch"`
+
+	// How many validators have already been assigned LastExitEpoch
+	LastExitEpochChurn uint64 `json:"lastExitEpochChurn"`
+}
+
+// Get the number of validators that can exit per epoch
+func GetChurnLimit(activeValidatorCount uint64) uint64 {
+	return max(MinPerEpochChurnLimit, activeValidatorCount/ChurnLimitQuotient)
+}
+
+// Measure the exit queue at the Beacon head. This downloads every active validator, so it can take a while.
+func Measure(bc beacon.Client) (Queue, error) {
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return Queue{}, fmt.Errorf("error getting Beacon head: %w", err)
+	}
+	validators, err := bc.GetValidatorStatusesByState(activeStates, &beacon.ValidatorStatusOptions{Epoch: &head.Epoch})
+	if err != nil {
+		return Queue{}, fmt.Errorf("error getting active validators: %w", err)
+	}
+	return NewQueue(head.Epoch, validators), nil
+}
+
+// Build the exit queue from the validators that were active at an epoch
+func NewQueue(epoch uint64, activeValidators []beacon.ValidatorStatus) Queue {
+	queue := Queue{
+		Epoch:                epoch,
+		ActiveValidatorCount: uint64(len(activeValidators)),
+		ChurnLimit:           GetChurnLimit(uint64(len(activeValidators))),
+	}
+	for _, validator := range activeValidators {
+		if validator.ExitEpoch == FarFutureEpoch {
+			continue
+		}
+		queue.Length++
+		if validator.ExitEpoch > queue.LastExitEpoch {
+			queue.LastExitEpoch = validator.ExitEpoch
+			queue.LastExitEpochChurn = 0
+		}
+		if validator.ExitEpoch == queue.LastExitEpoch {
+			queue.LastExitEpochChurn++
+		}
+	}
+	return queue
+}
+
+// Get the exit epoch a validator would be assigned if it initiated an exit now, behind position others that exit with it.
+// This follows initiate_validator_exit, assuming the exit is processed in the next epoch.
+func (q Queue) GetExitEpoch(position uint64) uint64 {
+	exitEpoch := q.Epoch + 1 + 1 + MaxSeedLookahead
+	churn := uint64(0)
+	if q.LastExitEpoch >= exitEpoch {
+		exitEpoch = q.LastExitEpoch
+		churn = q.LastExitEpochChurn
+	}
+
+	// Fill up the rest of the first epoch, then every epoch after it
+	return exitEpoch + (churn+position)/q.ChurnLimit
+}
+
+// Get the epoch an exited validator's balance becomes withdrawable at
+func GetWithdrawableEpoch(exitEpoch uint64) uint64 {
+	return exitEpoch + MinValidatorWithdrawabilityDelay
+}
+
+// A projection of when a validator will exit and be fully withdrawn
+type Projection struct {
+	// True if the validator has already initiated its exit, so the epochs are assigned rather than projected
+	Initiated bool `json:"initiated"`
+
+	// The epoch the validator stops attesting
+	ExitEpoch uint64 `json:"exitEpoch"`
+
+	// The epoch the validator's balance becomes withdrawable
+	WithdrawableEpoch uint64 `json:"withdrawableEpoch"`
+
+	// The estimated slot the sweep withdraws the validator's balance in
+	WithdrawalSlot uint64 `json:"withdrawalSlot"`
+}
+
+// Project when a set of active validators will exit and be fully withdrawn if the ones that haven't initiated an exit yet
+// all do so now, in order
+func (q Queue) ProjectValidators(validators []beacon.ValidatorStatus, progress sweep.Progress, slotsPerEpoch uint64) ([]Projection, error) {
+	projections := make([]Projection, len(validators))
+	position := uint64(0)
+	for i, validator := range validators {
+		index, err := strconv.ParseUint(validator.Index, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing validator index [%s]: %w", validator.Index, err)
+		}
+
+		projection := Projection{}
+		if validator.ExitEpoch != FarFutureEpoch {
+			projection.Initiated = true
+			projection.ExitEpoch = validator.ExitEpoch
+			projection.WithdrawableEpoch = validator.WithdrawableEpoch
+		} else {
+			projection.ExitEpoch = q.GetExitEpoch(position)
+			projection.WithdrawableEpoch = GetWithdrawableEpoch(projection.ExitEpoch)
+			position++
+		}
+		projection.WithdrawalSlot = progress.GetNextSweepSlot(index, projection.WithdrawableEpoch*slotsPerEpoch)
+		projections[i] = projection
+	}
+	return projections, nil
+}
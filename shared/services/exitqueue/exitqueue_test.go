@@ -0,0 +1,77 @@
+package exitqueue
+
+import (
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/sweep"
+)
+
+func TestGetChurnLimit(t *testing.T) {
+	if limit := GetChurnLimit(100000); limit != MinPerEpochChurnLimit {
+		t.Fatalf("expected the minimum churn limit for a small validator set, got %d", limit)
+	}
+	if limit := GetChurnLimit(1000000); limit != 15 {
+		t.Fatalf("expected a churn limit of 15 for 1000000 validators, got %d", limit)
+	}
+}
+
+func TestGetExitEpoch(t *testing.T) {
+	// An empty queue exits at the earliest possible epoch
+	queue := Queue{Epoch: 100, ChurnLimit: 4}
+	if epoch := queue.GetExitEpoch(0); epoch != 106 {
+		t.Fatalf("expected an exit at epoch 106 with an empty queue, got %d", epoch)
+	}
+	if epoch := queue.GetExitEpoch(4); epoch != 107 {
+		t.Fatalf("expected the fifth exit at epoch 107 with an empty queue, got %d", epoch)
+	}
+
+	// A long queue fills the rest of its last epoch first
+	queue.LastExitEpoch = 200
+	queue.LastExitEpochChurn = 3
+	if epoch := queue.GetExitEpoch(0); epoch != 200 {
+		t.Fatalf("expected an exit at epoch 200 behind the queue, got %d", epoch)
+	}
+	if epoch := queue.GetExitEpoch(1); epoch != 201 {
+		t.Fatalf("expected the second exit at epoch 201 behind the queue, got %d", epoch)
+	}
+	if epoch := queue.GetExitEpoch(5); epoch != 202 {
+		t.Fatalf("expected the sixth exit at epoch 202 behind the queue, got %d", epoch)
+	}
+}
+
+func TestProjectValidators(t *testing.T) {
+	// Two validators are already exiting, both in epoch 110
+	active := []beacon.ValidatorStatus{
+		{Index: "0", ExitEpoch: FarFutureEpoch},
+		{Index: "1", ExitEpoch: 110, WithdrawableEpoch: 366},
+		{Index: "2", ExitEpoch: 110, WithdrawableEpoch: 366},
+	}
+	queue := NewQueue(100, active)
+	if queue.Length != 2 || queue.LastExitEpoch != 110 || queue.LastExitEpochChurn != 2 || queue.ChurnLimit != MinPerEpochChurnLimit {
+		t.Fatalf("unexpected queue %+v", queue)
+	}
+
+	// A sweep that passes 1 validator per slot over 32 validators, so it starts each epoch at index 0
+	progress := sweep.Progress{Slot: 0, NextIndex: 0, ValidatorCount: 32, ValidatorsPerSlot: 1}
+	projections, err := queue.ProjectValidators([]beacon.ValidatorStatus{
+		{Index: "0", ExitEpoch: FarFutureEpoch},
+		{Index: "1", ExitEpoch: 110, WithdrawableEpoch: 366},
+		{Index: "3", ExitEpoch: FarFutureEpoch},
+		{Index: "4", ExitEpoch: FarFutureEpoch},
+	}, progress, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Projection{
+		{Initiated: false, ExitEpoch: 110, WithdrawableEpoch: 366, WithdrawalSlot: 366*32 + 1},
+		{Initiated: true, ExitEpoch: 110, WithdrawableEpoch: 366, WithdrawalSlot: 366*32 + 2},
+		{Initiated: false, ExitEpoch: 110, WithdrawableEpoch: 366, WithdrawalSlot: 366*32 + 4},
+		{Initiated: false, ExitEpoch: 111, WithdrawableEpoch: 367, WithdrawalSlot: 367*32 + 5},
+	}
+	for i := range expected {
+		if projections[i] != expected[i] {
+			t.Fatalf("expected projection %d to be %+v, got %+v", i, expected[i], projections[i])
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package faults
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	mathrand "math/rand"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The clients faults can be injected into
+const (
+	Client_Execution string = "ec"
+	Client_Beacon    string = "bc"
+)
+
+// A kind of fault to inject
+type Kind string
+
+const (
+	// Hang until the delay passes or the request's context expires, then fail with a timeout
+	Kind_Timeout Kind = "timeout"
+
+	// Fail as if the client refused the connection, which makes the client managers switch to the fallback
+	Kind_Disconnect Kind = "disconnect"
+
+	// Return a truncated response body
+	Kind_Malformed Kind = "malformed"
+
+	// Make block queries look like the block was reorged: the EC returns a block with a different parent hash,
+	// and the BC reports the block as missing
+	Kind_Reorg Kind = "reorg"
+)
+
+// How long a timeout fault hangs for if the rule doesn't say
+const defaultTimeoutDelay time.Duration = 30 * time.Second
+
+// A rule describing which requests to inject a fault into
+type Rule struct {
+	// The client to inject into, ec or bc
+	Client string `yaml:"client"`
+
+	// The daemons to inject into (api, node, or watchtower); all of them if empty
+	Daemons []string `yaml:"daemons,omitempty"`
+
+	// A substring of the JSON-RPC method (EC) or request path (BC) to match; all requests if empty
+	Match string `yaml:"match,omitempty"`
+
+	// The fault to inject
+	Fault Kind `yaml:"fault"`
+
+	// The chance of injecting the fault into each matching request, from 0 to 1; always if omitted
+	Probability *float64 `yaml:"probability,omitempty"`
+
+	// How long timeout faults hang for, such as 30s
+	Delay string `yaml:"delay,omitempty"`
+
+	delay time.Duration
+}
+
+// The fault injection settings
+type Config struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Injects faults into the requests a daemon makes to its clients
+type Injector struct {
+	daemon string
+	rules  []Rule
+	log    log.ColorLogger
+}
+
+// Load the fault injection settings for a daemon from the provided path.
+// Returns nil if the file doesn't exist, in which case no faults are injected. This is deliberately not part of the
+// regular configuration; it's only meant for testing failover and retry behavior.
+func Load(path string, daemon string) (*Injector, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading fault injection settings %s: %w", path, err)
+	}
+
+	config := Config{}
+	if err := yaml.Unmarshal(bytes, &config); err != nil {
+		return nil, fmt.Errorf("error parsing fault injection settings %s: %w", path, err)
+	}
+
+	// Keep the rules that apply to this daemon
+	injector := &Injector{
+		daemon: daemon,
+		rules:  []Rule{},
+		log:    log.NewColorLogger(color.FgHiRed),
+	}
+	for i, rule := range config.Rules {
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("fault injection rule %d is invalid: %w", i, err)
+		}
+		if len(rule.Daemons) > 0 && !slices.Contains(rule.Daemons, daemon) {
+			continue
+		}
+		injector.rules = append(injector.rules, rule)
+	}
+	if len(injector.rules) == 0 {
+		return nil, nil
+	}
+	injector.log.Printlnf("WARNING: fault injection is enabled by %s with %d rule(s) for this daemon. Requests to the clients will fail on purpose.", path, len(injector.rules))
+	return injector, nil
+}
+
+// Wrap an HTTP transport for the given client with the injector. A nil injector returns the transport unchanged.
+func (i *Injector) Wrap(client string, base http.RoundTripper) http.RoundTripper {
+	if i == nil {
+		return base
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{
+		injector: i,
+		client:   client,
+		base:     base,
+	}
+}
+
+// Check that a rule can be applied
+func (r *Rule) validate() error {
+	if r.Client != Client_Execution && r.Client != Client_Beacon {
+		return fmt.Errorf("unknown client [%s], expected %s or %s", r.Client, Client_Execution, Client_Beacon)
+	}
+	switch r.Fault {
+	case Kind_Timeout, Kind_Disconnect, Kind_Malformed, Kind_Reorg:
+	default:
+		return fmt.Errorf("unknown fault [%s]", r.Fault)
+	}
+	if r.Probability != nil && (*r.Probability < 0 || *r.Probability > 1) {
+		return fmt.Errorf("probability %f is not between 0 and 1", *r.Probability)
+	}
+	r.delay = defaultTimeoutDelay
+	if r.Delay != "" {
+		delay, err := time.ParseDuration(r.Delay)
+		if err != nil {
+			return fmt.Errorf("invalid delay [%s]: %w", r.Delay, err)
+		}
+		r.delay = delay
+	}
+	return nil
+}
+
+// An HTTP transport that injects faults into matching requests
+type transport struct {
+	injector *Injector
+	client   string
+	base     http.RoundTripper
+}
+
+// Send a request, injecting the first matching fault
+func (t *transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	// Get what the request is for
+	target, err := t.getTarget(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find a rule to apply
+	var rule *Rule
+	for i := range t.injector.rules {
+		candidate := &t.injector.rules[i]
+		if candidate.Client != t.client || !strings.Contains(target, candidate.Match) {
+			continue
+		}
+		if candidate.Probability != nil && mathrand.Float64() >= *candidate.Probability {
+			continue
+		}
+		rule = candidate
+		break
+	}
+	if rule == nil {
+		return t.base.RoundTrip(request)
+	}
+	t.injector.log.Printlnf("Injecting a %s fault into %s request [%s].", rule.Fault, t.client, target)
+
+	switch rule.Fault {
+	case Kind_Timeout:
+		timer := time.NewTimer(rule.delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-request.Context().Done():
+		}
+		return nil, fmt.Errorf("fault injection: simulated timeout: %w", context.DeadlineExceeded)
+
+	case Kind_Disconnect:
+		// The client managers look for this to decide whether to switch to the fallback
+		return nil, fmt.Errorf("dial tcp %s: fault injection: simulated connection refused", request.URL.Host)
+	}
+
+	// Only block queries can be reorged on the BC
+	if rule.Fault == Kind_Reorg && t.client == Client_Beacon && !strings.Contains(target, "/blocks/") && !strings.Contains(target, "/headers/") {
+		return t.base.RoundTrip(request)
+	}
+
+	// The other faults alter a real response
+	response, err := t.base.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	switch rule.Fault {
+	case Kind_Malformed:
+		body = body[:len(body)/2]
+
+	case Kind_Reorg:
+		if t.client == Client_Beacon {
+			response.StatusCode = http.StatusNotFound
+			response.Status = http.StatusText(http.StatusNotFound)
+			body = []byte(`{"code":404,"message":"fault injection: simulated reorg"}`)
+		} else {
+			body = reorgBlock(body)
+		}
+	}
+
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	response.ContentLength = int64(len(body))
+	response.Header.Del("Content-Length")
+	return response, nil
+}
+
+// Get the JSON-RPC method(s) of an EC request, or the path of a BC request
+func (t *transport) getTarget(request *http.Request) (string, error) {
+	if t.client == Client_Beacon || request.Body == nil {
+		return request.URL.Path, nil
+	}
+
+	// Read the body and put it back for the real request
+	body, err := io.ReadAll(request.Body)
+	_ = request.Body.Close()
+	if err != nil {
+		return "", err
+	}
+	request.Body = io.NopCloser(bytes.NewReader(body))
+
+	type rpcRequest struct {
+		Method string `json:"method"`
+	}
+	single := rpcRequest{}
+	if err := json.Unmarshal(body, &single); err == nil {
+		return single.Method, nil
+	}
+	batch := []rpcRequest{}
+	if err := json.Unmarshal(body, &batch); err == nil {
+		methods := make([]string, len(batch))
+		for i, request := range batch {
+			methods[i] = request.Method
+		}
+		return strings.Join(methods, ","), nil
+	}
+	return "", nil
+}
+
+// Give the block in a JSON-RPC response a random parent hash, so its hash no longer matches the canonical block
+func reorgBlock(body []byte) []byte {
+	response := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return body
+	}
+	block := map[string]json.RawMessage{}
+	if err := json.Unmarshal(response["result"], &block); err != nil || block["parentHash"] == nil {
+		return body
+	}
+
+	parentHash := make([]byte, 32)
+	_, _ = rand.Read(parentHash)
+	block["parentHash"] = json.RawMessage(fmt.Sprintf(`"0x%s"`, hex.EncodeToString(parentHash)))
+	result, err := json.Marshal(block)
+	if err != nil {
+		return body
+	}
+	response["result"] = result
+	altered, err := json.Marshal(response)
+	if err != nil {
+		return body
+	}
+	return altered
+}
@@ -0,0 +1,89 @@
+package faults
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSettings = `rules:
+  - client: ec
+    match: eth_getBlockByNumber
+    fault: reorg
+  - client: ec
+    daemons: [watchtower]
+    fault: disconnect
+  - client: bc
+    match: /eth/v1/node
+    fault: malformed
+`
+
+const testBlock = `{"jsonrpc":"2.0","id":1,"result":{"number":"0x1","parentHash":"0x00"}}`
+
+func TestFaultInjection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testBlock))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "fault-injection.yml")
+	if err := os.WriteFile(path, []byte(testSettings), 0644); err != nil {
+		t.Fatal(err)
+	}
+	injector, err := Load(path, "node")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	send := func(client string, path string, body string) (string, error) {
+		httpClient := &http.Client{Transport: injector.Wrap(client, nil)}
+		response, err := httpClient.Post(server.URL+path, "application/json", strings.NewReader(body))
+		if err != nil {
+			return "", err
+		}
+		defer response.Body.Close()
+		bytes, err := io.ReadAll(response.Body)
+		return string(bytes), err
+	}
+
+	// Reorged blocks get a new parent hash
+	body, err := send(Client_Execution, "", `{"method":"eth_getBlockByNumber"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body == testBlock || strings.Contains(body, `"parentHash":"0x00"`) {
+		t.Fatalf("expected the parent hash to be changed, got %s", body)
+	}
+
+	// The disconnect rule only applies to the watchtower
+	body, err = send(Client_Execution, "", `{"method":"eth_chainId"}`)
+	if err != nil || body != testBlock {
+		t.Fatalf("expected an untouched response, got %s (%v)", body, err)
+	}
+
+	// Malformed responses are truncated
+	body, err = send(Client_Beacon, "/eth/v1/node/syncing", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body != testBlock[:len(testBlock)/2] {
+		t.Fatalf("expected a truncated response, got %s", body)
+	}
+}
+
+func TestFaultInjectionDisabled(t *testing.T) {
+	injector, err := Load(filepath.Join(t.TempDir(), "fault-injection.yml"), "node")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if injector != nil {
+		t.Fatal("expected no injector without a settings file")
+	}
+	if injector.Wrap(Client_Execution, http.DefaultTransport) != http.DefaultTransport {
+		t.Fatal("expected a nil injector to leave the transport alone")
+	}
+}
@@ -0,0 +1,95 @@
+package finality
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// Inactivity leak parameters from the consensus spec (Bellatrix and later)
+const (
+	// The number of epochs without finality before the inactivity leak starts
+	MinEpochsToInactivityPenalty uint64 = 4
+
+	inactivityScoreBias          uint64 = 4
+	inactivityScoreRecoveryRate  uint64 = 16
+	inactivityPenaltyQuotient    uint64 = 1 << 24
+	inactivityPenaltyDenominator uint64 = inactivityScoreBias * inactivityPenaltyQuotient
+)
+
+// The finality of the Beacon Chain at the latest check
+type Status struct {
+	HeadEpoch           uint64
+	FinalizedEpoch      uint64
+	EpochsSinceFinality uint64
+
+	// True if the chain has gone at least the threshold number of epochs without finalizing
+	Stalled bool
+
+	// True if Stalled is different from the previous check
+	Changed bool
+}
+
+// Watches the Beacon Chain for finality stalls
+type Monitor struct {
+	bc        beacon.Client
+	threshold uint64
+	stalled   bool
+}
+
+// Create a monitor that considers finality stalled after threshold epochs without it; a threshold of 0 disables it
+func NewMonitor(bc beacon.Client, threshold uint64) *Monitor {
+	return &Monitor{
+		bc:        bc,
+		threshold: threshold,
+	}
+}
+
+// Check the chain's finality
+func (m *Monitor) Check() (Status, error) {
+	head, err := m.bc.GetBeaconHead()
+	if err != nil {
+		return Status{}, fmt.Errorf("error getting Beacon head: %w", err)
+	}
+
+	status := Status{
+		HeadEpoch:      head.Epoch,
+		FinalizedEpoch: head.FinalizedEpoch,
+	}
+	if head.Epoch > head.FinalizedEpoch {
+		status.EpochsSinceFinality = head.Epoch - head.FinalizedEpoch
+	}
+	status.Stalled = m.threshold > 0 && status.EpochsSinceFinality >= m.threshold
+	status.Changed = status.Stalled != m.stalled
+	m.stalled = status.Stalled
+	return status, nil
+}
+
+// Get whether finality was stalled at the latest check
+func (m *Monitor) IsStalled() bool {
+	return m.stalled
+}
+
+// Project the inactivity penalties (in gwei) a validator that has been online so far would take if it went offline now
+// and the chain didn't finalize for another horizonEpochs epochs.
+// Effective balance reductions during the horizon aren't modeled, so this errs slightly on the high side.
+func ProjectInactivityLeak(effectiveBalanceGwei uint64, epochsSinceFinality uint64, horizonEpochs uint64) uint64 {
+	balance := new(big.Int).SetUint64(effectiveBalanceGwei)
+	denominator := new(big.Int).SetUint64(inactivityPenaltyDenominator)
+	total := uint64(0)
+	score := uint64(0)
+	for i := uint64(1); i <= horizonEpochs; i++ {
+		// Missing the target vote raises the score, and it only decays while the chain is finalizing
+		score += inactivityScoreBias
+		if epochsSinceFinality+i <= MinEpochsToInactivityPenalty {
+			score -= min(inactivityScoreRecoveryRate, score)
+		}
+
+		penalty := new(big.Int).SetUint64(score)
+		penalty.Mul(penalty, balance)
+		penalty.Quo(penalty, denominator)
+		total += penalty.Uint64()
+	}
+	return total
+}
@@ -0,0 +1,37 @@
+package finality
+
+import (
+	"testing"
+)
+
+const fullEffectiveBalanceGwei uint64 = 32e9
+
+func TestProjectInactivityLeakWhileFinalizing(t *testing.T) {
+	// Scores decay faster than they grow until the leak starts, so there's no penalty yet
+	penalty := ProjectInactivityLeak(fullEffectiveBalanceGwei, 0, MinEpochsToInactivityPenalty)
+	if penalty != 0 {
+		t.Fatalf("expected no penalty before the leak starts, got %d gwei", penalty)
+	}
+}
+
+func TestProjectInactivityLeakDuringLeak(t *testing.T) {
+	// Each epoch costs score * balance / (4 * 2^24), and the score grows by 4 per epoch
+	penalty := ProjectInactivityLeak(fullEffectiveBalanceGwei, 10, 1)
+	if penalty != 1907 {
+		t.Fatalf("expected a 1907 gwei penalty for the first epoch, got %d", penalty)
+	}
+
+	expected := uint64(0)
+	for i := uint64(1); i <= 225; i++ {
+		expected += i * fullEffectiveBalanceGwei / (1 << 24)
+	}
+	penalty = ProjectInactivityLeak(fullEffectiveBalanceGwei, 10, 225)
+	if penalty != expected {
+		t.Fatalf("expected a %d gwei penalty over a day, got %d", expected, penalty)
+	}
+
+	// The penalty grows quadratically, so a day of leaking costs far more than 225 times the first epoch
+	if penalty <= 225*1907 {
+		t.Fatalf("expected the penalty to grow quadratically, got %d gwei", penalty)
+	}
+}
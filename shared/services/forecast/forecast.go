@@ -0,0 +1,159 @@
+package forecast
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// The shortest period a burn rate is measured over, so a few transactions close together don't inflate it
+const minWindow time.Duration = 24 * time.Hour
+
+// A transaction fee the node wallet paid for an automated transaction
+type Spend struct {
+	Time    time.Time `json:"time"`
+	Source  string    `json:"source"`
+	Command string    `json:"command"`
+	TxHash  string    `json:"txHash"`
+	FeeWei  *big.Int  `json:"feeWei"`
+}
+
+// An append-only record of the fees the node and watchtower daemons pay for their transactions
+type Ledger struct {
+	path string
+}
+
+// Create a new ledger handle for the file at the provided path
+func NewLedger(path string) *Ledger {
+	return &Ledger{
+		path: path,
+	}
+}
+
+// Append a spend to the ledger
+func (l *Ledger) Record(spend Spend) error {
+	err := os.MkdirAll(filepath.Dir(l.path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating gas spend ledger directory: %w", err)
+	}
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening gas spend ledger: %w", err)
+	}
+	defer file.Close()
+
+	// The node and watchtower both write to the same ledger, so lock it while appending
+	err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX)
+	if err != nil {
+		return fmt.Errorf("error locking gas spend ledger: %w", err)
+	}
+	defer func() {
+		_ = syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+	}()
+
+	bytes, err := json.Marshal(spend)
+	if err != nil {
+		return fmt.Errorf("error serializing gas spend: %w", err)
+	}
+	_, err = file.Write(append(bytes, '\n'))
+	if err != nil {
+		return fmt.Errorf("error writing gas spend: %w", err)
+	}
+	return nil
+}
+
+// Read the spends made since the provided time. A missing ledger is treated as an empty one.
+func (l *Ledger) Read(since time.Time) ([]Spend, error) {
+	file, err := os.Open(l.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return []Spend{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening gas spend ledger: %w", err)
+	}
+	defer file.Close()
+
+	spends := []Spend{}
+	scanner := bufio.NewScanner(file)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var spend Spend
+		err := json.Unmarshal(scanner.Bytes(), &spend)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing gas spend ledger line %d: %w", line, err)
+		}
+		if spend.FeeWei == nil || spend.Time.Before(since) {
+			continue
+		}
+		spends = append(spends, spend)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading gas spend ledger: %w", err)
+	}
+	return spends, nil
+}
+
+// A forecast of when the node wallet's balance will be too low to pay for its next duties
+type Forecast struct {
+	BalanceEth        float64
+	Transactions      int
+	Window            time.Duration
+	BurnRateEthPerDay float64
+	AverageFeeEth     float64
+	Duties            uint64
+	ReserveEth        float64
+
+	// The number of days until the balance drops below the reserve at the current burn rate; 0 if it's already below
+	// it, and +Inf if nothing has been spent
+	DaysUntilReserve float64
+}
+
+// Forecast the node wallet's balance from the provided spends, with a reserve that covers the provided number of duties
+// at their average fee. The burn rate is measured from the oldest spend until now.
+func NewForecast(balance *big.Int, spends []Spend, now time.Time, duties uint64) Forecast {
+	forecast := Forecast{
+		BalanceEth:       eth.WeiToEth(balance),
+		Transactions:     len(spends),
+		Duties:           duties,
+		DaysUntilReserve: math.Inf(1),
+	}
+	if len(spends) == 0 {
+		return forecast
+	}
+
+	total := big.NewInt(0)
+	oldest := now
+	for _, spend := range spends {
+		total.Add(total, spend.FeeWei)
+		if spend.Time.Before(oldest) {
+			oldest = spend.Time
+		}
+	}
+	window := max(now.Sub(oldest), minWindow)
+	forecast.Window = window
+	totalEth := eth.WeiToEth(total)
+	forecast.BurnRateEthPerDay = totalEth / window.Hours() * 24
+	forecast.AverageFeeEth = totalEth / float64(len(spends))
+	forecast.ReserveEth = forecast.AverageFeeEth * float64(duties)
+
+	if forecast.BalanceEth <= forecast.ReserveEth {
+		forecast.DaysUntilReserve = 0
+	} else if forecast.BurnRateEthPerDay > 0 {
+		forecast.DaysUntilReserve = (forecast.BalanceEth - forecast.ReserveEth) / forecast.BurnRateEthPerDay
+	}
+	return forecast
+}
@@ -0,0 +1,69 @@
+package forecast
+
+import (
+	"math"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+func TestNewForecast(t *testing.T) {
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	spends := []Spend{
+		{Time: now.Add(-96 * time.Hour), FeeWei: eth.EthToWei(0.01)},
+		{Time: now.Add(-48 * time.Hour), FeeWei: eth.EthToWei(0.01)},
+		{Time: now.Add(-1 * time.Hour), FeeWei: eth.EthToWei(0.02)},
+	}
+
+	// 0.04 ETH over 4 days is 0.01 ETH a day, and 10 duties at 0.04 / 3 ETH each need about 0.133 ETH
+	forecast := NewForecast(eth.EthToWei(0.5), spends, now, 10)
+	if math.Abs(forecast.BurnRateEthPerDay-0.01) > 1e-9 {
+		t.Errorf("expected a burn rate of 0.01 ETH/day, got %f", forecast.BurnRateEthPerDay)
+	}
+	if math.Abs(forecast.ReserveEth-0.4/3) > 1e-9 {
+		t.Errorf("expected a reserve of %f ETH, got %f", 0.4/3, forecast.ReserveEth)
+	}
+	if expected := (0.5 - 0.4/3) / 0.01; math.Abs(forecast.DaysUntilReserve-expected) > 1e-6 {
+		t.Errorf("expected %f days until the reserve, got %f", expected, forecast.DaysUntilReserve)
+	}
+
+	// A balance below the reserve has no days left
+	forecast = NewForecast(eth.EthToWei(0.1), spends, now, 10)
+	if forecast.DaysUntilReserve != 0 {
+		t.Errorf("expected no days until the reserve, got %f", forecast.DaysUntilReserve)
+	}
+
+	// Recent spends are measured over at least a day
+	forecast = NewForecast(eth.EthToWei(1), spends[2:], now, 10)
+	if forecast.Window != minWindow || math.Abs(forecast.BurnRateEthPerDay-0.02) > 1e-9 {
+		t.Errorf("expected a burn rate of 0.02 ETH/day over %s, got %f over %s", minWindow, forecast.BurnRateEthPerDay, forecast.Window)
+	}
+
+	// Nothing spent means the balance never runs out
+	forecast = NewForecast(eth.EthToWei(1), []Spend{}, now, 10)
+	if !math.IsInf(forecast.DaysUntilReserve, 1) {
+		t.Errorf("expected no forecast without spends, got %f days", forecast.DaysUntilReserve)
+	}
+}
+
+func TestLedger(t *testing.T) {
+	ledger := NewLedger(filepath.Join(t.TempDir(), "gas-spend.jsonl"))
+	now := time.Now().UTC()
+	for i, age := range []time.Duration{10 * 24 * time.Hour, time.Hour} {
+		err := ledger.Record(Spend{Time: now.Add(-age), Source: "node", TxHash: string(rune('a' + i)), FeeWei: big.NewInt(int64(i + 1))})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	spends, err := ledger.Read(now.Add(-7 * 24 * time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spends) != 1 || spends[0].FeeWei.Int64() != 2 {
+		t.Errorf("expected only the recent spend, got %+v", spends)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services/gas/etherchain"
 	"github.com/rocket-pool/smartnode/shared/services/gas/etherscan"
+	"github.com/rocket-pool/smartnode/shared/services/gas/thresholds"
 	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
@@ -38,8 +39,16 @@ func (g *Gas) Assign(rp *rpsvc.Client) {
 	return
 }
 
+// The selected max fee, in gwei
+func (g *Gas) MaxFeeGwei() float64 {
+	return g.maxFeeGwei
+}
+
 func GetMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless bool) (Gas, error) {
 
+	// Never prompt for gas in non-interactive mode
+	headless = headless || cliutils.IsNonInteractive()
+
 	cfg, isNew, err := rp.LoadConfig()
 	if err != nil {
 		return Gas{}, fmt.Errorf("Error getting Rocket Pool configuration: %w", err)
@@ -136,6 +145,12 @@ func GetMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless bo
 	} else if response.Balance.Cmp(ethRequired) < 0 {
 		return Gas{}, fmt.Errorf("Your node has %.6f ETH in its wallet, which is not enough to pay for this transaction with a max fee of %.4f gwei; you require at least %.6f more ETH.", eth.WeiToEth(response.Balance), maxFeeGwei, eth.WeiToEth(big.NewInt(0).Sub(ethRequired, response.Balance)))
 	}
+
+	// Make sure the user is fine with the cost if it's over their threshold for this command
+	err = confirmCost(cfg.Smartnode.GetGasThresholdsPath(false), rp.GetCommand(), eth.WeiToEth(ethRequired), headless)
+	if err != nil {
+		return Gas{}, err
+	}
 	return Gas{maxFeeGwei, maxPriorityFeeGwei, gasLimit}, nil
 
 }
@@ -328,3 +343,25 @@ func handleEtherscanGasPrices(gasSuggestion etherscan.GasFeeSuggestion, gasInfo
 	}
 
 }
+
+// Ask for confirmation if a transaction's projected cost (in ETH) exceeds the command's confirmation threshold.
+// There's nobody to ask in headless mode, so the transaction is aborted instead.
+func confirmCost(thresholdsPath string, command string, costEth float64, headless bool) error {
+	gasThresholds, err := thresholds.Load(thresholdsPath)
+	if err != nil {
+		return err
+	}
+	threshold, exists := gasThresholds.GetCommandThreshold(command)
+	if !exists || costEth <= threshold {
+		return nil
+	}
+
+	message := fmt.Sprintf("This transaction could cost up to %.6f ETH, which is more than your confirmation threshold of %.6f ETH for `%s`.", costEth, threshold, command)
+	if headless {
+		return fmt.Errorf("%s Aborting since this command isn't running interactively; you can change the threshold with `rocketpool service config gas command`.", message)
+	}
+	if !cliutils.Confirm(fmt.Sprintf("%s%s%s\nDo you want to continue?", colorYellow, message, colorReset)) {
+		return fmt.Errorf("Cancelled.")
+	}
+	return nil
+}
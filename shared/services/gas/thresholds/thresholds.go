@@ -0,0 +1,130 @@
+package thresholds
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The node daemon's automatic transactions that can have their own gas ceiling
+const (
+	Task_AutoInitVotingPower     string = "auto-init-voting-power"
+	Task_DefendPdaoProps         string = "defend-pdao-props"
+	Task_DistributeMinipools     string = "distribute-minipools"
+	Task_PromoteMinipools        string = "promote-minipools"
+	Task_ReduceBonds             string = "reduce-bonds"
+	Task_RelayClaims             string = "relay-claims"
+	Task_StakePrelaunchMinipools string = "stake-prelaunch-minipools"
+	Task_UpgradeDelegates        string = "upgrade-delegates"
+	Task_VerifyPdaoProps         string = "verify-pdao-props"
+)
+
+// All of the tasks that can have their own gas ceiling
+var Tasks = []string{
+	Task_AutoInitVotingPower,
+	Task_DefendPdaoProps,
+	Task_DistributeMinipools,
+	Task_PromoteMinipools,
+	Task_ReduceBonds,
+	Task_RelayClaims,
+	Task_StakePrelaunchMinipools,
+	Task_UpgradeDelegates,
+	Task_VerifyPdaoProps,
+}
+
+// The key of the confirmation threshold used by commands that don't have their own
+const DefaultCommand string = "default"
+
+// Gas spend thresholds for individual commands and tasks
+type Thresholds struct {
+	// The max fee (in gwei) each automatic task will send transactions at; tasks without one use the Automatic TX Gas Threshold
+	Tasks map[string]float64 `yaml:"tasks,omitempty"`
+
+	// The projected cost (in ETH) above which each interactive command asks for confirmation, keyed by command such as "node claim-rewards"
+	Commands map[string]float64 `yaml:"commands,omitempty"`
+}
+
+// Load the thresholds from the provided path. Returns empty thresholds if the file doesn't exist.
+func Load(path string) (*Thresholds, error) {
+	thresholds := &Thresholds{
+		Tasks:    map[string]float64{},
+		Commands: map[string]float64{},
+	}
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return thresholds, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading gas thresholds %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(bytes, thresholds); err != nil {
+		return nil, fmt.Errorf("error parsing gas thresholds %s: %w", path, err)
+	}
+	if thresholds.Tasks == nil {
+		thresholds.Tasks = map[string]float64{}
+	}
+	if thresholds.Commands == nil {
+		thresholds.Commands = map[string]float64{}
+	}
+	return thresholds, nil
+}
+
+// Save the thresholds to the provided path
+func (t *Thresholds) Save(path string) error {
+	bytes, err := yaml.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("error serializing gas thresholds: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return fmt.Errorf("error saving gas thresholds to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Get the confirmation threshold (in ETH) for an interactive command, falling back to the default one.
+// Returns false if neither is set, in which case the command never asks.
+func (t *Thresholds) GetCommandThreshold(command string) (float64, bool) {
+	if threshold, exists := t.Commands[command]; exists {
+		return threshold, true
+	}
+	threshold, exists := t.Commands[DefaultCommand]
+	return threshold, exists
+}
+
+// Get the names of the commands with a confirmation threshold, in order
+func (t *Thresholds) GetCommandNames() []string {
+	names := make([]string, 0, len(t.Commands))
+	for name := range t.Commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Check if a task name is known
+func IsTask(task string) bool {
+	for _, candidate := range Tasks {
+		if candidate == task {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the gas ceiling (in gwei) for one of the node daemon's automatic tasks, or the fallback if it doesn't have one
+func GetTaskThreshold(cfg *config.RocketPoolConfig, task string, fallback float64) (float64, error) {
+	thresholds, err := Load(cfg.Smartnode.GetGasThresholdsPath(true))
+	if err != nil {
+		return 0, err
+	}
+	if threshold, exists := thresholds.Tasks[task]; exists {
+		return threshold, nil
+	}
+	return fallback, nil
+}
@@ -0,0 +1,138 @@
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// The paths of the liveness and readiness endpoints
+const (
+	LivenessPath  string = "/healthz"
+	ReadinessPath string = "/readyz"
+)
+
+// Reported for clients whose status hasn't been checked yet
+var errNotChecked = errors.New("not checked yet")
+
+// The result of a health check
+type Status struct {
+	Healthy bool              `json:"healthy"`
+	Checks  map[string]string `json:"checks"`
+}
+
+// Tracks a daemon's health for orchestrators.
+// The daemon is live as long as its task loop keeps running, and ready once its clients are synced and its wallet
+// can be used.
+type Monitor struct {
+	loopTimeout   time.Duration
+	lastLoop      time.Time
+	ecErr         error
+	bcErr         error
+	walletChecker func() error
+	lock          sync.Mutex
+}
+
+// Create a monitor that considers the daemon dead if its task loop doesn't run for loopTimeout.
+// The clients are considered unavailable until they've been reported.
+func NewMonitor(loopTimeout time.Duration, walletChecker func() error) *Monitor {
+	return &Monitor{
+		loopTimeout:   loopTimeout,
+		lastLoop:      time.Now(),
+		ecErr:         errNotChecked,
+		bcErr:         errNotChecked,
+		walletChecker: walletChecker,
+	}
+}
+
+// Record that the task loop has started a new iteration
+func (m *Monitor) Heartbeat() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.lastLoop = time.Now()
+}
+
+// Record the result of the latest Execution client sync check
+func (m *Monitor) SetExecutionClientStatus(err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.ecErr = err
+}
+
+// Record the result of the latest Beacon client sync check
+func (m *Monitor) SetBeaconClientStatus(err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.bcErr = err
+}
+
+// Check if the task loop is still running
+func (m *Monitor) GetLiveness() Status {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	status := Status{
+		Healthy: true,
+		Checks:  map[string]string{},
+	}
+	status.Checks["taskLoop"] = m.checkLoop(&status)
+	return status
+}
+
+// Check if the daemon is live and can do its duties
+func (m *Monitor) GetReadiness() Status {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	status := Status{
+		Healthy: true,
+		Checks:  map[string]string{},
+	}
+	status.Checks["taskLoop"] = m.checkLoop(&status)
+	status.Checks["executionClient"] = checkError(&status, m.ecErr)
+	status.Checks["beaconClient"] = checkError(&status, m.bcErr)
+	if m.walletChecker != nil {
+		status.Checks["wallet"] = checkError(&status, m.walletChecker())
+	}
+	return status
+}
+
+// Add the liveness and readiness endpoints to an HTTP server
+func (m *Monitor) RegisterHandlers(mux *http.ServeMux) {
+	mux.HandleFunc(LivenessPath, func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, m.GetLiveness())
+	})
+	mux.HandleFunc(ReadinessPath, func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, m.GetReadiness())
+	})
+}
+
+// Check how long ago the task loop last ran
+func (m *Monitor) checkLoop(status *Status) string {
+	since := time.Since(m.lastLoop)
+	if since > m.loopTimeout {
+		status.Healthy = false
+		return "task loop hasn't run for " + since.Round(time.Second).String()
+	}
+	return "ok"
+}
+
+// Describe the result of a check
+func checkError(status *Status, err error) string {
+	if err != nil {
+		status.Healthy = false
+		return err.Error()
+	}
+	return "ok"
+}
+
+// Write a status as JSON, with a 503 if it isn't healthy
+func writeStatus(w http.ResponseWriter, status Status) {
+	w.Header().Set("Content-Type", "application/json")
+	if !status.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(status)
+}
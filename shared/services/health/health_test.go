@@ -0,0 +1,54 @@
+package health
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReadiness(t *testing.T) {
+	walletErr := errors.New("wallet not initialized")
+	monitor := NewMonitor(time.Minute, func() error { return walletErr })
+	mux := http.NewServeMux()
+	monitor.RegisterHandlers(mux)
+
+	// The loop has just started, so it's live but not ready
+	if code := get(mux, LivenessPath); code != http.StatusOK {
+		t.Fatalf("Expected liveness 200, got %d", code)
+	}
+	if code := get(mux, ReadinessPath); code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected readiness 503 before the clients were checked, got %d", code)
+	}
+
+	// Ready once the clients are synced and the wallet is available
+	monitor.SetExecutionClientStatus(nil)
+	monitor.SetBeaconClientStatus(nil)
+	if code := get(mux, ReadinessPath); code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected readiness 503 without a wallet, got %d", code)
+	}
+	walletErr = nil
+	if code := get(mux, ReadinessPath); code != http.StatusOK {
+		t.Fatalf("Expected readiness 200, got %d", code)
+	}
+
+	// A stalled loop makes the daemon dead and not ready
+	monitor.lastLoop = time.Now().Add(-2 * time.Minute)
+	if code := get(mux, LivenessPath); code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected liveness 503 for a stalled loop, got %d", code)
+	}
+	if code := get(mux, ReadinessPath); code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected readiness 503 for a stalled loop, got %d", code)
+	}
+	monitor.Heartbeat()
+	if code := get(mux, LivenessPath); code != http.StatusOK {
+		t.Fatalf("Expected liveness 200 after a heartbeat, got %d", code)
+	}
+}
+
+func get(handler http.Handler, path string) int {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	return recorder.Code
+}
@@ -0,0 +1,109 @@
+package l2
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// The parts of a Layer 2 rewards distributor's ABI the Smartnode uses. The distributor takes the same claims as the
+// one on Ethereum, and holds the Merkle root of each interval once the rewards for it have been relayed across.
+const DistributorAbi string = `[
+	{"inputs":[{"internalType":"address","name":"_nodeAddress","type":"address"},{"internalType":"uint256[]","name":"_rewardIndex","type":"uint256[]"},{"internalType":"uint256[]","name":"_amountRPL","type":"uint256[]"},{"internalType":"uint256[]","name":"_amountETH","type":"uint256[]"},{"internalType":"bytes32[][]","name":"_merkleProof","type":"bytes32[][]"}],"name":"claim","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[{"internalType":"uint256","name":"_rewardIndex","type":"uint256"},{"internalType":"address","name":"_claimer","type":"address"}],"name":"isClaimed","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"internalType":"uint256","name":"_rewardIndex","type":"uint256"}],"name":"getMerkleRoot","outputs":[{"internalType":"bytes32","name":"","type":"bytes32"}],"stateMutability":"view","type":"function"}
+]`
+
+// A binding for a Layer 2 network's rewards distributor
+type Distributor struct {
+	Network  *Network
+	ChainID  *big.Int
+	client   *ethclient.Client
+	contract *rocketpool.Contract
+}
+
+// Connect to a network's rewards distributor
+func NewDistributor(network *Network) (*Distributor, error) {
+	client, err := ethclient.Dial(network.RpcUrl)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to %s: %w", network.Name, err)
+	}
+
+	// Make sure the client is on the expected chain, since claims are signed for it
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("error getting the chain ID of %s: %w", network.Name, err)
+	}
+	if chainID.Uint64() != network.ChainID {
+		client.Close()
+		return nil, fmt.Errorf("the RPC URL for %s is on chain %d, but chain %d was expected", network.Name, chainID.Uint64(), network.ChainID)
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(DistributorAbi))
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("error decoding distributor ABI: %w", err)
+	}
+	address := common.HexToAddress(network.Distributor)
+	return &Distributor{
+		Network: network,
+		ChainID: chainID,
+		client:  client,
+		contract: &rocketpool.Contract{
+			Contract: bind.NewBoundContract(address, parsed, client, client, client),
+			Address:  &address,
+			ABI:      &parsed,
+			Client:   client,
+		},
+	}, nil
+}
+
+// Close the connection to the network
+func (d *Distributor) Close() {
+	d.client.Close()
+}
+
+// Get the execution client for the network
+func (d *Distributor) Client() *ethclient.Client {
+	return d.client
+}
+
+// Check if a node has claimed its rewards for an interval on the network
+func (d *Distributor) IsClaimed(index uint64, nodeAddress common.Address, opts *bind.CallOpts) (bool, error) {
+	claimed := new(bool)
+	if err := d.contract.Call(opts, claimed, "isClaimed", new(big.Int).SetUint64(index), nodeAddress); err != nil {
+		return false, fmt.Errorf("error checking if interval %d has been claimed on %s: %w", index, d.Network.Name, err)
+	}
+	return *claimed, nil
+}
+
+// Get the Merkle root relayed to the network for an interval; it's empty if the interval hasn't been relayed yet
+func (d *Distributor) GetMerkleRoot(index uint64, opts *bind.CallOpts) (common.Hash, error) {
+	root := new(common.Hash)
+	if err := d.contract.Call(opts, root, "getMerkleRoot", new(big.Int).SetUint64(index)); err != nil {
+		return common.Hash{}, fmt.Errorf("error getting the relayed Merkle root for interval %d on %s: %w", index, d.Network.Name, err)
+	}
+	return *root, nil
+}
+
+// Estimate the gas of claiming rewards on the network
+func (d *Distributor) EstimateClaimGas(nodeAddress common.Address, indices []*big.Int, amountRPL []*big.Int, amountETH []*big.Int, merkleProofs [][]common.Hash, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	return d.contract.GetTransactionGasInfo(opts, "claim", nodeAddress, indices, amountRPL, amountETH, merkleProofs)
+}
+
+// Claim rewards on the network
+func (d *Distributor) Claim(nodeAddress common.Address, indices []*big.Int, amountRPL []*big.Int, amountETH []*big.Int, merkleProofs [][]common.Hash, opts *bind.TransactOpts) (common.Hash, error) {
+	tx, err := d.contract.Transact(opts, "claim", nodeAddress, indices, amountRPL, amountETH, merkleProofs)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error claiming rewards on %s: %w", d.Network.Name, err)
+	}
+	return tx.Hash(), nil
+}
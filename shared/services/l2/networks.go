@@ -0,0 +1,114 @@
+package l2
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/yaml.v2"
+)
+
+// The reward network that pays out on Ethereum itself
+const MainnetRewardNetwork uint64 = 0
+
+// A Layer 2 network that node rewards can be routed to
+type Network struct {
+	// The reward network ID the protocol assigns to this network
+	ID uint64 `yaml:"id"`
+
+	// A human-readable name, such as Arbitrum One
+	Name string `yaml:"name"`
+
+	// The network's chain ID, used to sign claim transactions
+	ChainID uint64 `yaml:"chainId"`
+
+	// The URL of an execution client for the network
+	RpcUrl string `yaml:"rpcUrl"`
+
+	// The address of the network's rewards distributor, which pays out the rewards relayed to it
+	Distributor string `yaml:"distributor"`
+}
+
+// The reward network settings
+type Config struct {
+	Networks []Network `yaml:"networks"`
+}
+
+// Load the reward network settings from the provided path. Returns an empty config if the file doesn't exist, in
+// which case no Layer 2 networks can be claimed on.
+func Load(path string) (*Config, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading reward network settings %s: %w", path, err)
+	}
+
+	config := Config{}
+	if err := yaml.Unmarshal(bytes, &config); err != nil {
+		return nil, fmt.Errorf("error parsing reward network settings %s: %w", path, err)
+	}
+	seen := map[uint64]bool{}
+	for i, network := range config.Networks {
+		if err := network.validate(); err != nil {
+			return nil, fmt.Errorf("reward network %d is invalid: %w", i, err)
+		}
+		if seen[network.ID] {
+			return nil, fmt.Errorf("reward network %d is configured more than once", network.ID)
+		}
+		seen[network.ID] = true
+	}
+	return &config, nil
+}
+
+// Get the settings for a reward network, or nil if it isn't configured
+func (c *Config) Get(id uint64) *Network {
+	for i := range c.Networks {
+		if c.Networks[i].ID == id {
+			return &c.Networks[i]
+		}
+	}
+	return nil
+}
+
+// Get the display name of a reward network
+func (c *Config) GetName(id uint64) string {
+	if id == MainnetRewardNetwork {
+		return "Ethereum"
+	}
+	if network := c.Get(id); network != nil && network.Name != "" {
+		return network.Name
+	}
+	return fmt.Sprintf("Network %d", id)
+}
+
+// Get the display names of every configured reward network, keyed by ID
+func (c *Config) GetNames() map[uint64]string {
+	names := map[uint64]string{
+		MainnetRewardNetwork: c.GetName(MainnetRewardNetwork),
+	}
+	for _, network := range c.Networks {
+		names[network.ID] = c.GetName(network.ID)
+	}
+	return names
+}
+
+// Make sure a network has everything needed to claim on it
+func (n *Network) validate() error {
+	if n.ID == MainnetRewardNetwork {
+		return fmt.Errorf("network %d is Ethereum itself; only Layer 2 networks can be configured", MainnetRewardNetwork)
+	}
+	if n.ChainID == 0 {
+		return errors.New("it doesn't have a chain ID")
+	}
+	if n.RpcUrl == "" {
+		return errors.New("it doesn't have an RPC URL")
+	}
+	if !common.IsHexAddress(n.Distributor) {
+		return fmt.Errorf("invalid distributor address '%s'", n.Distributor)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package mevboost
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// The relay data API endpoint that returns a validator's latest registration
+const registrationPath string = "/relay/v1/data/validator_registration"
+
+// How long to wait for a relay to answer
+const requestTimeout time.Duration = 10 * time.Second
+
+// A validator's latest registration with a relay
+type Registration struct {
+	Relay        string
+	Found        bool
+	Timestamp    time.Time
+	FeeRecipient common.Address
+}
+
+type registrationResponse struct {
+	Message struct {
+		FeeRecipient common.Address `json:"fee_recipient"`
+		Timestamp    string         `json:"timestamp"`
+	} `json:"message"`
+}
+
+// Get a validator's latest registration with a relay. The relay URL can include the relay's public key, as the MEV-Boost
+// relay lists do.
+func GetRegistration(relayUrl string, pubkey types.ValidatorPubkey) (Registration, error) {
+	relay, err := url.Parse(relayUrl)
+	if err != nil {
+		return Registration{}, fmt.Errorf("error parsing relay URL: %w", err)
+	}
+	relay.User = nil
+	registration := Registration{
+		Relay: relay.Host,
+	}
+
+	query := url.Values{}
+	query.Set("pubkey", hexutil.AddPrefix(pubkey.Hex()))
+	requestUrl := relay.JoinPath(registrationPath)
+	requestUrl.RawQuery = query.Encode()
+
+	client := http.Client{
+		Timeout: requestTimeout,
+	}
+	response, err := client.Get(requestUrl.String())
+	if err != nil {
+		return registration, fmt.Errorf("error getting registration from %s: %w", relay.Host, err)
+	}
+	defer response.Body.Close()
+
+	// Relays answer with a client error when they don't have a registration for the validator
+	if response.StatusCode == http.StatusNotFound || response.StatusCode == http.StatusBadRequest {
+		return registration, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return registration, fmt.Errorf("relay %s returned status %d", relay.Host, response.StatusCode)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return registration, fmt.Errorf("error reading registration from %s: %w", relay.Host, err)
+	}
+	var data registrationResponse
+	err = json.Unmarshal(body, &data)
+	if err != nil {
+		return registration, fmt.Errorf("error deserializing registration from %s: %w", relay.Host, err)
+	}
+	timestamp, err := strconv.ParseInt(data.Message.Timestamp, 10, 64)
+	if err != nil {
+		return registration, fmt.Errorf("registration from %s has an invalid timestamp [%s]: %w", relay.Host, data.Message.Timestamp, err)
+	}
+
+	registration.Found = true
+	registration.Timestamp = time.Unix(timestamp, 0)
+	registration.FeeRecipient = data.Message.FeeRecipient
+	return registration, nil
+}
+
+// Get what's wrong with a registration, if anything: it should exist, be newer than maxAge, and use the expected fee
+// recipient
+func (r Registration) GetProblem(now time.Time, maxAge time.Duration, feeRecipient common.Address) string {
+	if !r.Found {
+		return fmt.Sprintf("%s has no registration for it", r.Relay)
+	}
+	if age := now.Sub(r.Timestamp); age > maxAge {
+		return fmt.Sprintf("%s last saw its registration %s ago", r.Relay, age.Round(time.Minute))
+	}
+	if r.FeeRecipient != feeRecipient {
+		return fmt.Sprintf("%s has it registered with fee recipient %s instead of %s", r.Relay, r.FeeRecipient.Hex(), feeRecipient.Hex())
+	}
+	return ""
+}
@@ -0,0 +1,53 @@
+package mevboost
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+func TestGetRegistration(t *testing.T) {
+	registered := types.ValidatorPubkey{0x01}
+	feeRecipient := common.HexToAddress("0xd4E96eF8eee8678dBFf4d535E033Ed1a4F7605b7")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != registrationPath || r.URL.Query().Get("pubkey") != "0x"+registered.Hex() {
+			http.Error(w, "no registration found for validator", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"message":{"fee_recipient":"` + feeRecipient.Hex() + `","gas_limit":"30000000","timestamp":"1700000000","pubkey":"0x01"},"signature":"0x"}`))
+	}))
+	defer server.Close()
+
+	// Relay URLs usually include the relay's public key, which isn't part of the API URL
+	relayUrl := strings.Replace(server.URL, "http://", "http://0xabcdef@", 1)
+	registration, err := GetRegistration(relayUrl, registered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !registration.Found || registration.FeeRecipient != feeRecipient || registration.Timestamp.Unix() != 1700000000 {
+		t.Fatalf("unexpected registration %+v", registration)
+	}
+	now := registration.Timestamp.Add(10 * time.Minute)
+	if problem := registration.GetProblem(now, time.Hour, feeRecipient); problem != "" {
+		t.Errorf("fresh registration has a problem: %s", problem)
+	}
+	if problem := registration.GetProblem(now, 5*time.Minute, feeRecipient); problem == "" {
+		t.Error("stale registration has no problem")
+	}
+	if problem := registration.GetProblem(now, time.Hour, common.Address{}); problem == "" {
+		t.Error("registration with the wrong fee recipient has no problem")
+	}
+
+	registration, err = GetRegistration(server.URL, types.ValidatorPubkey{0x02})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if registration.Found || registration.GetProblem(now, time.Hour, feeRecipient) == "" {
+		t.Fatalf("missing registration was found: %+v", registration)
+	}
+}
@@ -0,0 +1,290 @@
+package mirror
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// The object storage services artifacts can be mirrored to
+const (
+	Provider_S3  string = "s3"
+	Provider_GCS string = "gcs"
+)
+
+// The GCS XML API endpoint, which accepts S3-style requests signed with HMAC keys
+const gcsEndpoint string = "https://storage.googleapis.com"
+
+// How long to wait for each upload
+const uploadTimeout time.Duration = 5 * time.Minute
+
+// An object storage bucket to mirror artifacts to
+type Bucket struct {
+	// s3 or gcs; any other S3-compatible service can use s3 with a custom endpoint
+	Provider string `yaml:"provider"`
+
+	// The bucket's name
+	Name string `yaml:"name"`
+
+	// The bucket's region; GCS ignores it
+	Region string `yaml:"region,omitempty"`
+
+	// The service's endpoint, if it isn't the provider's default
+	Endpoint string `yaml:"endpoint,omitempty"`
+
+	// A prefix for the object keys, such as rewards-trees/
+	Prefix string `yaml:"prefix,omitempty"`
+
+	// The access key; for GCS this is an HMAC key for a service account that can write to the bucket
+	AccessKeyID string `yaml:"accessKeyId"`
+
+	// The access key's secret
+	SecretAccessKey string `yaml:"secretAccessKey"`
+
+	// The base URL the artifacts can be downloaded from, such as a CDN in front of the bucket; defaults to the object URL
+	PublicUrl string `yaml:"publicUrl,omitempty"`
+}
+
+// The artifact mirror settings
+type Config struct {
+	Buckets []Bucket `yaml:"buckets"`
+}
+
+// Uploads rewards artifacts to object storage buckets
+type Uploader struct {
+	buckets []Bucket
+	client  *http.Client
+}
+
+// Load the artifact mirror settings from the provided path. Returns nil if the file doesn't exist, in which case
+// artifacts aren't mirrored.
+func Load(path string) (*Uploader, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading artifact mirror settings %s: %w", path, err)
+	}
+
+	config := Config{}
+	if err := yaml.Unmarshal(bytes, &config); err != nil {
+		return nil, fmt.Errorf("error parsing artifact mirror settings %s: %w", path, err)
+	}
+	for i, bucket := range config.Buckets {
+		if err := bucket.validate(); err != nil {
+			return nil, fmt.Errorf("artifact mirror bucket %d is invalid: %w", i, err)
+		}
+	}
+	if len(config.Buckets) == 0 {
+		return nil, nil
+	}
+	return NewUploader(config.Buckets, &http.Client{}), nil
+}
+
+// Create an uploader for the provided buckets
+func NewUploader(buckets []Bucket, client *http.Client) *Uploader {
+	return &Uploader{
+		buckets: buckets,
+		client:  client,
+	}
+}
+
+// Upload files to every bucket with public-read access. Returns the public URLs of each file, keyed by filename.
+// A bucket that fails doesn't stop the others; the errors are returned together.
+func (u *Uploader) Upload(paths []string) (map[string][]string, error) {
+	urls := map[string][]string{}
+	errs := []error{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error reading %s: %w", path, err))
+			continue
+		}
+		filename := filepath.Base(path)
+		for _, bucket := range u.buckets {
+			publicUrl, err := u.put(&bucket, filename, data)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("error uploading %s to %s bucket %s: %w", filename, bucket.Provider, bucket.Name, err))
+				continue
+			}
+			urls[filename] = append(urls[filename], publicUrl)
+		}
+	}
+	return urls, errors.Join(errs...)
+}
+
+// Upload a file to a bucket, returning its public URL
+func (u *Uploader) put(bucket *Bucket, filename string, data []byte) (string, error) {
+	key := bucket.Prefix + filename
+	objectUrl := fmt.Sprintf("%s/%s/%s", bucket.getEndpoint(), bucket.Name, encodePath(key))
+
+	ctx, cancel := context.WithTimeout(context.Background(), uploadTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, objectUrl, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Content-Type", getContentType(filename))
+	if bucket.Provider == Provider_GCS {
+		request.Header.Set("x-goog-acl", "public-read")
+	} else {
+		request.Header.Set("x-amz-acl", "public-read")
+	}
+	signRequest(request, data, bucket, time.Now().UTC())
+
+	response, err := u.client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return "", fmt.Errorf("%s: %s", response.Status, strings.TrimSpace(string(body)))
+	}
+
+	if bucket.PublicUrl != "" {
+		return fmt.Sprintf("%s/%s", strings.TrimSuffix(bucket.PublicUrl, "/"), encodePath(key)), nil
+	}
+	return objectUrl, nil
+}
+
+// Make sure a bucket has everything needed to upload to it
+func (b *Bucket) validate() error {
+	if b.Provider != Provider_S3 && b.Provider != Provider_GCS {
+		return fmt.Errorf("unknown provider '%s'; it must be %s or %s", b.Provider, Provider_S3, Provider_GCS)
+	}
+	if b.Name == "" {
+		return errors.New("it doesn't have a name")
+	}
+	if b.Provider == Provider_S3 && b.Region == "" && b.Endpoint == "" {
+		return errors.New("S3 buckets need a region or an endpoint")
+	}
+	if b.AccessKeyID == "" || b.SecretAccessKey == "" {
+		return errors.New("it needs an access key and secret")
+	}
+	if b.Endpoint != "" {
+		if _, err := url.Parse(b.Endpoint); err != nil {
+			return fmt.Errorf("invalid endpoint '%s': %w", b.Endpoint, err)
+		}
+	}
+	return nil
+}
+
+// Get the endpoint requests for the bucket go to
+func (b *Bucket) getEndpoint() string {
+	if b.Endpoint != "" {
+		return strings.TrimSuffix(b.Endpoint, "/")
+	}
+	if b.Provider == Provider_GCS {
+		return gcsEndpoint
+	}
+	return fmt.Sprintf("https://s3.%s.amazonaws.com", b.Region)
+}
+
+// Get the region requests for the bucket are signed for
+func (b *Bucket) getRegion() string {
+	if b.Region != "" {
+		return b.Region
+	}
+	if b.Provider == Provider_GCS {
+		return "auto"
+	}
+	return "us-east-1"
+}
+
+// Sign a request with AWS Signature Version 4, which both S3 and the GCS XML API accept
+func signRequest(request *http.Request, payload []byte, bucket *Bucket, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(payload)
+	request.Header.Set("x-amz-date", amzDate)
+	request.Header.Set("x-amz-content-sha256", payloadHash)
+
+	// Sign the host and every content and ACL header
+	headers := map[string]string{"host": request.URL.Host}
+	for name := range request.Header {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || strings.HasPrefix(lower, "x-amz-") || strings.HasPrefix(lower, "x-goog-") {
+			headers[lower] = strings.TrimSpace(request.Header.Get(name))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	canonicalHeaders := ""
+	for _, name := range names {
+		canonicalHeaders += name + ":" + headers[name] + "\n"
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		request.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, bucket.getRegion())
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSha256([]byte("AWS4"+bucket.SecretAccessKey), date)
+	key = hmacSha256(key, bucket.getRegion())
+	key = hmacSha256(key, "s3")
+	key = hmacSha256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSha256(key, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", bucket.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// Encode an object key for a URL path, keeping the slashes that separate its parts
+func encodePath(key string) string {
+	parts := strings.Split(key, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/")
+}
+
+// Get the content type of an artifact
+func getContentType(filename string) string {
+	switch filepath.Ext(filename) {
+	case ".json":
+		return "application/json"
+	case ".zst":
+		return "application/zstd"
+	case ".html":
+		return "text/html; charset=utf-8"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
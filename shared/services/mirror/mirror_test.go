@@ -0,0 +1,75 @@
+package mirror
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestUpload(t *testing.T) {
+	var lock sync.Mutex
+	uploads := map[string]*http.Request{}
+	bodies := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		if strings.Contains(r.URL.Path, "broken") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		uploads[r.URL.Path] = r
+		bodies[r.URL.Path] = string(body)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "rp-rewards-holesky-5.json")
+	if err := os.WriteFile(path, []byte(`{"index":5}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	uploader := NewUploader([]Bucket{
+		{Provider: Provider_S3, Name: "rewards", Endpoint: server.URL, Region: "eu-west-1", Prefix: "trees/", AccessKeyID: "AKID", SecretAccessKey: "secret"},
+		{Provider: Provider_GCS, Name: "mirror", Endpoint: server.URL, AccessKeyID: "GOOG", SecretAccessKey: "secret", PublicUrl: "https://cdn.example.com/"},
+		{Provider: Provider_S3, Name: "broken", Endpoint: server.URL, Region: "us-east-1", AccessKeyID: "AKID", SecretAccessKey: "secret"},
+	}, server.Client())
+	urls, err := uploader.Upload([]string{path})
+	if err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Fatalf("expected the broken bucket to fail, got %v", err)
+	}
+
+	// The working buckets still get the file, with public-read ACLs and signed requests
+	s3Request := uploads["/rewards/trees/rp-rewards-holesky-5.json"]
+	if s3Request == nil {
+		t.Fatalf("expected an upload to the S3 bucket, got %v", uploads)
+	}
+	if s3Request.Header.Get("x-amz-acl") != "public-read" {
+		t.Fatalf("expected a public-read ACL, got %s", s3Request.Header.Get("x-amz-acl"))
+	}
+	auth := s3Request.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") || !strings.Contains(auth, "/eu-west-1/s3/aws4_request") || !strings.Contains(auth, "x-amz-acl") {
+		t.Fatalf("unexpected authorization header %s", auth)
+	}
+	if bodies["/rewards/trees/rp-rewards-holesky-5.json"] != `{"index":5}` {
+		t.Fatalf("unexpected body %s", bodies["/rewards/trees/rp-rewards-holesky-5.json"])
+	}
+	gcsRequest := uploads["/mirror/rp-rewards-holesky-5.json"]
+	if gcsRequest == nil || gcsRequest.Header.Get("x-goog-acl") != "public-read" {
+		t.Fatalf("expected a public-read upload to the GCS bucket, got %v", uploads)
+	}
+
+	// The URLs point at the object, or the bucket's public URL if it has one
+	expected := []string{
+		server.URL + "/rewards/trees/rp-rewards-holesky-5.json",
+		"https://cdn.example.com/rp-rewards-holesky-5.json",
+	}
+	actual := urls["rp-rewards-holesky-5.json"]
+	if len(actual) != len(expected) || actual[0] != expected[0] || actual[1] != expected[1] {
+		t.Fatalf("expected URLs %v, got %v", expected, actual)
+	}
+}
@@ -0,0 +1,364 @@
+package nonce
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Priority classes for transactions sent from a wallet that is shared between daemons
+type Priority int
+
+const (
+	// Routine node maintenance and user-initiated transactions
+	Priority_Routine Priority = iota
+
+	// Oracle DAO duties, which preempt routine transactions
+	Priority_Duty
+)
+
+// Coordinator settings
+const (
+	// How long a reservation is held after its transaction is signed if the transaction is never sent.
+	// The lease only starts once signing returns, so a slow signer can't lose the reservation mid-signature.
+	reservationLease = 2 * time.Minute
+
+	// How often to retry acquiring a sender's lock
+	lockPollInterval = 250 * time.Millisecond
+
+	// How long a waiting duty transaction holds off routine ones
+	dutyIntentLifetime = 30 * time.Second
+
+	// How long a recorded nonce is trusted over the client's pending nonce; older records are ignored
+	// in case the transaction they belong to was dropped
+	usedNonceLifetime = 10 * time.Minute
+)
+
+// The client used to get a sender's pending nonce
+type PendingNonceClient interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+// Coordinates nonce assignment for the node wallet across every process that uses it (the API, the node daemon,
+// and the watchtower), so two processes never pick the same nonce. Each sender has its own queue, guarded by a
+// lock file in a directory shared between the daemons.
+// Nonces are reserved when a transaction is signed by a transactor from WrapTransactor, and released once it has
+// been sent.
+type Coordinator struct {
+	dir      string
+	priority Priority
+
+	lock    sync.Mutex
+	senders map[common.Address]*senderQueue
+	lastID  uint64
+}
+
+// The reservation state of a single sender in this process
+type senderQueue struct {
+	slot     chan struct{}
+	file     *os.File
+	reserved bool
+	id       uint64
+	hash     common.Hash
+	timer    *time.Timer
+}
+
+// A nonce reserved for a sender
+type Reservation struct {
+	Sender common.Address
+	Nonce  uint64
+	id     uint64
+}
+
+// Create a new nonce coordinator that keeps its lock files in the provided directory
+func NewCoordinator(dir string, priority Priority) *Coordinator {
+	return &Coordinator{
+		dir:      dir,
+		priority: priority,
+		senders:  map[common.Address]*senderQueue{},
+	}
+}
+
+// Reserve the next nonce for the sender, waiting until no other process is in the middle of sending from it.
+// getPendingNonce should return the client's view of the sender's pending nonce.
+// The reservation is held until it's cancelled, or until its signed transaction is released or its lease expires.
+func (c *Coordinator) Reserve(ctx context.Context, sender common.Address, getPendingNonce func() (uint64, error)) (Reservation, error) {
+	queue := c.getQueue(sender)
+
+	// Wait for this process's turn
+	select {
+	case queue.slot <- struct{}{}:
+	case <-ctx.Done():
+		return Reservation{}, ctx.Err()
+	}
+
+	// Wait for the other processes
+	file, err := c.acquireFileLock(ctx, sender)
+	if err != nil {
+		<-queue.slot
+		return Reservation{}, err
+	}
+
+	// Get the nonce, skipping past any that another process used recently but the client doesn't know about yet
+	nonce, err := getPendingNonce()
+	if err != nil {
+		releaseFileLock(file)
+		<-queue.slot
+		return Reservation{}, err
+	}
+	lastUsed, usedAt, exists := c.readUsedNonce(sender)
+	if exists && time.Since(usedAt) < usedNonceLifetime && lastUsed+1 > nonce {
+		nonce = lastUsed + 1
+	}
+
+	c.lock.Lock()
+	c.lastID++
+	queue.file = file
+	queue.reserved = true
+	queue.id = c.lastID
+	queue.hash = common.Hash{}
+	queue.timer = nil
+	c.lock.Unlock()
+	return Reservation{
+		Sender: sender,
+		Nonce:  nonce,
+		id:     queue.id,
+	}, nil
+}
+
+// Attach a reservation to the transaction that was signed with it, and start its lease
+func (c *Coordinator) Lease(reservation Reservation, hash common.Hash) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	queue, exists := c.senders[reservation.Sender]
+	if !exists || !queue.reserved || queue.id != reservation.id {
+		return
+	}
+	queue.hash = hash
+	queue.timer = time.AfterFunc(reservationLease, func() {
+		c.Cancel(reservation)
+	})
+}
+
+// Give up a reservation without sending a transaction with it
+func (c *Coordinator) Cancel(reservation Reservation) {
+	c.lock.Lock()
+	queue, exists := c.senders[reservation.Sender]
+	if !exists || !queue.reserved || queue.id != reservation.id {
+		// The reservation already ended
+		c.lock.Unlock()
+		return
+	}
+	c.finish(queue)
+}
+
+// Finish the reservation a transaction was signed with, recording its nonce as used if it was sent
+func (c *Coordinator) Release(sender common.Address, tx *types.Transaction, sent bool) {
+	if sent {
+		c.writeUsedNonce(sender, tx.Nonce())
+	}
+
+	c.lock.Lock()
+	queue, exists := c.senders[sender]
+	if !exists || !queue.reserved || queue.hash != tx.Hash() {
+		// The nonce was provided explicitly or the lease already expired, so there's nothing to release
+		c.lock.Unlock()
+		return
+	}
+	c.finish(queue)
+}
+
+// End a sender's current reservation; the coordinator's lock must be held, and is released
+func (c *Coordinator) finish(queue *senderQueue) {
+	if queue.timer != nil {
+		queue.timer.Stop()
+		queue.timer = nil
+	}
+	releaseFileLock(queue.file)
+	queue.file = nil
+	queue.reserved = false
+	c.lock.Unlock()
+	<-queue.slot
+}
+
+// Wrap a transactor's signer so each transaction it signs gets a reserved nonce, replacing the one it was built with.
+// Transactions with an explicit nonce, and ones that won't be sent, are signed as-is without a reservation.
+// The reservation is cancelled if signing fails; otherwise the client that sends the signed transaction must release it.
+func (c *Coordinator) WrapTransactor(opts *bind.TransactOpts, client PendingNonceClient) {
+	signer := opts.Signer
+	opts.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if opts.Nonce != nil || opts.NoSend {
+			return signer(address, tx)
+		}
+
+		ctx := opts.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		reservation, err := c.Reserve(ctx, address, func() (uint64, error) {
+			return client.PendingNonceAt(ctx, address)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error reserving a nonce for %s: %w", address.Hex(), err)
+		}
+
+		if tx.Nonce() != reservation.Nonce {
+			tx, err = withNonce(tx, reservation.Nonce)
+			if err != nil {
+				c.Cancel(reservation)
+				return nil, err
+			}
+		}
+		signedTx, err := signer(address, tx)
+		if err != nil {
+			c.Cancel(reservation)
+			return nil, err
+		}
+		c.Lease(reservation, signedTx.Hash())
+		return signedTx, nil
+	}
+}
+
+// Get a copy of an unsigned transaction with a different nonce
+func withNonce(tx *types.Transaction, nonce uint64) (*types.Transaction, error) {
+	switch tx.Type() {
+	case types.DynamicFeeTxType:
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      nonce,
+			GasTipCap:  tx.GasTipCap(),
+			GasFeeCap:  tx.GasFeeCap(),
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}), nil
+	case types.LegacyTxType:
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: tx.GasPrice(),
+			Gas:      tx.Gas(),
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		}), nil
+	default:
+		return nil, fmt.Errorf("can't coordinate the nonce of a type %d transaction", tx.Type())
+	}
+}
+
+// Get the queue for a sender, creating it if necessary
+func (c *Coordinator) getQueue(sender common.Address) *senderQueue {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	queue, exists := c.senders[sender]
+	if !exists {
+		queue = &senderQueue{
+			slot: make(chan struct{}, 1),
+		}
+		c.senders[sender] = queue
+	}
+	return queue
+}
+
+// Acquire the sender's lock file, giving way to waiting duty transactions if this is a routine one
+func (c *Coordinator) acquireFileLock(ctx context.Context, sender common.Address) (*os.File, error) {
+	err := os.MkdirAll(c.dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("error creating nonce lock directory: %w", err)
+	}
+	file, err := os.OpenFile(c.getPath(sender, "lock"), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("error opening nonce lock for %s: %w", sender.Hex(), err)
+	}
+
+	intentPath := c.getPath(sender, "duty")
+	for {
+		if c.priority == Priority_Duty {
+			// Let routine senders know a duty is waiting
+			_ = os.WriteFile(intentPath, []byte(strconv.FormatInt(time.Now().Unix(), 10)), 0644)
+		}
+		if c.priority == Priority_Duty || !isDutyWaiting(intentPath) {
+			err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+			if err == nil {
+				if c.priority == Priority_Duty {
+					_ = os.Remove(intentPath)
+				}
+				return file, nil
+			}
+			if err != syscall.EWOULDBLOCK {
+				file.Close()
+				return nil, fmt.Errorf("error locking nonce lock for %s: %w", sender.Hex(), err)
+			}
+		}
+
+		select {
+		case <-time.After(lockPollInterval):
+		case <-ctx.Done():
+			file.Close()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Release a lock file
+func releaseFileLock(file *os.File) {
+	_ = syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+	file.Close()
+}
+
+// Check if a duty transaction is currently waiting for the lock
+func isDutyWaiting(intentPath string) bool {
+	info, err := os.Stat(intentPath)
+	if err != nil {
+		return false
+	}
+	return time.Since(info.ModTime()) < dutyIntentLifetime
+}
+
+// Read the last nonce a process sent for the sender and when it was sent
+func (c *Coordinator) readUsedNonce(sender common.Address) (uint64, time.Time, bool) {
+	data, err := os.ReadFile(c.getPath(sender, "nonce"))
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	parts := strings.Split(strings.TrimSpace(string(data)), " ")
+	if len(parts) != 2 {
+		return 0, time.Time{}, false
+	}
+	nonce, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	usedAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	return nonce, time.Unix(usedAt, 0), true
+}
+
+// Record the last nonce sent for the sender
+func (c *Coordinator) writeUsedNonce(sender common.Address, nonce uint64) {
+	lastUsed, usedAt, exists := c.readUsedNonce(sender)
+	if exists && time.Since(usedAt) < usedNonceLifetime && lastUsed > nonce {
+		return
+	}
+	data := fmt.Sprintf("%d %d", nonce, time.Now().Unix())
+	_ = os.WriteFile(c.getPath(sender, "nonce"), []byte(data), 0644)
+}
+
+// Get the path of one of a sender's coordination files
+func (c *Coordinator) getPath(sender common.Address, extension string) string {
+	return filepath.Join(c.dir, fmt.Sprintf("%s.%s", sender.Hex(), extension))
+}
@@ -0,0 +1,122 @@
+package nonce
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+type testNonceClient struct {
+	nonce uint64
+}
+
+func (c *testNonceClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return c.nonce, nil
+}
+
+func TestWrapTransactor(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainID := big.NewInt(1)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coordinator := NewCoordinator(t.TempDir(), Priority_Routine)
+	coordinator.WrapTransactor(opts, &testNonceClient{nonce: 7})
+
+	// The transaction should be signed with the reserved nonce instead of the one it was built with
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 3, Gas: 21000})
+	signedTx, err := opts.Signer(opts.From, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signedTx.Nonce() != 7 {
+		t.Fatalf("Expected nonce 7, got %d", signedTx.Nonce())
+	}
+
+	// Sending it releases the reservation and records the nonce, so the next one is reserved right away
+	coordinator.Release(opts.From, signedTx, true)
+	signedTx, err = opts.Signer(opts.From, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signedTx.Nonce() != 8 {
+		t.Fatalf("Expected nonce 8 after nonce 7 was sent, got %d", signedTx.Nonce())
+	}
+	coordinator.Release(opts.From, signedTx, false)
+
+	// A failed signature shouldn't hold the reservation until the lease expires
+	signer := opts.Signer
+	opts.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		return nil, errors.New("signing failed")
+	}
+	coordinator.WrapTransactor(opts, &testNonceClient{nonce: 7})
+	_, err = opts.Signer(opts.From, tx)
+	if err == nil {
+		t.Fatalf("Expected the signing error")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	opts.Signer = signer
+	opts.Context = ctx
+	_, err = opts.Signer(opts.From, tx)
+	if err != nil {
+		t.Fatalf("Expected the reservation to be released after a failed signature: %s", err.Error())
+	}
+}
+
+func TestExpiredReservationRelease(t *testing.T) {
+	sender := common.HexToAddress("0x1")
+	coordinator := NewCoordinator(t.TempDir(), Priority_Routine)
+	getPendingNonce := func() (uint64, error) {
+		return 7, nil
+	}
+
+	// Let the first reservation's lease run out after its transaction was signed
+	first, err := coordinator.Reserve(context.Background(), sender, getPendingNonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	firstTx := types.NewTx(&types.DynamicFeeTx{Nonce: first.Nonce, Gas: 21000})
+	coordinator.Lease(first, firstTx.Hash())
+	coordinator.Cancel(first)
+
+	// The next reservation gets the same nonce, since the first transaction was never sent
+	second, err := coordinator.Reserve(context.Background(), sender, getPendingNonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Nonce != first.Nonce {
+		t.Fatalf("Expected nonce %d, got %d", first.Nonce, second.Nonce)
+	}
+	secondTx := types.NewTx(&types.DynamicFeeTx{Nonce: second.Nonce, Gas: 21001})
+	coordinator.Lease(second, secondTx.Hash())
+
+	// A late release of the first transaction must not end the second reservation
+	coordinator.Release(sender, firstTx, false)
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	_, err = coordinator.Reserve(ctx, sender, getPendingNonce)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the second reservation to still be held, got %v", err)
+	}
+
+	// Releasing the second transaction ends it
+	coordinator.Release(sender, secondTx, false)
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = coordinator.Reserve(ctx, sender, getPendingNonce)
+	if err != nil {
+		t.Fatalf("Expected the second reservation to be released: %s", err.Error())
+	}
+}
@@ -0,0 +1,91 @@
+package onboarding
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/storage"
+	"github.com/rocket-pool/rocketpool-go/utils"
+)
+
+// Send each step in a node's plan in order, waiting for each one to be mined before sending the next since later
+// steps depend on earlier ones.
+// Returns the hashes of the transactions that were mined.
+func ExecutePlan(rp *rocketpool.RocketPool, plan NodePlan, opts *bind.TransactOpts, printMessage func(string)) ([]common.Hash, error) {
+	hashes := []common.Hash{}
+	if len(plan.Problems) > 0 {
+		return hashes, fmt.Errorf("node %s has %d problem(s) that have to be resolved first", plan.Name, len(plan.Problems))
+	}
+	if opts.From != plan.Address {
+		return hashes, fmt.Errorf("node %s is planned for %s, but its transactions are signed by %s", plan.Name, plan.Address.Hex(), opts.From.Hex())
+	}
+
+	for _, step := range plan.Steps {
+		estimate, send, err := getStepFunctions(rp, plan.Address, step)
+		if err != nil {
+			return hashes, err
+		}
+		gasInfo, err := estimate(opts)
+		if err != nil {
+			return hashes, fmt.Errorf("error estimating gas for [%s]: %w", step.Description, err)
+		}
+		stepOpts := *opts
+		stepOpts.GasLimit = gasInfo.SafeGasLimit
+
+		hash, err := send(&stepOpts)
+		if err != nil {
+			return hashes, fmt.Errorf("error sending [%s]: %w", step.Description, err)
+		}
+		printMessage(fmt.Sprintf("%s: %s (transaction %s)", plan.Name, step.Description, hash.Hex()))
+		receipt, err := utils.WaitForTransaction(rp.Client, hash)
+		if err != nil {
+			return hashes, fmt.Errorf("error waiting for [%s]: %w", step.Description, err)
+		}
+		if receipt.Status == 0 {
+			return hashes, fmt.Errorf("transaction %s for [%s] reverted", hash.Hex(), step.Description)
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, nil
+}
+
+// Get the gas estimator and sender for a step
+func getStepFunctions(rp *rocketpool.RocketPool, address common.Address, step PlannedStep) (func(*bind.TransactOpts) (rocketpool.GasInfo, error), func(*bind.TransactOpts) (common.Hash, error), error) {
+	switch step.Kind {
+	case StepKind_Register:
+		return func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return node.EstimateRegisterNodeGas(rp, step.Timezone, opts)
+			}, func(opts *bind.TransactOpts) (common.Hash, error) {
+				return node.RegisterNode(rp, step.Timezone, opts)
+			}, nil
+	case StepKind_SetTimezone:
+		return func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return node.EstimateSetTimezoneLocationGas(rp, step.Timezone, opts)
+			}, func(opts *bind.TransactOpts) (common.Hash, error) {
+				return node.SetTimezoneLocation(rp, step.Timezone, opts)
+			}, nil
+	case StepKind_SetSmoothingPool:
+		return func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return node.EstimateSetSmoothingPoolRegistrationStateGas(rp, step.OptIn, opts)
+			}, func(opts *bind.TransactOpts) (common.Hash, error) {
+				return node.SetSmoothingPoolRegistrationState(rp, step.OptIn, opts)
+			}, nil
+	case StepKind_SetRplWithdrawalAddress:
+		return func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return node.EstimateSetRPLWithdrawalAddressGas(rp, address, step.Address, step.Confirm, opts)
+			}, func(opts *bind.TransactOpts) (common.Hash, error) {
+				return node.SetRPLWithdrawalAddress(rp, address, step.Address, step.Confirm, opts)
+			}, nil
+	case StepKind_SetWithdrawalAddress:
+		return func(opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+				return storage.EstimateSetWithdrawalAddressGas(rp, address, step.Address, step.Confirm, opts)
+			}, func(opts *bind.TransactOpts) (common.Hash, error) {
+				return storage.SetWithdrawalAddress(rp, address, step.Address, step.Confirm, opts)
+			}, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown step kind [%s]", step.Kind)
+	}
+}
@@ -0,0 +1,130 @@
+package onboarding
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/yaml.v2"
+)
+
+// The timezone nodes are registered with if their manifest entry doesn't set one
+const DefaultTimezone string = "Etc/UTC"
+
+// A remote signer that holds a node's key, connected to over mutually-authenticated TLS
+type SignerConfig struct {
+	Endpoint string `yaml:"endpoint"`
+	CaCert   string `yaml:"caCert"`
+	Cert     string `yaml:"cert"`
+	Key      string `yaml:"key"`
+}
+
+// How one node should be registered and configured, and how to sign its transactions.
+// Each node signs with either an encrypted keystore or a remote signer.
+type NodeEntry struct {
+	Name    string `yaml:"name"`
+	Address string `yaml:"address,omitempty"`
+
+	// Signing
+	Keystore     string        `yaml:"keystore,omitempty"`
+	PasswordFile string        `yaml:"passwordFile,omitempty"`
+	Signer       *SignerConfig `yaml:"signer,omitempty"`
+
+	// Configuration
+	Timezone                        string `yaml:"timezone,omitempty"`
+	SmoothingPool                   *bool  `yaml:"smoothingPool,omitempty"`
+	RplWithdrawalAddress            string `yaml:"rplWithdrawalAddress,omitempty"`
+	ConfirmRplWithdrawalAddress     bool   `yaml:"confirmRplWithdrawalAddress,omitempty"`
+	PrimaryWithdrawalAddress        string `yaml:"primaryWithdrawalAddress,omitempty"`
+	ConfirmPrimaryWithdrawalAddress bool   `yaml:"confirmPrimaryWithdrawalAddress,omitempty"`
+}
+
+// A manifest of the nodes to register and configure in one batch
+type Manifest struct {
+	ChainID uint64      `yaml:"chainId"`
+	Nodes   []NodeEntry `yaml:"nodes"`
+}
+
+// Load a manifest and make sure every entry in it is usable
+func LoadManifest(path string) (*Manifest, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("manifest %s does not exist", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading manifest %s: %w", path, err)
+	}
+	var manifest Manifest
+	if err := yaml.Unmarshal(bytes, &manifest); err != nil {
+		return nil, fmt.Errorf("error parsing manifest %s: %w", path, err)
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, fmt.Errorf("manifest %s is invalid: %w", path, err)
+	}
+	return &manifest, nil
+}
+
+// Make sure every entry in the manifest is usable
+func (m *Manifest) Validate() error {
+	if m.ChainID == 0 {
+		return fmt.Errorf("chainId must be set")
+	}
+	if len(m.Nodes) == 0 {
+		return fmt.Errorf("it doesn't have any nodes")
+	}
+	names := map[string]bool{}
+	for i, entry := range m.Nodes {
+		if entry.Name == "" {
+			return fmt.Errorf("node %d doesn't have a name", i)
+		}
+		if names[entry.Name] {
+			return fmt.Errorf("node name [%s] is used more than once", entry.Name)
+		}
+		names[entry.Name] = true
+		if err := entry.validate(); err != nil {
+			return fmt.Errorf("node [%s]: %w", entry.Name, err)
+		}
+	}
+	return nil
+}
+
+func (e *NodeEntry) validate() error {
+	// Signing
+	if (e.Keystore == "") == (e.Signer == nil) {
+		return fmt.Errorf("it must have either a keystore or a signer")
+	}
+	if e.Keystore != "" && e.PasswordFile == "" {
+		return fmt.Errorf("its keystore needs a passwordFile")
+	}
+	if e.Signer != nil && (e.Signer.Endpoint == "" || e.Signer.CaCert == "" || e.Signer.Cert == "" || e.Signer.Key == "") {
+		return fmt.Errorf("its signer needs an endpoint, caCert, cert, and key")
+	}
+
+	// Configuration
+	if e.Timezone != "" {
+		if _, err := time.LoadLocation(e.Timezone); err != nil {
+			return fmt.Errorf("[%s] is not a valid timezone: %w", e.Timezone, err)
+		}
+	}
+	for field, address := range map[string]string{
+		"address":                  e.Address,
+		"rplWithdrawalAddress":     e.RplWithdrawalAddress,
+		"primaryWithdrawalAddress": e.PrimaryWithdrawalAddress,
+	} {
+		if address != "" && !common.IsHexAddress(address) {
+			return fmt.Errorf("%s [%s] is not a valid address", field, address)
+		}
+	}
+	return nil
+}
+
+// Get the timezone the node should have
+func (e *NodeEntry) GetTimezone() string {
+	if e.Timezone == "" {
+		return DefaultTimezone
+	}
+	return e.Timezone
+}
@@ -0,0 +1,197 @@
+package onboarding
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/storage"
+	"golang.org/x/sync/errgroup"
+)
+
+// The kinds of transactions a node's plan can include
+type StepKind string
+
+const (
+	StepKind_Register                StepKind = "register"
+	StepKind_SetTimezone             StepKind = "set-timezone"
+	StepKind_SetSmoothingPool        StepKind = "set-smoothing-pool"
+	StepKind_SetRplWithdrawalAddress StepKind = "set-rpl-withdrawal-address"
+	StepKind_SetWithdrawalAddress    StepKind = "set-withdrawal-address"
+)
+
+// A transaction a node needs to send
+type PlannedStep struct {
+	Kind        StepKind       `json:"kind"`
+	Description string         `json:"description"`
+	Timezone    string         `json:"timezone,omitempty"`
+	OptIn       bool           `json:"optIn,omitempty"`
+	Address     common.Address `json:"address,omitempty"`
+	Confirm     bool           `json:"confirm,omitempty"`
+}
+
+// The transactions a node needs to send to match its manifest entry, in the order they have to be sent.
+// Problems are settings the node can't change itself; nodes with problems aren't executed.
+type NodePlan struct {
+	Name     string         `json:"name"`
+	Address  common.Address `json:"address"`
+	Steps    []PlannedStep  `json:"steps"`
+	Problems []string       `json:"problems,omitempty"`
+}
+
+// A node's current registration and configuration
+type NodeChainState struct {
+	Exists                      bool
+	Timezone                    string
+	SmoothingPoolOptedIn        bool
+	WithdrawalAddress           common.Address
+	PendingWithdrawalAddress    common.Address
+	RplWithdrawalAddressIsSet   bool
+	RplWithdrawalAddress        common.Address
+	PendingRplWithdrawalAddress common.Address
+}
+
+// Get a node's current registration and configuration
+func GetNodeChainState(rp *rocketpool.RocketPool, address common.Address, opts *bind.CallOpts) (NodeChainState, error) {
+	state := NodeChainState{}
+	var err error
+	state.Exists, err = node.GetNodeExists(rp, address, opts)
+	if err != nil {
+		return state, fmt.Errorf("error checking if node %s is registered: %w", address.Hex(), err)
+	}
+	if !state.Exists {
+		// Unregistered nodes are their own withdrawal address once they register
+		state.WithdrawalAddress = address
+		return state, nil
+	}
+
+	var wg errgroup.Group
+	wg.Go(func() error {
+		var err error
+		state.Timezone, err = node.GetNodeTimezoneLocation(rp, address, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		state.SmoothingPoolOptedIn, err = node.GetSmoothingPoolRegistrationState(rp, address, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		state.WithdrawalAddress, err = storage.GetNodeWithdrawalAddress(rp, address, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		state.PendingWithdrawalAddress, err = storage.GetNodePendingWithdrawalAddress(rp, address, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		state.RplWithdrawalAddressIsSet, err = node.GetNodeRPLWithdrawalAddressIsSet(rp, address, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		state.RplWithdrawalAddress, err = node.GetNodeRPLWithdrawalAddress(rp, address, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		state.PendingRplWithdrawalAddress, err = node.GetNodePendingRPLWithdrawalAddress(rp, address, opts)
+		return err
+	})
+	if err := wg.Wait(); err != nil {
+		return state, fmt.Errorf("error getting the configuration of node %s: %w", address.Hex(), err)
+	}
+	return state, nil
+}
+
+// Plan the transactions that bring a node from its current state to its manifest entry.
+// The RPL withdrawal address is set before the primary one, since the node can only set it while it's still its own
+// primary withdrawal address.
+func PlanNode(entry NodeEntry, address common.Address, state NodeChainState) NodePlan {
+	plan := NodePlan{
+		Name:    entry.Name,
+		Address: address,
+		Steps:   []PlannedStep{},
+	}
+
+	// Registration and timezone
+	timezone := entry.GetTimezone()
+	if !state.Exists {
+		plan.Steps = append(plan.Steps, PlannedStep{
+			Kind:        StepKind_Register,
+			Description: fmt.Sprintf("Register the node with timezone %s", timezone),
+			Timezone:    timezone,
+		})
+	} else if entry.Timezone != "" && state.Timezone != entry.Timezone {
+		plan.Steps = append(plan.Steps, PlannedStep{
+			Kind:        StepKind_SetTimezone,
+			Description: fmt.Sprintf("Change the node's timezone from %s to %s", state.Timezone, entry.Timezone),
+			Timezone:    entry.Timezone,
+		})
+	}
+
+	// Smoothing pool
+	if entry.SmoothingPool != nil && *entry.SmoothingPool != state.SmoothingPoolOptedIn {
+		description := "Opt the node into the smoothing pool"
+		if !*entry.SmoothingPool {
+			description = "Opt the node out of the smoothing pool"
+		}
+		plan.Steps = append(plan.Steps, PlannedStep{
+			Kind:        StepKind_SetSmoothingPool,
+			Description: description,
+			OptIn:       *entry.SmoothingPool,
+		})
+	}
+
+	// RPL withdrawal address
+	if entry.RplWithdrawalAddress != "" {
+		target := common.HexToAddress(entry.RplWithdrawalAddress)
+		current := address
+		if state.RplWithdrawalAddressIsSet {
+			current = state.RplWithdrawalAddress
+		}
+		switch {
+		case current == target:
+		case !entry.ConfirmRplWithdrawalAddress && state.PendingRplWithdrawalAddress == target:
+			plan.Problems = append(plan.Problems, fmt.Sprintf("%s is already the pending RPL withdrawal address; it needs to confirm itself", target.Hex()))
+		case state.WithdrawalAddress != address || current != address:
+			plan.Problems = append(plan.Problems, "the RPL withdrawal address can't be changed by the node because its withdrawal addresses have already been handed off")
+		default:
+			plan.Steps = append(plan.Steps, newWithdrawalAddressStep(StepKind_SetRplWithdrawalAddress, "RPL withdrawal address", target, entry.ConfirmRplWithdrawalAddress))
+		}
+	}
+
+	// Primary withdrawal address
+	if entry.PrimaryWithdrawalAddress != "" {
+		target := common.HexToAddress(entry.PrimaryWithdrawalAddress)
+		switch {
+		case state.WithdrawalAddress == target:
+		case !entry.ConfirmPrimaryWithdrawalAddress && state.PendingWithdrawalAddress == target:
+			plan.Problems = append(plan.Problems, fmt.Sprintf("%s is already the pending withdrawal address; it needs to confirm itself", target.Hex()))
+		case state.WithdrawalAddress != address:
+			plan.Problems = append(plan.Problems, fmt.Sprintf("the withdrawal address can only be changed by the current withdrawal address, %s", state.WithdrawalAddress.Hex()))
+		default:
+			plan.Steps = append(plan.Steps, newWithdrawalAddressStep(StepKind_SetWithdrawalAddress, "withdrawal address", target, entry.ConfirmPrimaryWithdrawalAddress))
+		}
+	}
+
+	return plan
+}
+
+func newWithdrawalAddressStep(kind StepKind, name string, address common.Address, confirm bool) PlannedStep {
+	description := fmt.Sprintf("Set %s as the pending %s; it will need to confirm itself", address.Hex(), name)
+	if confirm {
+		description = fmt.Sprintf("Set %s as the %s", address.Hex(), name)
+	}
+	return PlannedStep{
+		Kind:        kind,
+		Description: description,
+		Address:     address,
+		Confirm:     confirm,
+	}
+}
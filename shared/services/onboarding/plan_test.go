@@ -0,0 +1,84 @@
+package onboarding
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestPlanNode(t *testing.T) {
+	node := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	withdrawal := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	rplWithdrawal := common.HexToAddress("0x3000000000000000000000000000000000000003")
+	optIn := true
+	entry := NodeEntry{
+		Name:                     "node-1",
+		Timezone:                 "Europe/Berlin",
+		SmoothingPool:            &optIn,
+		RplWithdrawalAddress:     rplWithdrawal.Hex(),
+		PrimaryWithdrawalAddress: withdrawal.Hex(),
+	}
+
+	// A new node registers, then sets the RPL withdrawal address before handing off the primary one
+	plan := PlanNode(entry, node, NodeChainState{WithdrawalAddress: node})
+	expected := []StepKind{StepKind_Register, StepKind_SetSmoothingPool, StepKind_SetRplWithdrawalAddress, StepKind_SetWithdrawalAddress}
+	if len(plan.Problems) != 0 || len(plan.Steps) != len(expected) {
+		t.Fatalf("unexpected plan for a new node: %+v", plan)
+	}
+	for i, kind := range expected {
+		if plan.Steps[i].Kind != kind {
+			t.Errorf("step %d: expected %s, got %s", i, kind, plan.Steps[i].Kind)
+		}
+	}
+
+	// A node that already matches the manifest has nothing to do
+	plan = PlanNode(entry, node, NodeChainState{
+		Exists:                    true,
+		Timezone:                  "Europe/Berlin",
+		SmoothingPoolOptedIn:      true,
+		WithdrawalAddress:         withdrawal,
+		RplWithdrawalAddressIsSet: true,
+		RplWithdrawalAddress:      rplWithdrawal,
+	})
+	if len(plan.Steps) != 0 || len(plan.Problems) != 0 {
+		t.Fatalf("expected an empty plan, got %+v", plan)
+	}
+
+	// A node whose withdrawal address was already handed off can't change either one
+	plan = PlanNode(entry, node, NodeChainState{
+		Exists:               true,
+		Timezone:             "Europe/Berlin",
+		SmoothingPoolOptedIn: true,
+		WithdrawalAddress:    common.HexToAddress("0x4000000000000000000000000000000000000004"),
+	})
+	if len(plan.Steps) != 0 || len(plan.Problems) != 2 {
+		t.Fatalf("expected two problems, got %+v", plan)
+	}
+}
+
+func TestManifestValidation(t *testing.T) {
+	valid := Manifest{
+		ChainID: 1,
+		Nodes: []NodeEntry{
+			{Name: "a", Keystore: "a.json", PasswordFile: "a.pass"},
+			{Name: "b", Signer: &SignerConfig{Endpoint: "signer:9190", CaCert: "ca.pem", Cert: "cert.pem", Key: "key.pem"}},
+		},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := map[string]NodeEntry{
+		"no signer":    {Name: "c"},
+		"two signers":  {Name: "c", Keystore: "c.json", PasswordFile: "c.pass", Signer: valid.Nodes[1].Signer},
+		"bad timezone": {Name: "c", Keystore: "c.json", PasswordFile: "c.pass", Timezone: "Mars/Olympus_Mons"},
+		"bad address":  {Name: "c", Keystore: "c.json", PasswordFile: "c.pass", PrimaryWithdrawalAddress: "0x1234"},
+		"duplicate":    {Name: "a", Keystore: "c.json", PasswordFile: "c.pass"},
+	}
+	for name, entry := range invalid {
+		manifest := Manifest{ChainID: 1, Nodes: append([]NodeEntry{valid.Nodes[0]}, entry)}
+		if err := manifest.Validate(); err == nil {
+			t.Errorf("%s: expected the manifest to be invalid", name)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package onboarding
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/remotesigner"
+)
+
+// Signs a node's transactions with its keystore or remote signer
+type NodeSigner struct {
+	Address common.Address
+	client  *remotesigner.Client
+	opts    *bind.TransactOpts
+}
+
+// Unlock a node's keystore or connect to its remote signer.
+// If the entry names the node's address, the signer must hold the key for it.
+func NewNodeSigner(entry NodeEntry, chainID *big.Int) (*NodeSigner, error) {
+	signer := &NodeSigner{}
+	if entry.Keystore != "" {
+		keystoreBytes, err := os.ReadFile(entry.Keystore)
+		if err != nil {
+			return nil, fmt.Errorf("error reading keystore %s: %w", entry.Keystore, err)
+		}
+		password, err := os.ReadFile(entry.PasswordFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading password file %s: %w", entry.PasswordFile, err)
+		}
+		key, err := keystore.DecryptKey(keystoreBytes, strings.TrimSpace(string(password)))
+		if err != nil {
+			return nil, fmt.Errorf("error decrypting keystore %s: %w", entry.Keystore, err)
+		}
+		signer.opts, err = bind.NewKeyedTransactorWithChainID(key.PrivateKey, chainID)
+		if err != nil {
+			return nil, fmt.Errorf("error creating transactor: %w", err)
+		}
+		signer.Address = key.Address
+	} else {
+		client, err := remotesigner.NewClient(entry.Signer.Endpoint, entry.Signer.CaCert, entry.Signer.Cert, entry.Signer.Key)
+		if err != nil {
+			return nil, err
+		}
+		signer.client = client
+		signer.Address = client.Address()
+		signer.opts = &bind.TransactOpts{
+			From: signer.Address,
+			Signer: func(signerAddress common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				if signerAddress != signer.Address {
+					return nil, bind.ErrNotAuthorized
+				}
+				return client.SignTx(context.Background(), tx, chainID)
+			},
+		}
+	}
+	signer.opts.Context = context.Background()
+
+	if entry.Address != "" && common.HexToAddress(entry.Address) != signer.Address {
+		signer.Close()
+		return nil, fmt.Errorf("the signer holds the key for %s, but the manifest expects %s", signer.Address.Hex(), entry.Address)
+	}
+	return signer, nil
+}
+
+// Get a transactor for the node with the given fee limits. Nil limits are left for the client to suggest.
+func (s *NodeSigner) GetTransactor(maxFee *big.Int, maxPriorityFee *big.Int) *bind.TransactOpts {
+	opts := *s.opts
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = maxPriorityFee
+	return &opts
+}
+
+// Disconnect from the remote signer, if there is one
+func (s *NodeSigner) Close() {
+	if s.client != nil {
+		s.client.Close()
+	}
+}
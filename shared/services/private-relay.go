@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// An execution client that broadcasts transactions through a private relay (such as Flashbots Protect) instead of
+// the public mempool. Everything else, including gas estimation and nonce lookups, is served by the wrapped client.
+type PrivateRelayClient struct {
+	rocketpool.ExecutionClient
+	relayUrl string
+	relay    *ethclient.Client
+}
+
+// Create a new private relay client
+func NewPrivateRelayClient(ec rocketpool.ExecutionClient, relayUrl string) (*PrivateRelayClient, error) {
+	relay, err := ethclient.Dial(relayUrl)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to private relay %s: %w", relayUrl, err)
+	}
+	return &PrivateRelayClient{
+		ExecutionClient: ec,
+		relayUrl:        relayUrl,
+		relay:           relay,
+	}, nil
+}
+
+// Send a signed transaction to the private relay
+func (c *PrivateRelayClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	err := c.relay.SendTransaction(ctx, tx)
+	if manager, ok := c.ExecutionClient.(*ExecutionClientManager); ok {
+		manager.ReleaseNonce(tx, err == nil)
+		manager.RecordTransaction(tx, err)
+	}
+	if err != nil {
+		return fmt.Errorf("error sending transaction to private relay %s: %w", c.relayUrl, err)
+	}
+	return nil
+}
+
+// Record the outcome of a transaction in the wrapped client's audit log
+func (c *PrivateRelayClient) RecordTransactionResult(hash common.Hash, receipt *types.Receipt, waitErr error) {
+	if manager, ok := c.ExecutionClient.(*ExecutionClientManager); ok {
+		manager.RecordTransactionResult(hash, receipt, waitErr)
+	}
+}
@@ -0,0 +1,173 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/addressbook"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The current version of the profile format
+const Version int = 1
+
+// The prefixes of the file names in a profile, which say which folder they're restored to
+const (
+	configPrefix string = "config/"
+	dataPrefix   string = "data/"
+)
+
+// The folder in the Smart Node's config directory that holds the user's docker compose overrides
+const overrideFolder string = "override"
+
+// The policy files in the data folder that belong in a profile. Everything else in the data folder is either a key,
+// chain data, or something the Smart Node rebuilds on its own.
+var dataFiles = []string{
+	config.ArtifactMirrorsFilename,
+	config.GasThresholdsFilename,
+	config.ResourceLimitsFilename,
+	config.RewardNetworksFilename,
+	config.StatusPageBucketsFilename,
+}
+
+// A portable copy of a Smart Node installation's configuration, address book, and policies. It never contains the
+// node wallet or validator keys.
+type Profile struct {
+	Version          int                          `yaml:"version"`
+	SmartnodeVersion string                       `yaml:"smartnodeVersion"`
+	Network          string                       `yaml:"network"`
+	CreatedAt        time.Time                    `yaml:"createdAt"`
+	Settings         map[string]map[string]string `yaml:"settings"`
+
+	// The contents of the profile's files, keyed by their path under the config or data folder
+	Files map[string]string `yaml:"files"`
+}
+
+// Create a profile from a configuration and the files in the config and data folders
+func Create(cfg *config.RocketPoolConfig, smartnodeVersion string, configDir string, dataDir string) (*Profile, error) {
+	profile := &Profile{
+		Version:          Version,
+		SmartnodeVersion: smartnodeVersion,
+		Network:          fmt.Sprint(cfg.Smartnode.Network.Value),
+		CreatedAt:        time.Now().UTC(),
+		Settings:         cfg.Serialize(),
+		Files:            map[string]string{},
+	}
+
+	// Add the address book and compose overrides
+	paths := map[string]string{
+		configPrefix + addressbook.Filename: filepath.Join(configDir, addressbook.Filename),
+	}
+	overrides, err := filepath.Glob(filepath.Join(configDir, overrideFolder, "*.yml"))
+	if err != nil {
+		return nil, fmt.Errorf("error finding compose overrides: %w", err)
+	}
+	for _, override := range overrides {
+		paths[configPrefix+overrideFolder+"/"+filepath.Base(override)] = override
+	}
+
+	// Add the policies
+	for _, filename := range dataFiles {
+		paths[dataPrefix+filename] = filepath.Join(dataDir, filename)
+	}
+
+	for name, path := range paths {
+		bytes, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", path, err)
+		}
+		profile.Files[name] = string(bytes)
+	}
+	return profile, nil
+}
+
+// Load a profile from a file
+func Load(path string) (*Profile, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading profile %s: %w", path, err)
+	}
+	profile := &Profile{}
+	if err := yaml.Unmarshal(bytes, profile); err != nil {
+		return nil, fmt.Errorf("error parsing profile %s: %w", path, err)
+	}
+	if profile.Version < 1 || profile.Version > Version {
+		return nil, fmt.Errorf("profile %s has version %d, but this Smart Node only supports up to version %d", path, profile.Version, Version)
+	}
+	if profile.Settings == nil {
+		return nil, fmt.Errorf("profile %s doesn't have any settings", path)
+	}
+	for name := range profile.Files {
+		if _, err := getFilePath(name, "", ""); err != nil {
+			return nil, fmt.Errorf("profile %s is invalid: %w", path, err)
+		}
+	}
+	return profile, nil
+}
+
+// Save the profile to a file. It's only readable by the user, since the settings can contain notification tokens.
+func (p *Profile) Save(path string) error {
+	bytes, err := yaml.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("error serializing profile: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0600); err != nil {
+		return fmt.Errorf("error saving profile to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Get the names of the profile's files, in order
+func (p *Profile) GetFileNames() []string {
+	names := make([]string, 0, len(p.Files))
+	for name := range p.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Write the profile's files into the config and data folders
+func (p *Profile) RestoreFiles(configDir string, dataDir string) error {
+	for _, name := range p.GetFileNames() {
+		path, err := getFilePath(name, configDir, dataDir)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0775); err != nil {
+			return fmt.Errorf("error creating folder for %s: %w", path, err)
+		}
+		if err := os.WriteFile(path, []byte(p.Files[name]), 0644); err != nil {
+			return fmt.Errorf("error writing %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Get the path a profile file is restored to, making sure it stays inside its folder
+func getFilePath(name string, configDir string, dataDir string) (string, error) {
+	var dir, relative string
+	switch {
+	case strings.HasPrefix(name, configPrefix):
+		dir, relative = configDir, strings.TrimPrefix(name, configPrefix)
+	case strings.HasPrefix(name, dataPrefix):
+		dir, relative = dataDir, strings.TrimPrefix(name, dataPrefix)
+	default:
+		return "", fmt.Errorf("file %s isn't in the config or data folder", name)
+	}
+	if !filepath.IsLocal(relative) {
+		return "", fmt.Errorf("file %s is outside of its folder", name)
+	}
+	return filepath.Join(dir, relative), nil
+}
@@ -0,0 +1,65 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+func TestProfileRoundTrip(t *testing.T) {
+	configDir := t.TempDir()
+	dataDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(configDir, overrideFolder), 0775); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		filepath.Join(configDir, "address-book.json"):        `{"entries":[]}`,
+		filepath.Join(configDir, overrideFolder, "eth1.yml"): "services: {}\n",
+		filepath.Join(dataDir, config.GasThresholdsFilename): "tasks: {}\n",
+		filepath.Join(dataDir, "wallet"):                     "secret",
+	}
+	for path, contents := range files {
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := config.NewRocketPoolConfig(configDir, false)
+	profile, err := Create(cfg, "1.0.0", configDir, dataDir)
+	if err != nil {
+		t.Fatalf("error creating profile: %s", err)
+	}
+	if len(profile.Files) != 3 {
+		t.Fatalf("expected 3 files without the wallet, got %v", profile.GetFileNames())
+	}
+
+	path := filepath.Join(t.TempDir(), "profile.yml")
+	if err := profile.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("error loading profile: %s", err)
+	}
+
+	newConfigDir := t.TempDir()
+	newDataDir := t.TempDir()
+	if err := loaded.RestoreFiles(newConfigDir, newDataDir); err != nil {
+		t.Fatalf("error restoring files: %s", err)
+	}
+	restored, err := os.ReadFile(filepath.Join(newConfigDir, overrideFolder, "eth1.yml"))
+	if err != nil || string(restored) != "services: {}\n" {
+		t.Fatalf("expected the override to be restored, got %q (%v)", restored, err)
+	}
+
+	// Files can't escape their folder
+	loaded.Files["data/../../escape"] = "bad"
+	if err := loaded.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatal("expected an error for a file outside of the data folder")
+	}
+}
@@ -0,0 +1,106 @@
+package remotesigner
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// How long to wait for the signer to respond, including any manual approval on its side
+const signTimeout time.Duration = 2 * time.Minute
+
+// A client for a remote signer that holds the node key on a separate host
+type Client struct {
+	endpoint string
+	conn     *grpc.ClientConn
+	address  common.Address
+}
+
+// Connect to a remote signer over mutually-authenticated TLS and get the address of the key it holds
+func NewClient(endpoint string, caCertPath string, certPath string, keyPath string) (*Client, error) {
+	tlsConfig, err := loadTLSConfig(caCertPath, certPath, keyPath, false)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.Dial(endpoint,
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype(jsonCodec{}.Name())),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to remote signer %s: %w", endpoint, err)
+	}
+	client := &Client{
+		endpoint: endpoint,
+		conn:     conn,
+	}
+
+	// Get the signer's address
+	ctx, cancel := context.WithTimeout(context.Background(), signTimeout)
+	defer cancel()
+	response := new(GetAddressResponse)
+	err = conn.Invoke(ctx, getAddressMethod, &GetAddressRequest{}, response)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error getting address from remote signer %s: %w", endpoint, err)
+	}
+	if !common.IsHexAddress(response.Address) {
+		conn.Close()
+		return nil, fmt.Errorf("remote signer %s returned an invalid address [%s]", endpoint, response.Address)
+	}
+	client.address = common.HexToAddress(response.Address)
+	return client, nil
+}
+
+// Get the address of the key held by the signer
+func (c *Client) Address() common.Address {
+	return c.address
+}
+
+// Have the signer approve and sign a transaction.
+// The signed transaction is checked against the original so a compromised signer can't substitute a different one.
+func (c *Client) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	txBytes, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("error serializing transaction: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, signTimeout)
+	defer cancel()
+	response := new(SignTransactionResponse)
+	err = c.conn.Invoke(ctx, signTransactionMethod, &SignTransactionRequest{
+		ChainID:     chainID.String(),
+		Transaction: txBytes,
+	}, response)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer %s did not sign the transaction: %w", c.endpoint, err)
+	}
+
+	signedTx := new(types.Transaction)
+	err = signedTx.UnmarshalBinary(response.SignedTransaction)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing signed transaction from remote signer: %w", err)
+	}
+	signer := types.LatestSignerForChainID(chainID)
+	if signer.Hash(signedTx) != signer.Hash(tx) {
+		return nil, fmt.Errorf("remote signer returned a different transaction than the one it was asked to sign")
+	}
+	sender, err := types.Sender(signer, signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("error recovering the sender of the signed transaction: %w", err)
+	}
+	if sender != c.address {
+		return nil, fmt.Errorf("remote signer signed the transaction with %s instead of %s", sender.Hex(), c.address.Hex())
+	}
+	return signedTx, nil
+}
+
+// Close the connection to the signer
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,211 @@
+package remotesigner
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The rules a transaction must satisfy before the signer will sign it.
+// Nothing is allowed by default: the targets, methods, gas limit, and total fee must all be set.
+type Policy struct {
+	ChainID        uint64   `yaml:"chainId"`
+	AllowedTargets []string `yaml:"allowedTargets"`
+	AllowedMethods []string `yaml:"allowedMethods"`
+	MaxGasLimit    uint64   `yaml:"maxGasLimit"`
+	MaxTxFeeEth    float64  `yaml:"maxTxFeeEth"`
+	MaxFeeGwei     float64  `yaml:"maxFeeGwei"`
+	AllowValue     bool     `yaml:"allowValue"`
+}
+
+// Load a signing policy from a YAML file
+func LoadPolicy(path string) (Policy, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Policy{}, fmt.Errorf("signing policy %s does not exist", path)
+	}
+	if err != nil {
+		return Policy{}, fmt.Errorf("error reading signing policy %s: %w", path, err)
+	}
+	var policy Policy
+	err = yaml.Unmarshal(bytes, &policy)
+	if err != nil {
+		return Policy{}, fmt.Errorf("error parsing signing policy %s: %w", path, err)
+	}
+	if policy.ChainID == 0 {
+		return Policy{}, fmt.Errorf("signing policy %s must set chainId", path)
+	}
+	if len(policy.AllowedTargets) == 0 {
+		return Policy{}, fmt.Errorf("signing policy %s must set allowedTargets", path)
+	}
+	if len(policy.AllowedMethods) == 0 {
+		return Policy{}, fmt.Errorf("signing policy %s must set allowedMethods", path)
+	}
+	if policy.MaxGasLimit == 0 {
+		return Policy{}, fmt.Errorf("signing policy %s must set maxGasLimit", path)
+	}
+	if policy.MaxTxFeeEth <= 0 {
+		return Policy{}, fmt.Errorf("signing policy %s must set maxTxFeeEth", path)
+	}
+	for _, target := range policy.AllowedTargets {
+		if !common.IsHexAddress(target) {
+			return Policy{}, fmt.Errorf("signing policy %s has an invalid target address [%s]", path, target)
+		}
+	}
+	for _, method := range policy.AllowedMethods {
+		if len(strings.TrimPrefix(method, "0x")) != 8 {
+			return Policy{}, fmt.Errorf("signing policy %s has an invalid method selector [%s]; it must be 4 bytes", path, method)
+		}
+	}
+	return policy, nil
+}
+
+// Check a transaction against the policy, returning the reason it was rejected if it doesn't satisfy it
+func (p Policy) check(tx *types.Transaction, chainID *big.Int) error {
+	if chainID.Uint64() != p.ChainID || tx.ChainId().Cmp(chainID) != 0 {
+		return fmt.Errorf("chain ID %s is not allowed", tx.ChainId().String())
+	}
+	if tx.To() == nil {
+		return fmt.Errorf("contract deployments are not allowed")
+	}
+	allowed := false
+	for _, target := range p.AllowedTargets {
+		if common.HexToAddress(target) == *tx.To() {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("target %s is not allowed", tx.To().Hex())
+	}
+	if len(tx.Data()) < 4 {
+		return fmt.Errorf("transactions without a method call are not allowed")
+	}
+	selector := common.Bytes2Hex(tx.Data()[:4])
+	allowed = false
+	for _, method := range p.AllowedMethods {
+		if strings.EqualFold(strings.TrimPrefix(method, "0x"), selector) {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("method 0x%s is not allowed", selector)
+	}
+	if !p.AllowValue && tx.Value().Sign() > 0 {
+		return fmt.Errorf("sending ETH is not allowed")
+	}
+	if p.MaxFeeGwei > 0 && tx.GasFeeCap().Cmp(eth.GweiToWei(p.MaxFeeGwei)) > 0 {
+		return fmt.Errorf("max fee of %.2f gwei exceeds the limit of %.2f gwei", eth.WeiToGwei(tx.GasFeeCap()), p.MaxFeeGwei)
+	}
+	if tx.Gas() > p.MaxGasLimit {
+		return fmt.Errorf("gas limit of %d exceeds the limit of %d", tx.Gas(), p.MaxGasLimit)
+	}
+	txFee := new(big.Int).Mul(tx.GasFeeCap(), new(big.Int).SetUint64(tx.Gas()))
+	if txFee.Cmp(eth.EthToWei(p.MaxTxFeeEth)) > 0 {
+		return fmt.Errorf("max transaction fee of %.6f ETH exceeds the limit of %.6f ETH", eth.WeiToEth(txFee), p.MaxTxFeeEth)
+	}
+	return nil
+}
+
+// A remote signer that holds the node key and signs the transactions that its policy approves
+type Server struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+	policy  Policy
+	log     *log.ColorLogger
+}
+
+// Create a new remote signer
+func NewServer(key *ecdsa.PrivateKey, policy Policy, logger *log.ColorLogger) *Server {
+	return &Server{
+		key:     key,
+		address: crypto.PubkeyToAddress(key.PublicKey),
+		policy:  policy,
+		log:     logger,
+	}
+}
+
+// Serve signing requests on the listen address, only accepting clients with a certificate signed by the CA
+func (s *Server) Serve(listenAddress string, caCertPath string, certPath string, keyPath string) error {
+	tlsConfig, err := loadTLSConfig(caCertPath, certPath, keyPath, true)
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return fmt.Errorf("error listening on %s: %w", listenAddress, err)
+	}
+
+	grpcServer := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	grpcServer.RegisterService(&serviceDesc, s)
+	s.log.Printlnf("Signing for %s on %s.", s.address.Hex(), listenAddress)
+	return grpcServer.Serve(listener)
+}
+
+// Get the address of the key held by the signer
+func (s *Server) GetAddress(ctx context.Context, request *GetAddressRequest) (*GetAddressResponse, error) {
+	return &GetAddressResponse{
+		Address: s.address.Hex(),
+	}, nil
+}
+
+// Sign a transaction if it satisfies the policy
+func (s *Server) SignTransaction(ctx context.Context, request *SignTransactionRequest) (*SignTransactionResponse, error) {
+	chainID, ok := big.NewInt(0).SetString(request.ChainID, 10)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid chain ID [%s]", request.ChainID)
+	}
+	tx := new(types.Transaction)
+	err := tx.UnmarshalBinary(request.Transaction)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction: %s", err.Error())
+	}
+
+	// Check the policy
+	err = s.policy.check(tx, chainID)
+	if err != nil {
+		s.log.Printlnf("Rejected transaction to %s with nonce %d: %s.", getTarget(tx), tx.Nonce(), err.Error())
+		return nil, status.Errorf(codes.PermissionDenied, "rejected by signing policy: %s", err.Error())
+	}
+
+	// Sign it
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error signing transaction: %s", err.Error())
+	}
+	signedBytes, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error serializing signed transaction: %s", err.Error())
+	}
+	s.log.Printlnf("Signed transaction %s to %s with nonce %d.", signedTx.Hash().Hex(), getTarget(tx), tx.Nonce())
+	return &SignTransactionResponse{
+		SignedTransaction: signedBytes,
+	}, nil
+}
+
+// Get a printable target for a transaction
+func getTarget(tx *types.Transaction) string {
+	if tx.To() == nil {
+		return "<contract creation>"
+	}
+	return tx.To().Hex()
+}
@@ -0,0 +1,104 @@
+package remotesigner
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+const (
+	testTarget = "0x1111111111111111111111111111111111111111"
+	testMethod = "0xaabbccdd"
+)
+
+func writePolicy(t *testing.T, contents string) string {
+	path := filepath.Join(t.TempDir(), "policy.yml")
+	err := os.WriteFile(path, []byte(contents), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPolicyDeniesByDefault(t *testing.T) {
+	complete := map[string]string{
+		"chainId":        "chainId: 1",
+		"allowedTargets": "allowedTargets: [\"" + testTarget + "\"]",
+		"allowedMethods": "allowedMethods: [\"" + testMethod + "\"]",
+		"maxGasLimit":    "maxGasLimit: 500000",
+		"maxTxFeeEth":    "maxTxFeeEth: 0.05",
+	}
+
+	var lines []string
+	for _, line := range complete {
+		lines = append(lines, line)
+	}
+	_, err := LoadPolicy(writePolicy(t, strings.Join(lines, "\n")))
+	if err != nil {
+		t.Fatalf("error loading a complete policy: %s", err.Error())
+	}
+
+	// Leaving out any of the limits should reject the policy instead of allowing everything
+	for missing := range complete {
+		var lines []string
+		for setting, line := range complete {
+			if setting != missing {
+				lines = append(lines, line)
+			}
+		}
+		_, err := LoadPolicy(writePolicy(t, strings.Join(lines, "\n")))
+		if err == nil {
+			t.Errorf("expected a policy without %s to be rejected", missing)
+		} else if !strings.Contains(err.Error(), missing) {
+			t.Errorf("expected the error for a policy without %s to mention it, got: %s", missing, err.Error())
+		}
+	}
+}
+
+func TestPolicyCheckLimits(t *testing.T) {
+	policy := Policy{
+		ChainID:        1,
+		AllowedTargets: []string{testTarget},
+		AllowedMethods: []string{testMethod},
+		MaxGasLimit:    500000,
+		MaxTxFeeEth:    0.01,
+	}
+	chainID := big.NewInt(1)
+	target := common.HexToAddress(testTarget)
+	newTx := func(gas uint64, maxFeeGwei float64) *types.Transaction {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Gas:       gas,
+			GasFeeCap: eth.GweiToWei(maxFeeGwei),
+			GasTipCap: big.NewInt(0),
+			To:        &target,
+			Value:     big.NewInt(0),
+			Data:      common.FromHex(testMethod),
+		})
+	}
+
+	cases := []struct {
+		name    string
+		tx      *types.Transaction
+		allowed bool
+	}{
+		{"within the limits", newTx(200000, 20), true},
+		{"gas limit too high", newTx(600000, 1), false},
+		{"total fee too high", newTx(400000, 30), false},
+	}
+	for _, c := range cases {
+		err := policy.check(c.tx, chainID)
+		if c.allowed && err != nil {
+			t.Errorf("%s: expected the transaction to be allowed, got: %s", c.name, err.Error())
+		}
+		if !c.allowed && err == nil {
+			t.Errorf("%s: expected the transaction to be rejected", c.name)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package remotesigner
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// The gRPC service served by the remote signer
+const (
+	serviceName           string = "smartnode.remotesigner.v1.Signer"
+	getAddressMethod      string = "/" + serviceName + "/GetAddress"
+	signTransactionMethod string = "/" + serviceName + "/SignTransaction"
+)
+
+// Request for the address of the key held by the signer
+type GetAddressRequest struct{}
+
+// The address of the key held by the signer
+type GetAddressResponse struct {
+	Address string `json:"address"`
+}
+
+// Request to sign an unsigned transaction built by the watchtower
+type SignTransactionRequest struct {
+	ChainID     string `json:"chainId"`
+	Transaction []byte `json:"transaction"`
+}
+
+// The signed transaction, ready to broadcast
+type SignTransactionResponse struct {
+	SignedTransaction []byte `json:"signedTransaction"`
+}
+
+// The signer's gRPC handlers
+type signerServer interface {
+	GetAddress(context.Context, *GetAddressRequest) (*GetAddressResponse, error)
+	SignTransaction(context.Context, *SignTransactionRequest) (*SignTransactionResponse, error)
+}
+
+// The messages are plain structs rather than generated protobuf types, so they're sent as JSON
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return "json"
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// The service definition, written by hand in place of protoc-generated code
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*signerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAddress",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				request := new(GetAddressRequest)
+				if err := dec(request); err != nil {
+					return nil, err
+				}
+				return srv.(signerServer).GetAddress(ctx, request)
+			},
+		},
+		{
+			MethodName: "SignTransaction",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				request := new(SignTransactionRequest)
+				if err := dec(request); err != nil {
+					return nil, err
+				}
+				return srv.(signerServer).SignTransaction(ctx, request)
+			},
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
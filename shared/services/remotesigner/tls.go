@@ -0,0 +1,37 @@
+package remotesigner
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// Build a mutual TLS config: both sides present a certificate, and each only trusts certificates signed by the CA
+func loadTLSConfig(caCertPath string, certPath string, keyPath string, isServer bool) (*tls.Config, error) {
+	caBytes, err := os.ReadFile(caCertPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading CA certificate %s: %w", caCertPath, err)
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caBytes) {
+		return nil, fmt.Errorf("CA certificate %s does not contain any PEM-encoded certificates", caCertPath)
+	}
+
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("error loading certificate %s and key %s: %w", certPath, keyPath, err)
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS13,
+	}
+	if isServer {
+		config.ClientCAs = caPool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	} else {
+		config.RootCAs = caPool
+	}
+	return config, nil
+}
@@ -167,6 +167,15 @@ func WaitNodePassword(c *cli.Context, verbose bool) error {
 }
 
 func WaitNodeWallet(c *cli.Context, verbose bool) error {
+	// A remote signer holds the node key, so the local wallet and its password aren't needed
+	w, err := GetWallet(c)
+	if err != nil {
+		return err
+	}
+	if w.HasRemoteSigner() {
+		return nil
+	}
+
 	if err := WaitNodePassword(c, verbose); err != nil {
 		return err
 	}
@@ -0,0 +1,87 @@
+package reth
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// The length of a year used to annualize returns
+const year time.Duration = 365 * 24 * time.Hour
+
+// Get the annualized return implied by the change in the rETH exchange rate between two times
+func GetRateApr(startRate float64, startTime time.Time, endRate float64, endTime time.Time) (float64, error) {
+	if startRate <= 0 {
+		return 0, fmt.Errorf("the starting exchange rate must be positive")
+	}
+	elapsed := endTime.Sub(startTime)
+	if elapsed <= 0 {
+		return 0, fmt.Errorf("the exchange rate samples must span a positive amount of time")
+	}
+	return (endRate/startRate - 1) * float64(year) / float64(elapsed), nil
+}
+
+// Get the annualized return implied by the exchange rates recorded in a set of network totals samples, using the oldest
+// and newest samples that have a rate. Returns false if there aren't two such samples.
+func GetSamplesApr(samples []state.NetworkTotalsSample) (float64, bool) {
+	var first, last *state.NetworkTotalsSample
+	for i := range samples {
+		if samples[i].RethExchangeRate <= 0 {
+			continue
+		}
+		if first == nil {
+			first = &samples[i]
+		}
+		last = &samples[i]
+	}
+	if first == nil || !last.Time.After(first.Time) {
+		return 0, false
+	}
+	apr, err := GetRateApr(first.RethExchangeRate, first.Time, last.RethExchangeRate, last.Time)
+	if err != nil {
+		return 0, false
+	}
+	return apr, true
+}
+
+// Get the annualized return the pool stakers' share of the smoothing pool adds to the ETH backing rETH, if the share
+// accrued over the elapsed part of the rewards interval
+func GetSmoothingPoolApr(stakerShare *big.Int, totalEth *big.Int, elapsed time.Duration) float64 {
+	if stakerShare == nil || totalEth == nil || totalEth.Sign() <= 0 || elapsed <= 0 {
+		return 0
+	}
+	share, _ := new(big.Float).Quo(new(big.Float).SetInt(stakerShare), new(big.Float).SetInt(totalEth)).Float64()
+	return share * float64(year) / float64(elapsed)
+}
+
+// Get when the Oracle DAO next updates the exchange rate. Updates target the multiples of the submission frequency after
+// the reference time, so the next one is the first multiple after the one the latest update targeted.
+func GetNextRateUpdate(lastUpdate time.Time, reference time.Time, frequency time.Duration) time.Time {
+	if frequency <= 0 {
+		return time.Time{}
+	}
+	if lastUpdate.Before(reference) {
+		return reference
+	}
+	intervals := lastUpdate.Sub(reference) / frequency
+	return reference.Add((intervals + 1) * frequency)
+}
+
+// Get how much ETH the deposit pool can accept, mirroring RocketDepositPool.getMaximumDepositAmount
+func GetDepositCapacity(depositEnabled bool, assignDepositsEnabled bool, maximumPoolSize *big.Int, poolBalance *big.Int, effectiveQueueCapacity *big.Int) *big.Int {
+	if !depositEnabled {
+		return big.NewInt(0)
+	}
+
+	// Deposits that can be assigned to minipools straight away don't count towards the pool's size
+	maximum := new(big.Int).Set(maximumPoolSize)
+	if assignDepositsEnabled && effectiveQueueCapacity != nil {
+		maximum.Add(maximum, effectiveQueueCapacity)
+	}
+	if poolBalance.Cmp(maximum) >= 0 {
+		return big.NewInt(0)
+	}
+	return maximum.Sub(maximum, poolBalance)
+}
@@ -0,0 +1,82 @@
+package reth
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+func TestGetRateApr(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	apr, err := GetRateApr(1.10, start, 1.1011, start.Add(year/10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(apr-0.01) > 1e-9 {
+		t.Fatalf("expected a 1%% APR, got %f", apr)
+	}
+	if _, err := GetRateApr(1.1, start, 1.2, start); err == nil {
+		t.Fatal("expected samples at the same time to fail")
+	}
+}
+
+func TestGetSamplesApr(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	samples := []state.NetworkTotalsSample{
+		{Time: start.Add(-time.Hour)},
+		{Time: start, RethExchangeRate: 1.10},
+		{Time: start.Add(year / 20), RethExchangeRate: 1.1005},
+		{Time: start.Add(year / 10), RethExchangeRate: 1.1011},
+	}
+	apr, ok := GetSamplesApr(samples)
+	if !ok {
+		t.Fatal("expected an APR")
+	}
+	if math.Abs(apr-0.01) > 1e-9 {
+		t.Fatalf("expected a 1%% APR, got %f", apr)
+	}
+	if _, ok := GetSamplesApr(samples[:2]); ok {
+		t.Fatal("expected a single rate sample not to have an APR")
+	}
+}
+
+func TestGetSmoothingPoolApr(t *testing.T) {
+	// 10 ETH of share on 10000 ETH over a tenth of a year is 1% annualized
+	apr := GetSmoothingPoolApr(big.NewInt(10), big.NewInt(10000), year/10)
+	if math.Abs(apr-0.01) > 1e-9 {
+		t.Fatalf("expected a 1%% APR, got %f", apr)
+	}
+	if apr := GetSmoothingPoolApr(big.NewInt(10), big.NewInt(0), year); apr != 0 {
+		t.Fatalf("expected no APR without any ETH, got %f", apr)
+	}
+}
+
+func TestGetNextRateUpdate(t *testing.T) {
+	reference := time.Unix(1700000000, 0)
+	frequency := 24 * time.Hour
+
+	// An update a few minutes after its target is followed by the next day's
+	next := GetNextRateUpdate(reference.Add(3*frequency+5*time.Minute), reference, frequency)
+	if !next.Equal(reference.Add(4 * frequency)) {
+		t.Fatalf("expected the next update at %s, got %s", reference.Add(4*frequency), next)
+	}
+}
+
+func TestGetDepositCapacity(t *testing.T) {
+	maximum := big.NewInt(5000)
+	if capacity := GetDepositCapacity(true, false, maximum, big.NewInt(4000), big.NewInt(2000)); capacity.Int64() != 1000 {
+		t.Fatalf("expected a capacity of 1000, got %s", capacity)
+	}
+	if capacity := GetDepositCapacity(true, true, maximum, big.NewInt(6000), big.NewInt(2000)); capacity.Int64() != 1000 {
+		t.Fatalf("expected a capacity of 1000 with the queue, got %s", capacity)
+	}
+	if capacity := GetDepositCapacity(true, false, maximum, big.NewInt(6000), big.NewInt(2000)); capacity.Sign() != 0 {
+		t.Fatalf("expected no capacity over the maximum, got %s", capacity)
+	}
+	if capacity := GetDepositCapacity(false, true, maximum, big.NewInt(0), big.NewInt(0)); capacity.Sign() != 0 {
+		t.Fatalf("expected no capacity with deposits disabled, got %s", capacity)
+	}
+}
@@ -0,0 +1,184 @@
+package rewards
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/smartnode/shared/services/rewards/ssz_types"
+)
+
+// How a ruleset accounts for the wei lost to integer division when rewards are split between nodes and minipools
+type AccountingPolicy string
+
+const (
+	// Every share is truncated, and the shares may add up to as much as max(nodeCount, minipoolCount) wei less than the
+	// expected total. The shortfall goes to the Protocol DAO (RPL) or the pool stakers (ETH).
+	AccountingPolicy_Legacy AccountingPolicy = "legacy"
+
+	// Shares are assigned with the largest remainder method so they add up to exactly the expected total, and any
+	// difference at all is treated as an error.
+	AccountingPolicy_Strict AccountingPolicy = "strict"
+)
+
+// The accounting policy to use for each ruleset version
+type AccountingPolicies struct {
+	// The policy for rulesets that aren't listed
+	Default AccountingPolicy
+
+	// The policy for specific rulesets
+	Rulesets map[uint64]AccountingPolicy
+}
+
+// Parse a list of accounting policies, such as "strict" to use strict accounting for every ruleset or "9=legacy,10=strict"
+// to choose per ruleset. A blank string uses the legacy policy for every ruleset, which is what all of the rulesets to date
+// were specified with.
+func ParseAccountingPolicies(value string) (AccountingPolicies, error) {
+	policies := AccountingPolicies{
+		Default:  AccountingPolicy_Legacy,
+		Rulesets: map[uint64]AccountingPolicy{},
+	}
+	for _, element := range strings.Split(value, ",") {
+		element = strings.TrimSpace(element)
+		if element == "" {
+			continue
+		}
+
+		versionString, policyString, hasVersion := strings.Cut(element, "=")
+		if !hasVersion {
+			policyString = versionString
+		}
+		policy := AccountingPolicy(strings.ToLower(strings.TrimSpace(policyString)))
+		if policy != AccountingPolicy_Legacy && policy != AccountingPolicy_Strict {
+			return AccountingPolicies{}, fmt.Errorf("invalid accounting policy '%s' - must be '%s' or '%s'", policyString, AccountingPolicy_Legacy, AccountingPolicy_Strict)
+		}
+		if !hasVersion {
+			policies.Default = policy
+			continue
+		}
+
+		versionString = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(versionString)), "v")
+		version, err := strconv.ParseUint(versionString, 10, 64)
+		if err != nil {
+			return AccountingPolicies{}, fmt.Errorf("invalid ruleset version in accounting policy '%s': %w", element, err)
+		}
+		policies.Rulesets[version] = policy
+	}
+	return policies, nil
+}
+
+// Get the accounting policy for a ruleset version
+func (p AccountingPolicies) ForRuleset(rulesetVersion uint64) AccountingPolicy {
+	if policy, exists := p.Rulesets[rulesetVersion]; exists {
+		return policy
+	}
+	if p.Default == "" {
+		return AccountingPolicy_Legacy
+	}
+	return p.Default
+}
+
+// Check that a list of accounting policies uses the legacy policy for every ruleset. Strict accounting changes the Merkle
+// roots of the existing rulesets, so it's only for local and offline tree generation; trees and balances submitted by the
+// Oracle DAO have to use the canonical policy.
+func CheckCanonicalAccountingPolicies(value string) error {
+	policies, err := ParseAccountingPolicies(value)
+	if err != nil {
+		return err
+	}
+	if policies.Default != AccountingPolicy_Legacy {
+		return fmt.Errorf("the %s accounting policy can only be used for local tree generation", policies.Default)
+	}
+	for version, policy := range policies.Rulesets {
+		if policy != AccountingPolicy_Legacy {
+			return fmt.Errorf("the %s accounting policy for ruleset v%d can only be used for local tree generation", policy, version)
+		}
+	}
+	return nil
+}
+
+// Get the largest difference the policy allows between a calculated total and the expected total
+func (p AccountingPolicy) tolerance(epsilon *big.Int) *big.Int {
+	if p == AccountingPolicy_Strict {
+		return big.NewInt(0)
+	}
+	return epsilon
+}
+
+// Split total between addresses in proportion to their weights, out of totalWeight.
+// Each share is first truncated, then the wei left over are handed out one at a time in order of the largest truncated
+// remainder, with ties going to the lowest address so the result doesn't depend on map or slice ordering.
+// If the weights add up to totalWeight, the shares add up to exactly total; if they add up to less, the shares can't be
+// topped up past one extra wei each and will fall short.
+func distributeLargestRemainder(total *big.Int, weights map[common.Address]*big.Int, totalWeight *big.Int) map[common.Address]*big.Int {
+	type entry struct {
+		address   common.Address
+		remainder *big.Int
+	}
+
+	shares := make(map[common.Address]*big.Int, len(weights))
+	if totalWeight.Sign() <= 0 {
+		for address := range weights {
+			shares[address] = big.NewInt(0)
+		}
+		return shares
+	}
+
+	entries := make([]entry, 0, len(weights))
+	leftover := big.NewInt(0).Set(total)
+	for address, weight := range weights {
+		if weight.Sign() <= 0 {
+			shares[address] = big.NewInt(0)
+			continue
+		}
+		share, remainder := big.NewInt(0).QuoRem(big.NewInt(0).Mul(total, weight), totalWeight, big.NewInt(0))
+		shares[address] = share
+		leftover.Sub(leftover, share)
+		if remainder.Sign() > 0 {
+			entries = append(entries, entry{address, remainder})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if comparison := entries[i].remainder.Cmp(entries[j].remainder); comparison != 0 {
+			return comparison > 0
+		}
+		return bytes.Compare(entries[i].address.Bytes(), entries[j].address.Bytes()) < 0
+	})
+	for i := 0; i < len(entries) && leftover.Sign() > 0; i++ {
+		shares[entries[i].address].Add(shares[entries[i].address], common.Big1)
+		leftover.Sub(leftover, common.Big1)
+	}
+	return shares
+}
+
+// Save the dust accounting for a rewards file that can't carry it natively
+func saveDustAccounting(dust *ssz_types.DustAccounting, path string) error {
+	bytes, err := json.Marshal(dust)
+	if err != nil {
+		return fmt.Errorf("error serializing dust accounting: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return fmt.Errorf("error writing dust accounting to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Load the dust accounting sidecar saved next to a rewards file
+func LoadDustAccounting(path string) (*ssz_types.DustAccounting, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading dust accounting from %s: %w", path, err)
+	}
+	dust := &ssz_types.DustAccounting{}
+	if err := json.Unmarshal(bytes, dust); err != nil {
+		return nil, fmt.Errorf("error deserializing dust accounting from %s: %w", path, err)
+	}
+	return dust, nil
+}
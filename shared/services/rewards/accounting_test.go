@@ -0,0 +1,237 @@
+package rewards
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/fatih/color"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/rewards/ssz_types"
+	"github.com/rocket-pool/smartnode/shared/services/rewards/test"
+	"github.com/rocket-pool/smartnode/shared/services/rewards/test/assets"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+func TestParseAccountingPolicies(t *testing.T) {
+	policies, err := ParseAccountingPolicies("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policies.ForRuleset(10) != AccountingPolicy_Legacy {
+		t.Fatalf("expected a blank policy to be legacy, got %s", policies.ForRuleset(10))
+	}
+
+	policies, err = ParseAccountingPolicies("Strict")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policies.ForRuleset(9) != AccountingPolicy_Strict || policies.ForRuleset(10) != AccountingPolicy_Strict {
+		t.Fatalf("expected every ruleset to be strict")
+	}
+
+	policies, err = ParseAccountingPolicies("strict, v9=legacy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policies.ForRuleset(9) != AccountingPolicy_Legacy {
+		t.Fatalf("expected ruleset 9 to be legacy, got %s", policies.ForRuleset(9))
+	}
+	if policies.ForRuleset(10) != AccountingPolicy_Strict {
+		t.Fatalf("expected ruleset 10 to be strict, got %s", policies.ForRuleset(10))
+	}
+
+	for _, value := range []string{"lenient", "10=", "ten=strict"} {
+		if _, err := ParseAccountingPolicies(value); err == nil {
+			t.Fatalf("expected '%s' to be rejected", value)
+		}
+	}
+}
+
+func TestCheckCanonicalAccountingPolicies(t *testing.T) {
+	for _, value := range []string{"", "legacy", "9=legacy,10=legacy"} {
+		if err := CheckCanonicalAccountingPolicies(value); err != nil {
+			t.Fatalf("expected '%s' to be canonical: %s", value, err)
+		}
+	}
+	for _, value := range []string{"strict", "legacy,10=strict", "strict,9=legacy"} {
+		if err := CheckCanonicalAccountingPolicies(value); err == nil {
+			t.Fatalf("expected '%s' to be refused", value)
+		}
+	}
+}
+
+func TestDistributeLargestRemainderAddsUpExactly(t *testing.T) {
+	weights := map[common.Address]*big.Int{}
+	totalWeight := big.NewInt(0)
+	for i := int64(1); i <= 7; i++ {
+		weight := big.NewInt(i * 13)
+		weights[common.BigToAddress(big.NewInt(i))] = weight
+		totalWeight.Add(totalWeight, weight)
+	}
+
+	total := big.NewInt(1000003)
+	shares := distributeLargestRemainder(total, weights, totalWeight)
+	sum := big.NewInt(0)
+	for address, share := range shares {
+		// Every share is within one wei of its truncated proportional amount
+		floor := big.NewInt(0).Mul(total, weights[address])
+		floor.Div(floor, totalWeight)
+		extra := big.NewInt(0).Sub(share, floor)
+		if extra.Sign() < 0 || extra.Cmp(common.Big1) > 0 {
+			t.Fatalf("share for %s is %s, which isn't within one wei of %s", address.Hex(), share, floor)
+		}
+		sum.Add(sum, share)
+	}
+	if sum.Cmp(total) != 0 {
+		t.Fatalf("shares add up to %s, expected %s", sum, total)
+	}
+}
+
+func TestDistributeLargestRemainderIsDeterministic(t *testing.T) {
+	// Three equal weights leave 2 wei of dust with tied remainders, which go to the two lowest addresses
+	low := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	middle := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	high := common.HexToAddress("0xffffffffffffffffffffffffffffffffffffffff")
+	weights := map[common.Address]*big.Int{
+		high:   big.NewInt(1),
+		low:    big.NewInt(1),
+		middle: big.NewInt(1),
+	}
+
+	expected := map[common.Address]int64{
+		low:    4,
+		middle: 4,
+		high:   3,
+	}
+	for i := 0; i < 20; i++ {
+		// Map iteration order is randomized, so repeating the distribution exercises different orders
+		shares := distributeLargestRemainder(big.NewInt(11), weights, big.NewInt(3))
+		for address, amount := range expected {
+			if shares[address].Int64() != amount {
+				t.Fatalf("share for %s is %s, expected %d", address.Hex(), shares[address], amount)
+			}
+		}
+	}
+
+	// The largest remainder wins regardless of address
+	weights = map[common.Address]*big.Int{
+		low:  big.NewInt(1),
+		high: big.NewInt(2),
+	}
+	shares := distributeLargestRemainder(big.NewInt(1), weights, big.NewInt(3))
+	if shares[high].Int64() != 1 || shares[low].Int64() != 0 {
+		t.Fatalf("expected the dust to go to the largest remainder, got %s and %s", shares[low], shares[high])
+	}
+}
+
+func TestDistributeLargestRemainderZeroWeight(t *testing.T) {
+	address := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	weights := map[common.Address]*big.Int{
+		address: big.NewInt(0),
+	}
+	shares := distributeLargestRemainder(big.NewInt(100), weights, big.NewInt(0))
+	if shares[address].Sign() != 0 {
+		t.Fatalf("expected a zero share, got %s", shares[address])
+	}
+}
+
+func TestMockStrictAccountingTreegenv10(tt *testing.T) {
+
+	history := test.NewDefaultMockHistory()
+	state := history.GetEndNetworkState()
+
+	t := newV8Test(tt, state.NetworkDetails.RewardIndex)
+
+	t.bc.SetState(state)
+	history.SetWithdrawals(t.bc)
+
+	consensusStartBlock := history.GetConsensusStartBlock()
+	executionStartBlock := history.GetExecutionStartBlock()
+	consensusEndBlock := history.GetConsensusEndBlock()
+	executionEndBlock := history.GetExecutionEndBlock()
+
+	logger := log.NewColorLogger(color.Faint)
+
+	t.rp.SetRewardSnapshotEvent(history.GetPreviousRewardSnapshotEvent())
+	t.bc.SetBeaconBlock(fmt.Sprint(consensusStartBlock-1), beacon.BeaconBlock{ExecutionBlockNumber: executionStartBlock - 1})
+	t.bc.SetBeaconBlock(fmt.Sprint(consensusStartBlock), beacon.BeaconBlock{ExecutionBlockNumber: executionStartBlock})
+	t.rp.SetHeaderByNumber(big.NewInt(int64(executionStartBlock)), &types.Header{Time: uint64(history.GetStartTime().Unix())})
+
+	for _, validator := range state.ValidatorDetails {
+		t.bc.SetMinipoolPerformance(validator.Index, make([]uint64, 0))
+	}
+
+	generate := func(policy AccountingPolicy) (IRewardsFile, *ssz_types.DustAccounting) {
+		generator := newTreeGeneratorImpl_v9_v10(
+			10,
+			&logger,
+			t.Name()+"-"+string(policy),
+			state.NetworkDetails.RewardIndex,
+			&SnapshotEnd{
+				Slot:           consensusEndBlock,
+				ConsensusBlock: consensusEndBlock,
+				ExecutionBlock: executionEndBlock,
+			},
+			&types.Header{
+				Number: big.NewInt(int64(history.GetExecutionEndBlock())),
+				Time:   assets.Mainnet20ELHeaderTime,
+			},
+			/* intervalsPassed= */ 1,
+			state,
+		)
+		generator.setAccountingPolicy(policy)
+		artifacts, err := generator.GenerateTree(t.rp, "mainnet", make([]common.Address, 0), t.bc)
+		t.failIf(err)
+		return artifacts.RewardsFile, artifacts.DustAccounting
+	}
+
+	legacyFile, legacyDust := generate(AccountingPolicy_Legacy)
+	strictFile, strictDust := generate(AccountingPolicy_Strict)
+
+	// The dust moves from the pDAO to the nodes, but nothing is created or lost
+	legacyRpl := big.NewInt(0).Add(legacyFile.GetTotalCollateralRpl(), legacyFile.GetTotalProtocolDaoRpl())
+	strictRpl := big.NewInt(0).Add(strictFile.GetTotalCollateralRpl(), strictFile.GetTotalProtocolDaoRpl())
+	if legacyRpl.Cmp(strictRpl) != 0 {
+		t.Fatalf("collateral and pDAO RPL add up to %s with strict accounting, expected %s", strictRpl, legacyRpl)
+	}
+	if strictFile.GetTotalCollateralRpl().Cmp(legacyFile.GetTotalCollateralRpl()) < 0 {
+		t.Fatalf("strict collateral RPL %s is less than the legacy %s", strictFile.GetTotalCollateralRpl(), legacyFile.GetTotalCollateralRpl())
+	}
+	legacyEth := big.NewInt(0).Add(legacyFile.GetTotalNodeOperatorSmoothingPoolEth(), legacyFile.GetTotalPoolStakerSmoothingPoolEth())
+	strictEth := big.NewInt(0).Add(strictFile.GetTotalNodeOperatorSmoothingPoolEth(), strictFile.GetTotalPoolStakerSmoothingPoolEth())
+	if legacyEth.Cmp(strictEth) != 0 {
+		t.Fatalf("smoothing pool ETH adds up to %s with strict accounting, expected %s", strictEth, legacyEth)
+	}
+
+	// The legacy file accounts for exactly the dust that strict accounting handed out
+	collateralDust := big.NewInt(0).Sub(strictFile.GetTotalCollateralRpl(), legacyFile.GetTotalCollateralRpl())
+	if legacyDust.CollateralRpl.Cmp(collateralDust) != 0 {
+		t.Fatalf("legacy collateral dust was recorded as %s, expected %s", legacyDust.CollateralRpl, collateralDust)
+	}
+	if legacyDust.CollateralRpl.Sign() == 0 {
+		t.Fatalf("expected the legacy file to have some collateral dust")
+	}
+	if strictDust.CollateralRpl.Sign() != 0 || strictDust.OracleDaoRpl.Sign() != 0 || strictDust.SmoothingPoolEth.Sign() != 0 {
+		t.Fatalf("expected no dust with strict accounting, got %s collateral RPL, %s oDAO RPL, and %s ETH", strictDust.CollateralRpl, strictDust.OracleDaoRpl, strictDust.SmoothingPoolEth)
+	}
+	if legacyDust.PercentageRpl.Cmp(strictDust.PercentageRpl.Int) != 0 {
+		t.Fatalf("percentage dust should be the same for both policies: %s != %s", legacyDust.PercentageRpl, strictDust.PercentageRpl)
+	}
+
+	// Each node gets at most one extra wei of collateral RPL
+	for _, address := range strictFile.GetNodeAddresses() {
+		extra := big.NewInt(0).Sub(strictFile.GetNodeCollateralRpl(address), legacyFile.GetNodeCollateralRpl(address))
+		if extra.Sign() < 0 || extra.Cmp(common.Big1) > 0 {
+			t.Fatalf("node %s got %s extra wei of collateral RPL with strict accounting", address.Hex(), extra)
+		}
+	}
+
+	// The dust is assigned deterministically, so repeated runs produce the same tree
+	repeatedFile, _ := generate(AccountingPolicy_Strict)
+	if strictRoot := repeatedFile.GetMerkleRoot(); strictRoot != strictFile.GetMerkleRoot() {
+		t.Fatalf("strict accounting produced different merkle roots: %s and %s", strictFile.GetMerkleRoot(), strictRoot)
+	}
+}
@@ -0,0 +1,208 @@
+package rewards
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The fields of the submission metadata that make up an interval's artifact manifest. The mirror URLs and generation time
+// differ between Oracle DAO members, so they aren't signed.
+type artifactManifest struct {
+	Index      uint64            `json:"index"`
+	Network    string            `json:"network"`
+	MerkleRoot string            `json:"merkleRoot"`
+	PrimaryCid string            `json:"primaryCid"`
+	Cids       map[string]string `json:"cids"`
+}
+
+// A detached signature over an interval's artifact manifest
+type ManifestSignature struct {
+	Index        uint64         `json:"index"`
+	ManifestHash common.Hash    `json:"manifestHash"`
+	Signer       common.Address `json:"signer"`
+	Signature    hexutil.Bytes  `json:"signature"`
+}
+
+// The signatures a signer quorum collected over an interval's artifact manifest
+type Attestation struct {
+	Index        uint64              `json:"index"`
+	ManifestHash common.Hash         `json:"manifestHash"`
+	Threshold    uint64              `json:"threshold"`
+	Signers      []common.Address    `json:"signers"`
+	Signatures   []ManifestSignature `json:"signatures"`
+}
+
+// Get the hash of the artifact manifest described by the submission metadata
+func (m *SubmissionMetadata) GetManifestHash() (common.Hash, error) {
+	// encoding/json sorts the CID map's keys, so every member serializes the same manifest identically
+	bytes, err := json.Marshal(artifactManifest{
+		Index:      m.Index,
+		Network:    m.Network,
+		MerkleRoot: m.MerkleRoot,
+		PrimaryCid: m.PrimaryCid,
+		Cids:       m.Cids,
+	})
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error serializing artifact manifest: %w", err)
+	}
+	return crypto.Keccak256Hash(bytes), nil
+}
+
+// Get the message that signers sign for a manifest; it's signed as a personal message, so any wallet can produce a
+// co-signature
+func GetManifestMessage(manifestHash common.Hash) string {
+	return fmt.Sprintf("Rocket Pool rewards artifact manifest %s", manifestHash.Hex())
+}
+
+// Sign an interval's artifact manifest with the provided personal message signer
+func SignManifest(index uint64, manifestHash common.Hash, signer common.Address, signMessage func(string) ([]byte, error)) (ManifestSignature, error) {
+	signature, err := signMessage(GetManifestMessage(manifestHash))
+	if err != nil {
+		return ManifestSignature{}, fmt.Errorf("error signing artifact manifest: %w", err)
+	}
+	return ManifestSignature{
+		Index:        index,
+		ManifestHash: manifestHash,
+		Signer:       signer,
+		Signature:    signature,
+	}, nil
+}
+
+// Check that the signature was made by its signer
+func (s ManifestSignature) Verify() error {
+	if len(s.Signature) != crypto.SignatureLength {
+		return fmt.Errorf("signature from %s has the wrong length", s.Signer.Hex())
+	}
+	signature := make([]byte, len(s.Signature))
+	copy(signature, s.Signature)
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	pubkey, err := crypto.SigToPub(accounts.TextHash([]byte(GetManifestMessage(s.ManifestHash))), signature)
+	if err != nil {
+		return fmt.Errorf("error recovering the signer of the signature from %s: %w", s.Signer.Hex(), err)
+	}
+	if recovered := crypto.PubkeyToAddress(*pubkey); recovered != s.Signer {
+		return fmt.Errorf("signature from %s was made by %s", s.Signer.Hex(), recovered.Hex())
+	}
+	return nil
+}
+
+// Save a detached signature to the signatures directory
+func SaveManifestSignature(dir string, signature ManifestSignature) error {
+	bytes, err := json.MarshalIndent(signature, "", "\t")
+	if err != nil {
+		return fmt.Errorf("error serializing manifest signature: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("error creating manifest signatures directory: %w", err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%d-%s.json", signature.Index, signature.Signer.Hex()))
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return fmt.Errorf("error writing manifest signature to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Load the detached signatures over a manifest from the signatures directory. Files that can't be read or belong to
+// another manifest are skipped, since co-signers drop their signatures there by hand.
+func LoadManifestSignatures(dir string, index uint64, manifestHash common.Hash) ([]ManifestSignature, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("error listing manifest signatures: %w", err)
+	}
+	signatures := []ManifestSignature{}
+	for _, path := range paths {
+		bytes, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var signature ManifestSignature
+		if err := json.Unmarshal(bytes, &signature); err != nil {
+			continue
+		}
+		if signature.Index == index && signature.ManifestHash == manifestHash {
+			signatures = append(signatures, signature)
+		}
+	}
+	return signatures, nil
+}
+
+// Combine the valid signatures from the quorum's signers into an attestation, failing if there are fewer than the threshold
+func AggregateAttestation(index uint64, manifestHash common.Hash, signers []common.Address, threshold uint64, signatures []ManifestSignature) (*Attestation, error) {
+	attestation := &Attestation{
+		Index:        index,
+		ManifestHash: manifestHash,
+		Threshold:    threshold,
+		Signers:      signers,
+		Signatures:   []ManifestSignature{},
+	}
+	quorum := map[common.Address]bool{}
+	for _, signer := range signers {
+		quorum[signer] = true
+	}
+	for _, signature := range signatures {
+		if !quorum[signature.Signer] || signature.Index != index || signature.ManifestHash != manifestHash || signature.Verify() != nil {
+			continue
+		}
+		attestation.Signatures = append(attestation.Signatures, signature)
+		delete(quorum, signature.Signer)
+	}
+	sort.Slice(attestation.Signatures, func(i, j int) bool {
+		return attestation.Signatures[i].Signer.Hex() < attestation.Signatures[j].Signer.Hex()
+	})
+	if uint64(len(attestation.Signatures)) < threshold {
+		return attestation, fmt.Errorf("only %d of the %d required signatures were collected", len(attestation.Signatures), threshold)
+	}
+	return attestation, nil
+}
+
+// Verify an attestation against a manifest and signer quorum, returning the signers whose signatures were valid
+func (a *Attestation) Verify(metadata *SubmissionMetadata, signers []common.Address, threshold uint64) ([]common.Address, error) {
+	manifestHash, err := metadata.GetManifestHash()
+	if err != nil {
+		return nil, err
+	}
+	if a.Index != metadata.Index || a.ManifestHash != manifestHash {
+		return nil, fmt.Errorf("attestation is for manifest %s of interval %d, but the artifacts have manifest %s of interval %d", a.ManifestHash.Hex(), a.Index, manifestHash.Hex(), metadata.Index)
+	}
+	aggregated, err := AggregateAttestation(a.Index, manifestHash, signers, threshold, a.Signatures)
+	valid := make([]common.Address, len(aggregated.Signatures))
+	for i, signature := range aggregated.Signatures {
+		valid[i] = signature.Signer
+	}
+	return valid, err
+}
+
+// Save the attestation to disk
+func (a *Attestation) Save(path string) error {
+	bytes, err := json.MarshalIndent(a, "", "\t")
+	if err != nil {
+		return fmt.Errorf("error serializing attestation: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return fmt.Errorf("error writing attestation to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Load an attestation from disk
+func LoadAttestation(path string) (*Attestation, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading attestation %s: %w", path, err)
+	}
+	var attestation Attestation
+	if err := json.Unmarshal(bytes, &attestation); err != nil {
+		return nil, fmt.Errorf("error deserializing attestation %s: %w", path, err)
+	}
+	return &attestation, nil
+}
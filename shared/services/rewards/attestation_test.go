@@ -0,0 +1,81 @@
+package rewards
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestAttestation(t *testing.T) {
+	metadata := &SubmissionMetadata{
+		Index:      12,
+		Network:    "mainnet",
+		MerkleRoot: "0x1234",
+		PrimaryCid: "bafy-primary",
+		Cids:       map[string]string{"rp-rewards-mainnet-12.json": "bafy-tree", "rp-minipool-performance-mainnet-12.json": "bafy-perf"},
+	}
+	manifestHash, err := metadata.GetManifestHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The mirror URLs aren't part of the manifest
+	metadata.MirrorUrls = map[string][]string{"rp-rewards-mainnet-12.json": {"https://mirror.example/tree"}}
+	if hash, _ := metadata.GetManifestHash(); hash != manifestHash {
+		t.Fatal("mirror URLs changed the manifest hash")
+	}
+
+	// Sign it with three keys, the last of which isn't in the quorum
+	signers := []common.Address{}
+	signatures := []ManifestSignature{}
+	for i := 0; i < 3; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		address := crypto.PubkeyToAddress(key.PublicKey)
+		signature, err := SignManifest(metadata.Index, manifestHash, address, func(message string) ([]byte, error) {
+			signature, err := crypto.Sign(accounts.TextHash([]byte(message)), key)
+			if err == nil {
+				signature[crypto.RecoveryIDOffset] += 27
+			}
+			return signature, err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		signatures = append(signatures, signature)
+		if i < 2 {
+			signers = append(signers, address)
+		}
+	}
+
+	// A signature claiming the wrong signer is rejected
+	forged := signatures[2]
+	forged.Signer = signers[1]
+	if forged.Verify() == nil {
+		t.Fatal("forged signature was accepted")
+	}
+
+	if _, err := AggregateAttestation(metadata.Index, manifestHash, signers, 2, []ManifestSignature{signatures[0], forged, signatures[2]}); err == nil {
+		t.Fatal("attestation without enough quorum signatures was accepted")
+	}
+	attestation, err := AggregateAttestation(metadata.Index, manifestHash, signers, 2, signatures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attestation.Signatures) != 2 {
+		t.Fatalf("expected 2 quorum signatures, got %d", len(attestation.Signatures))
+	}
+
+	valid, err := attestation.Verify(metadata, signers, 2)
+	if err != nil || len(valid) != 2 {
+		t.Fatalf("attestation failed verification: %v (%d valid)", err, len(valid))
+	}
+	metadata.Cids["rp-rewards-mainnet-12.json"] = "bafy-other"
+	if _, err := attestation.Verify(metadata, signers, 2); err == nil {
+		t.Fatal("attestation verified against a different manifest")
+	}
+}
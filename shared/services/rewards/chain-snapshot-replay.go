@@ -0,0 +1,241 @@
+package rewards
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Serves the Execution and Beacon client calls of a tree generation run from a chain snapshot
+type ChainSnapshotReader struct {
+	Manifest     ChainSnapshotManifest
+	NetworkState *state.NetworkState
+
+	archive *zip.ReadCloser
+	entries map[string]*zip.File
+}
+
+// The recorded committees of an epoch. Validators that weren't recorded are blank, so committee positions are preserved.
+type chainSnapshotCommittees []chainSnapshotCommittee
+
+func (c chainSnapshotCommittees) Index(i int) uint64 {
+	return c[i].Index
+}
+
+func (c chainSnapshotCommittees) Slot(i int) uint64 {
+	return c[i].Slot
+}
+
+func (c chainSnapshotCommittees) Validators(i int) []string {
+	validators := make([]string, c[i].Size)
+	for j, position := range c[i].Positions {
+		validators[position] = c[i].Validators[j]
+	}
+	return validators
+}
+
+func (c chainSnapshotCommittees) Count() int {
+	return len(c)
+}
+
+func (c chainSnapshotCommittees) Release() {
+}
+
+// Open a chain snapshot
+func OpenChainSnapshot(path string) (*ChainSnapshotReader, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening chain snapshot %s: %w", path, err)
+	}
+	r := &ChainSnapshotReader{
+		archive: archive,
+		entries: map[string]*zip.File{},
+	}
+	for _, file := range archive.File {
+		r.entries[file.Name] = file
+	}
+
+	if err := r.readRequiredEntry(chainSnapshotManifestEntry, &r.Manifest); err != nil {
+		archive.Close()
+		return nil, err
+	}
+	if r.Manifest.Version != chainSnapshotVersion {
+		archive.Close()
+		return nil, fmt.Errorf("chain snapshot %s is version %d, but only version %d is supported", path, r.Manifest.Version, chainSnapshotVersion)
+	}
+	r.NetworkState = &state.NetworkState{}
+	if err := r.readRequiredEntry(chainSnapshotNetworkStateEntry, r.NetworkState); err != nil {
+		archive.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// Close the chain snapshot
+func (r *ChainSnapshotReader) Close() error {
+	return r.archive.Close()
+}
+
+// Deserialize an archive entry, returning false if the snapshot doesn't have it
+func (r *ChainSnapshotReader) readEntry(name string, value interface{}) (bool, error) {
+	file, exists := r.entries[name]
+	if !exists {
+		return false, nil
+	}
+	entry, err := file.Open()
+	if err != nil {
+		return false, fmt.Errorf("error opening chain snapshot entry %s: %w", name, err)
+	}
+	defer entry.Close()
+	bytes, err := io.ReadAll(entry)
+	if err != nil {
+		return false, fmt.Errorf("error reading chain snapshot entry %s: %w", name, err)
+	}
+	if err := json.Unmarshal(bytes, value); err != nil {
+		return false, fmt.Errorf("error deserializing chain snapshot entry %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// Deserialize an archive entry the generator needs
+func (r *ChainSnapshotReader) readRequiredEntry(name string, value interface{}) error {
+	exists, err := r.readEntry(name, value)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("the chain snapshot doesn't include %s", name)
+	}
+	return nil
+}
+
+func (r *ChainSnapshotReader) GetNetworkEnabled(networkId *big.Int, opts *bind.CallOpts) (bool, error) {
+	enabled, exists := r.Manifest.NetworkEnabled[networkId.String()+"@"+getCallKey(opts)]
+	if !exists {
+		return false, fmt.Errorf("the chain snapshot doesn't include whether network %s is enabled at block %s", networkId.String(), getCallKey(opts))
+	}
+	return enabled, nil
+}
+
+func (r *ChainSnapshotReader) HeaderByNumber(ctx context.Context, number *big.Int) (*ethtypes.Header, error) {
+	key := getCallKey(&bind.CallOpts{BlockNumber: number})
+	header, exists := r.Manifest.Headers[key]
+	if !exists {
+		return nil, fmt.Errorf("the chain snapshot doesn't include the header of block %s", key)
+	}
+	return header.toHeader(), nil
+}
+
+func (r *ChainSnapshotReader) GetRewardsEvent(index uint64, rocketRewardsPoolAddresses []common.Address, opts *bind.CallOpts) (bool, rewards.RewardsEvent, error) {
+	event, exists := r.Manifest.RewardsEvents[index]
+	if !exists {
+		return false, rewards.RewardsEvent{}, fmt.Errorf("the chain snapshot doesn't include the rewards event for interval %d", index)
+	}
+	return event.Found, event.Event, nil
+}
+
+func (r *ChainSnapshotReader) GetRewardSnapshotEvent(previousRewardsPoolAddresses []common.Address, interval uint64, opts *bind.CallOpts) (rewards.RewardsEvent, error) {
+	event, exists := r.Manifest.RewardSnapshotEvents[interval]
+	if !exists {
+		return rewards.RewardsEvent{}, fmt.Errorf("the chain snapshot doesn't include the reward snapshot event for interval %d", interval)
+	}
+	return event, nil
+}
+
+func (r *ChainSnapshotReader) GetRewardIndex(opts *bind.CallOpts) (*big.Int, error) {
+	index, exists := r.Manifest.RewardIndices[getCallKey(opts)]
+	if !exists {
+		return nil, fmt.Errorf("the chain snapshot doesn't include the reward index at block %s", getCallKey(opts))
+	}
+	return index, nil
+}
+
+func (r *ChainSnapshotReader) GetBeaconBlock(slot string) (beacon.BeaconBlock, bool, error) {
+	var block chainSnapshotBlock
+	err := r.readRequiredEntry(fmt.Sprintf(chainSnapshotBlockFormat, slot), &block)
+	if err != nil {
+		return beacon.BeaconBlock{}, false, err
+	}
+	return block.Block, block.Exists, nil
+}
+
+func (r *ChainSnapshotReader) GetCommitteesForEpoch(epoch *uint64) (beacon.Committees, error) {
+	if epoch == nil {
+		return nil, fmt.Errorf("the chain snapshot only includes the committees of specific epochs")
+	}
+	var committees chainSnapshotCommittees
+	err := r.readRequiredEntry(fmt.Sprintf(chainSnapshotCommitteesFormat, *epoch), &committees)
+	if err != nil {
+		return nil, err
+	}
+	return committees, nil
+}
+
+func (r *ChainSnapshotReader) GetAttestations(slot string) ([]beacon.AttestationInfo, bool, error) {
+	var attestations chainSnapshotAttestations
+	err := r.readRequiredEntry(fmt.Sprintf(chainSnapshotAttestationFormat, slot), &attestations)
+	if err != nil {
+		return nil, false, err
+	}
+	return attestations.Attestations, attestations.Found, nil
+}
+
+func (r *ChainSnapshotReader) GetEth2Config() (beacon.Eth2Config, error) {
+	if r.Manifest.Eth2Config == nil {
+		return beacon.Eth2Config{}, fmt.Errorf("the chain snapshot doesn't include the Beacon config")
+	}
+	return *r.Manifest.Eth2Config, nil
+}
+
+func (r *ChainSnapshotReader) GetBeaconHead() (beacon.BeaconHead, error) {
+	if r.Manifest.BeaconHead == nil {
+		return beacon.BeaconHead{}, fmt.Errorf("the chain snapshot doesn't include the Beacon head")
+	}
+	return *r.Manifest.BeaconHead, nil
+}
+
+// Regenerate the tree recorded in a chain snapshot without any Execution or Beacon clients.
+// The config has to use the same network and accounting policy as the run that recorded it.
+func ReplayChainSnapshot(logger *log.ColorLogger, logPrefix string, cfg *config.RocketPoolConfig, path string) (*GenerateTreeResult, *ChainSnapshotManifest, error) {
+	snapshot, err := OpenChainSnapshot(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer snapshot.Close()
+	manifest := &snapshot.Manifest
+
+	network := fmt.Sprint(cfg.Smartnode.Network.Value)
+	if network != manifest.Network {
+		return nil, manifest, fmt.Errorf("the chain snapshot is for the %s network, but the Smartnode is configured for %s", manifest.Network, network)
+	}
+	accountingPolicy := cfg.Smartnode.RewardsAccountingPolicy.Value.(string)
+	if accountingPolicy != manifest.AccountingPolicy {
+		return nil, manifest, fmt.Errorf("the chain snapshot was generated with accounting policy [%s], but the Smartnode is configured for [%s]", manifest.AccountingPolicy, accountingPolicy)
+	}
+
+	generator, err := NewTreeGenerator(logger, logPrefix, snapshot, cfg, nil, manifest.Index, manifest.StartTime, manifest.EndTime, manifest.SnapshotEnd, manifest.ElSnapshotHeader.toHeader(), manifest.IntervalsPassed, snapshot.NetworkState)
+	if err != nil {
+		return nil, manifest, fmt.Errorf("error creating tree generator: %w", err)
+	}
+	if generator.GetGeneratorRulesetVersion() != manifest.RulesetVersion {
+		return nil, manifest, fmt.Errorf("the chain snapshot was generated with ruleset v%d, but this Smartnode uses v%d for interval %d", manifest.RulesetVersion, generator.GetGeneratorRulesetVersion(), manifest.Index)
+	}
+	result, err := generator.generatorImpl.GenerateTree(snapshot, manifest.Network, manifest.PreviousRewardsPoolAddresses, snapshot)
+	if err != nil {
+		return nil, manifest, err
+	}
+	return result, manifest, nil
+}
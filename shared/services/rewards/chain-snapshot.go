@@ -0,0 +1,435 @@
+package rewards
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/goccy/go-json"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+const (
+	chainSnapshotVersion uint64 = 1
+
+	chainSnapshotManifestEntry     string = "manifest.json"
+	chainSnapshotNetworkStateEntry string = "network-state.json"
+	chainSnapshotCommitteesFormat  string = "committees/%d.json"
+	chainSnapshotBlockFormat       string = "blocks/%s.json"
+	chainSnapshotAttestationFormat string = "attestations/%s.json"
+)
+
+// Everything a tree generation run read from the chain apart from the bulky per-epoch and per-slot data, which is
+// stored in separate archive entries
+type ChainSnapshotManifest struct {
+	Version                      uint64                          `json:"version"`
+	Index                        uint64                          `json:"index"`
+	Network                      string                          `json:"network"`
+	RulesetVersion               uint64                          `json:"rulesetVersion"`
+	AccountingPolicy             string                          `json:"accountingPolicy"`
+	MerkleRoot                   string                          `json:"merkleRoot"`
+	StartTime                    time.Time                       `json:"startTime"`
+	EndTime                      time.Time                       `json:"endTime"`
+	IntervalsPassed              uint64                          `json:"intervalsPassed"`
+	SnapshotEnd                  *SnapshotEnd                    `json:"snapshotEnd"`
+	ElSnapshotHeader             *chainSnapshotHeader            `json:"elSnapshotHeader"`
+	PreviousRewardsPoolAddresses []common.Address                `json:"previousRewardsPoolAddresses"`
+	NetworkEnabled               map[string]bool                 `json:"networkEnabled"`
+	Headers                      map[string]*chainSnapshotHeader `json:"headers"`
+	RewardsEvents                map[uint64]chainSnapshotEvent   `json:"rewardsEvents"`
+	RewardSnapshotEvents         map[uint64]rewards.RewardsEvent `json:"rewardSnapshotEvents"`
+	RewardIndices                map[string]*big.Int             `json:"rewardIndices"`
+	Eth2Config                   *beacon.Eth2Config              `json:"eth2Config"`
+	BeaconHead                   *beacon.BeaconHead              `json:"beaconHead"`
+}
+
+// The parts of an Execution block header the generator reads
+type chainSnapshotHeader struct {
+	Number *big.Int `json:"number"`
+	Time   uint64   `json:"time"`
+}
+
+func newChainSnapshotHeader(header *ethtypes.Header) *chainSnapshotHeader {
+	if header == nil {
+		return nil
+	}
+	return &chainSnapshotHeader{Number: header.Number, Time: header.Time}
+}
+
+func (h *chainSnapshotHeader) toHeader() *ethtypes.Header {
+	if h == nil {
+		return nil
+	}
+	return &ethtypes.Header{Number: h.Number, Time: h.Time}
+}
+
+type chainSnapshotEvent struct {
+	Found bool                 `json:"found"`
+	Event rewards.RewardsEvent `json:"event"`
+}
+
+// The committees of one epoch, keeping only the positions of the validators in the network state
+type chainSnapshotCommittee struct {
+	Index      uint64   `json:"index"`
+	Slot       uint64   `json:"slot"`
+	Size       int      `json:"size"`
+	Positions  []int    `json:"positions"`
+	Validators []string `json:"validators"`
+}
+
+type chainSnapshotBlock struct {
+	Exists bool               `json:"exists"`
+	Block  beacon.BeaconBlock `json:"block"`
+}
+
+type chainSnapshotAttestations struct {
+	Found        bool                     `json:"found"`
+	Attestations []beacon.AttestationInfo `json:"attestations"`
+}
+
+// An archive entry holding attestations that can't be trimmed until the committees they refer to are recorded
+type pendingChainSnapshotEntry struct {
+	name         string
+	value        interface{}
+	attestations []beacon.AttestationInfo
+}
+
+// Records everything a tree generation run reads from the Execution and Beacon clients into a portable archive, so
+// anyone can reproduce the tree without access to archive nodes.
+// Per-validator data is trimmed to the validators in the network state, since the generator ignores the rest: committees
+// only keep their positions, withdrawals only keep theirs, and attestation bits for everyone else are cleared.
+type ChainSnapshotRecorder struct {
+	rp RewardsExecutionClient
+	bc RewardsBeaconClient
+
+	lock          sync.Mutex
+	file          *os.File
+	writer        *zip.Writer
+	written       map[string]bool
+	manifest      ChainSnapshotManifest
+	validators    map[string]bool
+	committees    map[uint64]map[uint64]chainSnapshotCommittee
+	pending       []pendingChainSnapshotEntry
+	slotsPerEpoch uint64
+	err           error
+}
+
+// Create a recorder that wraps the clients a tree generator will use and streams what it reads to an archive at path
+func NewChainSnapshotRecorder(rp RewardsExecutionClient, bc RewardsBeaconClient, networkState *state.NetworkState, path string) (*ChainSnapshotRecorder, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("error creating chain snapshot directory: %w", err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("error creating chain snapshot %s: %w", path, err)
+	}
+	r := &ChainSnapshotRecorder{
+		rp:         rp,
+		bc:         bc,
+		file:       file,
+		writer:     zip.NewWriter(file),
+		written:    map[string]bool{},
+		validators: map[string]bool{},
+		committees: map[uint64]map[uint64]chainSnapshotCommittee{},
+		manifest: ChainSnapshotManifest{
+			Version:              chainSnapshotVersion,
+			NetworkEnabled:       map[string]bool{},
+			Headers:              map[string]*chainSnapshotHeader{},
+			RewardsEvents:        map[uint64]chainSnapshotEvent{},
+			RewardSnapshotEvents: map[uint64]rewards.RewardsEvent{},
+			RewardIndices:        map[string]*big.Int{},
+		},
+		slotsPerEpoch: networkState.BeaconConfig.SlotsPerEpoch,
+	}
+	for _, validator := range networkState.ValidatorDetails {
+		r.validators[validator.Index] = true
+	}
+	if err := r.writeEntry(chainSnapshotNetworkStateEntry, networkState); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// Finish the archive with the details of the run that aren't read from the clients
+func (r *ChainSnapshotRecorder) Close(index uint64, network string, rulesetVersion uint64, accountingPolicy string, merkleRoot string, startTime time.Time, endTime time.Time, intervalsPassed uint64, snapshotEnd *SnapshotEnd, elSnapshotHeader *ethtypes.Header, previousRewardsPoolAddresses []common.Address) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	defer r.file.Close()
+
+	// Attestations that refer to committees the generator never asked for can't affect the tree, so they're cleared
+	for _, entry := range r.pending {
+		for i := range entry.attestations {
+			entry.attestations[i].AggregationBits = bitfield.NewBitlist(entry.attestations[i].AggregationBits.Len())
+		}
+		r.writeEntryLocked(entry.name, entry.value)
+	}
+	r.pending = nil
+
+	r.manifest.Index = index
+	r.manifest.Network = network
+	r.manifest.RulesetVersion = rulesetVersion
+	r.manifest.AccountingPolicy = accountingPolicy
+	r.manifest.MerkleRoot = merkleRoot
+	r.manifest.StartTime = startTime
+	r.manifest.EndTime = endTime
+	r.manifest.IntervalsPassed = intervalsPassed
+	r.manifest.SnapshotEnd = snapshotEnd
+	r.manifest.ElSnapshotHeader = newChainSnapshotHeader(elSnapshotHeader)
+	r.manifest.PreviousRewardsPoolAddresses = previousRewardsPoolAddresses
+	r.writeEntryLocked(chainSnapshotManifestEntry, r.manifest)
+	if r.err != nil {
+		return r.err
+	}
+	if err := r.writer.Close(); err != nil {
+		return fmt.Errorf("error finishing chain snapshot: %w", err)
+	}
+	return nil
+}
+
+// Abandon the archive, such as when generation fails
+func (r *ChainSnapshotRecorder) Discard() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.file.Close()
+	os.Remove(r.file.Name())
+}
+
+func (r *ChainSnapshotRecorder) writeEntry(name string, value interface{}) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.writeEntryLocked(name, value)
+	return r.err
+}
+
+// Write an archive entry unless it's already been written, remembering the first error so the run can report it at the end
+func (r *ChainSnapshotRecorder) writeEntryLocked(name string, value interface{}) {
+	if r.err != nil || r.written[name] {
+		return
+	}
+	r.written[name] = true
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		r.err = fmt.Errorf("error serializing chain snapshot entry %s: %w", name, err)
+		return
+	}
+	entry, err := r.writer.Create(name)
+	if err == nil {
+		_, err = entry.Write(bytes)
+	}
+	if err != nil {
+		r.err = fmt.Errorf("error writing chain snapshot entry %s: %w", name, err)
+	}
+}
+
+// Clear the attestation bits of validators that aren't in the network state, returning false if a committee an
+// attestation refers to hasn't been recorded yet
+func (r *ChainSnapshotRecorder) trimAttestationsLocked(attestations []beacon.AttestationInfo) bool {
+	for _, attestation := range attestations {
+		if _, exists := r.committees[attestation.SlotIndex/r.slotsPerEpoch][attestation.SlotIndex<<8|attestation.CommitteeIndex]; !exists {
+			return false
+		}
+	}
+	for i, attestation := range attestations {
+		committee := r.committees[attestation.SlotIndex/r.slotsPerEpoch][attestation.SlotIndex<<8|attestation.CommitteeIndex]
+		trimmed := bitfield.NewBitlist(attestation.AggregationBits.Len())
+		for _, position := range committee.Positions {
+			if uint64(position) < trimmed.Len() && attestation.AggregationBits.BitAt(uint64(position)) {
+				trimmed.SetBitAt(uint64(position), true)
+			}
+		}
+		attestations[i].AggregationBits = trimmed
+	}
+	return true
+}
+
+// Write an entry holding attestations once the committees they refer to are available
+func (r *ChainSnapshotRecorder) writeAttestationEntryLocked(name string, value interface{}, attestations []beacon.AttestationInfo) {
+	if r.trimAttestationsLocked(attestations) {
+		r.writeEntryLocked(name, value)
+		return
+	}
+	r.pending = append(r.pending, pendingChainSnapshotEntry{name: name, value: value, attestations: attestations})
+}
+
+// Write the pending entries whose committees are now available
+func (r *ChainSnapshotRecorder) flushPendingLocked() {
+	remaining := r.pending[:0]
+	for _, entry := range r.pending {
+		if r.trimAttestationsLocked(entry.attestations) {
+			r.writeEntryLocked(entry.name, entry.value)
+		} else {
+			remaining = append(remaining, entry)
+		}
+	}
+	r.pending = remaining
+}
+
+// Get the key for a call made at a specific block
+func getCallKey(opts *bind.CallOpts) string {
+	if opts == nil || opts.BlockNumber == nil {
+		return "latest"
+	}
+	return opts.BlockNumber.String()
+}
+
+func (r *ChainSnapshotRecorder) GetNetworkEnabled(networkId *big.Int, opts *bind.CallOpts) (bool, error) {
+	enabled, err := r.rp.GetNetworkEnabled(networkId, opts)
+	if err != nil {
+		return false, err
+	}
+	r.lock.Lock()
+	r.manifest.NetworkEnabled[networkId.String()+"@"+getCallKey(opts)] = enabled
+	r.lock.Unlock()
+	return enabled, nil
+}
+
+func (r *ChainSnapshotRecorder) HeaderByNumber(ctx context.Context, number *big.Int) (*ethtypes.Header, error) {
+	header, err := r.rp.HeaderByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	r.manifest.Headers[getCallKey(&bind.CallOpts{BlockNumber: number})] = newChainSnapshotHeader(header)
+	r.lock.Unlock()
+	return header, nil
+}
+
+func (r *ChainSnapshotRecorder) GetRewardsEvent(index uint64, rocketRewardsPoolAddresses []common.Address, opts *bind.CallOpts) (bool, rewards.RewardsEvent, error) {
+	found, event, err := r.rp.GetRewardsEvent(index, rocketRewardsPoolAddresses, opts)
+	if err != nil {
+		return false, event, err
+	}
+	r.lock.Lock()
+	r.manifest.RewardsEvents[index] = chainSnapshotEvent{Found: found, Event: event}
+	r.lock.Unlock()
+	return found, event, nil
+}
+
+func (r *ChainSnapshotRecorder) GetRewardSnapshotEvent(previousRewardsPoolAddresses []common.Address, interval uint64, opts *bind.CallOpts) (rewards.RewardsEvent, error) {
+	event, err := r.rp.GetRewardSnapshotEvent(previousRewardsPoolAddresses, interval, opts)
+	if err != nil {
+		return event, err
+	}
+	r.lock.Lock()
+	r.manifest.RewardSnapshotEvents[interval] = event
+	r.lock.Unlock()
+	return event, nil
+}
+
+func (r *ChainSnapshotRecorder) GetRewardIndex(opts *bind.CallOpts) (*big.Int, error) {
+	index, err := r.rp.GetRewardIndex(opts)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	r.manifest.RewardIndices[getCallKey(opts)] = index
+	r.lock.Unlock()
+	return index, nil
+}
+
+func (r *ChainSnapshotRecorder) GetBeaconBlock(slot string) (beacon.BeaconBlock, bool, error) {
+	block, exists, err := r.bc.GetBeaconBlock(slot)
+	if err != nil {
+		return block, false, err
+	}
+
+	// Trim a copy so the generator still sees the whole block
+	trimmed := block
+	trimmed.Withdrawals = []beacon.WithdrawalInfo{}
+	for _, withdrawal := range block.Withdrawals {
+		if r.validators[withdrawal.ValidatorIndex] {
+			trimmed.Withdrawals = append(trimmed.Withdrawals, withdrawal)
+		}
+	}
+	trimmed.Attestations = append([]beacon.AttestationInfo{}, block.Attestations...)
+
+	r.lock.Lock()
+	r.writeAttestationEntryLocked(fmt.Sprintf(chainSnapshotBlockFormat, slot), &chainSnapshotBlock{Exists: exists, Block: trimmed}, trimmed.Attestations)
+	r.lock.Unlock()
+	return block, exists, nil
+}
+
+func (r *ChainSnapshotRecorder) GetCommitteesForEpoch(epoch *uint64) (beacon.Committees, error) {
+	committees, err := r.bc.GetCommitteesForEpoch(epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the positions of the validators in the network state
+	recorded := map[uint64]chainSnapshotCommittee{}
+	list := make([]chainSnapshotCommittee, 0, committees.Count())
+	for i := 0; i < committees.Count(); i++ {
+		validators := committees.Validators(i)
+		committee := chainSnapshotCommittee{
+			Index:      committees.Index(i),
+			Slot:       committees.Slot(i),
+			Size:       len(validators),
+			Positions:  []int{},
+			Validators: []string{},
+		}
+		for position, validator := range validators {
+			if r.validators[validator] {
+				committee.Positions = append(committee.Positions, position)
+				committee.Validators = append(committee.Validators, validator)
+			}
+		}
+		recorded[committee.Slot<<8|committee.Index] = committee
+		list = append(list, committee)
+	}
+
+	r.lock.Lock()
+	if epoch != nil {
+		r.committees[*epoch] = recorded
+		r.writeEntryLocked(fmt.Sprintf(chainSnapshotCommitteesFormat, *epoch), list)
+		r.flushPendingLocked()
+	}
+	r.lock.Unlock()
+	return committees, nil
+}
+
+func (r *ChainSnapshotRecorder) GetAttestations(slot string) ([]beacon.AttestationInfo, bool, error) {
+	attestations, found, err := r.bc.GetAttestations(slot)
+	if err != nil {
+		return nil, false, err
+	}
+	trimmed := append([]beacon.AttestationInfo{}, attestations...)
+	r.lock.Lock()
+	r.writeAttestationEntryLocked(fmt.Sprintf(chainSnapshotAttestationFormat, slot), &chainSnapshotAttestations{Found: found, Attestations: trimmed}, trimmed)
+	r.lock.Unlock()
+	return attestations, found, nil
+}
+
+func (r *ChainSnapshotRecorder) GetEth2Config() (beacon.Eth2Config, error) {
+	config, err := r.bc.GetEth2Config()
+	if err != nil {
+		return config, err
+	}
+	r.lock.Lock()
+	r.manifest.Eth2Config = &config
+	r.lock.Unlock()
+	return config, nil
+}
+
+func (r *ChainSnapshotRecorder) GetBeaconHead() (beacon.BeaconHead, error) {
+	head, err := r.bc.GetBeaconHead()
+	if err != nil {
+		return head, err
+	}
+	r.lock.Lock()
+	r.manifest.BeaconHead = &head
+	r.lock.Unlock()
+	return head, nil
+}
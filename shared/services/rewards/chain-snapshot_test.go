@@ -0,0 +1,89 @@
+package rewards
+
+import (
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/fatih/color"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/rewards/test"
+	"github.com/rocket-pool/smartnode/shared/services/rewards/test/assets"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+func TestMockChainSnapshotReplayTreegenv10(tt *testing.T) {
+
+	history := test.NewDefaultMockHistory()
+	state := history.GetEndNetworkState()
+
+	t := newV8Test(tt, state.NetworkDetails.RewardIndex)
+
+	t.bc.SetState(state)
+	history.SetWithdrawals(t.bc)
+
+	consensusStartBlock := history.GetConsensusStartBlock()
+	executionStartBlock := history.GetExecutionStartBlock()
+	consensusEndBlock := history.GetConsensusEndBlock()
+	executionEndBlock := history.GetExecutionEndBlock()
+
+	logger := log.NewColorLogger(color.Faint)
+
+	t.rp.SetRewardSnapshotEvent(history.GetPreviousRewardSnapshotEvent())
+	t.bc.SetBeaconBlock(fmt.Sprint(consensusStartBlock-1), beacon.BeaconBlock{ExecutionBlockNumber: executionStartBlock - 1})
+	t.bc.SetBeaconBlock(fmt.Sprint(consensusStartBlock), beacon.BeaconBlock{ExecutionBlockNumber: executionStartBlock})
+	t.rp.SetHeaderByNumber(big.NewInt(int64(executionStartBlock)), &types.Header{Time: uint64(history.GetStartTime().Unix())})
+
+	// Miss a few duties so the snapshot has to preserve attestation bits
+	missedSlots := []uint64{consensusStartBlock + 64, consensusEndBlock - 64}
+	for _, validator := range state.ValidatorDetails {
+		t.bc.SetMinipoolPerformance(validator.Index, missedSlots)
+	}
+
+	snapshotEnd := &SnapshotEnd{
+		Slot:           consensusEndBlock,
+		ConsensusBlock: consensusEndBlock,
+		ExecutionBlock: executionEndBlock,
+	}
+	elSnapshotHeader := &types.Header{
+		Number: big.NewInt(int64(history.GetExecutionEndBlock())),
+		Time:   assets.Mainnet20ELHeaderTime,
+	}
+	newGenerator := func() *treeGeneratorImpl_v9_v10 {
+		return newTreeGeneratorImpl_v9_v10(10, &logger, t.Name(), state.NetworkDetails.RewardIndex, snapshotEnd, elSnapshotHeader, 1, state)
+	}
+
+	// Record a run
+	path := filepath.Join(tt.TempDir(), "snapshot.zip")
+	recorder, err := NewChainSnapshotRecorder(t.rp, t.bc, state, path)
+	t.failIf(err)
+	recorded, err := newGenerator().GenerateTree(recorder, "mainnet", make([]common.Address, 0), recorder)
+	t.failIf(err)
+	t.failIf(recorder.Close(state.NetworkDetails.RewardIndex, "mainnet", 10, "", recorded.RewardsFile.GetMerkleRoot(), history.GetStartTime(), history.GetEndTime(), 1, snapshotEnd, elSnapshotHeader, nil))
+
+	// Replay it without the mock clients
+	snapshot, err := OpenChainSnapshot(path)
+	t.failIf(err)
+	defer snapshot.Close()
+	if snapshot.Manifest.MerkleRoot != recorded.RewardsFile.GetMerkleRoot() {
+		t.Fatalf("snapshot manifest has merkle root %s, expected %s", snapshot.Manifest.MerkleRoot, recorded.RewardsFile.GetMerkleRoot())
+	}
+	replayed, err := newGenerator().GenerateTree(snapshot, "mainnet", make([]common.Address, 0), snapshot)
+	t.failIf(err)
+	if replayed.RewardsFile.GetMerkleRoot() != recorded.RewardsFile.GetMerkleRoot() {
+		t.Fatalf("replayed merkle root %s doesn't match %s", replayed.RewardsFile.GetMerkleRoot(), recorded.RewardsFile.GetMerkleRoot())
+	}
+	for _, address := range recorded.MinipoolPerformanceFile.GetMinipoolAddresses() {
+		expected, _ := recorded.MinipoolPerformanceFile.GetSmoothingPoolPerformance(address)
+		actual, exists := replayed.MinipoolPerformanceFile.GetSmoothingPoolPerformance(address)
+		if !exists {
+			t.Fatalf("minipool %s is missing from the replayed performance file", address.Hex())
+		}
+		if actual.GetSuccessfulAttestationCount() != expected.GetSuccessfulAttestationCount() || actual.GetMissedAttestationCount() != expected.GetMissedAttestationCount() {
+			t.Fatalf("minipool %s has different attestation counts after replay", address.Hex())
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package rewards
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// The name of the Merkle distributor contract that emits claim events
+const claimDistributorContractName string = "rocketMerkleDistributorMainnet"
+
+// A single interval claimed by a node, as recorded in a RewardsClaimed event
+type ClaimRecord struct {
+	Interval    uint64        `json:"interval"`
+	AmountRPL   *QuotedBigInt `json:"amountRpl"`
+	AmountETH   *QuotedBigInt `json:"amountEth"`
+	BlockNumber uint64        `json:"blockNumber"`
+	TxHash      common.Hash   `json:"txHash"`
+}
+
+// A persistent index of a node's historical reward claims, built from the distributor's event logs
+type ClaimIndex struct {
+	NodeAddress      common.Address         `json:"nodeAddress"`
+	Distributor      common.Address         `json:"distributor"`
+	LastScannedBlock uint64                 `json:"lastScannedBlock"`
+	Claims           map[uint64]ClaimRecord `json:"claims"`
+}
+
+// Load the claim index from disk, returning an empty one if it doesn't exist
+func LoadClaimIndex(path string) (*ClaimIndex, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &ClaimIndex{Claims: map[uint64]ClaimRecord{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading claim index: %w", err)
+	}
+	var index ClaimIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("error deserializing claim index: %w", err)
+	}
+	if index.Claims == nil {
+		index.Claims = map[uint64]ClaimRecord{}
+	}
+	return &index, nil
+}
+
+// Save the claim index to disk
+func SaveClaimIndex(path string, index *ClaimIndex) error {
+	data, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("error serializing claim index: %w", err)
+	}
+	if err := files.WriteFileAtomic(path, data, 0644); err != nil {
+		return fmt.Errorf("error saving claim index: %w", err)
+	}
+	return nil
+}
+
+// Scan the distributor's RewardsClaimed events for the node since the index was last updated.
+// The scan only starts once the node has claimed at least one interval, beginning at the snapshot block of the earliest claimed one.
+func (i *ClaimIndex) Update(rp *rocketpool.RocketPool, nodeAddress common.Address, earliestClaimBlock *big.Int, intervalSize *big.Int) error {
+	_, err := i.UpdateBatch(rp, nodeAddress, earliestClaimBlock, intervalSize, 0)
+	return err
+}
+
+// Like Update, but scans at most maxBlocks blocks (0 for no limit) so a long history can be indexed incrementally.
+// Returns true once the index has caught up to the latest block.
+func (i *ClaimIndex) UpdateBatch(rp *rocketpool.RocketPool, nodeAddress common.Address, earliestClaimBlock *big.Int, intervalSize *big.Int, maxBlocks uint64) (bool, error) {
+	distributor, err := rp.GetContract(claimDistributorContractName, nil)
+	if err != nil {
+		return false, fmt.Errorf("error getting the Merkle distributor contract: %w", err)
+	}
+	event, exists := distributor.ABI.Events["RewardsClaimed"]
+	if !exists {
+		return false, fmt.Errorf("the Merkle distributor contract does not have a RewardsClaimed event")
+	}
+
+	// Start over if the index belongs to a different node or distributor
+	if i.NodeAddress != nodeAddress || i.Distributor != *distributor.Address {
+		i.NodeAddress = nodeAddress
+		i.Distributor = *distributor.Address
+		i.LastScannedBlock = 0
+		i.Claims = map[uint64]ClaimRecord{}
+	}
+
+	// Get the block range to scan
+	latestBlock, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return false, fmt.Errorf("error getting latest block: %w", err)
+	}
+	fromBlock := big.NewInt(0).SetUint64(i.LastScannedBlock + 1)
+	if i.LastScannedBlock == 0 {
+		if earliestClaimBlock == nil {
+			// Nothing has been claimed yet so there's nothing to scan
+			return true, nil
+		}
+		fromBlock.Set(earliestClaimBlock)
+	}
+	toBlock := big.NewInt(0).SetUint64(latestBlock)
+	if fromBlock.Cmp(toBlock) > 0 {
+		return true, nil
+	}
+	if maxBlocks > 0 && toBlock.Uint64()-fromBlock.Uint64()+1 > maxBlocks {
+		toBlock.SetUint64(fromBlock.Uint64() + maxBlocks - 1)
+	}
+
+	// Get the claim events for the node
+	nodeTopic := common.BytesToHash(nodeAddress.Bytes())
+	logs, err := eth.GetLogs(rp, []common.Address{*distributor.Address}, [][]common.Hash{{event.ID}, {nodeTopic}}, intervalSize, fromBlock, toBlock, nil)
+	if err != nil {
+		return false, fmt.Errorf("error getting claim events: %w", err)
+	}
+	for _, log := range logs {
+		values, err := event.Inputs.Unpack(log.Data)
+		if err != nil {
+			return false, fmt.Errorf("error unpacking claim event in tx %s: %w", log.TxHash.Hex(), err)
+		}
+		if len(values) != 3 {
+			return false, fmt.Errorf("claim event in tx %s has %d values, expected 3", log.TxHash.Hex(), len(values))
+		}
+		indices, ok1 := values[0].([]*big.Int)
+		amountsRpl, ok2 := values[1].([]*big.Int)
+		amountsEth, ok3 := values[2].([]*big.Int)
+		if !ok1 || !ok2 || !ok3 || len(indices) != len(amountsRpl) || len(indices) != len(amountsEth) {
+			return false, fmt.Errorf("claim event in tx %s has an unexpected format", log.TxHash.Hex())
+		}
+		for j, index := range indices {
+			i.Claims[index.Uint64()] = ClaimRecord{
+				Interval:    index.Uint64(),
+				AmountRPL:   QuotedBigIntFromBigInt(amountsRpl[j]),
+				AmountETH:   QuotedBigIntFromBigInt(amountsEth[j]),
+				BlockNumber: log.BlockNumber,
+				TxHash:      log.TxHash,
+			}
+		}
+	}
+
+	i.LastScannedBlock = toBlock.Uint64()
+	return i.LastScannedBlock == latestBlock, nil
+}
+
+// Get the indexed claims, sorted by interval
+func (i *ClaimIndex) GetClaims() []ClaimRecord {
+	claims := make([]ClaimRecord, 0, len(i.Claims))
+	for _, claim := range i.Claims {
+		claims = append(claims, claim)
+	}
+	sort.Slice(claims, func(a, b int) bool {
+		return claims[a].Interval < claims[b].Interval
+	})
+	return claims
+}
@@ -0,0 +1,106 @@
+package rewards
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// A claim of rewards from one or more rewards files, described for compliance screening
+type ClaimScreening struct {
+	NodeAddress common.Address
+	Recipient   common.Address
+	Asset       string
+	Intervals   []uint64
+}
+
+// A claim that a compliance hook flagged for review
+type ComplianceFlag struct {
+	Recipient common.Address `json:"recipient"`
+	Asset     string         `json:"asset"`
+	Reason    string         `json:"reason"`
+}
+
+// A hook that screens rewards claims before they're made.
+// Hooks only flag claims for the operator to review; they never change the rewards files or the claims themselves.
+type ComplianceHook interface {
+	ScreenClaim(claim ClaimScreening) ([]ComplianceFlag, error)
+}
+
+// Get the compliance hook the node is configured to use.
+// Screening is opt-in, so this is a no-op hook unless a blocklist has been configured.
+func GetComplianceHook(cfg *config.RocketPoolConfig) (ComplianceHook, error) {
+	path := cfg.Smartnode.GetComplianceBlocklistPath()
+	if path == "" {
+		return noopComplianceHook{}, nil
+	}
+	return NewBlocklistComplianceHook(path)
+}
+
+// The default hook, which doesn't flag anything
+type noopComplianceHook struct{}
+
+func (noopComplianceHook) ScreenClaim(claim ClaimScreening) ([]ComplianceFlag, error) {
+	return nil, nil
+}
+
+// A hook that flags claims sent to addresses on a local blocklist
+type blocklistComplianceHook struct {
+	path    string
+	reasons map[common.Address]string
+}
+
+// Load a blocklist hook from a file with one address per line. Anything after a # on a line is used as the reason
+// the address is listed, and blank lines are ignored.
+func NewBlocklistComplianceHook(path string) (ComplianceHook, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening compliance blocklist %s: %w", path, err)
+	}
+	defer file.Close()
+
+	hook := &blocklistComplianceHook{
+		path:    path,
+		reasons: map[common.Address]string{},
+	}
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line, reason, _ := strings.Cut(scanner.Text(), "#")
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !common.IsHexAddress(line) {
+			return nil, fmt.Errorf("compliance blocklist %s has an invalid address on line %d: [%s]", path, lineNumber, line)
+		}
+		hook.reasons[common.HexToAddress(line)] = strings.TrimSpace(reason)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading compliance blocklist %s: %w", path, err)
+	}
+	return hook, nil
+}
+
+func (h *blocklistComplianceHook) ScreenClaim(claim ClaimScreening) ([]ComplianceFlag, error) {
+	reason, listed := h.reasons[claim.Recipient]
+	if !listed {
+		return nil, nil
+	}
+	description := fmt.Sprintf("%s is on the blocklist at %s", claim.Recipient.Hex(), h.path)
+	if reason != "" {
+		description = fmt.Sprintf("%s (%s)", description, reason)
+	}
+	return []ComplianceFlag{
+		{
+			Recipient: claim.Recipient,
+			Asset:     claim.Asset,
+			Reason:    description,
+		},
+	}, nil
+}
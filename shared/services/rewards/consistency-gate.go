@@ -0,0 +1,283 @@
+package rewards
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+)
+
+// The names of the consistency gate's checks
+const (
+	GateCheck_TotalsConservation string = "totals conservation"
+	GateCheck_Continuity         string = "continuity"
+	GateCheck_NodeSanity         string = "node sanity"
+	GateCheck_BonusCaps          string = "bonus caps"
+	GateCheck_RootStability      string = "root stability"
+)
+
+// The maximum commission a minipool can earn with bonuses, as a fraction of 1 ETH
+var maxBonusCommission = big.NewInt(14e16)
+
+// The outcome of one of the consistency gate's checks
+type ConsistencyGateCheck struct {
+	Name     string   `json:"name"`
+	Passed   bool     `json:"passed"`
+	Skipped  bool     `json:"skipped,omitempty"`
+	Problems []string `json:"problems,omitempty"`
+}
+
+// The outcome of running the consistency gate on an interval's rewards tree
+type ConsistencyGateReport struct {
+	Index       uint64                 `json:"index"`
+	MerkleRoot  string                 `json:"merkleRoot"`
+	Passed      bool                   `json:"passed"`
+	Checks      []ConsistencyGateCheck `json:"checks"`
+	GeneratedAt time.Time              `json:"generatedAt"`
+}
+
+// Everything the consistency gate checks a rewards tree against.
+// Optional inputs can be left nil, in which case the checks that need them are skipped.
+type ConsistencyGateInputs struct {
+	RewardsFile             IRewardsFile
+	MinipoolPerformanceFile IMinipoolPerformanceFile
+	PreviousRewardsFile     IRewardsFile
+	SmoothingPoolBalance    *big.Int
+	BeaconClient            RewardsBeaconClient
+
+	// Generates the tree again from scratch so its root can be compared with the first run
+	Regenerate func() (IRewardsFile, error)
+}
+
+// Run every consistency check on a rewards tree. The tree passes the gate if none of the checks fail.
+func RunConsistencyGate(inputs ConsistencyGateInputs) (*ConsistencyGateReport, error) {
+	file := inputs.RewardsFile
+	report := &ConsistencyGateReport{
+		Index:       file.GetIndex(),
+		MerkleRoot:  file.GetMerkleRoot(),
+		Passed:      true,
+		GeneratedAt: time.Now().UTC(),
+	}
+
+	continuity, err := checkGateContinuity(inputs)
+	if err != nil {
+		return nil, err
+	}
+	stability, err := checkGateRootStability(inputs)
+	if err != nil {
+		return nil, err
+	}
+	report.Checks = []ConsistencyGateCheck{
+		checkGateTotals(file, inputs.SmoothingPoolBalance),
+		continuity,
+		checkGateNodeSanity(file),
+		checkGateBonusCaps(file, inputs.MinipoolPerformanceFile),
+		stability,
+	}
+	for _, check := range report.Checks {
+		if !check.Passed {
+			report.Passed = false
+		}
+	}
+	return report, nil
+}
+
+// Make sure the tree's totals add up, don't exceed the interval's allocations, and don't distribute more ETH than the smoothing pool holds
+func checkGateTotals(file IRewardsFile, smoothingPoolBalance *big.Int) ConsistencyGateCheck {
+	problems := []string{}
+	if err := crossCheckTotals(file); err != nil {
+		problems = append(problems, err.Error())
+	}
+	for _, violation := range checkAllocations(file) {
+		problems = append(problems, violation.Description)
+	}
+	if smoothingPoolBalance != nil {
+		distributed := big.NewInt(0)
+		if eth := file.GetTotalNodeOperatorSmoothingPoolEth(); eth != nil {
+			distributed.Add(distributed, eth)
+		}
+		if eth := file.GetTotalPoolStakerSmoothingPoolEth(); eth != nil {
+			distributed.Add(distributed, eth)
+		}
+		if distributed.Cmp(smoothingPoolBalance) > 0 {
+			problems = append(problems, fmt.Sprintf("the tree distributes %s wei of smoothing pool ETH, but the pool only holds %s wei", distributed.String(), smoothingPoolBalance.String()))
+		}
+	}
+	return newGateCheck(GateCheck_TotalsConservation, problems)
+}
+
+// Make sure the tree picks up where the previous interval's tree left off
+func checkGateContinuity(inputs ConsistencyGateInputs) (ConsistencyGateCheck, error) {
+	if inputs.RewardsFile.GetIndex() == 0 {
+		return ConsistencyGateCheck{Name: GateCheck_Continuity, Passed: true, Skipped: true}, nil
+	}
+	if inputs.PreviousRewardsFile == nil {
+		return ConsistencyGateCheck{
+			Name:     GateCheck_Continuity,
+			Problems: []string{fmt.Sprintf("the rewards tree for interval %d isn't available to check against", inputs.RewardsFile.GetIndex()-1)},
+		}, nil
+	}
+	violations, err := CheckIntervalContinuity(inputs.PreviousRewardsFile, inputs.RewardsFile, inputs.BeaconClient)
+	if err != nil {
+		return ConsistencyGateCheck{}, fmt.Errorf("error checking continuity: %w", err)
+	}
+	problems := []string{}
+	for _, violation := range violations {
+		problems = append(problems, violation.String())
+	}
+	return newGateCheck(GateCheck_Continuity, problems), nil
+}
+
+// Make sure the tree has nodes if it allocates anything to them, and that every node in it is real and has rewards
+func checkGateNodeSanity(file IRewardsFile) ConsistencyGateCheck {
+	problems := []string{}
+	addresses := file.GetNodeAddresses()
+	if len(addresses) == 0 {
+		for _, total := range []*big.Int{file.GetTotalCollateralRpl(), file.GetTotalOracleDaoRpl(), file.GetTotalNodeOperatorSmoothingPoolEth()} {
+			if total != nil && total.Sign() > 0 {
+				problems = append(problems, "the tree allocates rewards to nodes but doesn't have any")
+				break
+			}
+		}
+	}
+	for _, address := range addresses {
+		if address == (common.Address{}) {
+			problems = append(problems, "the tree has rewards for the zero address")
+			continue
+		}
+		if file.GetNodeCollateralRpl(address).Sign() == 0 && file.GetNodeOracleDaoRpl(address).Sign() == 0 && file.GetNodeSmoothingPoolEth(address).Sign() == 0 {
+			problems = append(problems, fmt.Sprintf("node %s is in the tree but has no rewards", address.Hex()))
+		}
+	}
+	return newGateCheck(GateCheck_NodeSanity, problems)
+}
+
+// Make sure no minipool's bonus pushes its commission over the cap or exceeds its consensus income, and that bonuses
+// fit within the ETH given to node operators
+func checkGateBonusCaps(file IRewardsFile, performanceFile IMinipoolPerformanceFile) ConsistencyGateCheck {
+	if performanceFile == nil {
+		return ConsistencyGateCheck{Name: GateCheck_BonusCaps, Passed: true, Skipped: true}
+	}
+	problems := []string{}
+	totalBonus := big.NewInt(0)
+	for _, address := range performanceFile.GetMinipoolAddresses() {
+		performance, exists := performanceFile.GetSmoothingPoolPerformance(address)
+		if !exists {
+			continue
+		}
+		bonus := performance.GetBonusEthEarned()
+		if bonus.Sign() == 0 {
+			continue
+		}
+		if bonus.Sign() < 0 {
+			problems = append(problems, fmt.Sprintf("minipool %s has a negative bonus of %s wei", address.Hex(), bonus.String()))
+			continue
+		}
+		totalBonus.Add(totalBonus, bonus)
+		if commission := performance.GetEffectiveCommission(); commission.Cmp(maxBonusCommission) > 0 {
+			problems = append(problems, fmt.Sprintf("minipool %s earned a bonus with a commission of %s, which exceeds the cap of %s", address.Hex(), commission.String(), maxBonusCommission.String()))
+		}
+		if income := performance.GetConsensusIncome(); bonus.Cmp(income) > 0 {
+			problems = append(problems, fmt.Sprintf("minipool %s earned a bonus of %s wei, which exceeds its consensus income of %s wei", address.Hex(), bonus.String(), income.String()))
+		}
+	}
+	nodeOperatorEth := file.GetTotalNodeOperatorSmoothingPoolEth()
+	if totalBonus.Sign() > 0 && (nodeOperatorEth == nil || totalBonus.Cmp(nodeOperatorEth) > 0) {
+		problems = append(problems, fmt.Sprintf("minipools earned %s wei in bonuses, which exceeds the %s wei given to node operators", totalBonus.String(), formatAmount(nodeOperatorEth)))
+	}
+	return newGateCheck(GateCheck_BonusCaps, problems)
+}
+
+// Make sure generating the tree a second time produces the same Merkle root
+func checkGateRootStability(inputs ConsistencyGateInputs) (ConsistencyGateCheck, error) {
+	if inputs.Regenerate == nil {
+		return ConsistencyGateCheck{Name: GateCheck_RootStability, Passed: true, Skipped: true}, nil
+	}
+	regenerated, err := inputs.Regenerate()
+	if err != nil {
+		return ConsistencyGateCheck{}, fmt.Errorf("error regenerating the tree: %w", err)
+	}
+	problems := []string{}
+	root := inputs.RewardsFile.GetMerkleRoot()
+	regeneratedRoot := regenerated.GetMerkleRoot()
+	if !strings.EqualFold(root, regeneratedRoot) {
+		problems = append(problems, fmt.Sprintf("the first run produced root %s but the second produced %s", root, regeneratedRoot))
+	}
+	return newGateCheck(GateCheck_RootStability, problems), nil
+}
+
+func newGateCheck(name string, problems []string) ConsistencyGateCheck {
+	return ConsistencyGateCheck{
+		Name:     name,
+		Passed:   len(problems) == 0,
+		Problems: problems,
+	}
+}
+
+// Get the checks that failed
+func (r *ConsistencyGateReport) GetFailedChecks() []ConsistencyGateCheck {
+	failed := []ConsistencyGateCheck{}
+	for _, check := range r.Checks {
+		if !check.Passed {
+			failed = append(failed, check)
+		}
+	}
+	return failed
+}
+
+// Save the report to disk
+func (r *ConsistencyGateReport) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "\t")
+	if err != nil {
+		return fmt.Errorf("error serializing consistency gate report: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating consistency gate report directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("error saving consistency gate report to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Load a consistency gate report from disk
+func LoadConsistencyGateReport(path string) (*ConsistencyGateReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading consistency gate report %s: %w", path, err)
+	}
+	var report ConsistencyGateReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("error deserializing consistency gate report %s: %w", path, err)
+	}
+	return &report, nil
+}
+
+// Check if a manual override approves submitting the tree with the given Merkle root, despite it failing the gate.
+// Overrides name the root they approve, so they don't carry over to a regenerated tree.
+func IsConsistencyGateOverridden(overridePath string, merkleRoot string) (bool, error) {
+	data, err := os.ReadFile(overridePath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error reading consistency gate override %s: %w", overridePath, err)
+	}
+	return strings.EqualFold(strings.TrimSpace(string(data)), merkleRoot), nil
+}
+
+// Approve submitting the tree with the given Merkle root despite it failing the gate
+func SaveConsistencyGateOverride(overridePath string, merkleRoot string) error {
+	if err := os.MkdirAll(filepath.Dir(overridePath), 0755); err != nil {
+		return fmt.Errorf("error creating consistency gate override directory: %w", err)
+	}
+	if err := os.WriteFile(overridePath, []byte(merkleRoot), 0644); err != nil {
+		return fmt.Errorf("error saving consistency gate override to %s: %w", overridePath, err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package rewards
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestConsistencyGate(t *testing.T) {
+	f := newCrossCheckTestFile(5)
+	if err := f.GenerateMerkleTree(); err != nil {
+		t.Fatal(err)
+	}
+	stable := func() (IRewardsFile, error) {
+		return f, nil
+	}
+
+	// A consistent tree with no previous interval passes
+	report, err := RunConsistencyGate(ConsistencyGateInputs{RewardsFile: f, Regenerate: stable})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Passed {
+		t.Fatalf("expected the tree to pass, but these checks failed: %v", report.GetFailedChecks())
+	}
+
+	// A second run that produces a different root fails the stability check
+	unstable := newCrossCheckTestFile(6)
+	if err := unstable.GenerateMerkleTree(); err != nil {
+		t.Fatal(err)
+	}
+	report, err = RunConsistencyGate(ConsistencyGateInputs{
+		RewardsFile: f,
+		Regenerate: func() (IRewardsFile, error) {
+			return unstable, nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failed := report.GetFailedChecks()
+	if report.Passed || len(failed) != 1 || failed[0].Name != GateCheck_RootStability {
+		t.Fatalf("expected only the root stability check to fail, got %v", failed)
+	}
+
+	// A node without rewards and a smoothing pool that can't cover the tree both fail
+	f.NodeRewards[common.BigToAddress(big.NewInt(100))] = &NodeRewardsInfo_v2{
+		CollateralRpl:    NewQuotedBigInt(0),
+		OracleDaoRpl:     NewQuotedBigInt(0),
+		SmoothingPoolEth: NewQuotedBigInt(0),
+	}
+	f.TotalRewards.PoolStakerSmoothingPoolEth = NewQuotedBigInt(0)
+	report, err = RunConsistencyGate(ConsistencyGateInputs{RewardsFile: f, SmoothingPoolBalance: big.NewInt(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failed = report.GetFailedChecks()
+	if len(failed) != 2 || failed[0].Name != GateCheck_TotalsConservation || failed[1].Name != GateCheck_NodeSanity {
+		t.Fatalf("expected the totals and node sanity checks to fail, got %v", failed)
+	}
+}
@@ -0,0 +1,146 @@
+package rewards
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// A problem found when checking a rewards interval against the interval before it
+type ContinuityViolation struct {
+	Check       string
+	Description string
+}
+
+func (v ContinuityViolation) String() string {
+	return fmt.Sprintf("[%s] %s", v.Check, v.Description)
+}
+
+// Check that a rewards file picks up exactly where the previous interval's file left off, and that the amounts
+// it lets nodes claim don't exceed what the interval allocated.
+// The beacon client is used to confirm that any slots skipped between the two intervals were missed.
+func CheckIntervalContinuity(previous IRewardsFile, current IRewardsFile, bc RewardsBeaconClient) ([]ContinuityViolation, error) {
+	violations := []ContinuityViolation{}
+
+	// Index
+	if current.GetIndex() != previous.GetIndex()+1 {
+		violations = append(violations, ContinuityViolation{
+			Check:       "index",
+			Description: fmt.Sprintf("interval %d does not follow interval %d", current.GetIndex(), previous.GetIndex()),
+		})
+	}
+
+	// Consensus blocks: the interval starts at the first proposed slot of the epoch after the previous interval's last slot
+	beaconConfig, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, fmt.Errorf("error getting beacon config: %w", err)
+	}
+	previousEnd := previous.GetConsensusEndBlock()
+	currentStart := current.GetConsensusStartBlock()
+	expectedStart := (previousEnd/beaconConfig.SlotsPerEpoch + 1) * beaconConfig.SlotsPerEpoch
+	if currentStart < expectedStart {
+		violations = append(violations, ContinuityViolation{
+			Check:       "consensus start",
+			Description: fmt.Sprintf("consensus start slot %d overlaps the previous interval, which ended at slot %d (expected %d)", currentStart, previousEnd, expectedStart),
+		})
+	} else {
+		for slot := expectedStart; slot < currentStart; slot++ {
+			_, exists, err := bc.GetBeaconBlock(fmt.Sprint(slot))
+			if err != nil {
+				return nil, fmt.Errorf("error checking slot %d: %w", slot, err)
+			}
+			if exists {
+				violations = append(violations, ContinuityViolation{
+					Check:       "consensus start",
+					Description: fmt.Sprintf("consensus start slot %d skips slot %d, which has a block (expected %d)", currentStart, slot, expectedStart),
+				})
+				break
+			}
+		}
+	}
+	if current.GetConsensusEndBlock() < currentStart {
+		violations = append(violations, ContinuityViolation{
+			Check:       "consensus end",
+			Description: fmt.Sprintf("consensus end slot %d is before the start slot %d", current.GetConsensusEndBlock(), currentStart),
+		})
+	}
+
+	// Execution blocks are contiguous, so the interval starts right after the previous one's last block
+	if current.GetExecutionStartBlock() != previous.GetExecutionEndBlock()+1 {
+		violations = append(violations, ContinuityViolation{
+			Check:       "execution start",
+			Description: fmt.Sprintf("execution start block %d does not follow the previous interval's end block %d", current.GetExecutionStartBlock(), previous.GetExecutionEndBlock()),
+		})
+	}
+	if current.GetExecutionEndBlock() < current.GetExecutionStartBlock() {
+		violations = append(violations, ContinuityViolation{
+			Check:       "execution end",
+			Description: fmt.Sprintf("execution end block %d is before the start block %d", current.GetExecutionEndBlock(), current.GetExecutionStartBlock()),
+		})
+	}
+
+	// Times
+	if current.GetStartTime().Before(previous.GetEndTime()) {
+		violations = append(violations, ContinuityViolation{
+			Check:       "start time",
+			Description: fmt.Sprintf("start time %s is before the previous interval's end time %s", current.GetStartTime(), previous.GetEndTime()),
+		})
+	}
+
+	// Claimable amounts
+	violations = append(violations, checkAllocations(current)...)
+	return violations, nil
+}
+
+// Check that the amounts nodes can claim in a rewards file don't exceed the totals allocated to them, both overall
+// and per network
+func checkAllocations(file IRewardsFile) []ContinuityViolation {
+	violations := []ContinuityViolation{}
+
+	nodeCollateralRpl := big.NewInt(0)
+	nodeOracleDaoRpl := big.NewInt(0)
+	nodeSmoothingPoolEth := big.NewInt(0)
+	for _, address := range file.GetNodeAddresses() {
+		nodeCollateralRpl.Add(nodeCollateralRpl, file.GetNodeCollateralRpl(address))
+		nodeOracleDaoRpl.Add(nodeOracleDaoRpl, file.GetNodeOracleDaoRpl(address))
+		nodeSmoothingPoolEth.Add(nodeSmoothingPoolEth, file.GetNodeSmoothingPoolEth(address))
+	}
+
+	networkCollateralRpl := big.NewInt(0)
+	networkOracleDaoRpl := big.NewInt(0)
+	networkSmoothingPoolEth := big.NewInt(0)
+	for network := uint64(0); file.HasRewardsForNetwork(network); network++ {
+		networkCollateralRpl.Add(networkCollateralRpl, file.GetNetworkCollateralRpl(network))
+		networkOracleDaoRpl.Add(networkOracleDaoRpl, file.GetNetworkOracleDaoRpl(network))
+		networkSmoothingPoolEth.Add(networkSmoothingPoolEth, file.GetNetworkSmoothingPoolEth(network))
+	}
+
+	checks := []struct {
+		name       string
+		claimable  *big.Int
+		allocation *big.Int
+	}{
+		{"collateral RPL", nodeCollateralRpl, file.GetTotalCollateralRpl()},
+		{"Oracle DAO RPL", nodeOracleDaoRpl, file.GetTotalOracleDaoRpl()},
+		{"smoothing pool ETH", nodeSmoothingPoolEth, file.GetTotalNodeOperatorSmoothingPoolEth()},
+		{"network collateral RPL", networkCollateralRpl, file.GetTotalCollateralRpl()},
+		{"network Oracle DAO RPL", networkOracleDaoRpl, file.GetTotalOracleDaoRpl()},
+		{"network smoothing pool ETH", networkSmoothingPoolEth, file.GetTotalNodeOperatorSmoothingPoolEth()},
+	}
+	for _, check := range checks {
+		if check.allocation == nil || check.claimable.Cmp(check.allocation) > 0 {
+			violations = append(violations, ContinuityViolation{
+				Check:       "allocations",
+				Description: fmt.Sprintf("claimable %s (%s wei) exceeds the interval's allocation (%s wei)", check.name, check.claimable.String(), formatAmount(check.allocation)),
+			})
+		}
+	}
+	return violations
+}
+
+// Format a possibly-missing amount
+func formatAmount(amount *big.Int) string {
+	if amount == nil {
+		return "none"
+	}
+	return amount.String()
+}
@@ -0,0 +1,133 @@
+package rewards
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Independently recomputes a rewards file's claim totals and Merkle root from its per-node amounts and makes sure they
+// agree with what the generator produced.
+// This deliberately shares no code with the rewards file types or the Merkle tree library, so a bug in either one
+// will show up as a disagreement here instead of being submitted.
+func CrossCheckRewardsFile(file IRewardsFile) error {
+	if err := crossCheckTotals(file); err != nil {
+		return err
+	}
+
+	root := crossCheckMerkleRoot(file)
+	expectedRoot := common.HexToHash(file.GetMerkleRoot())
+	if root != expectedRoot {
+		return fmt.Errorf("the independently computed Merkle root %s does not match the generated root %s", root.Hex(), expectedRoot.Hex())
+	}
+	return nil
+}
+
+// Recompute the per-network and overall totals by walking the individual node rewards
+func crossCheckTotals(file IRewardsFile) error {
+	type amounts struct {
+		collateralRpl    *big.Int
+		oracleDaoRpl     *big.Int
+		smoothingPoolEth *big.Int
+	}
+	networks := map[uint64]*amounts{}
+	total := amounts{big.NewInt(0), big.NewInt(0), big.NewInt(0)}
+	for _, address := range file.GetNodeAddresses() {
+		network := file.GetNodeRewardNetwork(address)
+		networkAmounts, exists := networks[network]
+		if !exists {
+			networkAmounts = &amounts{big.NewInt(0), big.NewInt(0), big.NewInt(0)}
+			networks[network] = networkAmounts
+		}
+		networkAmounts.collateralRpl.Add(networkAmounts.collateralRpl, file.GetNodeCollateralRpl(address))
+		networkAmounts.oracleDaoRpl.Add(networkAmounts.oracleDaoRpl, file.GetNodeOracleDaoRpl(address))
+		networkAmounts.smoothingPoolEth.Add(networkAmounts.smoothingPoolEth, file.GetNodeSmoothingPoolEth(address))
+	}
+
+	for network, networkAmounts := range networks {
+		if !file.HasRewardsForNetwork(network) {
+			return fmt.Errorf("nodes have rewards on network %d, but the file has no rewards for that network", network)
+		}
+		checks := []struct {
+			name     string
+			computed *big.Int
+			reported *big.Int
+		}{
+			{"collateral RPL", networkAmounts.collateralRpl, file.GetNetworkCollateralRpl(network)},
+			{"Oracle DAO RPL", networkAmounts.oracleDaoRpl, file.GetNetworkOracleDaoRpl(network)},
+			{"smoothing pool ETH", networkAmounts.smoothingPoolEth, file.GetNetworkSmoothingPoolEth(network)},
+		}
+		for _, check := range checks {
+			if check.reported == nil || check.computed.Cmp(check.reported) != 0 {
+				return fmt.Errorf("network %d %s: nodes add up to %s wei but the file reports %s wei", network, check.name, check.computed.String(), formatAmount(check.reported))
+			}
+		}
+		total.collateralRpl.Add(total.collateralRpl, networkAmounts.collateralRpl)
+		total.oracleDaoRpl.Add(total.oracleDaoRpl, networkAmounts.oracleDaoRpl)
+		total.smoothingPoolEth.Add(total.smoothingPoolEth, networkAmounts.smoothingPoolEth)
+	}
+
+	// RPL totals are set to exactly what the nodes received, but the node operator ETH total can include rounding dust
+	reportedCollateralRpl := file.GetTotalCollateralRpl()
+	if reportedCollateralRpl == nil || total.collateralRpl.Cmp(reportedCollateralRpl) != 0 {
+		return fmt.Errorf("total collateral RPL: nodes add up to %s wei but the file reports %s wei", total.collateralRpl.String(), formatAmount(reportedCollateralRpl))
+	}
+	reportedOracleDaoRpl := file.GetTotalOracleDaoRpl()
+	if reportedOracleDaoRpl == nil || total.oracleDaoRpl.Cmp(reportedOracleDaoRpl) != 0 {
+		return fmt.Errorf("total Oracle DAO RPL: nodes add up to %s wei but the file reports %s wei", total.oracleDaoRpl.String(), formatAmount(reportedOracleDaoRpl))
+	}
+	reportedSmoothingPoolEth := file.GetTotalNodeOperatorSmoothingPoolEth()
+	if reportedSmoothingPoolEth == nil || total.smoothingPoolEth.Cmp(reportedSmoothingPoolEth) > 0 {
+		return fmt.Errorf("total smoothing pool ETH: nodes add up to %s wei, which exceeds the %s wei the file reports", total.smoothingPoolEth.String(), formatAmount(reportedSmoothingPoolEth))
+	}
+	return nil
+}
+
+// Build the Merkle root from scratch: leaves are keccak256(address[20] :: network[32] :: RPL[32] :: ETH[32]) in ascending
+// order, padded with zero hashes to a power of two, and each parent is the keccak256 of its children in ascending order.
+func crossCheckMerkleRoot(file IRewardsFile) common.Hash {
+	layer := [][]byte{}
+	for _, address := range file.GetNodeAddresses() {
+		rpl := new(big.Int).Add(file.GetNodeCollateralRpl(address), file.GetNodeOracleDaoRpl(address))
+		eth := file.GetNodeSmoothingPoolEth(address)
+		if rpl.Sign() == 0 && eth.Sign() == 0 {
+			continue
+		}
+		network := new(big.Int).SetUint64(file.GetNodeRewardNetwork(address))
+		leafData := append(address.Bytes(), common.BigToHash(network).Bytes()...)
+		leafData = append(leafData, common.BigToHash(rpl).Bytes()...)
+		leafData = append(leafData, common.BigToHash(eth).Bytes()...)
+		layer = append(layer, crypto.Keccak256(leafData))
+	}
+	if len(layer) == 0 {
+		return common.Hash{}
+	}
+	sort.Slice(layer, func(i, j int) bool {
+		return bytes.Compare(layer[i], layer[j]) < 0
+	})
+
+	width := 1
+	for width < len(layer) {
+		width *= 2
+	}
+	for len(layer) < width {
+		layer = append(layer, make([]byte, common.HashLength))
+	}
+
+	for len(layer) > 1 {
+		parents := make([][]byte, len(layer)/2)
+		for i := range parents {
+			left, right := layer[2*i], layer[2*i+1]
+			if bytes.Compare(left, right) > 0 {
+				left, right = right, left
+			}
+			parents[i] = crypto.Keccak256(left, right)
+		}
+		layer = parents
+	}
+	return common.BytesToHash(layer[0])
+}
@@ -0,0 +1,89 @@
+package rewards
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func newCrossCheckTestFile(nodeCount int) *RewardsFile_v3 {
+	f := &RewardsFile_v3{
+		RewardsFileHeader: &RewardsFileHeader{
+			RewardsFileVersion: 3,
+			RulesetVersion:     9,
+			TotalRewards: &TotalRewards{
+				TotalCollateralRpl:           NewQuotedBigInt(0),
+				TotalOracleDaoRpl:            NewQuotedBigInt(0),
+				NodeOperatorSmoothingPoolEth: NewQuotedBigInt(7),
+			},
+			NetworkRewards: map[uint64]*NetworkRewardsInfo{},
+		},
+		NodeRewards: map[common.Address]*NodeRewardsInfo_v2{},
+	}
+	for i := 0; i < nodeCount; i++ {
+		network := uint64(i % 2)
+		nr := &NodeRewardsInfo_v2{
+			RewardNetwork:    network,
+			CollateralRpl:    NewQuotedBigInt(int64(1000 + i)),
+			OracleDaoRpl:     NewQuotedBigInt(int64(i % 3)),
+			SmoothingPoolEth: NewQuotedBigInt(int64(500 * i)),
+		}
+		f.NodeRewards[common.BigToAddress(big.NewInt(int64(i+1)))] = nr
+
+		networkRewards, exists := f.NetworkRewards[network]
+		if !exists {
+			networkRewards = &NetworkRewardsInfo{
+				CollateralRpl:    NewQuotedBigInt(0),
+				OracleDaoRpl:     NewQuotedBigInt(0),
+				SmoothingPoolEth: NewQuotedBigInt(0),
+			}
+			f.NetworkRewards[network] = networkRewards
+		}
+		networkRewards.CollateralRpl.Add(&networkRewards.CollateralRpl.Int, &nr.CollateralRpl.Int)
+		networkRewards.OracleDaoRpl.Add(&networkRewards.OracleDaoRpl.Int, &nr.OracleDaoRpl.Int)
+		networkRewards.SmoothingPoolEth.Add(&networkRewards.SmoothingPoolEth.Int, &nr.SmoothingPoolEth.Int)
+
+		f.TotalRewards.TotalCollateralRpl.Add(&f.TotalRewards.TotalCollateralRpl.Int, &nr.CollateralRpl.Int)
+		f.TotalRewards.TotalOracleDaoRpl.Add(&f.TotalRewards.TotalOracleDaoRpl.Int, &nr.OracleDaoRpl.Int)
+		f.TotalRewards.NodeOperatorSmoothingPoolEth.Add(&f.TotalRewards.NodeOperatorSmoothingPoolEth.Int, &nr.SmoothingPoolEth.Int)
+	}
+	return f
+}
+
+func TestCrossCheckMatchesGenerator(t *testing.T) {
+	// Cover a single leaf, an exact power of two, and trees that need padding
+	for _, nodeCount := range []int{1, 2, 5, 8, 13} {
+		f := newCrossCheckTestFile(nodeCount)
+		if err := f.GenerateMerkleTree(); err != nil {
+			t.Fatal(err)
+		}
+		if err := CrossCheckRewardsFile(f); err != nil {
+			t.Fatalf("%d nodes: %s", nodeCount, err)
+		}
+	}
+}
+
+func TestCrossCheckCatchesDisagreements(t *testing.T) {
+	// A node amount changed after the tree was built
+	f := newCrossCheckTestFile(5)
+	if err := f.GenerateMerkleTree(); err != nil {
+		t.Fatal(err)
+	}
+	nr := f.NodeRewards[common.BigToAddress(big.NewInt(3))]
+	nr.SmoothingPoolEth.Add(&nr.SmoothingPoolEth.Int, big.NewInt(1))
+	f.NetworkRewards[nr.RewardNetwork].SmoothingPoolEth.Add(&f.NetworkRewards[nr.RewardNetwork].SmoothingPoolEth.Int, big.NewInt(1))
+	if err := CrossCheckRewardsFile(f); err == nil {
+		t.Fatal("expected a Merkle root mismatch")
+	}
+
+	// A network total that doesn't match its nodes
+	f = newCrossCheckTestFile(5)
+	if err := f.GenerateMerkleTree(); err != nil {
+		t.Fatal(err)
+	}
+	f.NetworkRewards[1].CollateralRpl.Add(&f.NetworkRewards[1].CollateralRpl.Int, big.NewInt(1))
+	if err := CrossCheckRewardsFile(f); err == nil {
+		t.Fatal("expected a network total mismatch")
+	}
+}
@@ -0,0 +1,172 @@
+package rewards
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The columns of a rewards CSV export, one row per node
+var rewardsCsvHeader = []string{
+	"interval",
+	"node_address",
+	"reward_network",
+	"collateral_rpl",
+	"oracle_dao_rpl",
+	"total_rpl",
+	"smoothing_pool_eth",
+	"collateral_rpl_share_percent",
+	"smoothing_pool_eth_share_percent",
+}
+
+// The columns of a minipool performance CSV export, one row per minipool
+var minipoolPerformanceCsvHeader = []string{
+	"interval",
+	"minipool_address",
+	"pubkey",
+	"successful_attestations",
+	"missed_attestations",
+	"participation_percent",
+	"attestation_score",
+	"average_inclusion_delay",
+	"consensus_income_eth",
+	"eth_earned",
+	"bonus_eth",
+	"total_eth_earned",
+	"effective_commission_percent",
+}
+
+var csvOneEth = big.NewInt(1e18)
+
+// Write a rewards file as a CSV with one row per node, sorted by address. Amounts are in ETH and RPL rather than wei,
+// and every node's share of the interval's collateral RPL and node operator Smoothing Pool ETH is included.
+func WriteRewardsCSV(w io.Writer, file IRewardsFile) error {
+	addresses := file.GetNodeAddresses()
+	sortCsvAddresses(addresses)
+	totalCollateralRpl := file.GetTotalCollateralRpl()
+	totalNodeOperatorEth := file.GetTotalNodeOperatorSmoothingPoolEth()
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(rewardsCsvHeader); err != nil {
+		return fmt.Errorf("error writing rewards CSV header: %w", err)
+	}
+	interval := strconv.FormatUint(file.GetIndex(), 10)
+	for _, address := range addresses {
+		collateralRpl := file.GetNodeCollateralRpl(address)
+		oracleDaoRpl := file.GetNodeOracleDaoRpl(address)
+		smoothingPoolEth := file.GetNodeSmoothingPoolEth(address)
+		totalRpl := big.NewInt(0).Add(collateralRpl, oracleDaoRpl)
+		err := writer.Write([]string{
+			interval,
+			address.Hex(),
+			strconv.FormatUint(file.GetNodeRewardNetwork(address), 10),
+			formatCsvAmount(collateralRpl),
+			formatCsvAmount(oracleDaoRpl),
+			formatCsvAmount(totalRpl),
+			formatCsvAmount(smoothingPoolEth),
+			formatCsvShare(collateralRpl, totalCollateralRpl),
+			formatCsvShare(smoothingPoolEth, totalNodeOperatorEth),
+		})
+		if err != nil {
+			return fmt.Errorf("error writing rewards CSV row for node %s: %w", address.Hex(), err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Write a minipool performance file as a CSV with one row per minipool, sorted by address. Amounts are in ETH rather
+// than wei, and each row includes the minipool's participation rate, the total ETH it earned including its bonus, and
+// its effective commission as a percentage.
+func WriteMinipoolPerformanceCSV(w io.Writer, interval uint64, file IMinipoolPerformanceFile) error {
+	addresses := file.GetMinipoolAddresses()
+	sortCsvAddresses(addresses)
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(minipoolPerformanceCsvHeader); err != nil {
+		return fmt.Errorf("error writing minipool performance CSV header: %w", err)
+	}
+	intervalString := strconv.FormatUint(interval, 10)
+	for _, address := range addresses {
+		performance, exists := file.GetSmoothingPoolPerformance(address)
+		if !exists {
+			continue
+		}
+		pubkey, err := performance.GetPubkey()
+		if err != nil {
+			return fmt.Errorf("error getting pubkey for minipool %s: %w", address.Hex(), err)
+		}
+
+		successful := performance.GetSuccessfulAttestationCount()
+		missed := performance.GetMissedAttestationCount()
+		participation := ""
+		if successful+missed > 0 {
+			participation = formatCsvShare(big.NewInt(0).SetUint64(successful), big.NewInt(0).SetUint64(successful+missed))
+		}
+		ethEarned := performance.GetEthEarned()
+		bonusEth := performance.GetBonusEthEarned()
+		totalEth := big.NewInt(0).Add(ethEarned, bonusEth)
+		commission := big.NewInt(0).Mul(performance.GetEffectiveCommission(), big.NewInt(100))
+
+		err = writer.Write([]string{
+			intervalString,
+			address.Hex(),
+			pubkey.Hex(),
+			strconv.FormatUint(successful, 10),
+			strconv.FormatUint(missed, 10),
+			participation,
+			performance.GetAttestationScore().String(),
+			strconv.FormatFloat(performance.GetAverageInclusionDelay(), 'f', -1, 64),
+			formatCsvAmount(performance.GetConsensusIncome()),
+			formatCsvAmount(ethEarned),
+			formatCsvAmount(bonusEth),
+			formatCsvAmount(totalEth),
+			formatCsvAmount(commission),
+		})
+		if err != nil {
+			return fmt.Errorf("error writing minipool performance CSV row for minipool %s: %w", address.Hex(), err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Sort addresses so exports are stable between runs
+func sortCsvAddresses(addresses []common.Address) {
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) < 0
+	})
+}
+
+// Format a wei amount as an exact decimal in ETH / RPL, without trailing zeros
+func formatCsvAmount(wei *big.Int) string {
+	if wei == nil {
+		return "0"
+	}
+	return trimCsvDecimal(big.NewRat(0, 1).SetFrac(wei, csvOneEth).FloatString(18))
+}
+
+// Format part of a total as a percentage, or leave it empty if the total is zero
+func formatCsvShare(part *big.Int, total *big.Int) string {
+	if part == nil || total == nil || total.Sign() == 0 {
+		return ""
+	}
+	share := big.NewRat(0, 1).SetFrac(big.NewInt(0).Mul(part, big.NewInt(100)), total)
+	return trimCsvDecimal(share.FloatString(6))
+}
+
+// Remove the trailing zeros from a decimal string, and the decimal point if nothing is left after it
+func trimCsvDecimal(value string) string {
+	if !strings.Contains(value, ".") {
+		return value
+	}
+	value = strings.TrimRight(value, "0")
+	return strings.TrimSuffix(value, ".")
+}
@@ -0,0 +1,78 @@
+package rewards
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/services/rewards/ssz_types"
+	sszbig "github.com/rocket-pool/smartnode/shared/services/rewards/ssz_types/big"
+)
+
+func TestWriteRewardsCSV(t *testing.T) {
+	file := ssz_types.NewSSZFile_v1()
+	file.Index = 7
+	file.TotalRewards = &ssz_types.TotalRewards{
+		TotalCollateralRpl:           sszbig.NewUint256(4e18),
+		NodeOperatorSmoothingPoolEth: sszbig.NewUint256(2e18),
+	}
+	second := ssz_types.NewNodeReward(0, ssz_types.AddressFromBytes(common.HexToAddress("0x02").Bytes()))
+	second.CollateralRpl = sszbig.NewUint256(3e18)
+	second.OracleDaoRpl = sszbig.NewUint256(5e17)
+	first := ssz_types.NewNodeReward(1, ssz_types.AddressFromBytes(common.HexToAddress("0x01").Bytes()))
+	first.CollateralRpl = sszbig.NewUint256(1e18)
+	first.SmoothingPoolEth = sszbig.NewUint256(5e17)
+	file.NodeRewards = ssz_types.NodeRewards{second, first}
+
+	buffer := &bytes.Buffer{}
+	if err := WriteRewardsCSV(buffer, file); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	expected := []string{
+		strings.Join(rewardsCsvHeader, ","),
+		"7,0x0000000000000000000000000000000000000001,1,1,0,1,0.5,25,25",
+		"7,0x0000000000000000000000000000000000000002,0,3,0.5,3.5,0,75,0",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %d lines, got %d:\n%s", len(expected), len(lines), buffer.String())
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Fatalf("line %d: expected %s, got %s", i, expected[i], lines[i])
+		}
+	}
+}
+
+func TestWriteMinipoolPerformanceCSV(t *testing.T) {
+	pubkey := strings.Repeat("ab", 48)
+	file := &MinipoolPerformanceFile_v2{
+		MinipoolPerformance: map[common.Address]*SmoothingPoolMinipoolPerformance_v2{
+			common.HexToAddress("0x03"): {
+				Pubkey:                 pubkey,
+				SuccessfulAttestations: 3,
+				MissedAttestations:     1,
+				AttestationScore:       NewQuotedBigInt(300),
+				EthEarned:              QuotedBigIntFromBigInt(big.NewInt(1e17)),
+				ConsensusIncome:        QuotedBigIntFromBigInt(big.NewInt(2e17)),
+				BonusEthEarned:         QuotedBigIntFromBigInt(big.NewInt(2e16)),
+				EffectiveCommission:    QuotedBigIntFromBigInt(big.NewInt(14e16)),
+			},
+		},
+	}
+
+	buffer := &bytes.Buffer{}
+	if err := WriteMinipoolPerformanceCSV(buffer, 7, file); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d:\n%s", len(lines), buffer.String())
+	}
+	expected := "7,0x0000000000000000000000000000000000000003," + pubkey + ",3,1,75,300,0,0.2,0.1,0.02,0.12,14"
+	if lines[1] != expected {
+		t.Fatalf("expected %s, got %s", expected, lines[1])
+	}
+}
@@ -0,0 +1,200 @@
+package rewards
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The attestation effectiveness of a single validator over a rewards interval, in the same terms used by external rating
+// services so the two can be compared directly
+type ValidatorEffectiveness struct {
+	Minipool               common.Address `json:"minipool"`
+	Node                   common.Address `json:"node,omitempty"`
+	Pubkey                 string         `json:"pubkey"`
+	SuccessfulAttestations uint64         `json:"successfulAttestations"`
+	MissedAttestations     uint64         `json:"missedAttestations"`
+
+	// The number of attestations included at each delay, in slots after the attested slot (1 is the earliest possible)
+	InclusionDelays map[uint64]uint64 `json:"inclusionDelays,omitempty"`
+
+	// The fraction of the validator's attestation duties that were included on chain
+	Participation float64 `json:"participation"`
+
+	// The mean inclusion delay of the included attestations, or 0 if the delays weren't recorded
+	AverageInclusionDelay float64 `json:"averageInclusionDelay"`
+
+	// The mean of 1 / inclusion delay over the included attestations, so an attestation included in the next slot scores 1.
+	// This is 1 if the delays weren't recorded.
+	InclusionScore float64 `json:"inclusionScore"`
+
+	// Participation weighted by the inclusion score, as a fraction
+	Effectiveness float64 `json:"effectiveness"`
+}
+
+// The effectiveness of every validator in a rewards interval
+type ValidatorEffectivenessFile struct {
+	Index   uint64 `json:"index"`
+	Network string `json:"network"`
+
+	// False if the effectiveness was derived from a minipool performance file, which records at most the average inclusion delay
+	HasInclusionDelays bool `json:"hasInclusionDelays"`
+
+	Validators []*ValidatorEffectiveness `json:"validators"`
+}
+
+// Score a validator's attestations. inclusionDelays can be nil if they weren't recorded, in which case the effectiveness is the
+// participation alone.
+func NewValidatorEffectiveness(minipool common.Address, node common.Address, pubkey string, successful uint64, missed uint64, inclusionDelays map[uint64]uint64) *ValidatorEffectiveness {
+	effectiveness := &ValidatorEffectiveness{
+		Minipool:               minipool,
+		Node:                   node,
+		Pubkey:                 pubkey,
+		SuccessfulAttestations: successful,
+		MissedAttestations:     missed,
+		InclusionDelays:        inclusionDelays,
+		InclusionScore:         1,
+	}
+	if successful+missed == 0 {
+		effectiveness.InclusionScore = 0
+		return effectiveness
+	}
+	effectiveness.Participation = float64(successful) / float64(successful+missed)
+
+	if len(inclusionDelays) > 0 {
+		count := uint64(0)
+		delaySum := uint64(0)
+		scoreSum := float64(0)
+		for delay, delayCount := range inclusionDelays {
+			if delay == 0 {
+				// Attestations can't be included in the slot they attest to; treat a bad record as the best case
+				delay = 1
+			}
+			count += delayCount
+			delaySum += delay * delayCount
+			scoreSum += float64(delayCount) / float64(delay)
+		}
+		if count > 0 {
+			effectiveness.AverageInclusionDelay = float64(delaySum) / float64(count)
+			effectiveness.InclusionScore = scoreSum / float64(count)
+		}
+	}
+
+	effectiveness.Effectiveness = effectiveness.Participation * effectiveness.InclusionScore
+	return effectiveness
+}
+
+// Derive the effectiveness of every validator in a minipool performance file. The performance file doesn't record each inclusion
+// delay, so this only scores participation; the average delay is carried over from files that have it.
+func NewValidatorEffectivenessFileFromPerformance(index uint64, file IMinipoolPerformanceFile) *ValidatorEffectivenessFile {
+	effectivenessFile := &ValidatorEffectivenessFile{
+		Index:      index,
+		Validators: []*ValidatorEffectiveness{},
+	}
+	for _, address := range file.GetMinipoolAddresses() {
+		performance, exists := file.GetSmoothingPoolPerformance(address)
+		if !exists {
+			continue
+		}
+		pubkey := ""
+		if validatorPubkey, err := performance.GetPubkey(); err == nil {
+			pubkey = validatorPubkey.Hex()
+		}
+		effectiveness := NewValidatorEffectiveness(address, common.Address{}, pubkey, performance.GetSuccessfulAttestationCount(), performance.GetMissedAttestationCount(), nil)
+		effectiveness.AverageInclusionDelay = performance.GetAverageInclusionDelay()
+		effectivenessFile.Validators = append(effectivenessFile.Validators, effectiveness)
+	}
+	effectivenessFile.sort()
+	return effectivenessFile
+}
+
+// Get the validators that belong to the given minipools
+func (f *ValidatorEffectivenessFile) Filter(minipools []common.Address) []*ValidatorEffectiveness {
+	wanted := make(map[common.Address]bool, len(minipools))
+	for _, minipool := range minipools {
+		wanted[minipool] = true
+	}
+	validators := []*ValidatorEffectiveness{}
+	for _, validator := range f.Validators {
+		if wanted[validator.Minipool] {
+			validators = append(validators, validator)
+		}
+	}
+	return validators
+}
+
+// Get the combined effectiveness of a set of validators, weighting each by its number of duties
+func GetCombinedEffectiveness(validators []*ValidatorEffectiveness) float64 {
+	duties := uint64(0)
+	weightedSum := float64(0)
+	for _, validator := range validators {
+		validatorDuties := validator.SuccessfulAttestations + validator.MissedAttestations
+		duties += validatorDuties
+		weightedSum += validator.Effectiveness * float64(validatorDuties)
+	}
+	if duties == 0 {
+		return 0
+	}
+	return weightedSum / float64(duties)
+}
+
+// Save the effectiveness file to disk
+func (f *ValidatorEffectivenessFile) Save(path string) error {
+	bytes, err := json.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("error serializing validator effectiveness: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return fmt.Errorf("error writing validator effectiveness to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Load an effectiveness file from disk
+func LoadValidatorEffectivenessFile(path string) (*ValidatorEffectivenessFile, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading validator effectiveness from %s: %w", path, err)
+	}
+	file := &ValidatorEffectivenessFile{}
+	if err := json.Unmarshal(bytes, file); err != nil {
+		return nil, fmt.Errorf("error deserializing validator effectiveness from %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// Get the validator effectiveness for an interval from the file saved during tree generation, or derive it from the minipool
+// performance file if the tree wasn't generated on this machine. Returns an error wrapping os.ErrNotExist if neither is available.
+func GetValidatorEffectiveness(cfg *config.RocketPoolConfig, interval uint64, isDaemon bool) (*ValidatorEffectivenessFile, error) {
+	effectivenessFile, err := LoadValidatorEffectivenessFile(cfg.Smartnode.GetValidatorEffectivenessPath(interval, isDaemon))
+	if err == nil {
+		return effectivenessFile, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	performancePath := cfg.Smartnode.GetMinipoolPerformancePath(interval, isDaemon)
+	if _, err := os.Stat(performancePath); err != nil {
+		return nil, fmt.Errorf("neither the validator effectiveness nor the minipool performance file for interval %d is on this machine: %w", interval, err)
+	}
+	performanceFile, err := ReadLocalMinipoolPerformanceFile(performancePath)
+	if err != nil {
+		return nil, err
+	}
+	effectivenessFile = NewValidatorEffectivenessFileFromPerformance(interval, performanceFile.Impl())
+	effectivenessFile.Network = fmt.Sprint(cfg.Smartnode.Network.Value)
+	return effectivenessFile, nil
+}
+
+// Sort the validators by minipool address so the file is always generated in the same state
+func (f *ValidatorEffectivenessFile) sort() {
+	sort.Slice(f.Validators, func(i, j int) bool {
+		return f.Validators[i].Minipool.Cmp(f.Validators[j].Minipool) < 0
+	})
+}
@@ -0,0 +1,42 @@
+package rewards
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestValidatorEffectiveness(t *testing.T) {
+	minipool := common.HexToAddress("0x1")
+	node := common.HexToAddress("0xa")
+
+	// 3 of 4 duties included, two in the next slot and one 2 slots late
+	effectiveness := NewValidatorEffectiveness(minipool, node, "", 3, 1, map[uint64]uint64{1: 2, 2: 1})
+	if effectiveness.Participation != 0.75 {
+		t.Fatalf("expected 0.75 participation, got %f", effectiveness.Participation)
+	}
+	if math.Abs(effectiveness.AverageInclusionDelay-4.0/3.0) > 1e-9 {
+		t.Fatalf("expected a mean inclusion delay of 4/3, got %f", effectiveness.AverageInclusionDelay)
+	}
+	if math.Abs(effectiveness.InclusionScore-2.5/3.0) > 1e-9 {
+		t.Fatalf("expected an inclusion score of 2.5/3, got %f", effectiveness.InclusionScore)
+	}
+	if math.Abs(effectiveness.Effectiveness-0.625) > 1e-9 {
+		t.Fatalf("expected 0.625 effectiveness, got %f", effectiveness.Effectiveness)
+	}
+
+	// Without delays, effectiveness is just participation
+	effectiveness = NewValidatorEffectiveness(minipool, node, "", 3, 1, nil)
+	if effectiveness.Effectiveness != 0.75 {
+		t.Fatalf("expected 0.75 effectiveness without delays, got %f", effectiveness.Effectiveness)
+	}
+
+	// Validators are weighted by their duties when combined
+	idle := NewValidatorEffectiveness(common.HexToAddress("0x2"), node, "", 0, 0, nil)
+	perfect := NewValidatorEffectiveness(common.HexToAddress("0x3"), node, "", 4, 0, map[uint64]uint64{1: 4})
+	combined := GetCombinedEffectiveness([]*ValidatorEffectiveness{effectiveness, idle, perfect})
+	if combined != 0.875 {
+		t.Fatalf("expected 0.875 combined effectiveness, got %f", combined)
+	}
+}
@@ -0,0 +1,109 @@
+package rewards
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// The inputs that went into each node's line of a rewards file, so node operators can audit their own rewards
+// without regenerating the whole tree
+type RewardsExplanationFile struct {
+	Index          uint64 `json:"index"`
+	RulesetVersion uint64 `json:"rulesetVersion"`
+	Network        string `json:"network"`
+
+	// The interval-wide amounts each node's share is taken from
+	TotalCollateralRpl        *QuotedBigInt `json:"totalCollateralRpl"`
+	TotalNodeWeight           *QuotedBigInt `json:"totalNodeWeight"`
+	TotalOracleDaoRpl         *QuotedBigInt `json:"totalOracleDaoRpl"`
+	TotalOracleDaoSeconds     *QuotedBigInt `json:"totalOracleDaoSeconds"`
+	SmoothingPoolBalance      *QuotedBigInt `json:"smoothingPoolBalance"`
+	TotalNodeOperatorShareEth *QuotedBigInt `json:"totalNodeOperatorShareEth"`
+	TotalAttestationScore     *QuotedBigInt `json:"totalAttestationScore"`
+	SuccessfulAttestations    uint64        `json:"successfulAttestations"`
+	BonusScalar               *QuotedBigInt `json:"bonusScalar,omitempty"`
+
+	Nodes map[common.Address]*NodeRewardsExplanation `json:"nodes"`
+}
+
+// The inputs and results for a single node
+type NodeRewardsExplanation struct {
+	// The network the node asked for its rewards on, and the one they were actually sent to
+	RequestedNetwork uint64 `json:"requestedNetwork"`
+	RewardsNetwork   uint64 `json:"rewardsNetwork"`
+
+	// Collateral rewards, which are the node's weight out of the total node weight
+	RplStake      *QuotedBigInt `json:"rplStake"`
+	Weight        *QuotedBigInt `json:"weight"`
+	CollateralRpl *QuotedBigInt `json:"collateralRpl"`
+
+	// oDAO rewards, which are the member's participation time out of the total participation time
+	OracleDaoSeconds *QuotedBigInt `json:"oracleDaoSeconds,omitempty"`
+	OracleDaoRpl     *QuotedBigInt `json:"oracleDaoRpl"`
+
+	// Smoothing pool rewards
+	SmoothingPool    *SmoothingPoolExplanation `json:"smoothingPool,omitempty"`
+	SmoothingPoolEth *QuotedBigInt             `json:"smoothingPoolEth"`
+}
+
+// How a node's smoothing pool rewards were calculated
+type SmoothingPoolExplanation struct {
+	IsEligible      bool                          `json:"isEligible"`
+	IsOptedIn       bool                          `json:"isOptedIn"`
+	EligibleSeconds *QuotedBigInt                 `json:"eligibleSeconds"`
+	BonusEth        *QuotedBigInt                 `json:"bonusEth,omitempty"`
+	Minipools       []*MinipoolRewardsExplanation `json:"minipools"`
+}
+
+// How a minipool's share of the smoothing pool was calculated
+type MinipoolRewardsExplanation struct {
+	Address                common.Address        `json:"address"`
+	Pubkey                 types.ValidatorPubkey `json:"pubkey"`
+	WasActive              bool                  `json:"wasActive"`
+	Fee                    *QuotedBigInt         `json:"fee"`
+	SuccessfulAttestations uint64                `json:"successfulAttestations"`
+	MissedAttestations     uint64                `json:"missedAttestations"`
+	AttestationScore       *QuotedBigInt         `json:"attestationScore"`
+	MinipoolShare          *QuotedBigInt         `json:"minipoolShare"`
+	ConsensusIncome        *QuotedBigInt         `json:"consensusIncome,omitempty"`
+	BonusEth               *QuotedBigInt         `json:"bonusEth,omitempty"`
+}
+
+// Save the explanation file to disk
+func (f *RewardsExplanationFile) Save(path string) error {
+	bytes, err := json.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("error serializing rewards explanations: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return fmt.Errorf("error writing rewards explanations to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Load an explanation file from disk
+func LoadRewardsExplanationFile(path string) (*RewardsExplanationFile, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading rewards explanations from %s: %w", path, err)
+	}
+	file := &RewardsExplanationFile{}
+	if err := json.Unmarshal(bytes, file); err != nil {
+		return nil, fmt.Errorf("error deserializing rewards explanations from %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// Copy an optional value into a QuotedBigInt, treating a missing value as zero
+func explanationAmount(value *big.Int) *QuotedBigInt {
+	amount := NewQuotedBigInt(0)
+	if value != nil {
+		amount.Set(value)
+	}
+	return amount
+}
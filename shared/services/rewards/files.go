@@ -1,24 +1,32 @@
 package rewards
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ipfs/go-cid"
 	"github.com/klauspost/compress/zstd"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rewards/ssz_types"
 )
 
-// Reads an existing RewardsFile from disk and wraps it in a LocalFile
+// Reads an existing RewardsFile from disk, in either JSON or SSZ format, and wraps it in a LocalFile
 func ReadLocalRewardsFile(path string) (*LocalRewardsFile, error) {
 	fileBytes, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("error reading rewards file from %s: %w", path, err)
 	}
 
-	// Unmarshal it
-	proofWrapper, err := DeserializeRewardsFile(fileBytes)
+	// Unmarshal it, using the SSZ parser if it's an SSZ file
+	var proofWrapper IRewardsFile
+	if bytes.HasPrefix(fileBytes, ssz_types.Magic[:]) {
+		proofWrapper, err = ssz_types.ParseSSZFile(fileBytes)
+	} else {
+		proofWrapper, err = DeserializeRewardsFile(fileBytes)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error unmarshaling rewards file from %s: %w", path, err)
 	}
@@ -26,6 +34,42 @@ func ReadLocalRewardsFile(path string) (*LocalRewardsFile, error) {
 	return NewLocalFile[IRewardsFile](proofWrapper, path), nil
 }
 
+// Reads an existing SSZ RewardsFile from disk, verifies it, and wraps it in a LocalFile
+func ReadLocalSSZRewardsFile(path string) (*LocalRewardsFile, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading rewards file from %s: %w", path, err)
+	}
+
+	// Unmarshal it
+	sszFile, err := ssz_types.ParseSSZFile(fileBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling rewards file from %s: %w", path, err)
+	}
+
+	return NewLocalFile[IRewardsFile](sszFile, path), nil
+}
+
+// Reads the header of an existing SSZ RewardsFile and a single node's rewards and Merkle proof from disk, without
+// decoding the rest of the nodes. The node's rewards are nil if it isn't in the file.
+func ReadLocalSSZNodeRewards(path string, nodeAddress common.Address) (*ssz_types.PartialSSZFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening rewards file %s: %w", path, err)
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("error getting the size of rewards file %s: %w", path, err)
+	}
+
+	partialFile, err := ssz_types.ReadPartialSSZFile(file, stat.Size(), ssz_types.AddressFromBytes(nodeAddress.Bytes()))
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshaling rewards file from %s: %w", path, err)
+	}
+	return partialFile, nil
+}
+
 // Reads an existing MinipoolPerformanceFile from disk and wraps it in a LocalFile
 func ReadLocalMinipoolPerformanceFile(path string) (*LocalMinipoolPerformanceFile, error) {
 	fileBytes, err := os.ReadFile(path)
@@ -276,5 +320,47 @@ func saveArtifactsImpl(smartnode *config.SmartnodeConfig, treeResult *GenerateTr
 		}
 
 	}
+
+	// Save the dust accounting next to the rewards file
+	if treeResult.DustAccounting != nil {
+		err := saveDustAccounting(treeResult.DustAccounting, smartnode.GetRewardsDustAccountingPath(currentIndex, true))
+		if err != nil {
+			return cid.Cid{}, nil, err
+		}
+	}
+
+	// Save the per-node explanations if they were generated
+	if treeResult.Explanations != nil {
+		err := treeResult.Explanations.Save(smartnode.GetRewardsExplanationsPath(currentIndex, true))
+		if err != nil {
+			return cid.Cid{}, nil, err
+		}
+	}
+
+	// Save the validator effectiveness if it was recorded
+	if treeResult.ValidatorEffectiveness != nil {
+		err := treeResult.ValidatorEffectiveness.Save(smartnode.GetValidatorEffectivenessPath(currentIndex, true))
+		if err != nil {
+			return cid.Cid{}, nil, err
+		}
+	}
+
+	// Save the research scores if they were requested
+	if treeResult.ResearchScores != nil {
+		err := treeResult.ResearchScores.Save(smartnode.GetResearchScoresPath(currentIndex, true))
+		if err != nil {
+			return cid.Cid{}, nil, err
+		}
+	}
+
+	// Index the minipool performance file so it can be queried a page at a time
+	if treeResult.MinipoolPerformanceFile != nil {
+		index := NewMinipoolPerformanceIndex(currentIndex, treeResult.MinipoolPerformanceFile, treeResult.MinipoolNodes)
+		err := index.Save(smartnode.GetMinipoolPerformanceIndexPath(currentIndex, true))
+		if err != nil {
+			return cid.Cid{}, nil, err
+		}
+	}
+
 	return *primaryCid, out, nil
 }
@@ -17,7 +17,9 @@ import (
 	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rewards/ssz_types"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"golang.org/x/sync/errgroup"
 )
@@ -51,6 +53,29 @@ type treeGeneratorImpl_v8 struct {
 	successfulAttestations       uint64
 	genesisTime                  time.Time
 	invalidNetworkNodes          map[common.Address]uint64
+	dustAccounting               *ssz_types.DustAccounting
+}
+
+func init() {
+	RegisterRuleset(Ruleset{
+		Version: 8,
+		StartIntervals: map[cfgtypes.Network]uint64{
+			cfgtypes.Network_Mainnet: MainnetV8Interval,
+			cfgtypes.Network_Holesky: HoleskyV8Interval,
+			cfgtypes.Network_Devnet:  0,
+		},
+		NewGenerator: func(params *RulesetGeneratorParams) (RulesetGenerator, error) {
+			// Strict accounting was introduced after v8, so v8 always uses the legacy epsilon
+			accountingPolicies, err := ParseAccountingPolicies(params.Config.Smartnode.RewardsAccountingPolicy.Value.(string))
+			if err != nil {
+				return nil, err
+			}
+			if policy, exists := accountingPolicies.Rulesets[8]; exists && policy != AccountingPolicy_Legacy {
+				return nil, fmt.Errorf("ruleset v8 only supports the %s accounting policy", AccountingPolicy_Legacy)
+			}
+			return newTreeGeneratorImpl_v8(params.Logger, params.LogPrefix, params.Index, params.StartTime, params.EndTime, params.SnapshotEnd.ConsensusBlock, params.ElSnapshotHeader, params.IntervalsPassed, params.State), nil
+		},
+	})
 }
 
 // Create a new tree generator
@@ -94,15 +119,16 @@ func newTreeGeneratorImpl_v8(log *log.ColorLogger, logPrefix string, index uint6
 		totalAttestationScore: big.NewInt(0),
 		networkState:          state,
 		invalidNetworkNodes:   map[common.Address]uint64{},
+		dustAccounting:        ssz_types.NewDustAccounting(),
 	}
 }
 
 // Get the version of the ruleset used by this generator
-func (r *treeGeneratorImpl_v8) getRulesetVersion() uint64 {
+func (r *treeGeneratorImpl_v8) GetRulesetVersion() uint64 {
 	return r.rewardsFile.RulesetVersion
 }
 
-func (r *treeGeneratorImpl_v8) generateTree(rp RewardsExecutionClient, networkName string, previousRewardsPoolAddresses []common.Address, bc RewardsBeaconClient) (*GenerateTreeResult, error) {
+func (r *treeGeneratorImpl_v8) GenerateTree(rp RewardsExecutionClient, networkName string, previousRewardsPoolAddresses []common.Address, bc RewardsBeaconClient) (*GenerateTreeResult, error) {
 
 	r.log.Printlnf("%s Generating tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
 
@@ -173,13 +199,15 @@ func (r *treeGeneratorImpl_v8) generateTree(rp RewardsExecutionClient, networkNa
 		RewardsFile:             r.rewardsFile,
 		InvalidNetworkNodes:     r.invalidNetworkNodes,
 		MinipoolPerformanceFile: &r.rewardsFile.MinipoolPerformanceFile,
+		MinipoolNodes:           getMinipoolNodes(r.networkState),
+		DustAccounting:          r.dustAccounting,
 	}, nil
 
 }
 
 // Quickly calculates an approximate of the staker's share of the smoothing pool balance without processing Beacon performance
 // Used for approximate returns in the rETH ratio update
-func (r *treeGeneratorImpl_v8) approximateStakerShareOfSmoothingPool(rp RewardsExecutionClient, networkName string, bc RewardsBeaconClient) (*big.Int, error) {
+func (r *treeGeneratorImpl_v8) ApproximateStakerShareOfSmoothingPool(rp RewardsExecutionClient, networkName string, bc RewardsBeaconClient) (*big.Int, error) {
 	r.log.Printlnf("%s Approximating tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
 
 	r.rp = rp
@@ -312,6 +340,7 @@ func (r *treeGeneratorImpl_v8) calculateRplRewards() error {
 	pDaoRewards := NewQuotedBigInt(0)
 	pDaoRewards.Mul(pendingRewards, pDaoPercent)
 	pDaoRewards.Div(&pDaoRewards.Int, eth.EthToWei(1))
+	expectedPDaoRewards := big.NewInt(0).Set(&pDaoRewards.Int)
 	r.log.Printlnf("%s Expected Protocol DAO rewards: %s (%.3f)", r.logPrefix, pDaoRewards.String(), eth.WeiToEth(&pDaoRewards.Int))
 
 	// Get node operator rewards
@@ -405,6 +434,7 @@ func (r *treeGeneratorImpl_v8) calculateRplRewards() error {
 			return fmt.Errorf("error calculating collateral RPL: total was %s, but expected %s; error was too large", totalCalculatedNodeRewards.String(), totalNodeRewards.String())
 		}
 		r.rewardsFile.TotalRewards.TotalCollateralRpl.Int = *totalCalculatedNodeRewards
+		r.dustAccounting.CollateralRpl.Sub(totalNodeRewards, totalCalculatedNodeRewards)
 		r.log.Printlnf("%s Calculated rewards:           %s (error = %s wei)", r.logPrefix, totalCalculatedNodeRewards.String(), delta.String())
 		pDaoRewards.Sub(pendingRewards, totalCalculatedNodeRewards)
 	} else {
@@ -500,6 +530,7 @@ func (r *treeGeneratorImpl_v8) calculateRplRewards() error {
 		return fmt.Errorf("error calculating ODao RPL: total was %s, but expected %s; error was too large", totalCalculatedOdaoRewards.String(), totalODaoRewards.String())
 	}
 	r.rewardsFile.TotalRewards.TotalOracleDaoRpl.Int = *totalCalculatedOdaoRewards
+	r.dustAccounting.OracleDaoRpl.Sub(totalODaoRewards, totalCalculatedOdaoRewards)
 	r.log.Printlnf("%s Calculated rewards:           %s (error = %s wei)", r.logPrefix, totalCalculatedOdaoRewards.String(), delta.String())
 
 	// Get actual protocol DAO rewards
@@ -507,6 +538,13 @@ func (r *treeGeneratorImpl_v8) calculateRplRewards() error {
 	r.rewardsFile.TotalRewards.ProtocolDaoRpl = pDaoRewards
 	r.log.Printlnf("%s Actual Protocol DAO rewards:  %s to account for truncation", r.logPrefix, pDaoRewards.String())
 
+	// Record the dust from splitting the pending rewards by percentage, which is whatever none of the three categories got
+	dust := r.dustAccounting
+	dust.PercentageRpl.Sub(pendingRewards, expectedPDaoRewards)
+	dust.PercentageRpl.Sub(dust.PercentageRpl.Int, totalNodeRewards)
+	dust.PercentageRpl.Sub(dust.PercentageRpl.Int, totalODaoRewards)
+	r.log.Printlnf("%s Dust sent to the pDAO:        %s (%s from percentages, %s from collateral, %s from oDAO)", r.logPrefix, dust.ProtocolDaoRpl().String(), dust.PercentageRpl.String(), dust.CollateralRpl.String(), dust.OracleDaoRpl.String())
+
 	// Print total node weight
 	r.log.Printlnf("%s Total Node Weight:            %s", r.logPrefix, totalNodeWeight)
 
@@ -719,6 +757,7 @@ func (r *treeGeneratorImpl_v8) calculateNodeRewards() (*big.Int, *big.Int, error
 	if delta.Cmp(r.epsilon) == 1 {
 		return nil, nil, fmt.Errorf("error calculating smoothing pool ETH: total was %s, but expected %s; error was too large (%s wei)", totalEthForMinipools.String(), totalNodeOpShare.String(), delta.String())
 	}
+	r.dustAccounting.SmoothingPoolEth.Sub(totalNodeOpShare, totalEthForMinipools)
 
 	// Calculate the staking pool share and the node op share
 	poolStakerShare := big.NewInt(0).Sub(r.smoothingPoolBalance, totalNodeOpShare)
@@ -1211,6 +1250,6 @@ func (r *treeGeneratorImpl_v8) getMinipoolBondAndNodeFee(details *rpstate.Native
 	return currentBond, currentFee
 }
 
-func (r *treeGeneratorImpl_v8) saveFiles(smartnode *config.SmartnodeConfig, treeResult *GenerateTreeResult, nodeTrusted bool) (cid.Cid, map[string]cid.Cid, error) {
+func (r *treeGeneratorImpl_v8) SaveFiles(smartnode *config.SmartnodeConfig, treeResult *GenerateTreeResult, nodeTrusted bool) (cid.Cid, map[string]cid.Cid, error) {
 	return saveJSONArtifacts(smartnode, treeResult, nodeTrusted)
 }
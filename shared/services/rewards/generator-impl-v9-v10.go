@@ -21,6 +21,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/rewards/ssz_types"
 	sszbig "github.com/rocket-pool/smartnode/shared/services/rewards/ssz_types/big"
 	"github.com/rocket-pool/smartnode/shared/services/state"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"golang.org/x/sync/errgroup"
 )
@@ -56,15 +57,92 @@ type treeGeneratorImpl_v9_v10 struct {
 	successfulAttestations       uint64
 	genesisTime                  time.Time
 	invalidNetworkNodes          map[common.Address]uint64
-	minipoolPerformanceFile      *MinipoolPerformanceFile_v2
+	minipoolPerformanceFile      *MinipoolPerformanceFile_v3
+	validatorEffectiveness       *ValidatorEffectivenessFile
 	nodeRewards                  map[common.Address]*ssz_types.NodeReward
 	networkRewards               map[ssz_types.Layer]*ssz_types.NetworkReward
+	epochWorkers                 uint64
+	nodeWorkers                  uint64
+	memoryBudget                 uint64
+	missedDuties                 *missedDutyStore
+	progressTracker              *progressTracker
+	accountingPolicy             AccountingPolicy
+	explain                      bool
+	researchScores               *researchScoreTracker
+	dustAccounting               *ssz_types.DustAccounting
+
+	// Intermediate values kept for the per-node explanations
+	nodeWeights           map[common.Address]*big.Int
+	expectedCollateralRpl *big.Int
+	expectedOracleDaoRpl  *big.Int
+	oDaoNodeTimes         map[common.Address]*big.Int
+	totalODaoNodeTime     *big.Int
+	totalNodeOpShare      *big.Int
+
+	// Guards minipoolWithdrawals, which is written by concurrent epoch fetches
+	withdrawalsLock sync.Mutex
 
 	// fields for RPIP-62 bonus calculations
 	// Withdrawals made by a minipool's validator.
 	minipoolWithdrawals map[common.Address]*big.Int
 }
 
+func init() {
+	RegisterRuleset(Ruleset{
+		Version: 10,
+		StartIntervals: map[cfgtypes.Network]uint64{
+			cfgtypes.Network_Mainnet: MainnetV10Interval,
+			cfgtypes.Network_Holesky: HoleskyV10Interval,
+			cfgtypes.Network_Devnet:  0,
+		},
+		NewGenerator: func(params *RulesetGeneratorParams) (RulesetGenerator, error) {
+			return newConfiguredTreeGeneratorImpl_v9_v10(10, params)
+		},
+	})
+	RegisterRuleset(Ruleset{
+		Version: 9,
+		StartIntervals: map[cfgtypes.Network]uint64{
+			cfgtypes.Network_Mainnet: MainnetV9Interval,
+			cfgtypes.Network_Holesky: HoleskyV9Interval,
+			cfgtypes.Network_Devnet:  0,
+		},
+		NewGenerator: func(params *RulesetGeneratorParams) (RulesetGenerator, error) {
+			return newConfiguredTreeGeneratorImpl_v9_v10(9, params)
+		},
+	})
+}
+
+// Create a new tree generator with the Smartnode's treegen settings
+func newConfiguredTreeGeneratorImpl_v9_v10(rulesetVersion uint64, params *RulesetGeneratorParams) (RulesetGenerator, error) {
+	generator := newTreeGeneratorImpl_v9_v10(rulesetVersion, params.Logger, params.LogPrefix, params.Index, params.SnapshotEnd, params.ElSnapshotHeader, params.IntervalsPassed, params.State)
+	cfg := params.Config
+
+	// Set the number of epochs to fetch and nodes to calculate rewards for in parallel
+	generator.setEpochWorkers(cfg.Smartnode.TreegenEpochWorkers.Value.(uint64))
+	generator.setNodeWorkers(cfg.Smartnode.TreegenNodeWorkers.Value.(uint64))
+
+	// Set the memory budget
+	generator.setMemoryBudget(cfg.Smartnode.TreegenMemoryBudget.Value.(uint64))
+
+	// Set the accounting policy
+	accountingPolicies, err := ParseAccountingPolicies(cfg.Smartnode.RewardsAccountingPolicy.Value.(string))
+	if err != nil {
+		return nil, err
+	}
+	generator.setAccountingPolicy(accountingPolicies.ForRuleset(rulesetVersion))
+
+	// Explain each node's rewards if requested
+	generator.setExplanations(cfg.Smartnode.SaveRewardsExplanations.Value.(bool))
+
+	// Score attestations with the research scorers if requested
+	researchScorers, err := ParseAttestationScorers(cfg.Smartnode.ResearchAttestationScorers.Value.(string))
+	if err != nil {
+		return nil, err
+	}
+	generator.setResearchScorers(researchScorers)
+	return generator, nil
+}
+
 // Create a new tree generator
 func newTreeGeneratorImpl_v9_v10(rulesetVersion uint64, log *log.ColorLogger, logPrefix string, index uint64, snapshotEnd *SnapshotEnd, elSnapshotHeader *types.Header, intervalsPassed uint64, state *state.NetworkState) *treeGeneratorImpl_v9_v10 {
 	return &treeGeneratorImpl_v9_v10{
@@ -94,22 +172,96 @@ func newTreeGeneratorImpl_v9_v10(rulesetVersion uint64, log *log.ColorLogger, lo
 		totalAttestationScore: big.NewInt(0),
 		networkState:          state,
 		invalidNetworkNodes:   map[common.Address]uint64{},
-		minipoolPerformanceFile: &MinipoolPerformanceFile_v2{
-			Index:               index,
-			MinipoolPerformance: map[common.Address]*SmoothingPoolMinipoolPerformance_v2{},
+		minipoolPerformanceFile: &MinipoolPerformanceFile_v3{
+			Index:                      index,
+			MinipoolPerformanceVersion: minipoolPerformanceVersionThree,
+			MinipoolPerformance:        map[common.Address]*SmoothingPoolMinipoolPerformance_v3{},
+		},
+		validatorEffectiveness: &ValidatorEffectivenessFile{
+			Index:              index,
+			HasInclusionDelays: true,
+			Validators:         []*ValidatorEffectiveness{},
 		},
 		nodeRewards:         map[common.Address]*ssz_types.NodeReward{},
 		networkRewards:      map[ssz_types.Layer]*ssz_types.NetworkReward{},
 		minipoolWithdrawals: map[common.Address]*big.Int{},
+		epochWorkers:        config.TreegenEpochWorkersDefault,
+		nodeWorkers:         config.TreegenNodeWorkersDefault,
+		accountingPolicy:    AccountingPolicy_Legacy,
+		dustAccounting:      ssz_types.NewDustAccounting(),
+	}
+}
+
+// Set the number of epochs that can be fetched from the Beacon Node in parallel
+func (r *treeGeneratorImpl_v9_v10) setEpochWorkers(workers uint64) {
+	if workers == 0 {
+		workers = 1
+	}
+	r.epochWorkers = workers
+}
+
+// Set the number of nodes whose rewards can be calculated in parallel
+func (r *treeGeneratorImpl_v9_v10) setNodeWorkers(workers uint64) {
+	if workers == 0 {
+		workers = 1
+	}
+	r.nodeWorkers = workers
+}
+
+// Run a calculation for every node with a pool of node workers. Each call may only modify its own node's details;
+// anything that combines nodes has to be done afterwards, in node order, so the results don't depend on scheduling.
+func (r *treeGeneratorImpl_v9_v10) forEachNode(calculate func(i int, nsd *NodeSmoothingDetails) error) error {
+	var wg errgroup.Group
+	wg.SetLimit(int(r.nodeWorkers))
+	for i, nsd := range r.nodeDetails {
+		i, nsd := i, nsd
+		wg.Go(func() error {
+			return calculate(i, nsd)
+		})
+	}
+	return wg.Wait()
+}
+
+// Set the memory (in MB) available to the generator, which decides whether it uses its bounded-memory algorithms; 0 means no limit
+func (r *treeGeneratorImpl_v9_v10) setMemoryBudget(budget uint64) {
+	r.memoryBudget = budget
+}
+
+// Check if the memory budget is low enough to use the bounded-memory algorithms
+func (r *treeGeneratorImpl_v9_v10) isMemoryBounded() bool {
+	return r.memoryBudget > 0 && r.memoryBudget < boundedMemoryBudgetThreshold
+}
+
+// Set how the generator accounts for the dust lost to integer division
+func (r *treeGeneratorImpl_v9_v10) setAccountingPolicy(policy AccountingPolicy) {
+	r.accountingPolicy = policy
+}
+
+// Set whether the generator should explain how each node's rewards were calculated
+func (r *treeGeneratorImpl_v9_v10) setExplanations(enabled bool) {
+	r.explain = enabled
+}
+
+// Set the alternative attestation scorers to report alongside the canonical scores; none disables research scoring
+func (r *treeGeneratorImpl_v9_v10) setResearchScorers(scorers []AttestationScorer) {
+	if len(scorers) == 0 {
+		r.researchScores = nil
+		return
 	}
+	r.researchScores = newResearchScoreTracker(scorers)
+}
+
+// Set the tracker used to report progress while processing the interval's epochs
+func (r *treeGeneratorImpl_v9_v10) setProgressTracker(tracker *progressTracker) {
+	r.progressTracker = tracker
 }
 
 // Get the version of the ruleset used by this generator
-func (r *treeGeneratorImpl_v9_v10) getRulesetVersion() uint64 {
+func (r *treeGeneratorImpl_v9_v10) GetRulesetVersion() uint64 {
 	return r.rewardsFile.RulesetVersion
 }
 
-func (r *treeGeneratorImpl_v9_v10) generateTree(rp RewardsExecutionClient, networkName string, previousRewardsPoolAddresses []common.Address, bc RewardsBeaconClient) (*GenerateTreeResult, error) {
+func (r *treeGeneratorImpl_v9_v10) GenerateTree(rp RewardsExecutionClient, networkName string, previousRewardsPoolAddresses []common.Address, bc RewardsBeaconClient) (*GenerateTreeResult, error) {
 
 	r.log.Printlnf("%s Generating tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
 
@@ -124,6 +276,7 @@ func (r *treeGeneratorImpl_v9_v10) generateTree(rp RewardsExecutionClient, netwo
 	// Set the network name
 	r.rewardsFile.Network, _ = ssz_types.NetworkFromString(networkName)
 	r.minipoolPerformanceFile.Network = networkName
+	r.validatorEffectiveness.Network = networkName
 	r.minipoolPerformanceFile.RewardsFileVersion = r.rewardsFile.RewardsFileVersion
 	r.minipoolPerformanceFile.RulesetVersion = r.rewardsFile.RulesetVersion
 
@@ -184,17 +337,35 @@ func (r *treeGeneratorImpl_v9_v10) generateTree(rp RewardsExecutionClient, netwo
 		})
 	}
 
+	// Explain each node's rewards if requested
+	var explanations *RewardsExplanationFile
+	if r.explain {
+		explanations = r.explainNodeRewards(networkName)
+	}
+	r.validatorEffectiveness.sort()
+
+	// Report the research scores if requested
+	var researchScores *ResearchScoresFile
+	if r.researchScores != nil {
+		researchScores = r.researchScores.getFile(r.rewardsFile.Index, r.rewardsFile.RulesetVersion, networkName)
+	}
+
 	return &GenerateTreeResult{
 		RewardsFile:             r.rewardsFile,
 		InvalidNetworkNodes:     r.invalidNetworkNodes,
 		MinipoolPerformanceFile: r.minipoolPerformanceFile,
+		MinipoolNodes:           getMinipoolNodes(r.networkState),
+		Explanations:            explanations,
+		ValidatorEffectiveness:  r.validatorEffectiveness,
+		ResearchScores:          researchScores,
+		DustAccounting:          r.dustAccounting,
 	}, nil
 
 }
 
 // Quickly calculates an approximate of the staker's share of the smoothing pool balance without processing Beacon performance
 // Used for approximate returns in the rETH ratio update
-func (r *treeGeneratorImpl_v9_v10) approximateStakerShareOfSmoothingPool(rp RewardsExecutionClient, networkName string, bc RewardsBeaconClient) (*big.Int, error) {
+func (r *treeGeneratorImpl_v9_v10) ApproximateStakerShareOfSmoothingPool(rp RewardsExecutionClient, networkName string, bc RewardsBeaconClient) (*big.Int, error) {
 	r.log.Printlnf("%s Approximating tree using Ruleset v%d.", r.logPrefix, r.rewardsFile.RulesetVersion)
 
 	r.rp = rp
@@ -269,6 +440,7 @@ func (r *treeGeneratorImpl_v9_v10) calculateRplRewards() error {
 	pDaoRewards := big.NewInt(0)
 	pDaoRewards.Mul(pendingRewards, pDaoPercent)
 	pDaoRewards.Div(pDaoRewards, oneEth)
+	expectedPDaoRewards := big.NewInt(0).Set(pDaoRewards)
 	r.log.Printlnf("%s Expected Protocol DAO rewards: %s (%.3f)", r.logPrefix, pDaoRewards.String(), eth.WeiToEth(pDaoRewards))
 
 	// Get node operator rewards
@@ -283,20 +455,33 @@ func (r *treeGeneratorImpl_v9_v10) calculateRplRewards() error {
 	if err != nil {
 		return fmt.Errorf("error calculating node weights: %w", err)
 	}
+	r.nodeWeights = nodeWeights
+	r.expectedCollateralRpl = totalNodeRewards
 
 	// Operate normally if any node has rewards
 	if totalNodeWeight.Sign() > 0 {
 		// Make sure to record totalNodeWeight in the rewards file
 		r.rewardsFile.TotalRewards.TotalNodeWeight.Set(totalNodeWeight)
 
+		// Strict accounting hands out the truncated dust too, so the nodes' shares add up to exactly the collateral rewards
+		var strictShares map[common.Address]*big.Int
+		if r.accountingPolicy == AccountingPolicy_Strict {
+			strictShares = distributeLargestRemainder(totalNodeRewards, nodeWeights, totalNodeWeight)
+		}
+
 		r.log.Printlnf("%s Calculating individual collateral rewards...", r.logPrefix)
 		for i, nodeDetails := range r.networkState.NodeDetails {
 			// Get how much RPL goes to this node
-			nodeRplRewards := r.calculateNodeRplRewards(
-				totalNodeRewards,
-				nodeWeights[nodeDetails.NodeAddress],
-				totalNodeWeight,
-			)
+			var nodeRplRewards *big.Int
+			if strictShares != nil {
+				nodeRplRewards = strictShares[nodeDetails.NodeAddress]
+			} else {
+				nodeRplRewards = r.calculateNodeRplRewards(
+					totalNodeRewards,
+					nodeWeights[nodeDetails.NodeAddress],
+					totalNodeWeight,
+				)
+			}
 
 			// If there are pending rewards, add it to the map
 			if nodeRplRewards.Sign() == 1 {
@@ -337,10 +522,11 @@ func (r *treeGeneratorImpl_v9_v10) calculateRplRewards() error {
 			totalCalculatedNodeRewards.Add(totalCalculatedNodeRewards, networkRewards.CollateralRpl.Int)
 		}
 		delta.Sub(totalNodeRewards, totalCalculatedNodeRewards).Abs(delta)
-		if delta.Cmp(r.epsilon) == 1 {
+		if delta.Cmp(r.accountingPolicy.tolerance(r.epsilon)) == 1 {
 			return fmt.Errorf("error calculating collateral RPL: total was %s, but expected %s; error was too large", totalCalculatedNodeRewards.String(), totalNodeRewards.String())
 		}
 		r.rewardsFile.TotalRewards.TotalCollateralRpl.Int.Set(totalCalculatedNodeRewards)
+		r.dustAccounting.CollateralRpl.Sub(totalNodeRewards, totalCalculatedNodeRewards)
 		r.log.Printlnf("%s Calculated rewards:           %s (error = %s wei)", r.logPrefix, totalCalculatedNodeRewards.String(), delta.String())
 		pDaoRewards.Sub(pendingRewards, totalCalculatedNodeRewards)
 	} else {
@@ -380,13 +566,27 @@ func (r *treeGeneratorImpl_v9_v10) calculateRplRewards() error {
 		totalODaoNodeTime.Add(totalODaoNodeTime, participationTime)
 	}
 
+	r.expectedOracleDaoRpl = totalODaoRewards
+	r.oDaoNodeTimes = trueODaoNodeTimes
+	r.totalODaoNodeTime = totalODaoNodeTime
+
+	// Strict accounting hands out the truncated dust too, so the members' shares add up to exactly the oDAO rewards
+	var strictODaoShares map[common.Address]*big.Int
+	if r.accountingPolicy == AccountingPolicy_Strict {
+		strictODaoShares = distributeLargestRemainder(totalODaoRewards, trueODaoNodeTimes, totalODaoNodeTime)
+	}
+
 	for _, details := range oDaoDetails {
 		address := details.Address
 
 		// Calculate the oDAO rewards for the node: (participation time) * (total oDAO rewards) / (total participation time)
 		individualOdaoRewards := big.NewInt(0)
-		individualOdaoRewards.Mul(trueODaoNodeTimes[address], totalODaoRewards)
-		individualOdaoRewards.Div(individualOdaoRewards, totalODaoNodeTime)
+		if strictODaoShares != nil {
+			individualOdaoRewards.Set(strictODaoShares[address])
+		} else {
+			individualOdaoRewards.Mul(trueODaoNodeTimes[address], totalODaoRewards)
+			individualOdaoRewards.Div(individualOdaoRewards, totalODaoNodeTime)
+		}
 
 		rewardsForNode, exists := r.nodeRewards[address]
 		if !exists {
@@ -426,10 +626,11 @@ func (r *treeGeneratorImpl_v9_v10) calculateRplRewards() error {
 		totalCalculatedOdaoRewards.Add(totalCalculatedOdaoRewards, networkRewards.OracleDaoRpl.Int)
 	}
 	delta.Sub(totalODaoRewards, totalCalculatedOdaoRewards).Abs(delta)
-	if delta.Cmp(r.epsilon) == 1 {
+	if delta.Cmp(r.accountingPolicy.tolerance(r.epsilon)) == 1 {
 		return fmt.Errorf("error calculating ODao RPL: total was %s, but expected %s; error was too large", totalCalculatedOdaoRewards.String(), totalODaoRewards.String())
 	}
 	r.rewardsFile.TotalRewards.TotalOracleDaoRpl.Int.Set(totalCalculatedOdaoRewards)
+	r.dustAccounting.OracleDaoRpl.Sub(totalODaoRewards, totalCalculatedOdaoRewards)
 	r.log.Printlnf("%s Calculated rewards:           %s (error = %s wei)", r.logPrefix, totalCalculatedOdaoRewards.String(), delta.String())
 
 	// Get actual protocol DAO rewards
@@ -438,6 +639,13 @@ func (r *treeGeneratorImpl_v9_v10) calculateRplRewards() error {
 	r.rewardsFile.TotalRewards.ProtocolDaoRpl.Set(pDaoRewards)
 	r.log.Printlnf("%s Actual Protocol DAO rewards:  %s to account for truncation", r.logPrefix, pDaoRewards.String())
 
+	// Record the dust from splitting the pending rewards by percentage, which is whatever none of the three categories got
+	dust := r.dustAccounting
+	dust.PercentageRpl.Sub(pendingRewards, expectedPDaoRewards)
+	dust.PercentageRpl.Sub(dust.PercentageRpl.Int, totalNodeRewards)
+	dust.PercentageRpl.Sub(dust.PercentageRpl.Int, totalODaoRewards)
+	r.log.Printlnf("%s Dust sent to the pDAO:        %s (%s from percentages, %s from collateral, %s from oDAO)", r.logPrefix, dust.ProtocolDaoRpl().String(), dust.PercentageRpl.String(), dust.CollateralRpl.String(), dust.OracleDaoRpl.String())
+
 	// Print total node weight
 	r.log.Printlnf("%s Total Node Weight:            %s", r.logPrefix, totalNodeWeight)
 
@@ -568,18 +776,20 @@ func (r *treeGeneratorImpl_v9_v10) calculateEthRewards(checkBeaconPerformance bo
 
 			// Add minipool rewards to the JSON
 			for _, minipoolInfo := range nodeInfo.Minipools {
-				successfulAttestations := uint64(len(minipoolInfo.CompletedAttestations))
+				successfulAttestations := getCompletedAttestationCount(minipoolInfo)
 				missingAttestations := uint64(len(minipoolInfo.MissingAttestationSlots))
-				performance := &SmoothingPoolMinipoolPerformance_v2{
-					Pubkey:                  minipoolInfo.ValidatorPubkey.Hex(),
-					SuccessfulAttestations:  successfulAttestations,
-					MissedAttestations:      missingAttestations,
-					AttestationScore:        minipoolInfo.AttestationScore,
-					EthEarned:               QuotedBigIntFromBigInt(minipoolInfo.MinipoolShare),
-					BonusEthEarned:          QuotedBigIntFromBigInt(minipoolInfo.MinipoolBonus),
-					ConsensusIncome:         minipoolInfo.ConsensusIncome,
-					EffectiveCommission:     QuotedBigIntFromBigInt(minipoolInfo.TotalFee),
-					MissingAttestationSlots: []uint64{},
+				performance := &SmoothingPoolMinipoolPerformance_v3{
+					SmoothingPoolMinipoolPerformance_v2: SmoothingPoolMinipoolPerformance_v2{
+						Pubkey:                  minipoolInfo.ValidatorPubkey.Hex(),
+						SuccessfulAttestations:  successfulAttestations,
+						MissedAttestations:      missingAttestations,
+						AttestationScore:        minipoolInfo.AttestationScore,
+						EthEarned:               QuotedBigIntFromBigInt(minipoolInfo.MinipoolShare),
+						BonusEthEarned:          QuotedBigIntFromBigInt(minipoolInfo.MinipoolBonus),
+						ConsensusIncome:         minipoolInfo.ConsensusIncome,
+						EffectiveCommission:     QuotedBigIntFromBigInt(minipoolInfo.TotalFee),
+						MissingAttestationSlots: []uint64{},
+					},
 				}
 				if successfulAttestations+missingAttestations == 0 {
 					// Don't include minipools that have zero attestations
@@ -588,7 +798,10 @@ func (r *treeGeneratorImpl_v9_v10) calculateEthRewards(checkBeaconPerformance bo
 				for slot := range minipoolInfo.MissingAttestationSlots {
 					performance.MissingAttestationSlots = append(performance.MissingAttestationSlots, slot)
 				}
+				effectiveness := NewValidatorEffectiveness(minipoolInfo.Address, nodeInfo.Address, performance.Pubkey, successfulAttestations, missingAttestations, minipoolInfo.InclusionDelays)
+				performance.AverageInclusionDelay = effectiveness.AverageInclusionDelay
 				r.minipoolPerformanceFile.MinipoolPerformance[minipoolInfo.Address] = performance
+				r.validatorEffectiveness.Validators = append(r.validatorEffectiveness.Validators, effectiveness)
 			}
 
 			// Add the rewards to the running total for the specified network
@@ -615,16 +828,17 @@ var fourteenPercentEth = big.NewInt(14e16)
 var thirtyTwoEth = big.NewInt(0).Mul(oneEth, big.NewInt(32))
 
 func (r *treeGeneratorImpl_v9_v10) calculateNodeBonuses() (*big.Int, error) {
-	totalConsensusBonus := big.NewInt(0)
-	for _, nsd := range r.nodeDetails {
+	nodeBonuses := make([]*big.Int, len(r.nodeDetails))
+	err := r.forEachNode(func(i int, nsd *NodeSmoothingDetails) error {
+		nodeBonuses[i] = big.NewInt(0)
 		if !nsd.IsEligible {
-			continue
+			return nil
 		}
 
 		nodeDetails := r.networkState.NodeDetailsByAddress[nsd.Address]
 		eligible, _, eligibleEnd := nodeDetails.IsEligibleForBonuses(r.elStartTime, r.elEndTime)
 		if !eligible {
-			continue
+			return nil
 		}
 
 		// Get the nodeDetails from the network state
@@ -652,7 +866,7 @@ func (r *treeGeneratorImpl_v9_v10) calculateNodeBonuses() (*big.Int, error) {
 			if fee.Cmp(fourteenPercentEth) > 0 {
 				r.log.Printlnf("WARNING: Minipool %s has a fee of %s, which is greater than the maximum allowed of 14%", mpd.Address.Hex(), fee.String())
 				r.log.Printlnf("WARNING: Aborting.")
-				return nil, fmt.Errorf("minipool %s has a fee of %s, which is greater than the maximum allowed of 14%%", mpd.Address.Hex(), fee.String())
+				return fmt.Errorf("minipool %s has a fee of %s, which is greater than the maximum allowed of 14%%", mpd.Address.Hex(), fee.String())
 			}
 			bonusFee := big.NewInt(0).Set(fee)
 			bonusFee.Sub(bonusFee, mpi.NodeFee)
@@ -670,9 +884,18 @@ func (r *treeGeneratorImpl_v9_v10) calculateNodeBonuses() (*big.Int, error) {
 				minipoolBonus = big.NewInt(0)
 			}
 			mpd.MinipoolBonus = minipoolBonus
-			totalConsensusBonus.Add(totalConsensusBonus, minipoolBonus)
+			nodeBonuses[i].Add(nodeBonuses[i], minipoolBonus)
 			nsd.BonusEth.Add(nsd.BonusEth, minipoolBonus)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalConsensusBonus := big.NewInt(0)
+	for _, bonus := range nodeBonuses {
+		totalConsensusBonus.Add(totalConsensusBonus, bonus)
 	}
 	return totalConsensusBonus, nil
 }
@@ -703,26 +926,56 @@ func (r *treeGeneratorImpl_v9_v10) calculateNodeRewards() (*big.Int, *big.Int, *
 	totalNodeOpShare.Mul(r.smoothingPoolBalance, r.totalAttestationScore)
 	totalNodeOpShare.Div(totalNodeOpShare, big.NewInt(int64(r.successfulAttestations)))
 	totalNodeOpShare.Div(totalNodeOpShare, oneEth)
+	r.totalNodeOpShare = totalNodeOpShare
 
-	for _, nodeInfo := range r.nodeDetails {
+	// Strict accounting hands out the truncated dust too, so the minipools' shares add up to exactly the node operator share
+	var strictShares map[common.Address]*big.Int
+	if r.accountingPolicy == AccountingPolicy_Strict {
+		attestationScores := map[common.Address]*big.Int{}
+		for _, nodeInfo := range r.nodeDetails {
+			if !nodeInfo.IsEligible {
+				continue
+			}
+			for _, minipool := range nodeInfo.Minipools {
+				if getCompletedAttestationCount(minipool)+uint64(len(minipool.MissingAttestationSlots)) == 0 || !minipool.WasActive {
+					continue
+				}
+				attestationScores[minipool.Address] = &minipool.AttestationScore.Int
+			}
+		}
+		strictShares = distributeLargestRemainder(totalNodeOpShare, attestationScores, r.totalAttestationScore)
+	}
+
+	err = r.forEachNode(func(i int, nodeInfo *NodeSmoothingDetails) error {
 		nodeInfo.SmoothingPoolEth = big.NewInt(0)
 		if !nodeInfo.IsEligible {
-			continue
+			return nil
 		}
 		for _, minipool := range nodeInfo.Minipools {
-			if len(minipool.CompletedAttestations)+len(minipool.MissingAttestationSlots) == 0 || !minipool.WasActive {
+			if getCompletedAttestationCount(minipool)+uint64(len(minipool.MissingAttestationSlots)) == 0 || !minipool.WasActive {
 				// Ignore minipools that weren't active for the interval
 				minipool.WasActive = false
 				minipool.MinipoolShare = big.NewInt(0)
 				continue
 			}
 
-			minipoolEth := big.NewInt(0).Set(totalNodeOpShare)
-			minipoolEth.Mul(minipoolEth, &minipool.AttestationScore.Int)
-			minipoolEth.Div(minipoolEth, r.totalAttestationScore)
+			var minipoolEth *big.Int
+			if strictShares != nil {
+				minipoolEth = strictShares[minipool.Address]
+			} else {
+				minipoolEth = big.NewInt(0).Set(totalNodeOpShare)
+				minipoolEth.Mul(minipoolEth, &minipool.AttestationScore.Int)
+				minipoolEth.Div(minipoolEth, r.totalAttestationScore)
+			}
 			minipool.MinipoolShare = minipoolEth
 			nodeInfo.SmoothingPoolEth.Add(nodeInfo.SmoothingPoolEth, minipoolEth)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for _, nodeInfo := range r.nodeDetails {
 		totalEthForMinipools.Add(totalEthForMinipools, nodeInfo.SmoothingPoolEth)
 	}
 
@@ -751,9 +1004,10 @@ func (r *treeGeneratorImpl_v9_v10) calculateNodeRewards() (*big.Int, *big.Int, *
 	// Sanity check the totalNodeOpShare before bonuses are awarded
 	delta := big.NewInt(0).Sub(totalEthForMinipools, totalNodeOpShare)
 	delta.Abs(delta)
-	if delta.Cmp(r.epsilon) == 1 {
+	if delta.Cmp(r.accountingPolicy.tolerance(r.epsilon)) == 1 {
 		return nil, nil, nil, fmt.Errorf("error calculating smoothing pool ETH: total was %s, but expected %s; error was too large (%s wei)", totalEthForMinipools.String(), totalNodeOpShare.String(), delta.String())
 	}
+	r.dustAccounting.SmoothingPoolEth.Sub(totalNodeOpShare, totalEthForMinipools)
 
 	// Finally, award the bonuses
 	if r.rewardsFile.RulesetVersion >= 10 {
@@ -796,19 +1050,77 @@ func (r *treeGeneratorImpl_v9_v10) processAttestationsBalancesAndWithdrawalsForI
 	r.log.Printlnf("%s Checking participation of %d minipools for epochs %d to %d", r.logPrefix, len(r.validatorIndexMap), startEpoch, endEpoch)
 	r.log.Printlnf("%s NOTE: this will take a long time, progress is reported every 100 epochs", r.logPrefix)
 
+	// A low memory budget trades speed for memory: epochs are fetched one at a time, and duties that can no longer be
+	// included are moved to disk instead of being held until the end of the interval
+	epochWorkers := r.epochWorkers
+	if r.isMemoryBounded() {
+		r.log.Printlnf("%s Memory budget is %d MB, so bounded-memory processing will be used", r.logPrefix, r.memoryBudget)
+		epochWorkers = 1
+		r.missedDuties, err = newMissedDutyStore()
+		if err != nil {
+			return err
+		}
+		defer func() {
+			r.missedDuties.close()
+			r.missedDuties = nil
+		}()
+	}
+
 	epochsDone := 0
 	reportStartTime := time.Now()
-	for epoch := startEpoch; epoch < endEpoch+1; epoch++ {
+	if r.progressTracker != nil {
+		r.progressTracker.start(startEpoch, endEpoch)
+	}
+
+	// Fetch upcoming epochs in the background while the current one is processed; the bounded
+	// queue keeps at most epochWorkers epochs in flight and guarantees they're committed in order
+	done := make(chan struct{})
+	queue := make(chan chan epochFetchResult, epochWorkers)
+	go func() {
+		defer close(queue)
+		for epoch := startEpoch; epoch < endEpoch+1; epoch++ {
+			resultChan := make(chan epochFetchResult, 1)
+			select {
+			case queue <- resultChan:
+			case <-done:
+				return
+			}
+			go func(epoch uint64) {
+				data, err := r.fetchEpoch(true, epoch)
+				resultChan <- epochFetchResult{data: data, err: err}
+			}(epoch)
+		}
+	}()
+	defer func() {
+		// Stop the producer and return the memory of any epochs that were fetched but not processed
+		close(done)
+		for resultChan := range queue {
+			result := <-resultChan
+			if result.data != nil {
+				result.data.release()
+			}
+		}
+	}()
+
+	for resultChan := range queue {
+		result := <-resultChan
+		if result.err != nil {
+			return result.err
+		}
+		epoch := result.data.epoch
 		if epochsDone == 100 {
 			timeTaken := time.Since(reportStartTime)
 			r.log.Printlnf("%s On Epoch %d of %d (%.2f%%)... (%s so far)", r.logPrefix, epoch, endEpoch, float64(epoch-startEpoch)/float64(endEpoch-startEpoch)*100.0, timeTaken)
 			epochsDone = 0
 		}
 
-		err := r.processEpoch(true, epoch)
+		err := r.commitEpoch(result.data)
 		if err != nil {
 			return err
 		}
+		if r.progressTracker != nil {
+			r.progressTracker.update(epoch)
+		}
 
 		epochsDone++
 	}
@@ -820,13 +1132,61 @@ func (r *treeGeneratorImpl_v9_v10) processAttestationsBalancesAndWithdrawalsForI
 		return err
 	}
 
+	// Bring the duties that were moved to disk back so the misses can be reported
+	if r.missedDuties != nil {
+		minipools := make(map[common.Address]*MinipoolInfo, len(r.validatorIndexMap))
+		for _, minipoolInfo := range r.validatorIndexMap {
+			minipools[minipoolInfo.Address] = minipoolInfo
+		}
+		err = r.missedDuties.restore(minipools)
+		if err != nil {
+			return err
+		}
+		r.log.Printlnf("%s Restored %d missed duties from disk", r.logPrefix, r.missedDuties.count)
+	}
+
+	if r.progressTracker != nil {
+		r.progressTracker.finish()
+	}
 	r.log.Printlnf("%s Finished participation check (total time = %s)", r.logPrefix, time.Since(reportStartTime))
 	return nil
 
 }
 
+// The committees and attestations of an epoch that has been fetched but not processed yet
+type epochData struct {
+	epoch               uint64
+	duringInterval      bool
+	committeeData       beacon.Committees
+	attestationsPerSlot [][]beacon.AttestationInfo
+}
+
+// Return the epoch's preallocated committee memory to the pool
+func (d *epochData) release() {
+	if d.committeeData != nil {
+		d.committeeData.Release()
+		d.committeeData = nil
+	}
+}
+
+// The result of fetching an epoch in the background
+type epochFetchResult struct {
+	data *epochData
+	err  error
+}
+
 // Process an epoch, optionally getting the duties for all eligible minipools in it and checking each one's attestation performance
 func (r *treeGeneratorImpl_v9_v10) processEpoch(duringInterval bool, epoch uint64) error {
+	data, err := r.fetchEpoch(duringInterval, epoch)
+	if err != nil {
+		return err
+	}
+	return r.commitEpoch(data)
+}
+
+// Get the committee info and attestation records for an epoch, and add up the withdrawals in it.
+// This is safe to call for multiple epochs concurrently.
+func (r *treeGeneratorImpl_v9_v10) fetchEpoch(duringInterval bool, epoch uint64) (*epochData, error) {
 
 	// Get the committee info and attestation records for this epoch
 	var committeeData beacon.Committees
@@ -841,7 +1201,6 @@ func (r *treeGeneratorImpl_v9_v10) processEpoch(duringInterval bool, epoch uint6
 		})
 	}
 
-	withdrawalsLock := &sync.Mutex{}
 	for i := uint64(0); i < r.slotsPerEpoch; i++ {
 		// Get the beacon block for this slot
 		i := i
@@ -894,47 +1253,88 @@ func (r *treeGeneratorImpl_v9_v10) processEpoch(duringInterval bool, epoch uint6
 				}
 
 				// Create the minipool's withdrawal sum big.Int if it doesn't exist
-				withdrawalsLock.Lock()
+				r.withdrawalsLock.Lock()
 				if r.minipoolWithdrawals[mpi.Address] == nil {
 					r.minipoolWithdrawals[mpi.Address] = big.NewInt(0)
 				}
 				// Add the withdrawal amount
 				r.minipoolWithdrawals[mpi.Address].Add(r.minipoolWithdrawals[mpi.Address], withdrawalAmount)
-				withdrawalsLock.Unlock()
+				r.withdrawalsLock.Unlock()
 			}
 			return nil
 		})
 	}
 	err := wg.Wait()
-	// Return preallocated memory to the pool if it exists
-	if committeeData != nil {
-		defer committeeData.Release()
-	}
 	if err != nil {
-		return fmt.Errorf("error getting committee and attestaion records for epoch %d: %w", epoch, err)
+		// Return preallocated memory to the pool if it exists
+		if committeeData != nil {
+			committeeData.Release()
+		}
+		return nil, fmt.Errorf("error getting committee and attestaion records for epoch %d: %w", epoch, err)
 	}
 
-	if duringInterval {
+	return &epochData{
+		epoch:               epoch,
+		duringInterval:      duringInterval,
+		committeeData:       committeeData,
+		attestationsPerSlot: attestationsPerSlot,
+	}, nil
+
+}
+
+// Get the duties for a fetched epoch and check its attestations. Epochs must be committed in order.
+func (r *treeGeneratorImpl_v9_v10) commitEpoch(data *epochData) error {
+	defer data.release()
+
+	if data.duringInterval {
 		// Get all of the expected duties for the epoch
-		err = r.getDutiesForEpoch(committeeData)
+		err := r.getDutiesForEpoch(data.committeeData)
 		if err != nil {
-			return fmt.Errorf("error getting duties for epoch %d: %w", epoch, err)
+			return fmt.Errorf("error getting duties for epoch %d: %w", data.epoch, err)
 		}
 	}
 
 	// Process all of the slots in the epoch
 	for i := uint64(0); i < r.slotsPerEpoch; i++ {
-		inclusionSlot := epoch*r.slotsPerEpoch + i
-		attestations := attestationsPerSlot[i]
+		inclusionSlot := data.epoch*r.slotsPerEpoch + i
+		attestations := data.attestationsPerSlot[i]
 		if len(attestations) > 0 {
 			r.checkAttestations(attestations, inclusionSlot)
 		}
 	}
 
+	// Attestations can't be included more than an epoch after their slot, so any duties before this epoch are final misses
+	if r.missedDuties != nil {
+		err := r.pruneExpiredDuties(data.epoch * r.slotsPerEpoch)
+		if err != nil {
+			return fmt.Errorf("error pruning duties before epoch %d: %w", data.epoch, err)
+		}
+	}
+
 	return nil
 
 }
 
+// Move the duties for slots before the provided one, which can no longer be attested to, from memory to the missed duty store
+func (r *treeGeneratorImpl_v9_v10) pruneExpiredDuties(beforeSlot uint64) error {
+	for slotIndex, slotInfo := range r.intervalDutiesInfo.Slots {
+		if slotIndex >= beforeSlot {
+			continue
+		}
+		for _, committee := range slotInfo.Committees {
+			for _, validator := range committee.Positions {
+				delete(validator.MissingAttestationSlots, slotIndex)
+				err := r.missedDuties.add(validator.Address, slotIndex)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		delete(r.intervalDutiesInfo.Slots, slotIndex)
+	}
+	return nil
+}
+
 func (r *treeGeneratorImpl_v9_v10) checkAttestations(attestations []beacon.AttestationInfo, inclusionSlot uint64) error {
 
 	// Go through the attestations for the block
@@ -981,7 +1381,14 @@ func (r *treeGeneratorImpl_v9_v10) checkAttestations(attestations []beacon.Attes
 			_, percentOfBorrowedEth := r.networkState.GetStakedRplValueInEthAndPercentOfBorrowedEth(eligibleBorrowedEth, nodeDetails.RplStake)
 
 			// Mark this duty as completed
-			validator.CompletedAttestations[attestation.SlotIndex] = true
+			if r.missedDuties != nil {
+				validator.GoodAttestations++
+			} else {
+				validator.CompletedAttestations[attestation.SlotIndex] = true
+			}
+			if validator.InclusionDelays != nil {
+				validator.InclusionDelays[inclusionSlot-attestation.SlotIndex]++
+			}
 
 			// Get the pseudoscore for this attestation
 			details := r.networkState.MinipoolDetailsByAddress[validator.Address]
@@ -1000,6 +1407,11 @@ func (r *treeGeneratorImpl_v9_v10) checkAttestations(attestations []beacon.Attes
 			validator.AttestationScore.Add(&validator.AttestationScore.Int, minipoolScore)
 			r.totalAttestationScore.Add(r.totalAttestationScore, minipoolScore)
 			r.successfulAttestations++
+
+			// Score it with the research scorers too; these don't affect the rewards
+			if r.researchScores != nil {
+				r.researchScores.add(nodeDetails.Address, minipoolScore, inclusionSlot-attestation.SlotIndex)
+			}
 		}
 	}
 
@@ -1195,6 +1607,7 @@ func (r *treeGeneratorImpl_v9_v10) getSmoothingPoolNodeDetails() error {
 							//GoodAttestations:        0,
 							MissingAttestationSlots: map[uint64]bool{},
 							CompletedAttestations:   map[uint64]bool{},
+							InclusionDelays:         map[uint64]uint64{},
 							WasActive:               true,
 							AttestationScore:        NewQuotedBigInt(0),
 							NodeOperatorBond:        nativeMinipoolDetails.NodeDepositBalance,
@@ -1308,6 +1721,91 @@ func (r *treeGeneratorImpl_v9_v10) getBlocksAndTimesForInterval(previousInterval
 	return startElHeader, nil
 }
 
-func (r *treeGeneratorImpl_v9_v10) saveFiles(smartnode *config.SmartnodeConfig, treeResult *GenerateTreeResult, nodeTrusted bool) (cid.Cid, map[string]cid.Cid, error) {
+func (r *treeGeneratorImpl_v9_v10) SaveFiles(smartnode *config.SmartnodeConfig, treeResult *GenerateTreeResult, nodeTrusted bool) (cid.Cid, map[string]cid.Cid, error) {
 	return saveRewardsArtifacts(smartnode, treeResult, nodeTrusted)
 }
+
+// Collect the inputs that went into each node's rewards
+func (r *treeGeneratorImpl_v9_v10) explainNodeRewards(networkName string) *RewardsExplanationFile {
+	file := &RewardsExplanationFile{
+		Index:                     r.rewardsFile.Index,
+		RulesetVersion:            r.rewardsFile.RulesetVersion,
+		Network:                   networkName,
+		TotalCollateralRpl:        explanationAmount(r.expectedCollateralRpl),
+		TotalNodeWeight:           explanationAmount(r.rewardsFile.TotalRewards.TotalNodeWeight.Int),
+		TotalOracleDaoRpl:         explanationAmount(r.expectedOracleDaoRpl),
+		TotalOracleDaoSeconds:     explanationAmount(r.totalODaoNodeTime),
+		SmoothingPoolBalance:      explanationAmount(r.smoothingPoolBalance),
+		TotalNodeOperatorShareEth: explanationAmount(r.totalNodeOpShare),
+		TotalAttestationScore:     explanationAmount(r.totalAttestationScore),
+		SuccessfulAttestations:    r.successfulAttestations,
+		BonusScalar:               r.minipoolPerformanceFile.BonusScalar,
+		Nodes:                     make(map[common.Address]*NodeRewardsExplanation, len(r.networkState.NodeDetails)),
+	}
+	smoothingDetails := make(map[common.Address]*NodeSmoothingDetails, len(r.nodeDetails))
+	for _, nodeInfo := range r.nodeDetails {
+		smoothingDetails[nodeInfo.Address] = nodeInfo
+	}
+
+	for _, nodeDetails := range r.networkState.NodeDetails {
+		address := nodeDetails.NodeAddress
+		explanation := &NodeRewardsExplanation{
+			RequestedNetwork: nodeDetails.RewardNetwork.Uint64(),
+			RewardsNetwork:   nodeDetails.RewardNetwork.Uint64(),
+			RplStake:         explanationAmount(nodeDetails.RplStake),
+			Weight:           explanationAmount(r.nodeWeights[address]),
+			CollateralRpl:    NewQuotedBigInt(0),
+			OracleDaoRpl:     NewQuotedBigInt(0),
+			SmoothingPoolEth: NewQuotedBigInt(0),
+		}
+		if participationTime, exists := r.oDaoNodeTimes[address]; exists {
+			explanation.OracleDaoSeconds = explanationAmount(participationTime)
+		}
+
+		// The rewards file has the final amounts and the network they were actually sent to
+		if nodeRewards, exists := r.nodeRewards[address]; exists {
+			explanation.RewardsNetwork = nodeRewards.Network
+			explanation.CollateralRpl.Set(nodeRewards.CollateralRpl.Int)
+			explanation.OracleDaoRpl.Set(nodeRewards.OracleDaoRpl.Int)
+			explanation.SmoothingPoolEth.Set(nodeRewards.SmoothingPoolEth.Int)
+		}
+
+		if nodeInfo, exists := smoothingDetails[address]; exists {
+			smoothingPool := &SmoothingPoolExplanation{
+				IsEligible:      nodeInfo.IsEligible,
+				IsOptedIn:       nodeInfo.IsOptedIn,
+				EligibleSeconds: explanationAmount(nodeInfo.EligibleSeconds),
+				Minipools:       []*MinipoolRewardsExplanation{},
+			}
+			if nodeInfo.BonusEth != nil {
+				smoothingPool.BonusEth = explanationAmount(nodeInfo.BonusEth)
+			}
+			for _, minipool := range nodeInfo.Minipools {
+				minipoolExplanation := &MinipoolRewardsExplanation{
+					Address:                minipool.Address,
+					Pubkey:                 minipool.ValidatorPubkey,
+					WasActive:              minipool.WasActive,
+					Fee:                    explanationAmount(minipool.Fee),
+					SuccessfulAttestations: getCompletedAttestationCount(minipool),
+					MissedAttestations:     uint64(len(minipool.MissingAttestationSlots)),
+					AttestationScore:       NewQuotedBigInt(0),
+					MinipoolShare:          explanationAmount(minipool.MinipoolShare),
+				}
+				if minipool.AttestationScore != nil {
+					minipoolExplanation.AttestationScore.Set(&minipool.AttestationScore.Int)
+				}
+				if minipool.ConsensusIncome != nil {
+					minipoolExplanation.ConsensusIncome = explanationAmount(&minipool.ConsensusIncome.Int)
+				}
+				if minipool.MinipoolBonus != nil {
+					minipoolExplanation.BonusEth = explanationAmount(minipool.MinipoolBonus)
+				}
+				smoothingPool.Minipools = append(smoothingPool.Minipools, minipoolExplanation)
+			}
+			explanation.SmoothingPool = smoothingPool
+		}
+
+		file.Nodes[address] = explanation
+	}
+	return file
+}
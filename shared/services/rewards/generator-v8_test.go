@@ -131,7 +131,7 @@ func TestV8Mainnet(tt *testing.T) {
 	// Set the minipool performance
 	t.SetMinipoolPerformance(canonicalPerformance, state)
 
-	artifacts, err := generator.generateTree(
+	artifacts, err := generator.GenerateTree(
 		t.rp,
 		"mainnet",
 		make([]common.Address, 0),
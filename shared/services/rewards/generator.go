@@ -3,7 +3,6 @@ package rewards
 import (
 	"fmt"
 	"math/big"
-	"slices"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -11,6 +10,7 @@ import (
 	"github.com/ipfs/go-cid"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rewards/ssz_types"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
@@ -59,53 +59,46 @@ const (
 )
 
 func GetMainnetRulesetVersion(interval uint64) uint64 {
-	if interval >= MainnetV10Interval {
-		return 10
-	}
-	if interval >= MainnetV9Interval {
-		return 9
-	}
-	return 8
+	return GetRulesetVersion(cfgtypes.Network_Mainnet, interval)
 }
 
 func GetHoleskyRulesetVersion(interval uint64) uint64 {
-	if interval >= HoleskyV10Interval {
-		return 10
-	}
-	if interval >= HoleskyV9Interval {
-		return 9
-	}
-	return 8
+	return GetRulesetVersion(cfgtypes.Network_Holesky, interval)
 }
 
+// Get the version of the registered ruleset that applies to an interval on a network
 func GetRulesetVersion(network cfgtypes.Network, interval uint64) uint64 {
-	switch network {
-	case cfgtypes.Network_Mainnet:
-		return GetMainnetRulesetVersion(interval)
-	case cfgtypes.Network_Holesky:
-		return GetHoleskyRulesetVersion(interval)
-	case cfgtypes.Network_Devnet:
-		return 10
-	default:
-		return 10
+	if ruleset, exists := GetActiveRuleset(network, interval); exists {
+		return ruleset.Version
+	}
+
+	// Intervals before a network's first ruleset use the oldest one it has, and networks without any use the newest
+	rulesets := GetRulesets()
+	for i := len(rulesets) - 1; i >= 0; i-- {
+		if _, exists := rulesets[i].GetStartInterval(network); exists {
+			return rulesets[i].Version
+		}
 	}
+	return rulesets[0].Version
 }
 
 type TreeGenerator struct {
-	rewardsIntervalInfos map[uint64]rewardsIntervalInfo
-	logger               *log.ColorLogger
-	logPrefix            string
-	rp                   RewardsExecutionClient
-	cfg                  *config.RocketPoolConfig
-	bc                   beacon.Client
-	index                uint64
-	startTime            time.Time
-	endTime              time.Time
-	snapshotEnd          *SnapshotEnd
-	elSnapshotHeader     *types.Header
-	intervalsPassed      uint64
-	generatorImpl        treeGeneratorImpl
-	approximatorImpl     treeGeneratorImpl
+	generators       map[uint64]RulesetGenerator
+	generatorParams  *RulesetGeneratorParams
+	memoryBudget     *uint64
+	logger           *log.ColorLogger
+	logPrefix        string
+	rp               RewardsExecutionClient
+	cfg              *config.RocketPoolConfig
+	bc               beacon.Client
+	index            uint64
+	startTime        time.Time
+	endTime          time.Time
+	snapshotEnd      *SnapshotEnd
+	elSnapshotHeader *types.Header
+	intervalsPassed  uint64
+	generatorImpl    RulesetGenerator
+	approximatorImpl RulesetGenerator
 }
 
 type SnapshotEnd struct {
@@ -117,12 +110,13 @@ type SnapshotEnd struct {
 	ExecutionBlock uint64
 }
 
-type treeGeneratorImpl interface {
-	generateTree(rp RewardsExecutionClient, networkName string, previousRewardsPoolAddresses []common.Address, bc RewardsBeaconClient) (*GenerateTreeResult, error)
-	approximateStakerShareOfSmoothingPool(rp RewardsExecutionClient, networkName string, bc RewardsBeaconClient) (*big.Int, error)
-	getRulesetVersion() uint64
+// The tree generator of a single ruleset, created by its Ruleset.NewGenerator
+type RulesetGenerator interface {
+	GenerateTree(rp RewardsExecutionClient, networkName string, previousRewardsPoolAddresses []common.Address, bc RewardsBeaconClient) (*GenerateTreeResult, error)
+	ApproximateStakerShareOfSmoothingPool(rp RewardsExecutionClient, networkName string, bc RewardsBeaconClient) (*big.Int, error)
+	GetRulesetVersion() uint64
 	// Returns the primary artifact cid for consensus, all cids of all files in a map, and any potential errors
-	saveFiles(smartnode *config.SmartnodeConfig, treeResult *GenerateTreeResult, nodeTrusted bool) (cid.Cid, map[string]cid.Cid, error)
+	SaveFiles(smartnode *config.SmartnodeConfig, treeResult *GenerateTreeResult, nodeTrusted bool) (cid.Cid, map[string]cid.Cid, error)
 }
 
 func NewTreeGenerator(logger *log.ColorLogger, logPrefix string, rp RewardsExecutionClient, cfg *config.RocketPoolConfig, bc beacon.Client, index uint64, startTime time.Time, endTime time.Time, snapshotEnd *SnapshotEnd, elSnapshotHeader *types.Header, intervalsPassed uint64, state *state.NetworkState) (*TreeGenerator, error) {
@@ -140,78 +134,51 @@ func NewTreeGenerator(logger *log.ColorLogger, logPrefix string, rp RewardsExecu
 		intervalsPassed:  intervalsPassed,
 	}
 
-	// v10
-	v10_generator := newTreeGeneratorImpl_v9_v10(10, t.logger, t.logPrefix, t.index, t.snapshotEnd, t.elSnapshotHeader, t.intervalsPassed, state)
-
-	// v9
-	v9_generator := newTreeGeneratorImpl_v9_v10(9, t.logger, t.logPrefix, t.index, t.snapshotEnd, t.elSnapshotHeader, t.intervalsPassed, state)
-
-	// v8
-	v8_generator := newTreeGeneratorImpl_v8(t.logger, t.logPrefix, t.index, t.startTime, t.endTime, t.snapshotEnd.ConsensusBlock, t.elSnapshotHeader, t.intervalsPassed, state)
-
-	// Create the interval wrappers
-	rewardsIntervalInfos := []rewardsIntervalInfo{
-		{
-			rewardsRulesetVersion: 10,
-			mainnetStartInterval:  MainnetV10Interval,
-			holeskyStartInterval:  HoleskyV10Interval,
-			generator:             v10_generator,
-		},
-		{
-			rewardsRulesetVersion: 9,
-			mainnetStartInterval:  MainnetV9Interval,
-			holeskyStartInterval:  HoleskyV9Interval,
-			generator:             v9_generator,
-		},
-		{
-			rewardsRulesetVersion: 8,
-			mainnetStartInterval:  MainnetV8Interval,
-			holeskyStartInterval:  HoleskyV8Interval,
-			generator:             v8_generator,
-		},
-	}
-
-	// Create the map of versions to infos
-	t.rewardsIntervalInfos = map[uint64]rewardsIntervalInfo{}
-	for _, info := range rewardsIntervalInfos {
-		// Sanity check to make sure there aren't multiple infos with the same version
-		_, exists := t.rewardsIntervalInfos[info.rewardsRulesetVersion]
-		if exists {
-			return nil, fmt.Errorf("multiple ruleset interval infos with ruleset v%d", info.rewardsRulesetVersion)
-		}
-
-		t.rewardsIntervalInfos[info.rewardsRulesetVersion] = info
+	// Generators are created from these as their rulesets are needed
+	t.generatorParams = &RulesetGeneratorParams{
+		Logger:           t.logger,
+		LogPrefix:        t.logPrefix,
+		Config:           t.cfg,
+		Index:            t.index,
+		StartTime:        t.startTime,
+		EndTime:          t.endTime,
+		SnapshotEnd:      t.snapshotEnd,
+		ElSnapshotHeader: t.elSnapshotHeader,
+		IntervalsPassed:  t.intervalsPassed,
+		State:            state,
 	}
+	t.generators = map[uint64]RulesetGenerator{}
 
 	// Get the current network
 	network := t.cfg.Smartnode.Network.Value.(cfgtypes.Network)
 
 	// Determine which actual rulesets to use based on the current interval number, checking in descending order.
-	foundGenerator := false
-	foundApproximator := false
-
-	// Sort by version number, reversed. That way we will pick the highest version number whose startInterval
-	// is eligible
-	slices.SortFunc(rewardsIntervalInfos, func(a, b rewardsIntervalInfo) int {
-		// b - a sorts high to low
-		return int(b.rewardsRulesetVersion) - int(a.rewardsRulesetVersion)
-	})
-
 	// The first ruleset whose startInterval is at most t.index is the one to use
 	// for treegen, and for some reason, the first ruleset whose start interval is less than t.index
-	// is the one to use for approximations
-	for _, info := range rewardsIntervalInfos {
+	// is the one to use for approximations.
+	// Only those rulesets' generators are created, so settings that other rulesets reject don't get in the way.
+	foundGenerator := false
+	foundApproximator := false
+	for _, ruleset := range GetRulesets() {
 
-		startInterval, err := info.GetStartInterval(network)
-		if err != nil {
-			return nil, fmt.Errorf("error getting start interval for rewards period %d: %w", t.index, err)
+		startInterval, exists := ruleset.GetStartInterval(network)
+		if !exists {
+			continue
 		}
 		if !foundGenerator && startInterval <= t.index {
-			t.generatorImpl = info.generator
+			generator, err := t.getGenerator(ruleset.Version)
+			if err != nil {
+				return nil, err
+			}
+			t.generatorImpl = generator
 			foundGenerator = true
 		}
 		if !foundApproximator && startInterval < t.index {
-			t.approximatorImpl = info.generator
+			generator, err := t.getGenerator(ruleset.Version)
+			if err != nil {
+				return nil, err
+			}
+			t.approximatorImpl = generator
 			foundApproximator = true
 		}
 
@@ -228,35 +195,91 @@ func NewTreeGenerator(logger *log.ColorLogger, logPrefix string, rp RewardsExecu
 	return t, nil
 }
 
+// Get the generator for a registered ruleset, creating it the first time it's needed
+func (t *TreeGenerator) getGenerator(version uint64) (RulesetGenerator, error) {
+	if generator, exists := t.generators[version]; exists {
+		return generator, nil
+	}
+	ruleset, exists := rulesets[version]
+	if !exists {
+		return nil, fmt.Errorf("ruleset v%d does not exist", version)
+	}
+	generator, err := ruleset.NewGenerator(t.generatorParams)
+	if err != nil {
+		return nil, fmt.Errorf("error creating the ruleset v%d tree generator: %w", version, err)
+	}
+	if t.memoryBudget != nil {
+		if generator, ok := generator.(*treeGeneratorImpl_v9_v10); ok {
+			generator.setMemoryBudget(*t.memoryBudget)
+		}
+	}
+	t.generators[version] = generator
+	return generator, nil
+}
+
 type GenerateTreeResult struct {
 	RewardsFile             IRewardsFile
 	MinipoolPerformanceFile IMinipoolPerformanceFile
 	InvalidNetworkNodes     map[common.Address]uint64
+	MinipoolNodes           map[common.Address]common.Address
+
+	// The breakdown of the wei lost to integer division. It isn't part of the rewards file, so the published file stays
+	// the same; it's saved as a sidecar next to it.
+	DustAccounting *ssz_types.DustAccounting
+
+	// The inputs that went into each node's rewards, if explanations were requested
+	Explanations *RewardsExplanationFile
+
+	// The attestation effectiveness of each smoothing pool validator, if the ruleset records it
+	ValidatorEffectiveness *ValidatorEffectivenessFile
+
+	// Attestation scores under alternative research scorers, side by side with the canonical ones, if requested.
+	// These are non-canonical and have no bearing on the rewards.
+	ResearchScores *ResearchScoresFile
 }
 
 func (t *TreeGenerator) GenerateTree() (*GenerateTreeResult, error) {
-	return t.generatorImpl.generateTree(t.rp, fmt.Sprint(t.cfg.Smartnode.Network.Value), t.cfg.Smartnode.GetPreviousRewardsPoolAddresses(), t.bc)
+	return t.generatorImpl.GenerateTree(t.rp, fmt.Sprint(t.cfg.Smartnode.Network.Value), t.cfg.Smartnode.GetPreviousRewardsPoolAddresses(), t.bc)
+}
+
+// Report the progress of GenerateTree through the interval's epochs to the provided callback.
+// A zero deadline means the generation has no deadline. Only rulesets that process epochs report progress.
+func (t *TreeGenerator) SetProgressCallback(deadline time.Time, callback func(TreeGenerationProgress)) {
+	if generator, ok := t.generatorImpl.(*treeGeneratorImpl_v9_v10); ok {
+		generator.setProgressTracker(newProgressTracker(t.index, deadline, callback))
+	}
+}
+
+// Override the memory (in MB) available to tree generation, which decides whether it uses its bounded-memory algorithms; 0 means no limit.
+// Only rulesets that process epochs have bounded-memory algorithms.
+func (t *TreeGenerator) SetMemoryBudget(budget uint64) {
+	t.memoryBudget = &budget
+	for _, generator := range t.generators {
+		if generator, ok := generator.(*treeGeneratorImpl_v9_v10); ok {
+			generator.setMemoryBudget(budget)
+		}
+	}
 }
 
 func (t *TreeGenerator) ApproximateStakerShareOfSmoothingPool() (*big.Int, error) {
-	return t.approximatorImpl.approximateStakerShareOfSmoothingPool(t.rp, fmt.Sprint(t.cfg.Smartnode.Network.Value), t.bc)
+	return t.approximatorImpl.ApproximateStakerShareOfSmoothingPool(t.rp, fmt.Sprint(t.cfg.Smartnode.Network.Value), t.bc)
 }
 
 func (t *TreeGenerator) GetGeneratorRulesetVersion() uint64 {
-	return t.generatorImpl.getRulesetVersion()
+	return t.generatorImpl.GetRulesetVersion()
 }
 
 func (t *TreeGenerator) GetApproximatorRulesetVersion() uint64 {
-	return t.approximatorImpl.getRulesetVersion()
+	return t.approximatorImpl.GetRulesetVersion()
 }
 
 func (t *TreeGenerator) GenerateTreeWithRuleset(ruleset uint64) (*GenerateTreeResult, error) {
-	info, exists := t.rewardsIntervalInfos[ruleset]
-	if !exists {
-		return nil, fmt.Errorf("ruleset v%d does not exist", ruleset)
+	generator, err := t.getGenerator(ruleset)
+	if err != nil {
+		return nil, err
 	}
 
-	return info.generator.generateTree(
+	return generator.GenerateTree(
 		t.rp,
 		fmt.Sprint(t.cfg.Smartnode.Network.Value),
 		t.cfg.Smartnode.GetPreviousRewardsPoolAddresses(),
@@ -265,14 +288,47 @@ func (t *TreeGenerator) GenerateTreeWithRuleset(ruleset uint64) (*GenerateTreeRe
 }
 
 func (t *TreeGenerator) ApproximateStakerShareOfSmoothingPoolWithRuleset(ruleset uint64) (*big.Int, error) {
-	info, exists := t.rewardsIntervalInfos[ruleset]
-	if !exists {
-		return nil, fmt.Errorf("ruleset v%d does not exist", ruleset)
+	generator, err := t.getGenerator(ruleset)
+	if err != nil {
+		return nil, err
 	}
 
-	return info.generator.approximateStakerShareOfSmoothingPool(t.rp, fmt.Sprint(t.cfg.Smartnode.Network.Value), t.bc)
+	return generator.ApproximateStakerShareOfSmoothingPool(t.rp, fmt.Sprint(t.cfg.Smartnode.Network.Value), t.bc)
 }
 
 func (t *TreeGenerator) SaveFiles(treeResult *GenerateTreeResult, nodeTrusted bool) (cid.Cid, map[string]cid.Cid, error) {
-	return t.generatorImpl.saveFiles(t.cfg.Smartnode, treeResult, nodeTrusted)
+	return t.generatorImpl.SaveFiles(t.cfg.Smartnode, treeResult, nodeTrusted)
+}
+
+// Generate the tree while recording everything it reads from the chain into a chain snapshot at path, so it can be
+// reproduced later without any clients. The snapshot is removed if generation fails.
+func (t *TreeGenerator) GenerateTreeWithChainSnapshot(path string, state *state.NetworkState) (*GenerateTreeResult, error) {
+	recorder, err := NewChainSnapshotRecorder(t.rp, t.bc, state, path)
+	if err != nil {
+		return nil, err
+	}
+	previousRewardsPoolAddresses := t.cfg.Smartnode.GetPreviousRewardsPoolAddresses()
+	result, err := t.generatorImpl.GenerateTree(recorder, fmt.Sprint(t.cfg.Smartnode.Network.Value), previousRewardsPoolAddresses, recorder)
+	if err != nil {
+		recorder.Discard()
+		return nil, err
+	}
+
+	err = recorder.Close(
+		t.index,
+		fmt.Sprint(t.cfg.Smartnode.Network.Value),
+		t.generatorImpl.GetRulesetVersion(),
+		t.cfg.Smartnode.RewardsAccountingPolicy.Value.(string),
+		result.RewardsFile.GetMerkleRoot(),
+		t.startTime,
+		t.endTime,
+		t.intervalsPassed,
+		t.snapshotEnd,
+		t.elSnapshotHeader,
+		previousRewardsPoolAddresses,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error saving chain snapshot: %w", err)
+	}
+	return result, nil
 }
@@ -0,0 +1,193 @@
+package rewards
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The kind recorded for the interval manifest itself when it's linked
+const intervalManifestKind string = "manifest"
+
+// A list of every rewards artifact saved for an interval, so downstream tools can find and verify them without guessing
+// their paths
+type IntervalManifest struct {
+	Index       uint64                  `json:"index"`
+	Network     string                  `json:"network"`
+	MerkleRoot  string                  `json:"merkleRoot,omitempty"`
+	GeneratedAt time.Time               `json:"generatedAt"`
+	Artifacts   []IntervalManifestEntry `json:"artifacts"`
+}
+
+// An artifact in an interval manifest
+type IntervalManifestEntry struct {
+	Kind     string `json:"kind"`
+	Filename string `json:"filename"`
+	Size     int64  `json:"size"`
+	Sha256   string `json:"sha256"`
+	Cid      string `json:"cid,omitempty"`
+
+	// The artifact's path relative to the links directory, if links are enabled
+	Link string `json:"link,omitempty"`
+}
+
+// Format the path an artifact is linked to, relative to the links directory. The format can use the {network},
+// {interval}, {kind}, {filename}, and {ext} placeholders, and must identify both the interval and the artifact so no two
+// artifacts share a link.
+func FormatArtifactLink(format string, network string, interval uint64, kind string, filename string) (string, error) {
+	hasFilename := strings.Contains(format, "{filename}")
+	if !hasFilename && !strings.Contains(format, "{interval}") {
+		return "", fmt.Errorf("artifact link format [%s] must include {interval} or {filename}", format)
+	}
+	if !hasFilename && !strings.Contains(format, "{kind}") {
+		return "", fmt.Errorf("artifact link format [%s] must include {kind} or {filename}", format)
+	}
+
+	// Compressed copies keep both extensions, e.g. .json.zst
+	ext := filepath.Ext(filename)
+	if ext == config.RewardsTreeIpfsExtension {
+		ext = filepath.Ext(strings.TrimSuffix(filename, ext)) + ext
+	}
+	link := strings.NewReplacer(
+		"{network}", network,
+		"{interval}", fmt.Sprint(interval),
+		"{kind}", kind,
+		"{filename}", filename,
+		"{ext}", ext,
+	).Replace(format)
+
+	link = filepath.Clean(link)
+	if filepath.IsAbs(link) || link == "." || link == ".." || strings.HasPrefix(link, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("artifact link format [%s] must be a path inside the links directory", format)
+	}
+	return link, nil
+}
+
+// Build the manifest of the artifacts saved for an interval, link them into the configured layout, and save the manifest
+// alongside them. CIDs are taken from the interval's submission metadata if it has been saved.
+func SaveIntervalManifest(smartnode *config.SmartnodeConfig, index uint64, daemon bool) (*IntervalManifest, error) {
+	network := fmt.Sprint(smartnode.Network.Value)
+	manifest := &IntervalManifest{
+		Index:       index,
+		Network:     network,
+		GeneratedAt: time.Now().UTC(),
+		Artifacts:   []IntervalManifestEntry{},
+	}
+	cids := map[string]string{}
+	if metadata, err := LoadSubmissionMetadata(smartnode.GetRewardsSubmissionPath(index, daemon)); err == nil {
+		manifest.MerkleRoot = metadata.MerkleRoot
+		cids = metadata.Cids
+	}
+
+	linkFormat := strings.TrimSpace(smartnode.ArtifactLinkFormat.Value.(string))
+	linksDir := smartnode.GetArtifactLinksDirectory(daemon)
+	for _, artifact := range smartnode.GetRewardsArtifactPaths(index, daemon) {
+		entry, err := newIntervalManifestEntry(artifact)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		entry.Cid = cids[entry.Filename]
+		if linkFormat != "" {
+			entry.Link, err = linkArtifact(linksDir, linkFormat, network, index, artifact)
+			if err != nil {
+				return nil, err
+			}
+		}
+		manifest.Artifacts = append(manifest.Artifacts, entry)
+	}
+
+	// Save the manifest, then link it too so tools can find it from the layout
+	path := smartnode.GetRewardsManifestPath(index, daemon)
+	bytes, err := json.MarshalIndent(manifest, "", "\t")
+	if err != nil {
+		return nil, fmt.Errorf("error serializing interval manifest: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return nil, fmt.Errorf("error writing interval manifest to %s: %w", path, err)
+	}
+	if linkFormat != "" {
+		_, err := linkArtifact(linksDir, linkFormat, network, index, config.RewardsArtifactPath{Kind: intervalManifestKind, Path: path})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
+
+// Load an interval manifest from disk
+func LoadIntervalManifest(path string) (*IntervalManifest, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading interval manifest %s: %w", path, err)
+	}
+	var manifest IntervalManifest
+	if err := json.Unmarshal(bytes, &manifest); err != nil {
+		return nil, fmt.Errorf("error deserializing interval manifest %s: %w", path, err)
+	}
+	return &manifest, nil
+}
+
+// Hash an artifact for the manifest
+func newIntervalManifestEntry(artifact config.RewardsArtifactPath) (IntervalManifestEntry, error) {
+	file, err := os.Open(artifact.Path)
+	if err != nil {
+		return IntervalManifestEntry{}, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return IntervalManifestEntry{}, fmt.Errorf("error hashing %s: %w", artifact.Path, err)
+	}
+	return IntervalManifestEntry{
+		Kind:     artifact.Kind,
+		Filename: filepath.Base(artifact.Path),
+		Size:     size,
+		Sha256:   hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// Link an artifact into the links directory with a relative symlink, so the link works from both the host and the
+// daemon containers. Existing symlinks are replaced, but other files are never overwritten.
+func linkArtifact(linksDir string, linkFormat string, network string, index uint64, artifact config.RewardsArtifactPath) (string, error) {
+	link, err := FormatArtifactLink(linkFormat, network, index, artifact.Kind, filepath.Base(artifact.Path))
+	if err != nil {
+		return "", err
+	}
+	linkPath := filepath.Join(linksDir, link)
+	if err := os.MkdirAll(filepath.Dir(linkPath), 0755); err != nil {
+		return "", fmt.Errorf("error creating artifact link directory: %w", err)
+	}
+	target, err := filepath.Rel(filepath.Dir(linkPath), artifact.Path)
+	if err != nil {
+		return "", fmt.Errorf("error getting link target for %s: %w", artifact.Path, err)
+	}
+
+	if info, err := os.Lstat(linkPath); err == nil {
+		if info.Mode()&os.ModeSymlink == 0 {
+			return "", fmt.Errorf("can't link %s to %s because a file that isn't a link is already there", artifact.Path, linkPath)
+		}
+		if err := os.Remove(linkPath); err != nil {
+			return "", fmt.Errorf("error removing old artifact link %s: %w", linkPath, err)
+		}
+	}
+	if err := os.Symlink(target, linkPath); err != nil {
+		return "", fmt.Errorf("error linking %s to %s: %w", artifact.Path, linkPath, err)
+	}
+	return link, nil
+}
@@ -0,0 +1,70 @@
+package rewards
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+func TestFormatArtifactLink(t *testing.T) {
+	link, err := FormatArtifactLink("{network}/{interval}/{kind}{ext}", "mainnet", 12, "rewards-compressed", "rp-rewards-mainnet-12.json.zst")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link != filepath.Join("mainnet", "12", "rewards-compressed.json.zst") {
+		t.Fatalf("unexpected link %s", link)
+	}
+
+	for _, format := range []string{"{network}/{kind}{ext}", "{network}/{interval}.json", "../{filename}", "/tmp/{filename}"} {
+		if _, err := FormatArtifactLink(format, "mainnet", 12, "rewards", "rp-rewards-mainnet-12.json"); err == nil {
+			t.Fatalf("expected format %s to be rejected", format)
+		}
+	}
+}
+
+func TestSaveIntervalManifest(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.NewRocketPoolConfig(dir, true)
+	cfg.Smartnode.DataPath.Value = dir
+	cfg.Smartnode.ArtifactLinkFormat.Value = "{network}/{interval}/{kind}{ext}"
+
+	rewardsPath := cfg.Smartnode.GetRewardsTreePath(3, true, config.RewardsExtensionJSON)
+	if err := os.MkdirAll(filepath.Dir(rewardsPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(rewardsPath, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Saving twice replaces the links instead of failing
+	if _, err := SaveIntervalManifest(cfg.Smartnode, 3, true); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := SaveIntervalManifest(cfg.Smartnode, 3, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest.Artifacts) != 1 {
+		t.Fatalf("expected only the rewards file to be listed, got %d artifacts", len(manifest.Artifacts))
+	}
+	entry := manifest.Artifacts[0]
+	if entry.Kind != "rewards" || entry.Size != 2 || entry.Sha256 != "44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a" {
+		t.Fatalf("unexpected manifest entry %+v", entry)
+	}
+
+	// The artifact and the manifest are both reachable through their links
+	linksDir := cfg.Smartnode.GetArtifactLinksDirectory(true)
+	bytes, err := os.ReadFile(filepath.Join(linksDir, entry.Link))
+	if err != nil || string(bytes) != "{}" {
+		t.Fatalf("couldn't read the rewards file through its link: %v", err)
+	}
+	linked, err := LoadIntervalManifest(filepath.Join(linksDir, manifest.Network, "3", "manifest.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if linked.Index != 3 {
+		t.Fatalf("linked manifest is for interval %d", linked.Index)
+	}
+}
@@ -0,0 +1,208 @@
+package rewards
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// How a locally stored rewards tree compares to its interval's on-chain submission
+type IntervalConsistency string
+
+const (
+	IntervalConsistency_Consistent   IntervalConsistency = "consistent"
+	IntervalConsistency_Missing      IntervalConsistency = "missing"
+	IntervalConsistency_Unreadable   IntervalConsistency = "unreadable"
+	IntervalConsistency_RootMismatch IntervalConsistency = "root-mismatch"
+)
+
+// An interval's rewards tree submission, as recorded on-chain when the Oracle DAO reached consensus on it
+type IntervalSubmission struct {
+	Index          uint64         `json:"index"`
+	MerkleRoot     common.Hash    `json:"merkleRoot"`
+	CID            string         `json:"cid"`
+	Submitter      common.Address `json:"submitter"`
+	BlockNumber    uint64         `json:"blockNumber"`
+	TxHash         common.Hash    `json:"txHash"`
+	SubmissionTime time.Time      `json:"submissionTime"`
+	StartTime      time.Time      `json:"startTime"`
+	EndTime        time.Time      `json:"endTime"`
+}
+
+// A persistent registry of every interval's on-chain submission
+type IntervalRegistry struct {
+	Intervals map[uint64]IntervalSubmission `json:"intervals"`
+}
+
+// Load the interval registry from disk, returning an empty one if it doesn't exist
+func LoadIntervalRegistry(path string) (*IntervalRegistry, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &IntervalRegistry{Intervals: map[uint64]IntervalSubmission{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading interval registry: %w", err)
+	}
+	var registry IntervalRegistry
+	if err := json.Unmarshal(data, &registry); err != nil {
+		return nil, fmt.Errorf("error deserializing interval registry: %w", err)
+	}
+	if registry.Intervals == nil {
+		registry.Intervals = map[uint64]IntervalSubmission{}
+	}
+	return &registry, nil
+}
+
+// Save the interval registry to disk
+func SaveIntervalRegistry(path string, registry *IntervalRegistry) error {
+	data, err := json.Marshal(registry)
+	if err != nil {
+		return fmt.Errorf("error serializing interval registry: %w", err)
+	}
+	if err := files.WriteFileAtomic(path, data, 0644); err != nil {
+		return fmt.Errorf("error saving interval registry: %w", err)
+	}
+	return nil
+}
+
+// Get the indices of the intervals before currentIndex that aren't in the registry yet
+func (r *IntervalRegistry) GetMissingIntervals(currentIndex uint64) []uint64 {
+	missing := []uint64{}
+	for i := uint64(0); i < currentIndex; i++ {
+		if _, exists := r.Intervals[i]; !exists {
+			missing = append(missing, i)
+		}
+	}
+	return missing
+}
+
+// Get the registered submissions, ordered by interval
+func (r *IntervalRegistry) GetSubmissions() []IntervalSubmission {
+	submissions := make([]IntervalSubmission, 0, len(r.Intervals))
+	for _, submission := range r.Intervals {
+		submissions = append(submissions, submission)
+	}
+	sort.Slice(submissions, func(i, j int) bool { return submissions[i].Index < submissions[j].Index })
+	return submissions
+}
+
+// Add the on-chain submissions of the intervals before currentIndex that aren't in the registry yet.
+// Returns the number of intervals that were added.
+func (r *IntervalRegistry) Update(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, currentIndex uint64) (int, error) {
+	added := 0
+	for _, interval := range r.GetMissingIntervals(currentIndex) {
+		submission, err := GetIntervalSubmission(rp, cfg, interval)
+		if err != nil {
+			return added, err
+		}
+		r.Intervals[interval] = submission
+		added++
+	}
+	return added, nil
+}
+
+// Get an interval's on-chain submission, including the account that sent the transaction that finalized it
+func GetIntervalSubmission(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, interval uint64) (IntervalSubmission, error) {
+	previousRewardsPoolAddresses := cfg.Smartnode.GetPreviousRewardsPoolAddresses()
+	client := NewRewardsExecutionClient(rp)
+	event, err := client.GetRewardSnapshotEvent(previousRewardsPoolAddresses, interval, nil)
+	if err != nil {
+		return IntervalSubmission{}, fmt.Errorf("error getting interval %d event: %w", interval, err)
+	}
+	submission := IntervalSubmission{
+		Index:          interval,
+		MerkleRoot:     event.MerkleRoot,
+		CID:            event.MerkleTreeCID,
+		SubmissionTime: event.SubmissionTime,
+		StartTime:      event.IntervalStartTime,
+		EndTime:        event.IntervalEndTime,
+	}
+
+	// Find the transaction that emitted the event
+	rocketRewardsPool, err := rp.GetContract("rocketRewardsPool", nil)
+	if err != nil {
+		return IntervalSubmission{}, fmt.Errorf("error getting rewards pool contract: %w", err)
+	}
+	submission.BlockNumber, err = GetIntervalExecutionBlock(rp, interval)
+	if err != nil {
+		return IntervalSubmission{}, err
+	}
+	block := big.NewInt(0).SetUint64(submission.BlockNumber)
+	indexBig := big.NewInt(0).SetUint64(interval)
+
+	indexBytes := [32]byte{}
+	indexBig.FillBytes(indexBytes[:])
+	addressFilter := append([]common.Address{*rocketRewardsPool.Address}, previousRewardsPoolAddresses...)
+	topicFilter := [][]common.Hash{{rocketRewardsPool.ABI.Events["RewardSnapshot"].ID}, {indexBytes}}
+	logs, err := eth.GetLogs(rp, addressFilter, topicFilter, big.NewInt(1), block, block, nil)
+	if err != nil {
+		return IntervalSubmission{}, fmt.Errorf("error getting interval %d event logs: %w", interval, err)
+	}
+	if len(logs) == 0 {
+		return IntervalSubmission{}, fmt.Errorf("interval %d event was not found in block %d", interval, submission.BlockNumber)
+	}
+	submission.TxHash = logs[0].TxHash
+
+	// Get its sender
+	tx, _, err := rp.Client.TransactionByHash(context.Background(), submission.TxHash)
+	if err != nil {
+		return IntervalSubmission{}, fmt.Errorf("error getting interval %d transaction %s: %w", interval, submission.TxHash.Hex(), err)
+	}
+	submission.Submitter, err = ethtypes.Sender(ethtypes.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return IntervalSubmission{}, fmt.Errorf("error getting the sender of interval %d transaction %s: %w", interval, submission.TxHash.Hex(), err)
+	}
+	return submission, nil
+}
+
+// Get the block an interval's rewards were submitted and distributed in
+func GetIntervalExecutionBlock(rp *rocketpool.RocketPool, interval uint64) (uint64, error) {
+	rocketRewardsPool, err := rp.GetContract("rocketRewardsPool", nil)
+	if err != nil {
+		return 0, fmt.Errorf("error getting rewards pool contract: %w", err)
+	}
+	blockWrapper := new(*big.Int)
+	if err := rocketRewardsPool.Call(nil, blockWrapper, "getClaimIntervalExecutionBlock", big.NewInt(0).SetUint64(interval)); err != nil {
+		return 0, fmt.Errorf("error getting the event block for interval %d: %w", interval, err)
+	}
+	return (*blockWrapper).Uint64(), nil
+}
+
+// Check the locally stored rewards tree for a submission against its Merkle root
+func (s IntervalSubmission) CheckLocalFile(cfg *config.RocketPoolConfig, isDaemon bool) (IntervalConsistency, error) {
+	path := cfg.Smartnode.GetRewardsTreePath(s.Index, isDaemon, config.RewardsExtensionJSON)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return IntervalConsistency_Missing, nil
+	}
+	localRewardsFile, err := ReadLocalRewardsFile(path)
+	if err != nil {
+		return IntervalConsistency_Unreadable, err
+	}
+	if common.HexToHash(localRewardsFile.Impl().GetMerkleRoot()) != s.MerkleRoot {
+		return IntervalConsistency_RootMismatch, nil
+	}
+	return IntervalConsistency_Consistent, nil
+}
+
+// Get the interval info needed to download the submission's rewards tree
+func (s IntervalSubmission) GetIntervalInfo() IntervalInfo {
+	return IntervalInfo{
+		Index:      s.Index,
+		CID:        s.CID,
+		MerkleRoot: s.MerkleRoot,
+		StartTime:  s.StartTime,
+		EndTime:    s.EndTime,
+	}
+}
@@ -0,0 +1,43 @@
+package rewards
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestIntervalRegistryRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry", "interval-registry.json")
+
+	registry, err := LoadIntervalRegistry(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if missing := registry.GetMissingIntervals(3); len(missing) != 3 {
+		t.Fatalf("expected 3 missing intervals in an empty registry, got %v", missing)
+	}
+
+	registry.Intervals[2] = IntervalSubmission{Index: 2, MerkleRoot: common.HexToHash("0x02"), CID: "cid2", SubmissionTime: time.Unix(1700000000, 0)}
+	registry.Intervals[0] = IntervalSubmission{Index: 0, MerkleRoot: common.HexToHash("0x01"), CID: "cid0", Submitter: common.HexToAddress("0x03")}
+	if err := SaveIntervalRegistry(path, registry); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadIntervalRegistry(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing := loaded.GetMissingIntervals(4)
+	if len(missing) != 2 || missing[0] != 1 || missing[1] != 3 {
+		t.Fatalf("expected intervals 1 and 3 to be missing, got %v", missing)
+	}
+	submissions := loaded.GetSubmissions()
+	if len(submissions) != 2 || submissions[0].Index != 0 || submissions[1].Index != 2 {
+		t.Fatalf("submissions are out of order: %+v", submissions)
+	}
+	if submissions[0] != registry.Intervals[0] || !submissions[1].SubmissionTime.Equal(registry.Intervals[2].SubmissionTime) {
+		t.Fatalf("submissions changed after a round trip: %+v", submissions)
+	}
+}
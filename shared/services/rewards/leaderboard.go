@@ -0,0 +1,205 @@
+package rewards
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// A network-wide ranking of nodes by weight, attestation effectiveness, and smoothing pool share
+type Leaderboard struct {
+	Network          string              `json:"network"`
+	Slot             uint64              `json:"slot"`
+	GeneratedTime    time.Time           `json:"generatedTime"`
+	Interval         uint64              `json:"interval"`
+	HasInterval      bool                `json:"hasInterval"`
+	TotalNodeWeight  *QuotedBigInt       `json:"totalNodeWeight"`
+	SmoothingPoolEth *QuotedBigInt       `json:"smoothingPoolEth"`
+	Nodes            []*LeaderboardEntry `json:"nodes"`
+}
+
+// A single node's standing on the leaderboard. Ranks start at 1; a rank of 0 means the node isn't ranked for that metric.
+type LeaderboardEntry struct {
+	Address            common.Address `json:"address"`
+	Weight             *QuotedBigInt  `json:"weight"`
+	WeightRank         int            `json:"weightRank"`
+	Effectiveness      float64        `json:"effectiveness"`
+	EffectivenessRank  int            `json:"effectivenessRank"`
+	SmoothingPoolEth   *QuotedBigInt  `json:"smoothingPoolEth"`
+	SmoothingPoolShare float64        `json:"smoothingPoolShare"`
+	SmoothingPoolRank  int            `json:"smoothingPoolRank"`
+}
+
+// Generate a leaderboard from the network state, ranking effectiveness and smoothing pool share from the latest
+// interval's rewards and minipool performance files if they're provided
+func GenerateLeaderboard(network string, networkState *state.NetworkState, rewardsFile IRewardsFile, performanceFile IMinipoolPerformanceFile) (*Leaderboard, error) {
+
+	// Get the node weights
+	weights, totalWeight, err := networkState.CalculateNodeWeights()
+	if err != nil {
+		return nil, fmt.Errorf("error calculating node weights: %w", err)
+	}
+
+	leaderboard := &Leaderboard{
+		Network:          network,
+		Slot:             networkState.BeaconSlotNumber,
+		GeneratedTime:    time.Now().UTC(),
+		TotalNodeWeight:  QuotedBigIntFromBigInt(totalWeight),
+		SmoothingPoolEth: NewQuotedBigInt(0),
+		Nodes:            make([]*LeaderboardEntry, 0, len(weights)),
+	}
+	entries := make(map[common.Address]*LeaderboardEntry, len(weights))
+	for _, node := range networkState.NodeDetails {
+		entry := &LeaderboardEntry{
+			Address:          node.NodeAddress,
+			Weight:           QuotedBigIntFromBigInt(weights[node.NodeAddress]),
+			SmoothingPoolEth: NewQuotedBigInt(0),
+		}
+		entries[node.NodeAddress] = entry
+		leaderboard.Nodes = append(leaderboard.Nodes, entry)
+	}
+
+	// Get each node's share of the smoothing pool
+	if rewardsFile != nil {
+		leaderboard.Interval = rewardsFile.GetIndex()
+		leaderboard.HasInterval = true
+		totalSmoothingPoolEth := rewardsFile.GetTotalNodeOperatorSmoothingPoolEth()
+		leaderboard.SmoothingPoolEth = QuotedBigIntFromBigInt(totalSmoothingPoolEth)
+		for _, address := range rewardsFile.GetNodeAddresses() {
+			entry, exists := entries[address]
+			if !exists {
+				continue
+			}
+			smoothingPoolEth := rewardsFile.GetNodeSmoothingPoolEth(address)
+			entry.SmoothingPoolEth = QuotedBigIntFromBigInt(smoothingPoolEth)
+			if totalSmoothingPoolEth.Sign() > 0 {
+				entry.SmoothingPoolShare, _ = new(big.Rat).SetFrac(smoothingPoolEth, totalSmoothingPoolEth).Float64()
+			}
+		}
+	}
+
+	// Get each node's attestation effectiveness across all of its minipools
+	effectiveNodes := map[common.Address]bool{}
+	if performanceFile != nil {
+		successful := map[common.Address]uint64{}
+		total := map[common.Address]uint64{}
+		for _, minipoolAddress := range performanceFile.GetMinipoolAddresses() {
+			performance, exists := performanceFile.GetSmoothingPoolPerformance(minipoolAddress)
+			if !exists {
+				continue
+			}
+			mpd, exists := networkState.MinipoolDetailsByAddress[minipoolAddress]
+			if !exists {
+				continue
+			}
+			successful[mpd.NodeAddress] += performance.GetSuccessfulAttestationCount()
+			total[mpd.NodeAddress] += performance.GetSuccessfulAttestationCount() + performance.GetMissedAttestationCount()
+		}
+		for address, count := range total {
+			entry, exists := entries[address]
+			if !exists || count == 0 {
+				continue
+			}
+			entry.Effectiveness = float64(successful[address]) / float64(count)
+			effectiveNodes[address] = true
+		}
+	}
+
+	// Rank the nodes by each metric; ties are broken by address so the leaderboard is deterministic
+	rankLeaderboard(leaderboard.Nodes, func(a, b *LeaderboardEntry) int {
+		return a.SmoothingPoolEth.Cmp(&b.SmoothingPoolEth.Int)
+	}, func(entry *LeaderboardEntry, rank int) {
+		if entry.SmoothingPoolEth.Sign() > 0 {
+			entry.SmoothingPoolRank = rank
+		}
+	})
+	rankLeaderboard(leaderboard.Nodes, func(a, b *LeaderboardEntry) int {
+		switch {
+		case a.Effectiveness > b.Effectiveness:
+			return 1
+		case a.Effectiveness < b.Effectiveness:
+			return -1
+		}
+		return 0
+	}, func(entry *LeaderboardEntry, rank int) {
+		if effectiveNodes[entry.Address] {
+			entry.EffectivenessRank = rank
+		}
+	})
+
+	// Weight goes last so the nodes are saved in order of weight
+	rankLeaderboard(leaderboard.Nodes, func(a, b *LeaderboardEntry) int {
+		return a.Weight.Cmp(&b.Weight.Int)
+	}, func(entry *LeaderboardEntry, rank int) {
+		entry.WeightRank = rank
+	})
+
+	return leaderboard, nil
+
+}
+
+// Sort the entries in descending order of the provided comparison and assign them ranks
+func rankLeaderboard(entries []*LeaderboardEntry, compare func(a, b *LeaderboardEntry) int, setRank func(entry *LeaderboardEntry, rank int)) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		result := compare(entries[i], entries[j])
+		if result != 0 {
+			return result > 0
+		}
+		return bytes.Compare(entries[i].Address[:], entries[j].Address[:]) < 0
+	})
+	rank := 0
+	for _, entry := range entries {
+		rank++
+		setRank(entry, rank)
+	}
+}
+
+// Get a node's entry on the leaderboard, if it has one
+func (l *Leaderboard) GetEntry(address common.Address) (*LeaderboardEntry, bool) {
+	for _, entry := range l.Nodes {
+		if entry.Address == address {
+			return entry, true
+		}
+	}
+	return nil, false
+}
+
+// Save a leaderboard to disk
+func SaveLeaderboard(path string, leaderboard *Leaderboard) error {
+	data, err := json.Marshal(leaderboard)
+	if err != nil {
+		return fmt.Errorf("error serializing leaderboard: %w", err)
+	}
+
+	err = files.WriteFileAtomic(path, data, 0644)
+	if err != nil {
+		return fmt.Errorf("error saving leaderboard: %w", err)
+	}
+	return nil
+}
+
+// Load a leaderboard from disk; returns nil if one hasn't been generated yet
+func LoadLeaderboard(path string) (*Leaderboard, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading leaderboard from [%s]: %w", path, err)
+	}
+
+	var leaderboard Leaderboard
+	err = json.Unmarshal(data, &leaderboard)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing leaderboard from [%s]: %w", path, err)
+	}
+	return &leaderboard, nil
+}
@@ -0,0 +1,92 @@
+package rewards
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Memory budgets (in MB) below this switch tree generation to its bounded-memory algorithms for attestation duties.
+// The budget isn't enforced: validator and minipool data are still kept in memory, so this is only the point where
+// the slower duty processing is worth it, not a measured requirement.
+const boundedMemoryBudgetThreshold uint64 = 32 * 1024
+
+// The size of a missed duty record: the minipool address followed by the slot
+const missedDutyRecordSize int = common.AddressLength + 8
+
+// An on-disk record of the attestation duties minipools missed, so they don't have to be kept in memory
+// until the end of the interval
+type missedDutyStore struct {
+	file   *os.File
+	writer *bufio.Writer
+	count  uint64
+}
+
+// Create a missed duty store backed by a temporary file
+func newMissedDutyStore() (*missedDutyStore, error) {
+	file, err := os.CreateTemp("", "treegen-missed-duties-*")
+	if err != nil {
+		return nil, fmt.Errorf("error creating missed duty store: %w", err)
+	}
+	return &missedDutyStore{
+		file:   file,
+		writer: bufio.NewWriter(file),
+	}, nil
+}
+
+// Record a duty the minipool missed
+func (s *missedDutyStore) add(minipool common.Address, slot uint64) error {
+	var record [missedDutyRecordSize]byte
+	copy(record[:], minipool.Bytes())
+	binary.BigEndian.PutUint64(record[common.AddressLength:], slot)
+	if _, err := s.writer.Write(record[:]); err != nil {
+		return fmt.Errorf("error writing to missed duty store: %w", err)
+	}
+	s.count++
+	return nil
+}
+
+// Add every recorded duty back to its minipool's missing attestation slots
+func (s *missedDutyStore) restore(minipools map[common.Address]*MinipoolInfo) error {
+	if err := s.writer.Flush(); err != nil {
+		return fmt.Errorf("error flushing missed duty store: %w", err)
+	}
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("error rewinding missed duty store: %w", err)
+	}
+
+	reader := bufio.NewReader(s.file)
+	var record [missedDutyRecordSize]byte
+	for {
+		_, err := io.ReadFull(reader, record[:])
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading missed duty store: %w", err)
+		}
+		address := common.BytesToAddress(record[:common.AddressLength])
+		minipool, exists := minipools[address]
+		if !exists {
+			return fmt.Errorf("missed duty store has a duty for unknown minipool %s", address.Hex())
+		}
+		minipool.MissingAttestationSlots[binary.BigEndian.Uint64(record[common.AddressLength:])] = true
+	}
+}
+
+// Close and delete the store's file
+func (s *missedDutyStore) close() {
+	s.file.Close()
+	os.Remove(s.file.Name())
+}
+
+// Get the number of attestation duties a minipool completed. Bounded-memory generation counts them instead of
+// recording their slots.
+func getCompletedAttestationCount(minipool *MinipoolInfo) uint64 {
+	return uint64(len(minipool.CompletedAttestations)) + minipool.GoodAttestations
+}
@@ -0,0 +1,103 @@
+package rewards
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/fatih/color"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/rewards/test"
+	"github.com/rocket-pool/smartnode/shared/services/rewards/test/assets"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+func TestMockMemoryBudgetTreegenv10(tt *testing.T) {
+
+	history := test.NewDefaultMockHistory()
+	state := history.GetEndNetworkState()
+
+	t := newV8Test(tt, state.NetworkDetails.RewardIndex)
+
+	t.bc.SetState(state)
+	history.SetWithdrawals(t.bc)
+
+	consensusStartBlock := history.GetConsensusStartBlock()
+	executionStartBlock := history.GetExecutionStartBlock()
+	consensusEndBlock := history.GetConsensusEndBlock()
+	executionEndBlock := history.GetExecutionEndBlock()
+
+	logger := log.NewColorLogger(color.Faint)
+
+	t.rp.SetRewardSnapshotEvent(history.GetPreviousRewardSnapshotEvent())
+	t.bc.SetBeaconBlock(fmt.Sprint(consensusStartBlock-1), beacon.BeaconBlock{ExecutionBlockNumber: executionStartBlock - 1})
+	t.bc.SetBeaconBlock(fmt.Sprint(consensusStartBlock), beacon.BeaconBlock{ExecutionBlockNumber: executionStartBlock})
+	t.rp.SetHeaderByNumber(big.NewInt(int64(executionStartBlock)), &types.Header{Time: uint64(history.GetStartTime().Unix())})
+
+	// Miss a few duties so some of them have to go through the missed duty store
+	missedSlots := []uint64{consensusStartBlock + 64, consensusStartBlock + 3200, consensusEndBlock - 64}
+	for _, validator := range state.ValidatorDetails {
+		t.bc.SetMinipoolPerformance(validator.Index, missedSlots)
+	}
+
+	generate := func(budget uint64) *GenerateTreeResult {
+		generator := newTreeGeneratorImpl_v9_v10(
+			10,
+			&logger,
+			fmt.Sprintf("%s-%d", t.Name(), budget),
+			state.NetworkDetails.RewardIndex,
+			&SnapshotEnd{
+				Slot:           consensusEndBlock,
+				ConsensusBlock: consensusEndBlock,
+				ExecutionBlock: executionEndBlock,
+			},
+			&types.Header{
+				Number: big.NewInt(int64(history.GetExecutionEndBlock())),
+				Time:   assets.Mainnet20ELHeaderTime,
+			},
+			/* intervalsPassed= */ 1,
+			state,
+		)
+		generator.setMemoryBudget(budget)
+		artifacts, err := generator.GenerateTree(t.rp, "mainnet", make([]common.Address, 0), t.bc)
+		t.failIf(err)
+		return artifacts
+	}
+
+	unbounded := generate(0)
+	bounded := generate(8192)
+
+	// Bounded-memory generation must produce exactly the same tree
+	if unbounded.RewardsFile.GetMerkleRoot() != bounded.RewardsFile.GetMerkleRoot() {
+		t.Fatalf("bounded-memory merkle root %s doesn't match %s", bounded.RewardsFile.GetMerkleRoot(), unbounded.RewardsFile.GetMerkleRoot())
+	}
+
+	// And the same performance for every minipool, including the duties it missed
+	sawMisses := false
+	addresses := unbounded.MinipoolPerformanceFile.GetMinipoolAddresses()
+	if len(addresses) != len(bounded.MinipoolPerformanceFile.GetMinipoolAddresses()) {
+		t.Fatalf("bounded-memory performance file has %d minipools, expected %d", len(bounded.MinipoolPerformanceFile.GetMinipoolAddresses()), len(addresses))
+	}
+	for _, address := range addresses {
+		expected, _ := unbounded.MinipoolPerformanceFile.GetSmoothingPoolPerformance(address)
+		actual, exists := bounded.MinipoolPerformanceFile.GetSmoothingPoolPerformance(address)
+		if !exists {
+			t.Fatalf("minipool %s is missing from the bounded-memory performance file", address.Hex())
+		}
+		if actual.GetSuccessfulAttestationCount() != expected.GetSuccessfulAttestationCount() {
+			t.Fatalf("minipool %s has %d successful attestations with bounded memory, expected %d", address.Hex(), actual.GetSuccessfulAttestationCount(), expected.GetSuccessfulAttestationCount())
+		}
+		if !reflect.DeepEqual(actual.GetMissingAttestationSlots(), expected.GetMissingAttestationSlots()) {
+			t.Fatalf("minipool %s missed slots %v with bounded memory, expected %v", address.Hex(), actual.GetMissingAttestationSlots(), expected.GetMissingAttestationSlots())
+		}
+		if len(expected.GetMissingAttestationSlots()) > 0 {
+			sawMisses = true
+		}
+	}
+	if !sawMisses {
+		t.Fatalf("expected some minipools to miss duties")
+	}
+}
@@ -57,7 +57,7 @@ func TestMockIntervalDefaultsTreegenv8v9(tt *testing.T) {
 		t.bc.SetMinipoolPerformance(validator.Index, make([]uint64, 0))
 	}
 
-	v8Artifacts, err := generator.generateTree(
+	v8Artifacts, err := generator.GenerateTree(
 		t.rp,
 		"mainnet",
 		make([]common.Address, 0),
@@ -86,7 +86,7 @@ func TestMockIntervalDefaultsTreegenv8v9(tt *testing.T) {
 		state,
 	)
 
-	v9Artifacts, err := generatorv9v10.generateTree(
+	v9Artifacts, err := generatorv9v10.GenerateTree(
 		t.rp,
 		"mainnet",
 		make([]common.Address, 0),
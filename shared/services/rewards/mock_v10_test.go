@@ -88,7 +88,7 @@ func TestMockIntervalDefaultsTreegenv10(tt *testing.T) {
 		state,
 	)
 
-	v10Artifacts, err := generatorv9v10.generateTree(
+	v10Artifacts, err := generatorv9v10.GenerateTree(
 		t.rp,
 		"mainnet",
 		make([]common.Address, 0),
@@ -448,7 +448,7 @@ func TestInsufficientEthForBonuseses(tt *testing.T) {
 		state,
 	)
 
-	v10Artifacts, err := generatorv9v10.generateTree(
+	v10Artifacts, err := generatorv9v10.GenerateTree(
 		t.rp,
 		"mainnet",
 		make([]common.Address, 0),
@@ -558,7 +558,7 @@ func TestMockNoRPLRewards(tt *testing.T) {
 		state,
 	)
 
-	v10Artifacts, err := generatorv9v10.generateTree(
+	v10Artifacts, err := generatorv9v10.GenerateTree(
 		t.rp,
 		"mainnet",
 		make([]common.Address, 0),
@@ -686,7 +686,7 @@ func TestMockOptedOutAndThenBondReduced(tt *testing.T) {
 		state,
 	)
 
-	v10Artifacts, err := generatorv9v10.generateTree(
+	v10Artifacts, err := generatorv9v10.GenerateTree(
 		t.rp,
 		"mainnet",
 		make([]common.Address, 0),
@@ -808,7 +808,7 @@ func TestMockWithdrawableEpoch(tt *testing.T) {
 		state,
 	)
 
-	v10Artifacts, err := generatorv9v10.generateTree(
+	v10Artifacts, err := generatorv9v10.GenerateTree(
 		t.rp,
 		"mainnet",
 		make([]common.Address, 0),
@@ -0,0 +1,82 @@
+package rewards
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/fatih/color"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/rewards/test"
+	"github.com/rocket-pool/smartnode/shared/services/rewards/test/assets"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+func TestMockNodeWorkersTreegenv10(tt *testing.T) {
+
+	history := test.NewDefaultMockHistory()
+	state := history.GetEndNetworkState()
+
+	t := newV8Test(tt, state.NetworkDetails.RewardIndex)
+
+	t.bc.SetState(state)
+	history.SetWithdrawals(t.bc)
+
+	consensusStartBlock := history.GetConsensusStartBlock()
+	executionStartBlock := history.GetExecutionStartBlock()
+	consensusEndBlock := history.GetConsensusEndBlock()
+	executionEndBlock := history.GetExecutionEndBlock()
+
+	logger := log.NewColorLogger(color.Faint)
+
+	t.rp.SetRewardSnapshotEvent(history.GetPreviousRewardSnapshotEvent())
+	t.bc.SetBeaconBlock(fmt.Sprint(consensusStartBlock-1), beacon.BeaconBlock{ExecutionBlockNumber: executionStartBlock - 1})
+	t.bc.SetBeaconBlock(fmt.Sprint(consensusStartBlock), beacon.BeaconBlock{ExecutionBlockNumber: executionStartBlock})
+	t.rp.SetHeaderByNumber(big.NewInt(int64(executionStartBlock)), &types.Header{Time: uint64(history.GetStartTime().Unix())})
+
+	generate := func(workers uint64) *GenerateTreeResult {
+		generator := newTreeGeneratorImpl_v9_v10(
+			10,
+			&logger,
+			fmt.Sprintf("%s-%d", t.Name(), workers),
+			state.NetworkDetails.RewardIndex,
+			&SnapshotEnd{
+				Slot:           consensusEndBlock,
+				ConsensusBlock: consensusEndBlock,
+				ExecutionBlock: executionEndBlock,
+			},
+			&types.Header{
+				Number: big.NewInt(int64(history.GetExecutionEndBlock())),
+				Time:   assets.Mainnet20ELHeaderTime,
+			},
+			/* intervalsPassed= */ 1,
+			state,
+		)
+		generator.setNodeWorkers(workers)
+		artifacts, err := generator.GenerateTree(t.rp, "mainnet", make([]common.Address, 0), t.bc)
+		t.failIf(err)
+		return artifacts
+	}
+
+	serial := generate(1)
+	parallel := generate(16)
+
+	// Calculating nodes in parallel must produce exactly the same tree
+	if serial.RewardsFile.GetMerkleRoot() != parallel.RewardsFile.GetMerkleRoot() {
+		t.Fatalf("parallel merkle root %s doesn't match %s", parallel.RewardsFile.GetMerkleRoot(), serial.RewardsFile.GetMerkleRoot())
+	}
+
+	// And the same bonus for every minipool
+	for _, address := range serial.MinipoolPerformanceFile.GetMinipoolAddresses() {
+		expected, _ := serial.MinipoolPerformanceFile.GetSmoothingPoolPerformance(address)
+		actual, exists := parallel.MinipoolPerformanceFile.GetSmoothingPoolPerformance(address)
+		if !exists {
+			t.Fatalf("minipool %s is missing from the parallel performance file", address.Hex())
+		}
+		if actual.GetBonusEthEarned().Cmp(expected.GetBonusEthEarned()) != 0 {
+			t.Fatalf("minipool %s earned a bonus of %s in parallel, expected %s", address.Hex(), actual.GetBonusEthEarned().String(), expected.GetBonusEthEarned().String())
+		}
+	}
+}
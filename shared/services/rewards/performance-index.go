@@ -0,0 +1,201 @@
+package rewards
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+)
+
+// A single minipool in a performance file index
+type MinipoolPerformanceIndexEntry struct {
+	Minipool           common.Address `json:"minipool"`
+	Node               common.Address `json:"node"`
+	Pubkey             string         `json:"pubkey"`
+	MissedAttestations uint64         `json:"missedAttestations"`
+}
+
+// An index over a minipool performance file, so it can be queried a page at a time without walking the whole file.
+// Entries are sorted by missed attestations (then by minipool address) so missed-attestation ranges can be found with a binary search.
+type MinipoolPerformanceIndex struct {
+	Index   uint64                          `json:"index"`
+	Entries []MinipoolPerformanceIndexEntry `json:"entries"`
+
+	byNode   map[common.Address][]int
+	byPubkey map[string]int
+}
+
+// Filters and paging for a minipool performance query.
+// Unset filters match everything.
+type MinipoolPerformanceQuery struct {
+	Node      *common.Address
+	Pubkey    string
+	MinMissed *uint64
+	MaxMissed *uint64
+	Offset    uint64
+	Limit     uint64
+}
+
+// Build an index over a minipool performance file.
+// minipoolNodes maps each minipool to its node; it can be nil (or missing some minipools) if the owners aren't known.
+func NewMinipoolPerformanceIndex(index uint64, file IMinipoolPerformanceFile, minipoolNodes map[common.Address]common.Address) *MinipoolPerformanceIndex {
+	addresses := file.GetMinipoolAddresses()
+	entries := make([]MinipoolPerformanceIndexEntry, 0, len(addresses))
+	for _, address := range addresses {
+		performance, exists := file.GetSmoothingPoolPerformance(address)
+		if !exists {
+			continue
+		}
+		entry := MinipoolPerformanceIndexEntry{
+			Minipool:           address,
+			Node:               minipoolNodes[address],
+			MissedAttestations: performance.GetMissedAttestationCount(),
+		}
+		pubkey, err := performance.GetPubkey()
+		if err == nil {
+			entry.Pubkey = pubkey.Hex()
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].MissedAttestations != entries[j].MissedAttestations {
+			return entries[i].MissedAttestations < entries[j].MissedAttestations
+		}
+		return entries[i].Minipool.Cmp(entries[j].Minipool) < 0
+	})
+
+	idx := &MinipoolPerformanceIndex{
+		Index:   index,
+		Entries: entries,
+	}
+	idx.buildLookups()
+	return idx
+}
+
+// Load a minipool performance index from disk
+func LoadMinipoolPerformanceIndex(path string) (*MinipoolPerformanceIndex, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading minipool performance index from %s: %w", path, err)
+	}
+	idx := &MinipoolPerformanceIndex{}
+	if err := json.Unmarshal(bytes, idx); err != nil {
+		return nil, fmt.Errorf("error deserializing minipool performance index from %s: %w", path, err)
+	}
+	idx.buildLookups()
+	return idx, nil
+}
+
+// Save the index to disk
+func (idx *MinipoolPerformanceIndex) Save(path string) error {
+	bytes, err := json.Marshal(idx)
+	if err != nil {
+		return fmt.Errorf("error serializing minipool performance index: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return fmt.Errorf("error writing minipool performance index to %s: %w", path, err)
+	}
+	return nil
+}
+
+// True if the index knows which node owns each minipool
+func (idx *MinipoolPerformanceIndex) HasNodes() bool {
+	for _, entry := range idx.Entries {
+		if entry.Node != (common.Address{}) {
+			return true
+		}
+	}
+	return len(idx.Entries) == 0
+}
+
+// Fill in the owner of the provided minipools, for indices that were built without them
+func (idx *MinipoolPerformanceIndex) SetNode(node common.Address, minipools []common.Address) {
+	positions := make(map[common.Address]int, len(idx.Entries))
+	for i, entry := range idx.Entries {
+		positions[entry.Minipool] = i
+	}
+	for _, minipool := range minipools {
+		if i, exists := positions[minipool]; exists {
+			idx.Entries[i].Node = node
+		}
+	}
+	idx.buildLookups()
+}
+
+// Run a query against the index, returning the requested page of matching entries and the total number of matches
+func (idx *MinipoolPerformanceIndex) Query(query MinipoolPerformanceQuery) ([]MinipoolPerformanceIndexEntry, uint64) {
+	// Narrow down the candidates using the lookups, falling back to the missed-attestation range of the sorted entries
+	var candidates []int
+	if query.Pubkey != "" {
+		i, exists := idx.byPubkey[normalizePubkey(query.Pubkey)]
+		if exists {
+			candidates = []int{i}
+		}
+	} else if query.Node != nil {
+		candidates = idx.byNode[*query.Node]
+	} else {
+		start := 0
+		if query.MinMissed != nil {
+			start = sort.Search(len(idx.Entries), func(i int) bool {
+				return idx.Entries[i].MissedAttestations >= *query.MinMissed
+			})
+		}
+		end := len(idx.Entries)
+		if query.MaxMissed != nil {
+			end = sort.Search(len(idx.Entries), func(i int) bool {
+				return idx.Entries[i].MissedAttestations > *query.MaxMissed
+			})
+		}
+		for i := start; i < end; i++ {
+			candidates = append(candidates, i)
+		}
+	}
+
+	// Apply the remaining filters
+	matches := []MinipoolPerformanceIndexEntry{}
+	for _, i := range candidates {
+		entry := idx.Entries[i]
+		if query.Node != nil && entry.Node != *query.Node {
+			continue
+		}
+		if query.MinMissed != nil && entry.MissedAttestations < *query.MinMissed {
+			continue
+		}
+		if query.MaxMissed != nil && entry.MissedAttestations > *query.MaxMissed {
+			continue
+		}
+		matches = append(matches, entry)
+	}
+
+	// Page the results
+	total := uint64(len(matches))
+	if query.Offset >= total {
+		return []MinipoolPerformanceIndexEntry{}, total
+	}
+	end := total
+	if query.Limit > 0 && query.Offset+query.Limit < total {
+		end = query.Offset + query.Limit
+	}
+	return matches[query.Offset:end], total
+}
+
+// Build the in-memory lookups for node and pubkey queries
+func (idx *MinipoolPerformanceIndex) buildLookups() {
+	idx.byNode = map[common.Address][]int{}
+	idx.byPubkey = make(map[string]int, len(idx.Entries))
+	for i, entry := range idx.Entries {
+		if entry.Node != (common.Address{}) {
+			idx.byNode[entry.Node] = append(idx.byNode[entry.Node], i)
+		}
+		if entry.Pubkey != "" {
+			idx.byPubkey[normalizePubkey(entry.Pubkey)] = i
+		}
+	}
+}
+
+func normalizePubkey(pubkey string) string {
+	return strings.TrimPrefix(strings.ToLower(pubkey), "0x")
+}
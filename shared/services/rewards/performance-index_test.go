@@ -0,0 +1,65 @@
+package rewards
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestMinipoolPerformanceIndexQuery(t *testing.T) {
+	file := &MinipoolPerformanceFile_v2{
+		MinipoolPerformance: map[common.Address]*SmoothingPoolMinipoolPerformance_v2{},
+	}
+	nodeA := common.HexToAddress("0xa")
+	nodeB := common.HexToAddress("0xb")
+	minipoolNodes := map[common.Address]common.Address{}
+	for i := 0; i < 10; i++ {
+		minipool := common.BigToAddress(big.NewInt(int64(100 + i)))
+		file.MinipoolPerformance[minipool] = &SmoothingPoolMinipoolPerformance_v2{
+			Pubkey:             common.Bytes2Hex(common.LeftPadBytes([]byte{byte(i + 1)}, 48)),
+			MissedAttestations: uint64(i % 5),
+		}
+		if i < 4 {
+			minipoolNodes[minipool] = nodeA
+		} else {
+			minipoolNodes[minipool] = nodeB
+		}
+	}
+	idx := NewMinipoolPerformanceIndex(1, file, minipoolNodes)
+
+	// Missed attestation range, paged
+	minMissed, maxMissed := uint64(2), uint64(3)
+	page, total := idx.Query(MinipoolPerformanceQuery{MinMissed: &minMissed, MaxMissed: &maxMissed, Limit: 3})
+	if total != 4 || len(page) != 3 {
+		t.Fatalf("expected 3 of 4 matches, got %d of %d", len(page), total)
+	}
+	page, _ = idx.Query(MinipoolPerformanceQuery{MinMissed: &minMissed, MaxMissed: &maxMissed, Offset: 3, Limit: 3})
+	if len(page) != 1 || page[0].MissedAttestations != 3 {
+		t.Fatalf("unexpected second page: %v", page)
+	}
+
+	// Node combined with a range
+	page, total = idx.Query(MinipoolPerformanceQuery{Node: &nodeA, MinMissed: &minMissed})
+	if total != 2 {
+		t.Fatalf("expected 2 matches for node A, got %d", total)
+	}
+	for _, entry := range page {
+		if entry.Node != nodeA {
+			t.Fatalf("entry for %s belongs to %s", entry.Minipool.Hex(), entry.Node.Hex())
+		}
+	}
+
+	// Pubkey, with a 0x prefix the file doesn't use
+	pubkey := "0x" + common.Bytes2Hex(common.LeftPadBytes([]byte{7}, 48))
+	page, total = idx.Query(MinipoolPerformanceQuery{Pubkey: pubkey})
+	if total != 1 || page[0].Minipool != common.BigToAddress(big.NewInt(106)) {
+		t.Fatalf("unexpected pubkey match: %v", page)
+	}
+
+	// Offset past the end
+	page, total = idx.Query(MinipoolPerformanceQuery{Offset: 50, Limit: 10})
+	if total != 10 || len(page) != 0 {
+		t.Fatalf("expected an empty page of 10 matches, got %d of %d", len(page), total)
+	}
+}
@@ -0,0 +1,138 @@
+package rewards
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// How often an in-flight tree generation reports its progress
+const progressReportInterval = 10 * time.Second
+
+// The progress of a rewards tree generation as it works through the epochs of an interval
+type TreeGenerationProgress struct {
+	Index           uint64    `json:"index"`
+	StartEpoch      uint64    `json:"startEpoch"`
+	EndEpoch        uint64    `json:"endEpoch"`
+	CurrentEpoch    uint64    `json:"currentEpoch"`
+	EpochsPerSecond float64   `json:"epochsPerSecond"`
+	StartTime       time.Time `json:"startTime"`
+	UpdateTime      time.Time `json:"updateTime"`
+	Eta             time.Time `json:"eta"`
+	Deadline        time.Time `json:"deadline"`
+	Complete        bool      `json:"complete"`
+}
+
+// Get the percentage of the interval's epochs that have been processed
+func (p *TreeGenerationProgress) GetPercent() float64 {
+	if p.Complete {
+		return 100
+	}
+	if p.EndEpoch <= p.StartEpoch {
+		return 0
+	}
+	return float64(p.CurrentEpoch-p.StartEpoch) / float64(p.EndEpoch-p.StartEpoch) * 100
+}
+
+// Check if the generation isn't expected to finish before its deadline
+func (p *TreeGenerationProgress) WillMissDeadline() bool {
+	if p.Complete || p.Deadline.IsZero() || p.Eta.IsZero() {
+		return false
+	}
+	return p.Eta.After(p.Deadline)
+}
+
+// Tracks the processing rate of a tree generation and periodically reports it
+type progressTracker struct {
+	progress   TreeGenerationProgress
+	callback   func(TreeGenerationProgress)
+	lastReport time.Time
+}
+
+// Create a new progress tracker; a zero deadline means the generation has no deadline
+func newProgressTracker(index uint64, deadline time.Time, callback func(TreeGenerationProgress)) *progressTracker {
+	return &progressTracker{
+		progress: TreeGenerationProgress{
+			Index:    index,
+			Deadline: deadline,
+		},
+		callback: callback,
+	}
+}
+
+// Mark the start of the epoch range that will be processed
+func (t *progressTracker) start(startEpoch uint64, endEpoch uint64) {
+	now := time.Now()
+	t.progress.StartEpoch = startEpoch
+	t.progress.EndEpoch = endEpoch
+	t.progress.CurrentEpoch = startEpoch
+	t.progress.StartTime = now
+	t.progress.UpdateTime = now
+	t.lastReport = now
+	t.callback(t.progress)
+}
+
+// Record that an epoch has been processed, reporting the progress if enough time has passed since the last report
+func (t *progressTracker) update(epoch uint64) {
+	now := time.Now()
+	t.progress.CurrentEpoch = epoch
+	t.progress.UpdateTime = now
+
+	elapsed := now.Sub(t.progress.StartTime).Seconds()
+	processed := epoch - t.progress.StartEpoch
+	if elapsed > 0 && processed > 0 {
+		t.progress.EpochsPerSecond = float64(processed) / elapsed
+		remaining := float64(t.progress.EndEpoch-epoch) / t.progress.EpochsPerSecond
+		t.progress.Eta = now.Add(time.Duration(remaining * float64(time.Second)))
+	}
+
+	if now.Sub(t.lastReport) >= progressReportInterval {
+		t.lastReport = now
+		t.callback(t.progress)
+	}
+}
+
+// Mark the generation as finished and report it
+func (t *progressTracker) finish() {
+	now := time.Now()
+	t.progress.CurrentEpoch = t.progress.EndEpoch
+	t.progress.UpdateTime = now
+	t.progress.Eta = now
+	t.progress.Complete = true
+	t.callback(t.progress)
+}
+
+// Save the progress of a tree generation to disk
+func SaveTreeGenerationProgress(path string, progress TreeGenerationProgress) error {
+	bytes, err := json.Marshal(progress)
+	if err != nil {
+		return fmt.Errorf("error serializing tree generation progress: %w", err)
+	}
+
+	err = files.WriteFileAtomic(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error saving tree generation progress: %w", err)
+	}
+	return nil
+}
+
+// Load the progress of the last tree generation from disk; returns nil if there isn't one
+func LoadTreeGenerationProgress(path string) (*TreeGenerationProgress, error) {
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading tree generation progress from [%s]: %w", path, err)
+	}
+
+	var progress TreeGenerationProgress
+	err = json.Unmarshal(bytes, &progress)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing tree generation progress from [%s]: %w", path, err)
+	}
+	return &progress, nil
+}
@@ -0,0 +1,240 @@
+package rewards
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+)
+
+// An alternative way of scoring attestations, for comparing against the canonical ruleset. Research scorers never
+// change the rewards tree; their scores are only reported in a separate, non-canonical file.
+type AttestationScorer interface {
+	// Get the name the scorer's results are reported under
+	GetName() string
+
+	// Score a successful attestation. canonicalScore is the score the ruleset gave it (the minipool's share of a full
+	// validator, in wei) and must not be modified; inclusionDelay is how many slots after its duty it was included.
+	Score(canonicalScore *big.Int, inclusionDelay uint64) *big.Int
+}
+
+// The built-in research scorers
+const (
+	// Scales the canonical score by 1 / inclusion delay
+	AttestationScorer_InclusionDelay string = "inclusion-delay"
+
+	// Scales the canonical score by the share of the Altair source, target, and head rewards the attestation would earn
+	// if its votes were correct, given its inclusion delay
+	AttestationScorer_Timeliness string = "timeliness"
+
+	// Scores every attestation the same regardless of the minipool's bond and commission
+	AttestationScorer_Flat string = "flat"
+)
+
+// Altair attestation reward weights, and the latest inclusion delay each one is paid for
+const (
+	timelySourceWeight   uint64 = 14
+	timelyTargetWeight   uint64 = 26
+	timelyHeadWeight     uint64 = 14
+	timelySourceMaxDelay uint64 = 5
+	timelyHeadMaxDelay   uint64 = 1
+)
+
+type inclusionDelayScorer struct{}
+
+func (s *inclusionDelayScorer) GetName() string {
+	return AttestationScorer_InclusionDelay
+}
+
+func (s *inclusionDelayScorer) Score(canonicalScore *big.Int, inclusionDelay uint64) *big.Int {
+	if inclusionDelay == 0 {
+		inclusionDelay = 1
+	}
+	return new(big.Int).Div(canonicalScore, new(big.Int).SetUint64(inclusionDelay))
+}
+
+type timelinessScorer struct{}
+
+func (s *timelinessScorer) GetName() string {
+	return AttestationScorer_Timeliness
+}
+
+func (s *timelinessScorer) Score(canonicalScore *big.Int, inclusionDelay uint64) *big.Int {
+	// Attestations are only included at all within an epoch, so the target reward always applies
+	weight := timelyTargetWeight
+	if inclusionDelay <= timelySourceMaxDelay {
+		weight += timelySourceWeight
+	}
+	if inclusionDelay <= timelyHeadMaxDelay {
+		weight += timelyHeadWeight
+	}
+	score := new(big.Int).Mul(canonicalScore, new(big.Int).SetUint64(weight))
+	return score.Div(score, new(big.Int).SetUint64(timelySourceWeight+timelyTargetWeight+timelyHeadWeight))
+}
+
+type flatScorer struct{}
+
+func (s *flatScorer) GetName() string {
+	return AttestationScorer_Flat
+}
+
+func (s *flatScorer) Score(canonicalScore *big.Int, inclusionDelay uint64) *big.Int {
+	return new(big.Int).Set(oneEth)
+}
+
+// Get the research scorers from a comma-separated list of their names; a blank list means no research scoring
+func ParseAttestationScorers(value string) ([]AttestationScorer, error) {
+	scorers := []AttestationScorer{}
+	seen := map[string]bool{}
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		switch name {
+		case AttestationScorer_InclusionDelay:
+			scorers = append(scorers, &inclusionDelayScorer{})
+		case AttestationScorer_Timeliness:
+			scorers = append(scorers, &timelinessScorer{})
+		case AttestationScorer_Flat:
+			scorers = append(scorers, &flatScorer{})
+		default:
+			return nil, fmt.Errorf("unknown attestation scorer [%s]; the options are %s, %s, and %s", name, AttestationScorer_InclusionDelay, AttestationScorer_Timeliness, AttestationScorer_Flat)
+		}
+	}
+	return scorers, nil
+}
+
+// Attestation scores under the canonical ruleset and each research scorer, side by side.
+// This file is for research only and has no bearing on the rewards tree.
+type ResearchScoresFile struct {
+	Index          uint64   `json:"index"`
+	RulesetVersion uint64   `json:"rulesetVersion"`
+	Network        string   `json:"network"`
+	Scorers        []string `json:"scorers"`
+
+	// The canonical total attestation score and each scorer's total
+	CanonicalTotal *QuotedBigInt            `json:"canonicalTotal"`
+	Totals         map[string]*QuotedBigInt `json:"totals"`
+
+	Nodes []*NodeResearchScores `json:"nodes"`
+}
+
+// A node's attestation scores, and the share of the interval's total each one gives it
+type NodeResearchScores struct {
+	Address        common.Address           `json:"address"`
+	CanonicalScore *QuotedBigInt            `json:"canonicalScore"`
+	CanonicalShare float64                  `json:"canonicalShare"`
+	Scores         map[string]*QuotedBigInt `json:"scores"`
+	Shares         map[string]float64       `json:"shares"`
+}
+
+// Accumulates research scores while the generator checks attestations
+type researchScoreTracker struct {
+	scorers         []AttestationScorer
+	canonicalTotal  *big.Int
+	canonicalByNode map[common.Address]*big.Int
+	totals          map[string]*big.Int
+	byNode          map[string]map[common.Address]*big.Int
+}
+
+func newResearchScoreTracker(scorers []AttestationScorer) *researchScoreTracker {
+	tracker := &researchScoreTracker{
+		scorers:         scorers,
+		canonicalTotal:  big.NewInt(0),
+		canonicalByNode: map[common.Address]*big.Int{},
+		totals:          map[string]*big.Int{},
+		byNode:          map[string]map[common.Address]*big.Int{},
+	}
+	for _, scorer := range scorers {
+		tracker.totals[scorer.GetName()] = big.NewInt(0)
+		tracker.byNode[scorer.GetName()] = map[common.Address]*big.Int{}
+	}
+	return tracker
+}
+
+// Score a successful attestation with every research scorer
+func (t *researchScoreTracker) add(node common.Address, canonicalScore *big.Int, inclusionDelay uint64) {
+	addTo(t.canonicalByNode, node, canonicalScore)
+	t.canonicalTotal.Add(t.canonicalTotal, canonicalScore)
+	for _, scorer := range t.scorers {
+		name := scorer.GetName()
+		score := scorer.Score(canonicalScore, inclusionDelay)
+		addTo(t.byNode[name], node, score)
+		t.totals[name].Add(t.totals[name], score)
+	}
+}
+
+// Build the report for the interval
+func (t *researchScoreTracker) getFile(index uint64, rulesetVersion uint64, network string) *ResearchScoresFile {
+	file := &ResearchScoresFile{
+		Index:          index,
+		RulesetVersion: rulesetVersion,
+		Network:        network,
+		Scorers:        []string{},
+		CanonicalTotal: QuotedBigIntFromBigInt(t.canonicalTotal),
+		Totals:         map[string]*QuotedBigInt{},
+		Nodes:          []*NodeResearchScores{},
+	}
+	for _, scorer := range t.scorers {
+		name := scorer.GetName()
+		file.Scorers = append(file.Scorers, name)
+		file.Totals[name] = QuotedBigIntFromBigInt(t.totals[name])
+	}
+	for node, canonicalScore := range t.canonicalByNode {
+		nodeScores := &NodeResearchScores{
+			Address:        node,
+			CanonicalScore: QuotedBigIntFromBigInt(canonicalScore),
+			CanonicalShare: getShare(canonicalScore, t.canonicalTotal),
+			Scores:         map[string]*QuotedBigInt{},
+			Shares:         map[string]float64{},
+		}
+		for _, scorer := range t.scorers {
+			name := scorer.GetName()
+			score := t.byNode[name][node]
+			nodeScores.Scores[name] = QuotedBigIntFromBigInt(score)
+			nodeScores.Shares[name] = getShare(score, t.totals[name])
+		}
+		file.Nodes = append(file.Nodes, nodeScores)
+	}
+	sort.Slice(file.Nodes, func(i, j int) bool {
+		return file.Nodes[i].Address.Cmp(file.Nodes[j].Address) < 0
+	})
+	return file
+}
+
+// Save the research scores to disk
+func (f *ResearchScoresFile) Save(path string) error {
+	bytes, err := json.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("error serializing research scores: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return fmt.Errorf("error writing research scores to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Add an amount to an address's running total
+func addTo(totals map[common.Address]*big.Int, address common.Address, amount *big.Int) {
+	total, exists := totals[address]
+	if !exists {
+		total = big.NewInt(0)
+		totals[address] = total
+	}
+	total.Add(total, amount)
+}
+
+// Get a score's share of a total as a fraction
+func getShare(score *big.Int, total *big.Int) float64 {
+	if score == nil || total.Sign() == 0 {
+		return 0
+	}
+	share, _ := new(big.Rat).SetFrac(score, total).Float64()
+	return share
+}
@@ -0,0 +1,53 @@
+package rewards
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestResearchScoring(t *testing.T) {
+	scorers, err := ParseAttestationScorers(" timeliness, inclusion-delay,timeliness,")
+	if err != nil {
+		t.Fatalf("unexpected error parsing scorers: %s", err)
+	}
+	if len(scorers) != 2 || scorers[0].GetName() != AttestationScorer_Timeliness || scorers[1].GetName() != AttestationScorer_InclusionDelay {
+		t.Fatalf("expected the timeliness and inclusion-delay scorers, got %v", scorers)
+	}
+	if _, err := ParseAttestationScorers("bogus"); err == nil {
+		t.Fatal("expected an error for an unknown scorer")
+	}
+
+	nodeA := common.HexToAddress("0xa")
+	nodeB := common.HexToAddress("0xb")
+	tracker := newResearchScoreTracker(scorers)
+
+	// A perfect attestation from A, and a late one from B that misses the source and head windows
+	score := big.NewInt(54)
+	tracker.add(nodeA, score, 1)
+	tracker.add(nodeB, score, 6)
+	if score.Int64() != 54 {
+		t.Fatalf("scorers modified the canonical score to %s", score.String())
+	}
+
+	file := tracker.getFile(1, 10, "mainnet")
+	if file.CanonicalTotal.Int64() != 108 {
+		t.Fatalf("expected a canonical total of 108, got %s", file.CanonicalTotal.String())
+	}
+	if len(file.Nodes) != 2 || file.Nodes[0].Address != nodeA {
+		t.Fatalf("expected 2 nodes sorted by address, got %d", len(file.Nodes))
+	}
+	if file.Nodes[0].CanonicalShare != 0.5 {
+		t.Fatalf("expected a 0.5 canonical share, got %f", file.Nodes[0].CanonicalShare)
+	}
+	if file.Nodes[1].Scores[AttestationScorer_Timeliness].Int64() != 26 {
+		t.Fatalf("expected a timeliness score of 26, got %s", file.Nodes[1].Scores[AttestationScorer_Timeliness].String())
+	}
+	if file.Nodes[1].Scores[AttestationScorer_InclusionDelay].Int64() != 9 {
+		t.Fatalf("expected an inclusion delay score of 9, got %s", file.Nodes[1].Scores[AttestationScorer_InclusionDelay].String())
+	}
+	if file.Totals[AttestationScorer_InclusionDelay].Int64() != 63 {
+		t.Fatalf("expected an inclusion delay total of 63, got %s", file.Totals[AttestationScorer_InclusionDelay].String())
+	}
+}
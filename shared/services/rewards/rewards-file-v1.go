@@ -103,6 +103,9 @@ func (p *SmoothingPoolMinipoolPerformance_v1) GetConsensusIncome() *big.Int {
 func (p *SmoothingPoolMinipoolPerformance_v1) GetAttestationScore() *big.Int {
 	return big.NewInt(0)
 }
+func (p *SmoothingPoolMinipoolPerformance_v1) GetAverageInclusionDelay() float64 {
+	return 0
+}
 
 // Node operator rewards
 type NodeRewardsInfo_v1 struct {
@@ -153,6 +156,11 @@ func (f *RewardsFile_v1) GetRewardsFileVersion() uint64 {
 	return rewardsFileVersionOne
 }
 
+// Get the ruleset version used to generate the rewards file
+func (f *RewardsFile_v1) GetRulesetVersion() uint64 {
+	return f.RewardsFileHeader.RulesetVersion
+}
+
 // Get the rewards file index
 func (f *RewardsFile_v1) GetIndex() uint64 {
 	return f.RewardsFileHeader.Index
@@ -279,6 +287,14 @@ func (f *RewardsFile_v1) GetNodeSmoothingPoolEth(addr common.Address) *big.Int {
 	return &nr.SmoothingPoolEth.Int
 }
 
+func (f *RewardsFile_v1) GetNodeRewardNetwork(addr common.Address) uint64 {
+	nr, ok := f.NodeRewards[addr]
+	if !ok {
+		return 0
+	}
+	return nr.RewardNetwork
+}
+
 // Getters for network info
 func (f *RewardsFile_v1) HasRewardsForNetwork(network uint64) bool {
 	_, ok := f.NetworkRewards[network]
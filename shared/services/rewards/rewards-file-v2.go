@@ -114,6 +114,9 @@ func (p *SmoothingPoolMinipoolPerformance_v2) GetConsensusIncome() *big.Int {
 func (p *SmoothingPoolMinipoolPerformance_v2) GetAttestationScore() *big.Int {
 	return &p.AttestationScore.Int
 }
+func (p *SmoothingPoolMinipoolPerformance_v2) GetAverageInclusionDelay() float64 {
+	return 0
+}
 
 // Node operator rewards
 type NodeRewardsInfo_v2 struct {
@@ -163,6 +166,11 @@ func (f *RewardsFile_v2) GetRewardsFileVersion() uint64 {
 	return rewardsFileVersionTwo
 }
 
+// Get the ruleset version used to generate the rewards file
+func (f *RewardsFile_v2) GetRulesetVersion() uint64 {
+	return f.RewardsFileHeader.RulesetVersion
+}
+
 // Get the rewards file index
 func (f *RewardsFile_v2) GetIndex() uint64 {
 	return f.RewardsFileHeader.Index
@@ -289,6 +297,14 @@ func (f *RewardsFile_v2) GetNodeSmoothingPoolEth(addr common.Address) *big.Int {
 	return &nr.SmoothingPoolEth.Int
 }
 
+func (f *RewardsFile_v2) GetNodeRewardNetwork(addr common.Address) uint64 {
+	nr, ok := f.NodeRewards[addr]
+	if !ok {
+		return 0
+	}
+	return nr.RewardNetwork
+}
+
 // Getters for network info
 func (f *RewardsFile_v2) HasRewardsForNetwork(network uint64) bool {
 	_, ok := f.NetworkRewards[network]
@@ -13,6 +13,69 @@ import (
 	"github.com/wealdtech/go-merkletree/keccak256"
 )
 
+type MinipoolPerformanceFile_v3 struct {
+	RewardsFileVersion         uint64                                                  `json:"rewardsFileVersion"`
+	MinipoolPerformanceVersion uint64                                                  `json:"minipoolPerformanceVersion"`
+	RulesetVersion             uint64                                                  `json:"rulesetVersion"`
+	Index                      uint64                                                  `json:"index"`
+	Network                    string                                                  `json:"network"`
+	StartTime                  time.Time                                               `json:"startTime,omitempty"`
+	EndTime                    time.Time                                               `json:"endTime,omitempty"`
+	ConsensusStartBlock        uint64                                                  `json:"consensusStartBlock,omitempty"`
+	ConsensusEndBlock          uint64                                                  `json:"consensusEndBlock,omitempty"`
+	ExecutionStartBlock        uint64                                                  `json:"executionStartBlock,omitempty"`
+	ExecutionEndBlock          uint64                                                  `json:"executionEndBlock,omitempty"`
+	MinipoolPerformance        map[common.Address]*SmoothingPoolMinipoolPerformance_v3 `json:"minipoolPerformance"`
+	BonusScalar                *QuotedBigInt                                           `json:"bonusScalar,omitempty"`
+}
+
+// Serialize a minipool performance file into bytes
+func (f *MinipoolPerformanceFile_v3) Serialize() ([]byte, error) {
+	return json.Marshal(f)
+}
+
+func (f *MinipoolPerformanceFile_v3) SerializeSSZ() ([]byte, error) {
+	return nil, fmt.Errorf("ssz format not implemented for minipool performance files")
+}
+
+// Serialize a minipool performance file into bytes designed for human readability
+func (f *MinipoolPerformanceFile_v3) SerializeHuman() ([]byte, error) {
+	return json.MarshalIndent(f, "", "\t")
+}
+
+// Deserialize a minipool performance file from bytes
+func (f *MinipoolPerformanceFile_v3) Deserialize(bytes []byte) error {
+	return json.Unmarshal(bytes, &f)
+}
+
+// Get all of the minipool addresses with rewards in this file
+// NOTE: the order of minipool addresses is not guaranteed to be stable, so don't rely on it
+func (f *MinipoolPerformanceFile_v3) GetMinipoolAddresses() []common.Address {
+	addresses := make([]common.Address, len(f.MinipoolPerformance))
+	i := 0
+	for address := range f.MinipoolPerformance {
+		addresses[i] = address
+		i++
+	}
+	return addresses
+}
+
+// Get a minipool's smoothing pool performance if it was present
+func (f *MinipoolPerformanceFile_v3) GetSmoothingPoolPerformance(minipoolAddress common.Address) (ISmoothingPoolMinipoolPerformance, bool) {
+	perf, exists := f.MinipoolPerformance[minipoolAddress]
+	return perf, exists
+}
+
+// Minipool stats, including how quickly the minipool's attestations were included
+type SmoothingPoolMinipoolPerformance_v3 struct {
+	SmoothingPoolMinipoolPerformance_v2
+	AverageInclusionDelay float64 `json:"averageInclusionDelay"`
+}
+
+func (p *SmoothingPoolMinipoolPerformance_v3) GetAverageInclusionDelay() float64 {
+	return p.AverageInclusionDelay
+}
+
 // JSON struct for a complete rewards file
 type RewardsFile_v3 struct {
 	*RewardsFileHeader
@@ -53,6 +116,11 @@ func (f *RewardsFile_v3) GetRewardsFileVersion() uint64 {
 	return rewardsFileVersionThree
 }
 
+// Get the ruleset version used to generate the rewards file
+func (f *RewardsFile_v3) GetRulesetVersion() uint64 {
+	return f.RewardsFileHeader.RulesetVersion
+}
+
 // Get the rewards file index
 func (f *RewardsFile_v3) GetIndex() uint64 {
 	return f.RewardsFileHeader.Index
@@ -179,6 +247,14 @@ func (f *RewardsFile_v3) GetNodeSmoothingPoolEth(addr common.Address) *big.Int {
 	return &nr.SmoothingPoolEth.Int
 }
 
+func (f *RewardsFile_v3) GetNodeRewardNetwork(addr common.Address) uint64 {
+	nr, ok := f.NodeRewards[addr]
+	if !ok {
+		return 0
+	}
+	return nr.RewardNetwork
+}
+
 func (f *RewardsFile_v3) GetMerkleProof(addr common.Address) ([]common.Hash, error) {
 	nr, ok := f.getNodeRewardsInfo(addr)
 	if !ok {
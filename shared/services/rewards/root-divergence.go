@@ -0,0 +1,181 @@
+package rewards
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+)
+
+// The categories a difference between two rewards trees can fall into
+const (
+	DivergenceCategory_Metadata         string = "metadata"
+	DivergenceCategory_Totals           string = "totals"
+	DivergenceCategory_NodeSet          string = "node set"
+	DivergenceCategory_CollateralRpl    string = "collateral RPL"
+	DivergenceCategory_OracleDaoRpl     string = "Oracle DAO RPL"
+	DivergenceCategory_SmoothingPoolEth string = "smoothing pool ETH"
+	DivergenceCategory_RewardNetwork    string = "reward network"
+)
+
+// A single value that differs between the locally generated tree and the consensus one
+type RewardsDivergence struct {
+	Category  string          `json:"category"`
+	Field     string          `json:"field"`
+	Node      *common.Address `json:"node,omitempty"`
+	Local     string          `json:"local"`
+	Consensus string          `json:"consensus"`
+}
+
+// A structured comparison of a locally generated rewards tree with the one the Oracle DAO reached consensus on
+type RootDivergenceReport struct {
+	Index         uint64 `json:"index"`
+	LocalRoot     string `json:"localRoot"`
+	ConsensusRoot string `json:"consensusRoot"`
+
+	// The first node, in address order, whose rewards differ between the trees, and how they differ.
+	// If no node differs, the category is the first tree-wide difference.
+	FirstDivergentNode     *common.Address `json:"firstDivergentNode,omitempty"`
+	FirstDivergentCategory string          `json:"firstDivergentCategory"`
+
+	Divergences []RewardsDivergence `json:"divergences"`
+	GeneratedAt time.Time           `json:"generatedAt"`
+}
+
+// Compare a locally generated rewards tree with the consensus one
+func DiffRewardsFiles(local IRewardsFile, consensus IRewardsFile) *RootDivergenceReport {
+	report := &RootDivergenceReport{
+		Index:         consensus.GetIndex(),
+		LocalRoot:     local.GetMerkleRoot(),
+		ConsensusRoot: consensus.GetMerkleRoot(),
+		Divergences:   []RewardsDivergence{},
+		GeneratedAt:   time.Now().UTC(),
+	}
+
+	// Tree-wide values
+	metadata := []struct {
+		field            string
+		local, consensus uint64
+	}{
+		{"ruleset version", local.GetRulesetVersion(), consensus.GetRulesetVersion()},
+		{"intervals passed", local.GetIntervalsPassed(), consensus.GetIntervalsPassed()},
+		{"consensus start block", local.GetConsensusStartBlock(), consensus.GetConsensusStartBlock()},
+		{"consensus end block", local.GetConsensusEndBlock(), consensus.GetConsensusEndBlock()},
+		{"execution start block", local.GetExecutionStartBlock(), consensus.GetExecutionStartBlock()},
+		{"execution end block", local.GetExecutionEndBlock(), consensus.GetExecutionEndBlock()},
+	}
+	for _, value := range metadata {
+		if value.local != value.consensus {
+			report.add(DivergenceCategory_Metadata, value.field, nil, fmt.Sprint(value.local), fmt.Sprint(value.consensus))
+		}
+	}
+	totals := []struct {
+		field            string
+		local, consensus *big.Int
+	}{
+		{"collateral RPL", local.GetTotalCollateralRpl(), consensus.GetTotalCollateralRpl()},
+		{"Oracle DAO RPL", local.GetTotalOracleDaoRpl(), consensus.GetTotalOracleDaoRpl()},
+		{"protocol DAO RPL", local.GetTotalProtocolDaoRpl(), consensus.GetTotalProtocolDaoRpl()},
+		{"node operator smoothing pool ETH", local.GetTotalNodeOperatorSmoothingPoolEth(), consensus.GetTotalNodeOperatorSmoothingPoolEth()},
+		{"pool staker smoothing pool ETH", local.GetTotalPoolStakerSmoothingPoolEth(), consensus.GetTotalPoolStakerSmoothingPoolEth()},
+		{"node weight", local.GetTotalNodeWeight(), consensus.GetTotalNodeWeight()},
+	}
+	for _, value := range totals {
+		if !amountsEqual(value.local, value.consensus) {
+			report.add(DivergenceCategory_Totals, value.field, nil, formatAmount(value.local), formatAmount(value.consensus))
+		}
+	}
+
+	// Per-node values, in address order so the first divergent node is deterministic
+	addresses := map[common.Address]bool{}
+	for _, address := range local.GetNodeAddresses() {
+		addresses[address] = true
+	}
+	for _, address := range consensus.GetNodeAddresses() {
+		addresses[address] = true
+	}
+	sorted := make([]common.Address, 0, len(addresses))
+	for address := range addresses {
+		sorted = append(sorted, address)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i][:], sorted[j][:]) < 0
+	})
+	for _, address := range sorted {
+		address := address
+		first := len(report.Divergences)
+		inLocal := local.HasRewardsFor(address)
+		inConsensus := consensus.HasRewardsFor(address)
+		if inLocal != inConsensus {
+			report.add(DivergenceCategory_NodeSet, "included", &address, fmt.Sprint(inLocal), fmt.Sprint(inConsensus))
+		} else {
+			if network, consensusNetwork := local.GetNodeRewardNetwork(address), consensus.GetNodeRewardNetwork(address); network != consensusNetwork {
+				report.add(DivergenceCategory_RewardNetwork, "reward network", &address, fmt.Sprint(network), fmt.Sprint(consensusNetwork))
+			}
+			nodeAmounts := []struct {
+				category         string
+				local, consensus *big.Int
+			}{
+				{DivergenceCategory_CollateralRpl, local.GetNodeCollateralRpl(address), consensus.GetNodeCollateralRpl(address)},
+				{DivergenceCategory_OracleDaoRpl, local.GetNodeOracleDaoRpl(address), consensus.GetNodeOracleDaoRpl(address)},
+				{DivergenceCategory_SmoothingPoolEth, local.GetNodeSmoothingPoolEth(address), consensus.GetNodeSmoothingPoolEth(address)},
+			}
+			for _, value := range nodeAmounts {
+				if !amountsEqual(value.local, value.consensus) {
+					report.add(value.category, value.category, &address, formatAmount(value.local), formatAmount(value.consensus))
+				}
+			}
+		}
+		if report.FirstDivergentNode == nil && len(report.Divergences) > first {
+			report.FirstDivergentNode = &address
+			report.FirstDivergentCategory = report.Divergences[first].Category
+		}
+	}
+
+	if report.FirstDivergentNode == nil && len(report.Divergences) > 0 {
+		report.FirstDivergentCategory = report.Divergences[0].Category
+	}
+	return report
+}
+
+func (r *RootDivergenceReport) add(category string, field string, node *common.Address, local string, consensus string) {
+	r.Divergences = append(r.Divergences, RewardsDivergence{
+		Category:  category,
+		Field:     field,
+		Node:      node,
+		Local:     local,
+		Consensus: consensus,
+	})
+}
+
+// Check if two amounts are equal, treating missing amounts as zero
+func amountsEqual(a *big.Int, b *big.Int) bool {
+	if a == nil {
+		a = big.NewInt(0)
+	}
+	if b == nil {
+		b = big.NewInt(0)
+	}
+	return a.Cmp(b) == 0
+}
+
+// Save the report to disk
+func (r *RootDivergenceReport) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "\t")
+	if err != nil {
+		return fmt.Errorf("error serializing root divergence report: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating root divergence report directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("error saving root divergence report to %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package rewards
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Get a test file with every total set, as generated trees have
+func newDivergenceTestFile(nodeCount int) *RewardsFile_v3 {
+	f := newCrossCheckTestFile(nodeCount)
+	f.TotalRewards.ProtocolDaoRpl = NewQuotedBigInt(0)
+	f.TotalRewards.PoolStakerSmoothingPoolEth = NewQuotedBigInt(0)
+	f.TotalRewards.TotalNodeWeight = NewQuotedBigInt(0)
+	return f
+}
+
+func TestDiffRewardsFiles(t *testing.T) {
+	local := newDivergenceTestFile(5)
+	consensus := newDivergenceTestFile(5)
+
+	// Identical trees don't diverge
+	report := DiffRewardsFiles(local, consensus)
+	if len(report.Divergences) != 0 || report.FirstDivergentNode != nil {
+		t.Fatalf("expected no divergences, got %v", report.Divergences)
+	}
+
+	// Change node 3's smoothing pool ETH (and the total with it) and node 4's reward network
+	node3 := common.BigToAddress(big.NewInt(3))
+	node4 := common.BigToAddress(big.NewInt(4))
+	consensus.NodeRewards[node3].SmoothingPoolEth = NewQuotedBigInt(1)
+	consensus.TotalRewards.NodeOperatorSmoothingPoolEth = NewQuotedBigInt(1)
+	consensus.NodeRewards[node4].RewardNetwork = 2
+	report = DiffRewardsFiles(local, consensus)
+	if report.FirstDivergentNode == nil || *report.FirstDivergentNode != node3 || report.FirstDivergentCategory != DivergenceCategory_SmoothingPoolEth {
+		t.Fatalf("expected node %s to diverge first in %s, got %v in %s", node3.Hex(), DivergenceCategory_SmoothingPoolEth, report.FirstDivergentNode, report.FirstDivergentCategory)
+	}
+	categories := []string{}
+	for _, divergence := range report.Divergences {
+		categories = append(categories, divergence.Category)
+	}
+	expected := []string{DivergenceCategory_Totals, DivergenceCategory_SmoothingPoolEth, DivergenceCategory_RewardNetwork}
+	if len(categories) != len(expected) {
+		t.Fatalf("expected divergences in %v, got %v", expected, categories)
+	}
+	for i := range expected {
+		if categories[i] != expected[i] {
+			t.Fatalf("expected divergences in %v, got %v", expected, categories)
+		}
+	}
+
+	// A node missing from the local tree is a node set divergence
+	report = DiffRewardsFiles(local, newDivergenceTestFile(6))
+	node6 := common.BigToAddress(big.NewInt(6))
+	if report.FirstDivergentNode == nil || *report.FirstDivergentNode != node6 || report.FirstDivergentCategory != DivergenceCategory_NodeSet {
+		t.Fatalf("expected node %s to diverge first in %s, got %v in %s", node6.Hex(), DivergenceCategory_NodeSet, report.FirstDivergentNode, report.FirstDivergentCategory)
+	}
+}
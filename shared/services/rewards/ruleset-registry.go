@@ -0,0 +1,82 @@
+package rewards
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Everything a ruleset needs to create a tree generator for an interval
+type RulesetGeneratorParams struct {
+	Logger           *log.ColorLogger
+	LogPrefix        string
+	Config           *config.RocketPoolConfig
+	Index            uint64
+	StartTime        time.Time
+	EndTime          time.Time
+	SnapshotEnd      *SnapshotEnd
+	ElSnapshotHeader *types.Header
+	IntervalsPassed  uint64
+	State            *state.NetworkState
+}
+
+// A rewards ruleset, which registers itself with RegisterRuleset so tree generation can select it automatically
+type Ruleset struct {
+	// The ruleset version recorded in the rewards files it generates
+	Version uint64
+
+	// The first interval the ruleset applies to on each network. The ruleset is never used on networks that aren't listed.
+	StartIntervals map[cfgtypes.Network]uint64
+
+	// Create the ruleset's tree generator for an interval, applying any of the Smartnode's treegen settings it supports
+	NewGenerator func(params *RulesetGeneratorParams) (RulesetGenerator, error)
+}
+
+// The registered rulesets, by version
+var rulesets = map[uint64]Ruleset{}
+
+// Register a ruleset so tree generation can use it. Rulesets register themselves in an init function, so a duplicate
+// version is a programming error and panics.
+func RegisterRuleset(ruleset Ruleset) {
+	if _, exists := rulesets[ruleset.Version]; exists {
+		panic(fmt.Sprintf("ruleset v%d is already registered", ruleset.Version))
+	}
+	if ruleset.NewGenerator == nil {
+		panic(fmt.Sprintf("ruleset v%d doesn't have a generator", ruleset.Version))
+	}
+	rulesets[ruleset.Version] = ruleset
+}
+
+// Get the registered rulesets, newest first
+func GetRulesets() []Ruleset {
+	sorted := make([]Ruleset, 0, len(rulesets))
+	for _, ruleset := range rulesets {
+		sorted = append(sorted, ruleset)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version > sorted[j].Version
+	})
+	return sorted
+}
+
+// Get the first interval a ruleset applies to on a network, and whether it applies to the network at all
+func (r Ruleset) GetStartInterval(network cfgtypes.Network) (uint64, bool) {
+	startInterval, exists := r.StartIntervals[network]
+	return startInterval, exists
+}
+
+// Get the newest ruleset that applies to an interval on a network
+func GetActiveRuleset(network cfgtypes.Network, interval uint64) (Ruleset, bool) {
+	for _, ruleset := range GetRulesets() {
+		if startInterval, exists := ruleset.GetStartInterval(network); exists && startInterval <= interval {
+			return ruleset, true
+		}
+	}
+	return Ruleset{}, false
+}
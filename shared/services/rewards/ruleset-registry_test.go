@@ -0,0 +1,67 @@
+package rewards
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+func TestGetRulesetVersion(t *testing.T) {
+	cases := []struct {
+		network  cfgtypes.Network
+		interval uint64
+		expected uint64
+	}{
+		{cfgtypes.Network_Mainnet, 0, 8},
+		{cfgtypes.Network_Mainnet, MainnetV8Interval, 8},
+		{cfgtypes.Network_Mainnet, MainnetV9Interval, 9},
+		{cfgtypes.Network_Mainnet, MainnetV10Interval, 10},
+		{cfgtypes.Network_Holesky, HoleskyV9Interval - 1, 8},
+		{cfgtypes.Network_Holesky, HoleskyV9Interval, 9},
+		{cfgtypes.Network_Holesky, HoleskyV10Interval + 100, 10},
+		{cfgtypes.Network_Devnet, 0, 10},
+		{cfgtypes.Network("unknown"), 0, 10},
+	}
+	for _, c := range cases {
+		if version := GetRulesetVersion(c.network, c.interval); version != c.expected {
+			t.Errorf("expected ruleset v%d for interval %d on %s, got v%d", c.expected, c.interval, c.network, version)
+		}
+	}
+}
+
+func TestRegisterDuplicateRuleset(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected registering ruleset v10 twice to panic")
+		}
+	}()
+	RegisterRuleset(Ruleset{
+		Version: 10,
+		NewGenerator: func(params *RulesetGeneratorParams) (RulesetGenerator, error) {
+			return nil, nil
+		},
+	})
+}
+
+func TestNewTreeGeneratorOnlyCreatesActiveRulesets(t *testing.T) {
+	cfg := config.NewRocketPoolConfig("", false)
+	cfg.Smartnode.Network.Value = cfgtypes.Network_Mainnet
+
+	// v8 rejects strict accounting, which shouldn't matter for an interval it isn't used for
+	cfg.Smartnode.RewardsAccountingPolicy.Value = "8=strict"
+	generator, err := NewTreeGenerator(nil, "", nil, cfg, nil, MainnetV10Interval+1, time.Time{}, time.Time{}, nil, nil, 1, nil)
+	if err != nil {
+		t.Fatalf("error creating the tree generator: %s", err.Error())
+	}
+	if version := generator.GetGeneratorRulesetVersion(); version != 10 {
+		t.Fatalf("expected ruleset v10, got v%d", version)
+	}
+
+	// The policy is still rejected for an interval v8 applies to
+	_, err = NewTreeGenerator(nil, "", nil, cfg, nil, MainnetV8Interval+1, time.Time{}, time.Time{}, nil, nil, 1, nil)
+	if err == nil {
+		t.Fatal("expected ruleset v8 to reject strict accounting")
+	}
+}
@@ -0,0 +1,371 @@
+package rewards
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// Where an ETH flow into or out of the smoothing pool came from
+type SmoothingPoolFlowKind string
+
+const (
+	// Priority fees or an MEV payment from a block proposed by an opted-in validator
+	SmoothingPoolFlowKind_Proposal SmoothingPoolFlowKind = "proposal"
+
+	// An ETH transfer to the smoothing pool outside of an opted-in validator's proposal
+	SmoothingPoolFlowKind_Transfer SmoothingPoolFlowKind = "transfer"
+
+	// The previous interval's rewards leaving the pool
+	SmoothingPoolFlowKind_Distribution SmoothingPoolFlowKind = "distribution"
+
+	// A balance change that couldn't be attributed to any of the above
+	SmoothingPoolFlowKind_Unexplained SmoothingPoolFlowKind = "unexplained"
+)
+
+// A single ETH flow into or out of the smoothing pool
+type SmoothingPoolFlow struct {
+	Kind           SmoothingPoolFlowKind `json:"kind"`
+	BlockNumber    uint64                `json:"blockNumber"`
+	Slot           uint64                `json:"slot,omitempty"`
+	ValidatorIndex string                `json:"validatorIndex,omitempty"`
+	NodeAddress    common.Address        `json:"nodeAddress,omitempty"`
+	Sender         common.Address        `json:"sender,omitempty"`
+	TxHash         common.Hash           `json:"txHash,omitempty"`
+	Amount         *QuotedBigInt         `json:"amount"`
+	Description    string                `json:"description,omitempty"`
+}
+
+// The smoothing pool's ETH flows over an interval, reconciled against its balance change
+type SmoothingPoolFlowReport struct {
+	Index           uint64              `json:"index"`
+	StartBlock      uint64              `json:"startBlock"`
+	EndBlock        uint64              `json:"endBlock"`
+	StartBalance    *QuotedBigInt       `json:"startBalance"`
+	EndBalance      *QuotedBigInt       `json:"endBalance"`
+	SnapshotBalance *QuotedBigInt       `json:"snapshotBalance"`
+	TracedTotal     *QuotedBigInt       `json:"tracedTotal"`
+	Flows           []SmoothingPoolFlow `json:"flows"`
+	Problems        []string            `json:"problems"`
+	GeneratedAt     time.Time           `json:"generatedAt"`
+}
+
+// Reconstructs the smoothing pool's ETH flows during an interval from EL history.
+// Transfers are found through the pool's EtherReceived events; priority fees are credited without a transaction, so
+// the blocks that credited them are found by bisecting the pool's balance history for changes the events don't cover.
+type SmoothingPoolFlowTracer struct {
+	rp                *rocketpool.RocketPool
+	bc                RewardsBeaconClient
+	networkState      *state.NetworkState
+	smoothingPool     common.Address
+	balances          map[uint64]*big.Int
+	validatorNodes    map[string]common.Address
+	distributionBlock uint64
+}
+
+// Create a tracer for the interval ending at the snapshot described by the network state. distributionBlock is the block
+// the previous interval's rewards were distributed in, or 0 if there wasn't one.
+func NewSmoothingPoolFlowTracer(rp *rocketpool.RocketPool, bc RewardsBeaconClient, networkState *state.NetworkState, distributionBlock uint64) *SmoothingPoolFlowTracer {
+	validatorNodes := map[string]common.Address{}
+	for _, mpd := range networkState.MinipoolDetails {
+		if validator, exists := networkState.ValidatorDetails[mpd.Pubkey]; exists {
+			validatorNodes[validator.Index] = mpd.NodeAddress
+		}
+	}
+	return &SmoothingPoolFlowTracer{
+		rp:                rp,
+		bc:                bc,
+		networkState:      networkState,
+		smoothingPool:     networkState.NetworkDetails.SmoothingPoolAddress,
+		balances:          map[uint64]*big.Int{},
+		validatorNodes:    validatorNodes,
+		distributionBlock: distributionBlock,
+	}
+}
+
+// Trace the pool's flows from startBlock through endBlock, inclusive
+func (t *SmoothingPoolFlowTracer) Trace(index uint64, startBlock uint64, endBlock uint64) (*SmoothingPoolFlowReport, error) {
+	report := &SmoothingPoolFlowReport{
+		Index:           index,
+		StartBlock:      startBlock,
+		EndBlock:        endBlock,
+		SnapshotBalance: QuotedBigIntFromBigInt(t.networkState.NetworkDetails.SmoothingPoolBalance),
+		Flows:           []SmoothingPoolFlow{},
+		Problems:        []string{},
+		GeneratedAt:     time.Now().UTC(),
+	}
+	startBalance, err := t.getBalance(startBlock - 1)
+	if err != nil {
+		return nil, err
+	}
+	endBalance, err := t.getBalance(endBlock)
+	if err != nil {
+		return nil, err
+	}
+	report.StartBalance = QuotedBigIntFromBigInt(startBalance)
+	report.EndBalance = QuotedBigIntFromBigInt(endBalance)
+
+	// Get the transfers
+	transfers, err := t.getTransfers(startBlock, endBlock)
+	if err != nil {
+		return nil, err
+	}
+	transferTotals := map[uint64]*big.Int{}
+	for _, transfer := range transfers {
+		total, exists := transferTotals[transfer.BlockNumber]
+		if !exists {
+			total = big.NewInt(0)
+			transferTotals[transfer.BlockNumber] = total
+		}
+		total.Add(total, &transfer.Amount.Int)
+	}
+
+	// Find the blocks with balance changes the transfers don't cover
+	transferTotal := func(lo uint64, hi uint64) *big.Int {
+		sum := big.NewInt(0)
+		for block, amount := range transferTotals {
+			if block >= lo && block <= hi {
+				sum.Add(sum, amount)
+			}
+		}
+		return sum
+	}
+	residuals, err := findUnexplainedBalanceChanges(startBlock, endBlock, t.getBalance, transferTotal)
+	if err != nil {
+		return nil, err
+	}
+
+	// Attribute everything to the blocks' proposers
+	proposers := map[uint64]blockProposer{}
+	getProposer := func(block uint64) (blockProposer, error) {
+		if proposer, exists := proposers[block]; exists {
+			return proposer, nil
+		}
+		proposer, err := t.getBlockProposer(block)
+		if err != nil {
+			return blockProposer{}, err
+		}
+		proposers[block] = proposer
+		return proposer, nil
+	}
+	for _, transfer := range transfers {
+		proposer, err := getProposer(transfer.BlockNumber)
+		if err != nil {
+			return nil, err
+		}
+		transfer.Slot = proposer.slot
+		transfer.ValidatorIndex = proposer.validatorIndex
+		// Builders pay the proposer's fee recipient from the block's coinbase
+		if proposer.optedIn && (proposer.feeRecipientIsPool || transfer.Sender == proposer.coinbase) {
+			transfer.Kind = SmoothingPoolFlowKind_Proposal
+			transfer.NodeAddress = proposer.nodeAddress
+			transfer.Description = "MEV payment"
+		}
+		report.Flows = append(report.Flows, transfer)
+	}
+	for block, amount := range residuals {
+		proposer, err := getProposer(block)
+		if err != nil {
+			return nil, err
+		}
+		flow := SmoothingPoolFlow{
+			Kind:           SmoothingPoolFlowKind_Unexplained,
+			BlockNumber:    block,
+			Slot:           proposer.slot,
+			ValidatorIndex: proposer.validatorIndex,
+			Amount:         QuotedBigIntFromBigInt(amount),
+		}
+		switch {
+		case amount.Sign() < 0 && block == t.distributionBlock:
+			flow.Kind = SmoothingPoolFlowKind_Distribution
+			flow.Description = "previous interval's rewards"
+		case amount.Sign() > 0 && proposer.feeRecipientIsPool && proposer.optedIn:
+			flow.Kind = SmoothingPoolFlowKind_Proposal
+			flow.NodeAddress = proposer.nodeAddress
+			flow.Description = "priority fees"
+		case amount.Sign() > 0 && proposer.feeRecipientIsPool:
+			flow.NodeAddress = proposer.nodeAddress
+			flow.Description = "priority fees from a validator that isn't opted into the smoothing pool"
+		default:
+			flow.Description = "balance change without a matching transfer or proposal"
+		}
+		report.Flows = append(report.Flows, flow)
+	}
+	sort.SliceStable(report.Flows, func(i, j int) bool { return report.Flows[i].BlockNumber < report.Flows[j].BlockNumber })
+
+	// Reconcile the flows against the balance changes
+	tracedTotal := big.NewInt(0)
+	for _, flow := range report.Flows {
+		tracedTotal.Add(tracedTotal, &flow.Amount.Int)
+		if flow.Kind == SmoothingPoolFlowKind_Unexplained {
+			report.Problems = append(report.Problems, fmt.Sprintf("block %d: %s wei (%s)", flow.BlockNumber, flow.Amount.String(), flow.Description))
+		}
+	}
+	report.TracedTotal = QuotedBigIntFromBigInt(tracedTotal)
+	delta := big.NewInt(0).Sub(endBalance, startBalance)
+	if delta.Cmp(tracedTotal) != 0 {
+		report.Problems = append(report.Problems, fmt.Sprintf("the traced flows total %s wei, but the balance changed by %s wei", tracedTotal.String(), delta.String()))
+	}
+	if endBalance.Cmp(&report.SnapshotBalance.Int) != 0 {
+		report.Problems = append(report.Problems, fmt.Sprintf("the balance at block %d is %s wei, but the snapshot state has %s wei", endBlock, endBalance.String(), report.SnapshotBalance.String()))
+	}
+	return report, nil
+}
+
+// Save the report to disk
+func (r *SmoothingPoolFlowReport) Save(path string) error {
+	bytes, err := json.MarshalIndent(r, "", "\t")
+	if err != nil {
+		return fmt.Errorf("error serializing smoothing pool flow report: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return fmt.Errorf("error writing smoothing pool flow report to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Get the pool's balance at the end of a block
+func (t *SmoothingPoolFlowTracer) getBalance(block uint64) (*big.Int, error) {
+	if balance, exists := t.balances[block]; exists {
+		return balance, nil
+	}
+	balance, err := t.rp.Client.BalanceAt(context.Background(), t.smoothingPool, big.NewInt(0).SetUint64(block))
+	if err != nil {
+		return nil, fmt.Errorf("error getting smoothing pool balance at block %d: %w", block, err)
+	}
+	t.balances[block] = balance
+	return balance, nil
+}
+
+// Get the ETH transfers to the pool from its EtherReceived events
+func (t *SmoothingPoolFlowTracer) getTransfers(startBlock uint64, endBlock uint64) ([]SmoothingPoolFlow, error) {
+	smoothingPool, err := t.rp.GetContract("rocketSmoothingPool", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting smoothing pool contract: %w", err)
+	}
+	event, exists := smoothingPool.ABI.Events["EtherReceived"]
+	if !exists {
+		return nil, fmt.Errorf("smoothing pool contract has no EtherReceived event")
+	}
+	logs, err := t.rp.Client.FilterLogs(context.Background(), ethereum.FilterQuery{
+		FromBlock: big.NewInt(0).SetUint64(startBlock),
+		ToBlock:   big.NewInt(0).SetUint64(endBlock),
+		Addresses: []common.Address{t.smoothingPool},
+		Topics:    [][]common.Hash{{event.ID}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting smoothing pool transfers: %w", err)
+	}
+
+	transfers := make([]SmoothingPoolFlow, 0, len(logs))
+	for _, log := range logs {
+		values, err := event.Inputs.NonIndexed().Unpack(log.Data)
+		if err != nil || len(values) == 0 {
+			return nil, fmt.Errorf("error unpacking smoothing pool transfer in transaction %s: %w", log.TxHash.Hex(), err)
+		}
+		amount, ok := values[0].(*big.Int)
+		if !ok {
+			return nil, fmt.Errorf("smoothing pool transfer in transaction %s has no amount", log.TxHash.Hex())
+		}
+		transfer := SmoothingPoolFlow{
+			Kind:        SmoothingPoolFlowKind_Transfer,
+			BlockNumber: log.BlockNumber,
+			TxHash:      log.TxHash,
+			Amount:      QuotedBigIntFromBigInt(amount),
+		}
+		if len(log.Topics) > 1 {
+			transfer.Sender = common.BytesToAddress(log.Topics[1].Bytes())
+		}
+		transfers = append(transfers, transfer)
+	}
+	return transfers, nil
+}
+
+// The validator that proposed an EL block
+type blockProposer struct {
+	slot               uint64
+	validatorIndex     string
+	nodeAddress        common.Address
+	coinbase           common.Address
+	feeRecipientIsPool bool
+	optedIn            bool
+}
+
+// Get the validator that proposed an EL block and whether it was opted into the smoothing pool at the time
+func (t *SmoothingPoolFlowTracer) getBlockProposer(block uint64) (blockProposer, error) {
+	header, err := t.rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(block))
+	if err != nil {
+		return blockProposer{}, fmt.Errorf("error getting header for block %d: %w", block, err)
+	}
+	slot := t.networkState.BeaconConfig.FirstSlotAtLeast(int64(header.Time))
+	beaconBlock, exists, err := t.bc.GetBeaconBlock(strconv.FormatUint(slot, 10))
+	if err != nil {
+		return blockProposer{}, fmt.Errorf("error getting Beacon block for slot %d: %w", slot, err)
+	}
+	proposer := blockProposer{
+		slot: slot,
+	}
+	if !exists || beaconBlock.ExecutionBlockNumber != block {
+		return proposer, nil
+	}
+	proposer.validatorIndex = beaconBlock.ProposerIndex
+	proposer.coinbase = header.Coinbase
+	proposer.feeRecipientIsPool = beaconBlock.FeeRecipient == t.smoothingPool
+	nodeAddress, exists := t.validatorNodes[beaconBlock.ProposerIndex]
+	if !exists {
+		return proposer, nil
+	}
+	proposer.nodeAddress = nodeAddress
+	node := t.networkState.NodeDetailsByAddress[nodeAddress]
+	if node != nil {
+		// The snapshot state only has the node's latest registration change, so work out its status at the time
+		changed := time.Unix(node.SmoothingPoolRegistrationChanged.Int64(), 0)
+		blockTime := time.Unix(int64(header.Time), 0)
+		proposer.optedIn = node.SmoothingPoolRegistrationState == !blockTime.Before(changed)
+	}
+	return proposer, nil
+}
+
+// Find the blocks in [lo, hi] whose balance changes aren't covered by the expected amounts, returning each block's
+// unexplained change. The range is bisected until each change is pinned to a single block, so only the blocks around
+// the changes are queried.
+func findUnexplainedBalanceChanges(lo uint64, hi uint64, getBalance func(uint64) (*big.Int, error), getExpected func(uint64, uint64) *big.Int) (map[uint64]*big.Int, error) {
+	changes := map[uint64]*big.Int{}
+	var search func(lo uint64, hi uint64) error
+	search = func(lo uint64, hi uint64) error {
+		before, err := getBalance(lo - 1)
+		if err != nil {
+			return err
+		}
+		after, err := getBalance(hi)
+		if err != nil {
+			return err
+		}
+		residual := big.NewInt(0).Sub(after, before)
+		residual.Sub(residual, getExpected(lo, hi))
+		if residual.Sign() == 0 {
+			return nil
+		}
+		if lo == hi {
+			changes[lo] = residual
+			return nil
+		}
+		mid := lo + (hi-lo)/2
+		if err := search(lo, mid); err != nil {
+			return err
+		}
+		return search(mid+1, hi)
+	}
+	return changes, search(lo, hi)
+}
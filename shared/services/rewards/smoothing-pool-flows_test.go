@@ -0,0 +1,58 @@
+package rewards
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestFindUnexplainedBalanceChanges(t *testing.T) {
+	// Per-block balance changes: transfers the events explain, priority fees they don't, and a distribution
+	changes := map[uint64]int64{
+		105: 40,   // transfer
+		230: 7,    // priority fees
+		231: 15,   // transfer plus 3 in priority fees
+		700: -200, // distribution
+		999: 1,    // priority fees in the last block
+	}
+	expected := map[uint64]int64{105: 40, 231: 12}
+
+	queries := 0
+	getBalance := func(block uint64) (*big.Int, error) {
+		queries++
+		balance := int64(1000)
+		for changed, amount := range changes {
+			if changed <= block {
+				balance += amount
+			}
+		}
+		return big.NewInt(balance), nil
+	}
+	getExpected := func(lo uint64, hi uint64) *big.Int {
+		sum := int64(0)
+		for block, amount := range expected {
+			if block >= lo && block <= hi {
+				sum += amount
+			}
+		}
+		return big.NewInt(sum)
+	}
+
+	residuals, err := findUnexplainedBalanceChanges(100, 999, getBalance, getExpected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[uint64]int64{230: 7, 231: 3, 700: -200, 999: 1}
+	if len(residuals) != len(want) {
+		t.Fatalf("expected %d unexplained changes, got %v", len(want), residuals)
+	}
+	for block, amount := range want {
+		if residual, exists := residuals[block]; !exists || residual.Int64() != amount {
+			t.Errorf("block %d: expected %d, got %v", block, amount, residual)
+		}
+	}
+
+	// Bisection should only need a small fraction of the 900 blocks
+	if queries > 200 {
+		t.Errorf("bisection queried %d balances", queries)
+	}
+}
@@ -0,0 +1,214 @@
+package ssz_types
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	ssz "github.com/ferranbt/fastssz"
+	"github.com/holiman/uint256"
+	"github.com/wealdtech/go-merkletree/keccak256"
+)
+
+const (
+	// The size of the fixed part of an SSZFile_v1, up to and including the offsets of its lists
+	sszFileFixedSize = 356
+
+	// The size of a single NodeReward in an SSZFile_v1
+	nodeRewardSize = 124
+
+	// How much of the NodeRewards list to buffer while scanning it
+	partialReadBufferSize = 64 * 1024
+)
+
+// The header of an SSZ rewards file and a single node's entry in it, decoded without the rest of its NodeRewards list.
+// This is all a claim needs, so low-memory machines can claim from large rewards files without decoding them in full.
+type PartialSSZFile struct {
+	RewardsFileVersion uint64
+	RulesetVersion     uint64
+	Network            Network
+	Index              uint64
+	StartTime          time.Time
+	EndTime            time.Time
+	IntervalsPassed    uint64
+	MerkleRoot         Hash
+	TotalRewards       *TotalRewards
+
+	// The number of nodes in the file
+	NodeCount uint64
+
+	// The node's rewards and Merkle proof, or nil if the node isn't in the file
+	NodeReward *NodeReward
+}
+
+// Read an SSZ rewards file's header and a single node's rewards and Merkle proof from it. The NodeRewards list is
+// scanned one record at a time and only the Merkle leaf of each node is kept, so memory use stays at 32 bytes per node
+// instead of a decoded record and proof for every node. Like ParseSSZFile, this fails if the node rewards are out of
+// order or duplicated, or if they don't produce the file's Merkle root.
+func ReadPartialSSZFile(r io.ReaderAt, size int64, address Address) (*PartialSSZFile, error) {
+	if size < sszFileFixedSize {
+		return nil, ssz.ErrSize
+	}
+
+	// Read the header
+	header := make([]byte, sszFileFixedSize)
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return nil, fmt.Errorf("error reading ssz file header: %w", err)
+	}
+	if !bytes.HasPrefix(header, Magic[:]) {
+		return nil, errors.New("magic header not found in reward ssz file")
+	}
+	file := &PartialSSZFile{
+		RewardsFileVersion: ssz.UnmarshallUint64(header[4:12]),
+		RulesetVersion:     ssz.UnmarshallUint64(header[12:20]),
+		Network:            Network(ssz.UnmarshallUint64(header[20:28])),
+		Index:              ssz.UnmarshallUint64(header[28:36]),
+		StartTime:          ssz.UnmarshalTime(header[36:44]),
+		EndTime:            ssz.UnmarshalTime(header[44:52]),
+		IntervalsPassed:    ssz.UnmarshallUint64(header[84:92]),
+		TotalRewards:       new(TotalRewards),
+	}
+	copy(file.MerkleRoot[:], header[92:124])
+	if err := file.TotalRewards.UnmarshalSSZ(header[124:348]); err != nil {
+		return nil, err
+	}
+
+	// Find the NodeRewards list, which runs to the end of the file
+	networkRewardsOffset := ssz.ReadOffset(header[348:352])
+	nodeRewardsOffset := ssz.ReadOffset(header[352:356])
+	if networkRewardsOffset < sszFileFixedSize {
+		return nil, ssz.ErrInvalidVariableOffset
+	}
+	if int64(nodeRewardsOffset) > size || networkRewardsOffset > nodeRewardsOffset {
+		return nil, ssz.ErrOffset
+	}
+	nodeRewardsSize := size - int64(nodeRewardsOffset)
+	if nodeRewardsSize%nodeRewardSize != 0 {
+		return nil, ssz.ErrSize
+	}
+	file.NodeCount = uint64(nodeRewardsSize / nodeRewardSize)
+	if file.NodeCount == 0 {
+		return nil, errors.New("ssz file has no node rewards")
+	}
+
+	// Scan the node rewards, keeping each node's Merkle leaf and decoding only the requested node
+	reader := bufio.NewReaderSize(io.NewSectionReader(r, int64(nodeRewardsOffset), nodeRewardsSize), partialReadBufferSize)
+	leaves := make([]Hash, file.NodeCount)
+	record := make([]byte, nodeRewardSize)
+	leafData := make([]byte, 20+32*3)
+	var previous Address
+	var nodeLeaf *Hash
+	for i := range leaves {
+		if _, err := io.ReadFull(reader, record); err != nil {
+			return nil, fmt.Errorf("error reading node reward %d: %w", i, err)
+		}
+		current := AddressFromBytes(record[0:20])
+		if i > 0 && bytes.Compare(previous[:], current[:]) >= 0 {
+			return nil, errors.New("ssz file node rewards are out of order or duplicated")
+		}
+		previous = current
+
+		leaf, err := getNodeRewardLeaf(record, leafData)
+		if err != nil {
+			return nil, fmt.Errorf("error hashing node reward for 0x%x: %w", current[:], err)
+		}
+		leaves[i] = leaf
+
+		if current == address {
+			file.NodeReward = new(NodeReward)
+			if err := file.NodeReward.UnmarshalSSZ(record); err != nil {
+				return nil, err
+			}
+			nodeLeaf = &leaf
+		}
+	}
+
+	// Rebuild the tree to get the node's proof, and check it against the root in the file
+	proof, root := getSortedMerkleProof(leaves, nodeLeaf)
+	if bytes.Count(file.MerkleRoot[:], []byte{0x00}) >= 32 {
+		file.MerkleRoot = root
+	} else if file.MerkleRoot != root {
+		return nil, fmt.Errorf("generated root %s mismatch against existing root %s", root, file.MerkleRoot)
+	}
+	if file.NodeReward != nil {
+		file.NodeReward.MerkleProof = proof
+	}
+	return file, nil
+}
+
+// Get the Merkle leaf for an SSZ-encoded node reward, which is the hash of the same node data Proofs() builds the tree
+// from. leafData is scratch space for that data, so it can be reused between records.
+func getNodeRewardLeaf(record []byte, leafData []byte) (Hash, error) {
+	var collateralRpl, oracleDaoRpl, smoothingPoolEth uint256.Int
+	if err := collateralRpl.UnmarshalSSZ(record[28:60]); err != nil {
+		return Hash{}, err
+	}
+	if err := oracleDaoRpl.UnmarshalSSZ(record[60:92]); err != nil {
+		return Hash{}, err
+	}
+	if err := smoothingPoolEth.UnmarshalSSZ(record[92:124]); err != nil {
+		return Hash{}, err
+	}
+	var rpl uint256.Int
+	if _, overflow := rpl.AddOverflow(&collateralRpl, &oracleDaoRpl); overflow {
+		return Hash{}, errors.New("total RPL overflows a uint256")
+	}
+
+	// 20 bytes for address, 32 each for network/rpl/eth
+	network := uint256.NewInt(ssz.UnmarshallUint64(record[20:28])).Bytes32()
+	rplBytes := rpl.Bytes32()
+	ethBytes := smoothingPoolEth.Bytes32()
+	copy(leafData[0:20], record[0:20])
+	copy(leafData[20:20+32], network[:])
+	copy(leafData[20+32:20+32*2], rplBytes[:])
+	copy(leafData[20+32*2:20+32*3], ethBytes[:])
+
+	leaf := Hash{}
+	copy(leaf[:], keccak256.New().Hash(leafData))
+	return leaf, nil
+}
+
+// Get the Merkle proof for a leaf and the root of the tree, building the tree the same way the sorted Merkle trees of
+// go-merkletree do: leaves are sorted by hash and padded with empty hashes to a power of 2, and each pair is hashed with
+// the lower hash first. leaves is sorted and overwritten with the branches as the tree is built. If the leaf isn't in the
+// tree or is nil, only the root is returned.
+func getSortedMerkleProof(leaves []Hash, leaf *Hash) (MerkleProof, Hash) {
+	sort.Slice(leaves, func(i, j int) bool {
+		return bytes.Compare(leaves[i][:], leaves[j][:]) < 0
+	})
+	index := 0
+	found := false
+	if leaf != nil {
+		index = sort.Search(len(leaves), func(i int) bool {
+			return bytes.Compare(leaves[i][:], leaf[:]) >= 0
+		})
+		found = index < len(leaves) && leaves[index] == *leaf
+	}
+
+	width := 1
+	for width < len(leaves) {
+		width *= 2
+	}
+	level := append(leaves, make([]Hash, width-len(leaves))...)
+
+	hasher := keccak256.New()
+	var proof MerkleProof
+	for ; width > 1; width /= 2 {
+		if found {
+			proof = append(proof, level[index^1])
+			index /= 2
+		}
+		for i := 0; i < width/2; i++ {
+			left, right := level[2*i], level[2*i+1]
+			if bytes.Compare(left[:], right[:]) > 0 {
+				left, right = right, left
+			}
+			copy(level[i][:], hasher.Hash(left[:], right[:]))
+		}
+	}
+	return proof, level[0]
+}
@@ -0,0 +1,89 @@
+package ssz_types
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/services/rewards/ssz_types/big"
+)
+
+// Build a file with a node count that isn't a power of 2, so the tree has padding
+func partialSampleFile(nodeCount int) *SSZFile_v1 {
+	f := sampleFile()
+	f.MerkleRoot = Hash{}
+	f.NodeRewards = NodeRewards{}
+	for i := 0; i < nodeCount; i++ {
+		f.NodeRewards = append(f.NodeRewards, &NodeReward{
+			Address:          Address{byte(i + 1), 0xaa},
+			Network:          uint64(i % 2),
+			CollateralRpl:    big.NewUint256(int64(1000 * (i + 1))),
+			OracleDaoRpl:     big.NewUint256(int64(i)),
+			SmoothingPoolEth: big.NewUint256(int64(500 * i)),
+		})
+	}
+	return f
+}
+
+func TestReadPartialSSZFile(t *testing.T) {
+	for _, nodeCount := range []int{1, 2, 5, 8} {
+		f := partialSampleFile(nodeCount)
+		data, err := f.FinalizeSSZ()
+		fatalIf(t, err)
+		proofs, err := f.Proofs()
+		fatalIf(t, err)
+
+		for _, expected := range f.NodeRewards {
+			partial, err := ReadPartialSSZFile(bytes.NewReader(data), int64(len(data)), expected.Address)
+			fatalIf(t, err)
+			if partial.MerkleRoot != f.MerkleRoot || partial.Index != f.Index || partial.NodeCount != uint64(nodeCount) {
+				t.Fatalf("unexpected header for %d nodes: %+v", nodeCount, partial)
+			}
+			actual := partial.NodeReward
+			if actual == nil {
+				t.Fatalf("node %x not found in a file with %d nodes", expected.Address, nodeCount)
+			}
+			if actual.Network != expected.Network || actual.CollateralRpl.Cmp(expected.CollateralRpl.Int) != 0 || actual.SmoothingPoolEth.Cmp(expected.SmoothingPoolEth.Int) != 0 {
+				t.Fatalf("unexpected rewards for node %x: %+v", expected.Address, actual)
+			}
+			if !slices.Equal(actual.MerkleProof, proofs[expected.Address]) {
+				t.Fatalf("proof for node %x in a file with %d nodes doesn't match the full decode", expected.Address, nodeCount)
+			}
+		}
+
+		// A node that isn't in the file still gets the header and a verified root
+		partial, err := ReadPartialSSZFile(bytes.NewReader(data), int64(len(data)), Address{0xff})
+		fatalIf(t, err)
+		if partial.NodeReward != nil || partial.MerkleRoot != f.MerkleRoot {
+			t.Fatalf("unexpected result for a missing node: %+v", partial)
+		}
+	}
+}
+
+func TestReadPartialSSZFileBadRoot(t *testing.T) {
+	f := partialSampleFile(3)
+	data, err := f.FinalizeSSZ()
+	fatalIf(t, err)
+	copy(data[92:96], []byte{0x00, 0x01, 0x02, 0x03})
+	_, err = ReadPartialSSZFile(bytes.NewReader(data), int64(len(data)), f.NodeRewards[0].Address)
+	if err == nil || !strings.Contains(err.Error(), "mismatch against existing root") {
+		t.Fatalf("expected a root mismatch, got %v", err)
+	}
+}
+
+func TestReadPartialSSZFileOutOfOrder(t *testing.T) {
+	f := partialSampleFile(3)
+	data, err := f.FinalizeSSZ()
+	fatalIf(t, err)
+
+	// Swap the first two node rewards
+	start := len(data) - 3*nodeRewardSize
+	first := slices.Clone(data[start : start+nodeRewardSize])
+	copy(data[start:], data[start+nodeRewardSize:start+2*nodeRewardSize])
+	copy(data[start+nodeRewardSize:], first)
+	_, err = ReadPartialSSZFile(bytes.NewReader(data), int64(len(data)), f.NodeRewards[0].Address)
+	if err == nil || !strings.Contains(err.Error(), "out of order") {
+		t.Fatalf("expected an ordering error, got %v", err)
+	}
+}
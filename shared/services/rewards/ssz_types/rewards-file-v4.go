@@ -308,6 +308,33 @@ type TotalRewards struct {
 	TotalNodeWeight big.Uint256 `ssz-size:"32" json:"totalNodeWeight,omitempty"`
 }
 
+// The wei lost to integer division in each category of an interval's rewards, and where they went
+type DustAccounting struct {
+	// RPL truncated when the pending rewards were split by the pDAO, collateral, and oDAO percentages. Sent to the pDAO
+	PercentageRpl big.Uint256 `json:"percentageRpl"`
+	// Collateral RPL truncated from the individual node shares. Sent to the pDAO
+	CollateralRpl big.Uint256 `json:"collateralRpl"`
+	// oDAO RPL truncated from the individual member shares. Sent to the pDAO
+	OracleDaoRpl big.Uint256 `json:"oracleDaoRpl"`
+	// Node operator ETH truncated from the individual minipool shares. Sent to the rETH contract
+	SmoothingPoolEth big.Uint256 `json:"smoothingPoolEth"`
+}
+
+func NewDustAccounting() *DustAccounting {
+	return &DustAccounting{
+		PercentageRpl:    big.NewUint256(0),
+		CollateralRpl:    big.NewUint256(0),
+		OracleDaoRpl:     big.NewUint256(0),
+		SmoothingPoolEth: big.NewUint256(0),
+	}
+}
+
+// The total RPL dust that was sent to the pDAO
+func (d *DustAccounting) ProtocolDaoRpl() *stdbig.Int {
+	total := stdbig.NewInt(0).Add(d.PercentageRpl.Int, d.CollateralRpl.Int)
+	return total.Add(total, d.OracleDaoRpl.Int)
+}
+
 type NetworkReward struct {
 	// Chain ID (key)
 	Network Layer `json:"-"`
@@ -421,6 +448,10 @@ func (f *SSZFile_v1) Deserialize(data []byte) error {
 	return json.Unmarshal(data, f)
 }
 
+func (f *SSZFile_v1) GetRulesetVersion() uint64 {
+	return f.RulesetVersion
+}
+
 func (f *SSZFile_v1) GetIndex() uint64 {
 	return f.Index
 }
@@ -562,6 +593,15 @@ func (f *SSZFile_v1) GetNodeSmoothingPoolEth(addr common.Address) *stdbig.Int {
 	return nr.SmoothingPoolEth.Int
 }
 
+func (f *SSZFile_v1) GetNodeRewardNetwork(addr common.Address) uint64 {
+	nr := f.getNodeRewards(addr)
+	if nr == nil {
+		return 0
+	}
+
+	return nr.Network
+}
+
 func (f *SSZFile_v1) GetRewardsFileVersion() uint64 {
 	return f.RewardsFileVersion
 }
@@ -0,0 +1,45 @@
+package rewards
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// A record of the rewards artifacts an Oracle DAO member generated for an interval and where they can be downloaded
+type SubmissionMetadata struct {
+	Index       uint64              `json:"index"`
+	Network     string              `json:"network"`
+	MerkleRoot  string              `json:"merkleRoot"`
+	PrimaryCid  string              `json:"primaryCid"`
+	Cids        map[string]string   `json:"cids"`
+	MirrorUrls  map[string][]string `json:"mirrorUrls,omitempty"`
+	GeneratedAt time.Time           `json:"generatedAt"`
+}
+
+// Save the submission metadata to disk
+func (m *SubmissionMetadata) Save(path string) error {
+	bytes, err := json.MarshalIndent(m, "", "\t")
+	if err != nil {
+		return fmt.Errorf("error serializing submission metadata: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return fmt.Errorf("error writing submission metadata to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Load submission metadata from disk
+func LoadSubmissionMetadata(path string) (*SubmissionMetadata, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading submission metadata %s: %w", path, err)
+	}
+	var metadata SubmissionMetadata
+	if err := json.Unmarshal(bytes, &metadata); err != nil {
+		return nil, fmt.Errorf("error deserializing submission metadata %s: %w", path, err)
+	}
+	return &metadata, nil
+}
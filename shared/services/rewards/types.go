@@ -30,6 +30,9 @@ const (
 	minRewardsFileVersionSSZ = rewardsFileVersionThree
 )
 
+// The first minipool performance file version that isn't tied to the rewards file version
+const minipoolPerformanceVersionThree uint64 = 3
+
 // RewardsExecutionClient defines and interface
 // that contains only the functions from rocketpool.RocketPool
 // required for rewards generation.
@@ -85,6 +88,7 @@ type IRewardsFile interface {
 
 	// Getters for general interval info
 	GetRewardsFileVersion() uint64
+	GetRulesetVersion() uint64
 	GetIndex() uint64
 	GetTotalNodeWeight() *big.Int
 	GetMerkleRoot() string
@@ -110,6 +114,7 @@ type IRewardsFile interface {
 	GetNodeCollateralRpl(common.Address) *big.Int
 	GetNodeOracleDaoRpl(common.Address) *big.Int
 	GetNodeSmoothingPoolEth(common.Address) *big.Int
+	GetNodeRewardNetwork(common.Address) uint64
 	GetMerkleProof(common.Address) ([]common.Hash, error)
 
 	// Getters for network info
@@ -154,11 +159,13 @@ type ISmoothingPoolMinipoolPerformance interface {
 	GetEffectiveCommission() *big.Int
 	GetConsensusIncome() *big.Int
 	GetAttestationScore() *big.Int
+	GetAverageInclusionDelay() float64
 }
 
 // Small struct to test version information for rewards files during deserialization
 type VersionHeader struct {
-	RewardsFileVersion uint64 `json:"rewardsFileVersion,omitempty"`
+	RewardsFileVersion         uint64 `json:"rewardsFileVersion,omitempty"`
+	MinipoolPerformanceVersion uint64 `json:"minipoolPerformanceVersion,omitempty"`
 }
 
 // General version-agnostic information about a rewards file
@@ -195,6 +202,7 @@ type IntervalInfo struct {
 	StartTime              time.Time     `json:"startTime"`
 	EndTime                time.Time     `json:"endTime"`
 	NodeExists             bool          `json:"nodeExists"`
+	RewardNetwork          uint64        `json:"rewardNetwork"`
 	CollateralRplAmount    *QuotedBigInt `json:"collateralRplAmount"`
 	ODaoRplAmount          *QuotedBigInt `json:"oDaoRplAmount"`
 	SmoothingPoolEthAmount *QuotedBigInt `json:"smoothingPoolEthAmount"`
@@ -219,6 +227,7 @@ type MinipoolInfo struct {
 	EndSlot                 uint64                `json:"-"`
 	AttestationScore        *QuotedBigInt         `json:"attestationScore"`
 	CompletedAttestations   map[uint64]bool       `json:"-"`
+	InclusionDelays         map[uint64]uint64     `json:"-"`
 	AttestationCount        int                   `json:"attestationCount"`
 	TotalFee                *big.Int              `json:"-"`
 	MinipoolBonus           *big.Int              `json:"-"`
@@ -338,6 +347,12 @@ func (versionHeader *VersionHeader) deserializeMinipoolPerformanceFile(bytes []b
 		return nil, err
 	}
 
+	// Performance files that record inclusion delays declare their own version
+	if versionHeader.MinipoolPerformanceVersion == minipoolPerformanceVersionThree {
+		file := &MinipoolPerformanceFile_v3{}
+		return file, file.Deserialize(bytes)
+	}
+
 	switch versionHeader.RewardsFileVersion {
 	case rewardsFileVersionOne:
 		file := &MinipoolPerformanceFile_v1{}
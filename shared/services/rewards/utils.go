@@ -21,6 +21,8 @@ import (
 	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rewards/ssz_types"
+	"github.com/rocket-pool/smartnode/shared/services/state"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 )
 
@@ -99,8 +101,23 @@ func GetIntervalInfo(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, no
 	merkleRootCanon := event.MerkleRoot
 	info.MerkleRoot = merkleRootCanon
 
+	// Use the SSZ file if it's here, since only this node's entry has to be decoded from it. If its root is wrong, fall
+	// back to the JSON file, which may have been downloaded to replace it.
+	jsonFilePath := cfg.Smartnode.GetRewardsTreePath(interval, true, config.RewardsExtensionJSON)
+	sszFilePath := cfg.Smartnode.GetRewardsTreePath(interval, true, config.RewardsExtensionSSZ)
+	if _, statErr := os.Stat(sszFilePath); statErr == nil {
+		sszInfo := info
+		sszInfo.TreeFilePath = sszFilePath
+		sszInfo.TreeFileExists = true
+		err = sszInfo.readNodeRewardsFromSSZ(nodeAddress)
+		if _, statErr := os.Stat(jsonFilePath); err != nil || sszInfo.MerkleRootValid || statErr != nil {
+			info = sszInfo
+			return
+		}
+	}
+
 	// Check if the tree file exists
-	info.TreeFilePath = cfg.Smartnode.GetRewardsTreePath(interval, true, config.RewardsExtensionJSON)
+	info.TreeFilePath = jsonFilePath
 	_, err = os.Stat(info.TreeFilePath)
 	if os.IsNotExist(err) {
 		info.TreeFileExists = false
@@ -133,6 +150,7 @@ func GetIntervalInfo(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, no
 	if !info.NodeExists {
 		return
 	}
+	info.RewardNetwork = proofWrapper.GetNodeRewardNetwork(nodeAddress)
 	info.CollateralRplAmount = &QuotedBigInt{*proofWrapper.GetNodeCollateralRpl(nodeAddress)}
 	info.ODaoRplAmount = &QuotedBigInt{*proofWrapper.GetNodeOracleDaoRpl(nodeAddress)}
 	info.SmoothingPoolEthAmount = &QuotedBigInt{*proofWrapper.GetNodeSmoothingPoolEth(nodeAddress)}
@@ -150,15 +168,81 @@ func GetIntervalInfo(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, no
 	return
 }
 
+// Fill in the node's rewards from the SSZ file at TreeFilePath, without decoding the other nodes' entries
+func (i *IntervalInfo) readNodeRewardsFromSSZ(nodeAddress common.Address) error {
+	partialFile, err := ReadLocalSSZNodeRewards(i.TreeFilePath, nodeAddress)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", i.TreeFilePath, err)
+	}
+	i.TotalNodeWeight = partialFile.TotalRewards.TotalNodeWeight.Int
+
+	// Make sure the Merkle root has the expected value
+	if i.MerkleRoot != common.Hash(partialFile.MerkleRoot) {
+		i.MerkleRootValid = false
+		return nil
+	}
+	i.MerkleRootValid = true
+
+	// Get the rewards from it
+	nodeRewards := partialFile.NodeReward
+	i.NodeExists = nodeRewards != nil
+	if !i.NodeExists {
+		return nil
+	}
+	i.RewardNetwork = nodeRewards.Network
+	i.CollateralRplAmount = QuotedBigIntFromBigInt(nodeRewards.CollateralRpl.Int)
+	i.ODaoRplAmount = QuotedBigIntFromBigInt(nodeRewards.OracleDaoRpl.Int)
+	i.SmoothingPoolEthAmount = QuotedBigIntFromBigInt(nodeRewards.SmoothingPoolEth.Int)
+	i.MerkleProof = make([]common.Hash, len(nodeRewards.MerkleProof))
+	for j, hash := range nodeRewards.MerkleProof {
+		i.MerkleProof[j] = common.Hash(hash)
+	}
+	return nil
+}
+
 // Downloads the rewards file for this interval
 func (i *IntervalInfo) DownloadRewardsFile(cfg *config.RocketPoolConfig, isDaemon bool) error {
-	interval := i.Index
+	rewardsTreePath, err := homedir.Expand(cfg.Smartnode.GetRewardsTreePath(i.Index, isDaemon, config.RewardsExtensionJSON))
+	if err != nil {
+		return fmt.Errorf("error expanding rewards tree path: %w", err)
+	}
+	rewardsFile, err := i.FetchRewardsFile(cfg, isDaemon)
+	if err != nil {
+		return err
+	}
+
+	// Serialize again so we're sure to have all the correct proofs that we've generated (instead of verifying every proof on the file)
+	localRewardsFile := NewLocalFile[IRewardsFile](
+		rewardsFile,
+		rewardsTreePath,
+	)
+	_, err = localRewardsFile.Write()
+	if err != nil {
+		return fmt.Errorf("error saving interval %d file to %s: %w", i.Index, rewardsTreePath, err)
+	}
+
+	// Save an SSZ copy too if the file has one, so claims can read just this node's entry from it
+	if sszFile, ok := rewardsFile.(*ssz_types.SSZFile_v1); ok {
+		sszPath, err := homedir.Expand(cfg.Smartnode.GetRewardsTreePath(i.Index, isDaemon, config.RewardsExtensionSSZ))
+		if err != nil {
+			return fmt.Errorf("error expanding rewards tree path: %w", err)
+		}
+		_, err = NewLocalFile[IRewardsFile](sszFile, sszPath).WriteSSZ()
+		if err != nil {
+			return fmt.Errorf("error saving interval %d SSZ file to %s: %w", i.Index, sszPath, err)
+		}
+	}
+	return nil
+}
+
+// Downloads the rewards file for this interval and verifies it against the canonical Merkle root, without saving it
+func (i *IntervalInfo) FetchRewardsFile(cfg *config.RocketPoolConfig, isDaemon bool) (IRewardsFile, error) {
 	expectedCid := i.CID
 	expectedRoot := i.MerkleRoot
 	// Determine file name and path
-	rewardsTreePath, err := homedir.Expand(cfg.Smartnode.GetRewardsTreePath(interval, isDaemon, config.RewardsExtensionJSON))
+	rewardsTreePath, err := homedir.Expand(cfg.Smartnode.GetRewardsTreePath(i.Index, isDaemon, config.RewardsExtensionJSON))
 	if err != nil {
-		return fmt.Errorf("error expanding rewards tree path: %w", err)
+		return nil, fmt.Errorf("error expanding rewards tree path: %w", err)
 	}
 	rewardsTreeFilename := filepath.Base(rewardsTreePath)
 	ipfsFilename := rewardsTreeFilename + config.RewardsTreeIpfsExtension
@@ -219,7 +303,7 @@ func (i *IntervalInfo) DownloadRewardsFile(cfg *config.RocketPoolConfig, isDaemo
 
 			deserializedRewardsFile, err := DeserializeRewardsFile(writeBytes)
 			if err != nil {
-				return fmt.Errorf("Error deserializing file %s: %w", rewardsTreePath, err)
+				return nil, fmt.Errorf("Error deserializing file %s: %w", rewardsTreePath, err)
 			}
 
 			// Get the original merkle root
@@ -233,33 +317,125 @@ func (i *IntervalInfo) DownloadRewardsFile(cfg *config.RocketPoolConfig, isDaemo
 
 			// Compare the merkle roots to see if the original is correct
 			if !strings.EqualFold(downloadedRoot, calculatedRoot) {
-				return fmt.Errorf("the merkle root from %s does not match the root generated by its tree data (had %s, but generated %s)", url, downloadedRoot, calculatedRoot)
+				return nil, fmt.Errorf("the merkle root from %s does not match the root generated by its tree data (had %s, but generated %s)", url, downloadedRoot, calculatedRoot)
 			}
 
 			// Make sure the calculated root matches the canonical one
 			if !strings.EqualFold(calculatedRoot, expectedRoot.Hex()) {
-				return fmt.Errorf("the merkle root from %s does not match the canonical one (had %s, but generated %s)", url, calculatedRoot, expectedRoot.Hex())
+				return nil, fmt.Errorf("the merkle root from %s does not match the canonical one (had %s, but generated %s)", url, calculatedRoot, expectedRoot.Hex())
 			}
 
-			// Serialize again so we're sure to have all the correct proofs that we've generated (instead of verifying every proof on the file)
-			localRewardsFile := NewLocalFile[IRewardsFile](
-				deserializedRewardsFile,
-				rewardsTreePath,
-			)
-			_, err = localRewardsFile.Write()
+			return deserializedRewardsFile, nil
+
+		}
+
+		errBuilder.WriteString(fmt.Sprintf("Downloading files with timeout %v failed.\n", timeout))
+	}
+
+	return nil, fmt.Errorf(errBuilder.String())
+
+}
+
+// Downloads the minipool performance file for this interval.
+// Rewards files that record the performance file's CID are tried on IPFS first; otherwise it comes from the
+// same mirrors as the rewards file. The performance file isn't covered by the Merkle root, so only the IPFS
+// copies can be checked against the CID.
+func (i *IntervalInfo) DownloadMinipoolPerformanceFile(cfg *config.RocketPoolConfig, isDaemon bool, rewardsFile IRewardsFile) (IMinipoolPerformanceFile, error) {
+	performancePath, err := homedir.Expand(cfg.Smartnode.GetMinipoolPerformancePath(i.Index, isDaemon))
+	if err != nil {
+		return nil, fmt.Errorf("error expanding minipool performance file path: %w", err)
+	}
+	performanceFilename := filepath.Base(performancePath)
+	ipfsFilename := performanceFilename + config.RewardsTreeIpfsExtension
+
+	// Create URL list
+	urls := []string{}
+	performanceCid := ""
+	if v3File, ok := rewardsFile.(*RewardsFile_v3); ok {
+		performanceCid = v3File.MinipoolPerformanceFileCID
+	}
+	if performanceCid != "" && performanceCid != "---" {
+		urls = append(urls,
+			fmt.Sprintf(config.PrimaryRewardsFileUrl, performanceCid, ipfsFilename),
+			fmt.Sprintf(config.SecondaryRewardsFileUrl, performanceCid, ipfsFilename),
+		)
+	}
+	urls = append(urls, fmt.Sprintf(config.GithubRewardsFileUrl, string(cfg.Smartnode.Network.Value.(cfgtypes.Network)), performanceFilename))
+	rewardsTreeCustomUrl := strings.TrimSpace(cfg.Smartnode.RewardsTreeCustomUrl.Value.(string))
+	if len(rewardsTreeCustomUrl) != 0 {
+		for _, customUrl := range strings.Split(rewardsTreeCustomUrl, ";") {
+			customUrl = strings.TrimSpace(customUrl)
+			urls = append(urls, fmt.Sprintf(customUrl, performanceFilename))
+		}
+	}
+
+	// Attempt downloads, with the same escalating timeouts as the rewards file
+	errBuilder := strings.Builder{}
+	for _, timeout := range []time.Duration{200 * time.Millisecond, 2 * time.Second, 60 * time.Second} {
+		client := http.Client{
+			Timeout: timeout,
+		}
+		for _, url := range urls {
+			bytes, err := downloadFile(client, url)
 			if err != nil {
-				return fmt.Errorf("error saving interval %d file to %s: %w", interval, rewardsTreePath, err)
+				errBuilder.WriteString(fmt.Sprintf("Downloading %s failed (%s)\n", url, err.Error()))
+				continue
+			}
+			if strings.HasSuffix(url, config.RewardsTreeIpfsExtension) {
+				// Make sure it's the file the rewards file refers to before decompressing it
+				downloadedCid, err := singleFileDirIPFSCid(bytes, ipfsFilename)
+				if err != nil {
+					errBuilder.WriteString(fmt.Sprintf("Error calculating the CID of %s: %s\n", url, err.Error()))
+					continue
+				}
+				if downloadedCid.String() != performanceCid {
+					errBuilder.WriteString(fmt.Sprintf("%s has CID %s, but the rewards file expects %s\n", url, downloadedCid.String(), performanceCid))
+					continue
+				}
+				bytes, err = decompressFile(bytes)
+				if err != nil {
+					errBuilder.WriteString(fmt.Sprintf("Error decompressing %s: %s\n", url, err.Error()))
+					continue
+				}
 			}
 
-			return nil
+			performanceFile, err := DeserializeMinipoolPerformanceFile(bytes)
+			if err != nil {
+				errBuilder.WriteString(fmt.Sprintf("Error deserializing %s: %s\n", url, err.Error()))
+				continue
+			}
 
+			// Save it so it doesn't have to be downloaded again
+			localPerformanceFile := NewLocalFile[IMinipoolPerformanceFile](
+				performanceFile,
+				performancePath,
+			)
+			_, err = localPerformanceFile.Write()
+			if err != nil {
+				return nil, fmt.Errorf("error saving interval %d minipool performance file to %s: %w", i.Index, performancePath, err)
+			}
+			return performanceFile, nil
 		}
 
 		errBuilder.WriteString(fmt.Sprintf("Downloading files with timeout %v failed.\n", timeout))
 	}
 
-	return fmt.Errorf(errBuilder.String())
+	return nil, fmt.Errorf(errBuilder.String())
+
+}
+
+// Download a file, failing on anything other than a 200 response
+func downloadFile(client http.Client, url string) ([]byte, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
 }
 
 // Gets the start slot for the given interval
@@ -383,3 +559,12 @@ func getMinipoolBondAndNodeFee(details *rpstate.NativeMinipoolDetails, blockTime
 
 	return currentBond, currentFee
 }
+
+// Get the node that owns each minipool in the network state
+func getMinipoolNodes(networkState *state.NetworkState) map[common.Address]common.Address {
+	minipoolNodes := make(map[common.Address]common.Address, len(networkState.MinipoolDetails))
+	for _, details := range networkState.MinipoolDetails {
+		minipoolNodes[details.MinipoolAddress] = details.NodeAddress
+	}
+	return minipoolNodes
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/rocket-pool/smartnode/addons/graffiti_wall_writer"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool/template"
+	"github.com/rocket-pool/smartnode/shared/services/tuning"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
@@ -79,8 +80,10 @@ type Client struct {
 	originalMaxPrioFee float64
 	originalGasLimit   uint64
 	debugPrint         bool
+	simulate           bool
 	ignoreSyncCheck    bool
 	forceFallbacks     bool
+	command            string
 }
 
 func getClientStatusString(clientStatus api.ClientStatus) string {
@@ -155,8 +158,10 @@ func NewClientFromCtx(c *cli.Context) *Client {
 		originalMaxPrioFee: c.GlobalFloat64("maxPrioFee"),
 		originalGasLimit:   c.GlobalUint64("gasLimit"),
 		debugPrint:         c.GlobalBool("debug"),
+		simulate:           c.GlobalBool("simulate"),
 		forceFallbacks:     false,
 		ignoreSyncCheck:    false,
+		command:            c.Command.FullName(),
 	}
 
 	if nonce, ok := c.App.Metadata["nonce"]; ok {
@@ -538,6 +543,36 @@ func (c *Client) TerminateService(composeFiles []string, configPath string) erro
 	return nil
 }
 
+// Move the data folder to a backup location, since its files are owned by the containers' user
+func (c *Client) MoveDataFolder(dataPath string, backupPath string) error {
+	rootCmd, err := c.getEscalationCommand()
+	if err != nil {
+		return fmt.Errorf("could not get privilege escalation command: %w", err)
+	}
+	cmd := fmt.Sprintf("%s mv %s %s", rootCmd, shellescape.Quote(dataPath), shellescape.Quote(backupPath))
+	if _, err := c.readOutput(cmd); err != nil {
+		return fmt.Errorf("error moving data folder [%s] to [%s]: %w", dataPath, backupPath, err)
+	}
+	return nil
+}
+
+// Copy files from one data folder to another, keeping their owners and permissions
+func (c *Client) CopyDataFiles(fromPath string, toPath string, filenames ...string) error {
+	rootCmd, err := c.getEscalationCommand()
+	if err != nil {
+		return fmt.Errorf("could not get privilege escalation command: %w", err)
+	}
+	sources := make([]string, len(filenames))
+	for i, filename := range filenames {
+		sources[i] = shellescape.Quote(filepath.Join(fromPath, filename))
+	}
+	cmd := fmt.Sprintf("%s cp -p %s %s", rootCmd, strings.Join(sources, " "), shellescape.Quote(toPath))
+	if _, err := c.readOutput(cmd); err != nil {
+		return fmt.Errorf("error copying %s from [%s] to [%s]: %w", strings.Join(filenames, ", "), fromPath, toPath, err)
+	}
+	return nil
+}
+
 // Print the Rocket Pool service status
 func (c *Client) PrintServiceStatus(composeFiles []string) error {
 	cmd, err := c.compose(composeFiles, "ps")
@@ -579,6 +614,32 @@ func (c *Client) PrintServiceStats(composeFiles []string) error {
 
 }
 
+// Get a single sample of the Rocket Pool service containers' memory and CPU usage, formatted for tuning.ParseStats
+func (c *Client) GetServiceResourceUsage(composeFiles []string) (string, error) {
+
+	// Get service container IDs
+	cmd, err := c.compose(composeFiles, "ps -q")
+	if err != nil {
+		return "", err
+	}
+	containers, err := c.readOutput(cmd)
+	if err != nil {
+		return "", err
+	}
+	containerIds := strings.Fields(string(containers))
+	if len(containerIds) == 0 {
+		return "", nil
+	}
+
+	// Get stats
+	stats, err := c.readOutput(fmt.Sprintf("docker stats --no-stream --format %s %s", shellescape.Quote(`{{.Name}}\t{{.MemUsage}}\t{{.CPUPerc}}`), strings.Join(containerIds, " ")))
+	if err != nil {
+		return "", err
+	}
+	return string(stats), nil
+
+}
+
 // Print the Rocket Pool service compose config
 func (c *Client) PrintServiceCompose(composeFiles []string) error {
 	cmd, err := c.compose(composeFiles, "config")
@@ -993,6 +1054,11 @@ func (c *Client) GetGasSettings() (float64, float64, uint64) {
 	return c.maxFee, c.maxPrioFee, c.gasLimit
 }
 
+// Get the command being run, such as "node claim-rewards"
+func (c *Client) GetCommand() string {
+	return c.command
+}
+
 // Get the gas fees
 func (c *Client) AssignGasSettings(maxFee float64, maxPrioFee float64, gasLimit uint64) {
 	c.maxFee = maxFee
@@ -1178,6 +1244,11 @@ func (c *Client) deployTemplates(cfg *config.RocketPoolConfig, rocketpoolDir str
 		)
 	}
 
+	// Check if the caching proxy is enabled
+	if cfg.Smartnode.UseCachingProxy() {
+		toDeploy = append(toDeploy, config.CacheProxyContainerName)
+	}
+
 	// Check if we are running the Mev-Boost container locally
 	if cfg.EnableMevBoost.Value == true && cfg.MevBoost.Mode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
 		toDeploy = append(toDeploy, config.MevBoostContainerName)
@@ -1191,6 +1262,25 @@ func (c *Client) deployTemplates(cfg *config.RocketPoolConfig, rocketpoolDir str
 		deployedContainers = append(deployedContainers, containers...)
 	}
 
+	// Add the resource limits for the containers being deployed
+	limitsPath, err := homedir.Expand(cfg.Smartnode.GetResourceLimitsPath())
+	if err != nil {
+		return []string{}, fmt.Errorf("error expanding resource limits path: %w", err)
+	}
+	limits, err := tuning.LoadLimits(limitsPath)
+	if err != nil {
+		return []string{}, err
+	}
+	deployedLimits := limits.Filter(toDeploy)
+	if len(deployedLimits.Services) > 0 {
+		runtimeLimitsPath := filepath.Join(runtimeFolder, config.ResourceLimitsFilename)
+		err = deployedLimits.Save(runtimeLimitsPath)
+		if err != nil {
+			return []string{}, err
+		}
+		deployedContainers = append(deployedContainers, runtimeLimitsPath)
+	}
+
 	// Create the custom keys dir
 	customKeyDir, err := homedir.Expand(filepath.Join(cfg.Smartnode.DataPath.Value.(string), "custom-keys"))
 	if err != nil {
@@ -1363,6 +1453,11 @@ func (c *Client) runApiCall(cmd string) ([]byte, error) {
 	c.maxPrioFee = c.originalMaxPrioFee
 	c.gasLimit = c.originalGasLimit
 
+	// A simulated transaction is where a dry run ends
+	if c.simulate && err == nil {
+		c.finishSimulation(output)
+	}
+
 	return output, err
 }
 
@@ -1384,6 +1479,9 @@ func (c *Client) getGasOpts() string {
 	opts += fmt.Sprintf("--maxFee %f ", c.maxFee)
 	opts += fmt.Sprintf("--maxPrioFee %f ", c.maxPrioFee)
 	opts += fmt.Sprintf("--gasLimit %d ", c.gasLimit)
+	if c.simulate {
+		opts += "--simulate "
+	}
 	return opts
 }
 
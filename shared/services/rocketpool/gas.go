@@ -7,6 +7,7 @@ import (
 const (
 	colorReset  string = "\033[0m"
 	colorRed    string = "\033[31m"
+	colorGreen  string = "\033[32m"
 	colorYellow string = "\033[33m"
 )
 
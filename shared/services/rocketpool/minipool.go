@@ -3,6 +3,7 @@ package rocketpool
 import (
 	"fmt"
 	"math/big"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/goccy/go-json"
@@ -56,6 +57,52 @@ func (c *Client) MinipoolStatus() (api.MinipoolStatusResponse, error) {
 	return response, nil
 }
 
+// Get one page of minipool statuses, using batched queries
+func (c *Client) MinipoolBulkStatus(includeFinalised bool, page uint64, pageSize uint64) (api.MinipoolBulkStatusResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool bulk-status %t %d %d", includeFinalised, page, pageSize))
+	if err != nil {
+		return api.MinipoolBulkStatusResponse{}, fmt.Errorf("Could not get minipool bulk status: %w", err)
+	}
+	var response api.MinipoolBulkStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolBulkStatusResponse{}, fmt.Errorf("Could not decode minipool bulk status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolBulkStatusResponse{}, fmt.Errorf("Could not get minipool bulk status: %s", response.Error)
+	}
+	for i := 0; i < len(response.Minipools); i++ {
+		mp := &response.Minipools[i]
+		if mp.Node.DepositBalance == nil {
+			mp.Node.DepositBalance = big.NewInt(0)
+		}
+		if mp.Node.RefundBalance == nil {
+			mp.Node.RefundBalance = big.NewInt(0)
+		}
+		if mp.User.DepositBalance == nil {
+			mp.User.DepositBalance = big.NewInt(0)
+		}
+		if mp.Balances.ETH == nil {
+			mp.Balances.ETH = big.NewInt(0)
+		}
+		if mp.Balances.RPL == nil {
+			mp.Balances.RPL = big.NewInt(0)
+		}
+		if mp.Balances.RETH == nil {
+			mp.Balances.RETH = big.NewInt(0)
+		}
+		if mp.Balances.FixedSupplyRPL == nil {
+			mp.Balances.FixedSupplyRPL = big.NewInt(0)
+		}
+		if mp.Validator.Balance == nil {
+			mp.Validator.Balance = big.NewInt(0)
+		}
+		if mp.Validator.NodeBalance == nil {
+			mp.Validator.NodeBalance = big.NewInt(0)
+		}
+	}
+	return response, nil
+}
+
 // Check whether a minipool is eligible for a refund
 func (c *Client) CanRefundMinipool(address common.Address) (api.CanRefundMinipoolResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-refund %s", address.Hex()))
@@ -248,6 +295,38 @@ func (c *Client) CloseMinipool(address common.Address) (api.CloseMinipoolRespons
 	return response, nil
 }
 
+// Cross-check the node's minipool statuses against their validators on the Beacon Chain
+func (c *Client) VerifyMinipoolStatus() (api.VerifyMinipoolStatusResponse, error) {
+	responseBytes, err := c.callAPI("minipool verify-status")
+	if err != nil {
+		return api.VerifyMinipoolStatusResponse{}, fmt.Errorf("Could not verify minipool status: %w", err)
+	}
+	var response api.VerifyMinipoolStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.VerifyMinipoolStatusResponse{}, fmt.Errorf("Could not decode verify minipool status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.VerifyMinipoolStatusResponse{}, fmt.Errorf("Could not verify minipool status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the delegate contract status of the node's minipools
+func (c *Client) MinipoolDelegateStatus() (api.DelegateStatusResponse, error) {
+	responseBytes, err := c.callAPI("minipool delegate-status")
+	if err != nil {
+		return api.DelegateStatusResponse{}, fmt.Errorf("Could not get minipool delegate status: %w", err)
+	}
+	var response api.DelegateStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.DelegateStatusResponse{}, fmt.Errorf("Could not decode minipool delegate status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.DelegateStatusResponse{}, fmt.Errorf("Could not get minipool delegate status: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether a minipool can have its delegate upgraded
 func (c *Client) CanDelegateUpgradeMinipool(address common.Address) (api.CanDelegateUpgradeResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-delegate-upgrade %s", address.Hex()))
@@ -440,6 +519,22 @@ func (c *Client) GetDistributeBalanceDetails() (api.GetDistributeBalanceDetailsR
 	return response, nil
 }
 
+// Get the node's refunds and balances worth claiming, ranked by their value after gas
+func (c *Client) GetHousekeepingPlan() (api.GetHousekeepingPlanResponse, error) {
+	responseBytes, err := c.callAPI("minipool get-housekeeping-plan")
+	if err != nil {
+		return api.GetHousekeepingPlanResponse{}, fmt.Errorf("Could not get housekeeping plan: %w", err)
+	}
+	var response api.GetHousekeepingPlanResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GetHousekeepingPlanResponse{}, fmt.Errorf("Could not decode housekeeping plan response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GetHousekeepingPlanResponse{}, fmt.Errorf("Could not get housekeeping plan: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Distribute a minipool's ETH balance
 func (c *Client) DistributeBalance(address common.Address) (api.DistributeBalanceResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("minipool distribute-balance %s", address.Hex()))
@@ -504,6 +599,58 @@ func (c *Client) ChangeWithdrawalCredentials(address common.Address, mnemonic st
 	return response, nil
 }
 
+// Find the node's minipools whose validators still have 0x00 (BLS) withdrawal credentials
+func (c *Client) GetBlsCredentialedMinipools() (api.GetBlsCredentialedMinipoolsResponse, error) {
+	responseBytes, err := c.callAPI("minipool get-bls-credentialed")
+	if err != nil {
+		return api.GetBlsCredentialedMinipoolsResponse{}, fmt.Errorf("Could not get BLS-credentialed minipools: %w", err)
+	}
+	var response api.GetBlsCredentialedMinipoolsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GetBlsCredentialedMinipoolsResponse{}, fmt.Errorf("Could not decode BLS-credentialed minipools response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GetBlsCredentialedMinipoolsResponse{}, fmt.Errorf("Could not get BLS-credentialed minipools: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Forecast when the withdrawal sweep will next reach each of the node's validators
+func (c *Client) GetMinipoolSweepForecast() (api.GetMinipoolSweepForecastResponse, error) {
+	responseBytes, err := c.callAPI("minipool get-sweep-forecast")
+	if err != nil {
+		return api.GetMinipoolSweepForecastResponse{}, fmt.Errorf("Could not get minipool sweep forecast: %w", err)
+	}
+	var response api.GetMinipoolSweepForecastResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GetMinipoolSweepForecastResponse{}, fmt.Errorf("Could not decode minipool sweep forecast response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GetMinipoolSweepForecastResponse{}, fmt.Errorf("Could not get minipool sweep forecast: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Project when minipools' validators would exit and be fully withdrawn if they were exited now
+func (c *Client) GetMinipoolExitProjection(addresses []common.Address) (api.GetMinipoolExitProjectionResponse, error) {
+	addressStrings := make([]string, len(addresses))
+	for i, address := range addresses {
+		addressStrings[i] = address.Hex()
+	}
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool get-exit-projection %s", strings.Join(addressStrings, ",")))
+	if err != nil {
+		return api.GetMinipoolExitProjectionResponse{}, fmt.Errorf("Could not get minipool exit projection: %w", err)
+	}
+	var response api.GetMinipoolExitProjectionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GetMinipoolExitProjectionResponse{}, fmt.Errorf("Could not decode minipool exit projection response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GetMinipoolExitProjectionResponse{}, fmt.Errorf("Could not get minipool exit projection: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check all of the node's minipools for rescue eligibility, and return the details of the rescuable ones
 func (c *Client) GetMinipoolRescueDissolvedDetailsForNode() (api.GetMinipoolRescueDissolvedDetailsForNodeResponse, error) {
 	responseBytes, err := c.callAPI("minipool get-rescue-dissolved-details-for-node")
@@ -3,8 +3,10 @@ package rocketpool
 import (
 	"fmt"
 	"math/big"
+	"strings"
 
 	"github.com/goccy/go-json"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
@@ -92,8 +94,8 @@ func (c *Client) CanGenerateRewardsTree(index uint64) (api.CanNetworkGenerateRew
 }
 
 // Set a request marker for the watchtower to generate the rewards tree for the given interval
-func (c *Client) GenerateRewardsTree(index uint64) (api.NetworkGenerateRewardsTreeResponse, error) {
-	responseBytes, err := c.callAPI(fmt.Sprintf("network generate-rewards-tree %d", index))
+func (c *Client) GenerateRewardsTree(index uint64, memoryBudget uint64) (api.NetworkGenerateRewardsTreeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network generate-rewards-tree %d %d", index, memoryBudget))
 	if err != nil {
 		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards tree generation: %w", err)
 	}
@@ -139,6 +141,185 @@ func (c *Client) DownloadRewardsFile(interval uint64) (api.DownloadRewardsFileRe
 	return response, nil
 }
 
+// Get a page of the minipool performance file for an interval
+func (c *Client) GetMinipoolPerformancePage(interval uint64, query rewards.MinipoolPerformanceQuery) (api.MinipoolPerformancePageResponse, error) {
+	command := fmt.Sprintf("network minipool-performance --offset %d --limit %d ", query.Offset, query.Limit)
+	if query.Node != nil {
+		command += fmt.Sprintf("--node %s ", query.Node.Hex())
+	}
+	if query.Pubkey != "" {
+		command += fmt.Sprintf("--pubkey %s ", query.Pubkey)
+	}
+	if query.MinMissed != nil {
+		command += fmt.Sprintf("--min-missed %d ", *query.MinMissed)
+	}
+	if query.MaxMissed != nil {
+		command += fmt.Sprintf("--max-missed %d ", *query.MaxMissed)
+	}
+	command += fmt.Sprint(interval)
+
+	responseBytes, err := c.callAPI(command)
+	if err != nil {
+		return api.MinipoolPerformancePageResponse{}, fmt.Errorf("could not get minipool performance: %w", err)
+	}
+	var response api.MinipoolPerformancePageResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolPerformancePageResponse{}, fmt.Errorf("could not decode minipool performance response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolPerformancePageResponse{}, fmt.Errorf("could not get minipool performance: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the network totals recorded over the given number of days
+func (c *Client) NetworkTotalsHistory(days uint64) (api.NetworkTotalsHistoryResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network totals-history %d", days))
+	if err != nil {
+		return api.NetworkTotalsHistoryResponse{}, fmt.Errorf("could not get network totals history: %w", err)
+	}
+	var response api.NetworkTotalsHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkTotalsHistoryResponse{}, fmt.Errorf("could not decode network totals history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkTotalsHistoryResponse{}, fmt.Errorf("could not get network totals history: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get every rewards interval's on-chain submission and the consistency of its local rewards tree file, optionally downloading the files that are missing or inconsistent
+func (c *Client) NetworkIntervals(downloadMissing bool) (api.NetworkIntervalsResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network intervals %t", downloadMissing))
+	if err != nil {
+		return api.NetworkIntervalsResponse{}, fmt.Errorf("could not get network intervals: %w", err)
+	}
+	var response api.NetworkIntervalsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkIntervalsResponse{}, fmt.Errorf("could not decode network intervals response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkIntervalsResponse{}, fmt.Errorf("could not get network intervals: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check if the watchtower's rewards tree for an interval is being held back by the consistency gate
+func (c *Client) CanApproveRewardsTree(interval uint64) (api.CanApproveRewardsTreeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network can-approve-rewards-tree %d", interval))
+	if err != nil {
+		return api.CanApproveRewardsTreeResponse{}, fmt.Errorf("could not get consistency gate report: %w", err)
+	}
+	var response api.CanApproveRewardsTreeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanApproveRewardsTreeResponse{}, fmt.Errorf("could not decode consistency gate report response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanApproveRewardsTreeResponse{}, fmt.Errorf("could not get consistency gate report: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Approve submitting the watchtower's rewards tree for an interval despite it failing the consistency gate
+func (c *Client) ApproveRewardsTree(interval uint64) (api.ApproveRewardsTreeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network approve-rewards-tree %d", interval))
+	if err != nil {
+		return api.ApproveRewardsTreeResponse{}, fmt.Errorf("could not approve rewards tree: %w", err)
+	}
+	var response api.ApproveRewardsTreeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ApproveRewardsTreeResponse{}, fmt.Errorf("could not decode approve rewards tree response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ApproveRewardsTreeResponse{}, fmt.Errorf("could not approve rewards tree: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Verify the signer quorum's attestation over an interval's artifact manifest
+func (c *Client) VerifyAttestation(interval uint64) (api.VerifyAttestationResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network verify-attestation %d", interval))
+	if err != nil {
+		return api.VerifyAttestationResponse{}, fmt.Errorf("could not verify attestation: %w", err)
+	}
+	var response api.VerifyAttestationResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.VerifyAttestationResponse{}, fmt.Errorf("could not decode verify attestation response: %w", err)
+	}
+	if response.Error != "" {
+		return api.VerifyAttestationResponse{}, fmt.Errorf("could not verify attestation: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Regenerate an interval's rewards tree and compare it with the published tree and the on-chain Merkle root
+func (c *Client) VerifyRewardsTree(interval uint64) (api.VerifyRewardsTreeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network verify-rewards-tree %d", interval))
+	if err != nil {
+		return api.VerifyRewardsTreeResponse{}, fmt.Errorf("could not verify rewards tree: %w", err)
+	}
+	var response api.VerifyRewardsTreeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.VerifyRewardsTreeResponse{}, fmt.Errorf("could not decode verify rewards tree response: %w", err)
+	}
+	if response.Error != "" {
+		return api.VerifyRewardsTreeResponse{}, fmt.Errorf("could not verify rewards tree: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Export the rewards file and minipool performance file for an interval as CSV files
+func (c *Client) ExportRewardsCSV(interval uint64) (api.ExportRewardsCSVResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network export-rewards-csv %d", interval))
+	if err != nil {
+		return api.ExportRewardsCSVResponse{}, fmt.Errorf("could not export rewards CSV: %w", err)
+	}
+	var response api.ExportRewardsCSVResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ExportRewardsCSVResponse{}, fmt.Errorf("could not decode export rewards CSV response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ExportRewardsCSVResponse{}, fmt.Errorf("could not export rewards CSV: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the network's smoothing pool membership and the node's expected share of the pool, with the registration changes made over the given number of days
+func (c *Client) SmoothingPoolStats(days uint64) (api.SmoothingPoolStatsResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network smoothing-pool-stats %d", days))
+	if err != nil {
+		return api.SmoothingPoolStatsResponse{}, fmt.Errorf("could not get smoothing pool stats: %w", err)
+	}
+	var response api.SmoothingPoolStatsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SmoothingPoolStatsResponse{}, fmt.Errorf("could not decode smoothing pool stats response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SmoothingPoolStatsResponse{}, fmt.Errorf("could not get smoothing pool stats: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get a snapshot of the network state at a Beacon slot (or the head slot if it's 0), limited to the given fields (or all of them if there are none)
+func (c *Client) NetworkState(slot uint64, fields []string) (api.NetworkStateResponse, error) {
+	command := fmt.Sprintf("network state --slot %d", slot)
+	if len(fields) > 0 {
+		command += fmt.Sprintf(" --fields %s", strings.Join(fields, ","))
+	}
+	responseBytes, err := c.callAPI(command)
+	if err != nil {
+		return api.NetworkStateResponse{}, fmt.Errorf("could not get network state: %w", err)
+	}
+	var response api.NetworkStateResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkStateResponse{}, fmt.Errorf("could not decode network state response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkStateResponse{}, fmt.Errorf("could not get network state: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check if Houston Hotfix 1.3.1 has been deployed yet
 func (c *Client) IsHoustonHotfixDeployed() (api.IsHoustonHotfixDeployedResponse, error) {
 	responseBytes, err := c.callAPI("network is-houston-hotfix-deployed")
@@ -152,6 +152,22 @@ func (c *Client) ConfirmNodePrimaryWithdrawalAddress() (api.SetNodePrimaryWithdr
 	return response, nil
 }
 
+// Get the transaction that must be sent from the node's pending primary withdrawal address to confirm it
+func (c *Client) GetNodePrimaryWithdrawalAddressConfirmationTx() (api.GetNodePrimaryWithdrawalAddressConfirmationTxResponse, error) {
+	responseBytes, err := c.callAPI("node get-primary-withdrawal-address-confirmation-tx")
+	if err != nil {
+		return api.GetNodePrimaryWithdrawalAddressConfirmationTxResponse{}, fmt.Errorf("Could not get primary withdrawal address confirmation transaction: %w", err)
+	}
+	var response api.GetNodePrimaryWithdrawalAddressConfirmationTxResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GetNodePrimaryWithdrawalAddressConfirmationTxResponse{}, fmt.Errorf("Could not decode primary withdrawal address confirmation transaction response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GetNodePrimaryWithdrawalAddressConfirmationTxResponse{}, fmt.Errorf("Could not get primary withdrawal address confirmation transaction: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Checks if the node's RPL withdrawal address can be set
 func (c *Client) CanSetNodeRPLWithdrawalAddress(withdrawalAddress common.Address, confirm bool) (api.CanSetNodeRPLWithdrawalAddressResponse, error) {
 	responseBytes, err := c.callAPI("node can-set-rpl-withdrawal-address", withdrawalAddress.Hex(), strconv.FormatBool(confirm))
@@ -248,6 +264,70 @@ func (c *Client) SetNodeTimezone(timezoneLocation string) (api.SetNodeTimezoneRe
 	return response, nil
 }
 
+// Get the number of nodes registered in each timezone
+func (c *Client) NodeTimezoneDistribution() (api.NodeTimezoneDistributionResponse, error) {
+	responseBytes, err := c.callAPI("node timezone-distribution")
+	if err != nil {
+		return api.NodeTimezoneDistributionResponse{}, fmt.Errorf("Could not get node timezone distribution: %w", err)
+	}
+	var response api.NodeTimezoneDistributionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeTimezoneDistributionResponse{}, fmt.Errorf("Could not decode node timezone distribution response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeTimezoneDistributionResponse{}, fmt.Errorf("Could not get node timezone distribution: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the contact info a node operator has published on their node's ENS name
+func (c *Client) NodeContactInfo(address common.Address) (api.NodeContactInfoResponse, error) {
+	responseBytes, err := c.callAPI("node contact-info", address.Hex())
+	if err != nil {
+		return api.NodeContactInfoResponse{}, fmt.Errorf("Could not get node contact info: %w", err)
+	}
+	var response api.NodeContactInfoResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeContactInfoResponse{}, fmt.Errorf("Could not decode node contact info response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeContactInfoResponse{}, fmt.Errorf("Could not get node contact info: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node can publish contact info on its ENS name
+func (c *Client) CanSetNodeContactInfo(key string, value string) (api.CanSetNodeContactInfoResponse, error) {
+	responseBytes, err := c.callAPI("node can-set-contact-info", key, value)
+	if err != nil {
+		return api.CanSetNodeContactInfoResponse{}, fmt.Errorf("Could not get can set node contact info: %w", err)
+	}
+	var response api.CanSetNodeContactInfoResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanSetNodeContactInfoResponse{}, fmt.Errorf("Could not decode can set node contact info response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanSetNodeContactInfoResponse{}, fmt.Errorf("Could not get can set node contact info: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Publish contact info on the node's ENS name
+func (c *Client) SetNodeContactInfo(key string, value string) (api.SetNodeContactInfoResponse, error) {
+	responseBytes, err := c.callAPI("node set-contact-info", key, value)
+	if err != nil {
+		return api.SetNodeContactInfoResponse{}, fmt.Errorf("Could not set node contact info: %w", err)
+	}
+	var response api.SetNodeContactInfoResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SetNodeContactInfoResponse{}, fmt.Errorf("Could not decode set node contact info response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SetNodeContactInfoResponse{}, fmt.Errorf("Could not set node contact info: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether the node can swap RPL tokens
 func (c *Client) CanNodeSwapRpl(amountWei *big.Int) (api.CanNodeSwapRplResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("node can-swap-rpl %s", amountWei.String()))
@@ -680,6 +760,54 @@ func (c *Client) NodeSync() (api.NodeSyncProgressResponse, error) {
 	return response, nil
 }
 
+// Get the network leaderboard's top nodes and the node's own rank
+func (c *Client) NodeLeaderboard(count uint64) (api.NodeLeaderboardResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node leaderboard %d", count))
+	if err != nil {
+		return api.NodeLeaderboardResponse{}, fmt.Errorf("Could not get node leaderboard: %w", err)
+	}
+	var response api.NodeLeaderboardResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeLeaderboardResponse{}, fmt.Errorf("Could not decode node leaderboard response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeLeaderboardResponse{}, fmt.Errorf("Could not get node leaderboard: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check the node's entry in the official rewards tree for an interval
+func (c *Client) VerifyMyRewards(interval uint64) (api.NodeVerifyMyRewardsResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node verify-my-rewards %d", interval))
+	if err != nil {
+		return api.NodeVerifyMyRewardsResponse{}, fmt.Errorf("Could not verify node rewards: %w", err)
+	}
+	var response api.NodeVerifyMyRewardsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeVerifyMyRewardsResponse{}, fmt.Errorf("Could not decode verify node rewards response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeVerifyMyRewardsResponse{}, fmt.Errorf("Could not verify node rewards: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the attestation effectiveness of the node's validators for an interval
+func (c *Client) NodeEffectiveness(interval uint64) (api.NodeEffectivenessResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node effectiveness %d", interval))
+	if err != nil {
+		return api.NodeEffectivenessResponse{}, fmt.Errorf("Could not get node effectiveness: %w", err)
+	}
+	var response api.NodeEffectivenessResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeEffectivenessResponse{}, fmt.Errorf("Could not decode node effectiveness response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeEffectivenessResponse{}, fmt.Errorf("Could not get node effectiveness: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether the node has RPL rewards available to claim
 func (c *Client) CanNodeClaimRpl() (api.CanNodeClaimRplResponse, error) {
 	responseBytes, err := c.callAPI("node can-claim-rpl-rewards")
@@ -840,6 +968,42 @@ func (c *Client) GetRewardsInfo() (api.NodeGetRewardsInfoResponse, error) {
 	return response, nil
 }
 
+// Get the claim status of every rewards interval for the node
+func (c *Client) NodeClaimStatus() (api.NodeClaimStatusResponse, error) {
+	responseBytes, err := c.callAPI("node claim-status")
+	if err != nil {
+		return api.NodeClaimStatusResponse{}, fmt.Errorf("Could not get claim status: %w", err)
+	}
+	var response api.NodeClaimStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeClaimStatusResponse{}, fmt.Errorf("Could not decode claim status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeClaimStatusResponse{}, fmt.Errorf("Could not get claim status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Build a bundle for claiming the rewards of the given intervals from another wallet
+func (c *Client) NodeExportClaimBundle(indices []uint64) (api.NodeExportClaimBundleResponse, error) {
+	indexStrings := []string{}
+	for _, index := range indices {
+		indexStrings = append(indexStrings, fmt.Sprint(index))
+	}
+	responseBytes, err := c.callAPI("node export-claim-bundle", strings.Join(indexStrings, ","))
+	if err != nil {
+		return api.NodeExportClaimBundleResponse{}, fmt.Errorf("Could not export claim bundle: %w", err)
+	}
+	var response api.NodeExportClaimBundleResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeExportClaimBundleResponse{}, fmt.Errorf("Could not decode export claim bundle response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeExportClaimBundleResponse{}, fmt.Errorf("Could not export claim bundle: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check if the rewards for the given intervals can be claimed
 func (c *Client) CanNodeClaimRewards(indices []uint64) (api.CanNodeClaimRewardsResponse, error) {
 	indexStrings := []string{}
@@ -880,6 +1044,46 @@ func (c *Client) NodeClaimRewards(indices []uint64) (api.NodeClaimRewardsRespons
 	return response, nil
 }
 
+// Check if the rewards for the given intervals can be claimed on the Layer 2 network they were routed to
+func (c *Client) CanNodeClaimL2Rewards(network uint64, indices []uint64) (api.CanNodeClaimL2RewardsResponse, error) {
+	indexStrings := []string{}
+	for _, index := range indices {
+		indexStrings = append(indexStrings, fmt.Sprint(index))
+	}
+	responseBytes, err := c.callAPI(fmt.Sprintf("node can-claim-l2-rewards %d %s", network, strings.Join(indexStrings, ",")))
+	if err != nil {
+		return api.CanNodeClaimL2RewardsResponse{}, fmt.Errorf("Could not check Layer 2 claim status: %w", err)
+	}
+	var response api.CanNodeClaimL2RewardsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanNodeClaimL2RewardsResponse{}, fmt.Errorf("Could not decode can claim Layer 2 rewards response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanNodeClaimL2RewardsResponse{}, fmt.Errorf("Could not check Layer 2 claim status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Claim rewards for the given intervals on the Layer 2 network they were routed to
+func (c *Client) NodeClaimL2Rewards(network uint64, indices []uint64) (api.NodeClaimL2RewardsResponse, error) {
+	indexStrings := []string{}
+	for _, index := range indices {
+		indexStrings = append(indexStrings, fmt.Sprint(index))
+	}
+	responseBytes, err := c.callAPI(fmt.Sprintf("node claim-l2-rewards %d %s", network, strings.Join(indexStrings, ",")))
+	if err != nil {
+		return api.NodeClaimL2RewardsResponse{}, fmt.Errorf("Could not claim Layer 2 rewards: %w", err)
+	}
+	var response api.NodeClaimL2RewardsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeClaimL2RewardsResponse{}, fmt.Errorf("Could not decode claim Layer 2 rewards response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeClaimL2RewardsResponse{}, fmt.Errorf("Could not claim Layer 2 rewards: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check if the rewards for the given intervals can be claimed, and RPL restaked automatically
 func (c *Client) CanNodeClaimAndStakeRewards(indices []uint64, stakeAmountWei *big.Int) (api.CanNodeClaimAndStakeRewardsResponse, error) {
 	indexStrings := []string{}
@@ -1111,3 +1315,51 @@ func (c *Client) SendMessage(address common.Address, message []byte) (api.NodeSe
 	}
 	return response, nil
 }
+
+// Get the network the node's rewards are routed to, and whether it's valid
+func (c *Client) RewardNetworkStatus() (api.NodeRewardNetworkStatusResponse, error) {
+	responseBytes, err := c.callAPI("node reward-network-status")
+	if err != nil {
+		return api.NodeRewardNetworkStatusResponse{}, fmt.Errorf("Could not get reward network status: %w", err)
+	}
+	var response api.NodeRewardNetworkStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeRewardNetworkStatusResponse{}, fmt.Errorf("Could not decode reward network status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeRewardNetworkStatusResponse{}, fmt.Errorf("Could not get reward network status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check if the node's reward network can be changed, and get the transaction that changes it
+func (c *Client) CanSetRewardNetwork(network uint64) (api.CanNodeSetRewardNetworkResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node can-set-reward-network %d", network))
+	if err != nil {
+		return api.CanNodeSetRewardNetworkResponse{}, fmt.Errorf("Could not get can set reward network status: %w", err)
+	}
+	var response api.CanNodeSetRewardNetworkResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanNodeSetRewardNetworkResponse{}, fmt.Errorf("Could not decode can set reward network response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanNodeSetRewardNetworkResponse{}, fmt.Errorf("Could not get can set reward network status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Change the node's reward network
+func (c *Client) SetRewardNetwork(network uint64) (api.NodeSetRewardNetworkResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node set-reward-network %d", network))
+	if err != nil {
+		return api.NodeSetRewardNetworkResponse{}, fmt.Errorf("Could not set reward network: %w", err)
+	}
+	var response api.NodeSetRewardNetworkResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeSetRewardNetworkResponse{}, fmt.Errorf("Could not decode set reward network response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeSetRewardNetworkResponse{}, fmt.Errorf("Could not set reward network: %s", response.Error)
+	}
+	return response, nil
+}
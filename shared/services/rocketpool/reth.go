@@ -0,0 +1,57 @@
+package rocketpool
+
+import (
+	"fmt"
+
+	"github.com/goccy/go-json"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get the rETH exchange rate, the next rate update, and the deposit and burn capacity
+func (c *Client) RethStatus() (api.RethStatusResponse, error) {
+	responseBytes, err := c.callAPI("reth status")
+	if err != nil {
+		return api.RethStatusResponse{}, fmt.Errorf("Could not get rETH status: %w", err)
+	}
+	var response api.RethStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RethStatusResponse{}, fmt.Errorf("Could not decode rETH status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RethStatusResponse{}, fmt.Errorf("Could not get rETH status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the rETH exchange rate recorded over the given number of days
+func (c *Client) RethRateHistory(days uint64) (api.RethRateHistoryResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("reth rate-history %d", days))
+	if err != nil {
+		return api.RethRateHistoryResponse{}, fmt.Errorf("Could not get rETH rate history: %w", err)
+	}
+	var response api.RethRateHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RethRateHistoryResponse{}, fmt.Errorf("Could not decode rETH rate history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RethRateHistoryResponse{}, fmt.Errorf("Could not get rETH rate history: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the projected rETH APR, using the exchange rate recorded over the given number of days
+func (c *Client) RethApr(days uint64) (api.RethAprResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("reth apr %d", days))
+	if err != nil {
+		return api.RethAprResponse{}, fmt.Errorf("Could not get rETH APR: %w", err)
+	}
+	var response api.RethAprResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RethAprResponse{}, fmt.Errorf("Could not decode rETH APR response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RethAprResponse{}, fmt.Errorf("Could not get rETH APR: %s", response.Error)
+	}
+	return response, nil
+}
@@ -56,3 +56,51 @@ func (c *Client) RestartVc() (api.RestartVcResponse, error) {
 	}
 	return response, nil
 }
+
+// Gets the result of the validator client watchdog's latest check
+func (c *Client) GetVcWatchdogStatus() (api.VcWatchdogStatusResponse, error) {
+	responseBytes, err := c.callAPI("service get-vc-watchdog-status")
+	if err != nil {
+		return api.VcWatchdogStatusResponse{}, fmt.Errorf("Could not get validator client watchdog status: %w", err)
+	}
+	var response api.VcWatchdogStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.VcWatchdogStatusResponse{}, fmt.Errorf("Could not decode validator client watchdog status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.VcWatchdogStatusResponse{}, fmt.Errorf("Could not get validator client watchdog status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Probes the Execution client's database and the disk for problems that would fail tree generation
+func (c *Client) ProbeEc() (api.ProbeEcResponse, error) {
+	responseBytes, err := c.callAPI("service probe-ec")
+	if err != nil {
+		return api.ProbeEcResponse{}, fmt.Errorf("Could not probe the Execution client: %w", err)
+	}
+	var response api.ProbeEcResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ProbeEcResponse{}, fmt.Errorf("Could not decode Execution client probe response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ProbeEcResponse{}, fmt.Errorf("Could not probe the Execution client: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Gets the anonymous telemetry report that would be submitted
+func (c *Client) GetTelemetryPreview() (api.TelemetryPreviewResponse, error) {
+	responseBytes, err := c.callAPI("service telemetry-preview")
+	if err != nil {
+		return api.TelemetryPreviewResponse{}, fmt.Errorf("Could not get telemetry preview: %w", err)
+	}
+	var response api.TelemetryPreviewResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TelemetryPreviewResponse{}, fmt.Errorf("Could not decode telemetry-preview response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TelemetryPreviewResponse{}, fmt.Errorf("Could not get telemetry preview: %s", response.Error)
+	}
+	return response, nil
+}
@@ -0,0 +1,59 @@
+package rocketpool
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/simulation"
+)
+
+// The simulated transactions in an API response
+type simulatedResponse struct {
+	Simulations []simulation.Report `json:"simulations"`
+}
+
+// If an API response came from a simulated transaction, print what it would have done and exit, since nothing
+// was sent for the rest of the command to wait on
+func (c *Client) finishSimulation(output []byte) {
+	var response simulatedResponse
+	if err := json.Unmarshal(output, &response); err != nil || len(response.Simulations) == 0 {
+		return
+	}
+
+	for _, report := range response.Simulations {
+		fmt.Println("=== Simulated Transaction (not sent) ===")
+		fmt.Printf("From:      %s\n", report.From.Hex())
+		if report.To != nil {
+			fmt.Printf("To:        %s\n", report.To.Hex())
+		}
+		fmt.Printf("Value:     %.6f ETH\n", eth.WeiToEth(report.Value))
+		fmt.Printf("Nonce:     %d\n", report.Nonce)
+		if report.Success {
+			fmt.Printf("Result:    %ssuccess%s\n", colorGreen, colorReset)
+		} else {
+			fmt.Printf("Result:    %sreverted (%s)%s\n", colorRed, report.RevertReason, colorReset)
+		}
+		if report.GasEstimate > 0 {
+			fmt.Printf("Gas:       %d estimated, %d limit\n", report.GasEstimate, report.GasLimit)
+		} else {
+			fmt.Printf("Gas:       %d limit\n", report.GasLimit)
+		}
+		fmt.Printf("Gas cost:  %.6f ETH expected at the current base fee, %.6f ETH at most\n", eth.WeiToEth(report.ExpectedGasCost), eth.WeiToEth(report.MaxGasCost))
+		if len(report.BalanceChanges) > 0 {
+			fmt.Println("Projected ETH balance changes:")
+			for _, change := range report.BalanceChanges {
+				fmt.Printf("\t%s: %.6f -> %.6f (%+.6f)\n", change.Address.Hex(), eth.WeiToEth(change.Before), eth.WeiToEth(change.After), eth.WeiToEth(change.Change))
+			}
+		}
+		if report.BalanceChangeNote != "" {
+			fmt.Println(report.BalanceChangeNote)
+		}
+		fmt.Println()
+	}
+	fmt.Println("This was a dry run, so nothing was sent. Any further transactions this command would send depend on this one, so they weren't simulated.")
+	c.Close()
+	os.Exit(0)
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"os"
@@ -16,6 +17,8 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/services/faults"
+	"github.com/rocket-pool/smartnode/shared/services/nonce"
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	lhkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
@@ -23,6 +26,8 @@ import (
 	nmkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
 	prkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
 	tkkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/teku"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/ens"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -38,21 +43,33 @@ var (
 	nodeWallet           *wallet.Wallet
 	ecManager            *ExecutionClientManager
 	bcManager            *BeaconClientManager
+	historicalBcManager  *BeaconClientManager
+	historicalBcErr      error
 	rocketPool           *rocketpool.RocketPool
 	rocketSignerRegistry *contracts.RocketSignerRegistry
 	beaconClient         beacon.Client
 	docker               *client.Client
+	faultInjector        *faults.Injector
+	faultInjectorErr     error
+	ensResolver          *ens.Resolver
+	nonceCoordinator     *nonce.Coordinator
+	coordinateNonces     bool
+	noncePriority        nonce.Priority
 
 	initCfg                  sync.Once
 	initPasswordManager      sync.Once
 	initNodeWallet           sync.Once
 	initECManager            sync.Once
 	initBCManager            sync.Once
+	initHistoricalBCManager  sync.Once
 	initRocketPool           sync.Once
 	initOneInchOracle        sync.Once
 	initRocketSignerRegistry sync.Once
 	initBeaconClient         sync.Once
 	initDocker               sync.Once
+	initFaultInjector        sync.Once
+	initEnsResolver          sync.Once
+	initNonceCoordinator     sync.Once
 )
 
 //
@@ -98,7 +115,7 @@ func GetRocketPool(c *cli.Context) (*rocketpool.RocketPool, error) {
 		return nil, err
 	}
 	var ec rocketpool.ExecutionClient
-	if c.GlobalBool("use-protected-api") {
+	if c.GlobalBool("use-protected-api") && !c.GlobalBool("simulate") {
 		url := cfg.Smartnode.GetFlashbotsProtectUrl()
 		ec, err = ethclient.Dial(url)
 	} else {
@@ -123,6 +140,18 @@ func GetRocketSignerRegistry(c *cli.Context) (*contracts.RocketSignerRegistry, e
 	return getRocketSignerRegistry(cfg, ec)
 }
 
+func GetEnsResolver(c *cli.Context) (*ens.Resolver, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := getEthClient(c, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return getEnsResolver(cfg, ec), nil
+}
+
 func GetBeaconClient(c *cli.Context) (*BeaconClientManager, error) {
 	cfg, err := getConfig(c)
 	if err != nil {
@@ -131,6 +160,34 @@ func GetBeaconClient(c *cli.Context) (*BeaconClientManager, error) {
 	return getBeaconClient(c, cfg)
 }
 
+// Get the Beacon client for heavy historical workloads like tree generation and rolling records.
+// This is the dedicated historical Beacon Node if one is configured, or the normal Beacon client otherwise.
+func GetHistoricalBeaconClient(c *cli.Context) (*BeaconClientManager, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return getHistoricalBeaconClient(c, cfg)
+}
+
+// Get the URLs of the primary Execution client and Beacon Node, ignoring the caching proxy.
+// These are the clients the caching proxy forwards requests to.
+// Coordinate the nonces of transactions sent from the node wallet with the other processes that use it.
+// This must be called before the wallet or the execution client are first retrieved.
+func EnableNonceCoordination(priority nonce.Priority) {
+	coordinateNonces = true
+	noncePriority = priority
+}
+
+func GetPrimaryClientUrls(cfg *config.RocketPoolConfig) (string, string, error) {
+	ecUrl, _ := getExecutionClientUrls(cfg)
+	bcUrl, _, err := getBeaconProviders(cfg)
+	if err != nil {
+		return "", "", err
+	}
+	return ecUrl, bcUrl, nil
+}
+
 func GetDocker(c *cli.Context) (*client.Client, error) {
 	var err error
 	initDocker.Do(func() {
@@ -185,7 +242,17 @@ func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.Passw
 
 		chainId := cfg.Smartnode.GetChainID()
 
-		nodeWallet, err = wallet.NewWallet(os.ExpandEnv(cfg.Smartnode.GetWalletPath()), chainId, maxFee, maxPriorityFee, 0, pm)
+		// Use a throwaway wallet with deterministic keys if requested, for devnets
+		testWalletSeed := c.GlobalString("test-wallet")
+		if testWalletSeed != "" {
+			if cfg.Smartnode.Network.Value.(cfgtypes.Network) == cfgtypes.Network_Mainnet {
+				err = errors.New("test wallets can't be used on Mainnet")
+				return
+			}
+			nodeWallet, err = wallet.NewTestWallet(testWalletSeed, os.ExpandEnv(cfg.Smartnode.GetTestWalletStatePath()), chainId, maxFee, maxPriorityFee, 0, pm)
+		} else {
+			nodeWallet, err = wallet.NewWallet(os.ExpandEnv(cfg.Smartnode.GetWalletPath()), chainId, maxFee, maxPriorityFee, 0, pm)
+		}
 		if err != nil {
 			return
 		}
@@ -201,6 +268,17 @@ func getWallet(c *cli.Context, cfg *config.RocketPoolConfig, pm *passwords.Passw
 		nodeWallet.AddKeystore("nimbus", nimbusKeystore)
 		nodeWallet.AddKeystore("prysm", prysmKeystore)
 		nodeWallet.AddKeystore("teku", tekuKeystore)
+
+		// Reserve nonces for the node account's transactions, unless they're sent through the protected API,
+		// which doesn't release them once they've been sent
+		if coordinateNonces && !(c.GlobalBool("use-protected-api") && !c.GlobalBool("simulate")) {
+			var ec *ExecutionClientManager
+			ec, err = getEthClient(c, cfg)
+			if err != nil {
+				return
+			}
+			nodeWallet.SetNonceCoordinator(getNonceCoordinator(cfg), ec)
+		}
 	})
 	return nodeWallet, err
 }
@@ -209,7 +287,12 @@ func getEthClient(c *cli.Context, cfg *config.RocketPoolConfig) (*ExecutionClien
 	var err error
 	initECManager.Do(func() {
 		// Create a new client manager
-		ecManager, err = NewExecutionClientManager(cfg)
+		var injector *faults.Injector
+		injector, err = getFaultInjector(c, cfg)
+		if err != nil {
+			return
+		}
+		ecManager, err = NewExecutionClientManager(cfg, injector)
 		if err == nil {
 			// Check if the manager should ignore sync checks and/or default to using the fallback (used by the API container when driven by the CLI)
 			if c.GlobalBool("ignore-sync-check") {
@@ -218,11 +301,25 @@ func getEthClient(c *cli.Context, cfg *config.RocketPoolConfig) (*ExecutionClien
 			if c.GlobalBool("force-fallbacks") {
 				ecManager.primaryReady = false
 			}
+			if c.GlobalBool("simulate") {
+				ecManager.simulate = true
+			}
+			ecManager.auditor = newTransactionAuditor(c, cfg)
+			if coordinateNonces {
+				ecManager.SetNonceCoordinator(getNonceCoordinator(cfg))
+			}
 		}
 	})
 	return ecManager, err
 }
 
+func getNonceCoordinator(cfg *config.RocketPoolConfig) *nonce.Coordinator {
+	initNonceCoordinator.Do(func() {
+		nonceCoordinator = nonce.NewCoordinator(os.ExpandEnv(cfg.Smartnode.GetNonceLockFolder()), noncePriority)
+	})
+	return nonceCoordinator
+}
+
 func getRocketPool(cfg *config.RocketPoolConfig, client rocketpool.ExecutionClient) (*rocketpool.RocketPool, error) {
 	var err error
 	initRocketPool.Do(func() {
@@ -242,11 +339,23 @@ func getRocketSignerRegistry(cfg *config.RocketPoolConfig, client rocketpool.Exe
 	return rocketSignerRegistry, err
 }
 
+func getEnsResolver(cfg *config.RocketPoolConfig, client rocketpool.ExecutionClient) *ens.Resolver {
+	initEnsResolver.Do(func() {
+		ensResolver = ens.NewResolver(client, cfg.Smartnode.GetEnsCachePath(), cfg.Smartnode.ResolveEnsNames.Value.(bool))
+	})
+	return ensResolver
+}
+
 func getBeaconClient(c *cli.Context, cfg *config.RocketPoolConfig) (*BeaconClientManager, error) {
 	var err error
 	initBCManager.Do(func() {
 		// Create a new client manager
-		bcManager, err = NewBeaconClientManager(cfg)
+		var injector *faults.Injector
+		injector, err = getFaultInjector(c, cfg)
+		if err != nil {
+			return
+		}
+		bcManager, err = NewBeaconClientManager(cfg, injector)
 		if err == nil {
 			// Check if the manager should ignore sync checks and/or default to using the fallback (used by the API container when driven by the CLI)
 			if c.GlobalBool("ignore-sync-check") {
@@ -259,3 +368,34 @@ func getBeaconClient(c *cli.Context, cfg *config.RocketPoolConfig) (*BeaconClien
 	})
 	return bcManager, err
 }
+
+func getHistoricalBeaconClient(c *cli.Context, cfg *config.RocketPoolConfig) (*BeaconClientManager, error) {
+	initHistoricalBCManager.Do(func() {
+		var injector *faults.Injector
+		injector, historicalBcErr = getFaultInjector(c, cfg)
+		if historicalBcErr != nil {
+			return
+		}
+		historicalBcManager, historicalBcErr = NewHistoricalBeaconClientManager(cfg, injector)
+		if historicalBcErr == nil && historicalBcManager != nil && c.GlobalBool("ignore-sync-check") {
+			historicalBcManager.ignoreSyncCheck = true
+		}
+	})
+	if historicalBcErr != nil {
+		// Never quietly fall back to the VC's Beacon Node if the dedicated one is misconfigured
+		return nil, historicalBcErr
+	}
+	if historicalBcManager == nil {
+		return getBeaconClient(c, cfg)
+	}
+	return historicalBcManager, nil
+}
+
+// Get the fault injector for this daemon, which is nil unless the hidden fault injection settings file exists
+func getFaultInjector(c *cli.Context, cfg *config.RocketPoolConfig) (*faults.Injector, error) {
+	initFaultInjector.Do(func() {
+		daemon, _ := getAuditCommand(c)
+		faultInjector, faultInjectorErr = faults.Load(cfg.Smartnode.GetFaultInjectionPath(true), daemon)
+	})
+	return faultInjector, faultInjectorErr
+}
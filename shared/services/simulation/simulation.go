@@ -0,0 +1,57 @@
+package simulation
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Returned in place of sending a transaction when the API is run with --simulate
+var ErrSimulated = errors.New("the transaction was simulated instead of being sent")
+
+// The projected change in an account's ETH balance
+type BalanceChange struct {
+	Address common.Address `json:"address"`
+	Before  *big.Int       `json:"before"`
+	After   *big.Int       `json:"after"`
+	Change  *big.Int       `json:"change"`
+}
+
+// The result of simulating a transaction against the pending block instead of sending it
+type Report struct {
+	From              common.Address  `json:"from"`
+	To                *common.Address `json:"to,omitempty"`
+	Value             *big.Int        `json:"value"`
+	Nonce             uint64          `json:"nonce"`
+	Success           bool            `json:"success"`
+	RevertReason      string          `json:"revertReason,omitempty"`
+	GasLimit          uint64          `json:"gasLimit"`
+	GasEstimate       uint64          `json:"gasEstimate"`
+	MaxFee            *big.Int        `json:"maxFee"`
+	MaxPriorityFee    *big.Int        `json:"maxPriorityFee"`
+	ExpectedGasCost   *big.Int        `json:"expectedGasCost"`
+	MaxGasCost        *big.Int        `json:"maxGasCost"`
+	BalanceChanges    []BalanceChange `json:"balanceChanges"`
+	BalanceChangeNote string          `json:"balanceChangeNote,omitempty"`
+}
+
+var (
+	reports []Report
+	lock    sync.Mutex
+)
+
+// Record the result of a simulated transaction
+func Record(report Report) {
+	lock.Lock()
+	defer lock.Unlock()
+	reports = append(reports, report)
+}
+
+// Get the results of every transaction simulated by this process
+func GetReports() []Report {
+	lock.Lock()
+	defer lock.Unlock()
+	return append([]Report{}, reports...)
+}
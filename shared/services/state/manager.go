@@ -80,6 +80,11 @@ func (m *NetworkStateManager) GetStateForSlot(slotNumber uint64) (*NetworkState,
 	return m.getState(slotNumber)
 }
 
+// Get the state of the network for a single node at the provided Beacon slot, along with the total effective RPL stake for the network
+func (m *NetworkStateManager) GetStateForNodeAtSlot(nodeAddress common.Address, slotNumber uint64, calculateTotalEffectiveStake bool) (*NetworkState, *big.Int, error) {
+	return m.getStateForNode(nodeAddress, slotNumber, calculateTotalEffectiveStake)
+}
+
 // Gets the latest valid block
 func (m *NetworkStateManager) GetLatestBeaconBlock() (beacon.BeaconBlock, error) {
 	targetSlot, err := m.GetHeadSlot()
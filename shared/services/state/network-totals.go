@@ -0,0 +1,155 @@
+package state
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// A sample of the network's aggregate values at one epoch
+type NetworkTotalsSample struct {
+	Epoch                uint64    `json:"epoch"`
+	Slot                 uint64    `json:"slot"`
+	Time                 time.Time `json:"time"`
+	TotalNodeWeight      *big.Int  `json:"totalNodeWeight"`
+	SmoothingPoolBalance *big.Int  `json:"smoothingPoolBalance"`
+	RethExchangeRate     float64   `json:"rethExchangeRate"`
+	ActiveMinipools      uint64    `json:"activeMinipools"`
+
+	// Smoothing pool membership; these are zero in samples recorded before they were added
+	SmoothingPoolNodes     uint64 `json:"smoothingPoolNodes,omitempty"`
+	SmoothingPoolMinipools uint64 `json:"smoothingPoolMinipools,omitempty"`
+}
+
+// Take a sample of the network totals from a full network state
+func NewNetworkTotalsSample(s *NetworkState) (NetworkTotalsSample, error) {
+	_, totalWeight, err := s.CalculateNodeWeights()
+	if err != nil {
+		return NetworkTotalsSample{}, fmt.Errorf("error calculating node weights: %w", err)
+	}
+
+	activeMinipools := uint64(0)
+	for _, mpd := range s.MinipoolDetails {
+		if mpd.Status == types.Staking && !mpd.Finalised {
+			activeMinipools++
+		}
+	}
+	membership := GetSmoothingPoolMembership(s, time.Time{})
+
+	genesisTime := time.Unix(int64(s.BeaconConfig.GenesisTime), 0)
+	slotTime := genesisTime.Add(time.Duration(s.BeaconSlotNumber*s.BeaconConfig.SecondsPerSlot) * time.Second)
+	return NetworkTotalsSample{
+		Epoch:                  s.BeaconSlotNumber / s.BeaconConfig.SlotsPerEpoch,
+		Slot:                   s.BeaconSlotNumber,
+		Time:                   slotTime.UTC(),
+		TotalNodeWeight:        totalWeight,
+		SmoothingPoolBalance:   s.NetworkDetails.SmoothingPoolBalance,
+		RethExchangeRate:       s.NetworkDetails.RETHExchangeRate,
+		ActiveMinipools:        activeMinipools,
+		SmoothingPoolNodes:     membership.OptedInNodes,
+		SmoothingPoolMinipools: membership.OptedInMinipools,
+	}, nil
+}
+
+// Load the recorded network totals samples taken at or after the provided time, oldest first.
+// A missing file just means nothing has been recorded yet.
+func LoadNetworkTotals(path string, since time.Time) ([]NetworkTotalsSample, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []NetworkTotalsSample{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening network totals %s: %w", path, err)
+	}
+	defer file.Close()
+
+	samples := []NetworkTotalsSample{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var sample NetworkTotalsSample
+		if err := json.Unmarshal(line, &sample); err != nil {
+			// A partially written line from an interrupted save shouldn't hide the rest of the history
+			continue
+		}
+		if !sample.Time.Before(since) {
+			samples = append(samples, sample)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading network totals %s: %w", path, err)
+	}
+	return samples, nil
+}
+
+// Load the most recently recorded network totals sample, or nil if nothing has been recorded yet
+func LoadLatestNetworkTotalsSample(path string) (*NetworkTotalsSample, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening network totals %s: %w", path, err)
+	}
+	defer file.Close()
+
+	// Only the last complete line needs to be deserialized
+	var latest *NetworkTotalsSample
+	scanner := bufio.NewScanner(file)
+	var lastLine []byte
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) > 0 {
+			lastLine = append(lastLine[:0], line...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading network totals %s: %w", path, err)
+	}
+	if lastLine != nil {
+		latest = &NetworkTotalsSample{}
+		if err := json.Unmarshal(lastLine, latest); err != nil {
+			return nil, fmt.Errorf("error deserializing the latest network totals sample in %s: %w", path, err)
+		}
+	}
+	return latest, nil
+}
+
+// Record a network totals sample, dropping any samples older than the retention period.
+// Samples for an epoch that has already been recorded are ignored.
+func SaveNetworkTotalsSample(path string, sample NetworkTotalsSample, retention time.Duration) error {
+	samples, err := LoadNetworkTotals(path, sample.Time.Add(-retention))
+	if err != nil {
+		return err
+	}
+	if len(samples) > 0 && samples[len(samples)-1].Epoch >= sample.Epoch {
+		return nil
+	}
+	samples = append(samples, sample)
+
+	var buffer bytes.Buffer
+	for _, sample := range samples {
+		line, err := json.Marshal(sample)
+		if err != nil {
+			return fmt.Errorf("error serializing network totals sample for epoch %d: %w", sample.Epoch, err)
+		}
+		buffer.Write(line)
+		buffer.WriteByte('\n')
+	}
+
+	if err := files.WriteFileAtomic(path, buffer.Bytes(), 0644); err != nil {
+		return fmt.Errorf("error saving network totals: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package state
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+)
+
+// The smoothing pool registration changes made on one day (UTC)
+type SmoothingPoolMembershipChanges struct {
+	Day     time.Time `json:"day"`
+	OptIns  uint64    `json:"optIns"`
+	OptOuts uint64    `json:"optOuts"`
+}
+
+// Network-wide smoothing pool membership
+type SmoothingPoolMembership struct {
+	TotalNodes       uint64 `json:"totalNodes"`
+	OptedInNodes     uint64 `json:"optedInNodes"`
+	ActiveMinipools  uint64 `json:"activeMinipools"`
+	OptedInMinipools uint64 `json:"optedInMinipools"`
+
+	// Registration changes by day, oldest first.
+	// The contracts only keep the time of each node's latest change, so earlier changes by the same node aren't counted.
+	Changes []SmoothingPoolMembershipChanges `json:"changes"`
+}
+
+// A node's expected portion of the smoothing pool if it were opted in
+type NodeSmoothingPoolShare struct {
+	OptedIn         bool   `json:"optedIn"`
+	ActiveMinipools uint64 `json:"activeMinipools"`
+
+	// The node's fraction of the opted-in minipools
+	MinipoolShare float64 `json:"minipoolShare"`
+
+	// The fraction of the smoothing pool's ETH that would go to the node; this accounts for each minipool's bond and
+	// commission, since the rest of a minipool's portion goes to the rETH holders
+	EthShare float64 `json:"ethShare"`
+
+	// The node's portion of the smoothing pool's current balance
+	ExpectedEth *big.Int `json:"expectedEth"`
+}
+
+// Get the network's smoothing pool membership, including the registration changes made since the provided time
+func GetSmoothingPoolMembership(s *NetworkState, since time.Time) SmoothingPoolMembership {
+	membership := SmoothingPoolMembership{
+		TotalNodes: uint64(len(s.NodeDetails)),
+		Changes:    []SmoothingPoolMembershipChanges{},
+	}
+	changesByDay := map[int64]*SmoothingPoolMembershipChanges{}
+	for _, node := range s.NodeDetails {
+		activeMinipools := countActiveMinipools(s.MinipoolDetailsByNode[node.NodeAddress])
+		membership.ActiveMinipools += activeMinipools
+		if node.SmoothingPoolRegistrationState {
+			membership.OptedInNodes++
+			membership.OptedInMinipools += activeMinipools
+		}
+
+		// Nodes that have never changed their registration have a timestamp of 0
+		if node.SmoothingPoolRegistrationChanged == nil || node.SmoothingPoolRegistrationChanged.Sign() == 0 {
+			continue
+		}
+		changed := time.Unix(node.SmoothingPoolRegistrationChanged.Int64(), 0).UTC()
+		if changed.Before(since) {
+			continue
+		}
+		day := changed.Truncate(24 * time.Hour)
+		changes, exists := changesByDay[day.Unix()]
+		if !exists {
+			changes = &SmoothingPoolMembershipChanges{Day: day}
+			changesByDay[day.Unix()] = changes
+		}
+		if node.SmoothingPoolRegistrationState {
+			changes.OptIns++
+		} else {
+			changes.OptOuts++
+		}
+	}
+
+	for _, changes := range changesByDay {
+		membership.Changes = append(membership.Changes, *changes)
+	}
+	sort.Slice(membership.Changes, func(i, j int) bool {
+		return membership.Changes[i].Day.Before(membership.Changes[j].Day)
+	})
+	return membership
+}
+
+// Get the portion of the smoothing pool a node would earn if it's opted in, or if it were.
+// Every opted-in minipool earns the same portion of the pool (assuming equal performance), and the node keeps its bond
+// and commission's share of that portion.
+func GetNodeSmoothingPoolShare(s *NetworkState, nodeAddress common.Address, membership SmoothingPoolMembership) NodeSmoothingPoolShare {
+	share := NodeSmoothingPoolShare{
+		ExpectedEth: big.NewInt(0),
+	}
+	node, exists := s.NodeDetailsByAddress[nodeAddress]
+	if !exists {
+		return share
+	}
+	share.OptedIn = node.SmoothingPoolRegistrationState
+
+	nodePortion := 0.0
+	for _, mpd := range s.MinipoolDetailsByNode[nodeAddress] {
+		if !isActiveMinipool(mpd) {
+			continue
+		}
+		share.ActiveMinipools++
+		bond := eth.WeiToEth(mpd.NodeDepositBalance)
+		borrowed := eth.WeiToEth(mpd.UserDepositBalance)
+		fee := eth.WeiToEth(mpd.NodeFee)
+		if bond+borrowed > 0 {
+			nodePortion += (bond + fee*borrowed) / (bond + borrowed)
+		}
+	}
+
+	// Add the node's own minipools to the pool if it isn't in it yet
+	poolMinipools := membership.OptedInMinipools
+	if !share.OptedIn {
+		poolMinipools += share.ActiveMinipools
+	}
+	if poolMinipools == 0 {
+		return share
+	}
+	share.MinipoolShare = float64(share.ActiveMinipools) / float64(poolMinipools)
+	share.EthShare = nodePortion / float64(poolMinipools)
+	if s.NetworkDetails.SmoothingPoolBalance != nil {
+		share.ExpectedEth = eth.EthToWei(eth.WeiToEth(s.NetworkDetails.SmoothingPoolBalance) * share.EthShare)
+	}
+	return share
+}
+
+// Count the minipools that are staking
+func countActiveMinipools(minipools []*rpstate.NativeMinipoolDetails) uint64 {
+	count := uint64(0)
+	for _, mpd := range minipools {
+		if isActiveMinipool(mpd) {
+			count++
+		}
+	}
+	return count
+}
+
+// Check if a minipool is staking
+func isActiveMinipool(mpd *rpstate.NativeMinipoolDetails) bool {
+	return mpd.Status == types.Staking && !mpd.Finalised
+}
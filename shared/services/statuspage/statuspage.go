@@ -0,0 +1,130 @@
+package statuspage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// The files the status page is made of
+const (
+	JsonFilename string = "status.json"
+	HtmlFilename string = "index.html"
+)
+
+// The number of the node's minipools in each stage of their lifecycle
+type ValidatorCounts struct {
+	Total     int `json:"total"`
+	Pending   int `json:"pending"`
+	Staking   int `json:"staking"`
+	Exited    int `json:"exited"`
+	Dissolved int `json:"dissolved"`
+}
+
+// The node's most recent rewards claim
+type LastClaim struct {
+	Interval uint64    `json:"interval"`
+	Time     time.Time `json:"time"`
+}
+
+// The public stats shown on the status page; nothing here can be used to access or control the node
+type Status struct {
+	Network              string          `json:"network"`
+	NodeAddress          *common.Address `json:"nodeAddress,omitempty"`
+	GeneratedTime        time.Time       `json:"generatedTime"`
+	Slot                 uint64          `json:"slot"`
+	Validators           ValidatorCounts `json:"validators"`
+	SmoothingPoolOptedIn bool            `json:"smoothingPoolOptedIn"`
+
+	// The fraction of attestations the node's minipools made in the latest finished rewards interval, if its
+	// performance file is available
+	Effectiveness         *float64 `json:"effectiveness,omitempty"`
+	EffectivenessInterval uint64   `json:"effectivenessInterval,omitempty"`
+
+	// When the node daemon started; the page is only regenerated while it's running
+	RunningSince time.Time `json:"runningSince"`
+
+	LastClaim *LastClaim `json:"lastClaim,omitempty"`
+}
+
+var pageTemplate = template.Must(template.New("status").Funcs(template.FuncMap{
+	"formatTime": func(t time.Time) string {
+		return t.UTC().Format("2006-01-02 15:04 MST")
+	},
+	"percent": func(value *float64) string {
+		return fmt.Sprintf("%.2f%%", *value*100)
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Rocket Pool Node Status</title>
+<style>
+body { font-family: sans-serif; max-width: 40em; margin: 2em auto; padding: 0 1em; color: #222; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 0.4em; border-bottom: 1px solid #ddd; }
+th { width: 45%; font-weight: normal; color: #666; }
+footer { margin-top: 2em; font-size: 0.85em; color: #666; }
+</style>
+</head>
+<body>
+<h1>Rocket Pool Node Status</h1>
+<table>
+<tr><th>Network</th><td>{{.Network}}</td></tr>
+{{- if .NodeAddress}}
+<tr><th>Node</th><td><code>{{.NodeAddress.Hex}}</code></td></tr>
+{{- end}}
+<tr><th>Validators</th><td>{{.Validators.Total}}</td></tr>
+<tr><th>Staking</th><td>{{.Validators.Staking}}</td></tr>
+<tr><th>Pending</th><td>{{.Validators.Pending}}</td></tr>
+<tr><th>Exited</th><td>{{.Validators.Exited}}</td></tr>
+<tr><th>Dissolved</th><td>{{.Validators.Dissolved}}</td></tr>
+<tr><th>Smoothing pool</th><td>{{if .SmoothingPoolOptedIn}}Opted in{{else}}Opted out{{end}}</td></tr>
+<tr><th>Attestation effectiveness</th><td>{{if .Effectiveness}}{{percent .Effectiveness}} (interval {{.EffectivenessInterval}}){{else}}Not available{{end}}</td></tr>
+<tr><th>Running since</th><td>{{formatTime .RunningSince}}</td></tr>
+<tr><th>Last rewards claim</th><td>{{if .LastClaim}}Interval {{.LastClaim.Interval}}, {{formatTime .LastClaim.Time}}{{else}}None{{end}}</td></tr>
+</table>
+<footer>Generated at {{formatTime .GeneratedTime}} (slot {{.Slot}}). Also available as <a href="` + JsonFilename + `">JSON</a>.</footer>
+</body>
+</html>
+`))
+
+// Write the status page to a directory, returning the paths of its files
+func Write(dir string, status *Status) ([]string, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("error creating status page directory [%s]: %w", dir, err)
+	}
+
+	jsonBytes, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error serializing status page: %w", err)
+	}
+	var html bytes.Buffer
+	err = pageTemplate.Execute(&html, status)
+	if err != nil {
+		return nil, fmt.Errorf("error rendering status page: %w", err)
+	}
+
+	paths := []string{}
+	for filename, data := range map[string][]byte{
+		JsonFilename: jsonBytes,
+		HtmlFilename: html.Bytes(),
+	} {
+		path := filepath.Join(dir, filename)
+		err = files.WriteFileAtomic(path, data, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("error saving status page: %w", err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
@@ -0,0 +1,215 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// The version of the store file's layout
+const storeFileVersion uint64 = 1
+
+// Upgrades a bucket's entries that were saved with an older schema version to the current one
+type MigrationFunc func(fromVersion uint64, entries map[string]json.RawMessage) (map[string]json.RawMessage, error)
+
+// The persisted layout of the store
+type storeFile struct {
+	Version uint64                 `json:"version"`
+	Buckets map[string]*bucketFile `json:"buckets"`
+}
+
+// The persisted layout of a bucket
+type bucketFile struct {
+	SchemaVersion uint64                     `json:"schemaVersion"`
+	Entries       map[string]json.RawMessage `json:"entries"`
+}
+
+// A small embedded store that daemon modules persist their cursors and pending work in, so they can pick up where they
+// left off after a restart or crash.
+// Every change is written to disk immediately by replacing the file atomically, and the previous version is kept as a
+// backup that's used if the main file is ever found corrupted.
+type Store struct {
+	path string
+	data storeFile
+	lock sync.Mutex
+}
+
+// A module's namespace within the store
+type Bucket struct {
+	store *Store
+	name  string
+}
+
+// Open the store at the given path, creating it if it doesn't exist yet
+func Open(path string) (*Store, error) {
+	s := &Store{
+		path: path,
+		data: storeFile{
+			Version: storeFileVersion,
+			Buckets: map[string]*bucketFile{},
+		},
+	}
+
+	err := s.load(path)
+	if err == nil {
+		return s, nil
+	}
+
+	// Recover from the backup if the main file is damaged, or missing because a save was interrupted
+	backupErr := s.load(getBackupPath(path))
+	if errors.Is(err, os.ErrNotExist) {
+		if backupErr == nil || errors.Is(backupErr, os.ErrNotExist) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("error loading daemon store backup: %w", backupErr)
+	}
+	if backupErr != nil {
+		return nil, fmt.Errorf("error loading daemon store %s (%w), and its backup couldn't be loaded either: %s", path, err, backupErr.Error())
+	}
+	return s, nil
+}
+
+// Get a module's bucket, migrating its entries if they were saved with an older schema version.
+// Buckets saved with a newer schema version than the module knows about are rejected rather than risk misreading them.
+func (s *Store) Bucket(name string, schemaVersion uint64, migrate MigrationFunc) (*Bucket, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	bucket, exists := s.data.Buckets[name]
+	if !exists {
+		s.data.Buckets[name] = &bucketFile{
+			SchemaVersion: schemaVersion,
+			Entries:       map[string]json.RawMessage{},
+		}
+		return &Bucket{store: s, name: name}, nil
+	}
+
+	if bucket.SchemaVersion > schemaVersion {
+		return nil, fmt.Errorf("daemon store bucket [%s] has schema version %d, but this version of the Smartnode only supports up to %d", name, bucket.SchemaVersion, schemaVersion)
+	}
+	if bucket.SchemaVersion < schemaVersion {
+		if migrate == nil {
+			return nil, fmt.Errorf("daemon store bucket [%s] has schema version %d and no migration to version %d", name, bucket.SchemaVersion, schemaVersion)
+		}
+		entries, err := migrate(bucket.SchemaVersion, bucket.Entries)
+		if err != nil {
+			return nil, fmt.Errorf("error migrating daemon store bucket [%s] from schema version %d to %d: %w", name, bucket.SchemaVersion, schemaVersion, err)
+		}
+		s.data.Buckets[name] = &bucketFile{
+			SchemaVersion: schemaVersion,
+			Entries:       entries,
+		}
+		if err := s.save(); err != nil {
+			return nil, err
+		}
+	}
+	return &Bucket{store: s, name: name}, nil
+}
+
+// Get an entry, returning false if it doesn't exist
+func (b *Bucket) Get(key string, value interface{}) (bool, error) {
+	b.store.lock.Lock()
+	defer b.store.lock.Unlock()
+
+	data, exists := b.store.data.Buckets[b.name].Entries[key]
+	if !exists {
+		return false, nil
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		return false, fmt.Errorf("error deserializing daemon store entry [%s/%s]: %w", b.name, key, err)
+	}
+	return true, nil
+}
+
+// Set an entry and save it to disk
+func (b *Bucket) Put(key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("error serializing daemon store entry [%s/%s]: %w", b.name, key, err)
+	}
+
+	b.store.lock.Lock()
+	defer b.store.lock.Unlock()
+	b.store.data.Buckets[b.name].Entries[key] = data
+	return b.store.save()
+}
+
+// Remove an entry and save the change to disk
+func (b *Bucket) Delete(key string) error {
+	b.store.lock.Lock()
+	defer b.store.lock.Unlock()
+
+	entries := b.store.data.Buckets[b.name].Entries
+	if _, exists := entries[key]; !exists {
+		return nil
+	}
+	delete(entries, key)
+	return b.store.save()
+}
+
+// Get the keys of every entry, in sorted order
+func (b *Bucket) Keys() []string {
+	b.store.lock.Lock()
+	defer b.store.lock.Unlock()
+
+	keys := []string{}
+	for key := range b.store.data.Buckets[b.name].Entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Load the store from a file
+func (s *Store) load(path string) error {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	data := storeFile{}
+	if err := json.Unmarshal(bytes, &data); err != nil {
+		return fmt.Errorf("error deserializing daemon store: %w", err)
+	}
+	if data.Version > storeFileVersion {
+		return fmt.Errorf("daemon store has version %d, but this version of the Smartnode only supports up to %d", data.Version, storeFileVersion)
+	}
+	if data.Buckets == nil {
+		data.Buckets = map[string]*bucketFile{}
+	}
+	for _, bucket := range data.Buckets {
+		if bucket.Entries == nil {
+			bucket.Entries = map[string]json.RawMessage{}
+		}
+	}
+	s.data = data
+	return nil
+}
+
+// Save the store to disk, keeping the previous version as a backup
+func (s *Store) save() error {
+	bytes, err := json.Marshal(s.data)
+	if err != nil {
+		return fmt.Errorf("error serializing daemon store: %w", err)
+	}
+
+	// Keep the old version as the backup, then write the new one; if that fails, loading falls back to the backup
+	if _, err := os.Stat(s.path); err == nil {
+		if err := os.Rename(s.path, getBackupPath(s.path)); err != nil {
+			return fmt.Errorf("error backing up daemon store: %w", err)
+		}
+	}
+	if err := files.WriteFileAtomic(s.path, bytes, 0644); err != nil {
+		return fmt.Errorf("error saving daemon store: %w", err)
+	}
+	return nil
+}
+
+// Get the path of a store's backup file
+func getBackupPath(path string) string {
+	return path + ".bak"
+}
@@ -0,0 +1,135 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+type testCursor struct {
+	Block uint64 `json:"block"`
+}
+
+func TestPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bucket, err := s.Bucket("test", 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bucket.Put("cursor", testCursor{Block: 100}); err != nil {
+		t.Fatal(err)
+	}
+	if err := bucket.Put("cursor", testCursor{Block: 200}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reopen it and make sure the latest value is there
+	s, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bucket, err = s.Bucket("test", 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cursor := testCursor{}
+	exists, err := bucket.Get("cursor", &cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !exists || cursor.Block != 200 {
+		t.Fatalf("Expected block 200, got %d (exists = %t)", cursor.Block, exists)
+	}
+
+	// A corrupted file should fall back to the previous version
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bucket, err = s.Bucket("test", 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exists, err = bucket.Get("cursor", &cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !exists || cursor.Block != 100 {
+		t.Fatalf("Expected block 100 from the backup, got %d (exists = %t)", cursor.Block, exists)
+	}
+
+	// So should a missing one, which happens if a save is interrupted between swapping the files
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	s, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bucket, err = s.Bucket("test", 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exists, _ := bucket.Get("cursor", &cursor); !exists {
+		t.Fatalf("Expected the backup to be loaded when the store file is missing")
+	}
+}
+
+func TestMigration(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bucket, err := s.Bucket("test", 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bucket.Put("cursor", uint64(42)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Upgrading without a migration fails
+	if _, err := s.Bucket("test", 2, nil); err == nil {
+		t.Fatalf("Expected an error upgrading without a migration")
+	}
+
+	// Version 2 wraps the block in an object
+	migrate := func(fromVersion uint64, entries map[string]json.RawMessage) (map[string]json.RawMessage, error) {
+		var block uint64
+		if err := json.Unmarshal(entries["cursor"], &block); err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(testCursor{Block: block})
+		if err != nil {
+			return nil, err
+		}
+		return map[string]json.RawMessage{"cursor": data}, nil
+	}
+	bucket, err = s.Bucket("test", 2, migrate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cursor := testCursor{}
+	if _, err := bucket.Get("cursor", &cursor); err != nil {
+		t.Fatal(err)
+	}
+	if cursor.Block != 42 {
+		t.Fatalf("Expected block 42 after migrating, got %d", cursor.Block)
+	}
+
+	// Downgrading is rejected
+	if _, err := s.Bucket("test", 1, nil); err == nil {
+		t.Fatalf("Expected an error opening a bucket with an older schema version")
+	}
+}
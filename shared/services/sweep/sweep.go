@@ -0,0 +1,213 @@
+package sweep
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// Withdrawal sweep parameters from the consensus spec (Capella and later)
+const (
+	MaxWithdrawalsPerPayload        uint64 = 16
+	MaxValidatorsPerWithdrawalSweep uint64 = 16384
+
+	// The balance (in gwei) above which a validator with a full effective balance gets partial withdrawals
+	MaxEffectiveBalanceGwei uint64 = 32e9
+)
+
+// How far back to look when measuring how fast the sweep is moving
+const DefaultSampleSlots uint64 = 256
+
+// How many empty slots to skip when looking for a block to sample
+const maxEmptySlots uint64 = 32
+
+// The sweep's position and speed at a sampled slot
+type Progress struct {
+	// The slot of the sampled block
+	Slot uint64 `json:"slot"`
+
+	// The validator index the sweep will start from in the block after Slot
+	NextIndex uint64 `json:"nextIndex"`
+
+	// The number of validators on the Beacon Chain, where the sweep wraps back to 0
+	ValidatorCount uint64 `json:"validatorCount"`
+
+	// How many validators the sweep passes per slot, on average
+	ValidatorsPerSlot float64 `json:"validatorsPerSlot"`
+}
+
+// Measure the sweep's position at the latest block at or before headSlot, and its speed over the sampleSlots before it
+func Measure(bc beacon.Client, headSlot uint64, sampleSlots uint64) (Progress, error) {
+	head, headNext, err := getSweepPosition(bc, headSlot)
+	if err != nil {
+		return Progress{}, err
+	}
+	if head.Slot < sampleSlots {
+		return Progress{}, fmt.Errorf("the chain is too young to measure the withdrawal sweep (slot %d)", head.Slot)
+	}
+	sample, sampleNext, err := getSweepPosition(bc, head.Slot-sampleSlots)
+	if err != nil {
+		return Progress{}, err
+	}
+	if sample.Slot >= head.Slot {
+		return Progress{}, fmt.Errorf("couldn't find two blocks with withdrawals to measure the sweep with")
+	}
+
+	count, err := getValidatorCount(bc, headNext)
+	if err != nil {
+		return Progress{}, err
+	}
+	progress := Progress{
+		Slot:           head.Slot,
+		NextIndex:      headNext % count,
+		ValidatorCount: count,
+	}
+	advance := (headNext%count + count - sampleNext%count) % count
+	progress.ValidatorsPerSlot = float64(advance) / float64(head.Slot-sample.Slot)
+	if progress.ValidatorsPerSlot == 0 {
+		return Progress{}, fmt.Errorf("the withdrawal sweep didn't move between slots %d and %d", sample.Slot, head.Slot)
+	}
+	return progress, nil
+}
+
+// Get the number of slots a full pass of the sweep takes
+func (p Progress) GetCycleSlots() uint64 {
+	return uint64(float64(p.ValidatorCount) / p.ValidatorsPerSlot)
+}
+
+// Estimate the slot at which the sweep next reaches a validator, after the given slot
+func (p Progress) GetNextSweepSlot(validatorIndex uint64, afterSlot uint64) uint64 {
+	// Work out where the sweep is at the given slot, assuming it keeps moving at the same speed
+	position := p.NextIndex
+	if afterSlot > p.Slot {
+		moved := uint64(float64(afterSlot-p.Slot) * p.ValidatorsPerSlot)
+		position = (position + moved) % p.ValidatorCount
+	} else {
+		afterSlot = p.Slot
+	}
+	distance := (validatorIndex%p.ValidatorCount + p.ValidatorCount - position) % p.ValidatorCount
+	return afterSlot + uint64(float64(distance)/p.ValidatorsPerSlot) + 1
+}
+
+// Project how much (in gwei) a validator will have skimmed at its next sweep, assuming its excess balance keeps growing
+// at the rate it has since its previous sweep. Validators without a full effective balance don't get partial
+// withdrawals, so nothing is projected for them.
+func (p Progress) ProjectSkim(balanceGwei uint64, effectiveBalanceGwei uint64, slotsUntilSweep uint64) uint64 {
+	if effectiveBalanceGwei < MaxEffectiveBalanceGwei || balanceGwei <= MaxEffectiveBalanceGwei {
+		return 0
+	}
+	excess := balanceGwei - MaxEffectiveBalanceGwei
+
+	// If the previous sweep was very recent, the growth rate is too noisy to extrapolate
+	cycleSlots := p.GetCycleSlots()
+	if slotsUntilSweep >= cycleSlots {
+		return excess
+	}
+	slotsSinceSweep := cycleSlots - slotsUntilSweep
+	if slotsSinceSweep < cycleSlots/10 {
+		return excess
+	}
+	return uint64(float64(excess) * float64(cycleSlots) / float64(slotsSinceSweep))
+}
+
+// A validator's next visit from the sweep
+type Forecast struct {
+	// The estimated slot of the visit
+	Slot uint64
+
+	// True if the validator's whole balance will be withdrawn because it has exited
+	Full bool
+
+	// The amount (in gwei) the sweep is expected to withdraw
+	AmountGwei uint64
+}
+
+// Forecast a validator's next visit from the sweep, after the given slot. Validators without 0x01 withdrawal credentials
+// are passed over, so nothing is withdrawn from them.
+func (p Progress) ForecastValidator(validator beacon.ValidatorStatus, afterSlot uint64) (Forecast, error) {
+	index, err := strconv.ParseUint(validator.Index, 10, 64)
+	if err != nil {
+		return Forecast{}, fmt.Errorf("error parsing validator index [%s]: %w", validator.Index, err)
+	}
+	forecast := Forecast{
+		Slot: p.GetNextSweepSlot(index, afterSlot),
+	}
+	if validator.WithdrawalCredentials[0] != 0x01 {
+		return forecast, nil
+	}
+	if validator.Status == beacon.ValidatorState_WithdrawalPossible {
+		forecast.Full = true
+		forecast.AmountGwei = validator.Balance
+		return forecast, nil
+	}
+	if afterSlot < p.Slot {
+		afterSlot = p.Slot
+	}
+	forecast.AmountGwei = p.ProjectSkim(validator.Balance, validator.EffectiveBalance, forecast.Slot-afterSlot)
+	return forecast, nil
+}
+
+// Find the latest block with withdrawals at or before a slot, and the index the sweep will start from after it
+func getSweepPosition(bc beacon.Client, slot uint64) (beacon.BeaconBlock, uint64, error) {
+	for i := uint64(0); i <= maxEmptySlots && i <= slot; i++ {
+		block, exists, err := bc.GetBeaconBlock(strconv.FormatUint(slot-i, 10))
+		if err != nil {
+			return beacon.BeaconBlock{}, 0, fmt.Errorf("error getting Beacon block %d: %w", slot-i, err)
+		}
+		if !exists || len(block.Withdrawals) == 0 {
+			continue
+		}
+
+		last, err := strconv.ParseUint(block.Withdrawals[len(block.Withdrawals)-1].ValidatorIndex, 10, 64)
+		if err != nil {
+			return beacon.BeaconBlock{}, 0, fmt.Errorf("error parsing withdrawal validator index in block %d: %w", block.Slot, err)
+		}
+
+		// A full payload stops right after its last withdrawal; otherwise the sweep passed over its whole range
+		if uint64(len(block.Withdrawals)) == MaxWithdrawalsPerPayload {
+			return block, last + 1, nil
+		}
+		return block, last + MaxValidatorsPerWithdrawalSweep, nil
+	}
+	return beacon.BeaconBlock{}, 0, fmt.Errorf("couldn't find a block with withdrawals in the %d slots before slot %d", maxEmptySlots, slot)
+}
+
+// Find the number of validators on the Beacon Chain by searching for the highest index that exists
+func getValidatorCount(bc beacon.Client, knownIndex uint64) (uint64, error) {
+	exists := func(index uint64) (bool, error) {
+		status, err := bc.GetValidatorStatusByIndex(strconv.FormatUint(index, 10), nil)
+		if err != nil {
+			return false, fmt.Errorf("error getting status of validator %d: %w", index, err)
+		}
+		return status.Exists, nil
+	}
+
+	// Find an index past the end, then search between it and the last index known to exist
+	low := uint64(0)
+	high := knownIndex + 1
+	for {
+		found, err := exists(high)
+		if err != nil {
+			return 0, err
+		}
+		if !found {
+			break
+		}
+		low = high
+		high *= 2
+	}
+	for low+1 < high {
+		mid := low + (high-low)/2
+		found, err := exists(mid)
+		if err != nil {
+			return 0, err
+		}
+		if found {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return high, nil
+}
@@ -0,0 +1,50 @@
+package sweep
+
+import (
+	"testing"
+)
+
+// A sweep that passes 10 validators per slot over 1000 validators, so a full pass takes 100 slots
+var testProgress = Progress{
+	Slot:              1000,
+	NextIndex:         500,
+	ValidatorCount:    1000,
+	ValidatorsPerSlot: 10,
+}
+
+func TestGetNextSweepSlot(t *testing.T) {
+	// Ahead of the sweep
+	if slot := testProgress.GetNextSweepSlot(600, 1000); slot != 1011 {
+		t.Fatalf("expected validator 600 to be swept at slot 1011, got %d", slot)
+	}
+
+	// Behind the sweep, so it has to wrap around
+	if slot := testProgress.GetNextSweepSlot(400, 1000); slot != 1091 {
+		t.Fatalf("expected validator 400 to be swept at slot 1091, got %d", slot)
+	}
+
+	// Later slots extrapolate the sweep's position first
+	if slot := testProgress.GetNextSweepSlot(600, 1005); slot != 1011 {
+		t.Fatalf("expected validator 600 to be swept at slot 1011 when checked at slot 1005, got %d", slot)
+	}
+	if slot := testProgress.GetNextSweepSlot(600, 1020); slot != 1111 {
+		t.Fatalf("expected validator 600 to be swept at slot 1111 when checked at slot 1020, got %d", slot)
+	}
+}
+
+func TestProjectSkim(t *testing.T) {
+	// Validators without a full effective balance don't get partial withdrawals
+	if skim := testProgress.ProjectSkim(31.5e9, 31e9, 50); skim != 0 {
+		t.Fatalf("expected no skim for a validator below the max effective balance, got %d gwei", skim)
+	}
+
+	// Half way through the cycle, the excess should double by the next sweep
+	if skim := testProgress.ProjectSkim(32e9+5e6, 32e9, 50); skim != 10e6 {
+		t.Fatalf("expected a 10000000 gwei skim, got %d gwei", skim)
+	}
+
+	// Right after a sweep, only the current excess is projected
+	if skim := testProgress.ProjectSkim(32e9+5e6, 32e9, 95); skim != 5e6 {
+		t.Fatalf("expected a 5000000 gwei skim, got %d gwei", skim)
+	}
+}
@@ -0,0 +1,76 @@
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"time"
+
+	"github.com/pbnjay/memory"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Timeout for submitting a report to the aggregation endpoint
+const submitTimeout = 30 * time.Second
+
+// Create a report from the node's configuration, hardware, and client statuses
+func NewReport(cfg *config.RocketPoolConfig, ecStatus *api.ClientManagerStatus, bcStatus *api.ClientManagerStatus) api.TelemetryReport {
+
+	// Get the client selections; the specific client of an externally managed EC isn't known
+	ecMode := cfg.ExecutionClientMode.Value.(cfgtypes.Mode)
+	ecName := "unknown"
+	if ecMode == cfgtypes.Mode_Local {
+		ecName = string(cfg.ExecutionClient.Value.(cfgtypes.ExecutionClient))
+	}
+	cc, ccMode := cfg.GetSelectedConsensusClient()
+
+	return api.TelemetryReport{
+		SmartnodeVersion:         shared.RocketPoolVersion,
+		Network:                  string(cfg.Smartnode.Network.Value.(cfgtypes.Network)),
+		ExecutionClientMode:      string(ecMode),
+		ExecutionClient:          ecName,
+		ConsensusClientMode:      string(ccMode),
+		ConsensusClient:          string(cc),
+		MevBoostEnabled:          cfg.EnableMevBoost.Value.(bool),
+		CpuCores:                 runtime.NumCPU(),
+		MemoryGB:                 memory.TotalMemory() / 1024 / 1024 / 1024,
+		ExecutionSynced:          ecStatus.PrimaryClientStatus.IsSynced,
+		ExecutionSyncProgress:    ecStatus.PrimaryClientStatus.SyncProgress,
+		ExecutionFallbackEnabled: ecStatus.FallbackEnabled,
+		ConsensusSynced:          bcStatus.PrimaryClientStatus.IsSynced,
+		ConsensusSyncProgress:    bcStatus.PrimaryClientStatus.SyncProgress,
+		ConsensusFallbackEnabled: bcStatus.FallbackEnabled,
+		ReportDate:               time.Now().UTC().Format("2006-01-02"),
+	}
+
+}
+
+// Submit a report to the aggregation endpoint
+func Submit(url string, report api.TelemetryReport) error {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("error serializing telemetry report: %w", err)
+	}
+
+	client := http.Client{
+		Timeout: submitTimeout,
+	}
+	response, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error submitting telemetry report to %s: %w", url, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("telemetry endpoint %s returned status %d: %s", url, response.StatusCode, string(message))
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+package tracer
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The JSON-RPC error code for methods the client doesn't support
+const methodNotFoundCode int = -32601
+
+// How long to wait for a trace
+const traceTimeout time.Duration = 1 * time.Minute
+
+// Gets a human-readable name for a contract address, or a blank string if it isn't known
+type NameResolver func(address common.Address) string
+
+// A call frame produced by the callTracer
+type Frame struct {
+	Type         string          `json:"type"`
+	From         common.Address  `json:"from"`
+	To           *common.Address `json:"to,omitempty"`
+	Value        *hexutil.Big    `json:"value,omitempty"`
+	Gas          hexutil.Uint64  `json:"gas"`
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`
+	Input        hexutil.Bytes   `json:"input"`
+	Output       hexutil.Bytes   `json:"output,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	Calls        []*Frame        `json:"calls,omitempty"`
+}
+
+// A decoded trace of a failed call, saved alongside the watchtower's logs
+type Trace struct {
+	Label               string          `json:"label"`
+	TracedAt            time.Time       `json:"tracedAt"`
+	Block               string          `json:"block"`
+	From                common.Address  `json:"from"`
+	To                  *common.Address `json:"to,omitempty"`
+	FailingContract     *common.Address `json:"failingContract,omitempty"`
+	FailingContractName string          `json:"failingContractName,omitempty"`
+	FailingMethod       string          `json:"failingMethod,omitempty"`
+	RevertReason        string          `json:"revertReason,omitempty"`
+	Depth               int             `json:"depth"`
+	Root                *Frame          `json:"trace"`
+}
+
+// Re-simulates failed calls with debug_traceCall and reports where they reverted
+type Tracer struct {
+	folder      string
+	log         *log.ColorLogger
+	resolver    NameResolver
+	lock        sync.Mutex
+	unavailable bool
+}
+
+// Create a new tracer that saves its traces to the provided folder
+func NewTracer(folder string, logger *log.ColorLogger, resolver NameResolver) *Tracer {
+	return &Tracer{
+		folder:   folder,
+		log:      logger,
+		resolver: resolver,
+	}
+}
+
+// Trace a failed call on the given client at the provided block (or the latest block if it's nil), then log and save the decoded trace.
+// Errors are logged rather than returned, since tracing is only a diagnostic for a failure that has already been reported.
+func (t *Tracer) TraceCall(client *rpc.Client, call ethereum.CallMsg, blockNumber *big.Int, label string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.unavailable {
+		return
+	}
+
+	block := "latest"
+	if blockNumber != nil {
+		block = hexutil.EncodeBig(blockNumber)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), traceTimeout)
+	defer cancel()
+	var root Frame
+	err := client.CallContext(ctx, &root, "debug_traceCall", toCallArg(call), block, map[string]string{"tracer": "callTracer"})
+	if err != nil {
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == methodNotFoundCode {
+			t.unavailable = true
+			t.log.Println("The Execution client doesn't support debug_traceCall, so failed duties won't be traced.")
+			return
+		}
+		t.log.Printlnf("Error tracing %s: %s", label, err.Error())
+		return
+	}
+
+	trace := t.decode(&root, call, block, label)
+	for _, line := range t.render(trace) {
+		t.log.Println(line)
+	}
+	path, err := trace.save(t.folder)
+	if err != nil {
+		t.log.Println(err.Error())
+		return
+	}
+	t.log.Printlnf("Saved the trace to %s.", path)
+}
+
+// Find where a traced call reverted
+func (t *Tracer) decode(root *Frame, call ethereum.CallMsg, block string, label string) *Trace {
+	trace := &Trace{
+		Label:    label,
+		TracedAt: time.Now(),
+		Block:    block,
+		From:     call.From,
+		To:       call.To,
+		Root:     root,
+	}
+
+	site, depth := findRevertSite(root, 0)
+	if site == nil {
+		return trace
+	}
+	trace.FailingContract = site.To
+	if site.To != nil {
+		trace.FailingContractName = t.getName(*site.To)
+	}
+	if len(site.Input) >= 4 {
+		trace.FailingMethod = hexutil.Encode(site.Input[:4])
+	}
+	trace.RevertReason = getRevertReason(site)
+	trace.Depth = depth
+	return trace
+}
+
+// Render a trace as log lines, showing the call tree and where it reverted
+func (t *Tracer) render(trace *Trace) []string {
+	lines := []string{fmt.Sprintf("=== Trace of %s (block %s) ===", trace.Label, trace.Block)}
+	var walk func(frame *Frame, depth int)
+	walk = func(frame *Frame, depth int) {
+		line := fmt.Sprintf("%s%s %s", strings.Repeat("  ", depth), frame.Type, t.describe(frame.To))
+		if len(frame.Input) >= 4 {
+			line += " " + hexutil.Encode(frame.Input[:4])
+		}
+		if frame.Error != "" {
+			line += fmt.Sprintf(" [%s]", frame.Error)
+		}
+		lines = append(lines, line)
+		for _, child := range frame.Calls {
+			walk(child, depth+1)
+		}
+	}
+	walk(trace.Root, 0)
+
+	if trace.FailingContract == nil {
+		lines = append(lines, "The trace didn't revert, so the failure may depend on state that has changed since.")
+	} else {
+		lines = append(lines, fmt.Sprintf("Reverted in %s, method %s, at call depth %d.", t.describe(trace.FailingContract), trace.FailingMethod, trace.Depth))
+		if trace.RevertReason != "" {
+			lines = append(lines, fmt.Sprintf("Revert reason: %s", trace.RevertReason))
+		}
+	}
+	return lines
+}
+
+// Describe a contract by its name and address
+func (t *Tracer) describe(address *common.Address) string {
+	if address == nil {
+		return "(contract creation)"
+	}
+	name := t.getName(*address)
+	if name == "" {
+		return address.Hex()
+	}
+	return fmt.Sprintf("%s (%s)", name, address.Hex())
+}
+
+// Get the name of a contract, if it's known
+func (t *Tracer) getName(address common.Address) string {
+	if t.resolver == nil {
+		return ""
+	}
+	return t.resolver(address)
+}
+
+// Save a trace to the provided folder
+func (trace *Trace) save(folder string) (string, error) {
+	err := os.MkdirAll(folder, 0755)
+	if err != nil {
+		return "", fmt.Errorf("error creating duty trace folder %s: %w", folder, err)
+	}
+
+	bytes, err := json.MarshalIndent(trace, "", "\t")
+	if err != nil {
+		return "", fmt.Errorf("error serializing trace of %s: %w", trace.Label, err)
+	}
+
+	name := strings.NewReplacer(" ", "-", "/", "-").Replace(trace.Label)
+	path := filepath.Join(folder, fmt.Sprintf("%d-%s.json", trace.TracedAt.Unix(), name))
+	err = os.WriteFile(path, bytes, 0644)
+	if err != nil {
+		return "", fmt.Errorf("error saving trace to %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// Find the frame a call reverted in by following the last failed subcall down from the root.
+// Returns nil if the call didn't fail.
+func findRevertSite(frame *Frame, depth int) (*Frame, int) {
+	if frame.Error == "" {
+		return nil, 0
+	}
+	for i := len(frame.Calls) - 1; i >= 0; i-- {
+		if frame.Calls[i].Error != "" {
+			return findRevertSite(frame.Calls[i], depth+1)
+		}
+	}
+	return frame, depth
+}
+
+// Get the reason a frame reverted, decoding the revert data if the client didn't
+func getRevertReason(frame *Frame) string {
+	if frame.RevertReason != "" {
+		return frame.RevertReason
+	}
+	if len(frame.Output) == 0 {
+		return frame.Error
+	}
+	reason, err := abi.UnpackRevert(frame.Output)
+	if err == nil {
+		return reason
+	}
+	if len(frame.Output) >= 4 {
+		return fmt.Sprintf("custom error 0x%s", hex.EncodeToString(frame.Output[:4]))
+	}
+	return frame.Error
+}
+
+// Convert a call to the argument debug_traceCall expects. The data is sent under both names, since clients differ in which one they read.
+func toCallArg(call ethereum.CallMsg) map[string]interface{} {
+	arg := map[string]interface{}{
+		"from":  call.From,
+		"data":  hexutil.Bytes(call.Data),
+		"input": hexutil.Bytes(call.Data),
+	}
+	if call.To != nil {
+		arg["to"] = call.To
+	}
+	if call.Value != nil {
+		arg["value"] = (*hexutil.Big)(call.Value)
+	}
+	if call.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(call.Gas)
+	}
+	return arg
+}
@@ -0,0 +1,81 @@
+package tuning
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+
+	"gopkg.in/yaml.v2"
+)
+
+// The resource limits for the managed containers. This is laid out as a docker compose file, so it can be added to
+// the generated compose files as they're deployed.
+type Limits struct {
+	Services map[string]ServiceLimits `yaml:"services"`
+}
+
+// The resource limits for a single container
+type ServiceLimits struct {
+	MemLimit string `yaml:"mem_limit,omitempty"`
+	Cpus     string `yaml:"cpus,omitempty"`
+}
+
+// Load the limits from the provided path. Returns empty limits if the file doesn't exist.
+func LoadLimits(path string) (*Limits, error) {
+	limits := &Limits{
+		Services: map[string]ServiceLimits{},
+	}
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return limits, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading resource limits %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(bytes, limits); err != nil {
+		return nil, fmt.Errorf("error parsing resource limits %s: %w", path, err)
+	}
+	if limits.Services == nil {
+		limits.Services = map[string]ServiceLimits{}
+	}
+	return limits, nil
+}
+
+// Save the limits to the provided path
+func (l *Limits) Save(path string) error {
+	bytes, err := yaml.Marshal(l)
+	if err != nil {
+		return fmt.Errorf("error serializing resource limits: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return fmt.Errorf("error saving resource limits to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Set the limits for a container from a recommendation
+func (l *Limits) Apply(recommendation Recommendation) {
+	limits := ServiceLimits{
+		MemLimit: fmt.Sprintf("%dm", recommendation.MemLimit>>20),
+	}
+	if recommendation.Cpus > 0 {
+		limits.Cpus = strconv.FormatFloat(recommendation.Cpus, 'f', -1, 64)
+	}
+	l.Services[recommendation.Service] = limits
+}
+
+// Get a copy of the limits with only the provided containers, since compose rejects limits for services it isn't
+// running
+func (l *Limits) Filter(services []string) *Limits {
+	filtered := &Limits{
+		Services: map[string]ServiceLimits{},
+	}
+	for _, service := range services {
+		if limits, exists := l.Services[service]; exists {
+			filtered.Services[service] = limits
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,325 @@
+package tuning
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Memory left over for the operating system and anything running outside of the Smart Node's containers
+const systemReserve uint64 = 2 << 30
+
+// The headroom a container's memory limit gets above its observed peak, so normal spikes don't get it killed
+const limitHeadroom float64 = 1.5
+
+// The smallest memory limit recommended for any container
+const minMemoryLimit uint64 = 256 << 20
+
+// The fraction a cache size must change by before it's worth recommending, since applying it restarts the client
+const minCacheChange float64 = 0.25
+
+// The known resource needs of a client, and the config parameter that controls its cache if it has one
+type ClientProfile struct {
+	// The memory (in bytes) the client needs to run reliably, including its default cache
+	MinMemory uint64
+
+	// The number of cores the client needs to keep up with the chain; it's never limited below this
+	MinCpus float64
+
+	// The ID of the client's cache or heap size parameter, blank if it doesn't have one
+	CacheParam string
+
+	// The bounds (in MB) of useful cache sizes; smaller is unstable and larger has little benefit
+	MinCache uint64
+	MaxCache uint64
+}
+
+// The profiles of the clients the Smart Node can run, keyed by client name
+var profiles = map[string]ClientProfile{
+	"geth":       {MinMemory: 8 << 30, MinCpus: 2},
+	"nethermind": {MinMemory: 8 << 30, MinCpus: 2, CacheParam: "cacheSize", MinCache: 512, MaxCache: 4096},
+	"besu":       {MinMemory: 8 << 30, MinCpus: 2, CacheParam: "jvmHeapSize", MinCache: 4096, MaxCache: 8192},
+	"reth":       {MinMemory: 6 << 30, MinCpus: 2, CacheParam: "cache", MinCache: 256, MaxCache: 16384},
+	"lighthouse": {MinMemory: 4 << 30, MinCpus: 1},
+	"lodestar":   {MinMemory: 4 << 30, MinCpus: 1},
+	"nimbus":     {MinMemory: 2 << 30, MinCpus: 1},
+	"prysm":      {MinMemory: 4 << 30, MinCpus: 1},
+	"teku":       {MinMemory: 4 << 30, MinCpus: 1, CacheParam: "jvmHeapSize", MinCache: 2048, MaxCache: 6144},
+}
+
+// Get the profile of a client by name
+func GetProfile(client string) (ClientProfile, bool) {
+	profile, exists := profiles[strings.ToLower(client)]
+	return profile, exists
+}
+
+// A single reading of a container's resource usage
+type Sample struct {
+	Service string
+
+	// Memory in use, in bytes
+	Memory uint64
+
+	// CPU use as a percentage of one core, so a container using two full cores is at 200
+	Cpu float64
+}
+
+// A container's resource usage across all of its samples
+type Usage struct {
+	Service    string
+	Samples    int
+	PeakMemory uint64
+	AverageCpu float64
+	PeakCpu    float64
+}
+
+// The machine the containers run on
+type Machine struct {
+	// Total memory, in bytes
+	TotalMemory uint64
+	Cpus        int
+}
+
+// A managed container and the client it runs, if any
+type Service struct {
+	Name string
+
+	// The name of the client, blank for the Smart Node's own containers
+	Client string
+
+	// The current value (in MB) of the client's cache parameter, 0 if it's automatic
+	Cache uint64
+}
+
+// The recommended resources for a single container
+type Recommendation struct {
+	Service string
+	Client  string
+	Usage   Usage
+
+	// The recommended memory limit in bytes, and CPU limit in cores; a CPU limit of 0 means none
+	MemLimit uint64
+	Cpus     float64
+
+	// The client's cache parameter and its recommended value (in MB), if it should change
+	CacheParam       string
+	CurrentCache     uint64
+	RecommendedCache uint64
+
+	// Explanations for the recommendation
+	Notes []string
+}
+
+// Check if the recommendation changes the client's cache
+func (r Recommendation) ChangesCache() bool {
+	return r.CacheParam != "" && r.RecommendedCache != r.CurrentCache
+}
+
+// Parse the output of `docker stats --no-stream --format "{{.Name}}\t{{.MemUsage}}\t{{.CPUPerc}}"`, keeping only the
+// project's containers. Container names are converted to their compose service names.
+func ParseStats(output string, projectName string) ([]Sample, error) {
+	samples := []Sample{}
+	prefix := projectName + "_"
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("unexpected docker stats line [%s]", line)
+		}
+		if !strings.HasPrefix(fields[0], prefix) {
+			continue
+		}
+
+		usage, _, _ := strings.Cut(fields[1], "/")
+		memory, err := parseMemory(usage)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing memory usage of %s: %w", fields[0], err)
+		}
+		cpu, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(fields[2]), "%"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing CPU usage of %s: %w", fields[0], err)
+		}
+		samples = append(samples, Sample{
+			Service: strings.TrimPrefix(fields[0], prefix),
+			Memory:  memory,
+			Cpu:     cpu,
+		})
+	}
+	return samples, nil
+}
+
+// Combine samples into the usage of each container, sorted by service name
+func Summarize(samples []Sample) []Usage {
+	usageByService := map[string]*Usage{}
+	totalCpu := map[string]float64{}
+	for _, sample := range samples {
+		usage, exists := usageByService[sample.Service]
+		if !exists {
+			usage = &Usage{
+				Service: sample.Service,
+			}
+			usageByService[sample.Service] = usage
+		}
+		usage.Samples++
+		if sample.Memory > usage.PeakMemory {
+			usage.PeakMemory = sample.Memory
+		}
+		if sample.Cpu > usage.PeakCpu {
+			usage.PeakCpu = sample.Cpu
+		}
+		totalCpu[sample.Service] += sample.Cpu
+	}
+
+	usages := make([]Usage, 0, len(usageByService))
+	for service, usage := range usageByService {
+		usage.AverageCpu = totalCpu[service] / float64(usage.Samples)
+		usages = append(usages, *usage)
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].Service < usages[j].Service
+	})
+	return usages
+}
+
+// Recommend memory and CPU limits for each sampled container, and cache sizes for the clients that have them.
+// Memory the containers don't use is shared out between the client caches, keeping half of it free as headroom;
+// if the containers already use more than the machine has, the caches are shrunk instead.
+func Recommend(machine Machine, services []Service, usages []Usage) []Recommendation {
+	usageByService := map[string]Usage{}
+	var totalPeak uint64
+	for _, usage := range usages {
+		usageByService[usage.Service] = usage
+		totalPeak += usage.PeakMemory
+	}
+
+	// Find the memory that's free for caches
+	tunable := 0
+	for _, service := range services {
+		profile, exists := GetProfile(service.Client)
+		if _, sampled := usageByService[service.Name]; exists && sampled && profile.CacheParam != "" {
+			tunable++
+		}
+	}
+	freeMemory := int64(machine.TotalMemory) - int64(systemReserve) - int64(totalPeak)
+	var cacheShareMb int64
+	if tunable > 0 {
+		cacheShareMb = freeMemory / 2 / int64(tunable) >> 20
+	}
+
+	recommendations := []Recommendation{}
+	for _, service := range services {
+		usage, exists := usageByService[service.Name]
+		if !exists {
+			continue
+		}
+		profile, hasProfile := GetProfile(service.Client)
+		recommendation := Recommendation{
+			Service:      service.Name,
+			Client:       service.Client,
+			Usage:        usage,
+			CurrentCache: service.Cache,
+			Notes:        []string{},
+		}
+
+		// Resize the cache if there's enough memory to make a difference
+		peakMemory := usage.PeakMemory
+		if hasProfile && profile.CacheParam != "" {
+			recommendation.CacheParam = profile.CacheParam
+			recommendation.RecommendedCache = recommendCache(profile, service.Cache, cacheShareMb)
+			if recommendation.ChangesCache() {
+				delta := int64(recommendation.RecommendedCache) - int64(getEffectiveCache(profile, service.Cache))
+				peakMemory = uint64(max(int64(peakMemory)+delta<<20, 0))
+				if delta > 0 {
+					recommendation.Notes = append(recommendation.Notes, fmt.Sprintf("%d MB of memory is unused, so a larger cache will reduce disk reads", freeMemory>>20))
+				} else {
+					recommendation.Notes = append(recommendation.Notes, fmt.Sprintf("the containers use %d MB more memory than is safe, so a smaller cache will avoid running out", -freeMemory>>20))
+				}
+			}
+		}
+
+		// Set the memory limit above the peak, but never below what the client needs
+		memLimit := uint64(float64(peakMemory) * limitHeadroom)
+		floor := minMemoryLimit
+		if hasProfile {
+			floor = profile.MinMemory
+		}
+		if memLimit < floor {
+			memLimit = floor
+		}
+		if machine.TotalMemory > 0 && memLimit > machine.TotalMemory {
+			memLimit = machine.TotalMemory
+			recommendation.Notes = append(recommendation.Notes, "its peak usage is close to the machine's total memory, so it isn't limited below it")
+		}
+		recommendation.MemLimit = roundUp(memLimit, 64<<20)
+
+		// Set the CPU limit above the peak in half cores, leaving it unlimited if it would use the whole machine
+		cpus := math.Ceil(usage.PeakCpu/100*limitHeadroom*2) / 2
+		cpus = math.Max(cpus, 0.5)
+		if hasProfile {
+			cpus = math.Max(cpus, profile.MinCpus)
+		}
+		if machine.Cpus > 0 && cpus < float64(machine.Cpus) {
+			recommendation.Cpus = cpus
+		}
+
+		recommendations = append(recommendations, recommendation)
+	}
+	return recommendations
+}
+
+// Get the cache size (in MB) for a client given its share of the free memory
+func recommendCache(profile ClientProfile, current uint64, shareMb int64) uint64 {
+	effective := getEffectiveCache(profile, current)
+
+	// Leave automatic sizing alone unless the machine is short on memory
+	if current == 0 && shareMb >= 0 {
+		return current
+	}
+	target := max(int64(effective)+shareMb, int64(profile.MinCache))
+	target = min(target, int64(profile.MaxCache))
+	if math.Abs(float64(target)-float64(effective)) < float64(effective)*minCacheChange {
+		return current
+	}
+	return uint64(target)
+}
+
+// Get the cache size (in MB) a client is running with, assuming the smallest useful size when it's automatic
+func getEffectiveCache(profile ClientProfile, current uint64) uint64 {
+	if current == 0 {
+		return profile.MinCache
+	}
+	return current
+}
+
+// Round a value up to a multiple of the step
+func roundUp(value uint64, step uint64) uint64 {
+	return (value + step - 1) / step * step
+}
+
+// Parse a memory amount as shown by docker stats, such as 1.5GiB or 512MB
+func parseMemory(value string) (uint64, error) {
+	value = strings.TrimSpace(value)
+	units := []struct {
+		suffix     string
+		multiplier float64
+	}{
+		{"TiB", 1 << 40}, {"GiB", 1 << 30}, {"MiB", 1 << 20}, {"KiB", 1 << 10},
+		{"TB", 1e12}, {"GB", 1e9}, {"MB", 1e6}, {"kB", 1e3}, {"B", 1},
+	}
+	for _, unit := range units {
+		if !strings.HasSuffix(value, unit.suffix) {
+			continue
+		}
+		amount, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(value, unit.suffix)), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid memory amount [%s]: %w", value, err)
+		}
+		return uint64(amount * unit.multiplier), nil
+	}
+	return 0, fmt.Errorf("invalid memory amount [%s]", value)
+}
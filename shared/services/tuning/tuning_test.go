@@ -0,0 +1,66 @@
+package tuning
+
+import (
+	"testing"
+)
+
+func TestRecommend(t *testing.T) {
+	output := "rocketpool_eth1\t6GiB / 31.2GiB\t150.5%\n" +
+		"rocketpool_node\t200MiB / 31.2GiB\t1.00%\n" +
+		"other_container\t1GiB / 31.2GiB\t5%\n" +
+		"rocketpool_eth1\t5.5GiB / 31.2GiB\t50%\n"
+	samples, err := ParseStats(output, "rocketpool")
+	if err != nil {
+		t.Fatalf("unexpected error parsing stats: %s", err)
+	}
+	if len(samples) != 3 {
+		t.Fatalf("expected 3 samples from the project, got %d", len(samples))
+	}
+
+	usages := Summarize(samples)
+	if len(usages) != 2 || usages[0].Service != "eth1" || usages[0].PeakMemory != 6<<30 || usages[0].AverageCpu != 100.25 {
+		t.Fatalf("unexpected usage %+v", usages)
+	}
+
+	// 32 GiB leaves 24 GiB free after the reserve and peaks, so Nethermind's cache grows to its max
+	machine := Machine{
+		TotalMemory: 32 << 30,
+		Cpus:        8,
+	}
+	services := []Service{
+		{Name: "eth1", Client: "nethermind", Cache: 1024},
+		{Name: "node"},
+		{Name: "eth2", Client: "lighthouse"},
+	}
+	recommendations := Recommend(machine, services, usages)
+	if len(recommendations) != 2 {
+		t.Fatalf("expected recommendations for the 2 sampled containers, got %d", len(recommendations))
+	}
+	eth1 := recommendations[0]
+	if !eth1.ChangesCache() || eth1.RecommendedCache != 4096 {
+		t.Fatalf("expected the cache to grow to 4096 MB, got %d", eth1.RecommendedCache)
+	}
+	if eth1.MemLimit != 13824<<20 || eth1.Cpus != 2.5 {
+		t.Fatalf("expected a 13824 MB limit with 2.5 cores, got %d MB with %f cores", eth1.MemLimit>>20, eth1.Cpus)
+	}
+	node := recommendations[1]
+	if node.ChangesCache() || node.MemLimit != 320<<20 || node.Cpus != 0.5 {
+		t.Fatalf("unexpected node recommendation %+v", node)
+	}
+
+	// On a small machine, the cache shrinks instead
+	machine.TotalMemory = 6 << 30
+	recommendations = Recommend(machine, services, usages)
+	if recommendations[0].RecommendedCache != 512 {
+		t.Fatalf("expected the cache to shrink to 512 MB, got %d", recommendations[0].RecommendedCache)
+	}
+
+	limits := &Limits{Services: map[string]ServiceLimits{}}
+	limits.Apply(recommendations[1])
+	if limits.Services["node"].MemLimit != "320m" || limits.Services["node"].Cpus != "0.5" {
+		t.Fatalf("unexpected limits %+v", limits.Services["node"])
+	}
+	if len(limits.Filter([]string{"eth1"}).Services) != 0 {
+		t.Fatal("expected filtering to drop the node's limits")
+	}
+}
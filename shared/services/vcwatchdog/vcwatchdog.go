@@ -0,0 +1,245 @@
+package vcwatchdog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// How long to wait for the keymanager API to respond
+const keymanagerTimeout = 10 * time.Second
+
+// The number of epochs that must pass between balance samples before they're compared, so each validator has had at
+// least one full epoch of duties
+const dutySampleEpochs uint64 = 2
+
+// A balance (in gwei) above which a validator is due a partial withdrawal, so a drop from above it to below it is the
+// sweep rather than a missed duty
+const sweepBalanceGwei uint64 = 32e9
+
+// The result of a validator client check
+type Status struct {
+	CheckedAt time.Time `json:"checkedAt"`
+	Healthy   bool      `json:"healthy"`
+
+	// The problems found by the check, if any
+	Problems []string `json:"problems"`
+
+	// The number of checks in a row that have found problems
+	ConsecutiveFailures uint64 `json:"consecutiveFailures"`
+
+	// Whether the keymanager API was checked and responded, and the keys it has loaded
+	KeymanagerChecked   bool                    `json:"keymanagerChecked"`
+	KeymanagerReachable bool                    `json:"keymanagerReachable"`
+	ExpectedKeys        int                     `json:"expectedKeys"`
+	LoadedKeys          int                     `json:"loadedKeys"`
+	MissingKeys         []types.ValidatorPubkey `json:"missingKeys"`
+
+	// Whether the validators' balances have been compared across epochs yet, and how many of them grew
+	DutiesChecked       bool `json:"dutiesChecked"`
+	ValidatorsChecked   int  `json:"validatorsChecked"`
+	ValidatorsPerformed int  `json:"validatorsPerformed"`
+
+	// The last time the watchdog restarted the validator client
+	LastRestart time.Time `json:"lastRestart"`
+}
+
+// Checks that the validator client is running, has the node's keys loaded, and is performing their duties.
+// Duties are judged by the validators' balances: if every one of them shrinks over an epoch (apart from withdrawal
+// sweeps), the validator client isn't attesting, usually because it has lost its connection to the Beacon Node.
+type Watchdog struct {
+	keymanagerUrl       string
+	tokenPath           string
+	sampleSlot          uint64
+	sampleBalances      map[types.ValidatorPubkey]uint64
+	lastDuties          Status
+	consecutiveFailures uint64
+	lastRestart         time.Time
+}
+
+// Create a watchdog. A blank keymanager URL skips the key check.
+func NewWatchdog(keymanagerUrl string, tokenPath string) *Watchdog {
+	return &Watchdog{
+		keymanagerUrl: strings.TrimSuffix(keymanagerUrl, "/"),
+		tokenPath:     tokenPath,
+	}
+}
+
+// Check the validator client against the node's active validators, as of the provided slot
+func (w *Watchdog) Check(validators map[types.ValidatorPubkey]beacon.ValidatorStatus, slot uint64, slotsPerEpoch uint64) Status {
+	status := Status{
+		CheckedAt:    time.Now(),
+		Problems:     []string{},
+		ExpectedKeys: len(validators),
+		MissingKeys:  []types.ValidatorPubkey{},
+		LastRestart:  w.lastRestart,
+	}
+
+	// Check the keys loaded in the validator client
+	if w.keymanagerUrl != "" {
+		status.KeymanagerChecked = true
+		loaded, err := w.getLoadedKeys()
+		if err != nil {
+			status.Problems = append(status.Problems, fmt.Sprintf("the keymanager API didn't respond: %s", err.Error()))
+		} else {
+			status.KeymanagerReachable = true
+			status.LoadedKeys = len(loaded)
+			for pubkey := range validators {
+				if !loaded[pubkey] {
+					status.MissingKeys = append(status.MissingKeys, pubkey)
+				}
+			}
+			if len(status.MissingKeys) > 0 {
+				status.Problems = append(status.Problems, fmt.Sprintf("%d of the node's %d active validator key(s) aren't loaded", len(status.MissingKeys), len(validators)))
+			}
+		}
+	}
+
+	// Compare the validators' balances with the previous sample once enough epochs have passed
+	w.checkDuties(validators, slot, slotsPerEpoch)
+	status.DutiesChecked = w.lastDuties.DutiesChecked
+	status.ValidatorsChecked = w.lastDuties.ValidatorsChecked
+	status.ValidatorsPerformed = w.lastDuties.ValidatorsPerformed
+	if status.DutiesChecked && status.ValidatorsChecked > 0 && status.ValidatorsPerformed == 0 {
+		status.Problems = append(status.Problems, fmt.Sprintf("none of the node's %d active validator(s) earned rewards over the last %d epochs", status.ValidatorsChecked, dutySampleEpochs))
+	}
+
+	status.Healthy = len(status.Problems) == 0
+	if status.Healthy {
+		w.consecutiveFailures = 0
+	} else {
+		w.consecutiveFailures++
+	}
+	status.ConsecutiveFailures = w.consecutiveFailures
+	return status
+}
+
+// Record that the validator client was restarted
+func (w *Watchdog) RecordRestart() {
+	w.lastRestart = time.Now()
+}
+
+// Get the last time the validator client was restarted
+func (w *Watchdog) GetLastRestart() time.Time {
+	return w.lastRestart
+}
+
+// Compare the validators' balances with the previous sample, replacing it if it's old enough
+func (w *Watchdog) checkDuties(validators map[types.ValidatorPubkey]beacon.ValidatorStatus, slot uint64, slotsPerEpoch uint64) {
+	if w.sampleBalances != nil && slot < w.sampleSlot+dutySampleEpochs*slotsPerEpoch {
+		return
+	}
+
+	balances := make(map[types.ValidatorPubkey]uint64, len(validators))
+	for pubkey, validator := range validators {
+		balances[pubkey] = validator.Balance
+	}
+	if w.sampleBalances != nil {
+		duties := Status{
+			DutiesChecked: true,
+		}
+		for pubkey, balance := range balances {
+			previous, exists := w.sampleBalances[pubkey]
+			if !exists {
+				continue
+			}
+
+			// Skip validators that were swept in between, since their drop isn't a penalty
+			if previous > sweepBalanceGwei && balance <= sweepBalanceGwei {
+				continue
+			}
+			duties.ValidatorsChecked++
+			if balance > previous {
+				duties.ValidatorsPerformed++
+			}
+		}
+		w.lastDuties = duties
+	}
+	w.sampleSlot = slot
+	w.sampleBalances = balances
+}
+
+// Get the keys loaded in the validator client from its keymanager API
+func (w *Watchdog) getLoadedKeys() (map[types.ValidatorPubkey]bool, error) {
+	request, err := http.NewRequest(http.MethodGet, w.keymanagerUrl+"/eth/v1/keystores", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating keymanager request: %w", err)
+	}
+	if w.tokenPath != "" {
+		token, err := os.ReadFile(w.tokenPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading keymanager token from %s: %w", w.tokenPath, err)
+		}
+		request.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	client := http.Client{
+		Timeout: keymanagerTimeout,
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("it returned status %d", response.StatusCode)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading keymanager response: %w", err)
+	}
+	var keystores struct {
+		Data []struct {
+			ValidatingPubkey string `json:"validating_pubkey"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &keystores); err != nil {
+		return nil, fmt.Errorf("error deserializing keymanager response: %w", err)
+	}
+	loaded := make(map[types.ValidatorPubkey]bool, len(keystores.Data))
+	for _, keystore := range keystores.Data {
+		pubkey, err := types.HexToValidatorPubkey(keystore.ValidatingPubkey)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing loaded key [%s]: %w", keystore.ValidatingPubkey, err)
+		}
+		loaded[pubkey] = true
+	}
+	return loaded, nil
+}
+
+// Save a check's result so it can be shown by `rocketpool service status`
+func (s Status) Save(path string) error {
+	bytes, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("error serializing validator client status: %w", err)
+	}
+	if err := os.WriteFile(path, bytes, 0644); err != nil {
+		return fmt.Errorf("error writing validator client status to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Load the latest check's result; returns nil if the watchdog hasn't run yet
+func LoadStatus(path string) (*Status, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading validator client status from %s: %w", path, err)
+	}
+	var status Status
+	if err := json.Unmarshal(bytes, &status); err != nil {
+		return nil, fmt.Errorf("error deserializing validator client status from %s: %w", path, err)
+	}
+	return &status, nil
+}
@@ -0,0 +1,56 @@
+package vcwatchdog
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+func TestWatchdog(t *testing.T) {
+	loaded := types.ValidatorPubkey{0x01}
+	missing := types.ValidatorPubkey{0x02}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"data":[{"validating_pubkey":"%s"}]}`, loaded.Hex())
+	}))
+	defer server.Close()
+
+	watchdog := NewWatchdog(server.URL, "")
+	validators := map[types.ValidatorPubkey]beacon.ValidatorStatus{
+		loaded:  {Balance: 32.01e9},
+		missing: {Balance: 32.01e9},
+	}
+
+	// The first check only samples balances, so just the missing key is a problem
+	status := watchdog.Check(validators, 100, 32)
+	if status.Healthy || len(status.MissingKeys) != 1 || status.MissingKeys[0] != missing {
+		t.Fatalf("expected the missing key to be reported, got %+v", status)
+	}
+	if status.DutiesChecked {
+		t.Fatal("expected duties to be unchecked after the first sample")
+	}
+
+	// Both validators shrink, except one is swept, which isn't counted
+	validators[loaded] = beacon.ValidatorStatus{Balance: 32.009e9}
+	validators[missing] = beacon.ValidatorStatus{Balance: 32e9}
+	status = watchdog.Check(validators, 100+2*32, 32)
+	if !status.DutiesChecked || status.ValidatorsChecked != 1 || status.ValidatorsPerformed != 0 {
+		t.Fatalf("expected 1 checked validator that didn't perform, got %+v", status)
+	}
+	if len(status.Problems) != 2 || status.ConsecutiveFailures != 2 {
+		t.Fatalf("expected 2 problems on the 2nd failure in a row, got %+v", status)
+	}
+
+	// Balances grow and the key is loaded again
+	loaded = missing
+	validators = map[types.ValidatorPubkey]beacon.ValidatorStatus{
+		missing: {Balance: 32.001e9},
+	}
+	status = watchdog.Check(validators, 100+4*32, 32)
+	if !status.Healthy || status.ConsecutiveFailures != 0 || status.ValidatorsPerformed != 1 {
+		t.Fatalf("expected a healthy check, got %+v", status)
+	}
+}
@@ -0,0 +1,116 @@
+package wallet
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/goccy/go-json"
+	"github.com/tyler-smith/go-bip39"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+)
+
+// The prefix of the named seeds test wallet mnemonics are derived from
+const testWalletSeedPrefix string = "rocketpool-test-wallet:"
+
+// Chain IDs test wallets refuse to run on, because their keys are public
+var mainnetChainIDs = map[uint]string{
+	1: "Ethereum Mainnet",
+}
+
+// The part of a test wallet that changes, saved so separate processes agree on the next validator key
+type testWalletState struct {
+	Seed        string `json:"seed"`
+	NextAccount uint   `json:"nextAccount"`
+}
+
+// Get the mnemonic of the test wallet with the given seed name. Anyone who knows the name can derive the keys,
+// so these wallets must only ever be used on devnets.
+func GetTestWalletMnemonic(seedName string) (string, error) {
+	if seedName == "" {
+		return "", errors.New("test wallets need a seed name")
+	}
+	entropy := sha256.Sum256([]byte(testWalletSeedPrefix + seedName))
+	mnemonic, err := bip39.NewMnemonic(entropy[:])
+	if err != nil {
+		return "", fmt.Errorf("Could not generate test wallet mnemonic: %w", err)
+	}
+	return mnemonic, nil
+}
+
+// Create a throwaway wallet with keys derived deterministically from a named seed, for devnets and automated tests.
+// The keys are never written to disk; only the index of the next validator key is saved to statePath.
+func NewTestWallet(seedName string, statePath string, chainId uint, maxFee *big.Int, maxPriorityFee *big.Int, gasLimit uint64, passwordManager *passwords.PasswordManager) (*Wallet, error) {
+
+	// Refuse to use public keys where they could hold real funds
+	if network, exists := mainnetChainIDs[chainId]; exists {
+		return nil, fmt.Errorf("test wallets can't be used on %s (chain ID %d)", network, chainId)
+	}
+
+	mnemonic, err := GetTestWalletMnemonic(seedName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize wallet
+	w := &Wallet{
+		walletPath:     statePath,
+		pm:             passwordManager,
+		encryptor:      eth2ks.New(),
+		chainID:        big.NewInt(int64(chainId)),
+		validatorKeys:  map[uint]*eth2types.BLSPrivateKey{},
+		keystores:      map[string]keystore.Keystore{},
+		maxFee:         maxFee,
+		maxPriorityFee: maxPriorityFee,
+		gasLimit:       gasLimit,
+		testSeed:       seedName,
+	}
+	if err := w.TestRecovery(DefaultNodeKeyPath, 0, mnemonic); err != nil {
+		return nil, err
+	}
+
+	// Pick up where the last process using this seed left off
+	stateBytes, err := os.ReadFile(statePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("Could not read test wallet state: %w", err)
+	}
+	if err == nil {
+		state := testWalletState{}
+		if err := json.Unmarshal(stateBytes, &state); err != nil {
+			return nil, fmt.Errorf("Could not decode test wallet state: %w", err)
+		}
+		if state.Seed == seedName {
+			w.ws.NextAccount = state.NextAccount
+		}
+	}
+
+	// Return
+	return w, nil
+
+}
+
+// Check if this is a test wallet
+func (w *Wallet) IsTestWallet() bool {
+	return w.testSeed != ""
+}
+
+// Save the test wallet's state to disk
+func (w *Wallet) saveTestState() error {
+	stateBytes, err := json.Marshal(testWalletState{
+		Seed:        w.testSeed,
+		NextAccount: w.ws.NextAccount,
+	})
+	if err != nil {
+		return fmt.Errorf("Could not encode test wallet state: %w", err)
+	}
+	if err := os.WriteFile(w.walletPath, stateBytes, FileMode); err != nil {
+		return fmt.Errorf("Could not write test wallet state to disk: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package wallet
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestTestWallet(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "test-wallet-state.json")
+
+	// Mainnet is refused
+	if _, err := NewTestWallet("alice", statePath, 1, nil, nil, 0, nil); err == nil {
+		t.Fatal("expected the test wallet to be refused on Mainnet")
+	}
+
+	// The same seed name always gives the same node account
+	first, err := NewTestWallet("alice", statePath, 17000, nil, nil, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	firstAccount, err := first.GetNodeAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := NewTestWallet("alice", statePath, 17000, nil, nil, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondAccount, err := second.GetNodeAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if firstAccount.Address != secondAccount.Address {
+		t.Fatalf("expected the same node account, got %s and %s", firstAccount.Address.Hex(), secondAccount.Address.Hex())
+	}
+
+	// A different seed name gives a different account
+	other, err := NewTestWallet("bob", statePath, 17000, nil, nil, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherAccount, err := other.GetNodeAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if otherAccount.Address == firstAccount.Address {
+		t.Fatal("expected different seed names to give different node accounts")
+	}
+
+	// The next validator key index carries over between processes
+	first.ws.NextAccount = 3
+	if err := first.Save(); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := NewTestWallet("alice", statePath, 17000, nil, nil, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.ws.NextAccount != 3 {
+		t.Fatalf("expected the next account to be 3, got %d", reloaded.ws.NextAccount)
+	}
+}
@@ -5,16 +5,26 @@ import (
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
 // Get the node account
 func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 
+	// Use the remote signer's account if there is one
+	if w.remoteSigner != nil {
+		return accounts.Account{
+			Address: w.remoteSigner.Address(),
+		}, nil
+	}
+
 	// Check wallet is initialized
 	if !w.IsInitialized() {
 		return accounts.Account{}, errors.New("Wallet is not initialized")
@@ -47,6 +57,13 @@ func (w *Wallet) GetNodeAccount() (accounts.Account, error) {
 // Get a transactor for the node account
 func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 
+	// Sign with the remote signer if there is one
+	if w.remoteSigner != nil {
+		transactor := w.getRemoteTransactor(w.GetChainID())
+		w.coordinateNonces(transactor)
+		return transactor, nil
+	}
+
 	// Check wallet is initialized
 	if !w.IsInitialized() {
 		return nil, errors.New("Wallet is not initialized")
@@ -60,12 +77,76 @@ func (w *Wallet) GetNodeAccountTransactor() (*bind.TransactOpts, error) {
 
 	// Create & return transactor
 	transactor, err := bind.NewKeyedTransactorWithChainID(privateKey, w.chainID)
+	if err != nil {
+		return nil, err
+	}
 	transactor.GasFeeCap = w.maxFee
 	transactor.GasTipCap = w.maxPriorityFee
 	transactor.GasLimit = w.gasLimit
 	transactor.Context = context.Background()
-	return transactor, err
+	w.coordinateNonces(transactor)
+	return transactor, nil
+
+}
+
+// Get a transactor for the node account that signs transactions for another chain, such as a Layer 2 network.
+// The gas settings are left empty so they're estimated by that chain's client.
+func (w *Wallet) GetNodeAccountTransactorForChain(chainID *big.Int) (*bind.TransactOpts, error) {
+
+	// Sign with the remote signer if there is one
+	var transactor *bind.TransactOpts
+	if w.remoteSigner != nil {
+		transactor = w.getRemoteTransactor(chainID)
+	} else {
+		// Check wallet is initialized
+		if !w.IsInitialized() {
+			return nil, errors.New("Wallet is not initialized")
+		}
+
+		// Get private key
+		privateKey, _, err := w.getNodePrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		transactor, err = bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Don't carry over the fees and limit meant for Ethereum
+	transactor.GasFeeCap = nil
+	transactor.GasTipCap = nil
+	transactor.GasLimit = 0
+	transactor.Context = context.Background()
+	return transactor, nil
+
+}
+
+// Have a node account transactor reserve its nonces with the coordinator, if nonce coordination is enabled
+func (w *Wallet) coordinateNonces(transactor *bind.TransactOpts) {
+	if w.nonces == nil {
+		return
+	}
+	w.nonces.WrapTransactor(transactor, w.nonceClient)
+}
 
+// Get a transactor that has the remote signer sign transactions for the node account
+func (w *Wallet) getRemoteTransactor(chainID *big.Int) *bind.TransactOpts {
+	address := w.remoteSigner.Address()
+	return &bind.TransactOpts{
+		From: address,
+		Signer: func(signerAddress common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if signerAddress != address {
+				return nil, bind.ErrNotAuthorized
+			}
+			return w.remoteSigner.SignTx(context.Background(), tx, chainID)
+		},
+		GasFeeCap: w.maxFee,
+		GasTipCap: w.maxPriorityFee,
+		GasLimit:  w.gasLimit,
+		Context:   context.Background(),
+	}
 }
 
 // Get the node account private key bytes
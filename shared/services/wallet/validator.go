@@ -200,6 +200,44 @@ func (w *Wallet) GetValidatorKeys(startIndex uint, length uint) ([]ValidatorKey,
 
 }
 
+// Derive a set of validator keys at another derivation path. Keys at the Smartnode's own path are cached and count
+// towards the wallet's key index when saved; keys at other paths aren't, so they should be saved with StoreValidatorKey.
+func (w *Wallet) GetValidatorKeysAtPath(pathFormat string, startIndex uint, length uint) ([]ValidatorKey, error) {
+
+	// Use the cached keys for the Smartnode's path
+	if pathFormat == validator.ValidatorKeyPath {
+		return w.GetValidatorKeys(startIndex, length)
+	}
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return nil, errors.New("Wallet is not initialized")
+	}
+
+	// Initialize BLS support
+	if err := validator.InitializeBLS(); err != nil {
+		return nil, fmt.Errorf("Could not initialize BLS library: %w", err)
+	}
+
+	validatorKeys := make([]ValidatorKey, 0, length)
+	for index := startIndex; index < startIndex+length; index++ {
+		derivationPath := fmt.Sprintf(pathFormat, index)
+		key, err := eth2util.PrivateKeyFromSeedAndPath(w.seed, derivationPath)
+		if err != nil {
+			return nil, fmt.Errorf("error getting validator key at %s: %w", derivationPath, err)
+		}
+		validatorKeys = append(validatorKeys, ValidatorKey{
+			PublicKey:      types.BytesToValidatorPubkey(key.PublicKey().Marshal()),
+			PrivateKey:     key,
+			DerivationPath: derivationPath,
+			WalletIndex:    index,
+		})
+	}
+
+	return validatorKeys, nil
+
+}
+
 // Save a validator key
 func (w *Wallet) SaveValidatorKey(key ValidatorKey) error {
 
@@ -0,0 +1,42 @@
+package wallet
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+func TestGetValidatorKeysAtPath(t *testing.T) {
+	w, err := NewTestWallet("alice", filepath.Join(t.TempDir(), "test-wallet-state.json"), 17000, nil, nil, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The Smartnode's path gives the same keys as the wallet's own derivation
+	keys, err := w.GetValidatorKeys(3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pathKeys, err := w.GetValidatorKeysAtPath(validator.ValidatorKeyPath, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range keys {
+		if keys[i].PublicKey != pathKeys[i].PublicKey || keys[i].WalletIndex != pathKeys[i].WalletIndex {
+			t.Fatalf("key %d differs between the wallet and the Smartnode's path", i)
+		}
+	}
+
+	// Other paths give different keys at the same index
+	otherKeys, err := w.GetValidatorKeysAtPath(validator.KnownValidatorKeyPaths[1], 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if otherKeys[0].DerivationPath != "m/12381/3600/3/0" {
+		t.Fatalf("unexpected derivation path %s", otherKeys[0].DerivationPath)
+	}
+	if otherKeys[0].PublicKey == keys[0].PublicKey {
+		t.Fatal("expected a different key at another derivation path")
+	}
+}
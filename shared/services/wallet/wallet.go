@@ -1,6 +1,7 @@
 package wallet
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/goccy/go-json"
@@ -18,6 +20,7 @@ import (
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 
+	"github.com/rocket-pool/smartnode/shared/services/nonce"
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
 )
@@ -61,6 +64,22 @@ type Wallet struct {
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
 	gasLimit       uint64
+
+	// Signer that holds the node key on another host, if used
+	remoteSigner RemoteSigner
+
+	// Nonce coordination with the other processes sending from the node account, if enabled
+	nonces      *nonce.Coordinator
+	nonceClient nonce.PendingNonceClient
+
+	// The seed name of a test wallet, blank for real wallets
+	testSeed string
+}
+
+// A signer that holds the node key somewhere other than this wallet
+type RemoteSigner interface {
+	Address() common.Address
+	SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
 }
 
 // Encrypted wallet store
@@ -111,6 +130,22 @@ func (w *Wallet) AddKeystore(name string, ks keystore.Keystore) {
 	w.keystores[name] = ks
 }
 
+// Use a remote signer for the node account instead of the node key in this wallet
+func (w *Wallet) SetRemoteSigner(signer RemoteSigner) {
+	w.remoteSigner = signer
+}
+
+// Reserve nonces with the coordinator for the transactions signed by the node account's transactors
+func (w *Wallet) SetNonceCoordinator(coordinator *nonce.Coordinator, client nonce.PendingNonceClient) {
+	w.nonces = coordinator
+	w.nonceClient = client
+}
+
+// Check if the node account is held by a remote signer
+func (w *Wallet) HasRemoteSigner() bool {
+	return w.remoteSigner != nil
+}
+
 // Check if the wallet has been initialized
 func (w *Wallet) IsInitialized() bool {
 	return (w.ws != nil && w.seed != nil && w.mk != nil)
@@ -118,7 +153,7 @@ func (w *Wallet) IsInitialized() bool {
 
 // Attempt to initialize the wallet if not initialized and return status
 func (w *Wallet) GetInitialized() (bool, error) {
-	if w.IsInitialized() {
+	if w.IsInitialized() || w.remoteSigner != nil {
 		return true, nil
 	}
 	return w.loadStore()
@@ -237,6 +272,11 @@ func (w *Wallet) Save() error {
 		return errors.New("Wallet is not initialized")
 	}
 
+	// Test wallets only save the state that isn't derived from their seed
+	if w.IsTestWallet() {
+		return w.saveTestState()
+	}
+
 	// Encode wallet store
 	wsBytes, err := json.Marshal(w.ws)
 	if err != nil {
@@ -326,6 +366,11 @@ func (w *Wallet) Reload() error {
 // Load the wallet store from disk and decrypt it
 func (w *Wallet) loadStore() (bool, error) {
 
+	// Test wallets are derived from their seed instead
+	if w.IsTestWallet() {
+		return true, nil
+	}
+
 	// Read wallet store from disk; cancel if not found
 	wsBytes, err := os.ReadFile(w.walletPath)
 	if err != nil {
@@ -11,6 +11,8 @@ import (
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/exitqueue"
+	"github.com/rocket-pool/smartnode/shared/services/sweep"
 )
 
 type MinipoolStatusResponse struct {
@@ -19,6 +21,16 @@ type MinipoolStatusResponse struct {
 	Minipools      []MinipoolDetails `json:"minipools"`
 	LatestDelegate common.Address    `json:"latestDelegate"`
 }
+type MinipoolBulkStatusResponse struct {
+	Status         string            `json:"status"`
+	Error          string            `json:"error"`
+	Minipools      []MinipoolDetails `json:"minipools"`
+	LatestDelegate common.Address    `json:"latestDelegate"`
+	Page           uint64            `json:"page"`
+	PageSize       uint64            `json:"pageSize"`
+	TotalCount     int               `json:"totalCount"`
+	HiddenCount    int               `json:"hiddenCount"`
+}
 type MinipoolDetails struct {
 	Address               common.Address         `json:"address"`
 	ValidatorPubkey       types.ValidatorPubkey  `json:"validatorPubkey"`
@@ -45,6 +57,25 @@ type MinipoolDetails struct {
 	ReduceBondTime        time.Time              `json:"reduceBondTime"`
 	ReduceBondCancelled   bool                   `json:"reduceBondCancelled"`
 }
+type MinipoolStatusIssue struct {
+	Description string `json:"description"`
+	Action      string `json:"action"`
+	Critical    bool   `json:"critical"`
+}
+type MinipoolStatusReconciliation struct {
+	Address         common.Address        `json:"address"`
+	ValidatorPubkey types.ValidatorPubkey `json:"validatorPubkey"`
+	ElStatus        types.MinipoolStatus  `json:"elStatus"`
+	Finalised       bool                  `json:"finalised"`
+	ClExists        bool                  `json:"clExists"`
+	ClStatus        beacon.ValidatorState `json:"clStatus"`
+	Issues          []MinipoolStatusIssue `json:"issues"`
+}
+type VerifyMinipoolStatusResponse struct {
+	Status    string                         `json:"status"`
+	Error     string                         `json:"error"`
+	Minipools []MinipoolStatusReconciliation `json:"minipools"`
+}
 type ValidatorDetails struct {
 	Exists      bool     `json:"exists"`
 	Active      bool     `json:"active"`
@@ -102,15 +133,33 @@ type ExitMinipoolResponse struct {
 }
 
 type CanChangeWithdrawalCredentialsResponse struct {
-	Status    string `json:"status"`
-	Error     string `json:"error"`
-	CanChange bool   `json:"canChange"`
+	Status             string      `json:"status"`
+	Error              string      `json:"error"`
+	CanChange          bool        `json:"canChange"`
+	ValidatorIndex     string      `json:"validatorIndex"`
+	CurrentCredentials common.Hash `json:"currentCredentials"`
+	NewCredentials     common.Hash `json:"newCredentials"`
 }
 type ChangeWithdrawalCredentialsResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
 
+type BlsCredentialedMinipool struct {
+	Address               common.Address        `json:"address"`
+	Pubkey                types.ValidatorPubkey `json:"pubkey"`
+	ValidatorIndex        string                `json:"validatorIndex"`
+	BeaconState           beacon.ValidatorState `json:"beaconState"`
+	WithdrawalCredentials common.Hash           `json:"withdrawalCredentials"`
+	MinipoolStatus        types.MinipoolStatus  `json:"minipoolStatus"`
+	IsVacant              bool                  `json:"isVacant"`
+}
+type GetBlsCredentialedMinipoolsResponse struct {
+	Status    string                    `json:"status"`
+	Error     string                    `json:"error"`
+	Minipools []BlsCredentialedMinipool `json:"minipools"`
+}
+
 type ImportKeyResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
@@ -217,6 +266,26 @@ type DelegateUpgradeResponse struct {
 	TxHash common.Hash `json:"txHash"`
 }
 
+type MinipoolDelegateStatus struct {
+	Address           common.Address `json:"address"`
+	Delegate          common.Address `json:"delegate"`
+	DelegateVersion   uint8          `json:"delegateVersion"`
+	UseLatestDelegate bool           `json:"useLatestDelegate"`
+	Finalised         bool           `json:"finalised"`
+	Outdated          bool           `json:"outdated"`
+	Critical          bool           `json:"critical"`
+	CriticalReason    string         `json:"criticalReason"`
+}
+type DelegateStatusResponse struct {
+	Status                string                   `json:"status"`
+	Error                 string                   `json:"error"`
+	LatestDelegate        common.Address           `json:"latestDelegate"`
+	LatestVersion         uint8                    `json:"latestVersion"`
+	CriticalDelegatesUsed bool                     `json:"criticalDelegatesUsed"`
+	CriticalDelegatesErr  string                   `json:"criticalDelegatesErr"`
+	Minipools             []MinipoolDelegateStatus `json:"minipools"`
+}
+
 type CanDelegateRollbackResponse struct {
 	Status          string             `json:"status"`
 	Error           string             `json:"error"`
@@ -349,3 +418,61 @@ type RescueDissolvedMinipoolResponse struct {
 	Error  string      `json:"error"`
 	TxHash common.Hash `json:"txHash"`
 }
+
+type MinipoolSweepForecast struct {
+	Address         common.Address `json:"address"`
+	ValidatorIndex  string         `json:"validatorIndex"`
+	NextSweepSlot   uint64         `json:"nextSweepSlot"`
+	NextSweepTime   time.Time      `json:"nextSweepTime"`
+	FullWithdrawal  bool           `json:"fullWithdrawal"`
+	ProjectedAmount *big.Int       `json:"projectedAmount"`
+}
+type GetMinipoolSweepForecastResponse struct {
+	Status    string                  `json:"status"`
+	Error     string                  `json:"error"`
+	Sweep     sweep.Progress          `json:"sweep"`
+	CycleTime time.Duration           `json:"cycleTime"`
+	Minipools []MinipoolSweepForecast `json:"minipools"`
+}
+
+type MinipoolExitProjection struct {
+	Address           common.Address `json:"address"`
+	ValidatorIndex    string         `json:"validatorIndex"`
+	Initiated         bool           `json:"initiated"`
+	ExitEpoch         uint64         `json:"exitEpoch"`
+	ExitTime          time.Time      `json:"exitTime"`
+	WithdrawableEpoch uint64         `json:"withdrawableEpoch"`
+	WithdrawableTime  time.Time      `json:"withdrawableTime"`
+	WithdrawalSlot    uint64         `json:"withdrawalSlot"`
+	WithdrawalTime    time.Time      `json:"withdrawalTime"`
+}
+type GetMinipoolExitProjectionResponse struct {
+	Status    string                   `json:"status"`
+	Error     string                   `json:"error"`
+	Queue     exitqueue.Queue          `json:"queue"`
+	Minipools []MinipoolExitProjection `json:"minipools"`
+}
+
+type HousekeepingActionKind string
+
+const (
+	HousekeepingAction_Refund            HousekeepingActionKind = "refund"
+	HousekeepingAction_DistributeBalance HousekeepingActionKind = "distribute-balance"
+	HousekeepingAction_DistributeFees    HousekeepingActionKind = "distribute-fees"
+)
+
+type HousekeepingAction struct {
+	Kind     HousekeepingActionKind `json:"kind"`
+	Address  common.Address         `json:"address"`
+	Value    *big.Int               `json:"value"`
+	GasInfo  rocketpool.GasInfo     `json:"gasInfo"`
+	GasCost  *big.Int               `json:"gasCost"`
+	NetValue *big.Int               `json:"netValue"`
+}
+type GetHousekeepingPlanResponse struct {
+	Status       string               `json:"status"`
+	Error        string               `json:"error"`
+	GasPrice     *big.Int             `json:"gasPrice"`
+	Actions      []HousekeepingAction `json:"actions"`
+	Unprofitable []HousekeepingAction `json:"unprofitable"`
+}
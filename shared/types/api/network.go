@@ -4,6 +4,11 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
 )
 
 type NodeFeeResponse struct {
@@ -109,6 +114,60 @@ type DownloadRewardsFileResponse struct {
 	Error  string `json:"error"`
 }
 
+type MinipoolPerformancePageResponse struct {
+	Status    string                     `json:"status"`
+	Error     string                     `json:"error"`
+	Index     uint64                     `json:"index"`
+	Total     uint64                     `json:"total"`
+	Offset    uint64                     `json:"offset"`
+	Limit     uint64                     `json:"limit"`
+	Minipools []MinipoolPerformanceEntry `json:"minipools"`
+}
+type MinipoolPerformanceEntry struct {
+	Minipool                common.Address `json:"minipool"`
+	Node                    common.Address `json:"node"`
+	Pubkey                  string         `json:"pubkey"`
+	SuccessfulAttestations  uint64         `json:"successfulAttestations"`
+	MissedAttestations      uint64         `json:"missedAttestations"`
+	MissingAttestationSlots []uint64       `json:"missingAttestationSlots"`
+	AttestationScore        *big.Int       `json:"attestationScore"`
+	EthEarned               *big.Int       `json:"ethEarned"`
+	BonusEthEarned          *big.Int       `json:"bonusEthEarned"`
+	ConsensusIncome         *big.Int       `json:"consensusIncome"`
+	AverageInclusionDelay   float64        `json:"averageInclusionDelay"`
+}
+
+type NetworkTotalsHistoryResponse struct {
+	Status  string                      `json:"status"`
+	Error   string                      `json:"error"`
+	Enabled bool                        `json:"enabled"`
+	Samples []state.NetworkTotalsSample `json:"samples"`
+}
+
+type SmoothingPoolStatsResponse struct {
+	Status               string                        `json:"status"`
+	Error                string                        `json:"error"`
+	SmoothingPoolBalance *big.Int                      `json:"smoothingPoolBalance"`
+	Membership           state.SmoothingPoolMembership `json:"membership"`
+	History              []state.NetworkTotalsSample   `json:"history"`
+	HistoryEnabled       bool                          `json:"historyEnabled"`
+	IsNodeRegistered     bool                          `json:"isNodeRegistered"`
+	NodeShare            state.NodeSmoothingPoolShare  `json:"nodeShare"`
+}
+
+type NetworkStateResponse struct {
+	Status                     string                                `json:"status"`
+	Error                      string                                `json:"error"`
+	ElBlockNumber              uint64                                `json:"elBlockNumber"`
+	BeaconSlotNumber           uint64                                `json:"beaconSlotNumber"`
+	NetworkDetails             *rpstate.NetworkDetails               `json:"networkDetails,omitempty"`
+	NodeDetails                []rpstate.NativeNodeDetails           `json:"nodeDetails,omitempty"`
+	MinipoolDetails            []rpstate.NativeMinipoolDetails       `json:"minipoolDetails,omitempty"`
+	ValidatorDetails           state.ValidatorDetailsMap             `json:"validatorDetails,omitempty"`
+	OracleDaoMemberDetails     []rpstate.OracleDaoMemberDetails      `json:"oracleDaoMemberDetails,omitempty"`
+	ProtocolDaoProposalDetails []protocol.ProtocolDaoProposalDetails `json:"protocolDaoProposalDetails,omitempty"`
+}
+
 type GetLatestDelegateResponse struct {
 	Status  string         `json:"status"`
 	Error   string         `json:"error"`
@@ -120,3 +179,66 @@ type IsHoustonHotfixDeployedResponse struct {
 	Error                   string `json:"error"`
 	IsHoustonHotfixDeployed bool   `json:"isHoustonHotfixDeployed"`
 }
+
+type NetworkIntervalStatus struct {
+	rewards.IntervalSubmission
+	Consistency rewards.IntervalConsistency `json:"consistency"`
+	Detail      string                      `json:"detail"`
+	Downloaded  bool                        `json:"downloaded"`
+}
+type NetworkIntervalsResponse struct {
+	Status    string                  `json:"status"`
+	Error     string                  `json:"error"`
+	Intervals []NetworkIntervalStatus `json:"intervals"`
+}
+
+type CanApproveRewardsTreeResponse struct {
+	Status       string                         `json:"status"`
+	Error        string                         `json:"error"`
+	ReportExists bool                           `json:"reportExists"`
+	Report       *rewards.ConsistencyGateReport `json:"report"`
+	Approved     bool                           `json:"approved"`
+}
+
+type ApproveRewardsTreeResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+type VerifyAttestationResponse struct {
+	Status        string           `json:"status"`
+	Error         string           `json:"error"`
+	ManifestHash  common.Hash      `json:"manifestHash"`
+	Threshold     uint64           `json:"threshold"`
+	Signers       []common.Address `json:"signers"`
+	SignersPinned bool             `json:"signersPinned"`
+	ValidSigners  []common.Address `json:"validSigners"`
+	Valid         bool             `json:"valid"`
+	Problem       string           `json:"problem"`
+}
+
+type VerifyRewardsTreeResponse struct {
+	Status         string                        `json:"status"`
+	Error          string                        `json:"error"`
+	Index          uint64                        `json:"index"`
+	Submitter      common.Address                `json:"submitter"`
+	TxHash         common.Hash                   `json:"txHash"`
+	ConsensusBlock uint64                        `json:"consensusBlock"`
+	ExecutionBlock uint64                        `json:"executionBlock"`
+	RulesetVersion uint64                        `json:"rulesetVersion"`
+	OnChainRoot    common.Hash                   `json:"onChainRoot"`
+	LocalRoot      common.Hash                   `json:"localRoot"`
+	Matches        bool                          `json:"matches"`
+	Report         *rewards.RootDivergenceReport `json:"report"`
+}
+
+type ExportRewardsCSVResponse struct {
+	Status                     string `json:"status"`
+	Error                      string `json:"error"`
+	Index                      uint64 `json:"index"`
+	RewardsPath                string `json:"rewardsPath"`
+	NodeCount                  int    `json:"nodeCount"`
+	MinipoolPerformancePath    string `json:"minipoolPerformancePath"`
+	MinipoolCount              int    `json:"minipoolCount"`
+	MinipoolPerformanceMissing bool   `json:"minipoolPerformanceMissing"`
+}
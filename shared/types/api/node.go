@@ -168,10 +168,11 @@ type RegisterNodeResponse struct {
 }
 
 type CanSetNodePrimaryWithdrawalAddressResponse struct {
-	Status  string             `json:"status"`
-	Error   string             `json:"error"`
-	CanSet  bool               `json:"canSet"`
-	GasInfo rocketpool.GasInfo `json:"gasInfo"`
+	Status     string             `json:"status"`
+	Error      string             `json:"error"`
+	CanSet     bool               `json:"canSet"`
+	IsContract bool               `json:"isContract"`
+	GasInfo    rocketpool.GasInfo `json:"gasInfo"`
 }
 type SetNodePrimaryWithdrawalAddressResponse struct {
 	Status string      `json:"status"`
@@ -230,6 +231,23 @@ type GetNodePendingPrimaryWithdrawalAddressResponse struct {
 	Address common.Address `json:"address"`
 }
 
+// An unsigned transaction that must be sent from the pending withdrawal address to confirm it
+type WithdrawalAddressConfirmationTx struct {
+	ChainID     uint           `json:"chainId"`
+	NodeAddress common.Address `json:"nodeAddress"`
+	From        common.Address `json:"from"`
+	To          common.Address `json:"to"`
+	Value       string         `json:"value"`
+	Data        string         `json:"data"`
+	Description string         `json:"description"`
+}
+type GetNodePrimaryWithdrawalAddressConfirmationTxResponse struct {
+	Status            string                          `json:"status"`
+	Error             string                          `json:"error"`
+	HasPendingAddress bool                            `json:"hasPendingAddress"`
+	Transaction       WithdrawalAddressConfirmationTx `json:"transaction"`
+}
+
 type CanSetNodeTimezoneResponse struct {
 	Status  string             `json:"status"`
 	Error   string             `json:"error"`
@@ -242,6 +260,40 @@ type SetNodeTimezoneResponse struct {
 	TxHash common.Hash `json:"txHash"`
 }
 
+type TimezoneCount struct {
+	Timezone string `json:"timezone"`
+	Count    uint64 `json:"count"`
+}
+type NodeTimezoneDistributionResponse struct {
+	Status       string          `json:"status"`
+	Error        string          `json:"error"`
+	TotalNodes   uint64          `json:"totalNodes"`
+	Timezones    []TimezoneCount `json:"timezones"`
+	NodeTimezone string          `json:"nodeTimezone"`
+}
+
+type NodeContactInfoResponse struct {
+	Status  string            `json:"status"`
+	Error   string            `json:"error"`
+	Address common.Address    `json:"address"`
+	EnsName string            `json:"ensName"`
+	Records map[string]string `json:"records"`
+}
+type CanSetNodeContactInfoResponse struct {
+	Status        string             `json:"status"`
+	Error         string             `json:"error"`
+	CanSet        bool               `json:"canSet"`
+	NoEnsName     bool               `json:"noEnsName"`
+	NotAuthorized bool               `json:"notAuthorized"`
+	EnsName       string             `json:"ensName"`
+	GasInfo       rocketpool.GasInfo `json:"gasInfo"`
+}
+type SetNodeContactInfoResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
 type CanNodeSwapRplResponse struct {
 	Status              string             `json:"status"`
 	Error               string             `json:"error"`
@@ -446,6 +498,47 @@ type NodeSyncProgressResponse struct {
 	Error    string              `json:"error"`
 	EcStatus ClientManagerStatus `json:"ecStatus"`
 	BcStatus ClientManagerStatus `json:"bcStatus"`
+
+	TreeGeneration      *rewards.TreeGenerationProgress `json:"treeGeneration"`
+	TreeGenerationError string                          `json:"treeGenerationError"`
+}
+
+type NodeLeaderboardResponse struct {
+	Status      string                    `json:"status"`
+	Error       string                    `json:"error"`
+	Enabled     bool                      `json:"enabled"`
+	TotalNodes  int                       `json:"totalNodes"`
+	Leaderboard *rewards.Leaderboard      `json:"leaderboard"`
+	NodeEntry   *rewards.LeaderboardEntry `json:"nodeEntry"`
+	EnsNames    map[common.Address]string `json:"ensNames"`
+}
+
+type NodeVerifyMyRewardsResponse struct {
+	Status           string                  `json:"status"`
+	Error            string                  `json:"error"`
+	Interval         uint64                  `json:"interval"`
+	RulesetVersion   uint64                  `json:"rulesetVersion"`
+	MerkleRoot       string                  `json:"merkleRoot"`
+	NodeInTree       bool                    `json:"nodeInTree"`
+	CollateralRpl    RewardsLineVerification `json:"collateralRpl"`
+	OracleDaoRpl     RewardsLineVerification `json:"oracleDaoRpl"`
+	SmoothingPoolEth RewardsLineVerification `json:"smoothingPoolEth"`
+	Matches          bool                    `json:"matches"`
+}
+type RewardsLineVerification struct {
+	Published *big.Int `json:"published"`
+	Expected  *big.Int `json:"expected"`
+	Checked   bool     `json:"checked"`
+	Reason    string   `json:"reason,omitempty"`
+}
+
+type NodeEffectivenessResponse struct {
+	Status             string                            `json:"status"`
+	Error              string                            `json:"error"`
+	Interval           uint64                            `json:"interval"`
+	HasInclusionDelays bool                              `json:"hasInclusionDelays"`
+	Effectiveness      float64                           `json:"effectiveness"`
+	Validators         []*rewards.ValidatorEffectiveness `json:"validators"`
 }
 
 type CanNodeClaimRplResponse struct {
@@ -535,6 +628,8 @@ type NodeGetRewardsInfoResponse struct {
 	ClaimedIntervals        []uint64               `json:"claimedIntervals"`
 	UnclaimedIntervals      []rewards.IntervalInfo `json:"unclaimedIntervals"`
 	InvalidIntervals        []rewards.IntervalInfo `json:"invalidIntervals"`
+	L2UnclaimedIntervals    []rewards.IntervalInfo `json:"l2UnclaimedIntervals"`
+	RewardNetworkNames      map[uint64]string      `json:"rewardNetworkNames"`
 	RplStake                *big.Int               `json:"rplStake"`
 	RplPrice                *big.Int               `json:"rplPrice"`
 	ActiveMinipools         int                    `json:"activeMinipools"`
@@ -545,6 +640,75 @@ type NodeGetRewardsInfoResponse struct {
 	PendingMatchAmount      *big.Int               `json:"pendingMatchAmount"`
 	BorrowedCollateralRatio float64                `json:"borrowedCollateralRatio"`
 	BondedCollateralRatio   float64                `json:"bondedCollateralRatio"`
+	ClaimRouting            RewardsClaimRouting    `json:"claimRouting"`
+}
+
+// The addresses that claimed rewards will be sent to
+type RewardsClaimRouting struct {
+	NodeAddress               common.Address           `json:"nodeAddress"`
+	EthRecipient              common.Address           `json:"ethRecipient"`
+	RplRecipient              common.Address           `json:"rplRecipient"`
+	IsRplWithdrawalAddressSet bool                     `json:"isRplWithdrawalAddressSet"`
+	PendingEthRecipient       common.Address           `json:"pendingEthRecipient"`
+	PendingRplRecipient       common.Address           `json:"pendingRplRecipient"`
+	EthRecipientIsContract    bool                     `json:"ethRecipientIsContract"`
+	RplRecipientIsContract    bool                     `json:"rplRecipientIsContract"`
+	ComplianceFlags           []rewards.ComplianceFlag `json:"complianceFlags"`
+}
+
+// The claim status of a single rewards interval for the node, reconciled against its rewards file
+type IntervalClaimStatus struct {
+	Index           uint64      `json:"index"`
+	StartTime       time.Time   `json:"startTime"`
+	EndTime         time.Time   `json:"endTime"`
+	Claimed         bool        `json:"claimed"`
+	TreeFileExists  bool        `json:"treeFileExists"`
+	MerkleRootValid bool        `json:"merkleRootValid"`
+	NodeExists      bool        `json:"nodeExists"`
+	ExpectedRpl     *big.Int    `json:"expectedRpl"`
+	ExpectedEth     *big.Int    `json:"expectedEth"`
+	ClaimEventFound bool        `json:"claimEventFound"`
+	ClaimedRpl      *big.Int    `json:"claimedRpl"`
+	ClaimedEth      *big.Int    `json:"claimedEth"`
+	ClaimBlock      uint64      `json:"claimBlock"`
+	ClaimTxHash     common.Hash `json:"claimTxHash"`
+	AmountMismatch  bool        `json:"amountMismatch"`
+}
+type NodeClaimStatusResponse struct {
+	Status       string                `json:"status"`
+	Error        string                `json:"error"`
+	Registered   bool                  `json:"registered"`
+	Intervals    []IntervalClaimStatus `json:"intervals"`
+	UnclaimedRpl *big.Int              `json:"unclaimedRpl"`
+	UnclaimedEth *big.Int              `json:"unclaimedEth"`
+}
+
+// A self-contained bundle that lets the node's rewards be claimed from a wallet in another environment
+type ClaimBundle struct {
+	Version      int                   `json:"version"`
+	Network      string                `json:"network"`
+	ChainID      uint                  `json:"chainId"`
+	CreatedAt    time.Time             `json:"createdAt"`
+	NodeAddress  common.Address        `json:"nodeAddress"`
+	To           common.Address        `json:"to"`
+	Value        string                `json:"value"`
+	Data         string                `json:"data"`
+	TotalRpl     *rewards.QuotedBigInt `json:"totalRpl"`
+	TotalEth     *rewards.QuotedBigInt `json:"totalEth"`
+	Intervals    []ClaimBundleInterval `json:"intervals"`
+	Instructions string                `json:"instructions"`
+}
+type ClaimBundleInterval struct {
+	Index       uint64                `json:"index"`
+	MerkleRoot  common.Hash           `json:"merkleRoot"`
+	AmountRpl   *rewards.QuotedBigInt `json:"amountRpl"`
+	AmountEth   *rewards.QuotedBigInt `json:"amountEth"`
+	MerkleProof []common.Hash         `json:"merkleProof"`
+}
+type NodeExportClaimBundleResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	Bundle ClaimBundle `json:"bundle"`
 }
 
 type CanNodeClaimRewardsResponse struct {
@@ -558,6 +722,56 @@ type NodeClaimRewardsResponse struct {
 	TxHash common.Hash `json:"txHash"`
 }
 
+type CanNodeClaimL2RewardsResponse struct {
+	Status             string             `json:"status"`
+	Error              string             `json:"error"`
+	CanClaim           bool               `json:"canClaim"`
+	NetworkName        string             `json:"networkName"`
+	ChainID            uint64             `json:"chainId"`
+	UnrelayedIntervals []uint64           `json:"unrelayedIntervals"`
+	ClaimedIntervals   []uint64           `json:"claimedIntervals"`
+	GasInfo            rocketpool.GasInfo `json:"gasInfo"`
+}
+type NodeClaimL2RewardsResponse struct {
+	Status      string      `json:"status"`
+	Error       string      `json:"error"`
+	NetworkName string      `json:"networkName"`
+	TxHash      common.Hash `json:"txHash"`
+	Succeeded   bool        `json:"succeeded"`
+}
+
+type NodeRewardNetworkStatusResponse struct {
+	Status                  string                `json:"status"`
+	Error                   string                `json:"error"`
+	RewardNetwork           uint64                `json:"rewardNetwork"`
+	NetworkName             string                `json:"networkName"`
+	IsValid                 bool                  `json:"isValid"`
+	Networks                []RewardNetworkOption `json:"networks"`
+	WithdrawalAddress       common.Address        `json:"withdrawalAddress"`
+	IsNodeWithdrawalAddress bool                  `json:"isNodeWithdrawalAddress"`
+	NextIntervalTime        time.Time             `json:"nextIntervalTime"`
+}
+type RewardNetworkOption struct {
+	ID      uint64 `json:"id"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+type CanNodeSetRewardNetworkResponse struct {
+	Status                  string                `json:"status"`
+	Error                   string                `json:"error"`
+	CanSet                  bool                  `json:"canSet"`
+	NetworkDisabled         bool                  `json:"networkDisabled"`
+	Unchanged               bool                  `json:"unchanged"`
+	IsNodeWithdrawalAddress bool                  `json:"isNodeWithdrawalAddress"`
+	Transaction             rp.SetRewardNetworkTx `json:"transaction"`
+	GasInfo                 rocketpool.GasInfo    `json:"gasInfo"`
+}
+type NodeSetRewardNetworkResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
 type CanNodeClaimAndStakeRewardsResponse struct {
 	Status  string             `json:"status"`
 	Error   string             `json:"error"`
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+type RethStatusResponse struct {
+	Status               string        `json:"status"`
+	Error                string        `json:"error"`
+	ExchangeRate         float64       `json:"exchangeRate"`
+	TotalSupply          *big.Int      `json:"totalSupply"`
+	CollateralRate       float64       `json:"collateralRate"`
+	TargetCollateralRate float64       `json:"targetCollateralRate"`
+	DepositEnabled       bool          `json:"depositEnabled"`
+	DepositPoolBalance   *big.Int      `json:"depositPoolBalance"`
+	DepositCapacity      *big.Int      `json:"depositCapacity"`
+	MinimumDeposit       *big.Int      `json:"minimumDeposit"`
+	DepositFee           *big.Int      `json:"depositFee"`
+	BurnCapacity         *big.Int      `json:"burnCapacity"`
+	BurnCapacityReth     *big.Int      `json:"burnCapacityReth"`
+	RateUpdatesEnabled   bool          `json:"rateUpdatesEnabled"`
+	LastRateUpdateBlock  uint64        `json:"lastRateUpdateBlock"`
+	LastRateUpdateTime   time.Time     `json:"lastRateUpdateTime"`
+	RateUpdateFrequency  time.Duration `json:"rateUpdateFrequency"`
+	NextRateUpdateTime   time.Time     `json:"nextRateUpdateTime"`
+}
+
+type RethRateHistoryResponse struct {
+	Status  string                      `json:"status"`
+	Error   string                      `json:"error"`
+	Enabled bool                        `json:"enabled"`
+	Samples []state.NetworkTotalsSample `json:"samples"`
+	HasApr  bool                        `json:"hasApr"`
+	Apr     float64                     `json:"apr"`
+}
+
+type RethAprResponse struct {
+	Status                   string        `json:"status"`
+	Error                    string        `json:"error"`
+	TotalEthBalance          *big.Int      `json:"totalEthBalance"`
+	SmoothingPoolBalance     *big.Int      `json:"smoothingPoolBalance"`
+	SmoothingPoolStakerShare *big.Int      `json:"smoothingPoolStakerShare"`
+	IntervalElapsed          time.Duration `json:"intervalElapsed"`
+	SmoothingPoolApr         float64       `json:"smoothingPoolApr"`
+	HasRateApr               bool          `json:"hasRateApr"`
+	RateApr                  float64       `json:"rateApr"`
+	RateAprDays              uint64        `json:"rateAprDays"`
+}
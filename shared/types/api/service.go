@@ -1,6 +1,12 @@
 package api
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/services/vcwatchdog"
+)
 
 type TerminateDataFolderResponse struct {
 	Status        string `json:"status"`
@@ -41,3 +47,53 @@ type RestartVcResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+type VcWatchdogStatusResponse struct {
+	Status   string             `json:"status"`
+	Error    string             `json:"error"`
+	Enabled  bool               `json:"enabled"`
+	VcStatus *vcwatchdog.Status `json:"vcStatus"`
+}
+
+type ProbeEcResponse struct {
+	Status         string          `json:"status"`
+	Error          string          `json:"error"`
+	Healthy        bool            `json:"healthy"`
+	ArchiveEcIsSet bool            `json:"archiveEcIsSet"`
+	Probes         []EcProbeResult `json:"probes"`
+}
+type EcProbeResult struct {
+	Name     string        `json:"name"`
+	Healthy  bool          `json:"healthy"`
+	Detail   string        `json:"detail"`
+	Duration time.Duration `json:"duration"`
+}
+
+// An anonymous snapshot of a node's setup and health.
+// It deliberately contains nothing that identifies the node: no addresses, keys, URLs, or hostnames.
+type TelemetryReport struct {
+	SmartnodeVersion         string  `json:"smartnodeVersion"`
+	Network                  string  `json:"network"`
+	ExecutionClientMode      string  `json:"executionClientMode"`
+	ExecutionClient          string  `json:"executionClient"`
+	ConsensusClientMode      string  `json:"consensusClientMode"`
+	ConsensusClient          string  `json:"consensusClient"`
+	MevBoostEnabled          bool    `json:"mevBoostEnabled"`
+	CpuCores                 int     `json:"cpuCores"`
+	MemoryGB                 uint64  `json:"memoryGb"`
+	ExecutionSynced          bool    `json:"executionSynced"`
+	ExecutionSyncProgress    float64 `json:"executionSyncProgress"`
+	ExecutionFallbackEnabled bool    `json:"executionFallbackEnabled"`
+	ConsensusSynced          bool    `json:"consensusSynced"`
+	ConsensusSyncProgress    float64 `json:"consensusSyncProgress"`
+	ConsensusFallbackEnabled bool    `json:"consensusFallbackEnabled"`
+	ReportDate               string  `json:"reportDate"`
+}
+
+type TelemetryPreviewResponse struct {
+	Status  string          `json:"status"`
+	Error   string          `json:"error"`
+	Enabled bool            `json:"enabled"`
+	Url     string          `json:"url"`
+	Report  TelemetryReport `json:"report"`
+}
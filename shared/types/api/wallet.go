@@ -38,26 +38,29 @@ type InitWalletResponse struct {
 }
 
 type RecoverWalletResponse struct {
-	Status         string                  `json:"status"`
-	Error          string                  `json:"error"`
-	AccountAddress common.Address          `json:"accountAddress"`
-	ValidatorKeys  []types.ValidatorPubkey `json:"validatorKeys"`
+	Status                 string                  `json:"status"`
+	Error                  string                  `json:"error"`
+	AccountAddress         common.Address          `json:"accountAddress"`
+	ValidatorKeys          []types.ValidatorPubkey `json:"validatorKeys"`
+	UnmatchedValidatorKeys []types.ValidatorPubkey `json:"unmatchedValidatorKeys"`
 }
 
 type SearchAndRecoverWalletResponse struct {
-	Status         string                  `json:"status"`
-	Error          string                  `json:"error"`
-	FoundWallet    bool                    `json:"foundWallet"`
-	AccountAddress common.Address          `json:"accountAddress"`
-	DerivationPath string                  `json:"derivationPath"`
-	Index          uint                    `json:"index"`
-	ValidatorKeys  []types.ValidatorPubkey `json:"validatorKeys"`
+	Status                 string                  `json:"status"`
+	Error                  string                  `json:"error"`
+	FoundWallet            bool                    `json:"foundWallet"`
+	AccountAddress         common.Address          `json:"accountAddress"`
+	DerivationPath         string                  `json:"derivationPath"`
+	Index                  uint                    `json:"index"`
+	ValidatorKeys          []types.ValidatorPubkey `json:"validatorKeys"`
+	UnmatchedValidatorKeys []types.ValidatorPubkey `json:"unmatchedValidatorKeys"`
 }
 
 type RebuildWalletResponse struct {
-	Status        string                  `json:"status"`
-	Error         string                  `json:"error"`
-	ValidatorKeys []types.ValidatorPubkey `json:"validatorKeys"`
+	Status                 string                  `json:"status"`
+	Error                  string                  `json:"error"`
+	ValidatorKeys          []types.ValidatorPubkey `json:"validatorKeys"`
+	UnmatchedValidatorKeys []types.ValidatorPubkey `json:"unmatchedValidatorKeys"`
 }
 
 type ExportWalletResponse struct {
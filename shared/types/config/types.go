@@ -11,6 +11,11 @@ type MevRelayID string
 type MevSelectionMode string
 type NimbusPruningMode string
 type PBSubmissionRef int
+type DelegateUpgradeMode string
+type WatchtowerRedundancyMode string
+type DisplayUnit string
+type NumberLocale string
+type VcWatchdogPolicy string
 
 // Enum to describe which container(s) a parameter impacts, so the Smartnode knows which
 // ones to restart upon a settings change
@@ -27,6 +32,7 @@ const (
 	ContainerID_Alertmanager ContainerID = "alertmanager"
 	ContainerID_Exporter     ContainerID = "exporter"
 	ContainerID_MevBoost     ContainerID = "mev-boost"
+	ContainerID_CacheProxy   ContainerID = "cache-proxy"
 )
 
 // Enum to describe which network the system is on
@@ -88,6 +94,46 @@ const (
 	PBSubmission_6AM PBSubmissionRef = 1713420000
 )
 
+// Enum to describe when the node should automatically upgrade its minipool delegates
+const (
+	DelegateUpgradeMode_Unknown  DelegateUpgradeMode = ""
+	DelegateUpgradeMode_Disabled DelegateUpgradeMode = "disabled"
+	DelegateUpgradeMode_Critical DelegateUpgradeMode = "critical"
+	DelegateUpgradeMode_All      DelegateUpgradeMode = "all"
+)
+
+// Enum to describe how redundant watchtower instances decide which one sends the Oracle DAO duties
+const (
+	WatchtowerRedundancyMode_Unknown  WatchtowerRedundancyMode = ""
+	WatchtowerRedundancyMode_Disabled WatchtowerRedundancyMode = "disabled"
+	WatchtowerRedundancyMode_Lease    WatchtowerRedundancyMode = "lease"
+	WatchtowerRedundancyMode_Delay    WatchtowerRedundancyMode = "delay"
+)
+
+// Enum to describe the unit ETH amounts are shown in by the CLI
+const (
+	DisplayUnit_Unknown DisplayUnit = ""
+	DisplayUnit_Eth     DisplayUnit = "eth"
+	DisplayUnit_Gwei    DisplayUnit = "gwei"
+)
+
+// Enum to describe the digit grouping and decimal separators the CLI uses for numbers
+const (
+	NumberLocale_Unknown NumberLocale = ""
+	NumberLocale_Plain   NumberLocale = "plain"
+	NumberLocale_Comma   NumberLocale = "comma"
+	NumberLocale_Period  NumberLocale = "period"
+	NumberLocale_Space   NumberLocale = "space"
+)
+
+// Enum to describe what the node does when the validator client watchdog finds a problem
+const (
+	VcWatchdogPolicy_Unknown  VcWatchdogPolicy = ""
+	VcWatchdogPolicy_Disabled VcWatchdogPolicy = "disabled"
+	VcWatchdogPolicy_Alert    VcWatchdogPolicy = "alert"
+	VcWatchdogPolicy_Restart  VcWatchdogPolicy = "restart"
+)
+
 // Enum to identify MEV-boost relays
 const (
 	MevRelayID_Unknown            MevRelayID = ""
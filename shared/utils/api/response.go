@@ -8,6 +8,7 @@ import (
 
 	"github.com/goccy/go-json"
 
+	"github.com/rocket-pool/smartnode/shared/services/simulation"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
@@ -47,8 +48,12 @@ func PrintResponse(response interface{}, responseError error) {
 		ef.SetString(responseError.Error())
 	}
 
-	// Set status
-	if ef.String() == "" {
+	// A simulated transaction stops the command where it would have been sent, so report the simulation instead
+	simulations := simulation.GetReports()
+	if len(simulations) > 0 {
+		ef.SetString("")
+		sf.SetString("simulated")
+	} else if ef.String() == "" {
 		sf.SetString("success")
 	} else {
 		sf.SetString("error")
@@ -60,6 +65,13 @@ func PrintResponse(response interface{}, responseError error) {
 		PrintErrorResponse(fmt.Errorf("Could not encode API response: %w", err))
 		return
 	}
+	if len(simulations) > 0 {
+		responseBytes, err = addSimulations(responseBytes, simulations)
+		if err != nil {
+			PrintErrorResponse(err)
+			return
+		}
+	}
 
 	// Print
 	fmt.Println(string(responseBytes))
@@ -70,3 +82,17 @@ func PrintResponse(response interface{}, responseError error) {
 func PrintErrorResponse(err error) {
 	PrintResponse(&api.APIResponse{}, err)
 }
+
+// Add the simulated transactions to a serialized response
+func addSimulations(responseBytes []byte, simulations []simulation.Report) ([]byte, error) {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(responseBytes, &fields); err != nil {
+		return nil, fmt.Errorf("Could not encode API response: %w", err)
+	}
+	simulationBytes, err := json.Marshal(simulations)
+	if err != nil {
+		return nil, fmt.Errorf("Could not encode simulated transactions: %w", err)
+	}
+	fields["simulations"] = simulationBytes
+	return json.Marshal(fields)
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/settings/protocol"
 	"github.com/rocket-pool/rocketpool-go/utils"
@@ -63,6 +64,11 @@ func PrintAndCheckGasInfo(gasInfo rocketpool.GasInfo, checkThreshold bool, gasTh
 	return true
 }
 
+// An execution client that records transaction outcomes in the audit log
+type transactionResultRecorder interface {
+	RecordTransactionResult(hash common.Hash, receipt *types.Receipt, waitErr error)
+}
+
 // Print a TX's details to the logger and waits for it to validated.
 func PrintAndWaitForTransaction(cfg *config.RocketPoolConfig, hash common.Hash, ec rocketpool.ExecutionClient, logger *log.ColorLogger) error {
 
@@ -77,7 +83,11 @@ func PrintAndWaitForTransaction(cfg *config.RocketPoolConfig, hash common.Hash,
 	logger.Println("Waiting for the transaction to be validated...")
 
 	// Wait for the TX to be included in a block
-	if _, err := utils.WaitForTransaction(ec, hash); err != nil {
+	receipt, err := utils.WaitForTransaction(ec, hash)
+	if recorder, ok := ec.(transactionResultRecorder); ok {
+		recorder.RecordTransactionResult(hash, receipt, err)
+	}
+	if err != nil {
 		return fmt.Errorf("Error waiting for transaction: %w", err)
 	}
 
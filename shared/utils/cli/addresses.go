@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/rocket-pool/smartnode/shared/services/addressbook"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/ens"
+)
+
+// The environment variable that holds the address book's password, for running with --yes
+const AddressBookPasswordEnvVar string = "ROCKETPOOL_ADDRESS_BOOK_PASSWORD"
+
+// The prompt pattern for an address, an ENS name, or an address book label
+const AddressOrNamePattern string = "^(0x[0-9a-fA-F]{40}|[^\\s.]+(\\.[^\\s.]+)+|[A-Za-z0-9][A-Za-z0-9 _-]{0,31})$"
+
+// The address book, once this command has tried to load it
+var (
+	addressBook       *addressbook.AddressBook
+	addressBookErr    error
+	addressBookLoaded bool
+)
+
+// Get the path of the address book in the CLI's config directory
+func GetAddressBookPath(rp *rocketpool.Client) (string, error) {
+	configPath, err := homedir.Expand(rp.ConfigPath())
+	if err != nil {
+		return "", fmt.Errorf("error expanding config path [%s]: %w", rp.ConfigPath(), err)
+	}
+	return filepath.Join(configPath, addressbook.Filename), nil
+}
+
+// Load the address book, prompting for its password if it's encrypted
+func LoadAddressBook(rp *rocketpool.Client) (*addressbook.AddressBook, error) {
+	if addressBookLoaded {
+		return addressBook, addressBookErr
+	}
+	addressBookLoaded = true
+	path, err := GetAddressBookPath(rp)
+	if err != nil {
+		addressBookErr = err
+		return nil, err
+	}
+	addressBook, addressBookErr = addressbook.Load(path, func() (string, error) {
+		if password := os.Getenv(AddressBookPasswordEnvVar); password != "" {
+			return password, nil
+		}
+		if nonInteractive {
+			return "", fmt.Errorf("the address book is encrypted; set %s to use it with --yes / --no-prompt", AddressBookPasswordEnvVar)
+		}
+		return PromptPassword("Please enter your address book's password:", "^.+$", "Please enter your address book's password:"), nil
+	})
+	return addressBook, addressBookErr
+}
+
+// Parse an address argument that can also be an ENS name or an address book label, resolving it if needed.
+// Returns the address and how to display it, e.g. "Safe, name.eth (0x...)".
+func ResolveAddress(rp *rocketpool.Client, name, value string) (common.Address, string, error) {
+	var address common.Address
+	names := []string{}
+	if common.IsHexAddress(value) {
+		address = common.HexToAddress(value)
+	} else if ens.IsName(value) {
+		response, err := rp.ResolveEnsName(value)
+		if err != nil {
+			return common.Address{}, "", err
+		}
+		address = response.Address
+		names = append(names, value)
+	} else {
+		book, err := LoadAddressBook(rp)
+		if err != nil {
+			return common.Address{}, "", err
+		}
+		var exists bool
+		address, exists = book.GetAddress(value)
+		if !exists {
+			return common.Address{}, "", fmt.Errorf("Invalid %s '%s': it isn't an address, an ENS name, or a label in your address book", name, value)
+		}
+	}
+
+	// Add the address's label; it's only for display, so it's fine if the address book can't be loaded
+	book, err := LoadAddressBook(rp)
+	if err == nil {
+		if label, exists := book.GetLabel(address); exists {
+			names = append([]string{label}, names...)
+		}
+	}
+	if len(names) == 0 {
+		return address, address.Hex(), nil
+	}
+	return address, fmt.Sprintf("%s (%s)", strings.Join(names, ", "), address.Hex()), nil
+}
+
+// Validate an address argument that can also be an ENS name or an address book label; names are resolved later by the
+// command itself
+func ValidateAddressOrName(name, value string) error {
+	if ens.IsName(value) || addressbook.ValidateLabel(value) == nil {
+		return nil
+	}
+	_, err := ValidateAddress(name, value)
+	return err
+}
+
+// Print a warning if the address book is in use but doesn't have an address, since it may have been entered incorrectly
+func WarnIfUnlabeled(rp *rocketpool.Client, address common.Address) {
+	book, err := LoadAddressBook(rp)
+	if err != nil || len(book.Entries) == 0 {
+		return
+	}
+	if _, exists := book.GetLabel(address); !exists {
+		fmt.Printf("%sWARNING: %s isn't in your address book. Please make sure it's correct before continuing; you can label it with `rocketpool wallet address-book add`.%s\n\n", colorYellow, address.Hex(), colorReset)
+	}
+}
@@ -9,9 +9,36 @@ import (
 	"strings"
 )
 
+// Whether prompts should be answered automatically instead of waiting for user input
+var nonInteractive bool
+
+// Enable or disable non-interactive mode.
+// In non-interactive mode, confirmation prompts are accepted automatically and any prompt that needs an actual
+// answer from the user aborts the command instead of waiting for input that will never come.
+func SetNonInteractive(enabled bool) {
+	nonInteractive = enabled
+}
+
+// Check if non-interactive mode is enabled
+func IsNonInteractive() bool {
+	return nonInteractive
+}
+
+// Abort the command because a prompt needs input that can't be provided in non-interactive mode
+func exitForRequiredInput(initialPrompt string) {
+	fmt.Println(initialPrompt)
+	fmt.Fprintln(os.Stderr, "This prompt requires input, which can't be provided when running with --yes / --no-prompt. Please provide the value with a command flag, or run the command interactively.")
+	os.Exit(1)
+}
+
 // Prompt for user input
 func Prompt(initialPrompt string, expectedFormat string, incorrectFormatPrompt string) string {
 
+	// Input can't be provided in non-interactive mode
+	if nonInteractive {
+		exitForRequiredInput(initialPrompt)
+	}
+
 	// Print initial prompt
 	fmt.Println(initialPrompt)
 
@@ -30,12 +57,20 @@ func Prompt(initialPrompt string, expectedFormat string, incorrectFormatPrompt s
 
 // Prompt for confirmation
 func Confirm(initialPrompt string) bool {
+	if nonInteractive {
+		fmt.Printf("%s [y/n]\ny (--yes)\n\n", initialPrompt)
+		return true
+	}
 	response := Prompt(fmt.Sprintf("%s [y/n]", initialPrompt), "(?i)^(y|yes|n|no)$", "Please answer 'y' or 'n'")
 	return (strings.ToLower(response[:1]) == "y")
 }
 
 // Prompt for 'I agree' confirmation (used on important questions to avoid a quick 'y' response from the user)
 func ConfirmWithIAgree(initialPrompt string) bool {
+	if nonInteractive {
+		fmt.Printf("%s [Type 'I agree' or 'n']\nI agree (--yes)\n\n", initialPrompt)
+		return true
+	}
 	response := Prompt(fmt.Sprintf("%s [Type 'I agree' or 'n']", initialPrompt), "(?i)^(i agree|n|no)$", "Please answer 'I agree' or 'n'")
 	return (len(response) == 7 && strings.ToLower(response[:7]) == "i agree")
 }
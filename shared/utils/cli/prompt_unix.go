@@ -14,6 +14,11 @@ import (
 // Prompt for password input
 func PromptPassword(initialPrompt string, expectedFormat string, incorrectFormatPrompt string) string {
 
+	// Input can't be provided in non-interactive mode
+	if nonInteractive {
+		exitForRequiredInput(initialPrompt)
+	}
+
 	// Print initial prompt
 	fmt.Println(initialPrompt)
 
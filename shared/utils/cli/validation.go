@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/tyler-smith/go-bip39"
@@ -23,6 +24,9 @@ const (
 	MinDAOMemberIDLength = 3
 )
 
+// The standard ENS text record keys that can be used to publish a node operator's contact info
+var ContactInfoKeys = []string{"url", "email", "com.discord", "com.twitter", "com.github", "org.telegram"}
+
 //
 // General types
 //
@@ -295,9 +299,22 @@ func ValidateTimezoneLocation(name, value string) (string, error) {
 	if !regexp.MustCompile("^([a-zA-Z_]{2,}\\/)+[a-zA-Z_]{2,}$").MatchString(value) {
 		return "", fmt.Errorf("Invalid %s '%s' - must be in the format 'Country/City'", name, value)
 	}
+	if _, err := time.LoadLocation(value); err != nil {
+		return "", fmt.Errorf("Invalid %s '%s' - not a known timezone in the tz database", name, value)
+	}
 	return value, nil
 }
 
+// Validate a contact info key
+func ValidateContactInfoKey(name, value string) (string, error) {
+	for _, key := range ContactInfoKeys {
+		if value == key {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("Invalid %s '%s' - valid keys are %s", name, value, strings.Join(ContactInfoKeys, ", "))
+}
+
 // Validate a DAO member ID
 func ValidateDAOMemberID(name, value string) (string, error) {
 	val := strings.TrimSpace(value)
@@ -0,0 +1,189 @@
+package ens
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+	goens "github.com/wealdtech/go-ens/v3"
+)
+
+// How long a cached reverse lookup is used before it's looked up again
+const cacheTtl = time.Hour
+
+// A cached reverse lookup
+type cachedName struct {
+	Address common.Address `json:"address"`
+	Name    string         `json:"name"`
+	Time    time.Time      `json:"time"`
+}
+
+// The lookups saved to disk between commands
+type cache struct {
+	Addresses map[common.Address]cachedName `json:"addresses"`
+}
+
+// Resolves ENS names to addresses and back. Reverse lookups are only used for display, so they're cached on disk;
+// names are always resolved live, since the address they resolve to may be sent funds or given withdrawal rights.
+type Resolver struct {
+	client           bind.ContractBackend
+	cachePath        string
+	reverseResolving bool
+	cache            cache
+	dirty            bool
+	lock             sync.Mutex
+}
+
+// Check if a value is an ENS name rather than an address
+func IsName(value string) bool {
+	return strings.Contains(value, ".") && !common.IsHexAddress(value)
+}
+
+// Create a new resolver. If reverseResolving is false, addresses are never looked up to show their names, but names can
+// still be resolved. A blank cache path disables the cache.
+func NewResolver(client bind.ContractBackend, cachePath string, reverseResolving bool) *Resolver {
+	r := &Resolver{
+		client:           client,
+		cachePath:        cachePath,
+		reverseResolving: reverseResolving,
+		cache: cache{
+			Addresses: map[common.Address]cachedName{},
+		},
+	}
+
+	// A missing or corrupt cache just means everything gets looked up again
+	if cachePath != "" {
+		data, err := os.ReadFile(cachePath)
+		if err == nil {
+			var loaded cache
+			if json.Unmarshal(data, &loaded) == nil {
+				if loaded.Addresses != nil {
+					r.cache.Addresses = loaded.Addresses
+				}
+			}
+		}
+	}
+	return r
+}
+
+// Resolve an ENS name to an address. This is never cached.
+func (r *Resolver) Resolve(name string) (common.Address, error) {
+	return r.resolve(name)
+}
+
+// Get the primary ENS name of an address, or a blank string if it doesn't have one.
+// The name is only returned if it resolves back to the address, since anyone can set any name on their reverse record.
+func (r *Resolver) ReverseResolve(address common.Address) (string, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	defer r.save()
+	return r.reverseResolve(address)
+}
+
+// Get the primary ENS names of a list of addresses; addresses without one (or that couldn't be looked up) are left out.
+// Returns an empty map if reverse resolution is disabled.
+func (r *Resolver) GetNames(addresses []common.Address) map[common.Address]string {
+	names := map[common.Address]string{}
+	if !r.reverseResolving {
+		return names
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	defer r.save()
+	for _, address := range addresses {
+		name, err := r.reverseResolve(address)
+		if err == nil && name != "" {
+			names[address] = name
+		}
+	}
+	return names
+}
+
+// Format an address with its primary ENS name, e.g. "node.eth (0x...)", or just the address if it doesn't have one or
+// reverse resolution is disabled
+func (r *Resolver) FormatAddress(address common.Address) string {
+	if !r.reverseResolving {
+		return address.Hex()
+	}
+	name, err := r.ReverseResolve(address)
+	if err != nil || name == "" {
+		return address.Hex()
+	}
+	return fmt.Sprintf("%s (%s)", name, address.Hex())
+}
+
+// Resolve a name live
+func (r *Resolver) resolve(name string) (common.Address, error) {
+	name = strings.ToLower(name)
+	address, err := goens.Resolve(r.client, name)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("error resolving ENS name '%s': %w", name, err)
+	}
+	return address, nil
+}
+
+// Reverse resolve an address, using the cache if possible
+func (r *Resolver) reverseResolve(address common.Address) (string, error) {
+	cached, exists := r.cache.Addresses[address]
+	if exists && time.Since(cached.Time) < cacheTtl {
+		return cached.Name, nil
+	}
+
+	name, err := goens.ReverseResolve(r.client, address)
+	if err != nil {
+		if !isNoNameError(err) {
+			return "", fmt.Errorf("error reverse resolving %s: %w", address.Hex(), err)
+		}
+		name = ""
+	}
+	if name != "" {
+		resolvedAddress, err := r.resolve(name)
+		if err != nil || resolvedAddress != address {
+			name = ""
+		}
+	}
+
+	// Cache addresses without a name too, since that's most of them
+	r.cache.Addresses[address] = cachedName{
+		Address: address,
+		Name:    name,
+		Time:    time.Now(),
+	}
+	r.dirty = true
+	return name, nil
+}
+
+// Check if a reverse resolution error just means the address doesn't have a name
+func isNoNameError(err error) bool {
+	switch err.Error() {
+	case "not a resolver", "no resolver", "no resolution":
+		return true
+	}
+	return false
+}
+
+// Save the cache if it changed, dropping expired entries; failures are ignored since it'll just be rebuilt
+func (r *Resolver) save() {
+	if !r.dirty || r.cachePath == "" {
+		return
+	}
+	r.dirty = false
+
+	for address, cached := range r.cache.Addresses {
+		if time.Since(cached.Time) >= cacheTtl {
+			delete(r.cache.Addresses, address)
+		}
+	}
+	data, err := json.Marshal(r.cache)
+	if err != nil {
+		return
+	}
+	_ = files.WriteFileAtomic(r.cachePath, data, 0644)
+}
@@ -62,6 +62,14 @@ func CheckForNonceOverride(c *cli.Context, opts *bind.TransactOpts) error {
 
 }
 
+// Check if an error from a historical query means the EC has pruned the state for that block
+func IsMissingStateError(err error) bool {
+	errMessage := err.Error()
+	return strings.Contains(errMessage, "missing trie node") || // Geth
+		strings.Contains(errMessage, "No state available for block") || // Nethermind
+		strings.Contains(errMessage, "Internal error") // Besu
+}
+
 // Determines if the primary EC can be used for historical queries, or if the Archive EC is required
 func GetBestApiClient(primary *rocketpool.RocketPool, cfg *config.RocketPoolConfig, printMessage func(string), blockNumber *big.Int) (*rocketpool.RocketPool, error) {
 
@@ -75,9 +83,7 @@ func GetBestApiClient(primary *rocketpool.RocketPool, cfg *config.RocketPoolConf
 	if err != nil {
 		errMessage := err.Error()
 		printMessage(fmt.Sprintf("Error getting state for block %d: %s", blockNumber.Uint64(), errMessage))
-		if strings.Contains(errMessage, "missing trie node") || // Geth
-			strings.Contains(errMessage, "No state available for block") || // Nethermind
-			strings.Contains(errMessage, "Internal error") { // Besu
+		if IsMissingStateError(err) {
 
 			// The state was missing so fall back to the archive node
 			archiveEcUrl := cfg.Smartnode.ArchiveECUrl.Value.(string)
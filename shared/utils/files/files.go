@@ -0,0 +1,47 @@
+package files
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Write data to a file by writing it to a temporary file in the same directory and renaming that over the original.
+// On Unix systems the rename is atomic, so readers see either the old file or the new one but never a partial write,
+// and a full disk or a crash leaves the original untouched.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("error creating directory [%s]: %w", dir, err)
+	}
+
+	// The * is replaced with random characters so concurrent writers don't share a temporary file
+	file, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating temporary file for [%s]: %w", path, err)
+	}
+	tempPath := file.Name()
+
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		// CreateTemp always uses 0600
+		err = os.Chmod(tempPath, perm)
+	}
+	if err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("error writing [%s]: %w", tempPath, err)
+	}
+
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("error moving [%s] to [%s]: %w", tempPath, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// How long to wait for the price feed to respond
+const priceFeedTimeout = 5 * time.Second
+
+// The number of decimal places amounts are shown with
+const (
+	TokenPlaces int = 6
+	fiatPlaces  int = 2
+)
+
+// The IDs of ETH and RPL in the price feed's response
+const (
+	ethPriceID string = "ethereum"
+	rplPriceID string = "rocket-pool"
+)
+
+// Symbols for the common fiat currencies; others are shown by their code
+var currencySymbols = map[string]string{
+	"usd": "$",
+	"eur": "€",
+	"gbp": "£",
+	"jpy": "¥",
+	"cny": "¥",
+	"inr": "₹",
+	"krw": "₩",
+}
+
+// Formats ETH and RPL amounts for the CLI according to the user's display settings, so every command presents them
+// the same way
+type Formatter struct {
+	unit     cfgtypes.DisplayUnit
+	locale   cfgtypes.NumberLocale
+	currency string
+	ethPrice float64
+	rplPrice float64
+	hasPrice bool
+	priceErr error
+}
+
+// Create a formatter from the user's display settings, fetching the prices of ETH and RPL if a fiat currency is set.
+// If the prices can't be fetched, amounts are shown without their fiat value and the error is available from
+// GetPriceError.
+func NewFormatter(cfg *config.RocketPoolConfig) *Formatter {
+	f := NewFormatterWithOptions(
+		cfgtypes.DisplayUnit(fmt.Sprint(cfg.Smartnode.DisplayUnit.Value)),
+		cfgtypes.NumberLocale(fmt.Sprint(cfg.Smartnode.NumberLocale.Value)),
+	)
+	currency := strings.ToLower(strings.TrimSpace(cfg.Smartnode.DisplayCurrency.Value.(string)))
+	if currency != "" {
+		ethPrice, rplPrice, err := getPrices(cfg.Smartnode.PriceFeedUrl.Value.(string), currency)
+		if err != nil {
+			f.priceErr = err
+		} else {
+			f.SetPrices(currency, ethPrice, rplPrice)
+		}
+	}
+	return f
+}
+
+// Create a formatter with the provided unit and locale, and no fiat prices
+func NewFormatterWithOptions(unit cfgtypes.DisplayUnit, locale cfgtypes.NumberLocale) *Formatter {
+	if unit == cfgtypes.DisplayUnit_Unknown {
+		unit = cfgtypes.DisplayUnit_Eth
+	}
+	if locale == cfgtypes.NumberLocale_Unknown {
+		locale = cfgtypes.NumberLocale_Plain
+	}
+	return &Formatter{
+		unit:   unit,
+		locale: locale,
+	}
+}
+
+// Set the prices of ETH and RPL in a fiat currency, so amounts are shown with their value in it
+func (f *Formatter) SetPrices(currency string, ethPrice float64, rplPrice float64) {
+	f.currency = strings.ToLower(currency)
+	f.ethPrice = ethPrice
+	f.rplPrice = rplPrice
+	f.hasPrice = true
+	f.priceErr = nil
+}
+
+// Get the error from fetching the fiat prices, if there was one
+func (f *Formatter) GetPriceError() error {
+	return f.priceErr
+}
+
+// Format an amount of ETH in wei
+func (f *Formatter) Eth(wei *big.Int) string {
+	if f.unit == cfgtypes.DisplayUnit_Gwei {
+		gwei := new(big.Int).Quo(wei, big.NewInt(1e9))
+		gweiFloat, _ := new(big.Float).SetInt(gwei).Float64()
+		return f.Number(gweiFloat, 0) + " gwei" + f.fiat(eth.WeiToEth(wei), f.ethPrice)
+	}
+	return f.EthFloat(eth.WeiToEth(wei))
+}
+
+// Format an amount of ETH
+func (f *Formatter) EthFloat(amount float64) string {
+	if f.unit == cfgtypes.DisplayUnit_Gwei {
+		return f.Number(math.RoundDown(amount*1e9, 0), 0) + " gwei" + f.fiat(amount, f.ethPrice)
+	}
+	return f.Number(math.RoundDown(amount, TokenPlaces), TokenPlaces) + " ETH" + f.fiat(amount, f.ethPrice)
+}
+
+// Format an amount of RPL in wei
+func (f *Formatter) Rpl(wei *big.Int) string {
+	return f.RplFloat(eth.WeiToEth(wei))
+}
+
+// Format an amount of RPL
+func (f *Formatter) RplFloat(amount float64) string {
+	return f.Number(math.RoundDown(amount, TokenPlaces), TokenPlaces) + " RPL" + f.fiat(amount, f.rplPrice)
+}
+
+// Format a number with the provided number of decimal places, using the locale's separators
+func (f *Formatter) Number(value float64, places int) string {
+	group, decimal := getSeparators(f.locale)
+	number := strconv.FormatFloat(value, 'f', places, 64)
+	sign := ""
+	if strings.HasPrefix(number, "-") {
+		sign = "-"
+		number = number[1:]
+	}
+	integer, fraction, hasFraction := strings.Cut(number, ".")
+
+	// Group the integer digits in threes from the right
+	if group != "" && len(integer) > 3 {
+		var builder strings.Builder
+		lead := len(integer) % 3
+		if lead > 0 {
+			builder.WriteString(integer[:lead])
+		}
+		for i := lead; i < len(integer); i += 3 {
+			if builder.Len() > 0 {
+				builder.WriteString(group)
+			}
+			builder.WriteString(integer[i : i+3])
+		}
+		integer = builder.String()
+	}
+
+	if !hasFraction {
+		return sign + integer
+	}
+	return sign + integer + decimal + fraction
+}
+
+// Get the approximate fiat value of an amount, if prices are available
+func (f *Formatter) fiat(amount float64, price float64) string {
+	if !f.hasPrice {
+		return ""
+	}
+	value := f.Number(amount*price, fiatPlaces)
+	if symbol, exists := currencySymbols[f.currency]; exists {
+		return fmt.Sprintf(" (~%s%s)", symbol, value)
+	}
+	return fmt.Sprintf(" (~%s %s)", value, strings.ToUpper(f.currency))
+}
+
+// Get the digit grouping and decimal separators for a locale
+func getSeparators(locale cfgtypes.NumberLocale) (string, string) {
+	switch locale {
+	case cfgtypes.NumberLocale_Comma:
+		return ",", "."
+	case cfgtypes.NumberLocale_Period:
+		return ".", ","
+	case cfgtypes.NumberLocale_Space:
+		return " ", ","
+	default:
+		return "", "."
+	}
+}
+
+// Get the prices of ETH and RPL in a fiat currency from the price feed
+func getPrices(urlFormat string, currency string) (float64, float64, error) {
+	url := urlFormat
+	if strings.Contains(urlFormat, "%s") {
+		url = fmt.Sprintf(urlFormat, currency)
+	}
+
+	client := http.Client{
+		Timeout: priceFeedTimeout,
+	}
+	response, err := client.Get(url)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error getting prices from %s: %w", url, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return 0, 0, fmt.Errorf("price feed %s returned status %d", url, response.StatusCode)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error reading prices from %s: %w", url, err)
+	}
+	var prices map[string]map[string]float64
+	err = json.Unmarshal(body, &prices)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error deserializing prices from %s: %w", url, err)
+	}
+	ethPrice, exists := prices[ethPriceID][currency]
+	if !exists {
+		return 0, 0, fmt.Errorf("price feed %s doesn't have the price of ETH in %s", url, strings.ToUpper(currency))
+	}
+	rplPrice, exists := prices[rplPriceID][currency]
+	if !exists {
+		return 0, 0, fmt.Errorf("price feed %s doesn't have the price of RPL in %s", url, strings.ToUpper(currency))
+	}
+	return ethPrice, rplPrice, nil
+}
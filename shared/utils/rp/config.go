@@ -1,14 +1,13 @@
 package rp
 
 import (
-	"errors"
 	"fmt"
-	"io/fs"
 	"os"
 	"path/filepath"
 
 	"github.com/alessio/shellescape"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
 	"gopkg.in/yaml.v2"
 )
 
@@ -41,56 +40,11 @@ func SaveConfig(cfg *config.RocketPoolConfig, directory, filename string) error
 		return fmt.Errorf("could not serialize settings file: %w", err)
 	}
 
-	// Make a tmp file
-	// The empty string directs CreateTemp to use the OS's $TMPDIR (or GetTempPath) on windows
-	// The * in the second string is replaced with random characters by CreateTemp
-	f, err := os.CreateTemp(directory, ".tmp-"+filename+"-*")
-	if err != nil {
-		if errors.Is(err, fs.ErrExist) {
-			return fmt.Errorf("could not create file to save config to disk... do you need to clean your tmpdir (%s)?: %w", os.TempDir(), err)
-		}
-
-		return fmt.Errorf("could not create file to save config to disk: %w", err)
-	}
-	// Clean up the temporary files
-	// This prevents us from filling up `directory` with partially written files on failure
-	// If the file is successfully written, it fails with an error since it will be renamed
-	// before it is deleted, which we explicitly ignore / don't care about.
-	defer func() {
-		// Clean up tmp files, if any found
-		oldFiles, err := filepath.Glob(filepath.Join(directory, ".tmp-"+filename+"-*"))
-		if err != nil {
-			// Only possible error is ErrBadPattern, which we should catch
-			// during development, since the pattern is a comptime constant.
-			panic(err.Error())
-		}
-
-		for _, match := range oldFiles {
-			os.RemoveAll(match)
-		}
-	}()
-
-	// Save the serialized settings to the temporary file
-	if _, err := f.Write(configBytes); err != nil {
-		return fmt.Errorf("could not write Rocket Pool config to %s: %w", shellescape.Quote(path), err)
-	}
-
-	// Close the file for writing
-	if err := f.Close(); err != nil {
+	// Write the config atomically so it's never left partially written, e.g. if the disk is full
+	if err := files.WriteFileAtomic(path, configBytes, 0664); err != nil {
 		return fmt.Errorf("error saving Rocket Pool config to %s: %w", shellescape.Quote(path), err)
 	}
 
-	// Rename the temp file to overwrite the actual file.
-	// On Unix systems this operation is atomic and won't fail if the disk is now full
-	if err := os.Rename(f.Name(), path); err != nil {
-		return fmt.Errorf("error replacing old Rocket Pool config with %s: %w", f.Name(), err)
-	}
-
-	// Just in case the rename didn't overwrite (and preserve the perms of) the original file, set them now.
-	if err := os.Chmod(path, 0664); err != nil {
-		return fmt.Errorf("error updating permissions of %s: %w", path, err)
-	}
-
 	return nil
 
 }
@@ -0,0 +1,60 @@
+package rp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// How long to wait for the critical delegates source to respond
+const criticalDelegatesFetchTimeout = 10 * time.Second
+
+// A minipool delegate that the Oracle DAO has flagged as critical to upgrade away from
+type CriticalDelegate struct {
+	Address common.Address `json:"address"`
+	Reason  string         `json:"reason"`
+}
+
+// The format of the critical delegates data source
+type criticalDelegateList struct {
+	CriticalDelegates []CriticalDelegate `json:"criticalDelegates"`
+}
+
+// Get the delegates flagged as critical by the provided source, indexed by address.
+// An empty URL means there is no trusted source, so no delegates are considered critical.
+func GetCriticalDelegates(url string) (map[common.Address]CriticalDelegate, error) {
+	delegates := map[common.Address]CriticalDelegate{}
+	if url == "" {
+		return delegates, nil
+	}
+
+	client := http.Client{
+		Timeout: criticalDelegatesFetchTimeout,
+	}
+	response, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("error getting critical delegates from %s: %w", url, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("critical delegates source %s returned status %d", url, response.StatusCode)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading critical delegates from %s: %w", url, err)
+	}
+	var list criticalDelegateList
+	err = json.Unmarshal(body, &list)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing critical delegates from %s: %w", url, err)
+	}
+	for _, delegate := range list.CriticalDelegates {
+		delegates[delegate.Address] = delegate
+	}
+	return delegates, nil
+}
@@ -0,0 +1,92 @@
+package rp
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/trustednode"
+)
+
+// The node manager function that changes a node's reward network. It can only be called from the node's primary
+// withdrawal address.
+const setRewardNetworkAbi string = `[{"inputs":[{"internalType":"address","name":"_nodeAddress","type":"address"},{"internalType":"uint256","name":"_network","type":"uint256"}],"name":"setRewardNetwork","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+
+// A transaction that sets a node's reward network, to be sent from its primary withdrawal address
+type SetRewardNetworkTx struct {
+	From common.Address `json:"from"`
+	To   common.Address `json:"to"`
+	Data hexutil.Bytes  `json:"data"`
+}
+
+// Check if a reward network is enabled; nodes routed to a disabled network have their rewards paid out on Ethereum instead
+func IsRewardNetworkEnabled(rp *rocketpool.RocketPool, network uint64, opts *bind.CallOpts) (bool, error) {
+	enabled, err := trustednode.GetNetworkEnabled(rp, new(big.Int).SetUint64(network), opts)
+	if err != nil {
+		return false, fmt.Errorf("error checking if reward network %d is enabled: %w", network, err)
+	}
+	return enabled, nil
+}
+
+// Build the transaction that sets a node's reward network
+func GetSetRewardNetworkTx(rp *rocketpool.RocketPool, nodeAddress common.Address, withdrawalAddress common.Address, network uint64, opts *bind.CallOpts) (SetRewardNetworkTx, error) {
+	rocketNodeManager, err := getRewardNetworkSetter(rp, opts)
+	if err != nil {
+		return SetRewardNetworkTx{}, err
+	}
+	data, err := rocketNodeManager.ABI.Pack("setRewardNetwork", nodeAddress, new(big.Int).SetUint64(network))
+	if err != nil {
+		return SetRewardNetworkTx{}, fmt.Errorf("error encoding setRewardNetwork call: %w", err)
+	}
+	return SetRewardNetworkTx{
+		From: withdrawalAddress,
+		To:   *rocketNodeManager.Address,
+		Data: data,
+	}, nil
+}
+
+// Estimate the gas of setting a node's reward network; the transactor must be the node's primary withdrawal address
+func EstimateSetRewardNetworkGas(rp *rocketpool.RocketPool, nodeAddress common.Address, network uint64, opts *bind.TransactOpts) (rocketpool.GasInfo, error) {
+	rocketNodeManager, err := getRewardNetworkSetter(rp, nil)
+	if err != nil {
+		return rocketpool.GasInfo{}, err
+	}
+	return rocketNodeManager.GetTransactionGasInfo(opts, "setRewardNetwork", nodeAddress, new(big.Int).SetUint64(network))
+}
+
+// Set a node's reward network; the transactor must be the node's primary withdrawal address
+func SetRewardNetwork(rp *rocketpool.RocketPool, nodeAddress common.Address, network uint64, opts *bind.TransactOpts) (common.Hash, error) {
+	rocketNodeManager, err := getRewardNetworkSetter(rp, nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx, err := rocketNodeManager.Transact(opts, "setRewardNetwork", nodeAddress, new(big.Int).SetUint64(network))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error setting node %s reward network: %w", nodeAddress.Hex(), err)
+	}
+	return tx.Hash(), nil
+}
+
+// Get a binding for the node manager's setRewardNetwork function
+func getRewardNetworkSetter(rp *rocketpool.RocketPool, opts *bind.CallOpts) (*rocketpool.Contract, error) {
+	rocketNodeManager, err := rp.GetContract("rocketNodeManager", opts)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := abi.JSON(strings.NewReader(setRewardNetworkAbi))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding setRewardNetwork ABI: %w", err)
+	}
+	address := *rocketNodeManager.Address
+	return &rocketpool.Contract{
+		Contract: bind.NewBoundContract(address, parsed, rp.Client, rp.Client, rp.Client),
+		Address:  &address,
+		ABI:      &parsed,
+		Client:   rp.Client,
+	}, nil
+}
@@ -14,6 +14,14 @@ const (
 	ValidatorKeyPath string = "m/12381/3600/%d/0/0"
 )
 
+// The derivation paths validator keys may have been generated at, starting with the one the Smartnode uses. The others
+// are checked when recovering keys in case a validator was created with a tool that derived its signing key from the
+// EIP-2334 withdrawal key path instead.
+var KnownValidatorKeyPaths = []string{
+	ValidatorKeyPath,
+	"m/12381/3600/%d/0",
+}
+
 // BLS signing root with domain
 type signingRoot struct {
 	ObjectRoot []byte `ssz-size:"32"`
@@ -17,6 +17,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
 	"github.com/urfave/cli"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
 	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
@@ -24,21 +25,63 @@ import (
 )
 
 const (
-	bucketSize  uint = 20
-	bucketLimit uint = 2000
+	bucketSize uint = 20
+
+	// The minimum number of keys to check on each derivation path, regardless of how many minipools the node has
+	minRecoveryKeys uint = 2000
+
+	// How far past the node's minipool count, or past the last key found, to keep checking each derivation path. The
+	// Smartnode uses up a key for every deposit attempt, including ones that failed, so there can be gaps between keys.
+	recoveryGapLimit uint = 500
 )
 
-func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address common.Address, w *wallet.Wallet, testOnly bool) ([]types.ValidatorPubkey, error) {
+// Search a derivation path for the keys in the pubkey map, calling found for each match and removing it from the map.
+// The search covers at least minRecoveryKeys keys, the node's minipool count plus the gap limit, and the gap limit past
+// the last match.
+func findDerivedKeys(getKeys func(startIndex uint, length uint) ([]wallet.ValidatorKey, error), pubkeyMap map[types.ValidatorPubkey]bool, minipoolCount uint64, found func(wallet.ValidatorKey) error) error {
+	searchEnd := max(minRecoveryKeys, uint(minipoolCount)+recoveryGapLimit)
+	for bucketStart := uint(0); bucketStart < searchEnd && len(pubkeyMap) > 0; bucketStart += bucketSize {
+
+		// Get the keys for this bucket
+		keys, err := getKeys(bucketStart, bucketSize)
+		if err != nil {
+			return err
+		}
+		for _, validatorKey := range keys {
+			_, exists := pubkeyMap[validatorKey.PublicKey]
+			if !exists {
+				continue
+			}
+
+			// Found one! Keep searching past it in case there are more after a gap.
+			delete(pubkeyMap, validatorKey.PublicKey)
+			searchEnd = max(searchEnd, validatorKey.WalletIndex+1+recoveryGapLimit)
+			if err := found(validatorKey); err != nil {
+				return fmt.Errorf("error recovering validator keys: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// Recover the keys for the node's validating minipools. The node's minipools are read from the chain, and candidate keys
+// are derived from the wallet at each known derivation path until every minipool's pubkey is matched or the search runs
+// past the last match. Returns the recovered pubkeys, and the pubkeys that couldn't be matched to any key.
+func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address common.Address, w *wallet.Wallet, testOnly bool) ([]types.ValidatorPubkey, []types.ValidatorPubkey, error) {
 
 	cfg, err := services.GetConfig(c)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Get node's validating pubkeys
 	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, address, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	minipoolCount, err := minipool.GetNodeMinipoolCount(rp, address, nil)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Remove zero pubkeys
@@ -58,49 +101,39 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 
 	pubkeyMap, err = CheckForAndRecoverCustomMinipoolKeys(cfg, pubkeyMap, w, testOnly)
 	if err != nil {
-		return nil, fmt.Errorf("error checking for or recovering custom validator keys: %w", err)
+		return nil, nil, fmt.Errorf("error checking for or recovering custom validator keys: %w", err)
 	}
 
-	// Recover conventionally generated keys
-	bucketStart := uint(0)
-	for {
-		if bucketStart >= bucketLimit {
-			return nil, fmt.Errorf("attempt limit exceeded (%d keys)", bucketLimit)
+	// Recover derived keys, checking the Smartnode's own path first
+	for _, path := range validator.KnownValidatorKeyPaths {
+		getKeys := func(startIndex uint, length uint) ([]wallet.ValidatorKey, error) {
+			return w.GetValidatorKeysAtPath(path, startIndex, length)
 		}
-		bucketEnd := bucketStart + bucketSize
-		if bucketEnd > bucketLimit {
-			bucketEnd = bucketLimit
-		}
-
-		// Get the keys for this bucket
-		keys, err := w.GetValidatorKeys(bucketStart, bucketEnd-bucketStart)
-		if err != nil {
-			return nil, err
-		}
-		for _, validatorKey := range keys {
-			_, exists := pubkeyMap[validatorKey.PublicKey]
-			if exists {
-				// Found one!
-				delete(pubkeyMap, validatorKey.PublicKey)
-				if !testOnly {
-					err := w.SaveValidatorKey(validatorKey)
-					if err != nil {
-						return nil, fmt.Errorf("error recovering validator keys: %w", err)
-					}
-				}
+		err = findDerivedKeys(getKeys, pubkeyMap, minipoolCount, func(validatorKey wallet.ValidatorKey) error {
+			if testOnly {
+				return nil
 			}
+			if path == validator.ValidatorKeyPath {
+				return w.SaveValidatorKey(validatorKey)
+			}
+			return w.StoreValidatorKey(validatorKey.PrivateKey, validatorKey.DerivationPath)
+		})
+		if err != nil {
+			return nil, nil, err
 		}
+	}
 
-		if len(pubkeyMap) == 0 {
-			// All keys recovered!
-			break
+	// Report the pubkeys that weren't found
+	recovered := []types.ValidatorPubkey{}
+	unmatched := []types.ValidatorPubkey{}
+	for _, pubkey := range pubkeys {
+		if pubkeyMap[pubkey] {
+			unmatched = append(unmatched, pubkey)
+		} else {
+			recovered = append(recovered, pubkey)
 		}
-
-		// Run another iteration with the next bucket
-		bucketStart = bucketEnd
 	}
-
-	return pubkeys, nil
+	return recovered, unmatched, nil
 
 }
 
@@ -0,0 +1,76 @@
+package wallet
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+)
+
+// Get a stand-in pubkey for the key at a wallet index
+func testPubkey(index uint) types.ValidatorPubkey {
+	var pubkey types.ValidatorPubkey
+	binary.BigEndian.PutUint64(pubkey[:], uint64(index)+1)
+	return pubkey
+}
+
+// Get the stand-in keys for a range of wallet indices, counting how many were derived
+func testKeys(derived *uint) func(startIndex uint, length uint) ([]wallet.ValidatorKey, error) {
+	return func(startIndex uint, length uint) ([]wallet.ValidatorKey, error) {
+		keys := make([]wallet.ValidatorKey, 0, length)
+		for index := startIndex; index < startIndex+length; index++ {
+			keys = append(keys, wallet.ValidatorKey{
+				PublicKey:   testPubkey(index),
+				WalletIndex: index,
+			})
+		}
+		*derived += length
+		return keys, nil
+	}
+}
+
+func TestFindDerivedKeysAcrossLargeGap(t *testing.T) {
+	// Two minipools, but the second key comes after well over the gap limit of failed deposits
+	indices := []uint{0, 1500, 1900}
+	pubkeyMap := map[types.ValidatorPubkey]bool{}
+	for _, index := range indices {
+		pubkeyMap[testPubkey(index)] = true
+	}
+
+	var derived uint
+	found := []uint{}
+	err := findDerivedKeys(testKeys(&derived), pubkeyMap, 2, func(key wallet.ValidatorKey) error {
+		found = append(found, key.WalletIndex)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pubkeyMap) != 0 || len(found) != len(indices) {
+		t.Fatalf("expected to find keys %v, found %v", indices, found)
+	}
+}
+
+func TestFindDerivedKeysStopsPastGapLimit(t *testing.T) {
+	// The last key is more than the gap limit past the previous one and past the minimum search
+	last := minRecoveryKeys + 2*recoveryGapLimit
+	pubkeyMap := map[types.ValidatorPubkey]bool{
+		testPubkey(10):   true,
+		testPubkey(last): true,
+	}
+
+	var derived uint
+	err := findDerivedKeys(testKeys(&derived), pubkeyMap, 1, func(key wallet.ValidatorKey) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pubkeyMap[testPubkey(last)] || pubkeyMap[testPubkey(10)] {
+		t.Fatalf("expected only the key at index 10 to be found")
+	}
+	if derived != minRecoveryKeys {
+		t.Fatalf("expected the search to stop after %d keys, derived %d", minRecoveryKeys, derived)
+	}
+}